@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/yourusername/mediawiki-mcp/config"
+	"github.com/yourusername/mediawiki-mcp/internal/profiles"
+	"github.com/yourusername/mediawiki-mcp/internal/schedule"
+	"github.com/yourusername/mediawiki-mcp/internal/watch"
+)
+
+// runCheckConfig validates the environment's configuration and the files
+// it names, as the server does at startup, and lists every problem found.
+// It returns the exit code: 0 when the configuration is valid.
+func runCheckConfig(args []string) int {
+	fs := flag.NewFlagSet("check-config", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: mediawiki-mcp --check-config\n\n"+
+			"Validates the configuration from the environment, including the profile, schedule,\n"+
+			"and watch files it names, and exits without starting the server.\n")
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	problems := configProblems(config.Load())
+	if len(problems) > 0 {
+		fmt.Fprintf(os.Stderr, "check-config: %d problems:\n", len(problems))
+		for _, problem := range problems {
+			fmt.Fprintf(os.Stderr, "  %s\n", problem)
+		}
+		return 1
+	}
+	fmt.Println("check-config: ok")
+	return 0
+}
+
+// configProblems validates cfg and parses the files it names. Files that
+// don't exist are already among Validate's problems.
+func configProblems(cfg *config.Config) []string {
+	var problems []string
+	var invalid *config.ValidationError
+	if err := cfg.Validate(); errors.As(err, &invalid) {
+		problems = append(problems, invalid.Problems...)
+	}
+
+	if exists(cfg.ProfilesFile) {
+		if _, err := profiles.Load(cfg.ProfilesFile); err != nil {
+			problems = append(problems, fmt.Sprintf("MCP_WIKI_PROFILES_FILE: %v", err))
+		}
+	}
+	if exists(cfg.ScheduleFile) {
+		if _, err := schedule.LoadJobs(cfg.ScheduleFile); err != nil {
+			problems = append(problems, fmt.Sprintf("MCP_SCHEDULE_FILE: %v", err))
+		}
+	}
+	if _, err := watch.ParsePages(cfg.WatchPages); err != nil {
+		problems = append(problems, fmt.Sprintf("MCP_WATCH_FILE: %v", err))
+	}
+	return problems
+}
+
+func exists(path string) bool {
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
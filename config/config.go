@@ -1,60 +1,561 @@
 package config
 
 import (
+	"fmt"
+	"net/url"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// Version is the server version reported to clients, in API specs, and in
+// the User-Agent
+const Version = "1.0.0"
+
+// DefaultUserAgent is the User-Agent template used when MCP_USER_AGENT is
+// unset. It has no operator contact, which Wikimedia's User-Agent policy
+// requires, so MCP_CONTACT_EMAIL should be set alongside it.
+const DefaultUserAgent = "MediaWikiMCP/{version} (https://github.com/yourusername/mediawiki-mcp)"
+
+// ResultCacheTools are the tools whose whole results the result cache may
+// keep: reads whose results only change with the wiki. Tools with side
+// effects, job tools, and tools reporting the account's or a session's
+// state are left out, and MCP_RESULT_CACHE_TTLS can't name them.
+var ResultCacheTools = map[string]bool{
+	"wiki_info":              true,
+	"wiki_farm_list":         true,
+	"wiki_search":            true,
+	"wiki_page_outline":      true,
+	"wiki_page_section":      true,
+	"wiki_page_full":         true,
+	"wiki_category":          true,
+	"wiki_backlinks":         true,
+	"wiki_compare":           true,
+	"wiki_subpages":          true,
+	"wiki_discussions":       true,
+	"wiki_page_coordinates":  true,
+	"wiki_page_timeline":     true,
+	"wiki_glossary":          true,
+	"wiki_infobox_schema":    true,
+	"wiki_references":        true,
+	"wiki_sister_links":      true,
+	"wiki_compare_languages": true,
+}
+
 // Config holds all server configuration
 type Config struct {
-	Port           string
-	RateLimit      float64 // requests per second per wiki
-	CacheTTL       time.Duration
-	CacheTTLInfo   time.Duration
-	UserAgent      string
-	RequestTimeout time.Duration
+	Port              string
+	RateLimit         float64 // requests per second per wiki
+	InteractiveShare  float64 // share of RateLimit reserved for interactive calls over background work
+	CacheTTL          time.Duration
+	CacheTTLInfo      time.Duration
+	CacheCompressMin  int    // cached value size from which values are compressed
+	UserAgent         string // resolved from UserAgentTemplate and ContactEmail
+	UserAgentTemplate string
+	ContactEmail      string // operator contact added to the User-Agent
+	RequestTimeout    time.Duration
+	MaxPageBytes      int // wikitext size above which wiki_page_full refuses to convert
+	MaxResponseBytes  int // decoded API response size above which requests fail
+	MaxUploadBytes    int // file size above which wiki_upload_file refuses to upload
+	MaxHTMLNodes      int // elements in page HTML above which conversion is refused
+	MaxHTMLDepth      int // element nesting in page HTML above which conversion is refused
+	CategoryAllMax    int // most members wiki_category returns with all=true
+	MaxSearchLimit    int // largest limit accepted by wiki_search
+	MaxCategoryLimit  int // largest limit accepted by wiki_category without all=true
+	MaxBacklinksLimit int // largest limit accepted by wiki_backlinks
+	HistorySearchMax  int // most revisions wiki_search_history scans
+	EnableGraphQL     bool
+	GRPCPort          string // empty disables the gRPC listener
+	GRPCTLSCert       string // PEM certificate for gRPC; empty serves plaintext
+	GRPCTLSKey        string // PEM private key for GRPCTLSCert
+
+	// Bearer tokens required by the MCP endpoint and the HTTP, REST,
+	// GraphQL, and gRPC interfaces. Without them those are open, and tools
+	// that change wikis or start jobs are only offered over MCP.
+	APITokens []string
+
+	// How often expired entries are removed from the cache's memory
+	CacheCleanupInterval time.Duration
+
+	// Sessions on the MCP endpoint
+	Stateful     bool // keep sessions across requests instead of one per request
+	SessionDedup bool // leave content a session was sent before out of page responses
+
+	// Format of tool results on the MCP endpoint, "json" or "yaml"; the
+	// response_format argument overrides it per call
+	ResponseFormat string
+
+	// Whole tool results cached by canonical arguments
+	ResultCache     bool
+	ResultCacheTTLs map[string]time.Duration // per-tool overrides of the default TTL
+
+	// Content safety filters applied to returned wiki content
+	RedactEmails      bool
+	RedactPhones      bool
+	StripExternalURLs bool
+	BlockPatterns     []string // regular expressions whose matches are redacted
+
+	// Attribution of edits made by write tools
+	EditSummaryTemplate string // placeholders: {summary}, {attribution}, {tool}, {client}, {audit}
+	EditBot             bool   // mark edits as bot edits when the account has the bot right
+	AuditURL            string // link to an audit record, with {ref} for its reference
+
+	// Namespaces and title prefixes write tools may edit; empty allows all
+	WriteAllowPrefixes []string
+
+	// Review queue for agent edits
+	EditApproval   bool              // hold edits from write tools for review instead of saving them
+	ReviewerTokens map[string]string // reviewer name -> bearer token for the review endpoint
+
+	// Page watching and outbound webhooks
+	WatchPages    []string // "<wiki_url> <title>" entries
+	WatchInterval time.Duration
+	WebhookURLs   []string
+	WebhookSecret string
+
+	// Wikimedia EventStreams consumption
+	EventStreams    bool
+	EventStreamsURL string
+
+	ScheduleFile string // JSON file of scheduled report jobs
+
+	ProfilesFile string // JSON file of per-wiki profiles, such as offline backends
+
+	// SQLite database for durable state (jobs, watch state, audit log);
+	// empty disables it
+	DBPath string
+
+	// Background job queue
+	JobWorkers    int
+	JobsFile      string  // JSON file persisting job state; empty keeps jobs in memory
+	JobRateBudget float64 // requests per second per job, 0 = only the per-wiki limit
+
+	// Files produced by jobs, such as dataset exports and crawl page lists
+	ArtifactsDir       string
+	ArtifactsRetention time.Duration // age at which artifacts are deleted, 0 keeps them
+	ArtifactsSecret    string        // key for signed download URLs; random per process when empty
+	ArtifactURLTTL     time.Duration // lifetime of signed download URLs
+	ArtifactTokens     []string      // bearer tokens allowed to download any artifact
+	PublicURL          string        // external base URL of the server, for download links
+
+	// Settings whose values couldn't be parsed, for Validate
+	malformed []string
 }
 
-// Load reads configuration from environment variables with sensible defaults
+// Load reads configuration from environment variables with sensible
+// defaults. Malformed values fall back to the default too; Validate reports
+// them.
 func Load() *Config {
+	env := &envReader{}
+
 	// Railway sets PORT, but we also check MCP_PORT for backward compatibility
-	port := getEnv("PORT", "")
+	port := env.getEnv("PORT", "")
 	if port == "" {
-		port = getEnv("MCP_PORT", "8080")
+		port = env.getEnv("MCP_PORT", "8080")
+	}
+
+	cfg := &Config{
+		Port:              port,
+		RateLimit:         env.getEnvFloat("MCP_RATE_LIMIT", 10.0),
+		InteractiveShare:  env.getEnvFloat("MCP_INTERACTIVE_SHARE", 0.5),
+		CacheTTL:          env.getEnvDuration("MCP_CACHE_TTL", 5*time.Minute),
+		CacheTTLInfo:      env.getEnvDuration("MCP_CACHE_TTL_INFO", time.Hour),
+		CacheCompressMin:  env.getEnvInt("MCP_CACHE_COMPRESS_MIN_BYTES", 4096),
+		UserAgentTemplate: env.getEnv("MCP_USER_AGENT", DefaultUserAgent),
+		ContactEmail:      env.getEnv("MCP_CONTACT_EMAIL", ""),
+		RequestTimeout:    env.getEnvDuration("MCP_REQUEST_TIMEOUT", 30*time.Second),
+		MaxPageBytes:      env.getEnvInt("MCP_MAX_PAGE_BYTES", 1048576),
+		MaxResponseBytes:  env.getEnvInt("MCP_MAX_RESPONSE_BYTES", 52428800),
+		MaxUploadBytes:    env.getEnvInt("MCP_MAX_UPLOAD_BYTES", 104857600),
+		MaxHTMLNodes:      env.getEnvInt("MCP_MAX_HTML_NODES", 200000),
+		MaxHTMLDepth:      env.getEnvInt("MCP_MAX_HTML_DEPTH", 100),
+		CategoryAllMax:    env.getEnvInt("MCP_CATEGORY_ALL_MAX", 5000),
+		MaxSearchLimit:    env.getEnvInt("MCP_MAX_SEARCH_LIMIT", 500),
+		MaxCategoryLimit:  env.getEnvInt("MCP_MAX_CATEGORY_LIMIT", 500),
+		MaxBacklinksLimit: env.getEnvInt("MCP_MAX_BACKLINKS_LIMIT", 500),
+		HistorySearchMax:  env.getEnvInt("MCP_HISTORY_SEARCH_MAX", 1000),
+		EnableGraphQL:     env.getEnvBool("MCP_ENABLE_GRAPHQL", false),
+		GRPCPort:          env.getEnv("MCP_GRPC_PORT", ""),
+		GRPCTLSCert:       env.getEnv("MCP_GRPC_TLS_CERT", ""),
+		GRPCTLSKey:        env.getEnv("MCP_GRPC_TLS_KEY", ""),
+		APITokens:         env.getEnvList("MCP_API_TOKENS"),
+
+		CacheCleanupInterval: env.getEnvDuration("MCP_CACHE_CLEANUP_INTERVAL", time.Minute),
+
+		Stateful:     env.getEnvBool("MCP_STATEFUL", false),
+		SessionDedup: env.getEnvBool("MCP_SESSION_DEDUP", false),
+
+		ResponseFormat: strings.ToLower(env.getEnv("MCP_RESPONSE_FORMAT", "json")),
+
+		ResultCache:     env.getEnvBool("MCP_RESULT_CACHE", false),
+		ResultCacheTTLs: env.getEnvDurations("MCP_RESULT_CACHE_TTLS"),
+
+		RedactEmails:      env.getEnvBool("MCP_REDACT_EMAILS", false),
+		RedactPhones:      env.getEnvBool("MCP_REDACT_PHONES", false),
+		StripExternalURLs: env.getEnvBool("MCP_STRIP_EXTERNAL_URLS", false),
+		BlockPatterns:     env.getEnvLines("MCP_BLOCK_PATTERNS_FILE"),
+
+		EditSummaryTemplate: env.getEnv("MCP_EDIT_SUMMARY_TEMPLATE", "{summary} ({attribution})"),
+		EditBot:             env.getEnvBool("MCP_EDIT_BOT", false),
+		AuditURL:            env.getEnv("MCP_AUDIT_URL", ""),
+
+		WriteAllowPrefixes: env.getEnvList("MCP_WRITE_ALLOW"),
+
+		EditApproval:   env.getEnvBool("MCP_EDIT_APPROVAL", false),
+		ReviewerTokens: env.getEnvPairs("MCP_REVIEWER_TOKENS"),
+
+		WatchPages:    env.getEnvLines("MCP_WATCH_FILE"),
+		WatchInterval: env.getEnvDuration("MCP_WATCH_INTERVAL", 5*time.Minute),
+		WebhookURLs:   env.getEnvList("MCP_WEBHOOK_URLS"),
+		WebhookSecret: env.getEnv("MCP_WEBHOOK_SECRET", ""),
+
+		EventStreams:    env.getEnvBool("MCP_EVENTSTREAMS", false),
+		EventStreamsURL: env.getEnv("MCP_EVENTSTREAMS_URL", "https://stream.wikimedia.org/v2/stream/recentchange"),
+
+		ScheduleFile: env.getEnv("MCP_SCHEDULE_FILE", ""),
+
+		ProfilesFile: env.getEnv("MCP_WIKI_PROFILES_FILE", ""),
+
+		DBPath: env.getEnv("MCP_DB_PATH", ""),
+
+		JobWorkers:    env.getEnvInt("MCP_JOB_WORKERS", 2),
+		JobsFile:      env.getEnv("MCP_JOBS_FILE", ""),
+		JobRateBudget: env.getEnvFloat("MCP_JOB_RATE_BUDGET", 2.0),
+
+		ArtifactsDir:       env.getEnv("MCP_ARTIFACTS_DIR", filepath.Join(os.TempDir(), "mediawiki-mcp-artifacts")),
+		ArtifactsRetention: env.getEnvDuration("MCP_ARTIFACTS_RETENTION", 7*24*time.Hour),
+		ArtifactsSecret:    env.getEnv("MCP_ARTIFACTS_SECRET", ""),
+		ArtifactURLTTL:     env.getEnvDuration("MCP_ARTIFACT_URL_TTL", time.Hour),
+		ArtifactTokens:     env.getEnvList("MCP_ARTIFACT_TOKENS"),
+		PublicURL:          strings.TrimRight(env.getEnv("MCP_PUBLIC_URL", ""), "/"),
+	}
+	cfg.UserAgent = BuildUserAgent(cfg.UserAgentTemplate, cfg.ContactEmail)
+	cfg.malformed = env.malformed
+	return cfg
+}
+
+// BuildUserAgent fills a User-Agent template. {contact} is replaced with the
+// operator contact, which otherwise goes at the end of the template's
+// comment, as Wikimedia's policy shows: "Tool/1.0 (https://example.org/tool;
+// operator@example.org)". {version} is replaced with the server version,
+// which is appended as a product token when the template doesn't mention it.
+func BuildUserAgent(template, contact string) string {
+	ua := strings.TrimSpace(template)
+	switch {
+	case strings.Contains(ua, "{contact}"):
+		ua = strings.ReplaceAll(ua, "{contact}", contact)
+		if contact == "" {
+			// Tidy the separators left around an empty contact
+			ua = strings.NewReplacer("; )", ")", " ()", "", "()", "").Replace(ua)
+		}
+	case contact == "":
+	case strings.HasSuffix(ua, ")"):
+		ua = ua[:len(ua)-1] + "; " + contact + ")"
+	default:
+		ua += " (" + contact + ")"
+	}
+
+	if strings.Contains(ua, "{version}") {
+		ua = strings.ReplaceAll(ua, "{version}", Version)
+	} else if !strings.Contains(ua, "MediaWikiMCP/") {
+		ua += " MediaWikiMCP/" + Version
+	}
+	return strings.TrimSpace(ua)
+}
+
+// PlaceholderUserAgent reports whether requests go out with the default
+// User-Agent and no operator contact, which Wikimedia's policy disallows
+func (c *Config) PlaceholderUserAgent() bool {
+	return c.UserAgentTemplate == DefaultUserAgent && c.ContactEmail == ""
+}
+
+// ValidationError lists every problem Validate found in a configuration
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return strings.Join(e.Problems, "\n")
+}
+
+// Validate checks the configuration for malformed values, which Load
+// replaced with defaults, and for values the server can't run with. It
+// reports all of them at once, as a *ValidationError.
+func (c *Config) Validate() error {
+	problems := append([]string(nil), c.malformed...)
+	fail := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	checkPort := func(key, port string) {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			fail("%s=%q: want a port number from 1 to 65535", key, port)
+		}
+	}
+	checkPort("PORT", c.Port)
+	if c.GRPCPort != "" {
+		checkPort("MCP_GRPC_PORT", c.GRPCPort)
+		if c.GRPCPort == c.Port {
+			fail("MCP_GRPC_PORT=%q: the HTTP server already listens on port %s", c.GRPCPort, c.Port)
+		}
+	}
+	if (c.GRPCTLSCert == "") != (c.GRPCTLSKey == "") {
+		fail("MCP_GRPC_TLS_CERT and MCP_GRPC_TLS_KEY: set both or neither")
+	}
+
+	if c.RateLimit <= 0 {
+		fail("MCP_RATE_LIMIT=%v: want more than 0 requests per second", c.RateLimit)
+	}
+	if c.InteractiveShare < 0 || c.InteractiveShare > 1 {
+		fail("MCP_INTERACTIVE_SHARE=%v: want a share from 0 to 1", c.InteractiveShare)
+	}
+	if c.JobRateBudget < 0 {
+		fail("MCP_JOB_RATE_BUDGET=%v: want 0 or more requests per second", c.JobRateBudget)
+	}
+	if c.JobWorkers < 1 {
+		fail("MCP_JOB_WORKERS=%d: want at least 1 worker", c.JobWorkers)
+	}
+
+	if c.RequestTimeout <= 0 {
+		fail("MCP_REQUEST_TIMEOUT=%s: want a timeout longer than 0", c.RequestTimeout)
+	}
+	if c.WatchInterval <= 0 {
+		fail("MCP_WATCH_INTERVAL=%s: want an interval longer than 0", c.WatchInterval)
+	}
+	if c.CacheCleanupInterval <= 0 {
+		fail("MCP_CACHE_CLEANUP_INTERVAL=%s: want an interval longer than 0", c.CacheCleanupInterval)
+	}
+	if c.ArtifactURLTTL <= 0 {
+		fail("MCP_ARTIFACT_URL_TTL=%s: want a lifetime longer than 0", c.ArtifactURLTTL)
+	}
+	for key, d := range map[string]time.Duration{
+		"MCP_CACHE_TTL":           c.CacheTTL,
+		"MCP_CACHE_TTL_INFO":      c.CacheTTLInfo,
+		"MCP_ARTIFACTS_RETENTION": c.ArtifactsRetention,
+	} {
+		if d < 0 {
+			fail("%s=%s: want 0 or more", key, d)
+		}
+	}
+	for tool, d := range c.ResultCacheTTLs {
+		if !ResultCacheTools[tool] {
+			fail("MCP_RESULT_CACHE_TTLS: %s: not a tool whose results can be cached", tool)
+			continue
+		}
+		if d < 0 {
+			fail("MCP_RESULT_CACHE_TTLS: %s=%s: want 0 or more", tool, d)
+		}
+	}
+	if c.ResponseFormat != "json" && c.ResponseFormat != "yaml" {
+		fail("MCP_RESPONSE_FORMAT=%q: want json or yaml", c.ResponseFormat)
+	}
+
+	for key, n := range map[string]int{
+		"MCP_MAX_PAGE_BYTES":      c.MaxPageBytes,
+		"MCP_MAX_RESPONSE_BYTES":  c.MaxResponseBytes,
+		"MCP_MAX_UPLOAD_BYTES":    c.MaxUploadBytes,
+		"MCP_MAX_HTML_NODES":      c.MaxHTMLNodes,
+		"MCP_MAX_HTML_DEPTH":      c.MaxHTMLDepth,
+		"MCP_CATEGORY_ALL_MAX":    c.CategoryAllMax,
+		"MCP_MAX_SEARCH_LIMIT":    c.MaxSearchLimit,
+		"MCP_MAX_CATEGORY_LIMIT":  c.MaxCategoryLimit,
+		"MCP_MAX_BACKLINKS_LIMIT": c.MaxBacklinksLimit,
+		"MCP_HISTORY_SEARCH_MAX":  c.HistorySearchMax,
+	} {
+		if n < 1 {
+			fail("%s=%d: want at least 1", key, n)
+		}
+	}
+	if c.CacheCompressMin < 0 {
+		fail("MCP_CACHE_COMPRESS_MIN_BYTES=%d: want 0 or more", c.CacheCompressMin)
+	}
+
+	for _, pattern := range c.BlockPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			fail("MCP_BLOCK_PATTERNS_FILE: %v", err)
+		}
+	}
+
+	for key, path := range map[string]string{
+		"MCP_SCHEDULE_FILE":      c.ScheduleFile,
+		"MCP_WIKI_PROFILES_FILE": c.ProfilesFile,
+		"MCP_GRPC_TLS_CERT":      c.GRPCTLSCert,
+		"MCP_GRPC_TLS_KEY":       c.GRPCTLSKey,
+	} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			fail("%s: %v", key, err)
+		}
+	}
+
+	checkURL := func(key, raw string) {
+		if u, err := url.Parse(raw); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			fail("%s=%q: want an http or https URL", key, raw)
+		}
+	}
+	for _, u := range c.WebhookURLs {
+		checkURL("MCP_WEBHOOK_URLS", u)
+	}
+	if c.EventStreams {
+		checkURL("MCP_EVENTSTREAMS_URL", c.EventStreamsURL)
+	}
+	if c.PublicURL != "" {
+		checkURL("MCP_PUBLIC_URL", c.PublicURL)
+	}
+	if c.AuditURL != "" {
+		checkURL("MCP_AUDIT_URL", strings.ReplaceAll(c.AuditURL, "{ref}", "ref"))
 	}
 
-	return &Config{
-		Port:           port,
-		RateLimit:      getEnvFloat("MCP_RATE_LIMIT", 10.0),
-		CacheTTL:       getEnvDuration("MCP_CACHE_TTL", 300),
-		CacheTTLInfo:   getEnvDuration("MCP_CACHE_TTL_INFO", 3600),
-		UserAgent:      getEnv("MCP_USER_AGENT", "MediaWikiMCP/1.0 (https://github.com/yourusername/mediawiki-mcp)"),
-		RequestTimeout: getEnvDuration("MCP_REQUEST_TIMEOUT", 30),
+	if len(problems) == 0 {
+		return nil
 	}
+	sort.Strings(problems)
+	return &ValidationError{Problems: problems}
 }
 
-func getEnv(key, defaultVal string) string {
+// envReader reads settings from the environment, noting the ones whose
+// values are malformed
+type envReader struct {
+	malformed []string
+}
+
+// invalid notes a malformed value and what was expected instead
+func (r *envReader) invalid(key, val, want string) {
+	r.malformed = append(r.malformed, fmt.Sprintf("%s=%q: want %s", key, val, want))
+}
+
+func (r *envReader) getEnv(key, defaultVal string) string {
 	if val := os.Getenv(key); val != "" {
 		return val
 	}
 	return defaultVal
 }
 
-func getEnvFloat(key string, defaultVal float64) float64 {
+func (r *envReader) getEnvFloat(key string, defaultVal float64) float64 {
 	if val := os.Getenv(key); val != "" {
 		if f, err := strconv.ParseFloat(val, 64); err == nil {
 			return f
 		}
+		r.invalid(key, val, "a number")
+	}
+	return defaultVal
+}
+
+func (r *envReader) getEnvDuration(key string, defaultVal time.Duration) time.Duration {
+	if val := os.Getenv(key); val != "" {
+		if d, ok := parseDuration(val); ok {
+			return d
+		}
+		r.invalid(key, val, "a duration such as 300 (seconds), 300s, 5m, or 1h")
 	}
 	return defaultVal
 }
 
-func getEnvDuration(key string, defaultSeconds int) time.Duration {
+// parseDuration parses a Go duration string, such as "90s" or "1h30m", or
+// a whole number of seconds as durations were originally given
+func parseDuration(val string) (time.Duration, bool) {
+	if i, err := strconv.Atoi(val); err == nil {
+		return time.Duration(i) * time.Second, true
+	}
+	d, err := time.ParseDuration(val)
+	return d, err == nil
+}
+
+func (r *envReader) getEnvInt(key string, defaultVal int) int {
 	if val := os.Getenv(key); val != "" {
 		if i, err := strconv.Atoi(val); err == nil {
-			return time.Duration(i) * time.Second
+			return i
+		}
+		r.invalid(key, val, "a whole number")
+	}
+	return defaultVal
+}
+
+func (r *envReader) getEnvBool(key string, defaultVal bool) bool {
+	if val := os.Getenv(key); val != "" {
+		if b, err := strconv.ParseBool(val); err == nil {
+			return b
+		}
+		r.invalid(key, val, "true or false")
+	}
+	return defaultVal
+}
+
+// getEnvList splits a comma-separated environment variable, dropping empty items
+func (r *envReader) getEnvList(key string) []string {
+	val := os.Getenv(key)
+	if val == "" {
+		return nil
+	}
+
+	items := make([]string, 0)
+	for _, item := range strings.Split(val, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getEnvDurations parses a comma-separated list of name=duration pairs,
+// skipping malformed entries
+func (r *envReader) getEnvDurations(key string) map[string]time.Duration {
+	durations := make(map[string]time.Duration)
+	for _, item := range r.getEnvList(key) {
+		name, val, ok := strings.Cut(item, "=")
+		d, valid := parseDuration(strings.TrimSpace(val))
+		if !ok || !valid {
+			r.invalid(key, item, "tool=duration, such as wiki_search=60 or wiki_search=1m")
+			continue
+		}
+		durations[strings.TrimSpace(name)] = d
+	}
+	return durations
+}
+
+// getEnvPairs parses a comma-separated list of name=value pairs, skipping
+// malformed entries
+func (r *envReader) getEnvPairs(key string) map[string]string {
+	pairs := make(map[string]string)
+	for _, item := range r.getEnvList(key) {
+		name, val, ok := strings.Cut(item, "=")
+		name, val = strings.TrimSpace(name), strings.TrimSpace(val)
+		if !ok || name == "" || val == "" {
+			r.invalid(key, item, "name=value")
+			continue
+		}
+		pairs[name] = val
+	}
+	return pairs
+}
+
+// getEnvLines reads the file named by an environment variable and returns
+// its non-empty lines, skipping # comments
+func (r *envReader) getEnvLines(key string) []string {
+	path := os.Getenv(key)
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		r.malformed = append(r.malformed, fmt.Sprintf("%s: %v", key, err))
+		return nil
+	}
+
+	lines := make([]string, 0)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
 		}
+		lines = append(lines, line)
 	}
-	return time.Duration(defaultSeconds) * time.Second
+	return lines
 }
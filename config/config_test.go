@@ -0,0 +1,130 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildUserAgent(t *testing.T) {
+	tests := []struct {
+		template, contact, want string
+	}{
+		{
+			DefaultUserAgent, "ops@example.org",
+			"MediaWikiMCP/" + Version + " (https://github.com/yourusername/mediawiki-mcp; ops@example.org)",
+		},
+		{
+			DefaultUserAgent, "",
+			"MediaWikiMCP/" + Version + " (https://github.com/yourusername/mediawiki-mcp)",
+		},
+		{
+			"ResearchBot/2.1 (https://example.org/bot)", "ops@example.org",
+			"ResearchBot/2.1 (https://example.org/bot; ops@example.org) MediaWikiMCP/" + Version,
+		},
+		{
+			"ResearchBot/2.1 ({contact}) MediaWikiMCP/{version}", "ops@example.org",
+			"ResearchBot/2.1 (ops@example.org) MediaWikiMCP/" + Version,
+		},
+		{
+			"ResearchBot/2.1 (https://example.org/bot; {contact})", "",
+			"ResearchBot/2.1 (https://example.org/bot) MediaWikiMCP/" + Version,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := BuildUserAgent(tt.template, tt.contact); got != tt.want {
+			t.Errorf("BuildUserAgent(%q, %q) = %q, want %q", tt.template, tt.contact, got, tt.want)
+		}
+	}
+}
+
+func TestValidateDefaults(t *testing.T) {
+	t.Setenv("PORT", "")
+	t.Setenv("MCP_PORT", "")
+	if err := Load().Validate(); err != nil {
+		t.Errorf("default configuration is invalid: %v", err)
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	t.Setenv("PORT", "8080")
+	t.Setenv("MCP_GRPC_PORT", "8080")
+	cert := filepath.Join(t.TempDir(), "cert.pem")
+	os.WriteFile(cert, []byte("-----BEGIN CERTIFICATE-----\n"), 0o600)
+	t.Setenv("MCP_GRPC_TLS_CERT", cert) // without MCP_GRPC_TLS_KEY
+	t.Setenv("MCP_CACHE_TTL", "five minutes")
+	t.Setenv("MCP_RATE_LIMIT", "0")
+	t.Setenv("MCP_STATEFUL", "sometimes")
+	t.Setenv("MCP_RESPONSE_FORMAT", "xml")
+	t.Setenv("MCP_RESULT_CACHE_TTLS", "wiki_search=60,wiki_page_full")
+	t.Setenv("MCP_WEBHOOK_URLS", "hooks.example.org/wiki")
+	t.Setenv("MCP_WIKI_PROFILES_FILE", filepath.Join(t.TempDir(), "missing.json"))
+
+	err := Load().Validate()
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Validate() = %v, want a *ValidationError", err)
+	}
+
+	for _, key := range []string{
+		"MCP_GRPC_PORT", "MCP_GRPC_TLS_CERT", "MCP_CACHE_TTL", "MCP_RATE_LIMIT", "MCP_STATEFUL",
+		"MCP_RESPONSE_FORMAT", "MCP_RESULT_CACHE_TTLS", "MCP_WEBHOOK_URLS", "MCP_WIKI_PROFILES_FILE",
+	} {
+		found := false
+		for _, problem := range invalid.Problems {
+			if strings.HasPrefix(problem, key) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("no problem reported for %s in:\n%v", key, err)
+		}
+	}
+	if len(invalid.Problems) != 9 {
+		t.Errorf("got %d problems, want 9:\n%v", len(invalid.Problems), err)
+	}
+}
+
+func TestValidateResultCacheTTLs(t *testing.T) {
+	t.Setenv("MCP_RESULT_CACHE_TTLS", "wiki_search=5m,wiki_edit_page=5m,wiki_job=1m")
+
+	err := Load().Validate()
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Validate() = %v, want a *ValidationError", err)
+	}
+	if len(invalid.Problems) != 2 || !strings.Contains(err.Error(), "wiki_edit_page") || !strings.Contains(err.Error(), "wiki_job") {
+		t.Errorf("Validate() = %v, want wiki_edit_page and wiki_job rejected", err)
+	}
+}
+
+func TestDurations(t *testing.T) {
+	t.Setenv("MCP_CACHE_TTL", "600")
+	t.Setenv("MCP_CACHE_TTL_INFO", "2h")
+	t.Setenv("MCP_WATCH_INTERVAL", "1m30s")
+	t.Setenv("MCP_RESULT_CACHE_TTLS", "wiki_search=60, wiki_page_full=15m, wiki_info=0")
+
+	cfg := Load()
+	if cfg.CacheTTL != 10*time.Minute {
+		t.Errorf("CacheTTL = %s, want 10m from whole seconds", cfg.CacheTTL)
+	}
+	if cfg.CacheTTLInfo != 2*time.Hour {
+		t.Errorf("CacheTTLInfo = %s, want 2h", cfg.CacheTTLInfo)
+	}
+	if cfg.WatchInterval != 90*time.Second {
+		t.Errorf("WatchInterval = %s, want 1m30s", cfg.WatchInterval)
+	}
+	want := map[string]time.Duration{"wiki_search": time.Minute, "wiki_page_full": 15 * time.Minute, "wiki_info": 0}
+	for tool, d := range want {
+		if got, ok := cfg.ResultCacheTTLs[tool]; !ok || got != d {
+			t.Errorf("ResultCacheTTLs[%s] = %s, want %s", tool, got, d)
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+}
@@ -0,0 +1,216 @@
+// Package approval holds agent edits for review before they reach the wiki
+package approval
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+var (
+	// ErrEditNotFound is returned for unknown edit IDs
+	ErrEditNotFound = errors.New("pending edit not found")
+	// ErrNotPending is returned when reviewing an edit that was already reviewed
+	ErrNotPending = errors.New("edit is not pending review")
+)
+
+// Status is the review state of an edit
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusApproved  Status = "approved" // being committed
+	StatusRejected  Status = "rejected"
+	StatusCommitted Status = "committed"
+	StatusFailed    Status = "failed" // approved, but the wiki refused it
+)
+
+// Edit is an agent edit held for review
+type Edit struct {
+	ID          string           `json:"id"`
+	WikiURL     string           `json:"wiki_url"`
+	Edit        wiki.EditParams  `json:"edit"`
+	Tool        string           `json:"tool"`
+	Client      string           `json:"client,omitempty"`
+	AuditRef    string           `json:"audit_ref,omitempty"`
+	Status      Status           `json:"status"`
+	SubmittedAt time.Time        `json:"submitted_at"`
+	Reviewer    string           `json:"reviewer,omitempty"`
+	ReviewedAt  *time.Time       `json:"reviewed_at,omitempty"`
+	Reason      string           `json:"reason,omitempty"` // given on rejection
+	Result      *wiki.EditResult `json:"result,omitempty"`
+	Error       string           `json:"error,omitempty"`
+}
+
+// Store persists edits across restarts
+type Store interface {
+	SavePendingEdit(edit Edit) error
+	LoadPendingEdits() ([]Edit, error)
+}
+
+// CommitFunc saves an approved edit to the wiki
+type CommitFunc func(ctx context.Context, edit Edit) (*wiki.EditResult, error)
+
+// Queue holds edits until a reviewer approves or rejects them
+type Queue struct {
+	store  Store
+	commit CommitFunc
+
+	mu    sync.Mutex
+	edits map[string]*Edit
+}
+
+// NewQueue creates a queue that commits approved edits with commit. store
+// may be nil for an in-memory queue; otherwise previous edits are loaded
+// from it, and any left mid-commit by a restart are marked failed, since
+// whether the wiki saved them is unknown.
+func NewQueue(store Store, commit CommitFunc) *Queue {
+	q := &Queue{
+		store:  store,
+		commit: commit,
+		edits:  make(map[string]*Edit),
+	}
+
+	if store != nil {
+		saved, err := store.LoadPendingEdits()
+		if err != nil {
+			log.Printf("approval: load: %v", err)
+		}
+		for i := range saved {
+			edit := saved[i]
+			if edit.Status == StatusApproved {
+				edit.Status = StatusFailed
+				edit.Error = "interrupted by a restart while committing; check the page history before resubmitting"
+				q.persist(edit)
+			}
+			q.edits[edit.ID] = &edit
+		}
+	}
+
+	return q
+}
+
+// Submit adds an edit to the review queue
+func (q *Queue) Submit(edit Edit) Edit {
+	edit.ID = newID()
+	edit.Status = StatusPending
+	edit.SubmittedAt = time.Now().UTC()
+
+	q.mu.Lock()
+	q.edits[edit.ID] = &edit
+	q.mu.Unlock()
+
+	q.persist(edit)
+	return edit
+}
+
+// Get returns an edit snapshot
+func (q *Queue) Get(id string) (Edit, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	edit, ok := q.edits[id]
+	if !ok {
+		return Edit{}, fmt.Errorf("%w: %s", ErrEditNotFound, id)
+	}
+	return *edit, nil
+}
+
+// List returns snapshots of the edits with a status, or all edits when
+// status is empty, oldest first so reviewers work through them in order
+func (q *Queue) List(status Status) []Edit {
+	q.mu.Lock()
+	edits := make([]Edit, 0, len(q.edits))
+	for _, edit := range q.edits {
+		if status == "" || edit.Status == status {
+			edits = append(edits, *edit)
+		}
+	}
+	q.mu.Unlock()
+
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].SubmittedAt.Before(edits[j].SubmittedAt)
+	})
+	return edits
+}
+
+// Approve commits a pending edit to the wiki on behalf of reviewer. A
+// commit the wiki refuses leaves the edit failed, with the error recorded.
+func (q *Queue) Approve(ctx context.Context, id, reviewer string) (Edit, error) {
+	edit, err := q.review(id, reviewer, StatusApproved, "")
+	if err != nil {
+		return Edit{}, err
+	}
+
+	result, err := q.commit(ctx, edit)
+	q.mu.Lock()
+	stored := q.edits[id]
+	if err != nil {
+		stored.Status = StatusFailed
+		stored.Error = err.Error()
+	} else {
+		stored.Status = StatusCommitted
+		stored.Result = result
+	}
+	edit = *stored
+	q.mu.Unlock()
+
+	q.persist(edit)
+	return edit, nil
+}
+
+// Reject discards a pending edit, recording the reviewer's reason
+func (q *Queue) Reject(id, reviewer, reason string) (Edit, error) {
+	return q.review(id, reviewer, StatusRejected, reason)
+}
+
+// review moves a pending edit to status. Checking and updating under one
+// lock means two reviewers can't both act on an edit.
+func (q *Queue) review(id, reviewer string, status Status, reason string) (Edit, error) {
+	q.mu.Lock()
+	stored, ok := q.edits[id]
+	if !ok {
+		q.mu.Unlock()
+		return Edit{}, fmt.Errorf("%w: %s", ErrEditNotFound, id)
+	}
+	if stored.Status != StatusPending {
+		q.mu.Unlock()
+		return Edit{}, fmt.Errorf("%w: %s is %s", ErrNotPending, id, stored.Status)
+	}
+
+	now := time.Now().UTC()
+	stored.Status = status
+	stored.Reviewer = reviewer
+	stored.ReviewedAt = &now
+	stored.Reason = reason
+	edit := *stored
+	q.mu.Unlock()
+
+	q.persist(edit)
+	return edit, nil
+}
+
+// persist saves an edit snapshot to the store, if any
+func (q *Queue) persist(edit Edit) {
+	if q.store == nil {
+		return
+	}
+	if err := q.store.SavePendingEdit(edit); err != nil {
+		log.Printf("approval: save %s: %v", edit.ID, err)
+	}
+}
+
+// newID returns a random edit identifier
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,87 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// memoryStore keeps saved edits in a map
+type memoryStore map[string]Edit
+
+func (m memoryStore) SavePendingEdit(edit Edit) error {
+	m[edit.ID] = edit
+	return nil
+}
+
+func (m memoryStore) LoadPendingEdits() ([]Edit, error) {
+	edits := make([]Edit, 0, len(m))
+	for _, edit := range m {
+		edits = append(edits, edit)
+	}
+	return edits, nil
+}
+
+func TestQueueReview(t *testing.T) {
+	var committed []string
+	commit := func(ctx context.Context, edit Edit) (*wiki.EditResult, error) {
+		if edit.Edit.Title == "Protected" {
+			return nil, errors.New("protectedpage")
+		}
+		committed = append(committed, edit.Edit.Title)
+		return &wiki.EditResult{Title: edit.Edit.Title, NewRevID: 42}, nil
+	}
+
+	store := memoryStore{}
+	q := NewQueue(store, commit)
+	ok := q.Submit(Edit{WikiURL: "https://wiki.example.org", Edit: wiki.EditParams{Title: "Draft:A", Text: "a"}})
+	refused := q.Submit(Edit{WikiURL: "https://wiki.example.org", Edit: wiki.EditParams{Title: "Protected", Text: "b"}})
+	rejected := q.Submit(Edit{WikiURL: "https://wiki.example.org", Edit: wiki.EditParams{Title: "Draft:C", Text: "c"}})
+
+	if got := len(q.List(StatusPending)); got != 3 {
+		t.Fatalf("pending edits = %d, want 3", got)
+	}
+	if len(committed) != 0 {
+		t.Fatalf("edits committed before review: %v", committed)
+	}
+
+	edit, err := q.Approve(context.Background(), ok.ID, "alice")
+	if err != nil || edit.Status != StatusCommitted || edit.Result.NewRevID != 42 || edit.Reviewer != "alice" {
+		t.Errorf("Approve = %+v, %v; want committed by alice", edit, err)
+	}
+	if _, err := q.Approve(context.Background(), ok.ID, "bob"); !errors.Is(err, ErrNotPending) {
+		t.Errorf("second Approve error = %v, want ErrNotPending", err)
+	}
+
+	edit, err = q.Approve(context.Background(), refused.ID, "alice")
+	if err != nil || edit.Status != StatusFailed || edit.Error != "protectedpage" {
+		t.Errorf("Approve of refused edit = %+v, %v; want failed", edit, err)
+	}
+
+	edit, err = q.Reject(rejected.ID, "bob", "unsourced")
+	if err != nil || edit.Status != StatusRejected || edit.Reason != "unsourced" {
+		t.Errorf("Reject = %+v, %v; want rejected with reason", edit, err)
+	}
+	if _, err := q.Get("missing"); !errors.Is(err, ErrEditNotFound) {
+		t.Errorf("Get(missing) error = %v, want ErrEditNotFound", err)
+	}
+	if len(committed) != 1 {
+		t.Errorf("committed = %v, want only the approved edit", committed)
+	}
+
+	// Edits survive a restart; one interrupted mid-commit is marked failed
+	interrupted := store[rejected.ID]
+	interrupted.ID = "interrupted"
+	interrupted.Status = StatusApproved
+	store[interrupted.ID] = interrupted
+
+	reloaded := NewQueue(store, commit)
+	if got := len(reloaded.List("")); got != 4 {
+		t.Errorf("reloaded edits = %d, want 4", got)
+	}
+	if edit, _ := reloaded.Get("interrupted"); edit.Status != StatusFailed {
+		t.Errorf("interrupted edit status = %s, want failed", edit.Status)
+	}
+}
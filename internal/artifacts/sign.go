@@ -0,0 +1,29 @@
+package artifacts
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// Sign returns the signature granting download of artifact id until
+// expires: the hex HMAC-SHA256 of "<id>\n<expires unix seconds>" keyed
+// with secret
+func Sign(secret []byte, id string, expires time.Time) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(id + "\n" + strconv.FormatInt(expires.Unix(), 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature grants download of artifact id at now,
+// given the expires query parameter it was issued with
+func Verify(secret []byte, id, expires, signature string, now time.Time) bool {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || now.Unix() > unix {
+		return false
+	}
+	want := Sign(secret, id, time.Unix(unix, 0))
+	return hmac.Equal([]byte(signature), []byte(want))
+}
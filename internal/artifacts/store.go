@@ -0,0 +1,151 @@
+// Package artifacts keeps files produced by background jobs, such as
+// dataset exports, so they can be downloaded instead of returned inline
+package artifacts
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when no artifact has the requested ID
+var ErrNotFound = errors.New("artifact not found")
+
+// validName matches artifact file names: no separators or leading dots
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Artifact describes a stored file
+type Artifact struct {
+	Name      string    `json:"name"`
+	Format    string    `json:"format"`         // e.g. "csv" or "jsonl"
+	Rows      int       `json:"rows,omitempty"` // known only when the artifact is written
+	Bytes     int64     `json:"bytes"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Store keeps artifacts as files in a directory
+type Store struct {
+	dir string
+}
+
+// NewStore creates a store in dir, creating the directory if needed
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create artifacts dir: %w", err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Find returns the artifact of job id, stored as "<id>.<format>", and the
+// path of its file
+func (s *Store) Find(id string) (Artifact, string, error) {
+	if !validName.MatchString(id) {
+		return Artifact{}, "", fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	matches, err := filepath.Glob(filepath.Join(s.dir, id+".*"))
+	if err != nil || len(matches) == 0 {
+		return Artifact{}, "", fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+
+	path := matches[0]
+	info, err := os.Stat(path)
+	if err != nil {
+		return Artifact{}, "", fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	name := filepath.Base(path)
+	return Artifact{
+		Name:      name,
+		Format:    strings.TrimPrefix(filepath.Ext(name), "."),
+		Bytes:     info.Size(),
+		CreatedAt: info.ModTime().UTC(),
+	}, path, nil
+}
+
+// Prune deletes artifacts, and files left by unfinished writers, last
+// modified more than maxAge ago. It returns the number of artifacts deleted.
+func (s *Store) Prune(maxAge time.Duration) (int, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return 0, fmt.Errorf("prune artifacts: %w", err)
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	pruned := 0
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, entry.Name())); err != nil {
+			continue
+		}
+		if !strings.HasPrefix(entry.Name(), ".") {
+			pruned++
+		}
+	}
+	return pruned, nil
+}
+
+// Writer writes an artifact. Nothing is visible under the artifact's name
+// until Commit, so readers never see a partial file.
+type Writer struct {
+	*bufio.Writer
+	file  *os.File
+	path  string
+	bytes int64
+}
+
+// Create starts writing the artifact name, replacing any previous one on
+// Commit
+func (s *Store) Create(name string) (*Writer, error) {
+	if !validName.MatchString(name) {
+		return nil, fmt.Errorf("invalid artifact name %q", name)
+	}
+
+	file, err := os.CreateTemp(s.dir, "."+name+".*")
+	if err != nil {
+		return nil, fmt.Errorf("create artifact: %w", err)
+	}
+	w := &Writer{file: file, path: filepath.Join(s.dir, name)}
+	w.Writer = bufio.NewWriter(countingWriter{w})
+	return w, nil
+}
+
+// Commit finishes the artifact and returns its size in bytes
+func (w *Writer) Commit() (int64, error) {
+	if err := w.Flush(); err != nil {
+		w.Abort()
+		return 0, fmt.Errorf("write artifact: %w", err)
+	}
+	if err := w.file.Close(); err != nil {
+		os.Remove(w.file.Name())
+		return 0, fmt.Errorf("write artifact: %w", err)
+	}
+	if err := os.Rename(w.file.Name(), w.path); err != nil {
+		os.Remove(w.file.Name())
+		return 0, fmt.Errorf("save artifact: %w", err)
+	}
+	return w.bytes, nil
+}
+
+// Abort discards the artifact
+func (w *Writer) Abort() {
+	w.file.Close()
+	os.Remove(w.file.Name())
+}
+
+// countingWriter writes to the artifact file, counting bytes
+type countingWriter struct {
+	w *Writer
+}
+
+func (c countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.file.Write(p)
+	c.w.bytes += int64(n)
+	return n, err
+}
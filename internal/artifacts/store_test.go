@@ -0,0 +1,82 @@
+package artifacts
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStoreFindAndPrune(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := store.Create("abc123.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.WriteString("page,revid\n")
+	if _, _, err := store.Find("abc123"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("uncommitted artifact found, err = %v", err)
+	}
+	size, err := w.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	artifact, path, err := store.Find("abc123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if artifact.Name != "abc123.csv" || artifact.Format != "csv" || artifact.Bytes != size || size != 11 {
+		t.Errorf("artifact = %+v, size %d", artifact, size)
+	}
+	if _, _, err := store.Find("../abc123"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("path traversal not rejected, err = %v", err)
+	}
+
+	// An abandoned writer's temp file and an old artifact are pruned
+	abandoned, err := store.Create("def456.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	for _, p := range []string{path, abandoned.file.Name()} {
+		if err := os.Chtimes(p, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n, err := store.Prune(time.Hour); err != nil || n != 1 {
+		t.Fatalf("Prune = %d, %v; want 1 artifact", n, err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 0 {
+		t.Errorf("left after pruning: %v", entries)
+	}
+}
+
+func TestSignature(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Unix(1700000000, 0)
+	expires := now.Add(time.Hour)
+	sig := Sign(secret, "abc123", expires)
+	exp := "1700003600"
+
+	if !Verify(secret, "abc123", exp, sig, now) {
+		t.Error("valid signature rejected")
+	}
+	if Verify(secret, "abc123", exp, sig, expires.Add(time.Second)) {
+		t.Error("expired signature accepted")
+	}
+	if Verify(secret, "other", exp, sig, now) {
+		t.Error("signature accepted for another artifact")
+	}
+	if Verify(secret, "abc123", "1700007200", sig, now) {
+		t.Error("signature accepted with a later expiry")
+	}
+	if Verify([]byte("other"), "abc123", exp, sig, now) {
+		t.Error("signature accepted under another key")
+	}
+}
@@ -0,0 +1,250 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/jobs"
+	mcpServer "github.com/yourusername/mediawiki-mcp/internal/mcp"
+	"github.com/yourusername/mediawiki-mcp/internal/tools"
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// missingID is a well-formed job or edit ID that no server has issued
+const missingID = "0000000000000000"
+
+// jobPollInterval is how often wiki_job is polled while waiting for a job
+const jobPollInterval = time.Second
+
+// jobList is the result of wiki_jobs
+type jobList struct {
+	Jobs  []jobs.Job `json:"jobs"`
+	Total int        `json:"total"`
+}
+
+// suite returns the cases in the order they run. Later cases use values
+// recorded by earlier ones, such as the revision of the page and job IDs.
+func suite() []testCase {
+	return []testCase{
+		{tool: "wiki_info", check: "ok", args: wikiArgs(nil), result: reflect.TypeFor[wiki.WikiInfo]()},
+		{tool: "wiki_whoami", check: "ok", args: wikiArgs(nil), result: reflect.TypeFor[wiki.UserInfo]()},
+		{
+			tool: "wiki_search", check: "ok",
+			args: func(_ context.Context, st *state) (map[string]any, error) {
+				return map[string]any{"wiki_url": st.opts.WikiURL, "query": st.opts.Title, "limit": 3}, nil
+			},
+			result: reflect.TypeFor[wiki.SearchResponse](),
+			after: func(_ context.Context, _ *state, data map[string]any) error {
+				if results, _ := data["results"].([]any); len(results) == 0 {
+					return fmt.Errorf("no results for the page's own title")
+				}
+				return nil
+			},
+		},
+		{tool: "wiki_page_outline", check: "ok", args: pageArgs(nil), result: reflect.TypeFor[wiki.PageOutline]()},
+		{tool: "wiki_page_section", check: "ok", args: pageArgs(map[string]any{"section_index": 1}), result: reflect.TypeFor[wiki.PageSection]()},
+		{tool: "wiki_page_section", check: "section_not_found", args: pageArgs(map[string]any{"section_index": 9999}), errorCode: "section_not_found"},
+		{
+			tool: "wiki_page_full", check: "ok", args: pageArgs(nil),
+			result: reflect.TypeFor[wiki.PageFull](),
+			after: func(_ context.Context, st *state, data map[string]any) error {
+				source, _ := data["source"].(map[string]any)
+				st.revID, _ = source["revid"].(float64)
+				st.contentHash, _ = data["content_hash"].(string)
+				return nil
+			},
+		},
+		{
+			tool: "wiki_has_changed", check: "ok",
+			args: func(ctx context.Context, st *state) (map[string]any, error) {
+				if st.revID == 0 || st.contentHash == "" {
+					return nil, fmt.Errorf("%w: wiki_page_full returned no revid or content_hash", errSkip)
+				}
+				return pageArgs(map[string]any{"revid": st.revID, "content_hash": st.contentHash})(ctx, st)
+			},
+			result: reflect.TypeFor[tools.ChangeCheck](),
+			after: func(_ context.Context, _ *state, data map[string]any) error {
+				if data["changed"] == true && data["reason"] != tools.ChangeContentChanged {
+					return fmt.Errorf("changed with reason %v right after fetching the page", data["reason"])
+				}
+				return nil
+			},
+		},
+		{tool: "wiki_category", check: "ok", args: categoryArgs(map[string]any{"limit": 5}), result: reflect.TypeFor[wiki.CategoryResponse]()},
+		{tool: "wiki_category", check: "all", args: categoryArgs(map[string]any{"all": true, "limit": 50}), result: reflect.TypeFor[wiki.CategoryResponse]()},
+		{tool: "wiki_backlinks", check: "ok", args: pageArgs(map[string]any{"limit": 5}), result: reflect.TypeFor[wiki.BacklinksResponse]()},
+		{tool: "wiki_compare", check: "ok", args: pageArgs(nil), result: reflect.TypeFor[wiki.CompareResponse]()},
+		{tool: "wiki_blame", check: "ok", args: pageArgs(map[string]any{"snippet": "the"}), result: reflect.TypeFor[wiki.BlameResponse]()},
+		{tool: "wiki_search_history", check: "ok", args: pageArgs(map[string]any{"pattern": "the", "max_revisions": 20}), result: reflect.TypeFor[wiki.HistorySearchResponse]()},
+		{tool: "wiki_subpages", check: "ok", args: pageArgs(map[string]any{"limit": 5}), result: reflect.TypeFor[wiki.SubpagesResponse]()},
+		{tool: "wiki_discussions", check: "ok", args: pageArgs(map[string]any{"limit": 5}), result: reflect.TypeFor[wiki.DiscussionsResponse]()},
+		{tool: "wiki_talk_read", check: "ok", args: pageArgs(map[string]any{"limit": 5}), result: reflect.TypeFor[mcpServer.TalkPageResponse]()},
+		{tool: "wiki_page_coordinates", check: "ok", args: pageArgs(nil), result: reflect.TypeFor[wiki.CoordinatesResponse]()},
+		{tool: "wiki_page_timeline", check: "ok", args: pageArgs(map[string]any{"limit": 5}), result: reflect.TypeFor[wiki.TimelineResponse]()},
+		{tool: "wiki_glossary", check: "ok", args: pageArgs(nil), result: reflect.TypeFor[wiki.GlossaryResponse]()},
+		{tool: "wiki_infobox_schema", check: "ok", args: categoryArgs(map[string]any{"sample_size": 5}), result: reflect.TypeFor[wiki.InfoboxSchema]()},
+		{tool: "wiki_references", check: "ok", args: pageArgs(nil), result: reflect.TypeFor[wiki.ReferencesResponse]()},
+		{tool: "wiki_sister_links", check: "ok", args: pageArgs(nil), result: reflect.TypeFor[wiki.SisterLinksResponse]()},
+		{
+			tool: "wiki_compare_languages", check: "ok",
+			args: func(ctx context.Context, st *state) (map[string]any, error) {
+				return pageArgs(map[string]any{"target_language": st.opts.TargetLanguage})(ctx, st)
+			},
+			result: reflect.TypeFor[wiki.LanguageComparison](),
+		},
+		{tool: "wiki_maintenance_report", check: "ok", args: wikiArgs(map[string]any{"report": "Wantedtemplates", "limit": 5}), result: reflect.TypeFor[wiki.MaintenanceReport]()},
+		{tool: "wiki_farm_list", check: "ok", args: wikiArgs(map[string]any{"limit": 5}), result: reflect.TypeFor[wiki.FarmResponse]()},
+
+		// Background jobs, kept small
+		{
+			tool: "wiki_crawl_category", check: "ok", args: categoryArgs(map[string]any{"max_depth": 0, "max_pages": 5}),
+			result: reflect.TypeFor[jobs.Job](),
+			after:  recordJob(func(st *state) *string { return &st.crawlJob }),
+		},
+		{tool: "wiki_job", check: "ok", args: jobArgs(func(st *state) string { return st.crawlJob }), result: reflect.TypeFor[jobs.Job]()},
+		{tool: "wiki_job", check: "job_not_found", args: fixedArgs(map[string]any{"job_id": missingID}), errorCode: "job_not_found"},
+		{tool: "wiki_jobs", check: "ok", args: fixedArgs(map[string]any{}), result: reflect.TypeFor[jobList]()},
+		{tool: "wiki_extract_dataset", check: "ok", args: categoryArgs(map[string]any{"max_depth": 0, "max_pages": 5}), result: reflect.TypeFor[jobs.Job]()},
+		{
+			tool: "wiki_dedup", check: "ok",
+			args: func(_ context.Context, st *state) (map[string]any, error) {
+				source := map[string]any{"wiki_url": st.opts.WikiURL, "titles": []string{st.opts.Title}}
+				return map[string]any{"sources": []any{source}, "max_pages": 5}, nil
+			},
+			result: reflect.TypeFor[jobs.Job](),
+			after:  recordJob(func(st *state) *string { return &st.dedupJob }),
+		},
+		{tool: "wiki_job_cancel", check: "ok", args: jobArgs(func(st *state) string { return st.dedupJob }), result: reflect.TypeFor[jobs.Job]()},
+		{
+			tool: "wiki_artifact_url", check: "ok",
+			args: func(ctx context.Context, st *state) (map[string]any, error) {
+				if st.crawlJob == "" {
+					return nil, fmt.Errorf("%w: no crawl job was started", errSkip)
+				}
+				if err := st.waitForJob(ctx, st.crawlJob); err != nil {
+					return nil, err
+				}
+				return map[string]any{"job_id": st.crawlJob}, nil
+			},
+			result: reflect.TypeFor[mcpServer.ArtifactLink](),
+			after: func(ctx context.Context, st *state, data map[string]any) error {
+				link, _ := data["url"].(string)
+				return st.download(ctx, link)
+			},
+		},
+		{tool: "wiki_artifact_url", check: "job_not_found", args: fixedArgs(map[string]any{"job_id": missingID}), errorCode: "job_not_found"},
+		{tool: "wiki_pending_edit", check: "edit_not_found", args: fixedArgs(map[string]any{"edit_id": missingID}), errorCode: "edit_not_found"},
+	}
+}
+
+// fixedArgs returns the same arguments every run
+func fixedArgs(args map[string]any) func(context.Context, *state) (map[string]any, error) {
+	return func(context.Context, *state) (map[string]any, error) {
+		return args, nil
+	}
+}
+
+// wikiArgs adds the wiki URL to extra
+func wikiArgs(extra map[string]any) func(context.Context, *state) (map[string]any, error) {
+	return func(_ context.Context, st *state) (map[string]any, error) {
+		args := map[string]any{"wiki_url": st.opts.WikiURL}
+		for k, v := range extra {
+			args[k] = v
+		}
+		return args, nil
+	}
+}
+
+// pageArgs adds the wiki URL and page title to extra
+func pageArgs(extra map[string]any) func(context.Context, *state) (map[string]any, error) {
+	return func(ctx context.Context, st *state) (map[string]any, error) {
+		args, _ := wikiArgs(extra)(ctx, st)
+		args["title"] = st.opts.Title
+		return args, nil
+	}
+}
+
+// categoryArgs adds the wiki URL and category to extra
+func categoryArgs(extra map[string]any) func(context.Context, *state) (map[string]any, error) {
+	return func(ctx context.Context, st *state) (map[string]any, error) {
+		args, _ := wikiArgs(extra)(ctx, st)
+		args["category"] = st.opts.Category
+		return args, nil
+	}
+}
+
+// jobArgs passes a job started by an earlier case
+func jobArgs(id func(*state) string) func(context.Context, *state) (map[string]any, error) {
+	return func(_ context.Context, st *state) (map[string]any, error) {
+		if id(st) == "" {
+			return nil, fmt.Errorf("%w: the job wasn't started", errSkip)
+		}
+		return map[string]any{"job_id": id(st)}, nil
+	}
+}
+
+// recordJob records the ID of a started job
+func recordJob(field func(*state) *string) func(context.Context, *state, map[string]any) error {
+	return func(_ context.Context, st *state, data map[string]any) error {
+		id, _ := data["id"].(string)
+		if id == "" {
+			return fmt.Errorf("job has no id")
+		}
+		*field(st) = id
+		return nil
+	}
+}
+
+// waitForJob polls wiki_job until the job succeeds
+func (st *state) waitForJob(ctx context.Context, id string) error {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+	for {
+		job, err := st.callData(ctx, "wiki_job", map[string]any{"job_id": id})
+		if err != nil {
+			return err
+		}
+		switch status := jobs.Status(fmt.Sprint(job["status"])); {
+		case status == jobs.StatusSucceeded:
+			return nil
+		case status.Finished():
+			return fmt.Errorf("job %s %s: %v", id, status, job["error"])
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: job %s didn't finish within %s", errSkip, id, st.opts.Timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// download fetches an artifact URL, resolved against the endpoint when the
+// server has no public URL configured
+func (st *state) download(ctx context.Context, link string) error {
+	base, err := url.Parse(st.opts.Endpoint)
+	if err != nil {
+		return err
+	}
+	ref, err := url.Parse(link)
+	if err != nil {
+		return fmt.Errorf("invalid artifact url: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, base.ResolveReference(ref).String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("download artifact: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download artifact: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/google/jsonschema-go/jsonschema"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// typeSchemas override inferred schemas for types that don't marshal as
+// their Go structure suggests, and break the recursion of tree types
+var typeSchemas = map[reflect.Type]*jsonschema.Schema{
+	reflect.TypeFor[json.RawMessage](): {},
+}
+
+func init() {
+	for _, t := range []reflect.Type{
+		reflect.TypeFor[wiki.Section](),
+		reflect.TypeFor[wiki.SubpageNode](),
+		reflect.TypeFor[wiki.DiscussionComment](),
+	} {
+		typeSchemas[t] = recursiveSchema(t)
+	}
+}
+
+// recursiveSchema infers the schema of a type holding a []*T of itself,
+// checking nested values only as objects
+func recursiveSchema(t reflect.Type) *jsonschema.Schema {
+	nested := map[reflect.Type]*jsonschema.Schema{
+		reflect.SliceOf(reflect.PointerTo(t)): {Type: "array", Items: &jsonschema.Schema{Type: "object"}},
+	}
+	for k, v := range typeSchemas {
+		nested[k] = v
+	}
+	schema, err := jsonschema.ForType(t, &jsonschema.ForOptions{TypeSchemas: nested})
+	if err != nil {
+		panic(fmt.Sprintf("conformance: schema for %v: %v", t, err))
+	}
+	return schema
+}
+
+var (
+	schemasMu sync.Mutex
+	schemas   = make(map[reflect.Type]*jsonschema.Resolved)
+)
+
+// schemaFor returns the resolved schema of the JSON encoding of t. Fields
+// without omitempty are required. Properties the type doesn't have are
+// allowed, as adding fields doesn't break clients.
+func schemaFor(t reflect.Type) (*jsonschema.Resolved, error) {
+	schemasMu.Lock()
+	defer schemasMu.Unlock()
+	if resolved, ok := schemas[t]; ok {
+		return resolved, nil
+	}
+
+	schema, err := jsonschema.ForType(t, &jsonschema.ForOptions{TypeSchemas: typeSchemas})
+	if err != nil {
+		return nil, err
+	}
+	allowExtraProperties(schema)
+	resolved, err := schema.Resolve(nil)
+	if err != nil {
+		return nil, err
+	}
+	schemas[t] = resolved
+	return resolved, nil
+}
+
+// allowExtraProperties drops the additionalProperties: false that schema
+// inference puts on structs
+func allowExtraProperties(s *jsonschema.Schema) {
+	if s == nil {
+		return
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Not != nil {
+		s.AdditionalProperties = nil
+	}
+	allowExtraProperties(s.AdditionalProperties)
+	allowExtraProperties(s.Items)
+	for _, property := range s.Properties {
+		allowExtraProperties(property)
+	}
+}
+
+// validate checks text against the schema of t and decodes it into v
+func validate(t reflect.Type, text string, v any) error {
+	resolved, err := schemaFor(t)
+	if err != nil {
+		return fmt.Errorf("schema for %v: %w", t, err)
+	}
+	var instance any
+	if err := json.Unmarshal([]byte(text), &instance); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if err := resolved.Validate(instance); err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(text), v)
+}
+
+// resolveSchema checks that a tool's input schema is a valid JSON schema
+func resolveSchema(schema any) (*jsonschema.Resolved, error) {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	var s jsonschema.Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid input schema: %w", err)
+	}
+	if s.Type != "object" {
+		return nil, fmt.Errorf("input schema type is %q, want object", s.Type)
+	}
+	resolved, err := s.Resolve(nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input schema: %w", err)
+	}
+	return resolved, nil
+}
+
+// jsonValue converts v to its generic JSON form, as schemas validate
+func jsonValue(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var generic any
+	json.Unmarshal(data, &generic)
+	return generic
+}
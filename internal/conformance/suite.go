@@ -0,0 +1,311 @@
+// Package conformance checks that a running server implements the tool API:
+// every tool it offers is called against a target wiki, and the results are
+// checked against the tool's input schema, the result envelope, and the
+// schema of the tool's response type
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/yourusername/mediawiki-mcp/config"
+	mcpServer "github.com/yourusername/mediawiki-mcp/internal/mcp"
+)
+
+// Options select the server to verify and the wiki content to exercise it
+// with. The defaults suit a server that can reach English Wikipedia; for a
+// fixture-backed server, point WikiURL, Title, and Category at the fixture.
+type Options struct {
+	Endpoint       string        // MCP endpoint, e.g. http://localhost:8080/mcp
+	WikiURL        string        // wiki passed to every tool
+	Title          string        // an article with sections, links, and references
+	Category       string        // a category with a few pages
+	TargetLanguage string        // language version of Title, for wiki_compare_languages
+	Timeout        time.Duration // per tool call, and for background jobs to finish
+}
+
+func (o *Options) setDefaults() {
+	if o.WikiURL == "" {
+		o.WikiURL = "https://en.wikipedia.org"
+	}
+	if o.Title == "" {
+		o.Title = "Go (programming language)"
+	}
+	if o.Category == "" {
+		o.Category = "Programming languages"
+	}
+	if o.TargetLanguage == "" {
+		o.TargetLanguage = "de"
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = time.Minute
+	}
+}
+
+// Outcome is the result of a check
+type Outcome string
+
+const (
+	Pass Outcome = "pass"
+	Fail Outcome = "fail"
+	Skip Outcome = "skip" // not run: the server lacks the tool, or the wiki the feature
+)
+
+// Result is the outcome of one check of one tool
+type Result struct {
+	Tool     string  `json:"tool"`
+	Check    string  `json:"check"`
+	Outcome  Outcome `json:"outcome"`
+	Detail   string  `json:"detail,omitempty"`
+	Duration int64   `json:"duration_ms"`
+}
+
+// Report is the pass/fail matrix of a conformance run
+type Report struct {
+	Endpoint string   `json:"endpoint"`
+	WikiURL  string   `json:"wiki_url"`
+	Results  []Result `json:"results"`
+}
+
+// Count returns the number of checks with an outcome
+func (r *Report) Count(outcome Outcome) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Outcome == outcome {
+			n++
+		}
+	}
+	return n
+}
+
+// WriteMatrix writes the report as a table, one row per check, followed by
+// the totals
+func (r *Report) WriteMatrix(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TOOL\tCHECK\tRESULT\tTIME\tDETAIL")
+	for _, result := range r.Results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%dms\t%s\n", result.Tool, result.Check, strings.ToUpper(string(result.Outcome)), result.Duration, result.Detail)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%d passed, %d failed, %d skipped\n", r.Count(Pass), r.Count(Fail), r.Count(Skip))
+	return err
+}
+
+// Run connects to the server at opts.Endpoint and runs the suite
+func Run(ctx context.Context, opts Options) (*Report, error) {
+	opts.setDefaults()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "mediawiki-mcp-verify", Version: config.Version}, nil)
+	session, err := client.Connect(ctx, &mcp.StreamableClientTransport{Endpoint: opts.Endpoint}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("connect to %s: %w", opts.Endpoint, err)
+	}
+	defer session.Close()
+
+	return run(ctx, session, opts, suite())
+}
+
+// run checks the input schema of every tool the server offers, then runs
+// the cases in order
+func run(ctx context.Context, session *mcp.ClientSession, opts Options, cases []testCase) (*Report, error) {
+	report := &Report{Endpoint: opts.Endpoint, WikiURL: opts.WikiURL, Results: make([]Result, 0)}
+
+	inputSchemas := make(map[string]*jsonschema.Resolved)
+	var names []string
+	for tool, err := range session.Tools(ctx, nil) {
+		if err != nil {
+			return nil, fmt.Errorf("list tools: %w", err)
+		}
+		names = append(names, tool.Name)
+
+		result := Result{Tool: tool.Name, Check: "input_schema", Outcome: Pass}
+		if resolved, err := resolveSchema(tool.InputSchema); err != nil {
+			result.Outcome, result.Detail = Fail, err.Error()
+		} else {
+			inputSchemas[tool.Name] = resolved
+		}
+		report.Results = append(report.Results, result)
+	}
+	sort.Strings(names)
+
+	st := &state{session: session, opts: opts}
+	covered := make(map[string]bool)
+	for _, tc := range cases {
+		covered[tc.tool] = true
+		if !contains(names, tc.tool) {
+			report.Results = append(report.Results, Result{Tool: tc.tool, Check: tc.check, Outcome: Skip, Detail: "not offered by the server"})
+			continue
+		}
+		report.Results = append(report.Results, st.runCase(ctx, tc, inputSchemas[tc.tool]))
+	}
+
+	for _, name := range names {
+		if !covered[name] {
+			report.Results = append(report.Results, Result{Tool: name, Check: "call", Outcome: Skip, Detail: "no conformance case for this tool"})
+		}
+	}
+
+	return report, nil
+}
+
+// testCase is one call of a tool and what its result must look like
+type testCase struct {
+	tool  string
+	check string
+
+	// args returns the tool arguments, or errSkip (wrapped) when a value an
+	// earlier case should have recorded is missing
+	args func(ctx context.Context, st *state) (map[string]any, error)
+
+	// result is the Go type the data of a successful result must match;
+	// errorCode instead expects an error result with that code
+	result    reflect.Type
+	errorCode string
+
+	// after checks the result data further and records values for later cases
+	after func(ctx context.Context, st *state, data map[string]any) error
+}
+
+// errSkip marks a case that can't run
+var errSkip = errors.New("skipped")
+
+// state carries the session and values recorded by earlier cases
+type state struct {
+	session *mcp.ClientSession
+	opts    Options
+
+	revID       float64 // source.revid of wiki_page_full
+	contentHash string  // content_hash of wiki_page_full
+	crawlJob    string
+	dedupJob    string
+}
+
+// runCase calls a case's tool and checks the result
+func (st *state) runCase(ctx context.Context, tc testCase, inputSchema *jsonschema.Resolved) Result {
+	result := Result{Tool: tc.tool, Check: tc.check}
+	start := time.Now()
+	outcome, detail := st.check(ctx, tc, inputSchema)
+	result.Outcome, result.Detail = outcome, detail
+	result.Duration = time.Since(start).Milliseconds()
+	return result
+}
+
+func (st *state) check(ctx context.Context, tc testCase, inputSchema *jsonschema.Resolved) (Outcome, string) {
+	ctx, cancel := context.WithTimeout(ctx, st.opts.Timeout)
+	defer cancel()
+
+	args, err := tc.args(ctx, st)
+	if errors.Is(err, errSkip) {
+		return Skip, err.Error()
+	}
+	if err != nil {
+		return Fail, err.Error()
+	}
+	if inputSchema != nil {
+		if err := inputSchema.Validate(jsonValue(args)); err != nil {
+			return Fail, fmt.Sprintf("arguments rejected by the input schema: %v", err)
+		}
+	}
+
+	text, isError, err := st.call(ctx, tc.tool, args)
+	if err != nil {
+		return Fail, err.Error()
+	}
+
+	if isError {
+		var resp mcpServer.ErrorResponse
+		if err := validate(reflect.TypeFor[mcpServer.ErrorResponse](), text, &resp); err != nil {
+			return Fail, fmt.Sprintf("error result: %v", err)
+		}
+		switch {
+		case resp.APIVersion != mcpServer.APIVersion:
+			return Fail, fmt.Sprintf("error api_version = %q, want %q", resp.APIVersion, mcpServer.APIVersion)
+		case tc.errorCode == resp.Error:
+			return Pass, ""
+		case resp.Error == "feature_unsupported":
+			return Skip, "the wiki doesn't support this: " + resp.Message
+		}
+		return Fail, fmt.Sprintf("unexpected error %s: %s", resp.Error, resp.Message)
+	}
+	if tc.errorCode != "" {
+		return Fail, fmt.Sprintf("succeeded, want error %s", tc.errorCode)
+	}
+
+	var envelope mcpServer.Envelope
+	if err := validate(reflect.TypeFor[mcpServer.Envelope](), text, &envelope); err != nil {
+		return Fail, fmt.Sprintf("envelope: %v", err)
+	}
+	if envelope.APIVersion != mcpServer.APIVersion {
+		return Fail, fmt.Sprintf("api_version = %q, want %q", envelope.APIVersion, mcpServer.APIVersion)
+	}
+	var data map[string]any
+	if err := validate(tc.result, string(envelope.Data), &data); err != nil {
+		return Fail, fmt.Sprintf("data: %v", err)
+	}
+	if tc.after != nil {
+		if err := tc.after(ctx, st, data); err != nil {
+			return Fail, err.Error()
+		}
+	}
+
+	if len(envelope.Warnings) > 0 {
+		skipped := make([]string, 0, len(envelope.Warnings))
+		for _, w := range envelope.Warnings {
+			skipped = append(skipped, w.Enrichment)
+		}
+		return Pass, "warnings: " + strings.Join(skipped, ", ")
+	}
+	return Pass, ""
+}
+
+// call calls a tool and returns the text of its result
+func (st *state) call(ctx context.Context, tool string, args map[string]any) (string, bool, error) {
+	res, err := st.session.CallTool(ctx, &mcp.CallToolParams{Name: tool, Arguments: args})
+	if err != nil {
+		return "", false, fmt.Errorf("call: %w", err)
+	}
+	if len(res.Content) != 1 {
+		return "", false, fmt.Errorf("result has %d content items, want 1", len(res.Content))
+	}
+	text, ok := res.Content[0].(*mcp.TextContent)
+	if !ok {
+		return "", false, fmt.Errorf("result content is %T, want text", res.Content[0])
+	}
+	return text.Text, res.IsError, nil
+}
+
+// callData calls a tool that must succeed and returns its result data
+func (st *state) callData(ctx context.Context, tool string, args map[string]any) (map[string]any, error) {
+	text, isError, err := st.call(ctx, tool, args)
+	if err != nil {
+		return nil, err
+	}
+	if isError {
+		return nil, fmt.Errorf("%s failed: %s", tool, text)
+	}
+	var envelope struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(text), &envelope); err != nil {
+		return nil, fmt.Errorf("%s: %w", tool, err)
+	}
+	return envelope.Data, nil
+}
+
+func contains(list []string, s string) bool {
+	i := sort.SearchStrings(list, s)
+	return i < len(list) && list[i] == s
+}
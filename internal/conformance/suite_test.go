@@ -0,0 +1,97 @@
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// newTestServer serves tools returning fixed result texts
+func newTestServer(t *testing.T, results map[string]*mcp.CallToolResult) *mcp.ClientSession {
+	t.Helper()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1"}, nil)
+	for name, result := range results {
+		result := result
+		server.AddTool(&mcp.Tool{
+			Name:        name,
+			InputSchema: json.RawMessage(`{"type":"object","properties":{"wiki_url":{"type":"string"}},"required":["wiki_url"]}`),
+		}, func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return result, nil
+		})
+	}
+	handler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return server }, nil)
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "verify", Version: "1"}, nil)
+	session, err := client.Connect(context.Background(), &mcp.StreamableClientTransport{Endpoint: srv.URL}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { session.Close() })
+	return session
+}
+
+func textResult(text string, isError bool) *mcp.CallToolResult {
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: text}}, IsError: isError}
+}
+
+func TestRun(t *testing.T) {
+	session := newTestServer(t, map[string]*mcp.CallToolResult{
+		"good":      textResult(`{"api_version":"1","data":{"name":"Test","base_url":"http://wiki","main_page":"Main","language":"en","article_count":3,"namespaces":{}},"warnings":[],"cache":{"hit":false,"age":0},"meta":{"cache_hit":false,"upstream_calls":1,"latency_ms":12}}`, false),
+		"bad_data":  textResult(`{"api_version":"1","data":{"name":7},"warnings":[],"cache":{"hit":false,"age":0},"meta":{"cache_hit":false,"upstream_calls":1,"latency_ms":12}}`, false),
+		"no_env":    textResult(`{"name":"Test"}`, false),
+		"not_found": textResult(`{"api_version":"1","error":"section_not_found","message":"no such section"}`, true),
+		"uncovered": textResult(`{}`, false),
+	})
+
+	info := reflect.TypeFor[wiki.WikiInfo]()
+	cases := []testCase{
+		{tool: "good", check: "ok", args: wikiArgs(nil), result: info},
+		{tool: "good", check: "bad_args", args: fixedArgs(map[string]any{}), result: info},
+		{tool: "bad_data", check: "ok", args: wikiArgs(nil), result: info},
+		{tool: "no_env", check: "ok", args: wikiArgs(nil), result: info},
+		{tool: "not_found", check: "section_not_found", args: wikiArgs(nil), errorCode: "section_not_found"},
+		{tool: "not_found", check: "wrong_code", args: wikiArgs(nil), errorCode: "job_not_found"},
+		{tool: "missing", check: "ok", args: wikiArgs(nil), result: info},
+	}
+
+	opts := Options{Endpoint: "test"}
+	opts.setDefaults()
+	report, err := run(context.Background(), session, opts, cases)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]Outcome{
+		"good/input_schema":           Pass,
+		"good/ok":                     Pass,
+		"good/bad_args":               Fail,
+		"bad_data/ok":                 Fail,
+		"no_env/ok":                   Fail,
+		"not_found/section_not_found": Pass,
+		"not_found/wrong_code":        Fail,
+		"missing/ok":                  Skip,
+		"uncovered/call":              Skip,
+	}
+	got := make(map[string]Outcome)
+	for _, result := range report.Results {
+		got[result.Tool+"/"+result.Check] = result.Outcome
+	}
+	for check, outcome := range want {
+		if got[check] != outcome {
+			t.Errorf("%s = %q, want %q", check, got[check], outcome)
+		}
+	}
+	if report.Count(Fail) != 4 {
+		t.Errorf("%d failures, want 4", report.Count(Fail))
+	}
+}
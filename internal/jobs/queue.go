@@ -0,0 +1,470 @@
+package jobs
+
+import (
+	"container/heap"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// ErrJobNotFound is returned for unknown job IDs
+var ErrJobNotFound = errors.New("job not found")
+
+// Status is the lifecycle state of a job
+type Status string
+
+const (
+	StatusQueued      Status = "queued"
+	StatusRunning     Status = "running"
+	StatusSucceeded   Status = "succeeded"
+	StatusFailed      Status = "failed"
+	StatusCancelled   Status = "cancelled"
+	StatusInterrupted Status = "interrupted" // lost to a restart
+)
+
+// Finished reports whether the status is terminal
+func (s Status) Finished() bool {
+	return s != StatusQueued && s != StatusRunning
+}
+
+// Progress reports how far a job has got
+type Progress struct {
+	Done    int    `json:"done"`
+	Total   int    `json:"total,omitempty"` // 0 when unknown
+	Message string `json:"message,omitempty"`
+}
+
+// Job is a snapshot of a queued or finished job
+type Job struct {
+	ID         string          `json:"id"`
+	Kind       string          `json:"kind"`
+	Params     json.RawMessage `json:"params,omitempty"`
+	Priority   int             `json:"priority"`
+	RateBudget float64         `json:"rate_budget,omitempty"` // requests per second, 0 = unbounded
+	Status     Status          `json:"status"`
+	Progress   Progress        `json:"progress"`
+	Error      string          `json:"error,omitempty"`
+	Result     json.RawMessage `json:"result,omitempty"`
+	Checkpoint json.RawMessage `json:"checkpoint,omitempty"` // resumable state saved by the job
+	CreatedAt  time.Time       `json:"created_at"`
+	StartedAt  *time.Time      `json:"started_at,omitempty"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty"`
+}
+
+// Func is the work of a job. It should return promptly when ctx is done.
+type Func func(ctx context.Context, task *Task) (interface{}, error)
+
+// Factory rebuilds a job's Func from its recorded params so interrupted
+// jobs can be resumed after a restart
+type Factory func(params json.RawMessage) (Func, error)
+
+// Spec describes a job to submit
+type Spec struct {
+	Kind       string
+	Params     interface{} // recorded for listing; must marshal to JSON
+	Priority   int         // higher runs first
+	RateBudget float64     // requests per second for this job's API calls
+	Run        Func
+}
+
+// Task is a running job's handle for reporting progress
+type Task struct {
+	queue *Queue
+	id    string
+}
+
+// ID returns the job's ID
+func (t *Task) ID() string {
+	return t.id
+}
+
+// SetTotal sets the expected amount of work
+func (t *Task) SetTotal(total int) {
+	t.queue.update(t.id, func(j *Job) { j.Progress.Total = total })
+}
+
+// SetProgress records work done and an optional status message
+func (t *Task) SetProgress(done int, message string) {
+	t.queue.update(t.id, func(j *Job) {
+		j.Progress.Done = done
+		j.Progress.Message = message
+	})
+}
+
+// Checkpoint saves resumable state for the job and persists it
+func (t *Task) Checkpoint(state interface{}) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("encode checkpoint: %w", err)
+	}
+
+	var job Job
+	t.queue.update(t.id, func(j *Job) {
+		j.Checkpoint = data
+		job = *j
+	})
+	t.queue.persist(job)
+	return nil
+}
+
+// LastCheckpoint returns the state saved by a previous run, or nil
+func (t *Task) LastCheckpoint() json.RawMessage {
+	var data json.RawMessage
+	t.queue.update(t.id, func(j *Job) { data = j.Checkpoint })
+	return data
+}
+
+// entry is the queue's internal record of a job
+type entry struct {
+	job    Job
+	run    Func
+	cancel context.CancelFunc
+	index  int // position in the pending heap, -1 when not pending
+}
+
+// Queue runs jobs on a bounded pool of workers in priority order
+type Queue struct {
+	workers int
+	store   Store
+
+	mu        sync.Mutex
+	jobs      map[string]*entry
+	factories map[string]Factory
+	pending   pendingHeap
+	wake      chan struct{}
+	ctx       context.Context
+	stop      context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+// NewQueue creates a queue with the given worker count. store may be nil
+// for an in-memory queue; otherwise previous jobs are loaded from it and
+// any that were unfinished are marked interrupted.
+func NewQueue(workers int, store Store) *Queue {
+	if workers < 1 {
+		workers = 1
+	}
+
+	q := &Queue{
+		workers:   workers,
+		store:     store,
+		jobs:      make(map[string]*entry),
+		factories: make(map[string]Factory),
+		wake:      make(chan struct{}, 1),
+	}
+
+	if store != nil {
+		saved, err := store.LoadJobs()
+		if err != nil {
+			log.Printf("jobs: load: %v", err)
+		}
+		for _, job := range saved {
+			if !job.Status.Finished() {
+				job.Status = StatusInterrupted
+				q.persist(job)
+			}
+			q.jobs[job.ID] = &entry{job: job, index: -1}
+		}
+	}
+
+	return q
+}
+
+// Register makes jobs of kind resumable via factory
+func (q *Queue) Register(kind string, factory Factory) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.factories[kind] = factory
+}
+
+// ResumeInterrupted re-queues interrupted jobs of registered kinds, which
+// continue from their last checkpoint. It returns the number resumed.
+func (q *Queue) ResumeInterrupted() int {
+	q.mu.Lock()
+	resumed := make([]Job, 0)
+	for _, e := range q.jobs {
+		factory, ok := q.factories[e.job.Kind]
+		if e.job.Status != StatusInterrupted || !ok {
+			continue
+		}
+		run, err := factory(e.job.Params)
+		if err != nil {
+			log.Printf("jobs: resume %s: %v", e.job.ID, err)
+			continue
+		}
+		e.run = run
+		e.job.Status = StatusQueued
+		e.job.StartedAt = nil
+		e.job.FinishedAt = nil
+		heap.Push(&q.pending, e)
+		resumed = append(resumed, e.job)
+	}
+	q.mu.Unlock()
+
+	for _, job := range resumed {
+		q.persist(job)
+	}
+	if len(resumed) > 0 {
+		q.signal()
+	}
+	return len(resumed)
+}
+
+// Start launches the workers. Stop cancels running jobs and waits for them.
+func (q *Queue) Start() {
+	q.ctx, q.stop = context.WithCancel(context.Background())
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go q.worker()
+	}
+}
+
+// Stop cancels running jobs and waits for workers to exit
+func (q *Queue) Stop() {
+	if q.stop != nil {
+		q.stop()
+		q.wg.Wait()
+	}
+}
+
+// Submit queues a job and returns its snapshot
+func (q *Queue) Submit(spec Spec) (Job, error) {
+	params, err := json.Marshal(spec.Params)
+	if err != nil {
+		return Job{}, fmt.Errorf("encode job params: %w", err)
+	}
+
+	e := &entry{
+		job: Job{
+			ID:         newID(),
+			Kind:       spec.Kind,
+			Params:     params,
+			Priority:   spec.Priority,
+			RateBudget: spec.RateBudget,
+			Status:     StatusQueued,
+			CreatedAt:  time.Now().UTC(),
+		},
+		run: spec.Run,
+	}
+
+	q.mu.Lock()
+	q.jobs[e.job.ID] = e
+	heap.Push(&q.pending, e)
+	job := e.job
+	q.mu.Unlock()
+
+	q.persist(job)
+	q.signal()
+	return job, nil
+}
+
+// Get returns a job snapshot
+func (q *Queue) Get(id string) (Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	e, ok := q.jobs[id]
+	if !ok {
+		return Job{}, fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	}
+	job := e.job
+	job.Checkpoint = nil
+	return job, nil
+}
+
+// List returns snapshots of all jobs, newest first, without results
+func (q *Queue) List() []Job {
+	q.mu.Lock()
+	jobs := make([]Job, 0, len(q.jobs))
+	for _, e := range q.jobs {
+		job := e.job
+		job.Result = nil
+		job.Checkpoint = nil
+		jobs = append(jobs, job)
+	}
+	q.mu.Unlock()
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.After(jobs[j].CreatedAt)
+	})
+	return jobs
+}
+
+// Cancel stops a queued or running job
+func (q *Queue) Cancel(id string) (Job, error) {
+	q.mu.Lock()
+	e, ok := q.jobs[id]
+	if !ok {
+		q.mu.Unlock()
+		return Job{}, fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	}
+
+	switch e.job.Status {
+	case StatusQueued:
+		heap.Remove(&q.pending, e.index)
+		finish(&e.job, StatusCancelled)
+	case StatusRunning:
+		// The worker records the cancellation when the job returns
+		e.cancel()
+	}
+	job := e.job
+	q.mu.Unlock()
+	job.Checkpoint = nil
+
+	q.persist(e.job)
+	return job, nil
+}
+
+// worker runs pending jobs until the queue is stopped
+func (q *Queue) worker() {
+	defer q.wg.Done()
+
+	for {
+		q.mu.Lock()
+		if q.pending.Len() == 0 {
+			q.mu.Unlock()
+			select {
+			case <-q.ctx.Done():
+				return
+			case <-q.wake:
+				continue
+			}
+		}
+
+		e := heap.Pop(&q.pending).(*entry)
+		ctx, cancel := context.WithCancel(q.ctx)
+		e.cancel = cancel
+		now := time.Now().UTC()
+		e.job.Status = StatusRunning
+		e.job.StartedAt = &now
+		job := e.job
+		q.mu.Unlock()
+
+		// Let other workers pick up remaining jobs
+		q.signal()
+		q.persist(job)
+		q.execute(ctx, e)
+		cancel()
+	}
+}
+
+// execute runs one job and records its outcome
+func (q *Queue) execute(ctx context.Context, e *entry) {
+	ctx = wiki.WithBackground(ctx)
+	if e.job.RateBudget > 0 {
+		ctx = wiki.WithRateBudget(ctx, rate.NewLimiter(rate.Limit(e.job.RateBudget), 1))
+	}
+
+	result, err := e.run(ctx, &Task{queue: q, id: e.job.ID})
+
+	q.mu.Lock()
+	switch {
+	case ctx.Err() != nil && q.ctx.Err() != nil:
+		finish(&e.job, StatusInterrupted)
+	case ctx.Err() != nil:
+		finish(&e.job, StatusCancelled)
+	case err != nil:
+		e.job.Error = err.Error()
+		finish(&e.job, StatusFailed)
+	default:
+		if data, err := json.Marshal(result); err != nil {
+			e.job.Error = fmt.Sprintf("encode result: %v", err)
+			finish(&e.job, StatusFailed)
+		} else {
+			e.job.Result = data
+			e.job.Checkpoint = nil
+			finish(&e.job, StatusSucceeded)
+		}
+	}
+	e.run = nil
+	job := e.job
+	q.mu.Unlock()
+
+	q.persist(job)
+}
+
+// update applies a change to a job's record
+func (q *Queue) update(id string, fn func(*Job)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if e, ok := q.jobs[id]; ok {
+		fn(&e.job)
+	}
+}
+
+// persist saves a job snapshot to the store, if any
+func (q *Queue) persist(job Job) {
+	if q.store == nil {
+		return
+	}
+	if err := q.store.SaveJob(job); err != nil {
+		log.Printf("jobs: save %s: %v", job.ID, err)
+	}
+}
+
+func (q *Queue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// finish marks a job as ended with status
+func finish(job *Job, status Status) {
+	now := time.Now().UTC()
+	job.Status = status
+	job.FinishedAt = &now
+}
+
+// newID returns a random job identifier
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// pendingHeap orders queued jobs by priority, then submission time
+type pendingHeap []*entry
+
+func (h pendingHeap) Len() int { return len(h) }
+
+func (h pendingHeap) Less(i, j int) bool {
+	if h[i].job.Priority != h[j].job.Priority {
+		return h[i].job.Priority > h[j].job.Priority
+	}
+	return h[i].job.CreatedAt.Before(h[j].job.CreatedAt)
+}
+
+func (h pendingHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *pendingHeap) Push(x interface{}) {
+	e := x.(*entry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *pendingHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	e.index = -1
+	*h = old[:n-1]
+	return e
+}
@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// waitStatus polls a job until it reaches status
+func waitStatus(t *testing.T, q *Queue, id string, status Status, timeout time.Duration) Job {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for {
+		job, err := q.Get(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.Status == status {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job %s is %s after %s, want %s", id, job.Status, timeout, status)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestCancelFreesWorker(t *testing.T) {
+	q := NewQueue(1, nil)
+	q.Start()
+	defer q.Stop()
+
+	// A CPU-bound job that only stops when cancelled
+	html := "<p>" + strings.Repeat(`<a href="/wiki/A">A</a> <span>b</span> `, 20000) + "</p>"
+	started := make(chan struct{})
+	busy, err := q.Submit(Spec{Kind: "busy", Run: func(ctx context.Context, task *Task) (interface{}, error) {
+		close(started)
+		for {
+			if _, err := wiki.HTMLToMarkdown(ctx, html); err != nil {
+				return nil, err
+			}
+		}
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next, err := q.Submit(Spec{Kind: "next", Run: func(ctx context.Context, task *Task) (interface{}, error) {
+		return "done", nil
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	<-started
+	time.Sleep(20 * time.Millisecond) // let it get into a conversion
+	if _, err := q.Cancel(busy.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// The only worker is freed for the next job well within one conversion,
+	// which takes a few hundred milliseconds
+	waitStatus(t, q, busy.ID, StatusCancelled, 100*time.Millisecond)
+	if job := waitStatus(t, q, next.ID, StatusSucceeded, 100*time.Millisecond); string(job.Result) != `"done"` {
+		t.Errorf("result = %s", job.Result)
+	}
+}
@@ -0,0 +1,91 @@
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store persists job snapshots across restarts
+type Store interface {
+	SaveJob(job Job) error
+	LoadJobs() ([]Job, error)
+}
+
+// FileStore keeps job snapshots in a JSON file
+type FileStore struct {
+	path string
+
+	mu   sync.Mutex
+	jobs map[string]Job
+}
+
+// NewFileStore creates a store backed by the JSON file at path
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// LoadJobs reads all saved jobs. A missing file is an empty store.
+func (s *FileStore) LoadJobs() ([]Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// SaveJob records a job snapshot and rewrites the file
+func (s *FileStore) SaveJob(job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return err
+	}
+	s.jobs[job.ID] = job
+
+	data, err := json.Marshal(s.jobs)
+	if err != nil {
+		return err
+	}
+
+	// Write atomically so a crash never leaves a truncated file
+	tmp := s.path + ".tmp"
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// load reads the file once; callers hold s.mu
+func (s *FileStore) load() error {
+	if s.jobs != nil {
+		return nil
+	}
+
+	s.jobs = make(map[string]Job)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read jobs: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.jobs); err != nil {
+		return fmt.Errorf("parse jobs: %w", err)
+	}
+	return nil
+}
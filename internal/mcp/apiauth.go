@@ -0,0 +1,70 @@
+package mcp
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// restrictedTools change wikis, start or cancel jobs, or grant access to
+// job artifacts. Without MCP_API_TOKENS, the HTTP, REST, GraphQL, and gRPC
+// interfaces are open to anyone who can reach them, so they leave these
+// tools out.
+var restrictedTools = map[string]bool{
+	"wiki_edit_page":       true,
+	"wiki_create_page":     true,
+	"wiki_append_section":  true,
+	"wiki_talk_post":       true,
+	"wiki_undo":            true,
+	"wiki_rollback":        true,
+	"wiki_move_page":       true,
+	"wiki_delete_page":     true,
+	"wiki_upload_file":     true,
+	"wiki_crawl_category":  true,
+	"wiki_dedup":           true,
+	"wiki_extract_dataset": true,
+	"wiki_job_cancel":      true,
+	"wiki_artifact_url":    true,
+}
+
+// APIAuthRequired reports whether MCP_API_TOKENS is set, so requests to
+// the MCP endpoint and the other interfaces need one of its tokens
+func (s *Server) APIAuthRequired() bool {
+	return len(s.config.APITokens) > 0
+}
+
+// ValidAPIToken reports whether token is one of MCP_API_TOKENS
+func (s *Server) ValidAPIToken(token string) bool {
+	if token == "" {
+		return false
+	}
+	for _, want := range s.config.APITokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(want)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// RequireAPIToken wraps a handler so that, with MCP_API_TOKENS set, it only
+// serves requests with one of the tokens as "Authorization: Bearer <token>"
+func (s *Server) RequireAPIToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.APIAuthRequired() {
+			token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !s.ValidAPIToken(token) {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+				writeJSONError(w, http.StatusUnauthorized, FormatErrorString("unauthorized", "a token from MCP_API_TOKENS is required"))
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// APIToolAllowed reports whether the HTTP, REST, GraphQL, and gRPC
+// interfaces may call a tool: any tool when their requests are
+// authenticated, and otherwise only tools without side effects
+func (s *Server) APIToolAllowed(name string) bool {
+	return s.APIAuthRequired() || !restrictedTools[name]
+}
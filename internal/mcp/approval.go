@@ -0,0 +1,253 @@
+package mcp
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/yourusername/mediawiki-mcp/internal/approval"
+	"github.com/yourusername/mediawiki-mcp/internal/profiles"
+	"github.com/yourusername/mediawiki-mcp/internal/tools"
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// PendingEdit is an edit held for review, with the change it would make to
+// the page's current revision
+type PendingEdit struct {
+	approval.Edit
+	Diff      string `json:"diff,omitempty"`
+	DiffError string `json:"diff_error,omitempty"`
+}
+
+// registerApprovalTools registers the tool agents use to follow their
+// edits through review
+func (s *Server) registerApprovalTools() {
+	if !s.config.EditApproval {
+		return
+	}
+
+	// wiki_pending_edit
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_pending_edit",
+		Description: "Get the review status of an edit held for approval: pending, rejected (with the reviewer's reason), committed (with the new revision), or failed",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"edit_id": {
+					"type": "string",
+					"description": "Edit ID returned when the edit was submitted"
+				}
+			},
+			"required": ["edit_id"]
+		}`),
+	}, s.handlePendingEdit)
+}
+
+func (s *Server) handlePendingEdit(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		EditID string `json:"edit_id"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+
+	edit, err := s.approvals.Get(args.EditID)
+	if err != nil {
+		return s.errorResult(req, err, ""), nil
+	}
+
+	return s.successResult(edit)
+}
+
+// saveEdit is the write path shared by write tools. Titles outside the
+// allowed write prefixes and wikis without credentials are refused before
+// any API call, and page creations are refused when the page exists. The
+// summary gets the configured attribution and the bot flag is applied; the
+// edit is then held for review when approval is required, or saved. The result is an
+// *approval.Edit for held edits and a *wiki.EditResult for saved ones. A
+// dry run stops short of both and returns a *DryRunResult.
+func (s *Server) saveEdit(ctx context.Context, req *mcp.CallToolRequest, wikiURL string, edit wiki.EditParams, dryRun bool) (interface{}, error) {
+	if err := tools.CheckWriteAllowed(edit.Title, s.config.WriteAllowPrefixes); err != nil {
+		return nil, err
+	}
+	if err := s.requireCredentials(wikiURL); err != nil {
+		return nil, err
+	}
+	if edit.CreateOnly {
+		if err := tools.CheckPageMissing(ctx, s.client, wikiURL, edit.Title); err != nil {
+			return nil, err
+		}
+	}
+
+	attr := s.editAttribution(ctx, req)
+	edit.Summary = tools.FormatEditSummary(s.config.EditSummaryTemplate, edit.Summary, attr)
+
+	bot, err := tools.BotFlag(ctx, s.client, wikiURL, s.config.EditBot)
+	if err != nil {
+		return nil, err
+	}
+	edit.Bot = bot
+
+	if dryRun {
+		return s.dryRunEdit(ctx, wikiURL, edit)
+	}
+
+	if s.config.EditApproval {
+		pending := s.approvals.Submit(approval.Edit{
+			WikiURL:  wikiURL,
+			Edit:     edit,
+			Tool:     attr.Tool,
+			Client:   attr.Client,
+			AuditRef: attr.AuditRef,
+		})
+		return &pending, nil
+	}
+
+	return s.client.Edit(ctx, wikiURL, edit)
+}
+
+// commitEdit saves an approved edit (approval.CommitFunc). The write
+// restrictions are checked again in case they changed since submission.
+func (s *Server) commitEdit(ctx context.Context, edit approval.Edit) (*wiki.EditResult, error) {
+	if err := tools.CheckWriteAllowed(edit.Edit.Title, s.config.WriteAllowPrefixes); err != nil {
+		return nil, err
+	}
+	if err := s.requireCredentials(edit.WikiURL); err != nil {
+		return nil, err
+	}
+	return s.client.Edit(ctx, edit.WikiURL, edit.Edit)
+}
+
+// requireCredentials refuses writes to a MediaWiki wiki no account is
+// configured for, naming the profile to add one to. Wikis served from a
+// backend are left to report that they can't be edited.
+func (s *Server) requireCredentials(wikiURL string) error {
+	if s.client.HasAuth(wikiURL) {
+		return nil
+	}
+	p, ok := profiles.Find(s.profiles, wikiURL)
+	if ok && p.Backend != profiles.BackendMediaWiki {
+		return nil
+	}
+	return &wiki.NoCredentialsError{WikiURL: wikiURL, Profile: p.Name}
+}
+
+// ReviewHandler serves the reviewer endpoint for held edits. Requests need
+// a reviewer's token as "Authorization: Bearer <token>".
+//
+//	GET  /review/edits[?status=pending]  edits with a status (default pending), with diffs
+//	GET  /review/edits/{id}              one edit with its diff
+//	POST /review/edits/{id}/approve      commit the edit to the wiki
+//	POST /review/edits/{id}/reject       discard it; body {"reason": "..."}
+func (s *Server) ReviewHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reviewer, ok := s.reviewer(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="review"`)
+			writeJSONError(w, http.StatusUnauthorized, FormatErrorString("unauthorized", "a reviewer token from MCP_REVIEWER_TOKENS is required"))
+			return
+		}
+
+		path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/review/edits"), "/")
+		id, action, _ := strings.Cut(path, "/")
+
+		switch {
+		case id == "" && r.Method == http.MethodGet:
+			status := approval.Status(r.URL.Query().Get("status"))
+			if status == "" {
+				status = approval.StatusPending
+			}
+			if status == "all" {
+				status = ""
+			}
+			edits := s.approvals.List(status)
+			result := make([]PendingEdit, 0, len(edits))
+			for _, edit := range edits {
+				result = append(result, s.withDiff(r.Context(), edit))
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"edits": result})
+
+		case id != "" && action == "" && r.Method == http.MethodGet:
+			edit, err := s.approvals.Get(id)
+			if err != nil {
+				writeReviewError(w, err)
+				return
+			}
+			writeJSON(w, http.StatusOK, s.withDiff(r.Context(), edit))
+
+		case id != "" && action == "approve" && r.Method == http.MethodPost:
+			edit, err := s.approvals.Approve(r.Context(), id, reviewer)
+			if err != nil {
+				writeReviewError(w, err)
+				return
+			}
+			writeJSON(w, http.StatusOK, edit)
+
+		case id != "" && action == "reject" && r.Method == http.MethodPost:
+			var body struct {
+				Reason string `json:"reason"`
+			}
+			data, _ := io.ReadAll(io.LimitReader(r.Body, maxToolRequestBytes))
+			if len(strings.TrimSpace(string(data))) > 0 && json.Unmarshal(data, &body) != nil {
+				writeJSONError(w, http.StatusBadRequest, FormatErrorString("bad_request", `body must be {"reason": "..."}`))
+				return
+			}
+			edit, err := s.approvals.Reject(id, reviewer, body.Reason)
+			if err != nil {
+				writeReviewError(w, err)
+				return
+			}
+			writeJSON(w, http.StatusOK, edit)
+
+		default:
+			writeJSONError(w, http.StatusNotFound, FormatErrorString("not_found", "unknown review endpoint"))
+		}
+	})
+}
+
+// reviewer returns the name of the reviewer whose token authorizes r
+func (s *Server) reviewer(r *http.Request) (string, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return "", false
+	}
+	for name, want := range s.config.ReviewerTokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(want)) == 1 {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// withDiff adds the change a pending edit would make to the page as it is
+// now, which may differ from when the edit was submitted
+func (s *Server) withDiff(ctx context.Context, edit approval.Edit) PendingEdit {
+	pending := PendingEdit{Edit: edit}
+	if edit.Status != approval.StatusPending {
+		return pending
+	}
+	diff, err := tools.DiffProposedEdit(ctx, s.client, edit.WikiURL, edit.Edit)
+	if err != nil {
+		pending.DiffError = err.Error()
+	}
+	pending.Diff = diff
+	return pending
+}
+
+// writeReviewError maps approval queue errors onto HTTP statuses
+func writeReviewError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, approval.ErrEditNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, approval.ErrNotPending):
+		status = http.StatusConflict
+	}
+	writeJSONError(w, status, FormatError(err, ""))
+}
@@ -0,0 +1,179 @@
+package mcp
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/yourusername/mediawiki-mcp/internal/artifacts"
+)
+
+// artifactRetentionInterval is how often expired artifacts are deleted
+const artifactRetentionInterval = time.Hour
+
+// errArtifactsDisabled is returned for artifact operations when the
+// artifacts directory could not be created
+var errArtifactsDisabled = errors.New("job artifacts are disabled: the artifacts directory is unavailable")
+
+// artifactContentTypes maps artifact formats to download content types
+var artifactContentTypes = map[string]string{
+	"csv":   "text/csv; charset=utf-8",
+	"jsonl": "application/x-ndjson",
+	"txt":   "text/plain; charset=utf-8",
+}
+
+// ArtifactLink is a signed download URL for a job's artifact
+type ArtifactLink struct {
+	JobID     string             `json:"job_id"`
+	URL       string             `json:"url"`
+	ExpiresAt time.Time          `json:"expires_at"`
+	Artifact  artifacts.Artifact `json:"artifact"`
+}
+
+// registerArtifactTools registers the tool that hands out download links
+// for job artifacts
+func (s *Server) registerArtifactTools() {
+	// wiki_artifact_url
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_artifact_url",
+		Description: "Get a signed, expiring download URL for the file a finished job produced, such as a wiki_extract_dataset export or a wiki_crawl_category page list, instead of reading it through tool results",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"job_id": {
+					"type": "string",
+					"description": "Job ID returned when the job was started"
+				}
+			},
+			"required": ["job_id"]
+		}`),
+	}, s.handleArtifactURL)
+}
+
+func (s *Server) handleArtifactURL(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		JobID string `json:"job_id"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	if s.artifacts == nil {
+		return nil, errArtifactsDisabled
+	}
+
+	if _, err := s.jobs.Get(args.JobID); err != nil {
+		return s.errorResult(req, err, ""), nil
+	}
+	artifact, _, err := s.artifacts.Find(args.JobID)
+	if err != nil {
+		return s.errorResult(req, err, ""), nil
+	}
+
+	expires := time.Now().Add(s.config.ArtifactURLTTL).Truncate(time.Second)
+	query := url.Values{
+		"expires":   {strconv.FormatInt(expires.Unix(), 10)},
+		"signature": {artifacts.Sign(s.artifactSecret, args.JobID, expires)},
+	}
+	return s.successResult(ArtifactLink{
+		JobID:     args.JobID,
+		URL:       s.config.PublicURL + "/artifacts/" + url.PathEscape(args.JobID) + "?" + query.Encode(),
+		ExpiresAt: expires.UTC(),
+		Artifact:  artifact,
+	})
+}
+
+// ArtifactsHandler serves job artifacts at /artifacts/{job_id}. Requests
+// need the expires and signature parameters of a URL from
+// wiki_artifact_url, or a token from MCP_ARTIFACT_TOKENS as
+// "Authorization: Bearer <token>".
+func (s *Server) ArtifactsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			writeJSONError(w, http.StatusMethodNotAllowed, FormatErrorString("method_not_allowed", "use GET"))
+			return
+		}
+		if s.artifacts == nil {
+			writeJSONError(w, http.StatusServiceUnavailable, FormatError(errArtifactsDisabled, ""))
+			return
+		}
+
+		id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/artifacts"), "/")
+		query := r.URL.Query()
+		signed := artifacts.Verify(s.artifactSecret, id, query.Get("expires"), query.Get("signature"), time.Now())
+		if !signed && !s.artifactToken(r) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="artifacts"`)
+			writeJSONError(w, http.StatusUnauthorized, FormatErrorString("unauthorized", "a signed URL from wiki_artifact_url or a token from MCP_ARTIFACT_TOKENS is required"))
+			return
+		}
+
+		artifact, path, err := s.artifacts.Find(id)
+		if err != nil {
+			writeJSONError(w, http.StatusNotFound, FormatError(err, ""))
+			return
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			// Deleted by retention since Find
+			writeJSONError(w, http.StatusNotFound, FormatError(artifacts.ErrNotFound, ""))
+			return
+		}
+		defer file.Close()
+
+		if contentType, ok := artifactContentTypes[artifact.Format]; ok {
+			w.Header().Set("Content-Type", contentType)
+		}
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": artifact.Name}))
+		http.ServeContent(w, r, artifact.Name, artifact.CreatedAt, file)
+	})
+}
+
+// artifactToken reports whether r carries a token from MCP_ARTIFACT_TOKENS
+func (s *Server) artifactToken(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return false
+	}
+	for _, want := range s.config.ArtifactTokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(want)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// RunArtifactRetention deletes artifacts older than MCP_ARTIFACTS_RETENTION
+// now and then every hour until ctx is done
+func (s *Server) RunArtifactRetention(ctx context.Context) {
+	if s.artifacts == nil || s.config.ArtifactsRetention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(artifactRetentionInterval)
+	defer ticker.Stop()
+	for {
+		n, err := s.artifacts.Prune(s.config.ArtifactsRetention)
+		switch {
+		case err != nil:
+			log.Printf("artifacts: %v", err)
+		case n > 0:
+			log.Printf("artifacts: deleted %d older than %s", n, s.config.ArtifactsRetention)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
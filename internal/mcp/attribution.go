@@ -0,0 +1,136 @@
+package mcp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/yourusername/mediawiki-mcp/internal/tools"
+)
+
+// auditRefKey carries a tool call's audit reference in its context
+type auditRefKey struct{}
+
+// newAuditRef returns an unguessable reference for a tool call, so audit
+// records linked from public edit summaries can't be enumerated
+func newAuditRef() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// auditRef returns the audit reference of the tool call a context belongs to
+func auditRef(ctx context.Context) string {
+	ref, _ := ctx.Value(auditRefKey{}).(string)
+	return ref
+}
+
+// editAttribution identifies the tool call making an edit. The audit
+// reference only resolves when the audit log is enabled.
+func (s *Server) editAttribution(ctx context.Context, req *mcp.CallToolRequest) tools.EditAttribution {
+	attr := tools.EditAttribution{
+		Tool:   req.Params.Name,
+		Client: clientName(req),
+	}
+	if s.db != nil {
+		attr.AuditRef = auditRef(ctx)
+		if s.config.AuditURL != "" && attr.AuditRef != "" {
+			attr.AuditURL = strings.ReplaceAll(s.config.AuditURL, "{ref}", attr.AuditRef)
+		}
+	}
+	return attr
+}
+
+// clientName returns the MCP client's name and version from its initialize
+// request. Stateless sessions and plain HTTP calls have none.
+func clientName(req *mcp.CallToolRequest) string {
+	if req.Session == nil {
+		return ""
+	}
+	params := req.Session.InitializeParams()
+	if params == nil || params.ClientInfo == nil || params.ClientInfo.Name == "" {
+		return ""
+	}
+	if params.ClientInfo.Version == "" {
+		return params.ClientInfo.Name
+	}
+	return params.ClientInfo.Name + "/" + params.ClientInfo.Version
+}
+
+// auditArgumentKeys are the tool arguments kept in the audit log: what a
+// call acted on, its summary, and the revisions involved. Audit records are
+// linked from public edit summaries, so page content, uploaded files, and
+// other arguments are left out.
+var auditArgumentKeys = map[string]bool{
+	"wiki_url":      true,
+	"title":         true,
+	"from":          true,
+	"to":            true,
+	"filename":      true,
+	"section_index": true,
+	"summary":       true,
+	"revision":      true,
+	"base_revid":    true,
+	"dry_run":       true,
+}
+
+// auditArguments reduces tool arguments to auditArgumentKeys
+func auditArguments(raw json.RawMessage) json.RawMessage {
+	var args map[string]json.RawMessage
+	if json.Unmarshal(raw, &args) != nil {
+		return json.RawMessage("{}")
+	}
+	for key := range args {
+		if !auditArgumentKeys[key] {
+			delete(args, key)
+		}
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return json.RawMessage("{}")
+	}
+	return data
+}
+
+// AuditHandler serves GET /audit/{ref}, the audit record an edit summary
+// links to. Session IDs are left out, and arguments are reduced to
+// auditArgumentKeys, as records written before they were are stored whole.
+func (s *Server) AuditHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			writeJSONError(w, http.StatusMethodNotAllowed, FormatErrorString("method_not_allowed", "use GET"))
+			return
+		}
+		if s.db == nil {
+			writeJSONError(w, http.StatusNotFound, FormatErrorString("audit_disabled", "the audit log needs MCP_DB_PATH"))
+			return
+		}
+
+		rec, err := s.db.AuditRecordByRef(strings.TrimPrefix(r.URL.Path, "/audit/"))
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, FormatErrorString("internal_error", err.Error()))
+			return
+		}
+		if rec == nil {
+			writeJSONError(w, http.StatusNotFound, FormatErrorString("audit_not_found", "no audit record with this reference"))
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"ref":         rec.Ref,
+			"at":          rec.At,
+			"tool":        rec.Tool,
+			"wiki_url":    rec.WikiURL,
+			"title":       rec.Title,
+			"arguments":   auditArguments(rec.Arguments),
+			"error_code":  rec.ErrorCode,
+			"duration_ms": rec.Duration.Milliseconds(),
+		})
+	})
+}
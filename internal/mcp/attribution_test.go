@@ -0,0 +1,32 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAuditArguments(t *testing.T) {
+	tests := []struct {
+		name, args, want string
+	}{
+		{
+			name: "edit",
+			args: `{"wiki_url":"https://wiki.example.org","title":"Town","content":"secret draft","summary":"Fix","base_revid":12}`,
+			want: `{"base_revid":12,"summary":"Fix","title":"Town","wiki_url":"https://wiki.example.org"}`,
+		},
+		{
+			name: "upload",
+			args: `{"filename":"Map.png","content_base64":"iVBORw0KGgo=","url":"https://files.example.org/map.png?token=x","description":"A map"}`,
+			want: `{"filename":"Map.png"}`,
+		},
+		{name: "empty", args: ``, want: `{}`},
+		{name: "not an object", args: `[1,2]`, want: `{}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := auditArguments(json.RawMessage(tt.args)); string(got) != tt.want {
+				t.Errorf("auditArguments(%s) = %s, want %s", tt.args, got, tt.want)
+			}
+		})
+	}
+}
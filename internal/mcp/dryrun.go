@@ -0,0 +1,63 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yourusername/mediawiki-mcp/internal/tools"
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// DryRunResult is what a write tool returns when called with dry_run: the
+// write it would make, after the same checks, without making it
+type DryRunResult struct {
+	DryRun   bool   `json:"dry_run"` // always true, so the result can't be mistaken for a write
+	Action   string `json:"action"`  // edit, move, delete, upload, or rollback
+	Title    string `json:"title"`
+	Target   string `json:"target,omitempty"`    // a move's new title
+	Summary  string `json:"summary"`             // edit summary or log reason, with the attribution
+	Diff     string `json:"diff,omitempty"`      // the change to the current revision, as Markdown
+	Preview  string `json:"preview,omitempty"`   // the text saved, rendered as Markdown
+	FileSize int    `json:"file_size,omitempty"` // bytes an upload would send
+
+	CurrentRevision  *wiki.RevisionDetails `json:"current_revision,omitempty"`  // absent for a page that doesn't exist yet
+	RestoredRevision *wiki.RevisionDetails `json:"restored_revision,omitempty"` // the revision a rollback goes back to
+}
+
+// dryRunEdit shows what saveEdit would save: the diff against the page's
+// current revision and the rendered text. An edit with a base revision the
+// page has moved on from fails with edit_conflict, even where the wiki
+// could have merged it, and an edit that must not create the page fails
+// with missingtitle. The diff and preview are extras and are left out, with
+// a warning, when they can't be had.
+func (s *Server) dryRunEdit(ctx context.Context, wikiURL string, edit wiki.EditParams) (*DryRunResult, error) {
+	result := &DryRunResult{DryRun: true, Action: "edit", Title: edit.Title, Summary: edit.Summary}
+
+	current, err := s.client.LatestRevision(ctx, wikiURL, edit.Title)
+	var apiErr *wiki.APIError
+	switch {
+	case errors.As(err, &apiErr) && apiErr.Code == "missingtitle":
+		if edit.NoCreate || edit.BaseRevID > 0 {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	default:
+		if edit.BaseRevID > 0 && current.ID != edit.BaseRevID {
+			return nil, &wiki.EditConflictError{Title: edit.Title, BaseRevID: edit.BaseRevID, Current: current}
+		}
+		result.CurrentRevision = current
+	}
+
+	if diff, err := tools.DiffProposedEdit(ctx, s.client, wikiURL, edit); err != nil {
+		wiki.AddWarning(ctx, "diff", err)
+	} else {
+		result.Diff = diff
+	}
+	if preview, err := tools.PreviewEdit(ctx, s.client, wikiURL, edit); err != nil {
+		wiki.AddWarning(ctx, "preview", err)
+	} else {
+		result.Preview = preview
+	}
+	return result, nil
+}
@@ -0,0 +1,995 @@
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/yourusername/mediawiki-mcp/internal/tools"
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// registerEditTools registers the tools that change wiki pages. Their edits
+// go through saveEdit, so write restrictions, attribution, and review apply.
+func (s *Server) registerEditTools() {
+	// wiki_edit_page
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_edit_page",
+		Description: "Replace the wikitext of a page, or of one of its sections, with the wiki's account. Returns the new revision ID and a diff URL to confirm the change, or an edit ID to follow with wiki_pending_edit when edits are held for review",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"title": {
+					"type": "string",
+					"description": "Page to edit; it is created if it doesn't exist"
+				},
+				"content": {
+					"type": "string",
+					"description": "New wikitext of the page, or of the section when section_index is given, including its heading"
+				},
+				"section_index": {
+					"type": "integer",
+					"description": "Replace only this section (index from wiki_page_outline; 0 is the lead) instead of the whole page"
+				},
+				"summary": {
+					"type": "string",
+					"description": "Edit summary describing the change"
+				},
+				"base_revid": {
+					"type": "integer",
+					"description": "Revision the new content was based on. If the page changed since, the edit fails with edit_conflict, with the current revision in its details, instead of overwriting the change"
+				},
+				"dry_run": {
+					"type": "boolean",
+					"description": "Check the edit and show it without saving: returns the diff against the current revision and the new text rendered as Markdown (default: false)",
+					"default": false
+				}
+			},
+			"required": ["wiki_url", "title", "content", "summary"]
+		}`),
+	}, s.handleEditPage)
+
+	// wiki_create_page
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_create_page",
+		Description: "Create a new page with the wiki's account. Fails with page_exists instead of overwriting a page, or redirect, that already has the title; use wiki_edit_page to change existing pages. Returns the new revision ID and a diff URL, or an edit ID to follow with wiki_pending_edit when edits are held for review",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"title": {
+					"type": "string",
+					"description": "Title of the page to create"
+				},
+				"content": {
+					"type": "string",
+					"description": "Wikitext of the new page"
+				},
+				"summary": {
+					"type": "string",
+					"description": "Edit summary describing the page"
+				},
+				"dry_run": {
+					"type": "boolean",
+					"description": "Check the page can be created and show it without saving: returns its text as a diff and rendered as Markdown (default: false)",
+					"default": false
+				}
+			},
+			"required": ["wiki_url", "title", "content", "summary"]
+		}`),
+	}, s.handleCreatePage)
+
+	// wiki_append_section
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_append_section",
+		Description: "Add a new section, a heading and its text, at the end of a page, such as a comment on a talk page. Existing content is left untouched, so it can't be overwritten. Returns the new revision ID and a diff URL, or an edit ID to follow with wiki_pending_edit when edits are held for review",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"title": {
+					"type": "string",
+					"description": "Page to add the section to (e.g. 'Talk:Albert Einstein')"
+				},
+				"heading": {
+					"type": "string",
+					"description": "Heading of the new section, without == markup"
+				},
+				"content": {
+					"type": "string",
+					"description": "Wikitext of the section body"
+				},
+				"summary": {
+					"type": "string",
+					"description": "Edit summary (default: '/* heading */ new section', as the wiki writes it)"
+				},
+				"create_page": {
+					"type": "boolean",
+					"description": "Create the page if it doesn't exist, as when starting a talk page (default: false, which fails with missingtitle)",
+					"default": false
+				},
+				"base_revid": {
+					"type": "integer",
+					"description": "Revision of the page the comment responds to. If the page changed since, the edit fails with edit_conflict, with the current revision in its details. The wiki adds sections whatever changed, so this is checked just before saving, and a change made in between is reported after saving as edit_conflict with the edit in its details"
+				},
+				"dry_run": {
+					"type": "boolean",
+					"description": "Check the section and show it without saving: returns it as a diff and rendered as Markdown (default: false)",
+					"default": false
+				}
+			},
+			"required": ["wiki_url", "title", "heading", "content"]
+		}`),
+	}, s.handleAppendSection)
+
+	// wiki_talk_post
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_talk_post",
+		Description: "Start a new discussion topic on the talk page of any page with the wiki's account, resolving the talk namespace from the title and creating the talk page if needed. The comment is signed. Posts with DiscussionTools where the wiki has it, and as a new section otherwise. Returns the new revision ID and a diff URL, or an edit ID to follow with wiki_pending_edit when edits are held for review",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"title": {
+					"type": "string",
+					"description": "Page to discuss, or its talk page (e.g. 'Albert Einstein' or 'Talk:Albert Einstein')"
+				},
+				"topic": {
+					"type": "string",
+					"description": "Heading of the new topic, without == markup"
+				},
+				"content": {
+					"type": "string",
+					"description": "Wikitext of the comment; a signature (~~~~) is added unless it has one"
+				},
+				"summary": {
+					"type": "string",
+					"description": "Edit summary (default: '/* topic */ new section', as the wiki writes it)"
+				},
+				"base_revid": {
+					"type": "integer",
+					"description": "Revision of the talk page the comment responds to. If the page changed since, the post fails with edit_conflict, with the current revision in its details. The wiki adds topics whatever changed, so this is checked just before posting, and a change made in between is reported after posting as edit_conflict with the post in its details"
+				},
+				"dry_run": {
+					"type": "boolean",
+					"description": "Check the topic and show it without posting: returns it as a diff and rendered as Markdown (default: false)",
+					"default": false
+				}
+			},
+			"required": ["wiki_url", "title", "topic", "content"]
+		}`),
+	}, s.handleTalkPost)
+
+	// wiki_undo
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_undo",
+		Description: "Undo a revision with the wiki's account, as the undo link in a page's history does: the change it made is reverted while later edits stay. Returns the undone revision (page, user, timestamp, summary) and the new revision, or an edit ID when edits are held for review. Fails with undofailure when later edits changed the same lines",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"revision": {
+					"type": "integer",
+					"description": "ID of the revision to undo"
+				},
+				"summary": {
+					"type": "string",
+					"description": "Edit summary (default: 'Undo revision <id> by <user>')"
+				},
+				"base_revid": {
+					"type": "integer",
+					"description": "Revision the page was at when the undo was decided on. If the page changed since, the undo fails with edit_conflict, with the current revision in its details"
+				},
+				"dry_run": {
+					"type": "boolean",
+					"description": "Check the undo and show it without saving: returns the diff it would make (default: false)",
+					"default": false
+				}
+			},
+			"required": ["wiki_url", "revision"]
+		}`),
+	}, s.handleUndo)
+
+	// Moves, deletions, uploads, and rollbacks take effect at once, with no
+	// pending edit for a reviewer to approve, so their tools are left out
+	// when edits are held for review
+	if s.config.EditApproval {
+		return
+	}
+
+	// wiki_move_page
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_move_page",
+		Description: "Rename a page with the wiki's account, optionally leaving a redirect at the old title and moving its talk page along. Returns the old and new titles, whether a redirect was left, and the move log entry. Not available when edits are held for review",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"from": {
+					"type": "string",
+					"description": "Current title of the page"
+				},
+				"to": {
+					"type": "string",
+					"description": "New title; it must not exist yet"
+				},
+				"reason": {
+					"type": "string",
+					"description": "Reason for the move, shown in the move log"
+				},
+				"leave_redirect": {
+					"type": "boolean",
+					"description": "Leave a redirect from the old title to the new one (default: true). Not leaving one needs the suppressredirect right; without it the wiki leaves one anyway, as redirect_created shows",
+					"default": true
+				},
+				"move_talk": {
+					"type": "boolean",
+					"description": "Move the talk page too, if it exists (default: true)",
+					"default": true
+				},
+				"base_revid": {
+					"type": "integer",
+					"description": "Revision the page was at when the move was decided on. If the page changed since, the move fails with edit_conflict, with the current revision in its details. The wiki can't check this itself, so it is checked just before moving, and a change made in between is reported after the move as edit_conflict with the move in its details"
+				},
+				"dry_run": {
+					"type": "boolean",
+					"description": "Check the move without making it: returns the page's current revision, failing as the move would if the new title is taken (default: false)",
+					"default": false
+				}
+			},
+			"required": ["wiki_url", "from", "to", "reason"]
+		}`),
+	}, s.handleMovePage)
+
+	// wiki_delete_page
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_delete_page",
+		Description: "Delete a page with the wiki's account, which needs the delete right (usually administrators). Requires confirm: true. Fails with missing_right, before trying, when the account can't delete. Returns the deletion log entry. Not available when edits are held for review",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"title": {
+					"type": "string",
+					"description": "Page to delete"
+				},
+				"reason": {
+					"type": "string",
+					"description": "Reason for the deletion, shown in the deletion log"
+				},
+				"confirm": {
+					"type": "boolean",
+					"description": "Must be true to delete: the page and its history are removed from view"
+				},
+				"base_revid": {
+					"type": "integer",
+					"description": "Revision the page was at when the deletion was decided on. If the page changed since, the deletion fails with edit_conflict, with the current revision in its details. The wiki can't check this itself, so it is checked just before deleting; a change made in between is deleted with the page, and shows in its deleted history"
+				},
+				"dry_run": {
+					"type": "boolean",
+					"description": "Check the deletion without making it: returns the page's current revision (default: false)",
+					"default": false
+				}
+			},
+			"required": ["wiki_url", "title", "reason", "confirm"]
+		}`),
+	}, s.handleDeletePage)
+
+	// wiki_upload_file
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_upload_file",
+		Description: "Upload an image or document to the wiki with its account, which needs the upload right, from a URL or base64 content. The file page gets the description and license wikitext. Fails with upload_warning when the name is taken or the file is a duplicate, unless ignore_warnings is set. Returns the file's URL, size, and type. Not available when edits are held for review",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"filename": {
+					"type": "string",
+					"description": "Name of the file on the wiki, with its extension (e.g. 'Logo.png'); a File: prefix is optional"
+				},
+				"url": {
+					"type": "string",
+					"description": "Public http(s) URL the server fetches the file from. Give this or content_base64"
+				},
+				"content_base64": {
+					"type": "string",
+					"description": "The file's content, base64-encoded. Give this or url"
+				},
+				"description": {
+					"type": "string",
+					"description": "Wikitext describing the file, for the file page's summary"
+				},
+				"license": {
+					"type": "string",
+					"description": "Wikitext for the file page's licensing section, usually a license template such as '{{cc-by-sa-4.0}}'"
+				},
+				"comment": {
+					"type": "string",
+					"description": "Upload comment, shown in the upload log and file history"
+				},
+				"ignore_warnings": {
+					"type": "boolean",
+					"description": "Upload despite warnings, such as replacing an existing file or duplicating another (default: false)",
+					"default": false
+				},
+				"base_revid": {
+					"type": "integer",
+					"description": "Revision of the existing file page the replacement was decided on. If the page changed since, the upload fails with edit_conflict, with the current revision in its details. The wiki can't check this itself, so it is checked just before uploading, and a change made in between is reported after uploading as edit_conflict with the upload in its details"
+				},
+				"dry_run": {
+					"type": "boolean",
+					"description": "Check and fetch the file without uploading it: returns its size and the file page text rendered as Markdown (default: false)",
+					"default": false
+				}
+			},
+			"required": ["wiki_url", "filename", "comment"]
+		}`),
+	}, s.handleUploadFile)
+
+	// wiki_rollback
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_rollback",
+		Description: "Revert all of a user's latest consecutive edits to a page at once with the wiki's account, which needs the rollback right (usually administrators and rollbackers). Fails with missing_right, before trying, when the account can't roll back, and with alreadyrolled when the latest edit is no longer the user's. Returns the reverted and restored revisions. Not available when edits are held for review",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"title": {
+					"type": "string",
+					"description": "Page to roll back"
+				},
+				"user": {
+					"type": "string",
+					"description": "User (or IP address) whose latest edits to revert; the rollback fails if someone else edited the page last"
+				},
+				"summary": {
+					"type": "string",
+					"description": "Edit summary (default: 'Reverted edits by <user>')"
+				},
+				"base_revid": {
+					"type": "integer",
+					"description": "Revision the page was at when the rollback was decided on. If the page changed since, the rollback fails with edit_conflict, with the current revision in its details. The wiki can't check this itself, so it is checked just before the rollback, and a change made in between is reported after it as edit_conflict with the rollback in its details"
+				},
+				"dry_run": {
+					"type": "boolean",
+					"description": "Check the rollback without making it: returns the revision it would restore and the diff to it (default: false)",
+					"default": false
+				}
+			},
+			"required": ["wiki_url", "title", "user"]
+		}`),
+	}, s.handleRollback)
+}
+
+func (s *Server) handleEditPage(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL      string `json:"wiki_url"`
+		Language     string `json:"language"`
+		Title        string `json:"title"`
+		Content      string `json:"content"`
+		SectionIndex *int   `json:"section_index"`
+		Summary      string `json:"summary"`
+		BaseRevID    int    `json:"base_revid"`
+		DryRun       bool   `json:"dry_run"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	if strings.TrimSpace(args.Summary) == "" {
+		return nil, fmt.Errorf("summary is required")
+	}
+
+	edit := wiki.EditParams{
+		Title:     args.Title,
+		Text:      args.Content,
+		Summary:   args.Summary,
+		BaseRevID: args.BaseRevID,
+	}
+	if args.SectionIndex != nil {
+		if *args.SectionIndex < 0 {
+			return nil, fmt.Errorf("section_index must be 0 or more")
+		}
+		edit.Section = strconv.Itoa(*args.SectionIndex)
+	}
+
+	result, err := s.saveEdit(ctx, req, args.WikiURL, edit, args.DryRun)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleCreatePage(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL  string `json:"wiki_url"`
+		Language string `json:"language"`
+		Title    string `json:"title"`
+		Content  string `json:"content"`
+		Summary  string `json:"summary"`
+		DryRun   bool   `json:"dry_run"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	if strings.TrimSpace(args.Summary) == "" {
+		return nil, fmt.Errorf("summary is required")
+	}
+
+	result, err := s.saveEdit(ctx, req, args.WikiURL, wiki.EditParams{
+		Title:      args.Title,
+		Text:       args.Content,
+		Summary:    args.Summary,
+		CreateOnly: true,
+	}, args.DryRun)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleAppendSection(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL    string `json:"wiki_url"`
+		Language   string `json:"language"`
+		Title      string `json:"title"`
+		Heading    string `json:"heading"`
+		Content    string `json:"content"`
+		Summary    string `json:"summary"`
+		CreatePage bool   `json:"create_page"`
+		BaseRevID  int    `json:"base_revid"`
+		DryRun     bool   `json:"dry_run"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	args.Heading = strings.TrimSpace(strings.Trim(strings.TrimSpace(args.Heading), "="))
+	if args.Heading == "" {
+		return nil, fmt.Errorf("heading is required")
+	}
+	if args.Summary == "" {
+		args.Summary = "/* " + args.Heading + " */ new section"
+	}
+
+	result, err := s.saveEdit(ctx, req, args.WikiURL, wiki.EditParams{
+		Title:        args.Title,
+		Text:         args.Content,
+		Section:      "new",
+		SectionTitle: args.Heading,
+		Summary:      args.Summary,
+		NoCreate:     !args.CreatePage,
+		BaseRevID:    args.BaseRevID,
+	}, args.DryRun)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleTalkPost(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL   string `json:"wiki_url"`
+		Language  string `json:"language"`
+		Title     string `json:"title"`
+		Topic     string `json:"topic"`
+		Content   string `json:"content"`
+		Summary   string `json:"summary"`
+		BaseRevID int    `json:"base_revid"`
+		DryRun    bool   `json:"dry_run"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	args.Topic = strings.TrimSpace(strings.Trim(strings.TrimSpace(args.Topic), "="))
+	if args.Topic == "" {
+		return nil, fmt.Errorf("topic is required")
+	}
+	content := strings.TrimSpace(args.Content)
+	if content == "" {
+		return nil, fmt.Errorf("content is required")
+	}
+	if !strings.Contains(content, "~~~") {
+		content += " ~~~~"
+	}
+	if args.Summary == "" {
+		args.Summary = "/* " + args.Topic + " */ new section"
+	}
+
+	talk, _, err := tools.TalkPages(ctx, s.client, args.WikiURL, args.Title)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+	// Without DiscussionTools, or its capabilities, the topic is an
+	// ordinary new section
+	caps, err := s.client.GetCapabilities(ctx, args.WikiURL)
+	topic := err == nil && caps.HasExtension("DiscussionTools")
+
+	result, err := s.saveEdit(ctx, req, args.WikiURL, wiki.EditParams{
+		Title:        talk,
+		Text:         content,
+		Section:      "new",
+		SectionTitle: args.Topic,
+		Summary:      args.Summary,
+		BaseRevID:    args.BaseRevID,
+		Topic:        topic,
+	}, args.DryRun)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleMovePage(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL       string `json:"wiki_url"`
+		Language      string `json:"language"`
+		From          string `json:"from"`
+		To            string `json:"to"`
+		Reason        string `json:"reason"`
+		LeaveRedirect *bool  `json:"leave_redirect"`
+		MoveTalk      *bool  `json:"move_talk"`
+		BaseRevID     int    `json:"base_revid"`
+		DryRun        bool   `json:"dry_run"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	if strings.TrimSpace(args.Reason) == "" {
+		return nil, fmt.Errorf("reason is required")
+	}
+
+	// Both titles must be writable: the move empties one and fills the other
+	for _, title := range []string{args.From, args.To} {
+		if err := tools.CheckWriteAllowed(title, s.config.WriteAllowPrefixes); err != nil {
+			return s.errorResult(req, err, args.Language), nil
+		}
+	}
+	if err := s.requireCredentials(args.WikiURL); err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+	if args.BaseRevID > 0 {
+		if err := s.client.CheckBaseRevision(ctx, args.WikiURL, args.From, args.BaseRevID); err != nil {
+			return s.errorResult(req, err, args.Language), nil
+		}
+	}
+
+	attr := s.editAttribution(ctx, req)
+	reason := tools.FormatEditSummary(s.config.EditSummaryTemplate, args.Reason, attr)
+	if args.DryRun {
+		current, err := s.client.LatestRevision(ctx, args.WikiURL, args.From)
+		if err != nil {
+			return s.errorResult(req, err, args.Language), nil
+		}
+		if err := tools.CheckPageMissing(ctx, s.client, args.WikiURL, args.To); err != nil {
+			return s.errorResult(req, err, args.Language), nil
+		}
+		return s.successResult(&DryRunResult{
+			DryRun:          true,
+			Action:          "move",
+			Title:           current.Title,
+			Target:          args.To,
+			Summary:         reason,
+			CurrentRevision: current,
+		})
+	}
+
+	result, err := s.client.Move(ctx, args.WikiURL, wiki.MoveParams{
+		From:       args.From,
+		To:         args.To,
+		Reason:     reason,
+		NoRedirect: args.LeaveRedirect != nil && !*args.LeaveRedirect,
+		MoveTalk:   args.MoveTalk == nil || *args.MoveTalk,
+	})
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+	if args.BaseRevID > 0 {
+		// The move adds a revision to the page at its new title, on top of
+		// the one it was moved at
+		if moved, err := s.client.LatestRevision(ctx, args.WikiURL, result.To); err != nil {
+			wiki.AddWarning(ctx, "base_revid", err)
+		} else if err := s.client.SavedOnBase(ctx, args.WikiURL, args.From, args.BaseRevID, moved.ParentID, result); err != nil {
+			return s.errorResult(req, err, args.Language), nil
+		}
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleDeletePage(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL   string `json:"wiki_url"`
+		Language  string `json:"language"`
+		Title     string `json:"title"`
+		Reason    string `json:"reason"`
+		Confirm   bool   `json:"confirm"`
+		BaseRevID int    `json:"base_revid"`
+		DryRun    bool   `json:"dry_run"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	if !args.Confirm {
+		return nil, fmt.Errorf("confirm must be true to delete a page")
+	}
+	if strings.TrimSpace(args.Reason) == "" {
+		return nil, fmt.Errorf("reason is required")
+	}
+
+	if err := tools.CheckWriteAllowed(args.Title, s.config.WriteAllowPrefixes); err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+	if err := s.requireCredentials(args.WikiURL); err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+	// Checked first, so an account without the right gets a clear error
+	// instead of the wiki's permissiondenied
+	if err := s.client.RequireRight(ctx, args.WikiURL, "delete", "delete pages"); err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+	if args.BaseRevID > 0 {
+		if err := s.client.CheckBaseRevision(ctx, args.WikiURL, args.Title, args.BaseRevID); err != nil {
+			return s.errorResult(req, err, args.Language), nil
+		}
+	}
+
+	attr := s.editAttribution(ctx, req)
+	reason := tools.FormatEditSummary(s.config.EditSummaryTemplate, args.Reason, attr)
+	if args.DryRun {
+		current, err := s.client.LatestRevision(ctx, args.WikiURL, args.Title)
+		if err != nil {
+			return s.errorResult(req, err, args.Language), nil
+		}
+		return s.successResult(&DryRunResult{
+			DryRun:          true,
+			Action:          "delete",
+			Title:           current.Title,
+			Summary:         reason,
+			CurrentRevision: current,
+		})
+	}
+
+	result, err := s.client.Delete(ctx, args.WikiURL, args.Title, reason)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleUploadFile(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL        string `json:"wiki_url"`
+		Language       string `json:"language"`
+		Filename       string `json:"filename"`
+		URL            string `json:"url"`
+		ContentBase64  string `json:"content_base64"`
+		Description    string `json:"description"`
+		License        string `json:"license"`
+		Comment        string `json:"comment"`
+		IgnoreWarnings bool   `json:"ignore_warnings"`
+		BaseRevID      int    `json:"base_revid"`
+		DryRun         bool   `json:"dry_run"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	filename := strings.TrimSpace(args.Filename)
+	for _, prefix := range []string{"File:", "Image:"} {
+		if len(filename) > len(prefix) && strings.EqualFold(filename[:len(prefix)], prefix) {
+			filename = strings.TrimSpace(filename[len(prefix):])
+		}
+	}
+	if filename == "" {
+		return nil, fmt.Errorf("filename is required")
+	}
+	if (args.URL == "") == (args.ContentBase64 == "") {
+		return nil, fmt.Errorf("give exactly one of url and content_base64")
+	}
+	if strings.TrimSpace(args.Comment) == "" {
+		return nil, fmt.Errorf("comment is required")
+	}
+
+	if err := tools.CheckWriteAllowed("File:"+filename, s.config.WriteAllowPrefixes); err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+	if err := s.requireCredentials(args.WikiURL); err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+	if err := s.client.RequireRight(ctx, args.WikiURL, "upload", "upload files"); err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+	title := "File:" + filename
+	if args.BaseRevID > 0 {
+		if err := s.client.CheckBaseRevision(ctx, args.WikiURL, title, args.BaseRevID); err != nil {
+			return s.errorResult(req, err, args.Language), nil
+		}
+	}
+
+	maxBytes := int64(s.config.MaxUploadBytes)
+	var data []byte
+	if args.URL != "" {
+		fetched, err := s.client.FetchFile(ctx, args.URL, maxBytes)
+		if err != nil {
+			return s.errorResult(req, err, args.Language), nil
+		}
+		data = fetched
+	} else {
+		// Checked before decoding, which the encoded length bounds
+		if int64(base64.StdEncoding.DecodedLen(len(args.ContentBase64))) > maxBytes+2 {
+			return s.errorResult(req, &wiki.FileTooLargeError{Source: "content_base64", MaxBytes: maxBytes}, args.Language), nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(args.ContentBase64)
+		if err != nil {
+			return nil, fmt.Errorf("content_base64: %w", err)
+		}
+		if int64(len(decoded)) > maxBytes {
+			return s.errorResult(req, &wiki.FileTooLargeError{Source: "content_base64", MaxBytes: maxBytes}, args.Language), nil
+		}
+		data = decoded
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("the file is empty")
+	}
+
+	attr := s.editAttribution(ctx, req)
+	comment := tools.FormatEditSummary(s.config.EditSummaryTemplate, args.Comment, attr)
+	if args.DryRun {
+		dryRun := &DryRunResult{DryRun: true, Action: "upload", Title: title, Summary: comment, FileSize: len(data)}
+		// An existing file page means the upload replaces a file, which
+		// the wiki warns about
+		current, err := s.client.LatestRevision(ctx, args.WikiURL, title)
+		var apiErr *wiki.APIError
+		if err != nil && !(errors.As(err, &apiErr) && apiErr.Code == "missingtitle") {
+			return s.errorResult(req, err, args.Language), nil
+		}
+		dryRun.CurrentRevision = current
+		if preview, err := tools.PreviewWikitext(ctx, s.client, args.WikiURL, title, wiki.UploadPageText(args.Description, args.License)); err != nil {
+			wiki.AddWarning(ctx, "preview", err)
+		} else {
+			dryRun.Preview = preview
+		}
+		return s.successResult(dryRun)
+	}
+
+	result, err := s.client.Upload(ctx, args.WikiURL, wiki.UploadParams{
+		Filename:       filename,
+		Data:           data,
+		Description:    args.Description,
+		License:        args.License,
+		Comment:        comment,
+		IgnoreWarnings: args.IgnoreWarnings,
+	})
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+	if args.BaseRevID > 0 {
+		// Replacing a file adds a revision to its file page, on top of the
+		// one it replaced
+		if latest, err := s.client.LatestRevision(ctx, args.WikiURL, result.Title); err != nil {
+			wiki.AddWarning(ctx, "base_revid", err)
+		} else if err := s.client.SavedOnBase(ctx, args.WikiURL, result.Title, args.BaseRevID, latest.ParentID, result); err != nil {
+			return s.errorResult(req, err, args.Language), nil
+		}
+	}
+
+	return s.successResult(result)
+}
+
+// UndoResponse is the result of wiki_undo: the revision undone and the edit
+// that undid it
+type UndoResponse struct {
+	Undone wiki.RevisionDetails `json:"undone"`
+	Result interface{}          `json:"result"` // a *wiki.EditResult, an *approval.Edit when held for review, or a *DryRunResult
+}
+
+func (s *Server) handleUndo(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL   string `json:"wiki_url"`
+		Language  string `json:"language"`
+		Revision  int    `json:"revision"`
+		Summary   string `json:"summary"`
+		BaseRevID int    `json:"base_revid"`
+		DryRun    bool   `json:"dry_run"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	if args.Revision <= 0 {
+		return nil, fmt.Errorf("revision is required")
+	}
+
+	revisions, err := s.client.GetRevisions(ctx, args.WikiURL, args.Revision)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+	undone := revisions[0]
+	if undone.ParentID == 0 {
+		return nil, fmt.Errorf("revision %d created %q and can't be undone; delete the page instead", undone.ID, undone.Title)
+	}
+
+	summary := args.Summary
+	if strings.TrimSpace(summary) == "" {
+		summary = fmt.Sprintf("Undo revision %d", undone.ID)
+		if undone.User != "" {
+			summary += fmt.Sprintf(" by [[Special:Contributions/%s|%s]]", undone.User, undone.User)
+		}
+	}
+
+	result, err := s.saveEdit(ctx, req, args.WikiURL, wiki.EditParams{
+		Title:     undone.Title,
+		Summary:   summary,
+		NoCreate:  true,
+		BaseRevID: args.BaseRevID,
+		Undo:      undone.ID,
+		UndoAfter: undone.ParentID,
+	}, args.DryRun)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(&UndoResponse{Undone: undone, Result: result})
+}
+
+func (s *Server) handleRollback(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL   string `json:"wiki_url"`
+		Language  string `json:"language"`
+		Title     string `json:"title"`
+		User      string `json:"user"`
+		Summary   string `json:"summary"`
+		BaseRevID int    `json:"base_revid"`
+		DryRun    bool   `json:"dry_run"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	if strings.TrimSpace(args.User) == "" {
+		return nil, fmt.Errorf("user is required")
+	}
+
+	if err := tools.CheckWriteAllowed(args.Title, s.config.WriteAllowPrefixes); err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+	if err := s.requireCredentials(args.WikiURL); err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+	// Checked first, so an account without the right gets a clear error
+	// instead of the wiki's permissiondenied
+	if err := s.client.RequireRight(ctx, args.WikiURL, "rollback", "roll back edits"); err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+	if args.BaseRevID > 0 {
+		if err := s.client.CheckBaseRevision(ctx, args.WikiURL, args.Title, args.BaseRevID); err != nil {
+			return s.errorResult(req, err, args.Language), nil
+		}
+	}
+	bot, err := tools.BotFlag(ctx, s.client, args.WikiURL, s.config.EditBot)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	summary := args.Summary
+	if strings.TrimSpace(summary) == "" {
+		summary = fmt.Sprintf("Reverted edits by [[Special:Contributions/%s|%s]]", args.User, args.User)
+	}
+	attr := s.editAttribution(ctx, req)
+	summary = tools.FormatEditSummary(s.config.EditSummaryTemplate, summary, attr)
+	if args.DryRun {
+		reverted, restored, err := s.client.RollbackTarget(ctx, args.WikiURL, args.Title, args.User)
+		if err != nil {
+			return s.errorResult(req, err, args.Language), nil
+		}
+		dryRun := &DryRunResult{
+			DryRun:           true,
+			Action:           "rollback",
+			Title:            reverted.Title,
+			Summary:          summary,
+			CurrentRevision:  reverted,
+			RestoredRevision: restored,
+		}
+		// A rollback is an undo of the user's edits all at once, which
+		// leaves nothing of them
+		undo := wiki.EditParams{Title: reverted.Title, Undo: reverted.ID, UndoAfter: restored.ID}
+		if diff, err := tools.DiffProposedEdit(ctx, s.client, args.WikiURL, undo); err != nil {
+			wiki.AddWarning(ctx, "diff", err)
+		} else {
+			dryRun.Diff = diff
+		}
+		return s.successResult(dryRun)
+	}
+
+	result, err := s.client.Rollback(ctx, args.WikiURL, args.Title, args.User, summary, bot)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+	if args.BaseRevID > 0 {
+		if err := s.client.SavedOnBase(ctx, args.WikiURL, args.Title, args.BaseRevID, result.OldRevID, result); err != nil {
+			return s.errorResult(req, err, args.Language), nil
+		}
+	}
+
+	return s.successResult(result)
+}
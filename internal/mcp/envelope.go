@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// APIVersion is the version of the tool result format. It changes when a
+// change to a result could break existing clients.
+const APIVersion = "1"
+
+// Envelope wraps every successful tool result
+type Envelope struct {
+	APIVersion string           `json:"api_version"`
+	Data       json.RawMessage  `json:"data"`
+	Warnings   []wiki.Warning   `json:"warnings"` // optional parts left out because fetching them failed
+	Cache      wiki.CacheStatus `json:"cache"`
+	Meta       CallMeta         `json:"meta"`
+
+	SuggestedNextCalls []SuggestedCall `json:"suggested_next_calls,omitempty"`
+}
+
+// CallMeta describes how a tool call was served, to explain slow calls
+type CallMeta struct {
+	CacheHit      bool  `json:"cache_hit"`      // the whole result came from the result cache
+	UpstreamCalls int   `json:"upstream_calls"` // HTTP requests made to wikis, including retries
+	LatencyMS     int64 `json:"latency_ms"`     // time spent on the call in the server
+}
+
+// enveloped wraps successful results in an Envelope with the warnings and
+// cache status collected while the call ran (track sets up the cache status
+// so it can count hits too), how the call was served, and the calls
+// suggested next. Error results carry the API version and call metadata in
+// the error response instead.
+func (s *Server) enveloped(handler mcp.ToolHandler) mcp.ToolHandler {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := time.Now()
+		ctx = wiki.WithWarnings(wiki.WithUpstreamCalls(ctx))
+		result, err := handler(ctx, req)
+		if err != nil || result == nil || len(result.Content) != 1 {
+			return result, err
+		}
+		text, ok := result.Content[0].(*mcp.TextContent)
+		if !ok {
+			return result, nil
+		}
+		meta := CallMeta{
+			CacheHit:      wiki.CacheStatusFrom(ctx).Hit,
+			UpstreamCalls: wiki.UpstreamCalls(ctx),
+			LatencyMS:     time.Since(start).Milliseconds(),
+		}
+
+		if result.IsError {
+			var errResp ErrorResponse
+			if json.Unmarshal([]byte(text.Text), &errResp) != nil || errResp.Error == "" {
+				return result, nil
+			}
+			errResp.Meta = &meta
+			data, err := json.Marshal(errResp)
+			if err != nil {
+				return nil, err
+			}
+			text.Text = string(data)
+			return result, nil
+		}
+
+		// Suggestions only draw on calls of the same session, and calls
+		// without one have none to draw on
+		var history []callRecord
+		if session := s.historyKey(req); session != "" {
+			history = s.history.recent(session, time.Now())
+		}
+		data, err := json.Marshal(Envelope{
+			APIVersion: APIVersion,
+			Data:       json.RawMessage(text.Text),
+			Warnings:   wiki.Warnings(ctx),
+			Cache:      wiki.CacheStatusFrom(ctx),
+			Meta:       meta,
+
+			SuggestedNextCalls: suggestNextCalls(req.Params.Name, req.Params.Arguments, json.RawMessage(text.Text), history),
+		})
+		if err != nil {
+			return nil, err
+		}
+		text.Text = string(data)
+		return result, nil
+	}
+}
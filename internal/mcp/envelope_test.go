@@ -0,0 +1,180 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/yourusername/mediawiki-mcp/config"
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+func textResult(text string, isError bool) *mcp.CallToolResult {
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: text}}, IsError: isError}
+}
+
+func resultText(t *testing.T, result *mcp.CallToolResult) string {
+	t.Helper()
+	if result == nil || len(result.Content) != 1 {
+		t.Fatalf("result = %#v, want one content", result)
+	}
+	text, ok := result.Content[0].(*mcp.TextContent)
+	if !ok {
+		t.Fatalf("content = %#v, want text", result.Content[0])
+	}
+	return text.Text
+}
+
+func TestEnvelopedSuccess(t *testing.T) {
+	s := &Server{config: &config.Config{}, history: newCallHistory()}
+	handler := s.enveloped(func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		wiki.RecordCacheLookup(ctx, true, 90*time.Second)
+		wiki.AddWarning(ctx, "coordinates", errors.New("timeout "))
+		return textResult(`{"results":[{"title":"Town"}]}`, false), nil
+	})
+
+	ctx := wiki.WithCacheStatus(context.Background())
+	result, err := handler(ctx, toolRequest("wiki_search", `{"wiki_url":"https://wiki.example.org","query":"town"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var env Envelope
+	if err := json.Unmarshal([]byte(resultText(t, result)), &env); err != nil {
+		t.Fatal(err)
+	}
+
+	if env.APIVersion != APIVersion || string(env.Data) != `{"results":[{"title":"Town"}]}` {
+		t.Errorf("api_version %q, data %s", env.APIVersion, env.Data)
+	}
+	if len(env.Warnings) != 1 || env.Warnings[0].Enrichment != "coordinates" || env.Warnings[0].Reason != "timeout" {
+		t.Errorf("warnings = %+v", env.Warnings)
+	}
+	if !env.Cache.Hit || env.Cache.Age != 90 || !env.Meta.CacheHit || env.Meta.UpstreamCalls != 0 {
+		t.Errorf("cache = %+v, meta = %+v, want a hit 90s old", env.Cache, env.Meta)
+	}
+	if len(env.SuggestedNextCalls) != 1 || env.SuggestedNextCalls[0].Tool != "wiki_page_outline" ||
+		env.SuggestedNextCalls[0].Arguments["title"] != "Town" || env.SuggestedNextCalls[0].Arguments["relevance_query"] != "town" {
+		t.Errorf("suggested_next_calls = %+v, want the outline of Town", env.SuggestedNextCalls)
+	}
+}
+
+func TestEnvelopedEmptyParts(t *testing.T) {
+	s := &Server{config: &config.Config{}, history: newCallHistory()}
+	handler := s.enveloped(func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return textResult(`{"title":"Town"}`, false), nil
+	})
+
+	result, err := handler(context.Background(), toolRequest("wiki_page_full", `{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var env map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(resultText(t, result)), &env); err != nil {
+		t.Fatal(err)
+	}
+	// Clients can rely on warnings being a list and cache being set
+	if string(env["warnings"]) != `[]` || string(env["cache"]) != `{"hit":false,"age":0}` {
+		t.Errorf("warnings = %s, cache = %s", env["warnings"], env["cache"])
+	}
+	if _, ok := env["suggested_next_calls"]; ok {
+		t.Errorf("suggested_next_calls = %s, want it left out", env["suggested_next_calls"])
+	}
+}
+
+func TestEnvelopedErrors(t *testing.T) {
+	s := &Server{config: &config.Config{}, history: newCallHistory()}
+	handlerErr := errors.New("bad arguments")
+	tests := []struct {
+		name   string
+		result *mcp.CallToolResult
+		err    error
+		check  func(t *testing.T, result *mcp.CallToolResult, err error)
+	}{
+		{
+			name:   "error response",
+			result: textResult(`{"api_version":"1","error":"page_not_found","message":"missing"}`, true),
+			check: func(t *testing.T, result *mcp.CallToolResult, err error) {
+				var resp ErrorResponse
+				if err := json.Unmarshal([]byte(resultText(t, result)), &resp); err != nil {
+					t.Fatal(err)
+				}
+				if !result.IsError || resp.Error != "page_not_found" || resp.APIVersion != APIVersion || resp.Meta == nil {
+					t.Errorf("result = %s, want the error with meta and not wrapped", resultText(t, result))
+				}
+			},
+		},
+		{
+			name:   "other error text",
+			result: textResult("something failed", true),
+			check: func(t *testing.T, result *mcp.CallToolResult, err error) {
+				if got := resultText(t, result); got != "something failed" {
+					t.Errorf("result = %q, want it unchanged", got)
+				}
+			},
+		},
+		{
+			name: "handler error",
+			err:  handlerErr,
+			check: func(t *testing.T, result *mcp.CallToolResult, err error) {
+				if result != nil || !errors.Is(err, handlerErr) {
+					t.Errorf("result = %#v, err = %v, want the handler's error", result, err)
+				}
+			},
+		},
+		{
+			name: "several contents",
+			result: &mcp.CallToolResult{Content: []mcp.Content{
+				&mcp.TextContent{Text: `{"a":1}`}, &mcp.TextContent{Text: `{"b":2}`},
+			}},
+			check: func(t *testing.T, result *mcp.CallToolResult, err error) {
+				if err != nil || result.Content[0].(*mcp.TextContent).Text != `{"a":1}` {
+					t.Errorf("result = %#v, %v, want it unchanged", result, err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := s.enveloped(func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return tt.result, tt.err
+			})
+			result, err := handler(context.Background(), toolRequest("wiki_page_full", `{}`))
+			tt.check(t, result, err)
+		})
+	}
+}
+
+func TestEnvelopedSuggestionsWithoutSession(t *testing.T) {
+	s := &Server{config: &config.Config{}, history: newCallHistory()}
+	// Another client's search, as a shared history would have it
+	s.history.record("", callRecord{Tool: "wiki_search", WikiURL: "https://wiki.example.org", Query: "secret plans", At: time.Now()})
+
+	outline := `{"title":"Town","sections":[{"index":1,"title":"Secret plans","preview":"Plans","relevance":0,"subsections":[]},{"index":2,"title":"History","preview":"Old","relevance":1,"subsections":[]}]}`
+	handler := s.enveloped(func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return textResult(outline, false), nil
+	})
+	suggested := func(args string) []SuggestedCall {
+		t.Helper()
+		result, err := handler(context.Background(), toolRequest("wiki_page_outline", args))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var env Envelope
+		if err := json.Unmarshal([]byte(resultText(t, result)), &env); err != nil {
+			t.Fatal(err)
+		}
+		return env.SuggestedNextCalls
+	}
+
+	if calls := suggested(`{"wiki_url":"https://wiki.example.org","title":"Town"}`); len(calls) != 0 {
+		t.Errorf("suggested_next_calls = %+v, want none without a query of its own", calls)
+	}
+	calls := suggested(`{"wiki_url":"https://wiki.example.org","title":"Town","query":"history"}`)
+	if len(calls) != 1 || calls[0].Arguments["section_index"] != float64(2) {
+		t.Errorf("suggested_next_calls = %+v, want the History section", calls)
+	}
+}
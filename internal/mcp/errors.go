@@ -1,66 +1,381 @@
 package mcp
 
 import (
+	"errors"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/approval"
+	"github.com/yourusername/mediawiki-mcp/internal/artifacts"
+	"github.com/yourusername/mediawiki-mcp/internal/jobs"
 	"github.com/yourusername/mediawiki-mcp/internal/tools"
 	"github.com/yourusername/mediawiki-mcp/internal/wiki"
 )
 
 // ErrorResponse represents a structured error response for MCP
 type ErrorResponse struct {
-	Error   string                 `json:"error"`
-	Message string                 `json:"message"`
-	Hint    string                 `json:"hint,omitempty"`
-	Details map[string]interface{} `json:"details,omitempty"`
+	APIVersion string                 `json:"api_version,omitempty"` // set on tool results
+	Error      string                 `json:"error"`
+	Message    string                 `json:"message"`
+	Hint       string                 `json:"hint,omitempty"`
+	NextSteps  []string               `json:"next_steps,omitempty"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+	Meta       *CallMeta              `json:"meta,omitempty"` // set on tool results
 }
 
-// FormatError converts various error types to structured ErrorResponse
-func FormatError(err error) *ErrorResponse {
+// FormatError converts various error types to structured ErrorResponse,
+// localizing hints into lang where a translation exists
+func FormatError(err error, lang string) *ErrorResponse {
 	if err == nil {
 		return nil
 	}
 
-	// Handle specific error types
-	switch e := err.(type) {
-	case *wiki.APIError:
-		return formatAPIError(e)
-	case *tools.SectionNotFoundError:
-		return formatSectionNotFoundError(e)
-	default:
+	// Handle specific error types (tools wrap them with context)
+	var apiErr *wiki.APIError
+	if errors.As(err, &apiErr) {
+		return formatAPIError(apiErr, lang)
+	}
+
+	var sectionErr *tools.SectionNotFoundError
+	if errors.As(err, &sectionErr) {
+		return formatSectionNotFoundError(sectionErr, lang)
+	}
+
+	var tooLargeErr *tools.PageTooLargeError
+	if errors.As(err, &tooLargeErr) {
+		return formatPageTooLargeError(tooLargeErr, lang)
+	}
+
+	var responseErr *wiki.ResponseTooLargeError
+	if errors.As(err, &responseErr) {
+		return &ErrorResponse{
+			Error:   "response_too_large",
+			Message: responseErr.Error(),
+			Hint:    localizedHint(hintResponseTooLarge, lang),
+			Details: map[string]interface{}{
+				"max_bytes": responseErr.MaxBytes,
+			},
+		}
+	}
+
+	var complexErr *wiki.PageTooComplexError
+	if errors.As(err, &complexErr) {
+		return &ErrorResponse{
+			Error:   "page_too_complex",
+			Message: complexErr.Error(),
+			Hint:    localizedHint(hintPageTooComplex, lang),
+			Details: map[string]interface{}{
+				"max_nodes": complexErr.MaxNodes,
+				"max_depth": complexErr.MaxDepth,
+			},
+		}
+	}
+
+	var forbiddenErr *tools.WriteForbiddenError
+	if errors.As(err, &forbiddenErr) {
+		return &ErrorResponse{
+			Error:   "namespace_write_forbidden",
+			Message: forbiddenErr.Error(),
+			Hint:    localizedHint(hintNamespaceWriteForbidden, lang),
+			Details: map[string]interface{}{
+				"title":            forbiddenErr.Title,
+				"allowed_prefixes": forbiddenErr.AllowedPrefixes,
+			},
+		}
+	}
+
+	var existsErr *wiki.PageExistsError
+	if errors.As(err, &existsErr) {
+		details := map[string]interface{}{
+			"title":    existsErr.Title,
+			"redirect": existsErr.Redirect,
+		}
+		if existsErr.PageID > 0 {
+			details["page_id"] = existsErr.PageID
+		}
+		return &ErrorResponse{
+			Error:   "page_exists",
+			Message: existsErr.Error(),
+			Hint:    localizedHint(hintPageExists, lang),
+			Details: details,
+		}
+	}
+
+	var credentialsErr *wiki.NoCredentialsError
+	if errors.As(err, &credentialsErr) {
+		details := map[string]interface{}{
+			"wiki_url": credentialsErr.WikiURL,
+		}
+		if credentialsErr.Profile != "" {
+			details["profile"] = credentialsErr.Profile
+		}
+		return &ErrorResponse{
+			Error:   "no_credentials_for_wiki",
+			Message: credentialsErr.Error(),
+			Hint:    localizedHint(hintNoCredentialsForWiki, lang),
+			Details: details,
+		}
+	}
+
+	var rightErr *wiki.MissingRightError
+	if errors.As(err, &rightErr) {
+		return &ErrorResponse{
+			Error:   "missing_right",
+			Message: rightErr.Error(),
+			Hint:    localizedHint(hintMissingRight, lang),
+			Details: map[string]interface{}{
+				"wiki_url": rightErr.WikiURL,
+				"right":    rightErr.Right,
+			},
+		}
+	}
+
+	var conflictErr *wiki.EditConflictError
+	if errors.As(err, &conflictErr) {
+		details := map[string]interface{}{
+			"title":      conflictErr.Title,
+			"base_revid": conflictErr.BaseRevID,
+		}
+		if conflictErr.Current != nil {
+			details["current_revision"] = conflictErr.Current
+		}
+		hint := hintEditConflict
+		if conflictErr.Saved != nil {
+			details["saved"] = conflictErr.Saved
+			hint = hintEditConflictSaved
+		}
+		return &ErrorResponse{
+			Error:   "edit_conflict",
+			Message: conflictErr.Error(),
+			Hint:    localizedHint(hint, lang),
+			Details: details,
+		}
+	}
+
+	var uploadErr *wiki.UploadWarningError
+	if errors.As(err, &uploadErr) {
+		return &ErrorResponse{
+			Error:   "upload_warning",
+			Message: uploadErr.Error(),
+			Hint:    localizedHint(hintUploadWarning, lang),
+			Details: map[string]interface{}{
+				"filename": uploadErr.Filename,
+				"warnings": uploadErr.Warnings,
+			},
+		}
+	}
+
+	var fileErr *wiki.FileTooLargeError
+	if errors.As(err, &fileErr) {
+		return &ErrorResponse{
+			Error:   "file_too_large",
+			Message: fileErr.Error(),
+			Hint:    localizedHint(hintFileTooLarge, lang),
+			Details: map[string]interface{}{
+				"max_bytes": fileErr.MaxBytes,
+			},
+		}
+	}
+
+	var languageErr *tools.LanguageVersionNotFoundError
+	if errors.As(err, &languageErr) {
+		return &ErrorResponse{
+			Error:   "language_version_not_found",
+			Message: languageErr.Error(),
+			Hint:    localizedHint(hintLanguageVersionNotFound, lang),
+			Details: map[string]interface{}{
+				"title":               languageErr.Title,
+				"available_languages": languageErr.Available,
+			},
+		}
+	}
+
+	var snippetErr *tools.SnippetNotFoundError
+	if errors.As(err, &snippetErr) {
+		return &ErrorResponse{
+			Error:   "snippet_not_found",
+			Message: snippetErr.Error(),
+			Hint:    localizedHint(hintSnippetNotFound, lang),
+			Details: map[string]interface{}{
+				"title": snippetErr.Title,
+			},
+		}
+	}
+
+	var unsupportedErr *tools.FeatureUnsupportedError
+	if errors.As(err, &unsupportedErr) {
+		return &ErrorResponse{
+			Error:   "feature_unsupported",
+			Message: unsupportedErr.Error(),
+			Hint:    localizedHint(hintFeatureUnsupported, lang),
+			Details: map[string]interface{}{
+				"feature": unsupportedErr.Feature,
+			},
+		}
+	}
+
+	var backendErr *wiki.BackendUnsupportedError
+	if errors.As(err, &backendErr) {
+		return &ErrorResponse{
+			Error:   "feature_unsupported",
+			Message: backendErr.Error(),
+			Hint:    localizedHint(hintFeatureUnsupported, lang),
+			Details: map[string]interface{}{
+				"feature": backendErr.Request,
+				"backend": backendErr.Backend,
+			},
+		}
+	}
+
+	if errors.Is(err, approval.ErrEditNotFound) {
+		return &ErrorResponse{
+			Error:   "edit_not_found",
+			Message: err.Error(),
+			Hint:    localizedHint(hintEditNotFound, lang),
+		}
+	}
+
+	if errors.Is(err, approval.ErrNotPending) {
+		return &ErrorResponse{
+			Error:   "edit_not_pending",
+			Message: err.Error(),
+		}
+	}
+
+	if errors.Is(err, jobs.ErrJobNotFound) {
 		return &ErrorResponse{
-			Error:   "internal_error",
+			Error:   "job_not_found",
 			Message: err.Error(),
+			Hint:    localizedHint(hintJobNotFound, lang),
 		}
 	}
+
+	if errors.Is(err, artifacts.ErrNotFound) {
+		return &ErrorResponse{
+			Error:   "artifact_not_found",
+			Message: err.Error(),
+			Hint:    localizedHint(hintArtifactNotFound, lang),
+		}
+	}
+
+	return &ErrorResponse{
+		Error:   "internal_error",
+		Message: err.Error(),
+	}
 }
 
-func formatAPIError(err *wiki.APIError) *ErrorResponse {
+func formatAPIError(err *wiki.APIError, lang string) *ErrorResponse {
 	resp := &ErrorResponse{
 		Error:   err.Code,
 		Message: err.Message,
 	}
 
+	// Write-protection errors carry structured details
+	if formatWriteProtectionError(resp, err, lang) {
+		return resp
+	}
+
 	// Add helpful hints based on error code
 	switch err.Code {
 	case "missingtitle":
-		resp.Hint = "The page doesn't exist. Try using wiki_search to find the correct title."
+		resp.Hint = localizedHint(hintMissingTitle, lang)
 	case "nosuchsection":
-		resp.Hint = "The section doesn't exist. Call wiki_page_outline to get fresh section indices."
+		resp.Hint = localizedHint(hintNoSuchSection, lang)
 	case "maxlag":
-		resp.Hint = "The wiki server is experiencing high load. Wait a moment and try again."
+		resp.Hint = localizedHint(hintMaxLag, lang)
+	case "editconflict":
+		resp.Hint = localizedHint(hintEditConflict, lang)
+	case "alreadyrolled":
+		resp.Hint = localizedHint(hintAlreadyRolled, lang)
+	case "onlyauthor":
+		resp.Hint = localizedHint(hintOnlyAuthor, lang)
+	case "undofailure":
+		resp.Hint = localizedHint(hintUndoFailure, lang)
 	}
 
 	return resp
 }
 
-func formatSectionNotFoundError(err *tools.SectionNotFoundError) *ErrorResponse {
+// formatWriteProtectionError fills in details for AbuseFilter hits, spam
+// blacklist matches, and blocks, reporting whether the error was one of them
+func formatWriteProtectionError(resp *ErrorResponse, err *wiki.APIError, lang string) bool {
+	switch {
+	case err.AbuseFilter != nil || strings.HasPrefix(err.Code, "abusefilter-"):
+		warningOnly := err.Code == "abusefilter-warning"
+		resp.Details = map[string]interface{}{
+			"retry_possible":     warningOnly,
+			"retry_with_changes": true,
+		}
+		if err.AbuseFilter != nil {
+			resp.Details["filter_id"] = err.AbuseFilter.ID
+			resp.Details["filter_description"] = err.AbuseFilter.Description
+			resp.Details["filter_actions"] = err.AbuseFilter.Actions
+		}
+		if warningOnly {
+			resp.Hint = localizedHint(hintAbuseFilterWarning, lang)
+		} else {
+			resp.Hint = localizedHint(hintAbuseFilterDisallowed, lang)
+		}
+		return true
+
+	case err.SpamBlacklistMatches != nil || err.Code == "spamblacklist":
+		resp.Details = map[string]interface{}{
+			"retry_possible":     false,
+			"retry_with_changes": true,
+			"blacklisted_urls":   err.SpamBlacklistMatches,
+		}
+		resp.Hint = localizedHint(hintSpamBlacklist, lang)
+		return true
+
+	case err.Block != nil || err.Code == "blocked" || err.Code == "autoblocked":
+		resp.Details = map[string]interface{}{
+			"retry_possible":     false,
+			"retry_with_changes": false,
+		}
+		if err.Block != nil {
+			resp.Details["blocked_by"] = err.Block.BlockedBy
+			resp.Details["block_reason"] = err.Block.BlockReason
+			resp.Details["block_expiry"] = err.Block.BlockExpiry
+			resp.Details["block_partial"] = err.Block.BlockPartial
+		}
+		resp.Hint = localizedHint(hintBlocked, lang)
+		return true
+	}
+
+	return false
+}
+
+func formatSectionNotFoundError(err *tools.SectionNotFoundError, lang string) *ErrorResponse {
+	details := map[string]interface{}{
+		"section_index":      err.SectionIndex,
+		"available_sections": err.AvailableSections,
+	}
+	if err.SectionTitle != "" {
+		delete(details, "section_index")
+		details["section_title"] = err.SectionTitle
+	}
+
 	return &ErrorResponse{
 		Error:   "section_not_found",
 		Message: err.Error(),
-		Hint:    "Call wiki_page_outline to get fresh section indices.",
-		Details: map[string]interface{}{
-			"section_index":      err.SectionIndex,
-			"available_sections": err.AvailableSections,
-		},
+		Hint:    localizedHint(hintSectionNotFound, lang),
+		Details: details,
+	}
+}
+
+func formatPageTooLargeError(err *tools.PageTooLargeError, lang string) *ErrorResponse {
+	details := map[string]interface{}{
+		"page_bytes": err.Length,
+		"max_bytes":  err.MaxBytes,
+	}
+	if err.Outline != nil {
+		details["outline"] = err.Outline
+	}
+
+	return &ErrorResponse{
+		Error:   "page_too_large",
+		Message: err.Error(),
+		Hint:    localizedHint(hintPageTooLarge, lang),
+		Details: details,
 	}
 }
 
@@ -0,0 +1,162 @@
+package mcp
+
+import (
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/config"
+)
+
+const redacted = "[redacted]"
+
+var (
+	emailRegex = regexp.MustCompile(`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`)
+
+	// phoneCandidateRegex finds digit runs that might be phone numbers;
+	// candidates are then checked for a plausible digit count
+	phoneCandidateRegex = regexp.MustCompile(`\+?\(?\d[\d\s().-]{6,}\d`)
+
+	// isbnPrefixRegex matches the label before an ISBN, whose digits would
+	// otherwise pass for a phone number
+	isbnPrefixRegex = regexp.MustCompile(`(?i)\bISBN(?:-1[03])?:?\s*$`)
+
+	// Markdown links to absolute URLs: [text](https://...) -> text
+	externalLinkRegex = regexp.MustCompile(`\[([^\]]*)\]\((?:https?:)?//[^)\s]+(?:\s+"[^"]*")?\)`)
+	bareURLRegex      = regexp.MustCompile(`(?:https?:)?//[^\s)\]>"]+`)
+)
+
+// fileExtensions are extensions of file names that look like addresses,
+// such as Map@2x.png for an image at twice the resolution
+var fileExtensions = map[string]bool{
+	"png": true, "jpg": true, "jpeg": true, "gif": true, "svg": true,
+	"webp": true, "tif": true, "tiff": true, "pdf": true,
+}
+
+// contentFields are the response keys whose string values hold wiki content.
+// Identifiers like titles and thumbnail URLs are left untouched.
+var contentFields = map[string]bool{
+	"content":       true,
+	"summary":       true,
+	"description":   true,
+	"preview":       true,
+	"snippet":       true,
+	"diff_markdown": true,
+	"infobox":       true,
+}
+
+// contentFilter redacts sensitive data from returned wiki content
+type contentFilter struct {
+	redactEmails      bool
+	redactPhones      bool
+	stripExternalURLs bool
+	blockPatterns     []*regexp.Regexp
+}
+
+// newContentFilter builds a filter from configuration, or returns nil if no
+// filtering is enabled
+func newContentFilter(cfg *config.Config) *contentFilter {
+	f := &contentFilter{
+		redactEmails:      cfg.RedactEmails,
+		redactPhones:      cfg.RedactPhones,
+		stripExternalURLs: cfg.StripExternalURLs,
+	}
+
+	for _, pattern := range cfg.BlockPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			log.Printf("Ignoring invalid block pattern %q: %v", pattern, err)
+			continue
+		}
+		f.blockPatterns = append(f.blockPatterns, re)
+	}
+
+	if !f.redactEmails && !f.redactPhones && !f.stripExternalURLs && len(f.blockPatterns) == 0 {
+		return nil
+	}
+	return f
+}
+
+// apply walks a decoded JSON response and filters content fields in place
+func (f *contentFilter) apply(value interface{}) interface{} {
+	return f.walk(value, false)
+}
+
+func (f *contentFilter) walk(value interface{}, inContent bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = f.walk(child, inContent || contentFields[key])
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = f.walk(child, inContent)
+		}
+		return v
+	case string:
+		if inContent {
+			return f.filterText(v)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// filterText applies every enabled filter to a piece of content
+func (f *contentFilter) filterText(text string) string {
+	for _, re := range f.blockPatterns {
+		text = re.ReplaceAllString(text, redacted)
+	}
+
+	if f.redactEmails {
+		text = emailRegex.ReplaceAllStringFunc(text, func(match string) string {
+			if fileExtensions[strings.ToLower(match[strings.LastIndexByte(match, '.')+1:])] {
+				return match
+			}
+			return redacted
+		})
+	}
+
+	if f.redactPhones {
+		text = redactPhones(text)
+	}
+
+	if f.stripExternalURLs {
+		text = externalLinkRegex.ReplaceAllString(text, "$1")
+		text = bareURLRegex.ReplaceAllString(text, "")
+	}
+
+	return text
+}
+
+// redactPhones redacts digit runs with as many digits as phone numbers
+// have, from 9 to the 15 E.164 allows, other than ISBNs
+func redactPhones(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range phoneCandidateRegex.FindAllStringIndex(text, -1) {
+		digits := countDigits(text[loc[0]:loc[1]])
+		if digits < 9 || digits > 15 || isbnPrefixRegex.MatchString(text[max(loc[0]-16, 0):loc[0]]) {
+			continue
+		}
+		b.WriteString(text[last:loc[0]])
+		b.WriteString(redacted)
+		last = loc[1]
+	}
+	if last == 0 {
+		return text
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+func countDigits(s string) int {
+	return len(s) - len(strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return -1
+		}
+		return r
+	}, s))
+}
@@ -0,0 +1,127 @@
+package mcp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/mediawiki-mcp/config"
+)
+
+func TestFilterTextEmails(t *testing.T) {
+	f := &contentFilter{redactEmails: true}
+	tests := []struct {
+		text, want string
+	}{
+		{"Write to jane.doe+wiki@example.co.uk today", "Write to [redacted] today"},
+		{"Contact: INFO@Example.ORG.", "Contact: [redacted]."},
+		{"a@b.io and c_d@e-f.com", "[redacted] and [redacted]"},
+		// Not addresses
+		{"Follow @wikipedia for news", "Follow @wikipedia for news"},
+		{"[[File:Map@2x.png|thumb]] and icon@3x.webp", "[[File:Map@2x.png|thumb]] and icon@3x.webp"},
+		{"root@localhost", "root@localhost"},
+		{"x @ example.org", "x @ example.org"},
+	}
+	for _, tt := range tests {
+		if got := f.filterText(tt.text); got != tt.want {
+			t.Errorf("filterText(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestFilterTextPhones(t *testing.T) {
+	f := &contentFilter{redactPhones: true}
+	tests := []struct {
+		text, want string
+	}{
+		{"Call +1 (555) 123-4567 now", "Call [redacted] now"},
+		{"Tel. +44 20 7946 0958", "Tel. [redacted]"},
+		{"Office: 555.123.4567", "Office: [redacted]"},
+		{"Fax 030 12345678", "Fax [redacted]"},
+		// Not phone numbers
+		{"The war lasted 1914-1918.", "The war lasted 1914-1918."},
+		{"Released 2024-01-15", "Released 2024-01-15"},
+		{"Population 3,645,000 (2023)", "Population 3,645,000 (2023)"},
+		{"ISBN 978-3-16-148410-0", "ISBN 978-3-16-148410-0"},
+		{"ISBN-13: 978-0-596-52068-7", "ISBN-13: 978-0-596-52068-7"},
+		{"Account 12345678901234567890", "Account 12345678901234567890"},
+		{"Version 1.2.3", "Version 1.2.3"},
+		{"Short 123 4567", "Short 123 4567"},
+	}
+	for _, tt := range tests {
+		if got := f.filterText(tt.text); got != tt.want {
+			t.Errorf("filterText(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestFilterTextExternalURLs(t *testing.T) {
+	f := &contentFilter{stripExternalURLs: true}
+	tests := []struct {
+		text, want string
+	}{
+		{"See [the docs](https://example.org/docs) first", "See the docs first"},
+		{`[Site](http://example.org "Title")`, "Site"},
+		{"[Relative](//example.org/x)", "Relative"},
+		{"Go to https://example.org/a?b=c now", "Go to  now"},
+		{"(https://example.org)", "()"},
+		// Links within the wiki and text that isn't a URL stay
+		{"[Town](/wiki/Town)", "[Town](/wiki/Town)"},
+		{"[Town](#History)", "[Town](#History)"},
+		{"and/or, 50/50", "and/or, 50/50"},
+		{"code // comment", "code // comment"},
+	}
+	for _, tt := range tests {
+		if got := f.filterText(tt.text); got != tt.want {
+			t.Errorf("filterText(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestNewContentFilter(t *testing.T) {
+	if f := newContentFilter(&config.Config{}); f != nil {
+		t.Errorf("newContentFilter(no filters) = %+v, want nil", f)
+	}
+	if f := newContentFilter(&config.Config{BlockPatterns: []string{"("}}); f != nil {
+		t.Errorf("newContentFilter(only an invalid pattern) = %+v, want nil", f)
+	}
+
+	f := newContentFilter(&config.Config{BlockPatterns: []string{"(", `(?i)project \w+`, `\bcodename\b`}})
+	if f == nil || len(f.blockPatterns) != 2 {
+		t.Fatalf("newContentFilter = %+v, want the two valid patterns", f)
+	}
+	tests := []struct {
+		text, want string
+	}{
+		{"Work on Project Falcon began", "Work on [redacted] began"},
+		{"The codename was kept; codenames weren't", "The [redacted] was kept; codenames weren't"},
+		{"Nothing to hide", "Nothing to hide"},
+	}
+	for _, tt := range tests {
+		if got := f.filterText(tt.text); got != tt.want {
+			t.Errorf("filterText(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestContentFilterApply(t *testing.T) {
+	f := &contentFilter{redactEmails: true}
+	value := map[string]interface{}{
+		"title":   "jane@example.org",
+		"content": "Mail jane@example.org",
+		"sections": []interface{}{
+			map[string]interface{}{"title": "a@example.org", "preview": "b@example.org"},
+		},
+		"summary": map[string]interface{}{"text": "c@example.org", "count": 1.0},
+	}
+	want := map[string]interface{}{
+		"title":   "jane@example.org",
+		"content": "Mail [redacted]",
+		"sections": []interface{}{
+			map[string]interface{}{"title": "a@example.org", "preview": "[redacted]"},
+		},
+		"summary": map[string]interface{}{"text": "[redacted]", "count": 1.0},
+	}
+	if got := f.apply(value); !reflect.DeepEqual(got, want) {
+		t.Errorf("apply = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,409 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// graphQLRequest is the standard GraphQL-over-HTTP request body
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// graphQLToolError carries a tool's structured error into GraphQL
+// "extensions" so clients get the same code and hint as over MCP
+type graphQLToolError struct {
+	resp *ErrorResponse
+}
+
+func (e *graphQLToolError) Error() string {
+	return e.resp.Message
+}
+
+func (e *graphQLToolError) Extensions() map[string]interface{} {
+	ext := map[string]interface{}{"code": e.resp.Error}
+	if e.resp.Hint != "" {
+		ext["hint"] = e.resp.Hint
+	}
+	if len(e.resp.NextSteps) > 0 {
+		ext["next_steps"] = e.resp.NextSteps
+	}
+	return ext
+}
+
+// pageSource is the parent value of Page fields: the outline plus the
+// arguments needed to lazily call further tools for the same page
+type pageSource struct {
+	args    map[string]interface{}
+	outline map[string]interface{}
+}
+
+// GraphQLHandler serves POST (or GET) /graphql. Queries are resolved by
+// calling the registered tools, so results share the cache, rate limits,
+// content filters, and the MCP_API_TOKENS check with MCP.
+func (s *Server) GraphQLHandler() http.Handler {
+	schema, err := s.graphQLSchema()
+	if err != nil {
+		panic("graphql schema: " + err.Error())
+	}
+
+	return s.RequireAPIToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphQLRequest
+		switch r.Method {
+		case http.MethodGet:
+			req.Query = r.URL.Query().Get("query")
+			req.OperationName = r.URL.Query().Get("operationName")
+			if vars := r.URL.Query().Get("variables"); vars != "" {
+				if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+					writeJSONError(w, http.StatusBadRequest, FormatErrorString("bad_request", "variables must be a JSON object"))
+					return
+				}
+			}
+		case http.MethodPost:
+			if err := json.NewDecoder(io.LimitReader(r.Body, maxToolRequestBytes)).Decode(&req); err != nil {
+				writeJSONError(w, http.StatusBadRequest, FormatErrorString("bad_request", "request body must be a GraphQL JSON request"))
+				return
+			}
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			writeJSONError(w, http.StatusMethodNotAllowed, FormatErrorString("method_not_allowed", "use GET or POST"))
+			return
+		}
+
+		if strings.TrimSpace(req.Query) == "" {
+			writeJSONError(w, http.StatusBadRequest, FormatErrorString("bad_request", "query is required"))
+			return
+		}
+
+		result := graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  req.Query,
+			VariableValues: req.Variables,
+			OperationName:  req.OperationName,
+			Context:        r.Context(),
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	}))
+}
+
+// callToolJSON invokes a tool and decodes its JSON result generically
+func (s *Server) callToolJSON(ctx context.Context, name string, args map[string]interface{}) (map[string]interface{}, error) {
+	if !s.APIToolAllowed(name) {
+		return nil, fmt.Errorf("%s is only served over GraphQL when MCP_API_TOKENS is set", name)
+	}
+	body, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+
+	text, err := s.CallToolJSON(ctx, name, body)
+	var toolErr *ToolError
+	if errors.As(err, &toolErr) {
+		return nil, &graphQLToolError{resp: toolErr.Response}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(text, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// jsonScalar passes arbitrary JSON values (e.g. infobox fields) through as-is
+var jsonScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "Arbitrary JSON value",
+	Serialize:   func(value interface{}) interface{} { return value },
+	ParseValue:  func(value interface{}) interface{} { return value },
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return valueAST.GetValue()
+	},
+})
+
+// field resolves a GraphQL field from a key of a decoded tool result
+func field(key string) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		switch source := p.Source.(type) {
+		case map[string]interface{}:
+			return source[key], nil
+		case *pageSource:
+			return source.outline[key], nil
+		}
+		return nil, nil
+	}
+}
+
+// pageArgs copies the shared page arguments and adds extra tool arguments
+func pageArgs(source *pageSource, extra map[string]interface{}) map[string]interface{} {
+	args := make(map[string]interface{}, len(source.args)+len(extra))
+	for k, v := range source.args {
+		args[k] = v
+	}
+	for k, v := range extra {
+		args[k] = v
+	}
+	return args
+}
+
+// trimSectionDepth drops subsections nested deeper than depth (1 = top level only)
+func trimSectionDepth(sections []interface{}, depth int) []interface{} {
+	for _, sec := range sections {
+		m, ok := sec.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		subs, _ := m["subsections"].([]interface{})
+		if depth <= 1 {
+			delete(m, "subsections")
+		} else {
+			m["subsections"] = trimSectionDepth(subs, depth-1)
+		}
+	}
+	return sections
+}
+
+// graphQLSchema builds the GraphQL schema over the page and search tools
+func (s *Server) graphQLSchema() (graphql.Schema, error) {
+	thumbnailType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Thumbnail",
+		Fields: graphql.Fields{
+			"url":    &graphql.Field{Type: graphql.String, Resolve: field("url")},
+			"width":  &graphql.Field{Type: graphql.Int, Resolve: field("width")},
+			"height": &graphql.Field{Type: graphql.Int, Resolve: field("height")},
+		},
+	})
+
+	coordinateType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Coordinate",
+		Fields: graphql.Fields{
+			"lat":     &graphql.Field{Type: graphql.Float, Resolve: field("lat")},
+			"lon":     &graphql.Field{Type: graphql.Float, Resolve: field("lon")},
+			"globe":   &graphql.Field{Type: graphql.String, Resolve: field("globe")},
+			"type":    &graphql.Field{Type: graphql.String, Resolve: field("type")},
+			"name":    &graphql.Field{Type: graphql.String, Resolve: field("name")},
+			"primary": &graphql.Field{Type: graphql.Boolean, Resolve: field("primary")},
+			"source":  &graphql.Field{Type: graphql.String, Resolve: field("source")},
+		},
+	})
+
+	assessmentType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageAssessment",
+		Fields: graphql.Fields{
+			"project":    &graphql.Field{Type: graphql.String, Resolve: field("project")},
+			"class":      &graphql.Field{Type: graphql.String, Resolve: field("class")},
+			"importance": &graphql.Field{Type: graphql.String, Resolve: field("importance")},
+		},
+	})
+
+	provenanceType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Provenance",
+		Fields: graphql.Fields{
+			"revid":     &graphql.Field{Type: graphql.Int, Resolve: field("revid")},
+			"fetchedAt": &graphql.Field{Type: graphql.String, Resolve: field("fetched_at")},
+			"url":       &graphql.Field{Type: graphql.String, Resolve: field("url")},
+		},
+	})
+
+	var sectionType *graphql.Object
+	sectionType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Section",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"index":          &graphql.Field{Type: graphql.Int, Resolve: field("index")},
+				"number":         &graphql.Field{Type: graphql.String, Resolve: field("number")},
+				"title":          &graphql.Field{Type: graphql.String, Resolve: field("title")},
+				"level":          &graphql.Field{Type: graphql.Int, Resolve: field("level")},
+				"preview":        &graphql.Field{Type: graphql.String, Resolve: field("preview")},
+				"wordCount":      &graphql.Field{Type: graphql.Int, Resolve: field("word_count")},
+				"tableWordCount": &graphql.Field{Type: graphql.Int, Resolve: field("table_word_count")},
+				"byteSize":       &graphql.Field{Type: graphql.Int, Resolve: field("byte_size")},
+				"subsections":    &graphql.Field{Type: graphql.NewList(sectionType), Resolve: field("subsections")},
+			}
+		}),
+	})
+
+	sectionContentType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SectionContent",
+		Fields: graphql.Fields{
+			"title":        &graphql.Field{Type: graphql.String, Resolve: field("title")},
+			"content":      &graphql.Field{Type: graphql.String, Resolve: field("content")},
+			"links":        &graphql.Field{Type: graphql.NewList(graphql.String), Resolve: field("links")},
+			"externalUrls": &graphql.Field{Type: graphql.NewList(graphql.String), Resolve: field("external_urls")},
+			"wordCount":    &graphql.Field{Type: graphql.Int, Resolve: field("word_count")},
+		},
+	})
+
+	pageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Page",
+		Fields: graphql.Fields{
+			"title":          &graphql.Field{Type: graphql.String, Resolve: field("title")},
+			"exists":         &graphql.Field{Type: graphql.Boolean, Resolve: field("exists")},
+			"redirect":       &graphql.Field{Type: graphql.String, Resolve: field("redirect")},
+			"description":    &graphql.Field{Type: graphql.String, Resolve: field("description")},
+			"summary":        &graphql.Field{Type: graphql.String, Resolve: field("summary")},
+			"summaryLinks":   &graphql.Field{Type: graphql.NewList(graphql.String), Resolve: field("summary_links")},
+			"internalTitles": &graphql.Field{Type: graphql.NewList(graphql.String), Resolve: field("internal_titles")},
+			"externalUrls":   &graphql.Field{Type: graphql.NewList(graphql.String), Resolve: field("external_urls")},
+			"thumbnail":      &graphql.Field{Type: thumbnailType, Resolve: field("thumbnail")},
+			"infobox":        &graphql.Field{Type: jsonScalar, Resolve: field("infobox")},
+			"coordinates":    &graphql.Field{Type: coordinateType, Resolve: field("coordinates")},
+			"assessments":    &graphql.Field{Type: graphql.NewList(assessmentType), Resolve: field("assessments")},
+			"categories":     &graphql.Field{Type: graphql.NewList(graphql.String), Resolve: field("categories")},
+			"seeAlso":        &graphql.Field{Type: graphql.NewList(graphql.String), Resolve: field("see_also")},
+			"totalWordCount": &graphql.Field{Type: graphql.Int, Resolve: field("total_word_count")},
+			"source":         &graphql.Field{Type: provenanceType, Resolve: field("source")},
+			"sections": &graphql.Field{
+				Type: graphql.NewList(sectionType),
+				Args: graphql.FieldConfigArgument{
+					"depth": &graphql.ArgumentConfig{Type: graphql.Int, Description: "Maximum nesting depth (1 = top-level sections only)"},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					source := p.Source.(*pageSource)
+					sections, _ := source.outline["sections"].([]interface{})
+					if depth, ok := p.Args["depth"].(int); ok && depth > 0 {
+						return trimSectionDepth(sections, depth), nil
+					}
+					return sections, nil
+				},
+			},
+			"section": &graphql.Field{
+				Type: sectionContentType,
+				Args: graphql.FieldConfigArgument{
+					"index": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					source := p.Source.(*pageSource)
+					resp, err := s.callToolJSON(p.Context, "wiki_page_section", pageArgs(source, map[string]interface{}{
+						"section_index": p.Args["index"],
+					}))
+					if err != nil {
+						return nil, err
+					}
+					section, ok := resp["section"].(map[string]interface{})
+					if !ok {
+						return nil, nil
+					}
+					section["external_urls"] = resp["external_urls"]
+					return section, nil
+				},
+			},
+			"content": &graphql.Field{
+				Type:        graphql.String,
+				Description: "Full page content as Markdown (may be large)",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					full, err := s.callToolJSON(p.Context, "wiki_page_full", pageArgs(p.Source.(*pageSource), nil))
+					if err != nil {
+						return nil, err
+					}
+					return full["content"], nil
+				},
+			},
+			"backlinks": &graphql.Field{
+				Type: graphql.NewList(graphql.String),
+				Args: graphql.FieldConfigArgument{
+					"limit": &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					extra := map[string]interface{}{}
+					if limit, ok := p.Args["limit"]; ok {
+						extra["limit"] = limit
+					}
+					resp, err := s.callToolJSON(p.Context, "wiki_backlinks", pageArgs(p.Source.(*pageSource), extra))
+					if err != nil {
+						return nil, err
+					}
+					backlinks, _ := resp["backlinks"].([]interface{})
+					titles := make([]interface{}, 0, len(backlinks))
+					for _, bl := range backlinks {
+						if m, ok := bl.(map[string]interface{}); ok {
+							titles = append(titles, m["title"])
+						}
+					}
+					return titles, nil
+				},
+			},
+		},
+	})
+
+	searchResultType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SearchResult",
+		Fields: graphql.Fields{
+			"title":       &graphql.Field{Type: graphql.String, Resolve: field("title")},
+			"snippet":     &graphql.Field{Type: graphql.String, Resolve: field("snippet")},
+			"wordCount":   &graphql.Field{Type: graphql.Int, Resolve: field("word_count")},
+			"viaRedirect": &graphql.Field{Type: graphql.String, Resolve: field("via_redirect")},
+			"source":      &graphql.Field{Type: provenanceType, Resolve: field("source")},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"page": &graphql.Field{
+				Type: pageType,
+				Args: graphql.FieldConfigArgument{
+					"wikiUrl":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"title":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"language": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					args := map[string]interface{}{
+						"wiki_url": p.Args["wikiUrl"],
+						"title":    p.Args["title"],
+					}
+					if lang, ok := p.Args["language"]; ok {
+						args["language"] = lang
+					}
+					outline, err := s.callToolJSON(p.Context, "wiki_page_outline", args)
+					if err != nil {
+						return nil, err
+					}
+					return &pageSource{args: args, outline: outline}, nil
+				},
+			},
+			"search": &graphql.Field{
+				Type: graphql.NewList(searchResultType),
+				Args: graphql.FieldConfigArgument{
+					"wikiUrl":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"query":      &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"limit":      &graphql.ArgumentConfig{Type: graphql.Int},
+					"inCategory": &graphql.ArgumentConfig{Type: graphql.String},
+					"language":   &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					args := map[string]interface{}{
+						"wiki_url": p.Args["wikiUrl"],
+						"query":    p.Args["query"],
+					}
+					for gqlName, toolName := range map[string]string{"limit": "limit", "inCategory": "in_category", "language": "language"} {
+						if v, ok := p.Args[gqlName]; ok {
+							args[toolName] = v
+						}
+					}
+					resp, err := s.callToolJSON(p.Context, "wiki_search", args)
+					if err != nil {
+						return nil, err
+					}
+					return resp["results"], nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
@@ -0,0 +1,324 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Hint identifiers used in structured error responses
+const (
+	hintMissingTitle    = "missingtitle"
+	hintNoSuchSection   = "nosuchsection"
+	hintMaxLag          = "maxlag"
+	hintSectionNotFound = "section_not_found"
+	hintPageTooLarge    = "page_too_large"
+
+	hintResponseTooLarge = "response_too_large"
+	hintPageTooComplex   = "page_too_complex"
+
+	hintLanguageVersionNotFound = "language_version_not_found"
+
+	hintFeatureUnsupported = "feature_unsupported"
+	hintJobNotFound        = "job_not_found"
+	hintEditNotFound       = "edit_not_found"
+	hintArtifactNotFound   = "artifact_not_found"
+	hintSnippetNotFound    = "snippet_not_found"
+
+	hintNamespaceWriteForbidden = "namespace_write_forbidden"
+	hintNoCredentialsForWiki    = "no_credentials_for_wiki"
+
+	hintAbuseFilterWarning    = "abusefilter_warning"
+	hintAbuseFilterDisallowed = "abusefilter_disallowed"
+	hintSpamBlacklist         = "spamblacklist"
+	hintBlocked               = "blocked"
+	hintEditConflict          = "editconflict"
+	hintEditConflictSaved     = "editconflict_saved"
+	hintPageExists            = "page_exists"
+	hintMissingRight          = "missing_right"
+	hintUploadWarning         = "upload_warning"
+	hintFileTooLarge          = "file_too_large"
+	hintAlreadyRolled         = "alreadyrolled"
+	hintOnlyAuthor            = "onlyauthor"
+	hintUndoFailure           = "undofailure"
+
+	// Contextual hints derived from the session's call history
+	hintStaleOutline    = "stale_outline"
+	hintNoOutline       = "no_outline"
+	hintRepeatedMissing = "repeated_missing"
+	hintSlowDown        = "slow_down"
+)
+
+const (
+	// staleOutlineAge is when an outline is considered likely out of date
+	staleOutlineAge = 5 * time.Minute
+	// busyCallThreshold is the per-minute call count that triggers a slow-down hint
+	busyCallThreshold = 20
+)
+
+// hintCatalog maps hint identifiers to translations keyed by language code.
+// English is the fallback for languages without a translation.
+var hintCatalog = map[string]map[string]string{
+	hintMissingTitle: {
+		"en": "The page doesn't exist. Try using wiki_search to find the correct title.",
+		"de": "Die Seite existiert nicht. Verwende wiki_search, um den richtigen Titel zu finden.",
+		"fr": "La page n'existe pas. Utilisez wiki_search pour trouver le titre correct.",
+		"es": "La página no existe. Usa wiki_search para encontrar el título correcto.",
+	},
+	hintNoSuchSection: {
+		"en": "The section doesn't exist. Call wiki_page_outline to get fresh section indices.",
+		"de": "Der Abschnitt existiert nicht. Rufe wiki_page_outline auf, um aktuelle Abschnittsindizes zu erhalten.",
+		"fr": "La section n'existe pas. Appelez wiki_page_outline pour obtenir des indices de section à jour.",
+		"es": "La sección no existe. Llama a wiki_page_outline para obtener índices de sección actualizados.",
+	},
+	hintMaxLag: {
+		"en": "The wiki server is experiencing high load. Wait a moment and try again.",
+		"de": "Der Wiki-Server ist stark ausgelastet. Warte einen Moment und versuche es erneut.",
+		"fr": "Le serveur du wiki est surchargé. Patientez un instant puis réessayez.",
+		"es": "El servidor de la wiki tiene mucha carga. Espera un momento e inténtalo de nuevo.",
+	},
+	hintSectionNotFound: {
+		"en": "Call wiki_page_outline to get fresh section indices.",
+		"de": "Rufe wiki_page_outline auf, um aktuelle Abschnittsindizes zu erhalten.",
+		"fr": "Appelez wiki_page_outline pour obtenir des indices de section à jour.",
+		"es": "Llama a wiki_page_outline para obtener índices de sección actualizados.",
+	},
+	hintPageTooLarge: {
+		"en": "The page is too large to return in full. Use the embedded outline and fetch sections with wiki_page_section.",
+		"de": "Die Seite ist zu groß, um vollständig zurückgegeben zu werden. Nutze die eingebettete Gliederung und rufe Abschnitte mit wiki_page_section ab.",
+		"fr": "La page est trop volumineuse pour être renvoyée en entier. Utilisez le plan inclus et récupérez les sections avec wiki_page_section.",
+		"es": "La página es demasiado grande para devolverla completa. Usa el esquema incluido y obtén secciones con wiki_page_section.",
+	},
+	hintResponseTooLarge: {
+		"en": "The wiki returned more data than the server accepts. Request less at once, for example with a lower limit or a single section.",
+		"de": "Das Wiki hat mehr Daten geliefert, als der Server annimmt. Fordere weniger auf einmal an, etwa mit einem kleineren Limit oder einem einzelnen Abschnitt.",
+		"fr": "Le wiki a renvoyé plus de données que le serveur n'en accepte. Demandez-en moins à la fois, par exemple avec une limite plus basse ou une seule section.",
+		"es": "La wiki devolvió más datos de los que el servidor acepta. Pide menos a la vez, por ejemplo con un límite menor o una sola sección.",
+	},
+	hintPageTooComplex: {
+		"en": "The page's HTML is too deeply nested or too large to convert. Call wiki_page_outline and fetch sections one at a time with wiki_page_section.",
+		"de": "Das HTML der Seite ist zu tief verschachtelt oder zu groß zum Umwandeln. Rufe wiki_page_outline auf und hole die Abschnitte einzeln mit wiki_page_section.",
+		"fr": "Le HTML de la page est trop profondément imbriqué ou trop volumineux pour être converti. Appelez wiki_page_outline et récupérez les sections une par une avec wiki_page_section.",
+		"es": "El HTML de la página está demasiado anidado o es demasiado grande para convertirlo. Llama a wiki_page_outline y obtén las secciones una a una con wiki_page_section.",
+	},
+	hintLanguageVersionNotFound: {
+		"en": "The article has no language link to that language. Pick one from details.available_languages, or search the other wiki for the topic directly.",
+		"de": "Der Artikel hat keinen Sprachlink in diese Sprache. Wähle eine aus details.available_languages oder suche das Thema direkt im anderen Wiki.",
+		"fr": "L'article n'a pas de lien interlangue vers cette langue. Choisissez-en une dans details.available_languages, ou cherchez le sujet directement sur l'autre wiki.",
+		"es": "El artículo no tiene enlace interlingüístico a ese idioma. Elige uno de details.available_languages o busca el tema directamente en la otra wiki.",
+	},
+	hintFeatureUnsupported: {
+		"en": "This wiki doesn't support the feature. Fall back to wiki_page_full or wiki_page_section on the page instead.",
+		"de": "Dieses Wiki unterstützt die Funktion nicht. Nutze stattdessen wiki_page_full oder wiki_page_section für die Seite.",
+		"fr": "Ce wiki ne prend pas en charge cette fonctionnalité. Utilisez plutôt wiki_page_full ou wiki_page_section sur la page.",
+		"es": "Esta wiki no admite la función. Usa wiki_page_full o wiki_page_section en la página en su lugar.",
+	},
+	hintJobNotFound: {
+		"en": "Call wiki_jobs to list known job IDs. Jobs from before a restart are only kept when a jobs file is configured.",
+		"de": "Rufe wiki_jobs auf, um bekannte Job-IDs aufzulisten. Jobs von vor einem Neustart bleiben nur erhalten, wenn eine Job-Datei konfiguriert ist.",
+		"fr": "Appelez wiki_jobs pour lister les identifiants de tâches connus. Les tâches antérieures à un redémarrage ne sont conservées que si un fichier de tâches est configuré.",
+		"es": "Llama a wiki_jobs para listar los ID de trabajos conocidos. Los trabajos anteriores a un reinicio solo se conservan si hay un archivo de trabajos configurado.",
+	},
+	hintEditNotFound: {
+		"en": "Use the edit ID a write tool returned when it held the edit for review. Without a database, held edits are lost on restart.",
+		"de": "Verwende die Bearbeitungs-ID, die ein Schreibwerkzeug beim Zurückhalten der Bearbeitung zur Prüfung zurückgegeben hat. Ohne Datenbank gehen zurückgehaltene Bearbeitungen bei einem Neustart verloren.",
+		"fr": "Utilisez l'identifiant de modification renvoyé par l'outil d'écriture lorsqu'il a mis la modification en attente de relecture. Sans base de données, les modifications en attente sont perdues au redémarrage.",
+		"es": "Usa el ID de edición que devolvió la herramienta de escritura al retener la edición para revisión. Sin base de datos, las ediciones retenidas se pierden al reiniciar.",
+	},
+	hintArtifactNotFound: {
+		"en": "Only finished export and crawl jobs have a file. Check the job with wiki_job; files are deleted after the retention period, so rerun the job if it is gone.",
+		"de": "Nur abgeschlossene Export- und Crawl-Jobs haben eine Datei. Prüfe den Job mit wiki_job; Dateien werden nach der Aufbewahrungsfrist gelöscht, starte den Job also neu, wenn sie fehlt.",
+		"fr": "Seules les tâches d'export et d'exploration terminées ont un fichier. Vérifiez la tâche avec wiki_job ; les fichiers sont supprimés après la durée de conservation, relancez donc la tâche s'il a disparu.",
+		"es": "Solo los trabajos de exportación y rastreo terminados tienen un archivo. Comprueba el trabajo con wiki_job; los archivos se eliminan tras el periodo de retención, así que vuelve a ejecutar el trabajo si ya no está.",
+	},
+	hintSnippetNotFound: {
+		"en": "The snippet must appear in the page's current wikitext, not its rendered text. Copy a shorter run of words without links or formatting.",
+		"de": "Der Ausschnitt muss im aktuellen Wikitext der Seite vorkommen, nicht im dargestellten Text. Kopiere eine kürzere Wortfolge ohne Links oder Formatierung.",
+		"fr": "L'extrait doit figurer dans le wikicode actuel de la page, pas dans son texte affiché. Copiez une suite de mots plus courte, sans liens ni mise en forme.",
+		"es": "El fragmento debe aparecer en el wikitexto actual de la página, no en su texto mostrado. Copia una secuencia de palabras más corta, sin enlaces ni formato.",
+	},
+	hintNamespaceWriteForbidden: {
+		"en": "This server only writes to the titles in details.allowed_prefixes. Write to a page there instead, such as a draft, and leave moving it to a human.",
+		"de": "Dieser Server schreibt nur in die Titel aus details.allowed_prefixes. Schreibe stattdessen dort eine Seite, etwa einen Entwurf, und überlasse das Verschieben einem Menschen.",
+		"fr": "Ce serveur n'écrit que dans les titres de details.allowed_prefixes. Écrivez plutôt une page à cet endroit, par exemple un brouillon, et laissez un humain la déplacer.",
+		"es": "Este servidor solo escribe en los títulos de details.allowed_prefixes. Escribe en su lugar una página allí, por ejemplo un borrador, y deja que una persona la traslade.",
+	},
+	hintNoCredentialsForWiki: {
+		"en": "This server doesn't edit anonymously and has no account for this wiki. Don't retry; ask the operator to add a bot password to the profile in details.profile, or a profile for details.wiki_url if there is none.",
+		"de": "Dieser Server bearbeitet nicht anonym und hat kein Konto für dieses Wiki. Versuche es nicht erneut; bitte den Betreiber, dem Profil in details.profile ein Bot-Passwort hinzuzufügen, oder ein Profil für details.wiki_url, falls keines existiert.",
+		"fr": "Ce serveur ne modifie pas anonymement et n'a pas de compte pour ce wiki. Ne réessayez pas ; demandez à l'opérateur d'ajouter un mot de passe de robot au profil indiqué dans details.profile, ou un profil pour details.wiki_url s'il n'y en a pas.",
+		"es": "Este servidor no edita de forma anónima y no tiene cuenta para este wiki. No lo reintentes; pide al operador que añada una contraseña de bot al perfil de details.profile, o un perfil para details.wiki_url si no existe.",
+	},
+	hintEditConflict: {
+		"en": "The page changed since base_revid; details.current_revision is the revision it is at now. Read it again, reapply your change to the current text, and retry with its revision ID as base_revid.",
+		"de": "Die Seite wurde seit base_revid geändert; details.current_revision ist ihre aktuelle Version. Lies sie erneut, übertrage deine Änderung auf den aktuellen Text und versuche es mit dessen Versions-ID als base_revid erneut.",
+		"fr": "La page a changé depuis base_revid ; details.current_revision est sa révision actuelle. Relisez-la, réappliquez votre modification au texte actuel et réessayez avec son identifiant de révision comme base_revid.",
+		"es": "La página cambió desde base_revid; details.current_revision es su revisión actual. Vuelve a leerla, aplica tu cambio al texto actual y reintenta con su ID de revisión como base_revid.",
+	},
+	hintEditConflictSaved: {
+		"en": "The write was saved, but the page changed after base_revid and the wiki couldn't refuse it; details.saved is the write and details.current_revision the change it was saved on top of. Don't retry: check that the two fit together, and revert the write if they don't.",
+		"de": "Der Schreibvorgang wurde gespeichert, aber die Seite wurde nach base_revid geändert und das Wiki konnte ihn nicht ablehnen; details.saved ist der Schreibvorgang und details.current_revision die Änderung, auf der er gespeichert wurde. Versuche es nicht erneut: Prüfe, ob beide zusammenpassen, und setze den Schreibvorgang zurück, wenn nicht.",
+		"fr": "L'écriture a été enregistrée, mais la page a changé après base_revid et le wiki n'a pas pu la refuser ; details.saved est l'écriture et details.current_revision la modification sur laquelle elle a été enregistrée. Ne réessayez pas : vérifiez que les deux sont compatibles et annulez l'écriture sinon.",
+		"es": "La escritura se guardó, pero la página cambió después de base_revid y el wiki no pudo rechazarla; details.saved es la escritura y details.current_revision el cambio sobre el que se guardó. No reintentes: comprueba que ambos encajan y revierte la escritura si no.",
+	},
+	hintPageExists: {
+		"en": "A page with this title already exists and was left unchanged. Choose another title, or read the page and change it with wiki_edit_page.",
+		"de": "Eine Seite mit diesem Titel existiert bereits und wurde nicht verändert. Wähle einen anderen Titel, oder lies die Seite und ändere sie mit wiki_edit_page.",
+		"fr": "Une page portant ce titre existe déjà et n'a pas été modifiée. Choisissez un autre titre, ou lisez la page et modifiez-la avec wiki_edit_page.",
+		"es": "Ya existe una página con este título y no se ha modificado. Elige otro título, o lee la página y cámbiala con wiki_edit_page.",
+	},
+	hintMissingRight: {
+		"en": "The wiki account this server uses lacks the right in details.right, so the action wasn't attempted. Don't retry; ask the wiki's administrators, or the operator, to grant the right to the account.",
+		"de": "Dem Wiki-Konto dieses Servers fehlt das Recht in details.right, daher wurde die Aktion nicht versucht. Versuche es nicht erneut; bitte die Administratoren des Wikis oder den Betreiber, dem Konto das Recht zu geben.",
+		"fr": "Le compte wiki utilisé par ce serveur n'a pas le droit indiqué dans details.right, l'action n'a donc pas été tentée. Ne réessayez pas ; demandez aux administrateurs du wiki, ou à l'opérateur, d'accorder ce droit au compte.",
+		"es": "La cuenta del wiki que usa este servidor no tiene el permiso de details.right, así que no se intentó la acción. No lo reintentes; pide a los administradores del wiki, o al operador, que concedan el permiso a la cuenta.",
+	},
+	hintUploadWarning: {
+		"en": "The wiki held the upload back because of the warnings in details.warnings, such as an existing file or a duplicate. Use another filename, or, if replacing or duplicating the file is intended, retry with ignore_warnings: true.",
+		"de": "Das Wiki hat den Upload wegen der Warnungen in details.warnings zurückgehalten, etwa einer vorhandenen Datei oder eines Duplikats. Verwende einen anderen Dateinamen oder, wenn das Ersetzen oder Duplizieren gewollt ist, versuche es mit ignore_warnings: true erneut.",
+		"fr": "Le wiki a retenu le téléversement à cause des avertissements de details.warnings, comme un fichier existant ou un doublon. Utilisez un autre nom de fichier ou, si remplacer ou dupliquer le fichier est voulu, réessayez avec ignore_warnings: true.",
+		"es": "El wiki retuvo la subida por las advertencias de details.warnings, como un archivo existente o un duplicado. Usa otro nombre de archivo o, si reemplazar o duplicar el archivo es intencionado, reinténtalo con ignore_warnings: true.",
+	},
+	hintFileTooLarge: {
+		"en": "The file is larger than this server uploads (details.max_bytes). Don't retry with the same file; upload a smaller version, or ask the operator to raise MCP_MAX_UPLOAD_BYTES.",
+		"de": "Die Datei ist größer, als dieser Server hochlädt (details.max_bytes). Versuche es nicht mit derselben Datei erneut; lade eine kleinere Version hoch oder bitte den Betreiber, MCP_MAX_UPLOAD_BYTES zu erhöhen.",
+		"fr": "Le fichier dépasse la taille que ce serveur téléverse (details.max_bytes). Ne réessayez pas avec le même fichier ; téléversez une version plus petite ou demandez à l'opérateur d'augmenter MCP_MAX_UPLOAD_BYTES.",
+		"es": "El archivo supera el tamaño que sube este servidor (details.max_bytes). No lo reintentes con el mismo archivo; sube una versión más pequeña o pide al operador que aumente MCP_MAX_UPLOAD_BYTES.",
+	},
+	hintAlreadyRolled: {
+		"en": "The page's latest edit is no longer by this user: their edits were already reverted, or someone edited the page since. Check the history with wiki_compare before reverting anything else.",
+		"de": "Die letzte Bearbeitung der Seite stammt nicht mehr von diesem Benutzer: Seine Bearbeitungen wurden bereits zurückgesetzt, oder jemand hat die Seite seitdem bearbeitet. Prüfe die Versionsgeschichte mit wiki_compare, bevor du weiteres zurücksetzt.",
+		"fr": "La dernière modification de la page n'est plus de cet utilisateur : ses modifications ont déjà été annulées, ou quelqu'un a modifié la page depuis. Vérifiez l'historique avec wiki_compare avant d'annuler quoi que ce soit d'autre.",
+		"es": "La última edición de la página ya no es de este usuario: sus ediciones ya se revirtieron o alguien editó la página después. Revisa el historial con wiki_compare antes de revertir nada más.",
+	},
+	hintOnlyAuthor: {
+		"en": "Every revision of the page is by this user, so there is no earlier version to roll back to. If the page itself is vandalism, delete it with wiki_delete_page instead.",
+		"de": "Alle Versionen der Seite stammen von diesem Benutzer, daher gibt es keine frühere Version zum Zurücksetzen. Ist die Seite selbst Vandalismus, lösche sie stattdessen mit wiki_delete_page.",
+		"fr": "Toutes les versions de la page sont de cet utilisateur, il n'y a donc pas de version antérieure à restaurer. Si la page elle-même est du vandalisme, supprimez-la plutôt avec wiki_delete_page.",
+		"es": "Todas las versiones de la página son de este usuario, así que no hay una versión anterior a la que revertir. Si la página en sí es vandalismo, bórrala con wiki_delete_page.",
+	},
+	hintUndoFailure: {
+		"en": "Later edits changed the same lines, so the revision can't be undone automatically. Compare the revisions with wiki_compare and make the change with wiki_edit_page instead.",
+		"de": "Spätere Bearbeitungen haben dieselben Zeilen geändert, daher kann die Version nicht automatisch rückgängig gemacht werden. Vergleiche die Versionen mit wiki_compare und nimm die Änderung stattdessen mit wiki_edit_page vor.",
+		"fr": "Des modifications ultérieures ont changé les mêmes lignes, la version ne peut donc pas être défaite automatiquement. Comparez les versions avec wiki_compare et faites la modification avec wiki_edit_page.",
+		"es": "Ediciones posteriores cambiaron las mismas líneas, así que la versión no se puede deshacer automáticamente. Compara las versiones con wiki_compare y haz el cambio con wiki_edit_page.",
+	},
+	hintAbuseFilterWarning: {
+		"en": "An abuse filter flagged this edit with a warning. Revise the content, or resubmit it unchanged to acknowledge the warning.",
+		"de": "Ein Missbrauchsfilter hat diese Bearbeitung mit einer Warnung markiert. Überarbeite den Inhalt oder sende ihn unverändert erneut, um die Warnung zu bestätigen.",
+		"fr": "Un filtre anti-abus a signalé cette modification par un avertissement. Révisez le contenu ou renvoyez-le tel quel pour confirmer.",
+		"es": "Un filtro antiabusos marcó esta edición con una advertencia. Revisa el contenido o reenvíalo sin cambios para confirmarla.",
+	},
+	hintAbuseFilterDisallowed: {
+		"en": "An abuse filter blocked this edit. Retrying unchanged will fail; change the content that matches the filter description.",
+		"de": "Ein Missbrauchsfilter hat diese Bearbeitung verhindert. Eine unveränderte Wiederholung schlägt fehl; ändere den Inhalt, auf den die Filterbeschreibung zutrifft.",
+		"fr": "Un filtre anti-abus a bloqué cette modification. Réessayer à l'identique échouera ; modifiez le contenu visé par la description du filtre.",
+		"es": "Un filtro antiabusos bloqueó esta edición. Reintentarla sin cambios fallará; cambia el contenido que coincide con la descripción del filtro.",
+	},
+	hintSpamBlacklist: {
+		"en": "The content contains blacklisted URLs. Remove the URLs listed in details.blacklisted_urls and retry.",
+		"de": "Der Inhalt enthält gesperrte URLs. Entferne die in details.blacklisted_urls aufgeführten URLs und versuche es erneut.",
+		"fr": "Le contenu contient des URL sur liste noire. Retirez les URL listées dans details.blacklisted_urls et réessayez.",
+		"es": "El contenido contiene URL en la lista negra. Elimina las URL de details.blacklisted_urls y vuelve a intentarlo.",
+	},
+	hintBlocked: {
+		"en": "The account is blocked from this action. Retrying will not help; ask a wiki administrator or the server operator.",
+		"de": "Das Konto ist für diese Aktion gesperrt. Wiederholen hilft nicht; wende dich an einen Wiki-Administrator oder den Serverbetreiber.",
+		"fr": "Le compte est bloqué pour cette action. Réessayer n'aidera pas ; contactez un administrateur du wiki ou l'opérateur du serveur.",
+		"es": "La cuenta está bloqueada para esta acción. Reintentar no servirá; consulta a un administrador de la wiki o al operador del servidor.",
+	},
+	hintStaleOutline: {
+		"en": "Your outline of %s was fetched %d minutes ago; the page has probably changed since. Re-fetch wiki_page_outline before retrying.",
+		"de": "Deine Gliederung von %s wurde vor %d Minuten abgerufen; die Seite hat sich vermutlich geändert. Rufe wiki_page_outline erneut auf.",
+		"fr": "Votre plan de %s date de %d minutes ; la page a probablement changé depuis. Rappelez wiki_page_outline avant de réessayer.",
+		"es": "Tu esquema de %s se obtuvo hace %d minutos; la página probablemente ha cambiado. Vuelve a llamar a wiki_page_outline antes de reintentar.",
+	},
+	hintNoOutline: {
+		"en": "No outline of %s was fetched in this session. Call wiki_page_outline first instead of guessing section indices.",
+		"de": "In dieser Sitzung wurde keine Gliederung von %s abgerufen. Rufe zuerst wiki_page_outline auf, statt Abschnittsindizes zu raten.",
+		"fr": "Aucun plan de %s n'a été récupéré dans cette session. Appelez d'abord wiki_page_outline au lieu de deviner les indices.",
+		"es": "No se obtuvo ningún esquema de %s en esta sesión. Llama primero a wiki_page_outline en lugar de adivinar índices.",
+	},
+	hintRepeatedMissing: {
+		"en": "%s has now failed %d times. Stop retrying this title and use wiki_search to find the right one.",
+		"de": "%s ist jetzt %d-mal fehlgeschlagen. Versuche diesen Titel nicht erneut, sondern nutze wiki_search.",
+		"fr": "%s a maintenant échoué %d fois. Cessez de réessayer ce titre et utilisez wiki_search.",
+		"es": "%s ha fallado %d veces. Deja de reintentar este título y usa wiki_search.",
+	},
+	hintSlowDown: {
+		"en": "This session made %d calls to this wiki in the last minute. Space out requests or fetch outlines instead of full pages.",
+		"de": "Diese Sitzung hat in der letzten Minute %d Aufrufe an dieses Wiki gesendet. Verteile die Anfragen oder nutze Gliederungen statt ganzer Seiten.",
+		"fr": "Cette session a effectué %d appels à ce wiki durant la dernière minute. Espacez les requêtes ou utilisez les plans plutôt que les pages entières.",
+		"es": "Esta sesión hizo %d llamadas a esta wiki en el último minuto. Espacia las solicitudes o usa esquemas en lugar de páginas completas.",
+	},
+}
+
+// localizedHint returns the hint text for an identifier in the requested
+// language, falling back to the base language and then English
+func localizedHint(id, lang string) string {
+	translations, ok := hintCatalog[id]
+	if !ok {
+		return ""
+	}
+
+	lang = strings.ToLower(lang)
+	if text, ok := translations[lang]; ok {
+		return text
+	}
+
+	// "de-at" -> "de"
+	if idx := strings.Index(lang, "-"); idx != -1 {
+		if text, ok := translations[lang[:idx]]; ok {
+			return text
+		}
+	}
+
+	return translations["en"]
+}
+
+// nextSteps inspects the session's recent calls and returns targeted hints
+// for a failed call, e.g. pointing out that the outline it relied on is stale
+func nextSteps(history []callRecord, call callRecord, code, lang string) []string {
+	var steps []string
+
+	switch code {
+	case "section_not_found", "nosuchsection":
+		var lastOutline *callRecord
+		for i := len(history) - 1; i >= 0; i-- {
+			c := history[i]
+			if c.Tool == "wiki_page_outline" && !c.Failed && c.WikiURL == call.WikiURL && c.Title == call.Title {
+				lastOutline = &history[i]
+				break
+			}
+		}
+		if lastOutline == nil {
+			steps = append(steps, fmt.Sprintf(localizedHint(hintNoOutline, lang), call.Title))
+		} else if age := call.At.Sub(lastOutline.At); age >= staleOutlineAge {
+			steps = append(steps, fmt.Sprintf(localizedHint(hintStaleOutline, lang), call.Title, int(age.Minutes())))
+		}
+
+	case "missingtitle":
+		failures := 1
+		for _, c := range history {
+			if c.Failed && c.WikiURL == call.WikiURL && c.Title == call.Title {
+				failures++
+			}
+		}
+		if failures > 1 {
+			steps = append(steps, fmt.Sprintf(localizedHint(hintRepeatedMissing, lang), call.Title, failures))
+		}
+
+	case "maxlag", "ratelimited":
+		recentCalls := 0
+		for _, c := range history {
+			if c.WikiURL == call.WikiURL && call.At.Sub(c.At) <= time.Minute {
+				recentCalls++
+			}
+		}
+		if recentCalls >= busyCallThreshold {
+			steps = append(steps, fmt.Sprintf(localizedHint(hintSlowDown, lang), recentCalls))
+		}
+	}
+
+	return steps
+}
@@ -0,0 +1,98 @@
+package mcp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxToolRequestBytes bounds the JSON arguments accepted over plain HTTP
+const maxToolRequestBytes = 1 << 20
+
+// ToolsHTTPHandler serves POST /tools/{name}, invoking a tool with the JSON
+// request body as its arguments. It lets non-MCP clients use the same tools.
+// With MCP_API_TOKENS set, requests need one of the tokens; without them,
+// tools that change wikis or start jobs aren't served.
+func (s *Server) ToolsHTTPHandler() http.Handler {
+	return s.RequireAPIToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeJSONError(w, http.StatusMethodNotAllowed, FormatErrorString("method_not_allowed", "use POST with a JSON body of tool arguments"))
+			return
+		}
+
+		name := strings.TrimPrefix(r.URL.Path, "/tools/")
+		if !s.APIToolAllowed(name) {
+			writeJSONError(w, http.StatusForbidden, FormatErrorString("tool_restricted", name+" changes the wiki or starts jobs, so it is only served over HTTP when MCP_API_TOKENS is set; use the MCP endpoint"))
+			return
+		}
+
+		// Uploads carry the file base64-encoded in the arguments
+		limit := int64(maxToolRequestBytes)
+		if name == "wiki_upload_file" {
+			limit += int64(base64.StdEncoding.EncodedLen(s.config.MaxUploadBytes))
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeJSONError(w, http.StatusRequestEntityTooLarge, FormatErrorString("request_too_large", fmt.Sprintf("request body is over %d bytes", tooLarge.Limit)))
+			return
+		}
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, FormatErrorString("bad_request", err.Error()))
+			return
+		}
+		if len(strings.TrimSpace(string(body))) == 0 {
+			body = []byte("{}")
+		}
+		if !json.Valid(body) {
+			writeJSONError(w, http.StatusBadRequest, FormatErrorString("bad_request", "request body must be a JSON object of tool arguments"))
+			return
+		}
+
+		result, err := s.CallTool(r.Context(), name, body)
+		s.writeToolResult(w, result, err)
+	}))
+}
+
+// writeToolResult writes a tool result as a plain JSON HTTP response
+func (s *Server) writeToolResult(w http.ResponseWriter, result *mcp.CallToolResult, err error) {
+	if errors.Is(err, ErrUnknownTool) {
+		writeJSONError(w, http.StatusNotFound, FormatErrorString("unknown_tool", err.Error()))
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, FormatErrorString("bad_request", err.Error()))
+		return
+	}
+
+	status := http.StatusOK
+	if result.IsError {
+		status = http.StatusUnprocessableEntity
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	for _, content := range result.Content {
+		if text, ok := content.(*mcp.TextContent); ok {
+			io.WriteString(w, text.Text)
+			return
+		}
+	}
+}
+
+func writeJSONError(w http.ResponseWriter, status int, errResp *ErrorResponse) {
+	writeJSON(w, status, errResp)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
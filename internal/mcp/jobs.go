@@ -0,0 +1,654 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/yourusername/mediawiki-mcp/internal/artifacts"
+	"github.com/yourusername/mediawiki-mcp/internal/jobs"
+	"github.com/yourusername/mediawiki-mcp/internal/tools"
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// registerJobTools registers the background job tools
+func (s *Server) registerJobTools() {
+	// wiki_crawl_category
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_crawl_category",
+		Description: "Start a background crawl of a category tree that collects all member pages. Returns a job ID; poll wiki_job for progress and the result",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"category": {
+					"type": "string",
+					"description": "Root category name (with or without 'Category:' prefix)"
+				},
+				"max_depth": {
+					"type": "integer",
+					"description": "Subcategory levels to descend (default: 1)",
+					"default": 1
+				},
+				"max_pages": {
+					"type": "integer",
+					"description": "Stop after this many pages (default: 1000)",
+					"default": 1000
+				},
+				"priority": {
+					"type": "integer",
+					"description": "Higher-priority jobs run first (default: 0)",
+					"default": 0
+				}
+			},
+			"required": ["wiki_url", "category"]
+		}`),
+	}, s.handleCrawlCategory)
+
+	// wiki_dedup
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_dedup",
+		Description: "Start a background job that finds near-duplicate pages across mirrored wikis by content hash and simhash, and reports the canonical source of each. Sources listed first are preferred as canonical. Returns a job ID; poll wiki_job for the result",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"sources": {
+					"type": "array",
+					"description": "Wikis to compare, in order of preference. Each source gives a category to crawl, explicit titles, or both",
+					"items": {
+						"type": "object",
+						"properties": {
+							"wiki_url": {
+								"type": "string",
+								"description": "Base URL of the wiki"
+							},
+							"category": {
+								"type": "string",
+								"description": "Category whose pages to include"
+							},
+							"titles": {
+								"type": "array",
+								"items": {"type": "string"},
+								"description": "Page titles to include"
+							}
+						},
+						"required": ["wiki_url"]
+					},
+					"minItems": 1
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"max_depth": {
+					"type": "integer",
+					"description": "Subcategory levels to descend when crawling a category (default: 0)",
+					"default": 0
+				},
+				"max_pages": {
+					"type": "integer",
+					"description": "Maximum pages taken from each source (default: 200)",
+					"default": 200
+				},
+				"max_distance": {
+					"type": "integer",
+					"description": "Simhash bits (of 64) that may differ for pages to count as near-duplicates (default: 3)",
+					"default": 3
+				},
+				"priority": {
+					"type": "integer",
+					"description": "Higher-priority jobs run first (default: 0)",
+					"default": 0
+				}
+			},
+			"required": ["sources"]
+		}`),
+	}, s.handleDedup)
+
+	// wiki_extract_dataset
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_extract_dataset",
+		Description: "Start a background job that walks a category and extracts an infobox, a chosen template, or a table from each page into a CSV or JSON Lines file, with page and revid provenance columns. Returns a job ID; the finished job's result describes the file and previews its first rows",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"category": {
+					"type": "string",
+					"description": "Category whose pages to extract from (with or without 'Category:' prefix)"
+				},
+				"template": {
+					"type": "string",
+					"description": "Extract this template's parameters, e.g. 'Infobox settlement' or 'Chembox'. Defaults to each page's first infobox"
+				},
+				"table": {
+					"type": "integer",
+					"description": "Extract the rows of this table on each page instead (1 = first table); headers become columns"
+				},
+				"format": {
+					"type": "string",
+					"description": "Output format (default: csv)",
+					"enum": ["csv", "jsonl"],
+					"default": "csv"
+				},
+				"max_depth": {
+					"type": "integer",
+					"description": "Subcategory levels to descend (default: 0)",
+					"default": 0
+				},
+				"max_pages": {
+					"type": "integer",
+					"description": "Stop after this many pages (default: 1000)",
+					"default": 1000
+				},
+				"priority": {
+					"type": "integer",
+					"description": "Higher-priority jobs run first (default: 0)",
+					"default": 0
+				}
+			},
+			"required": ["wiki_url", "category"]
+		}`),
+	}, s.handleExtractDataset)
+
+	// wiki_jobs
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_jobs",
+		Description: "List background jobs (crawls, scheduled reports) with their status and progress, newest first",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"status": {
+					"type": "string",
+					"description": "Only list jobs with this status",
+					"enum": ["queued", "running", "succeeded", "failed", "cancelled", "interrupted"]
+				}
+			}
+		}`),
+	}, s.handleJobs)
+
+	// wiki_job
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_job",
+		Description: "Get a background job's status, progress, and result once finished",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"job_id": {
+					"type": "string",
+					"description": "Job ID returned when the job was started"
+				}
+			},
+			"required": ["job_id"]
+		}`),
+	}, s.handleJob)
+
+	// wiki_job_cancel
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_job_cancel",
+		Description: "Cancel a queued or running background job",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"job_id": {
+					"type": "string",
+					"description": "Job ID to cancel"
+				}
+			},
+			"required": ["job_id"]
+		}`),
+	}, s.handleJobCancel)
+}
+
+// GetJobs returns the background job queue
+func (s *Server) GetJobs() *jobs.Queue {
+	return s.jobs
+}
+
+// crawlParams are the recorded parameters of a crawl_category job
+type crawlParams struct {
+	WikiURL  string `json:"wiki_url"`
+	Language string `json:"language,omitempty"`
+	Category string `json:"category"`
+	MaxDepth int    `json:"max_depth"`
+	MaxPages int    `json:"max_pages"`
+}
+
+// crawlJobResult is a crawl's result with the page list also saved as an
+// artifact, one title per line
+type crawlJobResult struct {
+	*wiki.CrawlResult
+	Artifact *artifacts.Artifact `json:"artifact,omitempty"`
+}
+
+// crawlJob returns the work of a crawl_category job, resuming from the
+// task's last checkpoint when there is one
+func (s *Server) crawlJob(p crawlParams) jobs.Func {
+	return func(ctx context.Context, task *jobs.Task) (interface{}, error) {
+		ctx = wiki.WithLanguage(ctx, p.Language)
+
+		state := tools.NewCrawlState(p.WikiURL, p.Category)
+		if saved := task.LastCheckpoint(); saved != nil {
+			if err := json.Unmarshal(saved, state); err != nil {
+				return nil, fmt.Errorf("decode crawl checkpoint: %w", err)
+			}
+		}
+
+		opts := tools.CrawlOptions{MaxDepth: p.MaxDepth, MaxPages: p.MaxPages}
+		crawl, err := tools.CrawlCategory(ctx, s.client, p.WikiURL, state, opts, func(state *tools.CrawlState) {
+			task.SetProgress(state.Result.TotalPages, fmt.Sprintf("%d categories queued", len(state.Queue)))
+			if err := task.Checkpoint(state); err != nil {
+				log.Printf("crawl: checkpoint: %v", err)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result := &crawlJobResult{CrawlResult: crawl}
+		if s.artifacts != nil {
+			artifact, err := s.savePageList(task.ID(), crawl.Pages)
+			if err != nil {
+				// The pages are still in the result
+				log.Printf("crawl: %v", err)
+			} else {
+				result.Artifact = artifact
+			}
+		}
+		return result, nil
+	}
+}
+
+// savePageList saves titles as the text artifact of job id
+func (s *Server) savePageList(id string, titles []string) (*artifacts.Artifact, error) {
+	name := id + ".txt"
+	w, err := s.artifacts.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, title := range titles {
+		w.WriteString(title)
+		w.WriteByte('\n')
+	}
+	size, err := w.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return &artifacts.Artifact{Name: name, Format: "txt", Rows: len(titles), Bytes: size, CreatedAt: time.Now().UTC()}, nil
+}
+
+// dedupSource is one wiki contributing pages to a dedup job
+type dedupSource struct {
+	WikiURL  string   `json:"wiki_url"`
+	Category string   `json:"category,omitempty"`
+	Titles   []string `json:"titles,omitempty"`
+}
+
+// dedupParams are the recorded parameters of a dedup job
+type dedupParams struct {
+	Sources     []dedupSource `json:"sources"`
+	Language    string        `json:"language,omitempty"`
+	MaxDepth    int           `json:"max_depth"`
+	MaxPages    int           `json:"max_pages"`
+	MaxDistance int           `json:"max_distance"`
+}
+
+// dedupJob returns the work of a dedup job: collect each source's pages,
+// then fingerprint and group them
+func (s *Server) dedupJob(p dedupParams) jobs.Func {
+	return func(ctx context.Context, task *jobs.Task) (interface{}, error) {
+		ctx = wiki.WithLanguage(ctx, p.Language)
+
+		var pages []wiki.PageRef
+		for _, src := range p.Sources {
+			titles := append([]string(nil), src.Titles...)
+			if src.Category != "" {
+				task.SetProgress(0, fmt.Sprintf("listing %s on %s", src.Category, src.WikiURL))
+				state := tools.NewCrawlState(src.WikiURL, src.Category)
+				crawl, err := tools.CrawlCategory(ctx, s.client, src.WikiURL, state, tools.CrawlOptions{MaxDepth: p.MaxDepth, MaxPages: p.MaxPages}, nil)
+				if err != nil {
+					return nil, err
+				}
+				titles = append(titles, crawl.Pages...)
+			}
+			if len(titles) > p.MaxPages {
+				titles = titles[:p.MaxPages]
+			}
+			for _, title := range titles {
+				pages = append(pages, wiki.PageRef{WikiURL: src.WikiURL, Title: title})
+			}
+		}
+
+		task.SetTotal(len(pages))
+		opts := tools.DedupOptions{MaxDistance: p.MaxDistance, MaxPageBytes: s.config.MaxPageBytes}
+		return tools.FindDuplicates(ctx, s.client, pages, opts, func(done int) {
+			task.SetProgress(done, "fingerprinting pages")
+		})
+	}
+}
+
+// datasetParams are the recorded parameters of an extract_dataset job
+type datasetParams struct {
+	WikiURL  string `json:"wiki_url"`
+	Language string `json:"language,omitempty"`
+	Category string `json:"category"`
+	Template string `json:"template,omitempty"`
+	Table    int    `json:"table,omitempty"`
+	Format   string `json:"format"`
+	MaxDepth int    `json:"max_depth"`
+	MaxPages int    `json:"max_pages"`
+}
+
+// datasetJob returns the work of an extract_dataset job: list the
+// category's pages, extract from each, and save the rows as an artifact
+// named after the job
+func (s *Server) datasetJob(p datasetParams) jobs.Func {
+	return func(ctx context.Context, task *jobs.Task) (interface{}, error) {
+		ctx = wiki.WithLanguage(ctx, p.Language)
+
+		task.SetProgress(0, fmt.Sprintf("listing %s", p.Category))
+		state := tools.NewCrawlState(p.WikiURL, p.Category)
+		crawl, err := tools.CrawlCategory(ctx, s.client, p.WikiURL, state, tools.CrawlOptions{MaxDepth: p.MaxDepth, MaxPages: p.MaxPages}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		task.SetTotal(len(crawl.Pages))
+		opts := tools.DatasetOptions{Template: p.Template, Table: p.Table}
+		dataset, err := tools.ExtractDataset(ctx, s.client, p.WikiURL, crawl.Pages, opts, func(done int) {
+			task.SetProgress(done, "extracting")
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if s.artifacts == nil {
+			return nil, errArtifactsDisabled
+		}
+		name := task.ID() + "." + p.Format
+		w, err := s.artifacts.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if err := tools.WriteDataset(w, p.Format, dataset); err != nil {
+			w.Abort()
+			return nil, fmt.Errorf("write dataset: %w", err)
+		}
+		size, err := w.Commit()
+		if err != nil {
+			return nil, err
+		}
+
+		source := "infobox"
+		switch {
+		case p.Table > 0:
+			source = fmt.Sprintf("table %d", p.Table)
+		case p.Template != "":
+			source = "template " + p.Template
+		}
+		return &tools.DatasetResult{
+			WikiURL:      p.WikiURL,
+			Category:     crawl.Category,
+			Source:       source,
+			Columns:      append([]string{"page", "revid"}, dataset.Columns...),
+			PagesScanned: dataset.PagesScanned,
+			PagesMatched: dataset.PagesMatched,
+			Artifact: artifacts.Artifact{
+				Name:      name,
+				Format:    p.Format,
+				Rows:      len(dataset.Rows),
+				Bytes:     size,
+				CreatedAt: time.Now().UTC(),
+			},
+			Preview: dataset.Preview(),
+		}, nil
+	}
+}
+
+// registerJobKinds makes background jobs resumable after a restart and
+// re-queues any that were interrupted
+func (s *Server) registerJobKinds() {
+	s.jobs.Register("crawl_category", func(raw json.RawMessage) (jobs.Func, error) {
+		var p crawlParams
+		if err := json.Unmarshal(raw, &p); err != nil {
+			return nil, err
+		}
+		return s.crawlJob(p), nil
+	})
+
+	s.jobs.Register("dedup", func(raw json.RawMessage) (jobs.Func, error) {
+		var p dedupParams
+		if err := json.Unmarshal(raw, &p); err != nil {
+			return nil, err
+		}
+		return s.dedupJob(p), nil
+	})
+
+	s.jobs.Register("extract_dataset", func(raw json.RawMessage) (jobs.Func, error) {
+		var p datasetParams
+		if err := json.Unmarshal(raw, &p); err != nil {
+			return nil, err
+		}
+		return s.datasetJob(p), nil
+	})
+
+	if n := s.jobs.ResumeInterrupted(); n > 0 {
+		log.Printf("jobs: resumed %d interrupted jobs", n)
+	}
+}
+
+func (s *Server) handleCrawlCategory(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL  string `json:"wiki_url"`
+		Language string `json:"language"`
+		Category string `json:"category"`
+		MaxDepth *int   `json:"max_depth"`
+		MaxPages int    `json:"max_pages"`
+		Priority int    `json:"priority"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	params := crawlParams{
+		WikiURL:  args.WikiURL,
+		Language: args.Language,
+		Category: args.Category,
+		MaxDepth: 1,
+		MaxPages: args.MaxPages,
+	}
+	if args.MaxDepth != nil {
+		params.MaxDepth = *args.MaxDepth
+	}
+	if params.MaxPages == 0 {
+		params.MaxPages = 1000
+	}
+
+	job, err := s.jobs.Submit(jobs.Spec{
+		Kind:       "crawl_category",
+		Params:     params,
+		Priority:   args.Priority,
+		RateBudget: s.config.JobRateBudget,
+		Run:        s.crawlJob(params),
+	})
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(job)
+}
+
+func (s *Server) handleDedup(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Sources     []dedupSource `json:"sources"`
+		Language    string        `json:"language"`
+		MaxDepth    int           `json:"max_depth"`
+		MaxPages    int           `json:"max_pages"`
+		MaxDistance *int          `json:"max_distance"`
+		Priority    int           `json:"priority"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	if len(args.Sources) == 0 {
+		return nil, fmt.Errorf("at least one source is required")
+	}
+	params := dedupParams{
+		Sources:     args.Sources,
+		Language:    args.Language,
+		MaxDepth:    args.MaxDepth,
+		MaxPages:    args.MaxPages,
+		MaxDistance: 3,
+	}
+	if args.MaxDistance != nil {
+		params.MaxDistance = *args.MaxDistance
+	}
+	if params.MaxPages == 0 {
+		params.MaxPages = 200
+	}
+
+	job, err := s.jobs.Submit(jobs.Spec{
+		Kind:       "dedup",
+		Params:     params,
+		Priority:   args.Priority,
+		RateBudget: s.config.JobRateBudget,
+		Run:        s.dedupJob(params),
+	})
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(job)
+}
+
+func (s *Server) handleExtractDataset(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL  string `json:"wiki_url"`
+		Language string `json:"language"`
+		Category string `json:"category"`
+		Template string `json:"template"`
+		Table    int    `json:"table"`
+		Format   string `json:"format"`
+		MaxDepth int    `json:"max_depth"`
+		MaxPages int    `json:"max_pages"`
+		Priority int    `json:"priority"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	if args.Template != "" && args.Table != 0 {
+		return nil, fmt.Errorf("give template or table, not both")
+	}
+	if args.Table < 0 {
+		return nil, fmt.Errorf("table must be 1 or more")
+	}
+	if s.artifacts == nil {
+		return nil, errArtifactsDisabled
+	}
+	params := datasetParams{
+		WikiURL:  args.WikiURL,
+		Language: args.Language,
+		Category: args.Category,
+		Template: args.Template,
+		Table:    args.Table,
+		Format:   args.Format,
+		MaxDepth: args.MaxDepth,
+		MaxPages: args.MaxPages,
+	}
+	if params.Format == "" {
+		params.Format = tools.DatasetCSV
+	}
+	if params.Format != tools.DatasetCSV && params.Format != tools.DatasetJSONL {
+		return nil, fmt.Errorf("format must be csv or jsonl")
+	}
+	if params.MaxPages == 0 {
+		params.MaxPages = 1000
+	}
+
+	job, err := s.jobs.Submit(jobs.Spec{
+		Kind:       "extract_dataset",
+		Params:     params,
+		Priority:   args.Priority,
+		RateBudget: s.config.JobRateBudget,
+		Run:        s.datasetJob(params),
+	})
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(job)
+}
+
+func (s *Server) handleJobs(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+
+	list := s.jobs.List()
+	if args.Status != "" {
+		filtered := make([]jobs.Job, 0, len(list))
+		for _, job := range list {
+			if string(job.Status) == args.Status {
+				filtered = append(filtered, job)
+			}
+		}
+		list = filtered
+	}
+
+	return s.successResult(map[string]interface{}{
+		"jobs":  list,
+		"total": len(list),
+	})
+}
+
+func (s *Server) handleJob(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		JobID string `json:"job_id"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+
+	job, err := s.jobs.Get(args.JobID)
+	if err != nil {
+		return s.errorResult(req, err, ""), nil
+	}
+
+	return s.successResult(job)
+}
+
+func (s *Server) handleJobCancel(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		JobID string `json:"job_id"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+
+	job, err := s.jobs.Cancel(args.JobID)
+	if err != nil {
+		return s.errorResult(req, err, ""), nil
+	}
+
+	return s.successResult(job)
+}
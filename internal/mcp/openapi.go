@@ -0,0 +1,228 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// OpenAPISpec builds an OpenAPI 3 document describing every registered tool
+// as a POST /tools/{name} operation, plus the /api/v1 REST routes. Tools
+// the HTTP interface doesn't serve without MCP_API_TOKENS are left out.
+// serverURL is the base URL clients use.
+func (s *Server) OpenAPISpec(serverURL string) map[string]interface{} {
+	paths := make(map[string]interface{}, len(s.tools))
+
+	for _, tool := range s.tools {
+		if !s.APIToolAllowed(tool.Name) {
+			continue
+		}
+		var schema interface{} = map[string]interface{}{"type": "object"}
+		if raw, ok := tool.InputSchema.(json.RawMessage); ok {
+			var decoded interface{}
+			if err := json.Unmarshal(raw, &decoded); err == nil {
+				schema = decoded
+			}
+		}
+
+		paths["/tools/"+tool.Name] = map[string]interface{}{
+			"post": map[string]interface{}{
+				"operationId": tool.Name,
+				"summary":     tool.Name,
+				"description": tool.Description,
+				"requestBody": map[string]interface{}{
+					"required": true,
+					"content": map[string]interface{}{
+						"application/json": map[string]interface{}{
+							"schema": schema,
+						},
+					},
+				},
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{"$ref": "#/components/responses/ToolResult"},
+					"422": map[string]interface{}{"$ref": "#/components/responses/ToolError"},
+				},
+			},
+		}
+	}
+
+	// Resource-style REST routes
+	for _, route := range restRoutes {
+		tool := s.findTool(route.Tool)
+		if tool == nil || !s.APIToolAllowed(route.Tool) {
+			continue
+		}
+		properties, required := toolSchema(tool)
+
+		isRequired := make(map[string]bool, len(required))
+		for _, name := range required {
+			isRequired[name] = true
+		}
+		inPath := make(map[string]bool, len(route.PathArgs))
+		parameters := make([]interface{}, 0, len(properties))
+		for wildcard, arg := range route.PathArgs {
+			inPath[arg] = true
+			parameters = append(parameters, map[string]interface{}{
+				"name":     wildcard,
+				"in":       "path",
+				"required": true,
+				"schema":   properties[arg],
+			})
+		}
+		for name, property := range properties {
+			if inPath[name] {
+				continue
+			}
+			parameters = append(parameters, map[string]interface{}{
+				"name":     name,
+				"in":       "query",
+				"required": isRequired[name],
+				"schema":   property,
+			})
+		}
+
+		operationID := "rest_" + tool.Name
+		if route.Name != "" {
+			operationID += "_" + route.Name
+		}
+
+		paths[route.Path] = map[string]interface{}{
+			"get": map[string]interface{}{
+				"operationId": operationID,
+				"summary":     tool.Name,
+				"description": tool.Description,
+				"parameters":  parameters,
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{"$ref": "#/components/responses/ToolResult"},
+					"422": map[string]interface{}{"$ref": "#/components/responses/ToolError"},
+				},
+			},
+		}
+	}
+
+	spec := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "MediaWiki MCP Server",
+			"version":     Version,
+			"description": "HTTP access to the MediaWiki MCP tools. Each operation takes the tool's arguments as a JSON body.",
+		},
+		"servers": []interface{}{
+			map[string]interface{}{"url": serverURL},
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{
+				"Envelope": map[string]interface{}{
+					"type":     "object",
+					"required": []string{"api_version", "data", "warnings", "cache", "meta"},
+					"properties": map[string]interface{}{
+						"api_version": map[string]interface{}{"type": "string", "example": APIVersion},
+						"data":        map[string]interface{}{"description": "The tool's result"},
+						"warnings": map[string]interface{}{
+							"type": "array",
+							"items": map[string]interface{}{
+								"type":     "object",
+								"required": []string{"enrichment", "reason"},
+								"properties": map[string]interface{}{
+									"enrichment": map[string]interface{}{"type": "string"},
+									"reason":     map[string]interface{}{"type": "string"},
+								},
+							},
+						},
+						"cache": map[string]interface{}{
+							"type":     "object",
+							"required": []string{"hit", "age"},
+							"properties": map[string]interface{}{
+								"hit": map[string]interface{}{"type": "boolean"},
+								"age": map[string]interface{}{"type": "integer", "description": "Seconds since the cached copy was fetched"},
+							},
+						},
+						"meta": map[string]interface{}{"$ref": "#/components/schemas/CallMeta"},
+						"suggested_next_calls": map[string]interface{}{
+							"type": "array",
+							"items": map[string]interface{}{
+								"type":     "object",
+								"required": []string{"tool", "arguments", "reason"},
+								"properties": map[string]interface{}{
+									"tool":      map[string]interface{}{"type": "string"},
+									"arguments": map[string]interface{}{"type": "object"},
+									"reason":    map[string]interface{}{"type": "string"},
+								},
+							},
+						},
+					},
+				},
+				"ErrorResponse": map[string]interface{}{
+					"type":     "object",
+					"required": []string{"error", "message"},
+					"properties": map[string]interface{}{
+						"api_version": map[string]interface{}{"type": "string"},
+						"error":       map[string]interface{}{"type": "string"},
+						"message":     map[string]interface{}{"type": "string"},
+						"hint":        map[string]interface{}{"type": "string"},
+						"next_steps":  map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+						"details":     map[string]interface{}{"type": "object"},
+						"meta":        map[string]interface{}{"$ref": "#/components/schemas/CallMeta"},
+					},
+				},
+				"CallMeta": map[string]interface{}{
+					"type":     "object",
+					"required": []string{"cache_hit", "upstream_calls", "latency_ms"},
+					"properties": map[string]interface{}{
+						"cache_hit":      map[string]interface{}{"type": "boolean"},
+						"upstream_calls": map[string]interface{}{"type": "integer", "description": "HTTP requests made to wikis, including retries"},
+						"latency_ms":     map[string]interface{}{"type": "integer", "description": "Time spent on the call in the server"},
+					},
+				},
+			},
+			"responses": map[string]interface{}{
+				"ToolResult": map[string]interface{}{
+					"description": "The tool's result in a versioned envelope",
+					"content": map[string]interface{}{
+						"application/json": map[string]interface{}{
+							"schema": map[string]interface{}{"$ref": "#/components/schemas/Envelope"},
+						},
+					},
+				},
+				"ToolError": map[string]interface{}{
+					"description": "The tool failed; the body is a structured error",
+					"content": map[string]interface{}{
+						"application/json": map[string]interface{}{
+							"schema": map[string]interface{}{"$ref": "#/components/schemas/ErrorResponse"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Requests need a token from MCP_API_TOKENS
+	if s.APIAuthRequired() {
+		spec["components"].(map[string]interface{})["securitySchemes"] = map[string]interface{}{
+			"bearer": map[string]interface{}{"type": "http", "scheme": "bearer"},
+		}
+		spec["security"] = []interface{}{map[string]interface{}{"bearer": []interface{}{}}}
+	}
+	return spec
+}
+
+// OpenAPIHandler serves the OpenAPI document as JSON
+func (s *Server) OpenAPIHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s.OpenAPISpec(requestBaseURL(r)))
+	})
+}
+
+// requestBaseURL reconstructs the externally visible base URL of a request,
+// honoring reverse proxy headers
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host
+}
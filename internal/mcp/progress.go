@@ -0,0 +1,33 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// progressNotifier returns a function that sends progress notifications
+// for a tool call, or nil when the client didn't ask for them with a
+// progress token. Plain HTTP calls have no session to notify.
+func progressNotifier(ctx context.Context, req *mcp.CallToolRequest, noun string) func(done, total int) {
+	if req.Session == nil || req.Params == nil {
+		return nil
+	}
+	token := req.Params.GetProgressToken()
+	if token == nil {
+		return nil
+	}
+	return func(done, total int) {
+		params := &mcp.ProgressNotificationParams{
+			ProgressToken: token,
+			Progress:      float64(done),
+			Total:         float64(total),
+			Message:       fmt.Sprintf("%d of %d %s", done, total, noun),
+		}
+		if err := req.Session.NotifyProgress(ctx, params); err != nil {
+			log.Printf("progress notification: %v", err)
+		}
+	}
+}
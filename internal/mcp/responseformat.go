@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// responseFormatProperty is the response_format argument every tool takes
+// on the MCP endpoint
+const responseFormatProperty = `"response_format": {
+	"type": "string",
+	"enum": ["json", "yaml"],
+	"description": "Format of the result: json for programs, or yaml, which is easier for people to read, with page content as literal blocks. Defaults to the server's MCP_RESPONSE_FORMAT"
+}`
+
+// withResponseFormat adds the response_format argument to a tool's input
+// schema
+func withResponseFormat(schema any) any {
+	raw, err := json.Marshal(schema)
+	if err != nil {
+		return schema
+	}
+	var top map[string]json.RawMessage
+	if json.Unmarshal(raw, &top) != nil {
+		return schema
+	}
+	properties := bytes.TrimSpace(top["properties"])
+	if len(properties) < 2 || properties[0] != '{' {
+		return schema
+	}
+
+	inner := bytes.TrimSpace(properties[1 : len(properties)-1])
+	var b bytes.Buffer
+	b.WriteByte('{')
+	if len(inner) > 0 {
+		b.Write(inner)
+		b.WriteByte(',')
+	}
+	b.WriteString(responseFormatProperty)
+	b.WriteByte('}')
+	top["properties"] = b.Bytes()
+
+	extended, err := json.Marshal(top)
+	if err != nil {
+		return schema
+	}
+	return json.RawMessage(extended)
+}
+
+// formatted returns results in the format the call's response_format
+// argument asks for, or else the configured one. Tools make their results
+// as JSON, and YAML is converted from it last, error results included; a
+// result that isn't JSON fails the call rather than going out as is. The
+// HTTP and gRPC interfaces call the handlers underneath and stay JSON.
+func (s *Server) formatted(handler mcp.ToolHandler) mcp.ToolHandler {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		format := s.config.ResponseFormat
+		var args struct {
+			ResponseFormat string `json:"response_format"`
+		}
+		if len(req.Params.Arguments) > 0 && json.Unmarshal(req.Params.Arguments, &args) == nil && args.ResponseFormat != "" {
+			format = strings.ToLower(args.ResponseFormat)
+		}
+		if format != "json" && format != "yaml" {
+			return nil, fmt.Errorf("response_format must be json or yaml, got %q", args.ResponseFormat)
+		}
+
+		result, err := handler(ctx, req)
+		if err != nil || result == nil || format == "json" {
+			return result, err
+		}
+		for _, content := range result.Content {
+			text, ok := content.(*mcp.TextContent)
+			if !ok {
+				continue
+			}
+			converted, err := jsonToYAML([]byte(text.Text))
+			if err != nil {
+				return nil, fmt.Errorf("render result as YAML: %w", err)
+			}
+			text.Text = string(converted)
+		}
+		return result, nil
+	}
+}
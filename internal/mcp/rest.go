@@ -0,0 +1,130 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// restRoute maps a REST endpoint onto a tool
+type restRoute struct {
+	Path     string            // ServeMux path pattern (GET only)
+	Tool     string            // tool invoked by the route
+	PathArgs map[string]string // path wildcard -> tool argument
+	Name     string            // distinguishes the OpenAPI operation when several routes share a tool
+}
+
+// restRoutes are the resource-style REST endpoints under /api/v1. Query
+// parameters are passed through as tool arguments; titles containing "/"
+// must be escaped as %2F.
+var restRoutes = []restRoute{
+	{Path: "/api/v1/info", Tool: "wiki_info"},
+	{Path: "/api/v1/search", Tool: "wiki_search"},
+	{Path: "/api/v1/page/{title}", Tool: "wiki_page_full", PathArgs: map[string]string{"title": "title"}},
+	{Path: "/api/v1/page/{title}/outline", Tool: "wiki_page_outline", PathArgs: map[string]string{"title": "title"}},
+	{Path: "/api/v1/page/{title}/sections/{index}", Tool: "wiki_page_section", PathArgs: map[string]string{"title": "title", "index": "section_index"}},
+	{Path: "/api/v1/page/{title}/backlinks", Tool: "wiki_backlinks", PathArgs: map[string]string{"title": "title"}},
+	{Path: "/api/v1/page/{title}/changed", Tool: "wiki_has_changed", PathArgs: map[string]string{"title": "title"}},
+	{Path: "/api/v1/page/{title}/compare", Tool: "wiki_compare", PathArgs: map[string]string{"title": "title"}},
+	{Path: "/api/v1/page/{title}/history-search", Tool: "wiki_search_history", PathArgs: map[string]string{"title": "title"}},
+	{Path: "/api/v1/page/{title}/blame", Tool: "wiki_blame", PathArgs: map[string]string{"title": "title"}},
+	{Path: "/api/v1/page/{title}/subpages", Tool: "wiki_subpages", PathArgs: map[string]string{"title": "title"}},
+	{Path: "/api/v1/page/{title}/discussions", Tool: "wiki_discussions", PathArgs: map[string]string{"title": "title"}},
+	{Path: "/api/v1/page/{title}/coordinates", Tool: "wiki_page_coordinates", PathArgs: map[string]string{"title": "title"}},
+	{Path: "/api/v1/page/{title}/timeline", Tool: "wiki_page_timeline", PathArgs: map[string]string{"title": "title"}},
+	{Path: "/api/v1/page/{title}/references", Tool: "wiki_references", PathArgs: map[string]string{"title": "title"}},
+	{Path: "/api/v1/page/{title}/sister-links", Tool: "wiki_sister_links", PathArgs: map[string]string{"title": "title"}},
+	{Path: "/api/v1/page/{title}/languages/{target_language}", Tool: "wiki_compare_languages", PathArgs: map[string]string{"title": "title", "target_language": "target_language"}},
+	{Path: "/api/v1/page/{title}/glossary", Tool: "wiki_glossary", PathArgs: map[string]string{"title": "title"}},
+	{Path: "/api/v1/category/{category}", Tool: "wiki_category", PathArgs: map[string]string{"category": "category"}},
+	{Path: "/api/v1/category/{category}/infobox-schema", Tool: "wiki_infobox_schema", PathArgs: map[string]string{"category": "category"}},
+	{Path: "/api/v1/category/{category}/glossary", Tool: "wiki_glossary", PathArgs: map[string]string{"category": "category"}, Name: "category"},
+	{Path: "/api/v1/reports/{report}", Tool: "wiki_maintenance_report", PathArgs: map[string]string{"report": "report"}},
+}
+
+// RESTHandler serves the /api/v1 REST facade. Routes call the same tool
+// handlers as MCP, so caching, rate limiting, filtering, and the
+// MCP_API_TOKENS check are shared.
+func (s *Server) RESTHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	for _, route := range restRoutes {
+		route := route
+		tool := s.findTool(route.Tool)
+		if tool == nil || !s.APIToolAllowed(route.Tool) {
+			continue
+		}
+		properties, _ := toolSchema(tool)
+
+		mux.HandleFunc("GET "+route.Path, func(w http.ResponseWriter, r *http.Request) {
+			args := make(map[string]interface{})
+			for key, values := range r.URL.Query() {
+				if len(values) > 0 {
+					args[key] = typedArgument(properties[key], values[0])
+				}
+			}
+			for wildcard, arg := range route.PathArgs {
+				args[arg] = typedArgument(properties[arg], r.PathValue(wildcard))
+			}
+
+			body, err := json.Marshal(args)
+			if err != nil {
+				writeJSONError(w, http.StatusBadRequest, FormatErrorString("bad_request", err.Error()))
+				return
+			}
+
+			result, err := s.CallTool(r.Context(), route.Tool, body)
+			s.writeToolResult(w, result, err)
+		})
+	}
+
+	mux.HandleFunc("/api/v1/", func(w http.ResponseWriter, r *http.Request) {
+		writeJSONError(w, http.StatusNotFound, FormatErrorString("not_found", "no such endpoint; see /openapi.json"))
+	})
+
+	return s.RequireAPIToken(mux)
+}
+
+// findTool returns a registered tool by name
+func (s *Server) findTool(name string) *mcp.Tool {
+	for _, tool := range s.tools {
+		if tool.Name == name {
+			return tool
+		}
+	}
+	return nil
+}
+
+// toolSchema decodes a tool's input schema into its properties and required
+// argument names
+func toolSchema(tool *mcp.Tool) (map[string]map[string]interface{}, []string) {
+	var schema struct {
+		Properties map[string]map[string]interface{} `json:"properties"`
+		Required   []string                          `json:"required"`
+	}
+	if raw, ok := tool.InputSchema.(json.RawMessage); ok {
+		json.Unmarshal(raw, &schema)
+	}
+	return schema.Properties, schema.Required
+}
+
+// typedArgument converts a string parameter to the type its schema declares,
+// leaving it as a string if conversion fails so the tool reports the error
+func typedArgument(property map[string]interface{}, value string) interface{} {
+	switch property["type"] {
+	case "integer":
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	case "array":
+		return strings.Split(value, ",")
+	}
+	return value
+}
@@ -0,0 +1,110 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/yourusername/mediawiki-mcp/config"
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// resultCacheTTL returns how long a tool's results may be served from the
+// result cache, or 0 for tools whose results must not be cached (jobs and
+// anything with side effects). MCP_RESULT_CACHE_TTLS only applies to tools
+// in config.ResultCacheTools.
+func (s *Server) resultCacheTTL(tool string) time.Duration {
+	if !config.ResultCacheTools[tool] {
+		return 0
+	}
+	if ttl, ok := s.config.ResultCacheTTLs[tool]; ok {
+		return ttl
+	}
+	if tool == "wiki_info" || tool == "wiki_farm_list" {
+		return s.config.CacheTTLInfo
+	}
+	return s.config.CacheTTL
+}
+
+// cached serves repeated calls with identical arguments from the cache,
+// including all post-processing. Only complete successful results (without
+// warnings) are stored, as text, so callers never share a result value.
+func (s *Server) cached(tool string, handler mcp.ToolHandler) mcp.ToolHandler {
+	ttl := s.resultCacheTTL(tool)
+	if !s.config.ResultCache || ttl <= 0 {
+		return handler
+	}
+
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		key, err := resultCacheKey(req)
+		if err != nil {
+			return handler(ctx, req)
+		}
+
+		cache := s.client.GetCache()
+		text, age, ok := cache.GetWithAge(key)
+		wiki.RecordCacheLookup(ctx, ok, age)
+		if ok {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: text.(string)},
+				},
+			}, nil
+		}
+
+		result, err := handler(ctx, req)
+		if err == nil && result != nil && !result.IsError && len(result.Content) == 1 && len(wiki.Warnings(ctx)) == 0 {
+			if text, ok := result.Content[0].(*mcp.TextContent); ok {
+				cache.Set(key, text.Text, ttl)
+			}
+		}
+		return result, err
+	}
+}
+
+// resultCacheKey derives a cache key from the tool name and a hash of its
+// canonical arguments, prefixed by the page concerned so page edits can
+// invalidate it
+func resultCacheKey(req *mcp.CallToolRequest) (string, error) {
+	args, err := canonicalArguments(req.Params.Arguments)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(req.Params.Name))
+	h.Write([]byte{0})
+	h.Write(args)
+
+	call := callFromRequest(req, time.Time{})
+	return wiki.ToolResultCacheKey(call.WikiURL, call.Title, hex.EncodeToString(h.Sum(nil))), nil
+}
+
+// canonicalArguments re-encodes tool arguments with sorted keys, no
+// insignificant whitespace, and null members dropped, so equivalent
+// argument objects hash the same
+func canonicalArguments(raw json.RawMessage) ([]byte, error) {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return []byte("{}"), nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var args interface{}
+	if err := dec.Decode(&args); err != nil {
+		return nil, err
+	}
+	if obj, ok := args.(map[string]interface{}); ok {
+		for key, val := range obj {
+			if val == nil {
+				delete(obj, key)
+			}
+		}
+	}
+	return json.Marshal(args)
+}
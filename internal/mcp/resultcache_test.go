@@ -0,0 +1,102 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/yourusername/mediawiki-mcp/config"
+)
+
+func toolRequest(name, args string) *mcp.CallToolRequest {
+	return &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: name, Arguments: json.RawMessage(args)}}
+}
+
+func TestCanonicalArguments(t *testing.T) {
+	tests := []struct {
+		args, want string
+	}{
+		{`{"title":"Town","wiki_url":"https://wiki.example.org"}`, `{"title":"Town","wiki_url":"https://wiki.example.org"}`},
+		{` { "wiki_url" : "https://wiki.example.org", "title" : "Town" } `, `{"title":"Town","wiki_url":"https://wiki.example.org"}`},
+		{`{"title":"Town","section_index":null}`, `{"title":"Town"}`},
+		{`{"limit":10.50}`, `{"limit":10.50}`},
+		{``, `{}`},
+	}
+	for _, tt := range tests {
+		got, err := canonicalArguments(json.RawMessage(tt.args))
+		if err != nil || string(got) != tt.want {
+			t.Errorf("canonicalArguments(%s) = %s, %v, want %s", tt.args, got, err, tt.want)
+		}
+	}
+
+	if _, err := canonicalArguments(json.RawMessage(`{"title":`)); err == nil {
+		t.Error("canonicalArguments(truncated) succeeded")
+	}
+}
+
+func TestResultCacheKey(t *testing.T) {
+	key := func(name, args string) string {
+		t.Helper()
+		k, err := resultCacheKey(toolRequest(name, args))
+		if err != nil {
+			t.Fatalf("resultCacheKey(%s, %s) error = %v", name, args, err)
+		}
+		return k
+	}
+
+	base := key("wiki_page_outline", `{"wiki_url":"https://wiki.example.org","title":"Town","include_toc":true}`)
+	if got := key("wiki_page_outline", `{"include_toc":true,"title":"Town","wiki_url":"https://wiki.example.org"}`); got != base {
+		t.Errorf("reordered arguments give key %q, want %q", got, base)
+	}
+
+	// Calls that differ in any way, including ones whose concatenated
+	// parts would be the same, must not share a key
+	distinct := []struct{ name, args string }{
+		{"wiki_page_outline", `{"wiki_url":"https://wiki.example.org","title":"Town","include_toc":false}`},
+		{"wiki_page_outline", `{"wiki_url":"https://wiki.example.org","title":"Town"}`},
+		{"wiki_page_outline", `{"wiki_url":"https://wiki.example.org","title":"Town","include_toc":"true"}`},
+		{"wiki_page_full", `{"wiki_url":"https://wiki.example.org","title":"Town","include_toc":true}`},
+		{"wiki_page_outline", `{"wiki_url":"https://wiki.example.org","title":"Towns","include_toc":true}`},
+		{"wiki_page_outline", `{"wiki_url":"https://other.example.org","title":"Town","include_toc":true}`},
+		{"wiki_page_outline", `{"wiki_url":"https://wiki.example.org/","title":"Town\u0000","include_toc":true}`},
+		{"wiki_search", `{"wiki_url":"https://wiki.example.org","query":"a","in_category":"b c"}`},
+		{"wiki_search", `{"wiki_url":"https://wiki.example.org","query":"a b","in_category":"c"}`},
+	}
+	seen := map[string]int{base: -1}
+	for i, call := range distinct {
+		k := key(call.name, call.args)
+		if j, ok := seen[k]; ok {
+			t.Errorf("calls %d and %d share key %q", i, j, k)
+		}
+		seen[k] = i
+	}
+}
+
+func TestResultCacheTTL(t *testing.T) {
+	s := &Server{config: &config.Config{
+		CacheTTL:     5 * time.Minute,
+		CacheTTLInfo: time.Hour,
+		ResultCacheTTLs: map[string]time.Duration{
+			"wiki_search":    time.Minute,
+			"wiki_edit_page": 5 * time.Minute,
+		},
+	}}
+
+	tests := []struct {
+		tool string
+		want time.Duration
+	}{
+		{"wiki_search", time.Minute},
+		{"wiki_page_full", 5 * time.Minute},
+		{"wiki_info", time.Hour},
+		{"wiki_edit_page", 0}, // overrides don't make side effects cacheable
+		{"wiki_job", 0},
+	}
+	for _, tt := range tests {
+		if got := s.resultCacheTTL(tt.tool); got != tt.want {
+			t.Errorf("resultCacheTTL(%s) = %s, want %s", tt.tool, got, tt.want)
+		}
+	}
+}
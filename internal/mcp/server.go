@@ -1,27 +1,74 @@
 package mcp
 
 import (
+	"cmp"
 	"context"
+	"crypto/rand"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/yourusername/mediawiki-mcp/config"
+	"github.com/yourusername/mediawiki-mcp/internal/approval"
+	"github.com/yourusername/mediawiki-mcp/internal/artifacts"
+	"github.com/yourusername/mediawiki-mcp/internal/jobs"
+	"github.com/yourusername/mediawiki-mcp/internal/profiles"
+	"github.com/yourusername/mediawiki-mcp/internal/store"
 	"github.com/yourusername/mediawiki-mcp/internal/tools"
 	"github.com/yourusername/mediawiki-mcp/internal/wiki"
 )
 
+// Version is the server version reported to MCP clients and in API specs
+const Version = config.Version
+
+// ErrUnknownTool is returned by CallTool for unregistered tool names
+var ErrUnknownTool = errors.New("unknown tool")
+
 // Server wraps the MCP server with our wiki client
 type Server struct {
-	mcp    *mcp.Server
-	client *wiki.Client
-	config *config.Config
+	mcp     *mcp.Server
+	client  *wiki.Client
+	config  *config.Config
+	history *callHistory
+	memory  *sessionMemory // content sent per session, for MCP_SESSION_DEDUP
+	stats   *statsCollector
+	filter  *contentFilter
+	jobs    *jobs.Queue
+	db      *store.Store // nil without a configured database
+
+	// Files produced by jobs; nil if the directory can't be created
+	artifacts      *artifacts.Store
+	artifactSecret []byte // key for signed download URLs
+
+	// Edits held for review when approval is required
+	approvals *approval.Queue
+
+	// Per-wiki settings from MCP_WIKI_PROFILES_FILE
+	profiles []profiles.Profile
+
+	// Registered tools in registration order, for non-MCP interfaces
+	tools    []*mcp.Tool
+	handlers map[string]mcp.ToolHandler
 }
 
-// NewServer creates a new MCP server
-func NewServer(cfg *config.Config) *Server {
+// NewServer creates a new MCP server. db may be nil; when set it persists
+// jobs and records every tool call in the audit log.
+func NewServer(cfg *config.Config, db *store.Store) *Server {
 	s := &Server{
-		config: cfg,
+		config:   cfg,
+		db:       db,
+		history:  newCallHistory(),
+		memory:   newSessionMemory(),
+		stats:    newStatsCollector(time.Now()),
+		filter:   newContentFilter(cfg),
+		handlers: make(map[string]mcp.ToolHandler),
 		client: wiki.NewClient(
 			cfg.UserAgent,
 			cfg.RequestTimeout,
@@ -30,11 +77,50 @@ func NewServer(cfg *config.Config) *Server {
 			cfg.CacheTTLInfo,
 		),
 	}
+	s.client.SetMaxResponseBytes(int64(cfg.MaxResponseBytes))
+	s.client.SetParseLimits(cfg.MaxHTMLNodes, cfg.MaxHTMLDepth)
+	s.client.GetCache().SetCompressThreshold(cfg.CacheCompressMin)
+	s.client.GetCache().SetCleanupInterval(cfg.CacheCleanupInterval)
+	s.client.SetInteractiveShare(cfg.InteractiveShare)
+	if cfg.PlaceholderUserAgent() {
+		s.client.WarnPlaceholderUserAgent()
+	}
+
+	if artifactStore, err := artifacts.NewStore(cfg.ArtifactsDir); err != nil {
+		log.Printf("Warning: dataset exports and crawl page lists are disabled: %v", err)
+	} else {
+		s.artifacts = artifactStore
+	}
+	s.artifactSecret = []byte(cfg.ArtifactsSecret)
+	if len(s.artifactSecret) == 0 {
+		// Signed URLs then stop working on restart
+		s.artifactSecret = make([]byte, 32)
+		rand.Read(s.artifactSecret)
+	}
+
+	// Background job queue, persisted in the database or a jobs file
+	var jobStore jobs.Store
+	switch {
+	case db != nil:
+		jobStore = db
+	case cfg.JobsFile != "":
+		jobStore = jobs.NewFileStore(cfg.JobsFile)
+	}
+	s.jobs = jobs.NewQueue(cfg.JobWorkers, jobStore)
+	s.registerJobKinds()
+	s.jobs.Start()
+
+	// Edit review queue, persisted in the database when there is one
+	var approvalStore approval.Store
+	if db != nil {
+		approvalStore = db
+	}
+	s.approvals = approval.NewQueue(approvalStore, s.commitEdit)
 
 	// Create MCP server
 	impl := &mcp.Implementation{
 		Name:    "mediawiki-mcp",
-		Version: "1.0.0",
+		Version: Version,
 	}
 
 	s.mcp = mcp.NewServer(impl, nil)
@@ -45,15 +131,53 @@ func NewServer(cfg *config.Config) *Server {
 	return s
 }
 
+// Close stops the server's background goroutines: the job workers and the
+// cache cleanup. Calls in progress finish, but the server shouldn't be
+// used afterwards.
+func (s *Server) Close() {
+	s.jobs.Stop()
+	s.client.Close()
+}
+
 // GetMCPServer returns the underlying MCP server
 func (s *Server) GetMCPServer() *mcp.Server {
 	return s.mcp
 }
 
+// GetApprovals returns the edit review queue
+func (s *Server) GetApprovals() *approval.Queue {
+	return s.approvals
+}
+
+// GetClient returns the shared wiki client
+func (s *Server) GetClient() *wiki.Client {
+	return s.client
+}
+
+// SetProfiles applies wiki profiles, opening the backends of wikis that
+// aren't MediaWiki servers reached over HTTP and setting the accounts
+// requests are made with
+func (s *Server) SetProfiles(list []profiles.Profile) error {
+	for _, p := range list {
+		backend, err := p.Open()
+		if err != nil {
+			return err
+		}
+		if backend != nil {
+			s.client.SetBackend(p.WikiURL, backend)
+		}
+		if auth, ok := p.Auth(); ok {
+			s.client.SetAuth(p.WikiURL, auth)
+		}
+	}
+	s.profiles = list
+	return nil
+}
+
 // registerTools registers all tools with the MCP server
 func (s *Server) registerTools() {
 	// wiki_info
-	s.mcp.AddTool(&mcp.Tool{
+	s.addTool(&mcp.Tool{
 		Name:        "wiki_info",
 		Description: "Get metadata about a MediaWiki site including name, language, article count, and namespaces",
 		InputSchema: json.RawMessage(`{
@@ -62,14 +186,38 @@ func (s *Server) registerTools() {
 				"wiki_url": {
 					"type": "string",
 					"description": "Base URL of the wiki (e.g. 'https://en.wikipedia.org')"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
 				}
 			},
 			"required": ["wiki_url"]
 		}`),
 	}, s.handleWikiInfo)
 
+	// wiki_whoami
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_whoami",
+		Description: "Get the account this server uses on a wiki: user name, groups, rights, rate limits, edit count, and any block. Use it to check an action is permitted before trying it, e.g. the delete, rollback, or upload right. Without configured credentials it describes the logged-out user the wiki sees",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				}
+			},
+			"required": ["wiki_url"]
+		}`),
+	}, s.handleWhoAmI)
+
 	// wiki_search
-	s.mcp.AddTool(&mcp.Tool{
+	s.addTool(&mcp.Tool{
 		Name:        "wiki_search",
 		Description: "Search a MediaWiki site for pages matching a query. Returns titles, snippets, and page metadata",
 		InputSchema: json.RawMessage(`{
@@ -79,14 +227,22 @@ func (s *Server) registerTools() {
 					"type": "string",
 					"description": "Base URL of the wiki"
 				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
 				"query": {
 					"type": "string",
 					"description": "Search terms"
 				},
 				"limit": {
 					"type": "integer",
-					"description": "Maximum number of results (default: 10)",
+					"description": "Maximum number of results (default: 10). Limits above the server maximum (MCP_MAX_SEARCH_LIMIT, 500 unless configured) are lowered to it and reported in limit_clamped",
 					"default": 10
+				},
+				"in_category": {
+					"type": "string",
+					"description": "Only return pages in this category (with or without 'Category:' prefix)"
 				}
 			},
 			"required": ["wiki_url", "query"]
@@ -94,7 +250,7 @@ func (s *Server) registerTools() {
 	}, s.handleWikiSearch)
 
 	// wiki_page_outline
-	s.mcp.AddTool(&mcp.Tool{
+	s.addTool(&mcp.Tool{
 		Name:        "wiki_page_outline",
 		Description: "Get page structure with section tree, summary, infobox, and metadata. Use this before fetching full content to understand page organization",
 		InputSchema: json.RawMessage(`{
@@ -104,9 +260,36 @@ func (s *Server) registerTools() {
 					"type": "string",
 					"description": "Base URL of the wiki"
 				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
 				"title": {
 					"type": "string",
 					"description": "Page title"
+				},
+				"relevance_query": {
+					"type": "string",
+					"description": "Score each section's heading and preview against this query (BM25) and return the score as relevance, from 0 to 1 for the best-matching section, to pick which sections to fetch; the best matches are listed in suggested_next_calls. Without it, suggestions use your last wiki_search query on this wiki"
+				},
+				"query": {
+					"type": "string",
+					"description": "Alias of relevance_query"
+				},
+				"include_toc": {
+					"type": "boolean",
+					"description": "Also return a rendered table of contents (numbered Markdown list matching the wiki's section numbers)",
+					"default": false
+				},
+				"include_readability": {
+					"type": "boolean",
+					"description": "Also measure each section's readability (sentence count, average sentence length, Flesch reading ease approximation) to find the densest sections",
+					"default": false
+				},
+				"include_link_details": {
+					"type": "boolean",
+					"description": "Also return link_details: the lead's links to wiki pages as {title, anchor, display_text}, keeping the section each one points to",
+					"default": false
 				}
 			},
 			"required": ["wiki_url", "title"]
@@ -114,7 +297,7 @@ func (s *Server) registerTools() {
 	}, s.handlePageOutline)
 
 	// wiki_page_section
-	s.mcp.AddTool(&mcp.Tool{
+	s.addTool(&mcp.Tool{
 		Name:        "wiki_page_section",
 		Description: "Get full content of a specific page section by index. If section index is invalid, an error will suggest calling wiki_page_outline to get fresh indices",
 		InputSchema: json.RawMessage(`{
@@ -124,6 +307,10 @@ func (s *Server) registerTools() {
 					"type": "string",
 					"description": "Base URL of the wiki"
 				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
 				"title": {
 					"type": "string",
 					"description": "Page title"
@@ -131,6 +318,11 @@ func (s *Server) registerTools() {
 				"section_index": {
 					"type": "integer",
 					"description": "Section index from wiki_page_outline"
+				},
+				"include_link_details": {
+					"type": "boolean",
+					"description": "Also return link_details: the section's links to wiki pages as {title, anchor, display_text}, keeping the section each one points to",
+					"default": false
 				}
 			},
 			"required": ["wiki_url", "title", "section_index"]
@@ -138,7 +330,7 @@ func (s *Server) registerTools() {
 	}, s.handlePageSection)
 
 	// wiki_page_full
-	s.mcp.AddTool(&mcp.Tool{
+	s.addTool(&mcp.Tool{
 		Name:        "wiki_page_full",
 		Description: "Get entire page content. Warning: may be large. Consider using wiki_page_outline + wiki_page_section for targeted retrieval",
 		InputSchema: json.RawMessage(`{
@@ -148,6 +340,10 @@ func (s *Server) registerTools() {
 					"type": "string",
 					"description": "Base URL of the wiki"
 				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
 				"title": {
 					"type": "string",
 					"description": "Page title"
@@ -157,10 +353,46 @@ func (s *Server) registerTools() {
 		}`),
 	}, s.handlePageFull)
 
+	// wiki_has_changed
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_has_changed",
+		Description: "Check whether a page or section fetched earlier has changed, given the source.revid and/or content_hash it was returned with. Cheaper than re-fetching: an unchanged revision is answered with one lookup, and with a content_hash an edit elsewhere on the page is reported as content_unchanged",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"title": {
+					"type": "string",
+					"description": "Page title"
+				},
+				"revid": {
+					"type": "integer",
+					"description": "source.revid of the earlier result"
+				},
+				"content_hash": {
+					"type": "string",
+					"description": "content_hash of the earlier wiki_page_full or wiki_page_section result"
+				},
+				"section_index": {
+					"type": "integer",
+					"description": "Section index, when content_hash is from wiki_page_section"
+				}
+			},
+			"required": ["wiki_url", "title"]
+		}`),
+	}, s.handleHasChanged)
+
 	// wiki_category
-	s.mcp.AddTool(&mcp.Tool{
+	s.addTool(&mcp.Tool{
 		Name:        "wiki_category",
-		Description: "Get pages and subcategories within a category",
+		Description: "Get pages and subcategories within a category. total_members and counts report the full category size, so compare them with the members returned to decide whether to page further",
 		InputSchema: json.RawMessage(`{
 			"type": "object",
 			"properties": {
@@ -168,14 +400,38 @@ func (s *Server) registerTools() {
 					"type": "string",
 					"description": "Base URL of the wiki"
 				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
 				"category": {
 					"type": "string",
 					"description": "Category name (with or without 'Category:' prefix)"
 				},
 				"limit": {
 					"type": "integer",
-					"description": "Maximum number of results (default: 20)",
+					"description": "Maximum number of results (default: 20). Limits above the server maximum (MCP_MAX_CATEGORY_LIMIT, or MCP_CATEGORY_ALL_MAX with all=true) are lowered to it and reported in limit_clamped",
 					"default": 20
+				},
+				"member_type": {
+					"type": "string",
+					"enum": ["page", "subcat", "file"],
+					"description": "Only return members of this type (default: all)"
+				},
+				"sort": {
+					"type": "string",
+					"enum": ["sortkey", "timestamp"],
+					"description": "Order by the category sort key or by when members were added (default: sortkey)"
+				},
+				"direction": {
+					"type": "string",
+					"enum": ["asc", "desc"],
+					"description": "Sort direction; use desc with sort=timestamp for the newest members first (default: asc)"
+				},
+				"all": {
+					"type": "boolean",
+					"description": "Follow continuations to return every member, up to the server's cap (limit, if given, caps it further). Sends progress notifications when the call has a progress token; truncated is true if the cap was reached first",
+					"default": false
 				}
 			},
 			"required": ["wiki_url", "category"]
@@ -183,7 +439,7 @@ func (s *Server) registerTools() {
 	}, s.handleCategory)
 
 	// wiki_backlinks
-	s.mcp.AddTool(&mcp.Tool{
+	s.addTool(&mcp.Tool{
 		Name:        "wiki_backlinks",
 		Description: "Find pages that link to a given page",
 		InputSchema: json.RawMessage(`{
@@ -193,13 +449,17 @@ func (s *Server) registerTools() {
 					"type": "string",
 					"description": "Base URL of the wiki"
 				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
 				"title": {
 					"type": "string",
 					"description": "Page title to find backlinks for"
 				},
 				"limit": {
 					"type": "integer",
-					"description": "Maximum number of results (default: 20)",
+					"description": "Maximum number of results (default: 20). Limits above the server maximum (MCP_MAX_BACKLINKS_LIMIT, 500 unless configured) are lowered to it and reported in limit_clamped",
 					"default": 20
 				}
 			},
@@ -208,9 +468,9 @@ func (s *Server) registerTools() {
 	}, s.handleBacklinks)
 
 	// wiki_compare
-	s.mcp.AddTool(&mcp.Tool{
+	s.addTool(&mcp.Tool{
 		Name:        "wiki_compare",
-		Description: "Compare two revisions of a page to see what changed",
+		Description: "Compare two revisions of a page to see what changed. Revisions can be given as dates or timestamps, e.g. to compare the page as of 2024-01-01 with the current version",
 		InputSchema: json.RawMessage(`{
 			"type": "object",
 			"properties": {
@@ -218,179 +478,1213 @@ func (s *Server) registerTools() {
 					"type": "string",
 					"description": "Base URL of the wiki"
 				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
 				"title": {
 					"type": "string",
 					"description": "Page title"
 				},
 				"from_revision": {
 					"type": "string",
-					"description": "Starting revision ('prev' or revision ID)",
+					"description": "Starting revision: a revision ID, 'prev' (the revision before to_revision), 'current', or a date or timestamp (e.g. '2024-01-01' or '2024-01-01T12:00:00Z') for the version current at that time; a date alone means the end of that day",
 					"default": "prev"
 				},
 				"to_revision": {
 					"type": "string",
-					"description": "Ending revision ('current', 'next', or revision ID)",
+					"description": "Ending revision: a revision ID, 'current' (or 'cur'), 'prev' or 'next' (relative to from_revision), or a date or timestamp for the version current at that time",
 					"default": "current"
 				}
 			},
 			"required": ["wiki_url", "title"]
 		}`),
 	}, s.handleCompare)
-}
-
-// Tool handlers
-
-func (s *Server) handleWikiInfo(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	var args struct {
-		WikiURL string `json:"wiki_url"`
-	}
-	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
-		return nil, err
-	}
 
-	result, err := tools.GetWikiInfo(ctx, s.client, args.WikiURL)
-	if err != nil {
-		return s.errorResult(err), nil
-	}
-
-	return s.successResult(result)
-}
+	// wiki_search_history
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_search_history",
+		Description: "Search the wikitext of every past revision of a page for a pattern and return the revisions in which it first appeared and later disappeared or reappeared, oldest first. Use to find when a claim was introduced or removed",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"title": {
+					"type": "string",
+					"description": "Page title"
+				},
+				"pattern": {
+					"type": "string",
+					"description": "Text to search for in the wikitext (case-sensitive)"
+				},
+				"regex": {
+					"type": "boolean",
+					"description": "Treat pattern as a regular expression (RE2 syntax)",
+					"default": false
+				},
+				"max_revisions": {
+					"type": "integer",
+					"description": "Most revisions to scan, starting from the oldest (default and maximum: server limit, 1000 unless configured)"
+				}
+			},
+			"required": ["wiki_url", "title", "pattern"]
+		}`),
+	}, s.handleSearchHistory)
 
-func (s *Server) handleWikiSearch(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	var args struct {
-		WikiURL string `json:"wiki_url"`
-		Query   string `json:"query"`
-		Limit   int    `json:"limit"`
-	}
-	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
-		return nil, err
-	}
-	if args.Limit == 0 {
-		args.Limit = 10
-	}
+	// wiki_blame
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_blame",
+		Description: "Find the edit that added a piece of text to a page: returns the revision ID, user, timestamp, edit summary, and diff of that edit. The snippet is matched against the wikitext of the page's revisions, ignoring differences in whitespace",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"title": {
+					"type": "string",
+					"description": "Page title"
+				},
+				"snippet": {
+					"type": "string",
+					"description": "Text from the page's current wikitext, such as a sentence"
+				}
+			},
+			"required": ["wiki_url", "title", "snippet"]
+		}`),
+	}, s.handleBlame)
 
-	result, err := tools.SearchWiki(ctx, s.client, args.WikiURL, args.Query, args.Limit)
-	if err != nil {
-		return s.errorResult(err), nil
-	}
+	// wiki_subpages
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_subpages",
+		Description: "List the subpages of a page (e.g. 'Project:Docs/...') as a tree. Useful for documentation wikis organized in subpage hierarchies",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"title": {
+					"type": "string",
+					"description": "Root page title whose subpages to list"
+				},
+				"limit": {
+					"type": "integer",
+					"description": "Maximum number of subpages (default: 100)",
+					"default": 100
+				}
+			},
+			"required": ["wiki_url", "title"]
+		}`),
+	}, s.handleSubpages)
 
-	return s.successResult(result)
-}
+	// wiki_titles_exist
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_titles_exist",
+		Description: "Check up to 50 titles in one request: whether each page exists, its namespace, and the final target of redirects. Use it to validate generated links or page lists instead of fetching pages one by one",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"titles": {
+					"type": "array",
+					"items": {"type": "string"},
+					"description": "Page titles to check, with namespace prefixes (e.g. 'Category:Physics')",
+					"maxItems": 50
+				}
+			},
+			"required": ["wiki_url", "titles"]
+		}`),
+	}, s.handleTitlesExist)
 
-func (s *Server) handlePageOutline(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	var args struct {
-		WikiURL string `json:"wiki_url"`
-		Title   string `json:"title"`
-	}
-	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
-		return nil, err
-	}
+	// wiki_discussions
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_discussions",
+		Description: "Get the discussion threads of a talk page as structured comments (author, timestamp, reply tree). Requires DiscussionTools or StructuredDiscussions (Flow) on the wiki",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"title": {
+					"type": "string",
+					"description": "Talk page title (e.g. 'Talk:Albert Einstein')"
+				},
+				"limit": {
+					"type": "integer",
+					"description": "Maximum number of threads (default: 20)",
+					"default": 20
+				}
+			},
+			"required": ["wiki_url", "title"]
+		}`),
+	}, s.handleDiscussions)
 
-	result, err := tools.GetPageOutline(ctx, s.client, args.WikiURL, args.Title)
-	if err != nil {
-		return s.errorResult(err), nil
-	}
+	// wiki_talk_read
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_talk_read",
+		Description: "Read the talk page of any page as discussion threads (author, timestamp, reply tree), resolving the talk namespace from the title, e.g. 'Albert Einstein' to 'Talk:Albert Einstein' or 'Help:Editing' to 'Help talk:Editing'. Requires DiscussionTools or StructuredDiscussions (Flow) on the wiki",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"title": {
+					"type": "string",
+					"description": "Page whose talk page to read, or the talk page itself"
+				},
+				"limit": {
+					"type": "integer",
+					"description": "Maximum number of threads (default: 20)",
+					"default": 20
+				}
+			},
+			"required": ["wiki_url", "title"]
+		}`),
+	}, s.handleTalkRead)
 
-	return s.successResult(result)
-}
+	// wiki_page_coordinates
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_page_coordinates",
+		Description: "Get the geographic coordinates on a page (lat, lon, globe, type), from GeoData where available or the page's {{coord}} templates. The primary coordinate is the location of the page's subject",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"title": {
+					"type": "string",
+					"description": "Page title"
+				}
+			},
+			"required": ["wiki_url", "title"]
+		}`),
+	}, s.handleCoordinates)
 
-func (s *Server) handlePageSection(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	var args struct {
+	// wiki_page_timeline
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_page_timeline",
+		Description: "Extract the dated statements on a page (or in one section, such as 'History', and its subsections) as a chronological list of {date, event, section}. Dates are normalized to ISO 8601 at the precision given; English date formats are recognized",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"title": {
+					"type": "string",
+					"description": "Page title"
+				},
+				"section": {
+					"type": "string",
+					"description": "Only scan the section with this heading (e.g. 'History'), including its subsections. Defaults to the whole page"
+				},
+				"limit": {
+					"type": "integer",
+					"description": "Maximum number of events (default: 100)",
+					"default": 100
+				}
+			},
+			"required": ["wiki_url", "title"]
+		}`),
+	}, s.handleTimeline)
+
+	// wiki_glossary
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_glossary",
+		Description: "Extract term/definition pairs from a page or the pages in a category: definition lists (;term :definition), glossary templates ({{term}}/{{defn}}, or templates with term and definition parameters), and abbreviation expansions ({{abbr}})",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"title": {
+					"type": "string",
+					"description": "Page title. Give either title or category"
+				},
+				"category": {
+					"type": "string",
+					"description": "Category whose pages to scan (with or without 'Category:' prefix)"
+				},
+				"max_pages": {
+					"type": "integer",
+					"description": "Maximum number of category pages to scan (default: 20)",
+					"default": 20
+				},
+				"limit": {
+					"type": "integer",
+					"description": "Maximum number of entries (default: 500)",
+					"default": 500
+				}
+			},
+			"required": ["wiki_url"]
+		}`),
+	}, s.handleGlossary)
+
+	// wiki_infobox_schema
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_infobox_schema",
+		Description: "Infer the implicit schema of the infoboxes in a category: samples its pages, extracts each infobox, and returns the union of fields with fill rates, inferred value types (number, date, url, text), and example values, plus which infobox templates are used. Useful for planning a migration of wiki data into a database",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"category": {
+					"type": "string",
+					"description": "Category whose pages to sample (with or without 'Category:' prefix)"
+				},
+				"template": {
+					"type": "string",
+					"description": "Only read this template, e.g. 'Infobox settlement' or a data template like 'Chembox'. Defaults to each page's first infobox"
+				},
+				"sample_size": {
+					"type": "integer",
+					"description": "Number of category pages to sample (default: 50)",
+					"default": 50
+				},
+				"examples": {
+					"type": "integer",
+					"description": "Maximum number of distinct example values per field (default: 3)",
+					"default": 3
+				}
+			},
+			"required": ["wiki_url", "category"]
+		}`),
+	}, s.handleInfoboxSchema)
+
+	// wiki_references
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_references",
+		Description: "Get a page's references as structured citations, with scholarly identifiers (DOI, ISBN, PMID, PMC, arXiv) normalized and given resolver URLs for citation verification",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"title": {
+					"type": "string",
+					"description": "Page title"
+				},
+				"identifiers_only": {
+					"type": "boolean",
+					"description": "Return only the distinct identifiers, without the references themselves (default: false)",
+					"default": false
+				}
+			},
+			"required": ["wiki_url", "title"]
+		}`),
+	}, s.handleReferences)
+
+	// wiki_sister_links
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_sister_links",
+		Description: "Get a page's links to sister projects (Wiktionary, Wikisource, Commons, Wikiquote, Wikidata, ...), with each target's wiki_url and title so other tools can be called on it directly",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"title": {
+					"type": "string",
+					"description": "Page title"
+				},
+				"project": {
+					"type": "string",
+					"description": "Only return links to this project, e.g. 'wiktionary' or 'wikisource'"
+				}
+			},
+			"required": ["wiki_url", "title"]
+		}`),
+	}, s.handleSisterLinks)
+
+	// wiki_compare_languages
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_compare_languages",
+		Description: "Compare an article with its version in another language (found through its language links): aligns the two section outlines by shared content such as dates, names, and files, and reports sections present in one language but missing in the other, to find translation gaps",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki with the source article"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"title": {
+					"type": "string",
+					"description": "Title of the source article"
+				},
+				"target_language": {
+					"type": "string",
+					"description": "Language code of the version to compare with, e.g. 'de'"
+				}
+			},
+			"required": ["wiki_url", "title", "target_language"]
+		}`),
+	}, s.handleCompareLanguages)
+
+	// wiki_farm_list
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_farm_list",
+		Description: "List the member wikis of a wiki farm or family (e.g. Wikimedia projects, or a farm's hub wiki) with their base and API URLs, for seeding multi-wiki searches and crawls. Uses SiteMatrix where available, otherwise the farm's local interwiki links",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of any wiki in the farm, e.g. https://meta.wikimedia.org"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"project": {
+					"type": "string",
+					"description": "Only list wikis of this project, e.g. 'wiki' (Wikipedia) or 'wiktionary'"
+				},
+				"wiki_language": {
+					"type": "string",
+					"description": "Only list wikis in this content language, e.g. 'fr'"
+				},
+				"include_closed": {
+					"type": "boolean",
+					"description": "Include closed and private wikis (default: false)",
+					"default": false
+				},
+				"limit": {
+					"type": "integer",
+					"description": "Maximum number of wikis (default: 100)",
+					"default": 100
+				}
+			},
+			"required": ["wiki_url"]
+		}`),
+	}, s.handleFarmList)
+
+	// wiki_maintenance_report
+	s.addTool(&mcp.Tool{
+		Name:        "wiki_maintenance_report",
+		Description: "Get a page of one of the wiki's maintenance reports (special pages such as unused files, wanted templates, or broken redirects) as structured entries. Large wikis serve these from a periodically updated copy; cached_at says when it was last updated",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"wiki_url": {
+					"type": "string",
+					"description": "Base URL of the wiki"
+				},
+				"language": {
+					"type": "string",
+					"description": "Language code for API messages and hints (e.g. 'de'). Defaults to the wiki's language"
+				},
+				"report": {
+					"type": "string",
+					"enum": ["BrokenRedirects", "DoubleRedirects", "Unusedimages", "Wantedfiles", "Wantedpages", "Wantedtemplates"],
+					"description": "Report to get: Unusedimages (files not used on any page), Wantedfiles, Wantedtemplates, and Wantedpages (missing pages with the number of links to them), BrokenRedirects, or DoubleRedirects"
+				},
+				"limit": {
+					"type": "integer",
+					"description": "Maximum number of entries (default: 50)",
+					"default": 50
+				},
+				"offset": {
+					"type": "integer",
+					"description": "Entries to skip, from next_offset of the previous page (default: 0)",
+					"default": 0
+				}
+			},
+			"required": ["wiki_url", "report"]
+		}`),
+	}, s.handleMaintenanceReport)
+
+	s.registerJobTools()
+	s.registerEditTools()
+	s.registerApprovalTools()
+	s.registerArtifactTools()
+}
+
+// addTool registers a tool with the MCP server and records it for the
+// OpenAPI spec and HTTP interfaces. Only the MCP endpoint takes the
+// response_format argument.
+func (s *Server) addTool(tool *mcp.Tool, handler mcp.ToolHandler) {
+	handler = s.track(s.enveloped(s.deduplicated(tool.Name, s.cached(tool.Name, handler))))
+	s.tools = append(s.tools, tool)
+	s.handlers[tool.Name] = handler
+
+	mcpTool := *tool
+	mcpTool.InputSchema = withResponseFormat(tool.InputSchema)
+	s.mcp.AddTool(&mcpTool, s.formatted(handler))
+}
+
+// CallTool invokes a registered tool outside of an MCP session
+func (s *Server) CallTool(ctx context.Context, name string, arguments json.RawMessage) (*mcp.CallToolResult, error) {
+	handler, ok := s.handlers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTool, name)
+	}
+
+	return handler(ctx, &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name:      name,
+			Arguments: arguments,
+		},
+	})
+}
+
+// ToolError is returned by CallToolJSON when a tool reports a structured error
+type ToolError struct {
+	Response *ErrorResponse
+}
+
+func (e *ToolError) Error() string {
+	return e.Response.Error + ": " + e.Response.Message
+}
+
+// CallToolJSON invokes a registered tool and returns the data of its JSON
+// result. Tool failures are returned as *ToolError.
+func (s *Server) CallToolJSON(ctx context.Context, name string, arguments json.RawMessage) (json.RawMessage, error) {
+	result, err := s.CallTool(ctx, name, arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	var text string
+	for _, content := range result.Content {
+		if t, ok := content.(*mcp.TextContent); ok {
+			text = t.Text
+			break
+		}
+	}
+
+	if result.IsError {
+		var resp ErrorResponse
+		if err := json.Unmarshal([]byte(text), &resp); err != nil {
+			return nil, err
+		}
+		return nil, &ToolError{Response: &resp}
+	}
+
+	var envelope Envelope
+	if err := json.Unmarshal([]byte(text), &envelope); err != nil {
+		return nil, err
+	}
+	return envelope.Data, nil
+}
+
+// Tool handlers
+
+func (s *Server) handleWikiInfo(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL  string `json:"wiki_url"`
+		Language string `json:"language"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+
+	result, err := tools.GetWikiInfo(ctx, s.client, args.WikiURL)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleWhoAmI(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL  string `json:"wiki_url"`
+		Language string `json:"language"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+
+	result, err := s.client.WhoAmI(ctx, args.WikiURL)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleWikiSearch(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL    string `json:"wiki_url"`
+		Language   string `json:"language"`
+		Query      string `json:"query"`
+		Limit      int    `json:"limit"`
+		InCategory string `json:"in_category"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	if args.Limit == 0 {
+		args.Limit = 10
+	}
+	clamp := clampLimit(&args.Limit, s.config.MaxSearchLimit)
+
+	result, err := tools.SearchWiki(ctx, s.client, args.WikiURL, args.Query, args.Limit, tools.SearchOptions{
+		InCategory: args.InCategory,
+	})
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+	result.LimitClamped = clamp
+
+	return s.successResult(result)
+}
+
+func (s *Server) handlePageOutline(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL            string `json:"wiki_url"`
+		Language           string `json:"language"`
+		Title              string `json:"title"`
+		IncludeTOC         bool   `json:"include_toc"`
+		IncludeReadability bool   `json:"include_readability"`
+		IncludeLinkDetails bool   `json:"include_link_details"`
+		RelevanceQuery     string `json:"relevance_query"`
+		Query              string `json:"query"` // alias of relevance_query
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+
+	result, err := tools.GetPageOutline(ctx, s.client, args.WikiURL, args.Title, tools.OutlineOptions{
+		IncludeTOC:         args.IncludeTOC,
+		IncludeReadability: args.IncludeReadability,
+		IncludeLinkDetails: args.IncludeLinkDetails,
+		RelevanceQuery:     cmp.Or(args.RelevanceQuery, args.Query),
+	})
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handlePageSection(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL            string `json:"wiki_url"`
+		Language           string `json:"language"`
+		Title              string `json:"title"`
+		SectionIndex       int    `json:"section_index"`
+		IncludeLinkDetails bool   `json:"include_link_details"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+
+	result, err := tools.GetPageSection(ctx, s.client, args.WikiURL, args.Title, args.SectionIndex, tools.SectionOptions{
+		IncludeLinkDetails: args.IncludeLinkDetails,
+	})
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handlePageFull(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL  string `json:"wiki_url"`
+		Language string `json:"language"`
+		Title    string `json:"title"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+
+	result, err := tools.GetPageFull(ctx, s.client, args.WikiURL, args.Title, s.config.MaxPageBytes)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleHasChanged(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL      string `json:"wiki_url"`
+		Language     string `json:"language"`
+		Title        string `json:"title"`
+		RevID        int    `json:"revid"`
+		ContentHash  string `json:"content_hash"`
+		SectionIndex *int   `json:"section_index"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	if args.RevID == 0 && args.ContentHash == "" {
+		return nil, fmt.Errorf("revid or content_hash is required")
+	}
+
+	result, err := tools.HasChanged(ctx, s.client, args.WikiURL, args.Title, tools.ChangeOptions{
+		RevID:    args.RevID,
+		Hash:     args.ContentHash,
+		Section:  args.SectionIndex,
+		MaxBytes: s.config.MaxPageBytes,
+	})
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleCategory(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL    string `json:"wiki_url"`
+		Language   string `json:"language"`
+		Category   string `json:"category"`
+		Limit      int    `json:"limit"`
+		MemberType string `json:"member_type"`
+		Sort       string `json:"sort"`
+		Direction  string `json:"direction"`
+		All        bool   `json:"all"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	var clamp *wiki.LimitClamp
+	if args.All {
+		if args.Limit <= 0 {
+			args.Limit = s.config.CategoryAllMax
+		}
+		clamp = clampLimit(&args.Limit, s.config.CategoryAllMax)
+	} else {
+		if args.Limit == 0 {
+			args.Limit = 20
+		}
+		clamp = clampLimit(&args.Limit, s.config.MaxCategoryLimit)
+	}
+
+	result, err := tools.GetCategory(ctx, s.client, args.WikiURL, args.Category, args.Limit, tools.CategoryOptions{
+		MemberType: args.MemberType,
+		Sort:       args.Sort,
+		Direction:  args.Direction,
+		All:        args.All,
+		Progress:   progressNotifier(ctx, req, "members"),
+	})
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+	result.LimitClamped = clamp
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleBacklinks(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL  string `json:"wiki_url"`
+		Language string `json:"language"`
+		Title    string `json:"title"`
+		Limit    int    `json:"limit"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	if args.Limit == 0 {
+		args.Limit = 20
+	}
+
+	clamp := clampLimit(&args.Limit, s.config.MaxBacklinksLimit)
+
+	result, err := tools.GetBacklinks(ctx, s.client, args.WikiURL, args.Title, args.Limit)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+	result.LimitClamped = clamp
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleCompare(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
 		WikiURL      string `json:"wiki_url"`
+		Language     string `json:"language"`
 		Title        string `json:"title"`
-		SectionIndex int    `json:"section_index"`
+		FromRevision string `json:"from_revision"`
+		ToRevision   string `json:"to_revision"`
 	}
 	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
 		return nil, err
 	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	if args.FromRevision == "" {
+		args.FromRevision = "prev"
+	}
+	if args.ToRevision == "" {
+		args.ToRevision = "current"
+	}
 
-	result, err := tools.GetPageSection(ctx, s.client, args.WikiURL, args.Title, args.SectionIndex)
+	result, err := tools.CompareRevisions(ctx, s.client, args.WikiURL, args.Title, args.FromRevision, args.ToRevision)
 	if err != nil {
-		return s.errorResult(err), nil
+		return s.errorResult(req, err, args.Language), nil
 	}
 
 	return s.successResult(result)
 }
 
-func (s *Server) handlePageFull(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func (s *Server) handleSubpages(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	var args struct {
-		WikiURL string `json:"wiki_url"`
-		Title   string `json:"title"`
+		WikiURL  string `json:"wiki_url"`
+		Language string `json:"language"`
+		Title    string `json:"title"`
+		Limit    int    `json:"limit"`
 	}
 	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
 		return nil, err
 	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	if args.Limit == 0 {
+		args.Limit = 100
+	}
 
-	result, err := tools.GetPageFull(ctx, s.client, args.WikiURL, args.Title)
+	result, err := tools.GetSubpages(ctx, s.client, args.WikiURL, args.Title, args.Limit)
 	if err != nil {
-		return s.errorResult(err), nil
+		return s.errorResult(req, err, args.Language), nil
 	}
 
 	return s.successResult(result)
 }
 
-func (s *Server) handleCategory(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func (s *Server) handleTitlesExist(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL  string   `json:"wiki_url"`
+		Language string   `json:"language"`
+		Titles   []string `json:"titles"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+
+	result, err := tools.TitlesExist(ctx, s.client, args.WikiURL, args.Titles)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleDiscussions(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	var args struct {
 		WikiURL  string `json:"wiki_url"`
-		Category string `json:"category"`
+		Language string `json:"language"`
+		Title    string `json:"title"`
 		Limit    int    `json:"limit"`
 	}
 	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
 		return nil, err
 	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
 	if args.Limit == 0 {
 		args.Limit = 20
 	}
 
-	result, err := tools.GetCategory(ctx, s.client, args.WikiURL, args.Category, args.Limit)
+	result, err := tools.GetDiscussions(ctx, s.client, args.WikiURL, args.Title, args.Limit)
 	if err != nil {
-		return s.errorResult(err), nil
+		return s.errorResult(req, err, args.Language), nil
 	}
 
 	return s.successResult(result)
 }
 
-func (s *Server) handleBacklinks(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+// TalkPageResponse is the result of wiki_talk_read: a talk page's threads
+// and the page it is about
+type TalkPageResponse struct {
+	Subject string `json:"subject"`
+	*wiki.DiscussionsResponse
+}
+
+func (s *Server) handleTalkRead(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	var args struct {
-		WikiURL string `json:"wiki_url"`
-		Title   string `json:"title"`
-		Limit   int    `json:"limit"`
+		WikiURL  string `json:"wiki_url"`
+		Language string `json:"language"`
+		Title    string `json:"title"`
+		Limit    int    `json:"limit"`
 	}
 	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
 		return nil, err
 	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
 	if args.Limit == 0 {
 		args.Limit = 20
 	}
 
-	result, err := tools.GetBacklinks(ctx, s.client, args.WikiURL, args.Title, args.Limit)
+	talk, subject, err := tools.TalkPages(ctx, s.client, args.WikiURL, args.Title)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+	result, err := tools.GetDiscussions(ctx, s.client, args.WikiURL, talk, args.Limit)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(&TalkPageResponse{Subject: subject, DiscussionsResponse: result})
+}
+
+func (s *Server) handleCoordinates(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL  string `json:"wiki_url"`
+		Language string `json:"language"`
+		Title    string `json:"title"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+
+	result, err := tools.GetPageCoordinates(ctx, s.client, args.WikiURL, args.Title)
 	if err != nil {
-		return s.errorResult(err), nil
+		return s.errorResult(req, err, args.Language), nil
 	}
 
 	return s.successResult(result)
 }
 
-func (s *Server) handleCompare(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func (s *Server) handleTimeline(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL  string `json:"wiki_url"`
+		Language string `json:"language"`
+		Title    string `json:"title"`
+		Section  string `json:"section"`
+		Limit    int    `json:"limit"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	if args.Limit == 0 {
+		args.Limit = 100
+	}
+
+	result, err := tools.GetPageTimeline(ctx, s.client, args.WikiURL, args.Title, args.Section, args.Limit, s.config.MaxPageBytes)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleGlossary(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL  string `json:"wiki_url"`
+		Language string `json:"language"`
+		Title    string `json:"title"`
+		Category string `json:"category"`
+		MaxPages int    `json:"max_pages"`
+		Limit    int    `json:"limit"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	if (args.Title == "") == (args.Category == "") {
+		return nil, fmt.Errorf("exactly one of title or category is required")
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	if args.MaxPages == 0 {
+		args.MaxPages = 20
+	}
+	if args.Limit == 0 {
+		args.Limit = 500
+	}
+
+	var result *wiki.GlossaryResponse
+	var err error
+	if args.Category != "" {
+		result, err = tools.GetCategoryGlossary(ctx, s.client, args.WikiURL, args.Category, args.MaxPages, args.Limit)
+	} else {
+		result, err = tools.GetPageGlossary(ctx, s.client, args.WikiURL, args.Title, args.Limit)
+	}
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleInfoboxSchema(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL    string `json:"wiki_url"`
+		Language   string `json:"language"`
+		Category   string `json:"category"`
+		Template   string `json:"template"`
+		SampleSize int    `json:"sample_size"`
+		Examples   int    `json:"examples"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	if args.SampleSize == 0 {
+		args.SampleSize = 50
+	}
+	if args.Examples == 0 {
+		args.Examples = 3
+	}
+
+	result, err := tools.GetInfoboxSchema(ctx, s.client, args.WikiURL, args.Category, args.Template, args.SampleSize, args.Examples)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleReferences(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL         string `json:"wiki_url"`
+		Language        string `json:"language"`
+		Title           string `json:"title"`
+		IdentifiersOnly bool   `json:"identifiers_only"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+
+	result, err := tools.GetReferences(ctx, s.client, args.WikiURL, args.Title)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	if args.IdentifiersOnly {
+		result.References = nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleSisterLinks(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL  string `json:"wiki_url"`
+		Language string `json:"language"`
+		Title    string `json:"title"`
+		Project  string `json:"project"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+
+	result, err := tools.GetSisterLinks(ctx, s.client, args.WikiURL, args.Title, args.Project)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleCompareLanguages(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL        string `json:"wiki_url"`
+		Language       string `json:"language"`
+		Title          string `json:"title"`
+		TargetLanguage string `json:"target_language"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+
+	result, err := tools.CompareLanguages(ctx, s.client, args.WikiURL, args.Title, args.TargetLanguage)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleSearchHistory(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	var args struct {
 		WikiURL      string `json:"wiki_url"`
+		Language     string `json:"language"`
 		Title        string `json:"title"`
-		FromRevision string `json:"from_revision"`
-		ToRevision   string `json:"to_revision"`
+		Pattern      string `json:"pattern"`
+		Regex        bool   `json:"regex"`
+		MaxRevisions int    `json:"max_revisions"`
 	}
 	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
 		return nil, err
 	}
-	if args.FromRevision == "" {
-		args.FromRevision = "prev"
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	if args.Pattern == "" {
+		return nil, fmt.Errorf("pattern is required")
 	}
-	if args.ToRevision == "" {
-		args.ToRevision = "current"
+	if args.Regex {
+		if _, err := regexp.Compile(args.Pattern); err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+	}
+	if args.MaxRevisions <= 0 || args.MaxRevisions > s.config.HistorySearchMax {
+		args.MaxRevisions = s.config.HistorySearchMax
 	}
 
-	result, err := tools.CompareRevisions(ctx, s.client, args.WikiURL, args.Title, args.FromRevision, args.ToRevision)
+	result, err := tools.SearchPageHistory(ctx, s.client, args.WikiURL, args.Title, args.Pattern, args.Regex, args.MaxRevisions)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleBlame(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL  string `json:"wiki_url"`
+		Language string `json:"language"`
+		Title    string `json:"title"`
+		Snippet  string `json:"snippet"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	if strings.TrimSpace(args.Snippet) == "" {
+		return nil, fmt.Errorf("snippet is required")
+	}
+
+	result, err := tools.BlameText(ctx, s.client, args.WikiURL, args.Title, args.Snippet)
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleMaintenanceReport(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL  string `json:"wiki_url"`
+		Language string `json:"language"`
+		Report   string `json:"report"`
+		Limit    int    `json:"limit"`
+		Offset   int    `json:"offset"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	if !slices.Contains(tools.MaintenanceReports(), args.Report) {
+		return nil, fmt.Errorf("report must be one of %s", strings.Join(tools.MaintenanceReports(), ", "))
+	}
+	if args.Limit == 0 {
+		args.Limit = 50
+	}
+
+	result, err := tools.GetMaintenanceReport(ctx, s.client, args.WikiURL, args.Report, args.Limit, args.Offset)
 	if err != nil {
-		return s.errorResult(err), nil
+		return s.errorResult(req, err, args.Language), nil
+	}
+
+	return s.successResult(result)
+}
+
+func (s *Server) handleFarmList(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		WikiURL       string `json:"wiki_url"`
+		Language      string `json:"language"`
+		Project       string `json:"project"`
+		WikiLanguage  string `json:"wiki_language"`
+		IncludeClosed bool   `json:"include_closed"`
+		Limit         int    `json:"limit"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil, err
+	}
+	ctx = wiki.WithLanguage(ctx, args.Language)
+	if args.Limit == 0 {
+		args.Limit = 100
+	}
+
+	result, err := tools.ListFarmWikis(ctx, s.client, args.WikiURL, args.Limit, tools.FarmOptions{
+		Project:       args.Project,
+		Language:      args.WikiLanguage,
+		IncludeClosed: args.IncludeClosed,
+	})
+	if err != nil {
+		return s.errorResult(req, err, args.Language), nil
 	}
 
 	return s.successResult(result)
@@ -398,12 +1692,35 @@ func (s *Server) handleCompare(ctx context.Context, req *mcp.CallToolRequest) (*
 
 // Helper methods
 
+// clampLimit lowers a requested limit to max, if it is set, and returns the
+// clamp to report in the response, or nil if the limit was within bounds
+func clampLimit(limit *int, max int) *wiki.LimitClamp {
+	if max <= 0 || *limit <= max {
+		return nil
+	}
+	clamp := &wiki.LimitClamp{Requested: *limit, Max: max}
+	*limit = max
+	return clamp
+}
+
 func (s *Server) successResult(data interface{}) (*mcp.CallToolResult, error) {
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return nil, err
 	}
 
+	// Apply deployment content filters to a generic copy of the response
+	if s.filter != nil {
+		var generic interface{}
+		if err := json.Unmarshal(jsonData, &generic); err != nil {
+			return nil, err
+		}
+		jsonData, err = json.Marshal(s.filter.apply(generic))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: string(jsonData)},
@@ -411,8 +1728,74 @@ func (s *Server) successResult(data interface{}) (*mcp.CallToolResult, error) {
 	}, nil
 }
 
-func (s *Server) errorResult(err error) *mcp.CallToolResult {
-	errResp := FormatError(err)
+// track records every tool call in its session's history used for hints
+// (see historyKey), in the statistics, and in the audit log when a database
+// is configured
+func (s *Server) track(handler mcp.ToolHandler) mcp.ToolHandler {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		call := callFromRequest(req, time.Now())
+		ref := newAuditRef()
+		ctx = wiki.WithCacheStatus(ctx)
+		result, err := handler(context.WithValue(ctx, auditRefKey{}, ref), req)
+		call.Failed = err != nil || (result != nil && result.IsError)
+		if session := s.historyKey(req); session != "" {
+			s.history.record(session, call)
+		}
+		code, message := callError(result, err)
+		s.stats.record(call, wiki.CacheLookedUp(ctx), wiki.CacheStatusFrom(ctx).Hit, code, message)
+		if s.db != nil {
+			s.audit(req, ref, call, code, time.Since(call.At))
+		}
+		return result, err
+	}
+}
+
+// callError returns the error code and message of a failed tool call
+func callError(result *mcp.CallToolResult, err error) (code, message string) {
+	switch {
+	case err != nil:
+		return "invalid_arguments", err.Error()
+	case result != nil && result.IsError:
+		code = "internal_error"
+		for _, content := range result.Content {
+			if text, ok := content.(*mcp.TextContent); ok {
+				var resp ErrorResponse
+				if json.Unmarshal([]byte(text.Text), &resp) == nil && resp.Error != "" {
+					code, message = resp.Error, resp.Message
+				}
+				break
+			}
+		}
+	}
+	return code, message
+}
+
+// audit appends a finished tool call to the audit log
+func (s *Server) audit(req *mcp.CallToolRequest, ref string, call callRecord, errorCode string, duration time.Duration) {
+	rec := store.AuditRecord{
+		Ref:       ref,
+		At:        call.At,
+		Session:   sessionKey(req),
+		Tool:      call.Tool,
+		WikiURL:   call.WikiURL,
+		Title:     call.Title,
+		Arguments: auditArguments(req.Params.Arguments),
+		ErrorCode: errorCode,
+		Duration:  duration,
+	}
+
+	if err := s.db.RecordAudit(rec); err != nil {
+		log.Printf("audit: %v", err)
+	}
+}
+
+func (s *Server) errorResult(req *mcp.CallToolRequest, err error, lang string) *mcp.CallToolResult {
+	errResp := FormatError(err, lang)
+	errResp.APIVersion = APIVersion
+	call := callFromRequest(req, time.Now())
+	if session := s.historyKey(req); session != "" {
+		errResp.NextSteps = nextSteps(s.history.recent(session, call.At), call, errResp.Error, lang)
+	}
 	errJSON, _ := json.Marshal(errResp)
 
 	return &mcp.CallToolResult{
@@ -0,0 +1,272 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// memoryMaxItems bounds the pieces of content remembered per session
+const memoryMaxItems = 1000
+
+// sentContent is a page or section a session has been sent
+type sentContent struct {
+	Page         string // see pageKey
+	RevID        int    // 0 if unknown
+	Section      *int   // nil for a whole page
+	SectionTitle string
+	ContentID    string
+	Tool         string
+	At           time.Time
+}
+
+// prior returns the reference to the content given in place of resending it
+func (c sentContent) prior() wiki.PriorContent {
+	return wiki.PriorContent{
+		ContentID:    c.ContentID,
+		Tool:         c.Tool,
+		SectionIndex: c.Section,
+		SectionTitle: c.SectionTitle,
+	}
+}
+
+// sessionMemory remembers the page content each session has been sent, so
+// a request overlapping it can be answered with only what's new
+type sessionMemory struct {
+	mu   sync.Mutex
+	sent map[string][]sentContent // by session, oldest first
+}
+
+func newSessionMemory() *sessionMemory {
+	return &sessionMemory{
+		sent: make(map[string][]sentContent),
+	}
+}
+
+// recall returns what a session has been sent of a page, dropping stale
+// entries
+func (m *sessionMemory) recall(session, page string, now time.Time) []sentContent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []sentContent
+	for _, c := range pruneSent(m.sent[session], now) {
+		if c.Page == page {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// remember records content sent to a session
+func (m *sessionMemory) remember(session string, c sentContent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sent := append(pruneSent(m.sent[session], c.At), c)
+	if len(sent) > memoryMaxItems {
+		sent = sent[len(sent)-memoryMaxItems:]
+	}
+	m.sent[session] = sent
+
+	// Sweep sessions that have gone quiet
+	if len(m.sent) > 1000 {
+		for key, items := range m.sent {
+			if len(pruneSent(items, c.At)) == 0 {
+				delete(m.sent, key)
+			}
+		}
+	}
+}
+
+// pruneSent drops content sent longer than historyMaxAge ago
+func pruneSent(sent []sentContent, now time.Time) []sentContent {
+	cutoff := now.Add(-historyMaxAge)
+	for i, c := range sent {
+		if c.At.After(cutoff) {
+			return sent[i:]
+		}
+	}
+	return nil
+}
+
+// pageKey identifies a page across tools: its canonical URL when known
+func pageKey(wikiURL, title string, source *wiki.Provenance) string {
+	if source != nil && source.URL != "" {
+		return source.URL
+	}
+	return strings.TrimSuffix(wikiURL, "/") + "|" + title
+}
+
+// sameRevision reports whether two pieces of content are known to come from
+// the same revision
+func sameRevision(a, b int) bool {
+	return a != 0 && a == b
+}
+
+// deduplicated answers wiki_page_section and wiki_page_full with only the
+// content the session hasn't been sent yet, referring to the rest by the
+// content IDs it was sent with. It only applies with MCP_SESSION_DEDUP in
+// stateful mode, where calls carry a lasting session ID, and wraps the
+// result cache so cached results stay the same for every session.
+func (s *Server) deduplicated(tool string, handler mcp.ToolHandler) mcp.ToolHandler {
+	if !s.config.SessionDedup || !s.config.Stateful || (tool != "wiki_page_section" && tool != "wiki_page_full") {
+		return handler
+	}
+
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, req)
+		session := sessionKey(req)
+		if session == "" || err != nil || result == nil || result.IsError || len(result.Content) != 1 {
+			return result, err
+		}
+		text, ok := result.Content[0].(*mcp.TextContent)
+		if !ok {
+			return result, nil
+		}
+
+		var args struct {
+			WikiURL string `json:"wiki_url"`
+		}
+		_ = json.Unmarshal(req.Params.Arguments, &args)
+
+		var page any
+		now := time.Now()
+		if tool == "wiki_page_section" {
+			var section wiki.PageSection
+			if json.Unmarshal([]byte(text.Text), &section) != nil || section.Section == nil {
+				return result, nil
+			}
+			s.memory.dedupSection(session, args.WikiURL, &section, now)
+			page = &section
+		} else {
+			var full wiki.PageFull
+			if json.Unmarshal([]byte(text.Text), &full) != nil {
+				return result, nil
+			}
+			s.memory.dedupPage(session, args.WikiURL, &full, now)
+			page = &full
+		}
+
+		data, err := json.Marshal(page)
+		if err != nil {
+			return result, nil
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		}, nil
+	}
+}
+
+// dedupSection leaves out a section's content if the session was sent the
+// same content before, or the whole page at the same revision
+func (m *sessionMemory) dedupSection(session, wikiURL string, page *wiki.PageSection, now time.Time) {
+	key := pageKey(wikiURL, page.Title, page.Source)
+	revID := 0
+	if page.Source != nil {
+		revID = page.Source.RevID
+	}
+
+	for _, c := range m.recall(session, key, now) {
+		same := c.ContentID == page.ContentHash && c.Section != nil && *c.Section == page.Section.Index
+		inPage := c.Section == nil && sameRevision(c.RevID, revID)
+		if same || inPage {
+			prior := c.prior()
+			page.PriorContent = &prior
+			page.Section.Content = priorPlaceholder(c)
+			return
+		}
+	}
+
+	index := page.Section.Index
+	m.remember(session, sentContent{
+		Page:         key,
+		RevID:        revID,
+		Section:      &index,
+		SectionTitle: page.Section.Title,
+		ContentID:    page.ContentHash,
+		Tool:         "wiki_page_section",
+		At:           now,
+	})
+}
+
+// dedupPage leaves out of a page's content what the session was sent
+// before: everything if it got the same content, or else the sections it
+// got from the same revision
+func (m *sessionMemory) dedupPage(session, wikiURL string, page *wiki.PageFull, now time.Time) {
+	key := pageKey(wikiURL, page.Title, page.Source)
+	revID := 0
+	if page.Source != nil {
+		revID = page.Source.RevID
+	}
+
+	var sections []sentContent
+	for _, c := range m.recall(session, key, now) {
+		if c.Section == nil && c.ContentID == page.ContentHash {
+			page.PriorContent = []wiki.PriorContent{c.prior()}
+			page.Content = priorPlaceholder(c)
+			return
+		}
+		if c.Section != nil && sameRevision(c.RevID, revID) {
+			sections = append(sections, c)
+		}
+	}
+
+	if len(sections) > 0 {
+		titles := make(map[string]int) // the page's headings, by headingKey
+		wiki.OmitSections(page.Content, func(_, _ int, heading string) (string, bool) {
+			titles[headingKey(heading)]++
+			return "", false
+		})
+		omitted := make(map[int]bool) // by section index
+		page.Content = wiki.OmitSections(page.Content, func(ordinal, _ int, heading string) (string, bool) {
+			heading = headingKey(heading)
+			for _, c := range sections {
+				// Section indexes count headings, but templates can add
+				// headings of their own, so a title unique on the page
+				// matches anywhere
+				if omitted[*c.Section] || headingKey(c.SectionTitle) != heading ||
+					(*c.Section != ordinal && titles[heading] > 1) {
+					continue
+				}
+				omitted[*c.Section] = true
+				page.PriorContent = append(page.PriorContent, c.prior())
+				return priorPlaceholder(c), true
+			}
+			return "", false
+		})
+	}
+
+	m.remember(session, sentContent{
+		Page:      key,
+		RevID:     revID,
+		ContentID: page.ContentHash,
+		Tool:      "wiki_page_full",
+		At:        now,
+	})
+}
+
+// priorPlaceholder stands in for content left out because it was sent before
+func priorPlaceholder(c sentContent) string {
+	return fmt.Sprintf("[Sent earlier in this session by %s as content_id %s]", c.Tool, c.ContentID)
+}
+
+// headingKey normalizes a heading for comparison, ignoring case, spacing,
+// and Markdown emphasis and escapes
+func headingKey(heading string) string {
+	heading = strings.Map(func(r rune) rune {
+		switch r {
+		case '\\', '*', '_', '`':
+			return -1
+		}
+		return r
+	}, heading)
+	return strings.ToLower(strings.Join(strings.Fields(heading), " "))
+}
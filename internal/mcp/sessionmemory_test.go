@@ -0,0 +1,198 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+const memoryWiki = "https://wiki.example.org"
+
+func testSection(index int, title, content, hash string, revID int) *wiki.PageSection {
+	return &wiki.PageSection{
+		Title:       "Town",
+		Section:     &wiki.Section{Index: index, Title: title, Content: content},
+		ContentHash: hash,
+		Source:      &wiki.Provenance{RevID: revID, URL: memoryWiki + "/wiki/Town"},
+	}
+}
+
+func testPage(content, hash string, revID int) *wiki.PageFull {
+	return &wiki.PageFull{
+		Title:       "Town",
+		Content:     content,
+		ContentHash: hash,
+		Source:      &wiki.Provenance{RevID: revID, URL: memoryWiki + "/wiki/Town"},
+	}
+}
+
+func TestDedupSection(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		sent     func(m *sessionMemory) // what the session was sent before
+		section  *wiki.PageSection
+		wantSame bool // the section's content is replaced
+	}{
+		{
+			name: "same section and content",
+			sent: func(m *sessionMemory) {
+				m.dedupSection("s1", memoryWiki, testSection(1, "History", "Old", "h1", 10), now)
+			},
+			section:  testSection(1, "History", "Old", "h1", 10),
+			wantSame: true,
+		},
+		{
+			name: "content changed",
+			sent: func(m *sessionMemory) {
+				m.dedupSection("s1", memoryWiki, testSection(1, "History", "Old", "h1", 10), now)
+			},
+			section: testSection(1, "History", "New", "h2", 11),
+		},
+		{
+			name: "same content at another index",
+			sent: func(m *sessionMemory) {
+				m.dedupSection("s1", memoryWiki, testSection(2, "History", "Old", "h1", 10), now)
+			},
+			section: testSection(1, "History", "Old", "h1", 10),
+		},
+		{
+			name: "another session",
+			sent: func(m *sessionMemory) {
+				m.dedupSection("s2", memoryWiki, testSection(1, "History", "Old", "h1", 10), now)
+			},
+			section: testSection(1, "History", "Old", "h1", 10),
+		},
+		{
+			name:     "whole page at the same revision",
+			sent:     func(m *sessionMemory) { m.dedupPage("s1", memoryWiki, testPage("## History\n\nOld\n", "p1", 10), now) },
+			section:  testSection(1, "History", "Old", "h1", 10),
+			wantSame: true,
+		},
+		{
+			name:    "whole page at another revision",
+			sent:    func(m *sessionMemory) { m.dedupPage("s1", memoryWiki, testPage("## History\n\nOld\n", "p1", 9), now) },
+			section: testSection(1, "History", "Old", "h1", 10),
+		},
+		{
+			name:    "whole page at an unknown revision",
+			sent:    func(m *sessionMemory) { m.dedupPage("s1", memoryWiki, testPage("## History\n\nOld\n", "p1", 0), now) },
+			section: testSection(1, "History", "Old", "h1", 0),
+		},
+		{
+			name: "sent too long ago",
+			sent: func(m *sessionMemory) {
+				m.dedupSection("s1", memoryWiki, testSection(1, "History", "Old", "h1", 10), now.Add(-historyMaxAge-time.Minute))
+			},
+			section: testSection(1, "History", "Old", "h1", 10),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newSessionMemory()
+			tt.sent(m)
+			m.dedupSection("s1", memoryWiki, tt.section, now)
+
+			if !tt.wantSame {
+				if tt.section.PriorContent != nil || tt.section.Section.Content == "" || strings.HasPrefix(tt.section.Section.Content, "[Sent earlier") {
+					t.Errorf("section replaced: content %q, prior %+v", tt.section.Section.Content, tt.section.PriorContent)
+				}
+				return
+			}
+			prior := tt.section.PriorContent
+			if prior == nil || !strings.Contains(tt.section.Section.Content, "content_id "+prior.ContentID) {
+				t.Fatalf("section not replaced: content %q, prior %+v", tt.section.Section.Content, prior)
+			}
+		})
+	}
+}
+
+func TestDedupSectionRemembersOnlyNewContent(t *testing.T) {
+	m := newSessionMemory()
+	now := time.Now()
+	m.dedupSection("s1", memoryWiki, testSection(1, "History", "Old", "h1", 10), now)
+	m.dedupSection("s1", memoryWiki, testSection(1, "History", "Old", "h1", 10), now)
+
+	sent := m.recall("s1", memoryWiki+"/wiki/Town", now)
+	if len(sent) != 1 {
+		t.Errorf("recall = %+v, want the section once", sent)
+	}
+}
+
+func TestDedupPage(t *testing.T) {
+	content := "Lead\n\n## History\n\nOld text\n\n### Founding\n\nFounded\n\n## Geography\n\nHills\n\n## Notes\n\nFirst notes\n\n## Notes\n\nSecond notes\n"
+	now := time.Now()
+
+	t.Run("same content", func(t *testing.T) {
+		m := newSessionMemory()
+		m.dedupPage("s1", memoryWiki, testPage(content, "p1", 10), now)
+		page := testPage(content, "p1", 10)
+		m.dedupPage("s1", memoryWiki, page, now)
+		if len(page.PriorContent) != 1 || page.PriorContent[0].SectionIndex != nil || page.Content != priorPlaceholder(m.recall("s1", memoryWiki+"/wiki/Town", now)[0]) {
+			t.Errorf("content %q, prior %+v, want it replaced as a whole", page.Content, page.PriorContent)
+		}
+	})
+
+	t.Run("sections from the same revision", func(t *testing.T) {
+		m := newSessionMemory()
+		m.dedupSection("s1", memoryWiki, testSection(1, "History", "Old text", "h1", 10), now)
+		m.dedupSection("s1", memoryWiki, testSection(5, "Notes", "Second notes", "h5", 10), now)
+		m.dedupSection("s1", memoryWiki, testSection(3, "Geography", "Hills", "h3", 9), now)
+		page := testPage(content, "p2", 10)
+		m.dedupPage("s1", memoryWiki, page, now)
+
+		// History goes with its subsection, and the second of the two
+		// Notes sections is matched by its index
+		for _, gone := range []string{"Old text", "Founded", "Second notes"} {
+			if strings.Contains(page.Content, gone) {
+				t.Errorf("content still has %q:\n%s", gone, page.Content)
+			}
+		}
+		for _, kept := range []string{"Lead", "Hills", "First notes", "content_id h1", "content_id h5"} {
+			if !strings.Contains(page.Content, kept) {
+				t.Errorf("content lacks %q:\n%s", kept, page.Content)
+			}
+		}
+		if len(page.PriorContent) != 2 {
+			t.Errorf("prior = %+v, want the two sections", page.PriorContent)
+		}
+	})
+
+	t.Run("sections matched by a unique heading", func(t *testing.T) {
+		m := newSessionMemory()
+		// A template added a heading, so the wiki's index is off by one
+		m.dedupSection("s1", memoryWiki, testSection(4, "*Geography*", "Hills", "h3", 10), now)
+		page := testPage(content, "p2", 10)
+		m.dedupPage("s1", memoryWiki, page, now)
+		if strings.Contains(page.Content, "Hills") || len(page.PriorContent) != 1 {
+			t.Errorf("content %q, prior %+v, want Geography left out", page.Content, page.PriorContent)
+		}
+	})
+
+	t.Run("another wiki", func(t *testing.T) {
+		m := newSessionMemory()
+		m.dedupPage("s1", memoryWiki, testPage(content, "p1", 10), now)
+		page := testPage(content, "p1", 10)
+		page.Source = nil
+		m.dedupPage("s1", "https://other.example.org", page, now)
+		if page.Content != content || page.PriorContent != nil {
+			t.Errorf("content %q, prior %+v, want it unchanged", page.Content, page.PriorContent)
+		}
+	})
+}
+
+func TestHeadingKey(t *testing.T) {
+	tests := map[string]string{
+		"History":            "history",
+		"  Early   *life*  ": "early life",
+		"`code` and \\_x\\_": "code and x",
+		"Ünïcode":            "ünïcode",
+	}
+	for heading, want := range tests {
+		if got := headingKey(heading); got != want {
+			t.Errorf("headingKey(%q) = %q, want %q", heading, got, want)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package mcp
+
+import (
+	"cmp"
+	"html/template"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/config"
+)
+
+const (
+	// statsTopPages is the number of most requested pages reported
+	statsTopPages = 10
+	// statsMaxPages bounds the pages counted; later pages are not counted
+	statsMaxPages = 10000
+	// statsRecentErrors is the number of recent errors remembered
+	statsRecentErrors = 20
+)
+
+// statsCollector counts tool calls since the server started, for the
+// operator dashboard at /stats
+type statsCollector struct {
+	mu      sync.Mutex
+	started time.Time
+	wikis   map[string]*WikiStats
+	pages   map[PageStats]int // keyed with Calls zero
+	errors  []RecentError     // oldest first
+}
+
+// StatsReport summarizes the server's activity since it started
+type StatsReport struct {
+	Version       string        `json:"version"`
+	StartedAt     time.Time     `json:"started_at"`
+	UptimeSeconds int           `json:"uptime_seconds"`
+	Calls         int           `json:"calls"`
+	Errors        int           `json:"errors"`
+	Cache         CacheStats    `json:"cache"`
+	Wikis         []WikiStats   `json:"wikis"`     // most called first
+	TopPages      []PageStats   `json:"top_pages"` // most called first
+	RecentErrors  []RecentError `json:"recent_errors"`
+}
+
+// CacheStats counts tool calls answered from cache, among those that look
+// up their result there
+type CacheStats struct {
+	Lookups  int     `json:"lookups"`
+	Hits     int     `json:"hits"`
+	HitRatio float64 `json:"hit_ratio"`
+	Entries  int     `json:"entries"`
+}
+
+// WikiStats counts the tool calls made for one wiki
+type WikiStats struct {
+	WikiURL      string  `json:"wiki_url"` // empty for calls without one
+	Calls        int     `json:"calls"`
+	Errors       int     `json:"errors"`
+	CacheLookups int     `json:"cache_lookups"`
+	CacheHits    int     `json:"cache_hits"`
+	HitRatio     float64 `json:"cache_hit_ratio"`
+}
+
+// PageStats counts the tool calls made for one page or category
+type PageStats struct {
+	WikiURL string `json:"wiki_url"`
+	Title   string `json:"title"`
+	Calls   int    `json:"calls"`
+}
+
+// RecentError is a failed tool call
+type RecentError struct {
+	At      time.Time `json:"at"`
+	Tool    string    `json:"tool"`
+	WikiURL string    `json:"wiki_url,omitempty"`
+	Title   string    `json:"title,omitempty"`
+	Error   string    `json:"error"`
+	Message string    `json:"message,omitempty"`
+}
+
+func newStatsCollector(started time.Time) *statsCollector {
+	return &statsCollector{
+		started: started,
+		wikis:   make(map[string]*WikiStats),
+		pages:   make(map[PageStats]int),
+	}
+}
+
+// record counts a finished tool call; errorCode is empty if it succeeded
+func (c *statsCollector) record(call callRecord, cacheLookup, cacheHit bool, errorCode, message string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	wikiURL := strings.TrimSuffix(call.WikiURL, "/")
+	w, ok := c.wikis[wikiURL]
+	if !ok {
+		w = &WikiStats{WikiURL: wikiURL}
+		c.wikis[wikiURL] = w
+	}
+	w.Calls++
+	if cacheLookup {
+		w.CacheLookups++
+		if cacheHit {
+			w.CacheHits++
+		}
+	}
+
+	if call.Title != "" {
+		page := PageStats{WikiURL: wikiURL, Title: call.Title}
+		if _, ok := c.pages[page]; ok || len(c.pages) < statsMaxPages {
+			c.pages[page]++
+		}
+	}
+
+	if errorCode != "" {
+		w.Errors++
+		c.errors = append(c.errors, RecentError{
+			At:      call.At,
+			Tool:    call.Tool,
+			WikiURL: wikiURL,
+			Title:   call.Title,
+			Error:   errorCode,
+			Message: message,
+		})
+		if len(c.errors) > statsRecentErrors {
+			c.errors = c.errors[len(c.errors)-statsRecentErrors:]
+		}
+	}
+}
+
+// report summarizes the calls counted so far
+func (c *statsCollector) report(now time.Time, cacheEntries int) *StatsReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	report := &StatsReport{
+		Version:       config.Version,
+		StartedAt:     c.started,
+		UptimeSeconds: int(now.Sub(c.started) / time.Second),
+		Cache:         CacheStats{Entries: cacheEntries},
+		Wikis:         make([]WikiStats, 0, len(c.wikis)),
+		TopPages:      make([]PageStats, 0, statsTopPages),
+		RecentErrors:  make([]RecentError, 0, len(c.errors)),
+	}
+	for _, w := range c.wikis {
+		stats := *w
+		stats.HitRatio = ratio(w.CacheHits, w.CacheLookups)
+		report.Wikis = append(report.Wikis, stats)
+		report.Calls += w.Calls
+		report.Errors += w.Errors
+		report.Cache.Lookups += w.CacheLookups
+		report.Cache.Hits += w.CacheHits
+	}
+	report.Cache.HitRatio = ratio(report.Cache.Hits, report.Cache.Lookups)
+	slices.SortFunc(report.Wikis, func(a, b WikiStats) int {
+		return cmp.Or(b.Calls-a.Calls, strings.Compare(a.WikiURL, b.WikiURL))
+	})
+
+	for page, calls := range c.pages {
+		page.Calls = calls
+		report.TopPages = append(report.TopPages, page)
+	}
+	slices.SortFunc(report.TopPages, func(a, b PageStats) int {
+		return cmp.Or(b.Calls-a.Calls, strings.Compare(a.WikiURL, b.WikiURL), strings.Compare(a.Title, b.Title))
+	})
+	report.TopPages = report.TopPages[:min(len(report.TopPages), statsTopPages)]
+
+	for i := len(c.errors) - 1; i >= 0; i-- {
+		report.RecentErrors = append(report.RecentErrors, c.errors[i])
+	}
+	return report
+}
+
+// ratio returns part/whole, or 0 when whole is 0
+func ratio(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) / float64(whole)
+}
+
+// StatsHandler serves GET /stats, a summary of the server's activity since
+// it started: an HTML page for browsers, or JSON for /stats.json and
+// requests that accept application/json. It lists page titles and error
+// messages, so with MCP_API_TOKENS set it needs a token like /mcp.
+func (s *Server) StatsHandler() http.Handler {
+	return s.RequireAPIToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			writeJSONError(w, http.StatusMethodNotAllowed, FormatErrorString("method_not_allowed", "use GET"))
+			return
+		}
+
+		report := s.stats.report(time.Now(), s.client.GetCache().Len())
+		if r.URL.Path == "/stats.json" || r.URL.Query().Get("format") == "json" ||
+			strings.Contains(r.Header.Get("Accept"), "application/json") {
+			writeJSON(w, http.StatusOK, report)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		statsPage.Execute(w, report)
+	}))
+}
+
+var statsPage = template.Must(template.New("stats").Funcs(template.FuncMap{
+	"percent": func(r float64) string { return strconv.FormatFloat(r*100, 'f', 1, 64) + "%" },
+	"uptime":  func(seconds int) string { return (time.Duration(seconds) * time.Second).String() },
+	"wiki": func(wikiURL string) string {
+		if wikiURL == "" {
+			return "(none)"
+		}
+		return wikiURL
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>MediaWiki MCP Server statistics</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; }
+td.n { text-align: right; }
+</style>
+</head>
+<body>
+<h1>MediaWiki MCP Server v{{.Version}}</h1>
+<p>Up {{uptime .UptimeSeconds}} since {{.StartedAt.UTC.Format "2006-01-02 15:04:05 UTC"}}.
+{{.Calls}} tool calls, {{.Errors}} errors.
+Cache: {{.Cache.Hits}} of {{.Cache.Lookups}} lookups hit ({{percent .Cache.HitRatio}}), {{.Cache.Entries}} entries.</p>
+
+<h2>Wikis</h2>
+<table>
+<tr><th>Wiki</th><th>Calls</th><th>Errors</th><th>Cache hits</th></tr>
+{{range .Wikis}}<tr><td>{{wiki .WikiURL}}</td><td class="n">{{.Calls}}</td><td class="n">{{.Errors}}</td><td class="n">{{.CacheHits}} of {{.CacheLookups}} ({{percent .HitRatio}})</td></tr>
+{{else}}<tr><td colspan="4">No calls yet</td></tr>
+{{end}}</table>
+
+<h2>Top pages</h2>
+<table>
+<tr><th>Wiki</th><th>Title</th><th>Calls</th></tr>
+{{range .TopPages}}<tr><td>{{wiki .WikiURL}}</td><td>{{.Title}}</td><td class="n">{{.Calls}}</td></tr>
+{{else}}<tr><td colspan="3">No pages requested yet</td></tr>
+{{end}}</table>
+
+<h2>Recent errors</h2>
+<table>
+<tr><th>Time</th><th>Tool</th><th>Wiki</th><th>Title</th><th>Error</th><th>Message</th></tr>
+{{range .RecentErrors}}<tr><td>{{.At.UTC.Format "2006-01-02 15:04:05"}}</td><td>{{.Tool}}</td><td>{{wiki .WikiURL}}</td><td>{{.Title}}</td><td>{{.Error}}</td><td>{{.Message}}</td></tr>
+{{else}}<tr><td colspan="6">No errors</td></tr>
+{{end}}</table>
+
+<p><a href="/stats.json">JSON</a></p>
+</body>
+</html>
+`))
@@ -0,0 +1,45 @@
+package mcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/config"
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+func TestStatsHandlerAuth(t *testing.T) {
+	tests := []struct {
+		name   string
+		tokens []string
+		header string
+		want   int
+	}{
+		{"no tokens configured", nil, "", http.StatusOK},
+		{"missing token", []string{"secret"}, "", http.StatusUnauthorized},
+		{"wrong token", []string{"secret"}, "Bearer other", http.StatusUnauthorized},
+		{"valid token", []string{"secret"}, "Bearer secret", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{
+				config: &config.Config{APITokens: tt.tokens},
+				client: wiki.NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute),
+				stats:  newStatsCollector(time.Now()),
+			}
+			for _, path := range []string{"/stats", "/stats.json"} {
+				req := httptest.NewRequest(http.MethodGet, path, nil)
+				if tt.header != "" {
+					req.Header.Set("Authorization", tt.header)
+				}
+				rec := httptest.NewRecorder()
+				s.StatsHandler().ServeHTTP(rec, req)
+				if rec.Code != tt.want {
+					t.Errorf("GET %s: status %d, want %d", path, rec.Code, tt.want)
+				}
+			}
+		})
+	}
+}
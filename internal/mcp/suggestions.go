@@ -0,0 +1,159 @@
+package mcp
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// maxSuggestedSections is the number of matching sections an outline
+// suggests reading
+const maxSuggestedSections = 3
+
+// SuggestedCall is a tool call an agent is likely to want next, with its
+// arguments filled in
+type SuggestedCall struct {
+	Tool      string         `json:"tool"`
+	Arguments map[string]any `json:"arguments"`
+	Reason    string         `json:"reason"`
+}
+
+// suggestionArgs are the arguments of the call being answered that
+// suggestions carry over
+type suggestionArgs struct {
+	WikiURL        string `json:"wiki_url"`
+	Language       string `json:"language"`
+	Title          string `json:"title"`
+	Query          string `json:"query"`
+	RelevanceQuery string `json:"relevance_query"` // wiki_page_outline's, with query as its alias
+}
+
+// with returns the arguments of a suggested call on the same wiki
+func (a suggestionArgs) with(extra map[string]any) map[string]any {
+	args := map[string]any{"wiki_url": a.WikiURL}
+	if a.Language != "" {
+		args["language"] = a.Language
+	}
+	for key, value := range extra {
+		args[key] = value
+	}
+	return args
+}
+
+// suggesters compute the suggested next calls from a tool's arguments and
+// result, given the session's earlier calls
+var suggesters = map[string]func(args suggestionArgs, data json.RawMessage, history []callRecord) []SuggestedCall{
+	"wiki_search":       suggestAfterSearch,
+	"wiki_page_outline": suggestAfterOutline,
+}
+
+// suggestNextCalls returns the suggested next calls for a successful result,
+// if any
+func suggestNextCalls(tool string, arguments, data json.RawMessage, history []callRecord) []SuggestedCall {
+	suggest, ok := suggesters[tool]
+	if !ok {
+		return nil
+	}
+	var args suggestionArgs
+	_ = json.Unmarshal(arguments, &args)
+	return suggest(args, data, history)
+}
+
+// suggestAfterSearch suggests the outline of the top hit, or searching for
+// the wiki's spelling suggestion when nothing was found
+func suggestAfterSearch(args suggestionArgs, data json.RawMessage, _ []callRecord) []SuggestedCall {
+	var result struct {
+		Results []struct {
+			Title string `json:"title"`
+		} `json:"results"`
+		Suggestion *string `json:"suggestion"`
+	}
+	if json.Unmarshal(data, &result) != nil {
+		return nil
+	}
+
+	if len(result.Results) > 0 {
+		return []SuggestedCall{{
+			Tool:      "wiki_page_outline",
+			Arguments: args.with(map[string]any{"title": result.Results[0].Title, "relevance_query": args.Query}),
+			Reason:    "Top search hit",
+		}}
+	}
+	if result.Suggestion != nil && *result.Suggestion != "" {
+		return []SuggestedCall{{
+			Tool:      "wiki_search",
+			Arguments: args.with(map[string]any{"query": *result.Suggestion}),
+			Reason:    "No hits; the wiki suggests this spelling",
+		}}
+	}
+	return nil
+}
+
+// outlineSection is the part of an outline section used for suggestions
+type outlineSection struct {
+	Index       int               `json:"index"`
+	Title       string            `json:"title"`
+	Preview     string            `json:"preview"`
+	Relevance   *float64          `json:"relevance"`
+	Subsections []*outlineSection `json:"subsections"`
+}
+
+// suggestAfterOutline suggests reading the sections most relevant to the
+// outline's relevance_query, by the relevance the outline returns for each.
+// Without one, the sections are scored the same way against the last search
+// on the same wiki in this session. history is empty for calls without a
+// session, which only go by their own query.
+func suggestAfterOutline(args suggestionArgs, data json.RawMessage, history []callRecord) []SuggestedCall {
+	var result struct {
+		Title    string            `json:"title"`
+		Sections []*outlineSection `json:"sections"`
+	}
+	if json.Unmarshal(data, &result) != nil {
+		return nil
+	}
+	var sections []*outlineSection
+	var flatten func(tree []*outlineSection)
+	flatten = func(tree []*outlineSection) {
+		for _, section := range tree {
+			sections = append(sections, section)
+			flatten(section.Subsections)
+		}
+	}
+	flatten(result.Sections)
+
+	query := cmp.Or(args.RelevanceQuery, args.Query)
+	if query == "" {
+		for i := len(history) - 1; i >= 0 && query == ""; i-- {
+			if c := history[i]; c.Tool == "wiki_search" && !c.Failed && c.WikiURL == args.WikiURL {
+				query = c.Query
+			}
+		}
+		// The outline wasn't scored, so score it as wiki_page_outline would
+		docs := make([]wiki.RelevanceDoc, len(sections))
+		for i, section := range sections {
+			docs[i] = wiki.RelevanceDoc{Heading: section.Title, Text: section.Preview}
+		}
+		for i, score := range wiki.ScoreRelevance(query, docs) {
+			sections[i].Relevance = &score
+		}
+	}
+
+	sections = slices.DeleteFunc(sections, func(section *outlineSection) bool {
+		return section.Relevance == nil || *section.Relevance <= 0
+	})
+	slices.SortStableFunc(sections, func(a, b *outlineSection) int {
+		return cmp.Or(cmp.Compare(*b.Relevance, *a.Relevance), a.Index-b.Index)
+	})
+	var calls []SuggestedCall
+	for _, section := range sections[:min(len(sections), maxSuggestedSections)] {
+		calls = append(calls, SuggestedCall{
+			Tool:      "wiki_page_section",
+			Arguments: args.with(map[string]any{"title": cmp.Or(result.Title, args.Title), "section_index": section.Index}),
+			Reason:    fmt.Sprintf("Section %q matches %q (relevance %.2f)", section.Title, query, *section.Relevance),
+		})
+	}
+	return calls
+}
@@ -0,0 +1,50 @@
+package mcp
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSuggestAfterOutline(t *testing.T) {
+	scored := `{"title":"Town","sections":[
+		{"index":1,"title":"History","preview":"Founded","relevance":0.4,"subsections":[
+			{"index":2,"title":"Awards","preview":"Early career awards","relevance":1}]},
+		{"index":3,"title":"Geography","preview":"Hills","relevance":0},
+		{"index":4,"title":"Career","preview":"Work","relevance":0.4},
+		{"index":5,"title":"Notes","preview":"Awards list","relevance":0.2}]}`
+	unscored := `{"title":"Town","sections":[
+		{"index":1,"title":"History","preview":"Founded"},
+		{"index":2,"title":"Geography","preview":"Hills and rivers"}]}`
+	search := callRecord{Tool: "wiki_search", WikiURL: "https://wiki.example.org", Query: "rivers", At: time.Now()}
+
+	tests := []struct {
+		name    string
+		args    suggestionArgs
+		data    string
+		history []callRecord
+		want    []any // section indexes, best first
+	}{
+		{"by relevance", suggestionArgs{RelevanceQuery: "early career awards"}, scored, nil, []any{2, 1, 4}},
+		{"query alias", suggestionArgs{Query: "early career awards"}, scored, nil, []any{2, 1, 4}},
+		{"session's search", suggestionArgs{}, unscored, []callRecord{search}, []any{2}},
+		{"search on another wiki", suggestionArgs{}, unscored, []callRecord{{Tool: "wiki_search", WikiURL: "https://other.example.org", Query: "rivers"}}, nil},
+		{"no query", suggestionArgs{}, unscored, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args.WikiURL = "https://wiki.example.org"
+			var got []any
+			for _, call := range suggestAfterOutline(tt.args, json.RawMessage(tt.data), tt.history) {
+				if call.Tool != "wiki_page_section" || call.Arguments["title"] != "Town" {
+					t.Errorf("call = %+v, want a section of Town", call)
+				}
+				got = append(got, call.Arguments["section_index"])
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("suggested sections %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
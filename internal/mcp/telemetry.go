@@ -0,0 +1,126 @@
+package mcp
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// historyMaxAge is how long tool calls are remembered per session
+	historyMaxAge = 1 * time.Hour
+	// historyMaxCalls bounds the number of calls remembered per session
+	historyMaxCalls = 100
+)
+
+// callRecord is a single tool invocation observed by the server
+type callRecord struct {
+	Tool    string
+	WikiURL string
+	Title   string
+	Query   string // search query, for suggestions based on it
+	At      time.Time
+	Failed  bool
+}
+
+// callHistory remembers recent tool calls per session so failures can be
+// explained in terms of what the client did before
+type callHistory struct {
+	mu    sync.Mutex
+	calls map[string][]callRecord
+}
+
+func newCallHistory() *callHistory {
+	return &callHistory{
+		calls: make(map[string][]callRecord),
+	}
+}
+
+// record appends a call to a session's history, dropping stale entries
+func (h *callHistory) record(session string, rec callRecord) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	calls := append(pruneCalls(h.calls[session], rec.At), rec)
+	if len(calls) > historyMaxCalls {
+		calls = calls[len(calls)-historyMaxCalls:]
+	}
+	h.calls[session] = calls
+
+	// Sweep sessions that have gone quiet
+	if len(h.calls) > 1000 {
+		for key, recs := range h.calls {
+			if len(pruneCalls(recs, rec.At)) == 0 {
+				delete(h.calls, key)
+			}
+		}
+	}
+}
+
+// recent returns a copy of a session's calls, oldest first
+func (h *callHistory) recent(session string, now time.Time) []callRecord {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	calls := pruneCalls(h.calls[session], now)
+	result := make([]callRecord, len(calls))
+	copy(result, calls)
+	return result
+}
+
+// pruneCalls drops calls older than historyMaxAge
+func pruneCalls(calls []callRecord, now time.Time) []callRecord {
+	cutoff := now.Add(-historyMaxAge)
+	for i, c := range calls {
+		if c.At.After(cutoff) {
+			return calls[i:]
+		}
+	}
+	return nil
+}
+
+// sessionKey identifies the client session a request belongs to, or is
+// empty when the request has none
+func sessionKey(req *mcp.CallToolRequest) string {
+	if req.Session == nil {
+		return ""
+	}
+	return req.Session.ID()
+}
+
+// historyKey returns the session a call is recorded under and its hints
+// are drawn from, or "" if it has no lasting session. Only stateful mode
+// gives calls one; without it, every client's calls would share a history
+// and get hints about each other's calls.
+func (s *Server) historyKey(req *mcp.CallToolRequest) string {
+	if !s.config.Stateful {
+		return ""
+	}
+	return sessionKey(req)
+}
+
+// callFromRequest extracts the fields the hint engine cares about
+func callFromRequest(req *mcp.CallToolRequest, now time.Time) callRecord {
+	var args struct {
+		WikiURL  string `json:"wiki_url"`
+		Title    string `json:"title"`
+		Category string `json:"category"`
+		Query    string `json:"query"`
+	}
+	_ = json.Unmarshal(req.Params.Arguments, &args)
+
+	title := args.Title
+	if title == "" {
+		title = args.Category
+	}
+
+	return callRecord{
+		Tool:    req.Params.Name,
+		WikiURL: args.WikiURL,
+		Title:   title,
+		Query:   args.Query,
+		At:      now,
+	}
+}
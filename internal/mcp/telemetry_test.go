@@ -0,0 +1,45 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/yourusername/mediawiki-mcp/config"
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+func TestSessionlessCallsShareNoHistory(t *testing.T) {
+	for _, stateful := range []bool{false, true} {
+		s := &Server{
+			config:  &config.Config{Stateful: stateful},
+			history: newCallHistory(),
+			stats:   newStatsCollector(time.Now()),
+		}
+		handler := s.track(func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return s.errorResult(req, &wiki.APIError{Code: "missingtitle", Message: "The page doesn't exist."}, ""), nil
+		})
+
+		// Calls without a session, as from different clients of a
+		// stateless server
+		var resp ErrorResponse
+		for i := 0; i < 3; i++ {
+			result, err := handler(context.Background(), toolRequest("wiki_page_full", `{"wiki_url":"https://wiki.example.org","title":"Gone"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(resultText(t, result)), &resp); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if resp.Error != "missingtitle" || len(resp.NextSteps) != 0 {
+			t.Errorf("stateful %v: error %q, next_steps %q, want none", stateful, resp.Error, resp.NextSteps)
+		}
+		if len(s.history.calls) != 0 {
+			t.Errorf("stateful %v: history = %+v, want nothing recorded", stateful, s.history.calls)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// yamlNode is a JSON value with its object members in their original order
+type yamlNode struct {
+	keys     []string    // object member names; nil for arrays and scalars
+	children []*yamlNode // object member values or array items
+	isObject bool
+	isArray  bool
+	scalar   interface{} // string, json.Number, bool, or nil
+}
+
+// jsonToYAML renders a JSON document as YAML, keeping the order of object
+// members. Multi-line strings, such as page content in Markdown, become
+// literal blocks so they read as they would in a file; other strings are
+// left unquoted where YAML can't mistake them for another type.
+func jsonToYAML(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	root, err := decodeYAMLNode(dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("trailing data after JSON value")
+	}
+
+	var b bytes.Buffer
+	switch {
+	case root.isObject && len(root.keys) > 0:
+		writeYAMLObject(&b, root, 0)
+	case root.isArray && len(root.children) > 0:
+		writeYAMLArray(&b, root, 0)
+	default:
+		b.WriteString(yamlInline(root, 0))
+		b.WriteByte('\n')
+	}
+	return b.Bytes(), nil
+}
+
+// decodeYAMLNode reads the next JSON value
+func decodeYAMLNode(dec *json.Decoder) (*yamlNode, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		node := &yamlNode{isObject: true}
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := keyTok.(string)
+			value, err := decodeYAMLNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			node.keys = append(node.keys, key)
+			node.children = append(node.children, value)
+		}
+		_, err := dec.Token() // }
+		return node, err
+	case json.Delim('['):
+		node := &yamlNode{isArray: true}
+		for dec.More() {
+			item, err := decodeYAMLNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			node.children = append(node.children, item)
+		}
+		_, err := dec.Token() // ]
+		return node, err
+	default:
+		return &yamlNode{scalar: tok}, nil
+	}
+}
+
+// nested reports whether a node is written on lines of its own, below its
+// key or dash, rather than inline
+func (n *yamlNode) nested() bool {
+	return (n.isObject || n.isArray) && len(n.children) > 0
+}
+
+// writeYAMLObject writes an object's members at an indentation, the first
+// without it when the caller has already written it (after an array dash)
+func writeYAMLObject(b *bytes.Buffer, n *yamlNode, indent int) {
+	for i, key := range n.keys {
+		if i > 0 || b.Len() == 0 || b.Bytes()[b.Len()-1] == '\n' {
+			b.WriteString(strings.Repeat(" ", indent))
+		}
+		b.WriteString(yamlString(key))
+		b.WriteByte(':')
+		writeYAMLValue(b, n.children[i], indent)
+	}
+}
+
+// writeYAMLArray writes an array's items as a block sequence
+func writeYAMLArray(b *bytes.Buffer, n *yamlNode, indent int) {
+	for _, item := range n.children {
+		b.WriteString(strings.Repeat(" ", indent))
+		b.WriteByte('-')
+		if item.isObject && item.nested() {
+			b.WriteByte(' ')
+			writeYAMLObject(b, item, indent+2)
+			continue
+		}
+		writeYAMLValue(b, item, indent)
+	}
+}
+
+// writeYAMLValue writes the value after a key's colon or an item's dash,
+// nested two spaces deeper than the key or dash
+func writeYAMLValue(b *bytes.Buffer, n *yamlNode, indent int) {
+	switch {
+	case n.isObject && n.nested():
+		b.WriteByte('\n')
+		writeYAMLObject(b, n, indent+2)
+	case n.isArray && n.nested():
+		b.WriteByte('\n')
+		writeYAMLArray(b, n, indent+2)
+	default:
+		b.WriteByte(' ')
+		b.WriteString(yamlInline(n, indent+2))
+		b.WriteByte('\n')
+	}
+}
+
+// yamlInline renders an empty collection or a scalar. A string that can be
+// a literal block is written as one, its lines at the indentation given.
+func yamlInline(n *yamlNode, indent int) string {
+	switch {
+	case n.isObject:
+		return "{}"
+	case n.isArray:
+		return "[]"
+	}
+	switch v := n.scalar.(type) {
+	case nil:
+		return "null"
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	case json.Number:
+		return v.String()
+	case string:
+		if block, ok := yamlBlock(v, indent); ok {
+			return block
+		}
+		return yamlString(v)
+	}
+	return fmt.Sprint(n.scalar)
+}
+
+// yamlPlain matches strings that can be plain scalars: starting with a
+// letter or one of a few symbols, and without control characters. Numbers,
+// dates, and other strings starting with a digit are quoted.
+var yamlPlain = regexp.MustCompile(`^[\p{L}_/(][^\x00-\x1f\x7f\x{2028}\x{2029}\x{feff}]*$`)
+
+// yamlReserved are the words YAML (1.1 or 1.2) reads as booleans or null
+var yamlReserved = map[string]bool{
+	"true": true, "false": true, "yes": true, "no": true, "on": true, "off": true,
+	"y": true, "n": true, "null": true,
+}
+
+// yamlString renders a string as a plain scalar where that is unambiguous,
+// and double-quoted otherwise. A JSON string is a valid double-quoted YAML
+// scalar, escapes included.
+func yamlString(s string) string {
+	plain := yamlPlain.MatchString(s) && !yamlReserved[strings.ToLower(s)] &&
+		!strings.HasSuffix(s, " ") && !strings.HasSuffix(s, ":") &&
+		!strings.Contains(s, ": ") && !strings.Contains(s, " #")
+	if plain {
+		return s
+	}
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// yamlBlock renders a multi-line string as a literal block scalar, with its
+// lines at the indentation given, if it can be one: it must not hold other
+// control characters, and its first line must not start with a space,
+// which would be taken for the block's indentation.
+func yamlBlock(s string, indent int) (string, bool) {
+	if !strings.Contains(strings.TrimRight(s, "\n"), "\n") {
+		return "", false
+	}
+	for _, r := range s {
+		if (r < 0x20 && r != '\n' && r != '\t') || r == 0x7f || r == '\u2028' || r == '\u2029' || r == '\ufeff' {
+			return "", false
+		}
+	}
+	if first := strings.TrimLeft(s, "\n"); strings.HasPrefix(first, " ") || strings.HasPrefix(first, "\t") {
+		return "", false
+	}
+
+	// Chomping keeps the string's trailing newlines as they are
+	body := strings.TrimRight(s, "\n")
+	header := "|-"
+	switch trailing := len(s) - len(body); {
+	case trailing == 1:
+		header = "|"
+	case trailing > 1:
+		header = "|+"
+	}
+
+	var b strings.Builder
+	b.WriteString(header)
+	prefix := strings.Repeat(" ", indent)
+	lines := strings.Split(body+strings.Repeat("\n", max(len(s)-len(body)-1, 0)), "\n")
+	for _, line := range lines {
+		b.WriteByte('\n')
+		if line != "" {
+			b.WriteString(prefix)
+			b.WriteString(line)
+		}
+	}
+	return b.String(), true
+}
@@ -0,0 +1,86 @@
+package mcp
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestJSONToYAMLRoundTrip(t *testing.T) {
+	tests := []struct {
+		name, json string
+	}{
+		{"reserved words", `{"a":"yes","b":"no","c":"null","d":"true","e":"on","f":"Off","g":"y","h":"~"}`},
+		{"reserved keys", `{"yes":1,"null":2,"true":3,"~":4}`},
+		{"numbers", `{"int":42,"neg":-7,"float":1.5,"exp":1e3,"big":12345678901234567}`},
+		{"numeric strings", `{"a":"1e3","b":"42","c":"0x1F","d":"1.5","e":"2026-01-01","f":".inf","g":"-.5"}`},
+		{"leading indicators", `{"a":"-x","b":"- item","c":":x","d":"#x","e":"? q","f":"!tag","g":"&anchor","h":"*alias","i":"|x","j":">x","k":"%x","l":"@x","m":"'q'","n":"\"q\"","o":"{x}","p":"[x]","q":",x","r":"` + "`x`" + `"}`},
+		{"colons and comments", `{"a":"key: value","b":"ends:","c":"x #y","d":"http://example.org/a#b","e":"a:b"}`},
+		{"spaces", `{"a":" leading","b":"trailing ","c":"","d":" ","e":"a\tb"}`},
+		{"multi-line", `{"text":"line one  \nline two \n","b":"no newline at end\nlast line  ","c":"two newlines\n\n","d":"\n\nstarts after blank lines","e":"a\n  \nb","f":"tab\tin\nblock\t"}`},
+		{"multi-line that can't be a block", `{"a":" indented\nsecond","b":"\ttab first\nsecond","c":"bell\u0007\nsecond","d":"\r\nwindows\r\n"}`},
+		{"empty collections", `{"map":{},"list":[],"nested":{"map":{},"list":[[]]}}`},
+		{"nested", `{"pages":[{"title":"Town","links":["A","B"],"meta":{"size":10}},[1,[2,3]],{"text":"multi\nline"},"x"]}`},
+		{"null and bools", `{"a":null,"b":true,"c":false,"d":[null,true]}`},
+		{"unicode", `{"a":"Zürich","b":"日本語","c":"line\u2028sep","d":"\ufeffbom"}`},
+		{"top-level array", `["a",{"b":1},[]]`},
+		{"top-level scalar", `"yes"`},
+		{"top-level empty", `{}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := jsonToYAML([]byte(tt.json))
+			if err != nil {
+				t.Fatalf("jsonToYAML: %v", err)
+			}
+			var want, got interface{}
+			if err := json.Unmarshal([]byte(tt.json), &want); err != nil {
+				t.Fatal(err)
+			}
+			var parsed interface{}
+			if err := yaml.Unmarshal(out, &parsed); err != nil {
+				t.Fatalf("YAML doesn't parse: %v\n%s", err, out)
+			}
+			// Compared as JSON values, since YAML decodes numbers as ints
+			// and floats
+			reencoded, err := json.Marshal(parsed)
+			if err != nil {
+				t.Fatalf("re-encoding %#v: %v", parsed, err)
+			}
+			if err := json.Unmarshal(reencoded, &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("YAML reads back as %s, want %s\n%s", reencoded, tt.json, out)
+			}
+		})
+	}
+}
+
+func TestJSONToYAMLLayout(t *testing.T) {
+	tests := []struct {
+		json, want string
+	}{
+		{`{"b":1,"a":"x"}`, "b: 1\na: x\n"},
+		// Quoted for YAML 1.1 readers too, which take yes and no as booleans
+		{`{"a":"yes","b":"No","c":"null"}`, "a: \"yes\"\nb: \"No\"\nc: \"null\"\n"},
+		{`{"content":"# Town\n\nText\n"}`, "content: |\n  # Town\n\n  Text\n"},
+		{`{"list":[{"a":1,"b":2}],"empty":[]}`, "list:\n  - a: 1\n    b: 2\nempty: []\n"},
+	}
+	for _, tt := range tests {
+		out, err := jsonToYAML([]byte(tt.json))
+		if err != nil || string(out) != tt.want {
+			t.Errorf("jsonToYAML(%s) = %q, %v, want %q", tt.json, out, err, tt.want)
+		}
+	}
+}
+
+func TestJSONToYAMLInvalid(t *testing.T) {
+	for _, input := range []string{``, `{"a":`, `{"a":1} {"b":2}`, `plain text`} {
+		if out, err := jsonToYAML([]byte(input)); err == nil {
+			t.Errorf("jsonToYAML(%q) = %q, want an error", input, out)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Package perf checks benchmarks against performance budgets, so CI catches
+// regressions in the conversion pipeline without comparing against a
+// previous run.
+package perf
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+)
+
+const (
+	// EnableEnv turns budget checks on. They are skipped otherwise because
+	// they take several seconds and depend on the machine.
+	EnableEnv = "MCP_PERF_BUDGETS"
+	// ScaleEnv multiplies every time budget, for runners slower or faster
+	// than the reference machine
+	ScaleEnv = "MCP_PERF_SCALE"
+	// ReportEnv names a file that receives the results as JSON lines
+	ReportEnv = "MCP_PERF_REPORT"
+)
+
+// Budget is the most a benchmark may cost per operation
+type Budget struct {
+	NsPerOp     int64
+	AllocsPerOp int64
+}
+
+// Benchmark is a benchmark function with its budget
+type Benchmark struct {
+	Name   string
+	Run    func(b *testing.B)
+	Budget Budget
+}
+
+// Result is one line of the threshold report
+type Result struct {
+	Name         string `json:"name"`
+	NsPerOp      int64  `json:"ns_per_op"`
+	BudgetNs     int64  `json:"budget_ns_per_op"`
+	AllocsPerOp  int64  `json:"allocs_per_op"`
+	BudgetAllocs int64  `json:"budget_allocs_per_op"`
+	BytesPerOp   int64  `json:"bytes_per_op"`
+	Pass         bool   `json:"pass"`
+}
+
+// Check runs each benchmark and fails t for those over budget. Every result
+// is logged, and appended to the report file when ReportEnv is set.
+func Check(t *testing.T, benchmarks []Benchmark) {
+	t.Helper()
+	if os.Getenv(EnableEnv) == "" {
+		t.Skipf("set %s=1 to check performance budgets", EnableEnv)
+	}
+
+	scale := 1.0
+	if val := os.Getenv(ScaleEnv); val != "" {
+		f, err := strconv.ParseFloat(val, 64)
+		if err != nil || f <= 0 {
+			t.Fatalf("invalid %s %q", ScaleEnv, val)
+		}
+		scale = f
+	}
+
+	results := make([]Result, 0, len(benchmarks))
+	for _, bench := range benchmarks {
+		r := testing.Benchmark(bench.Run)
+		result := Result{
+			Name:         bench.Name,
+			NsPerOp:      r.NsPerOp(),
+			BudgetNs:     int64(float64(bench.Budget.NsPerOp) * scale),
+			AllocsPerOp:  r.AllocsPerOp(),
+			BudgetAllocs: bench.Budget.AllocsPerOp,
+			BytesPerOp:   r.AllocedBytesPerOp(),
+		}
+		result.Pass = result.NsPerOp <= result.BudgetNs && result.AllocsPerOp <= result.BudgetAllocs
+		results = append(results, result)
+
+		status := "ok"
+		if !result.Pass {
+			status = "OVER BUDGET"
+			t.Fail()
+		}
+		t.Logf("%-28s %12d ns/op (budget %12d) %9d allocs/op (budget %9d)  %s",
+			result.Name, result.NsPerOp, result.BudgetNs, result.AllocsPerOp, result.BudgetAllocs, status)
+	}
+
+	if path := os.Getenv(ReportEnv); path != "" {
+		if err := appendReport(path, results); err != nil {
+			t.Errorf("write report: %v", err)
+		}
+	}
+}
+
+// appendReport writes results to path as JSON lines, so several packages
+// can contribute to one report
+func appendReport(path string, results []Result) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	for _, result := range results {
+		if err := enc.Encode(result); err != nil {
+			return fmt.Errorf("encode %s: %w", result.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Package profiles reads wiki profiles: named per-wiki settings, such as
+// the backend a wiki is served from
+package profiles
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// Backends a profile can serve its wiki from
+const (
+	BackendMediaWiki  = "mediawiki"  // the wiki's API over HTTP
+	BackendZIM        = "zim"        // a local ZIM archive
+	BackendConfluence = "confluence" // a Confluence space over its REST API
+)
+
+// Profile configures how the server reaches one wiki
+type Profile struct {
+	Name    string `json:"name"`
+	WikiURL string `json:"wiki_url"`          // the wiki_url tools are called with
+	Backend string `json:"backend,omitempty"` // BackendMediaWiki when empty
+	ZIMPath string `json:"zim_path,omitempty"`
+
+	// The account to use: for MediaWiki, a bot password or the access token
+	// of an OAuth 2.0 owner-only consumer, which requests are made with; for
+	// Confluence, the user whose API token reads the space
+	Username   string `json:"username,omitempty"`
+	Password   string `json:"password,omitempty"`
+	OAuthToken string `json:"oauth_token,omitempty"`
+
+	// Confluence backend: the site, the space served as the wiki, and an API
+	// token for Username or, without one, a personal access token
+	BaseURL string `json:"base_url,omitempty"`
+	Space   string `json:"space,omitempty"`
+	Token   string `json:"token,omitempty"`
+}
+
+// Load reads profiles from a JSON file
+func Load(path string) ([]Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read profiles: %w", err)
+	}
+
+	var profiles []Profile
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("parse profiles: %w", err)
+	}
+
+	names := make(map[string]bool)
+	wikis := make(map[string]string)
+	for i := range profiles {
+		p := &profiles[i]
+		if p.Name == "" || p.WikiURL == "" {
+			return nil, fmt.Errorf("profile %q: name and wiki_url are required", p.Name)
+		}
+		if names[p.Name] {
+			return nil, fmt.Errorf("profile %q is defined twice", p.Name)
+		}
+		names[p.Name] = true
+		if other, ok := wikis[key(p.WikiURL)]; ok {
+			return nil, fmt.Errorf("profiles %q and %q are both for %s", other, p.Name, p.WikiURL)
+		}
+		wikis[key(p.WikiURL)] = p.Name
+
+		if p.Backend == "" {
+			p.Backend = BackendMediaWiki
+		}
+		switch p.Backend {
+		case BackendMediaWiki:
+			if (p.Username == "") != (p.Password == "") {
+				return nil, fmt.Errorf("profile %q: username and password must be set together", p.Name)
+			}
+			if p.Username != "" && p.OAuthToken != "" {
+				return nil, fmt.Errorf("profile %q: set either a bot password or oauth_token, not both", p.Name)
+			}
+		case BackendZIM:
+			if p.ZIMPath == "" {
+				return nil, fmt.Errorf("profile %q: zim_path is required for the zim backend", p.Name)
+			}
+		case BackendConfluence:
+			if p.BaseURL == "" || p.Space == "" {
+				return nil, fmt.Errorf("profile %q: base_url and space are required for the confluence backend", p.Name)
+			}
+		default:
+			return nil, fmt.Errorf("profile %q: unknown backend %q", p.Name, p.Backend)
+		}
+	}
+	return profiles, nil
+}
+
+// Open returns the backend the profile's wiki is served from, or nil for a
+// MediaWiki server reached over HTTP
+func (p Profile) Open() (wiki.Backend, error) {
+	switch p.Backend {
+	case BackendZIM:
+		backend, err := wiki.NewZIMBackend(p.ZIMPath, p.WikiURL)
+		if err != nil {
+			return nil, fmt.Errorf("profile %q: %w", p.Name, err)
+		}
+		return backend, nil
+	case BackendConfluence:
+		backend, err := wiki.NewConfluenceBackend(wiki.ConfluenceConfig{
+			BaseURL:  p.BaseURL,
+			Space:    p.Space,
+			Username: p.Username,
+			Token:    p.Token,
+		}, p.WikiURL)
+		if err != nil {
+			return nil, fmt.Errorf("profile %q: %w", p.Name, err)
+		}
+		return backend, nil
+	}
+	return nil, nil
+}
+
+// Auth returns how the client authenticates to the profile's wiki, if it
+// has an account
+func (p Profile) Auth() (wiki.Auth, bool) {
+	if p.Backend != BackendMediaWiki || (p.Username == "" && p.OAuthToken == "") {
+		return wiki.Auth{}, false
+	}
+	return wiki.Auth{Username: p.Username, Password: p.Password, AccessToken: p.OAuthToken}, true
+}
+
+// Find returns the profile for a wiki, if there is one
+func Find(profiles []Profile, wikiURL string) (Profile, bool) {
+	for _, p := range profiles {
+		if key(p.WikiURL) == key(wikiURL) {
+			return p, true
+		}
+	}
+	return Profile{}, false
+}
+
+// key compares wiki URLs regardless of a trailing slash
+func key(wikiURL string) string {
+	return strings.TrimRight(wikiURL, "/")
+}
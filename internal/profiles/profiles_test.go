@@ -0,0 +1,72 @@
+package profiles
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeProfiles(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeProfiles(t, `[
+		{"name": "enwiki", "wiki_url": "https://en.wikipedia.org/", "username": "Bot@mcp", "password": "secret"},
+		{"name": "offline", "wiki_url": "https://de.wikipedia.org", "backend": "zim", "zim_path": "/data/de.zim"}
+	]`)
+
+	profiles, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(profiles) != 2 || profiles[0].Backend != BackendMediaWiki || profiles[1].Backend != BackendZIM {
+		t.Errorf("Load() = %+v", profiles)
+	}
+
+	p, ok := Find(profiles, "https://en.wikipedia.org")
+	if !ok || p.Name != "enwiki" {
+		t.Errorf("Find() = %+v, %v, want enwiki", p, ok)
+	}
+	if auth, ok := p.Auth(); !ok || auth.Username != "Bot@mcp" {
+		t.Errorf("Auth() = %+v, %v", auth, ok)
+	}
+	if _, ok := profiles[1].Auth(); ok {
+		t.Error("Auth() found an account for a profile without one")
+	}
+	if _, ok := Find(profiles, "https://fr.wikipedia.org"); ok {
+		t.Error("Find() found a profile for a wiki without one")
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"missing url", `[{"name": "a"}]`, "required"},
+		{"duplicate name", `[{"name": "a", "wiki_url": "https://a.org"}, {"name": "a", "wiki_url": "https://b.org"}]`, "twice"},
+		{"duplicate wiki", `[{"name": "a", "wiki_url": "https://a.org"}, {"name": "b", "wiki_url": "https://a.org/"}]`, "both"},
+		{"unknown backend", `[{"name": "a", "wiki_url": "https://a.org", "backend": "gopher"}]`, "unknown backend"},
+		{"username without password", `[{"name": "a", "wiki_url": "https://a.org", "username": "Bot@mcp"}]`, "together"},
+		{"bot password and oauth", `[{"name": "a", "wiki_url": "https://a.org", "username": "Bot@mcp", "password": "x", "oauth_token": "y"}]`, "not both"},
+		{"zim without path", `[{"name": "a", "wiki_url": "https://a.org", "backend": "zim"}]`, "zim_path"},
+		{"confluence without space", `[{"name": "a", "wiki_url": "https://a.org", "backend": "confluence", "base_url": "https://c.org"}]`, "space"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeProfiles(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
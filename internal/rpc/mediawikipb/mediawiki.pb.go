@@ -0,0 +1,3410 @@
+// gRPC interface to the MediaWiki tools. Messages mirror internal/wiki/types.go
+// and field names match the JSON returned by the MCP tools.
+//
+// Regenerate with:
+//   protoc --go_out=. --go_opt=module=github.com/yourusername/mediawiki-mcp \
+//     --go-grpc_out=. --go-grpc_opt=module=github.com/yourusername/mediawiki-mcp \
+//     proto/mediawiki/v1/mediawiki.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: proto/mediawiki/v1/mediawiki.proto
+
+package mediawikipb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WikiInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WikiUrl       string                 `protobuf:"bytes,1,opt,name=wiki_url,json=wikiUrl,proto3" json:"wiki_url,omitempty"`
+	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WikiInfoRequest) Reset() {
+	*x = WikiInfoRequest{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WikiInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WikiInfoRequest) ProtoMessage() {}
+
+func (x *WikiInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WikiInfoRequest.ProtoReflect.Descriptor instead.
+func (*WikiInfoRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WikiInfoRequest) GetWikiUrl() string {
+	if x != nil {
+		return x.WikiUrl
+	}
+	return ""
+}
+
+func (x *WikiInfoRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type SearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WikiUrl       string                 `protobuf:"bytes,1,opt,name=wiki_url,json=wikiUrl,proto3" json:"wiki_url,omitempty"`
+	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Query         string                 `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	InCategory    string                 `protobuf:"bytes,5,opt,name=in_category,json=inCategory,proto3" json:"in_category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SearchRequest) GetWikiUrl() string {
+	if x != nil {
+		return x.WikiUrl
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetInCategory() string {
+	if x != nil {
+		return x.InCategory
+	}
+	return ""
+}
+
+type PageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WikiUrl       string                 `protobuf:"bytes,1,opt,name=wiki_url,json=wikiUrl,proto3" json:"wiki_url,omitempty"`
+	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageRequest) Reset() {
+	*x = PageRequest{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageRequest) ProtoMessage() {}
+
+func (x *PageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageRequest.ProtoReflect.Descriptor instead.
+func (*PageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PageRequest) GetWikiUrl() string {
+	if x != nil {
+		return x.WikiUrl
+	}
+	return ""
+}
+
+func (x *PageRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *PageRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type PageOutlineRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	WikiUrl            string                 `protobuf:"bytes,1,opt,name=wiki_url,json=wikiUrl,proto3" json:"wiki_url,omitempty"`
+	Language           string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Title              string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	IncludeToc         bool                   `protobuf:"varint,4,opt,name=include_toc,json=includeToc,proto3" json:"include_toc,omitempty"`
+	IncludeReadability bool                   `protobuf:"varint,5,opt,name=include_readability,json=includeReadability,proto3" json:"include_readability,omitempty"`
+	IncludeLinkDetails bool                   `protobuf:"varint,6,opt,name=include_link_details,json=includeLinkDetails,proto3" json:"include_link_details,omitempty"`
+	RelevanceQuery     string                 `protobuf:"bytes,7,opt,name=relevance_query,json=relevanceQuery,proto3" json:"relevance_query,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *PageOutlineRequest) Reset() {
+	*x = PageOutlineRequest{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageOutlineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageOutlineRequest) ProtoMessage() {}
+
+func (x *PageOutlineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageOutlineRequest.ProtoReflect.Descriptor instead.
+func (*PageOutlineRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PageOutlineRequest) GetWikiUrl() string {
+	if x != nil {
+		return x.WikiUrl
+	}
+	return ""
+}
+
+func (x *PageOutlineRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *PageOutlineRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PageOutlineRequest) GetIncludeToc() bool {
+	if x != nil {
+		return x.IncludeToc
+	}
+	return false
+}
+
+func (x *PageOutlineRequest) GetIncludeReadability() bool {
+	if x != nil {
+		return x.IncludeReadability
+	}
+	return false
+}
+
+func (x *PageOutlineRequest) GetIncludeLinkDetails() bool {
+	if x != nil {
+		return x.IncludeLinkDetails
+	}
+	return false
+}
+
+func (x *PageOutlineRequest) GetRelevanceQuery() string {
+	if x != nil {
+		return x.RelevanceQuery
+	}
+	return ""
+}
+
+type PageSectionRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	WikiUrl            string                 `protobuf:"bytes,1,opt,name=wiki_url,json=wikiUrl,proto3" json:"wiki_url,omitempty"`
+	Language           string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Title              string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	SectionIndex       int32                  `protobuf:"varint,4,opt,name=section_index,json=sectionIndex,proto3" json:"section_index,omitempty"`
+	IncludeLinkDetails bool                   `protobuf:"varint,5,opt,name=include_link_details,json=includeLinkDetails,proto3" json:"include_link_details,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *PageSectionRequest) Reset() {
+	*x = PageSectionRequest{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageSectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageSectionRequest) ProtoMessage() {}
+
+func (x *PageSectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageSectionRequest.ProtoReflect.Descriptor instead.
+func (*PageSectionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PageSectionRequest) GetWikiUrl() string {
+	if x != nil {
+		return x.WikiUrl
+	}
+	return ""
+}
+
+func (x *PageSectionRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *PageSectionRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PageSectionRequest) GetSectionIndex() int32 {
+	if x != nil {
+		return x.SectionIndex
+	}
+	return 0
+}
+
+func (x *PageSectionRequest) GetIncludeLinkDetails() bool {
+	if x != nil {
+		return x.IncludeLinkDetails
+	}
+	return false
+}
+
+type PageListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WikiUrl       string                 `protobuf:"bytes,1,opt,name=wiki_url,json=wikiUrl,proto3" json:"wiki_url,omitempty"`
+	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageListRequest) Reset() {
+	*x = PageListRequest{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageListRequest) ProtoMessage() {}
+
+func (x *PageListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageListRequest.ProtoReflect.Descriptor instead.
+func (*PageListRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PageListRequest) GetWikiUrl() string {
+	if x != nil {
+		return x.WikiUrl
+	}
+	return ""
+}
+
+func (x *PageListRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *PageListRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PageListRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type CategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WikiUrl       string                 `protobuf:"bytes,1,opt,name=wiki_url,json=wikiUrl,proto3" json:"wiki_url,omitempty"`
+	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Category      string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	MemberType    string                 `protobuf:"bytes,5,opt,name=member_type,json=memberType,proto3" json:"member_type,omitempty"`
+	Sort          string                 `protobuf:"bytes,6,opt,name=sort,proto3" json:"sort,omitempty"`
+	Direction     string                 `protobuf:"bytes,7,opt,name=direction,proto3" json:"direction,omitempty"`
+	All           bool                   `protobuf:"varint,8,opt,name=all,proto3" json:"all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryRequest) Reset() {
+	*x = CategoryRequest{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryRequest) ProtoMessage() {}
+
+func (x *CategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryRequest.ProtoReflect.Descriptor instead.
+func (*CategoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CategoryRequest) GetWikiUrl() string {
+	if x != nil {
+		return x.WikiUrl
+	}
+	return ""
+}
+
+func (x *CategoryRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *CategoryRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CategoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *CategoryRequest) GetMemberType() string {
+	if x != nil {
+		return x.MemberType
+	}
+	return ""
+}
+
+func (x *CategoryRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *CategoryRequest) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *CategoryRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+type HasChangedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WikiUrl       string                 `protobuf:"bytes,1,opt,name=wiki_url,json=wikiUrl,proto3" json:"wiki_url,omitempty"`
+	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Revid         int32                  `protobuf:"varint,4,opt,name=revid,proto3" json:"revid,omitempty"`
+	ContentHash   string                 `protobuf:"bytes,5,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	SectionIndex  *int32                 `protobuf:"varint,6,opt,name=section_index,json=sectionIndex,proto3,oneof" json:"section_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HasChangedRequest) Reset() {
+	*x = HasChangedRequest{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HasChangedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HasChangedRequest) ProtoMessage() {}
+
+func (x *HasChangedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HasChangedRequest.ProtoReflect.Descriptor instead.
+func (*HasChangedRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *HasChangedRequest) GetWikiUrl() string {
+	if x != nil {
+		return x.WikiUrl
+	}
+	return ""
+}
+
+func (x *HasChangedRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *HasChangedRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *HasChangedRequest) GetRevid() int32 {
+	if x != nil {
+		return x.Revid
+	}
+	return 0
+}
+
+func (x *HasChangedRequest) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+func (x *HasChangedRequest) GetSectionIndex() int32 {
+	if x != nil && x.SectionIndex != nil {
+		return *x.SectionIndex
+	}
+	return 0
+}
+
+type CompareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WikiUrl       string                 `protobuf:"bytes,1,opt,name=wiki_url,json=wikiUrl,proto3" json:"wiki_url,omitempty"`
+	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	FromRevision  string                 `protobuf:"bytes,4,opt,name=from_revision,json=fromRevision,proto3" json:"from_revision,omitempty"`
+	ToRevision    string                 `protobuf:"bytes,5,opt,name=to_revision,json=toRevision,proto3" json:"to_revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareRequest) Reset() {
+	*x = CompareRequest{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareRequest) ProtoMessage() {}
+
+func (x *CompareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareRequest.ProtoReflect.Descriptor instead.
+func (*CompareRequest) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CompareRequest) GetWikiUrl() string {
+	if x != nil {
+		return x.WikiUrl
+	}
+	return ""
+}
+
+func (x *CompareRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *CompareRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CompareRequest) GetFromRevision() string {
+	if x != nil {
+		return x.FromRevision
+	}
+	return ""
+}
+
+func (x *CompareRequest) GetToRevision() string {
+	if x != nil {
+		return x.ToRevision
+	}
+	return ""
+}
+
+type WikiInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	BaseUrl       string                 `protobuf:"bytes,2,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	MainPage      string                 `protobuf:"bytes,3,opt,name=main_page,json=mainPage,proto3" json:"main_page,omitempty"`
+	Language      string                 `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"`
+	ArticleCount  int32                  `protobuf:"varint,5,opt,name=article_count,json=articleCount,proto3" json:"article_count,omitempty"`
+	Namespaces    map[string]string      `protobuf:"bytes,6,rep,name=namespaces,proto3" json:"namespaces,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WikiInfo) Reset() {
+	*x = WikiInfo{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WikiInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WikiInfo) ProtoMessage() {}
+
+func (x *WikiInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WikiInfo.ProtoReflect.Descriptor instead.
+func (*WikiInfo) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WikiInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WikiInfo) GetBaseUrl() string {
+	if x != nil {
+		return x.BaseUrl
+	}
+	return ""
+}
+
+func (x *WikiInfo) GetMainPage() string {
+	if x != nil {
+		return x.MainPage
+	}
+	return ""
+}
+
+func (x *WikiInfo) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *WikiInfo) GetArticleCount() int32 {
+	if x != nil {
+		return x.ArticleCount
+	}
+	return 0
+}
+
+func (x *WikiInfo) GetNamespaces() map[string]string {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+type SearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Snippet       string                 `protobuf:"bytes,2,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	SnippetLinks  []string               `protobuf:"bytes,3,rep,name=snippet_links,json=snippetLinks,proto3" json:"snippet_links,omitempty"`
+	WordCount     int32                  `protobuf:"varint,4,opt,name=word_count,json=wordCount,proto3" json:"word_count,omitempty"`
+	ViaRedirect   string                 `protobuf:"bytes,5,opt,name=via_redirect,json=viaRedirect,proto3" json:"via_redirect,omitempty"`
+	Source        *Provenance            `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchResult) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SearchResult) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *SearchResult) GetSnippetLinks() []string {
+	if x != nil {
+		return x.SnippetLinks
+	}
+	return nil
+}
+
+func (x *SearchResult) GetWordCount() int32 {
+	if x != nil {
+		return x.WordCount
+	}
+	return 0
+}
+
+func (x *SearchResult) GetViaRedirect() string {
+	if x != nil {
+		return x.ViaRedirect
+	}
+	return ""
+}
+
+func (x *SearchResult) GetSource() *Provenance {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+type SearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	TotalHits     int32                  `protobuf:"varint,2,opt,name=total_hits,json=totalHits,proto3" json:"total_hits,omitempty"`
+	Suggestion    *string                `protobuf:"bytes,3,opt,name=suggestion,proto3,oneof" json:"suggestion,omitempty"`
+	LimitClamped  *LimitClamp            `protobuf:"bytes,4,opt,name=limit_clamped,json=limitClamped,proto3,oneof" json:"limit_clamped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetTotalHits() int32 {
+	if x != nil {
+		return x.TotalHits
+	}
+	return 0
+}
+
+func (x *SearchResponse) GetSuggestion() string {
+	if x != nil && x.Suggestion != nil {
+		return *x.Suggestion
+	}
+	return ""
+}
+
+func (x *SearchResponse) GetLimitClamped() *LimitClamp {
+	if x != nil {
+		return x.LimitClamped
+	}
+	return nil
+}
+
+// LimitClamp reports that a requested limit was lowered to the server's maximum
+type LimitClamp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requested     int32                  `protobuf:"varint,1,opt,name=requested,proto3" json:"requested,omitempty"`
+	Max           int32                  `protobuf:"varint,2,opt,name=max,proto3" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LimitClamp) Reset() {
+	*x = LimitClamp{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LimitClamp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LimitClamp) ProtoMessage() {}
+
+func (x *LimitClamp) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LimitClamp.ProtoReflect.Descriptor instead.
+func (*LimitClamp) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LimitClamp) GetRequested() int32 {
+	if x != nil {
+		return x.Requested
+	}
+	return 0
+}
+
+func (x *LimitClamp) GetMax() int32 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+type Section struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Index          int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Number         string                 `protobuf:"bytes,2,opt,name=number,proto3" json:"number,omitempty"`
+	Title          string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Level          int32                  `protobuf:"varint,4,opt,name=level,proto3" json:"level,omitempty"`
+	Preview        string                 `protobuf:"bytes,5,opt,name=preview,proto3" json:"preview,omitempty"`
+	Content        string                 `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`
+	Links          []string               `protobuf:"bytes,7,rep,name=links,proto3" json:"links,omitempty"`
+	WordCount      int32                  `protobuf:"varint,8,opt,name=word_count,json=wordCount,proto3" json:"word_count,omitempty"`
+	TableWordCount int32                  `protobuf:"varint,9,opt,name=table_word_count,json=tableWordCount,proto3" json:"table_word_count,omitempty"`
+	ByteOffset     *int32                 `protobuf:"varint,10,opt,name=byte_offset,json=byteOffset,proto3,oneof" json:"byte_offset,omitempty"`
+	ByteSize       int32                  `protobuf:"varint,11,opt,name=byte_size,json=byteSize,proto3" json:"byte_size,omitempty"`
+	Subsections    []*Section             `protobuf:"bytes,12,rep,name=subsections,proto3" json:"subsections,omitempty"`
+	Readability    *Readability           `protobuf:"bytes,13,opt,name=readability,proto3" json:"readability,omitempty"`
+	Relevance      *float64               `protobuf:"fixed64,14,opt,name=relevance,proto3,oneof" json:"relevance,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Section) Reset() {
+	*x = Section{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Section) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Section) ProtoMessage() {}
+
+func (x *Section) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Section.ProtoReflect.Descriptor instead.
+func (*Section) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Section) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Section) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *Section) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Section) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Section) GetPreview() string {
+	if x != nil {
+		return x.Preview
+	}
+	return ""
+}
+
+func (x *Section) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Section) GetLinks() []string {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *Section) GetWordCount() int32 {
+	if x != nil {
+		return x.WordCount
+	}
+	return 0
+}
+
+func (x *Section) GetTableWordCount() int32 {
+	if x != nil {
+		return x.TableWordCount
+	}
+	return 0
+}
+
+func (x *Section) GetByteOffset() int32 {
+	if x != nil && x.ByteOffset != nil {
+		return *x.ByteOffset
+	}
+	return 0
+}
+
+func (x *Section) GetByteSize() int32 {
+	if x != nil {
+		return x.ByteSize
+	}
+	return 0
+}
+
+func (x *Section) GetSubsections() []*Section {
+	if x != nil {
+		return x.Subsections
+	}
+	return nil
+}
+
+func (x *Section) GetReadability() *Readability {
+	if x != nil {
+		return x.Readability
+	}
+	return nil
+}
+
+func (x *Section) GetRelevance() float64 {
+	if x != nil && x.Relevance != nil {
+		return *x.Relevance
+	}
+	return 0
+}
+
+type Readability struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Sentences           int32                  `protobuf:"varint,1,opt,name=sentences,proto3" json:"sentences,omitempty"`
+	Words               int32                  `protobuf:"varint,2,opt,name=words,proto3" json:"words,omitempty"`
+	AvgSentenceLength   float64                `protobuf:"fixed64,3,opt,name=avg_sentence_length,json=avgSentenceLength,proto3" json:"avg_sentence_length,omitempty"`
+	AvgSyllablesPerWord float64                `protobuf:"fixed64,4,opt,name=avg_syllables_per_word,json=avgSyllablesPerWord,proto3" json:"avg_syllables_per_word,omitempty"`
+	FleschReadingEase   float64                `protobuf:"fixed64,5,opt,name=flesch_reading_ease,json=fleschReadingEase,proto3" json:"flesch_reading_ease,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Readability) Reset() {
+	*x = Readability{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Readability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Readability) ProtoMessage() {}
+
+func (x *Readability) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Readability.ProtoReflect.Descriptor instead.
+func (*Readability) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Readability) GetSentences() int32 {
+	if x != nil {
+		return x.Sentences
+	}
+	return 0
+}
+
+func (x *Readability) GetWords() int32 {
+	if x != nil {
+		return x.Words
+	}
+	return 0
+}
+
+func (x *Readability) GetAvgSentenceLength() float64 {
+	if x != nil {
+		return x.AvgSentenceLength
+	}
+	return 0
+}
+
+func (x *Readability) GetAvgSyllablesPerWord() float64 {
+	if x != nil {
+		return x.AvgSyllablesPerWord
+	}
+	return 0
+}
+
+func (x *Readability) GetFleschReadingEase() float64 {
+	if x != nil {
+		return x.FleschReadingEase
+	}
+	return 0
+}
+
+type Thumbnail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Width         int32                  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Thumbnail) Reset() {
+	*x = Thumbnail{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Thumbnail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Thumbnail) ProtoMessage() {}
+
+func (x *Thumbnail) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Thumbnail.ProtoReflect.Descriptor instead.
+func (*Thumbnail) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Thumbnail) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Thumbnail) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Thumbnail) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type PageOutline struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Title          string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Exists         bool                   `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"`
+	Redirect       *string                `protobuf:"bytes,3,opt,name=redirect,proto3,oneof" json:"redirect,omitempty"`
+	Description    string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Summary        string                 `protobuf:"bytes,5,opt,name=summary,proto3" json:"summary,omitempty"`
+	Thumbnail      *Thumbnail             `protobuf:"bytes,6,opt,name=thumbnail,proto3" json:"thumbnail,omitempty"`
+	SummaryLinks   []string               `protobuf:"bytes,7,rep,name=summary_links,json=summaryLinks,proto3" json:"summary_links,omitempty"`
+	Infobox        *structpb.Struct       `protobuf:"bytes,8,opt,name=infobox,proto3" json:"infobox,omitempty"`
+	Sections       []*Section             `protobuf:"bytes,9,rep,name=sections,proto3" json:"sections,omitempty"`
+	Toc            string                 `protobuf:"bytes,10,opt,name=toc,proto3" json:"toc,omitempty"`
+	Categories     []string               `protobuf:"bytes,11,rep,name=categories,proto3" json:"categories,omitempty"`
+	SeeAlso        []string               `protobuf:"bytes,12,rep,name=see_also,json=seeAlso,proto3" json:"see_also,omitempty"`
+	TotalWordCount int32                  `protobuf:"varint,13,opt,name=total_word_count,json=totalWordCount,proto3" json:"total_word_count,omitempty"`
+	Assessments    []*PageAssessment      `protobuf:"bytes,14,rep,name=assessments,proto3" json:"assessments,omitempty"`
+	Coordinates    *Coordinate            `protobuf:"bytes,15,opt,name=coordinates,proto3,oneof" json:"coordinates,omitempty"`
+	Source         *Provenance            `protobuf:"bytes,16,opt,name=source,proto3" json:"source,omitempty"`
+	InternalTitles []string               `protobuf:"bytes,17,rep,name=internal_titles,json=internalTitles,proto3" json:"internal_titles,omitempty"`
+	ExternalUrls   []string               `protobuf:"bytes,18,rep,name=external_urls,json=externalUrls,proto3" json:"external_urls,omitempty"`
+	LinkDetails    []*Link                `protobuf:"bytes,19,rep,name=link_details,json=linkDetails,proto3" json:"link_details,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PageOutline) Reset() {
+	*x = PageOutline{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageOutline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageOutline) ProtoMessage() {}
+
+func (x *PageOutline) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageOutline.ProtoReflect.Descriptor instead.
+func (*PageOutline) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PageOutline) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PageOutline) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+func (x *PageOutline) GetRedirect() string {
+	if x != nil && x.Redirect != nil {
+		return *x.Redirect
+	}
+	return ""
+}
+
+func (x *PageOutline) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PageOutline) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *PageOutline) GetThumbnail() *Thumbnail {
+	if x != nil {
+		return x.Thumbnail
+	}
+	return nil
+}
+
+func (x *PageOutline) GetSummaryLinks() []string {
+	if x != nil {
+		return x.SummaryLinks
+	}
+	return nil
+}
+
+func (x *PageOutline) GetInfobox() *structpb.Struct {
+	if x != nil {
+		return x.Infobox
+	}
+	return nil
+}
+
+func (x *PageOutline) GetSections() []*Section {
+	if x != nil {
+		return x.Sections
+	}
+	return nil
+}
+
+func (x *PageOutline) GetToc() string {
+	if x != nil {
+		return x.Toc
+	}
+	return ""
+}
+
+func (x *PageOutline) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *PageOutline) GetSeeAlso() []string {
+	if x != nil {
+		return x.SeeAlso
+	}
+	return nil
+}
+
+func (x *PageOutline) GetTotalWordCount() int32 {
+	if x != nil {
+		return x.TotalWordCount
+	}
+	return 0
+}
+
+func (x *PageOutline) GetAssessments() []*PageAssessment {
+	if x != nil {
+		return x.Assessments
+	}
+	return nil
+}
+
+func (x *PageOutline) GetCoordinates() *Coordinate {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+func (x *PageOutline) GetSource() *Provenance {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *PageOutline) GetInternalTitles() []string {
+	if x != nil {
+		return x.InternalTitles
+	}
+	return nil
+}
+
+func (x *PageOutline) GetExternalUrls() []string {
+	if x != nil {
+		return x.ExternalUrls
+	}
+	return nil
+}
+
+func (x *PageOutline) GetLinkDetails() []*Link {
+	if x != nil {
+		return x.LinkDetails
+	}
+	return nil
+}
+
+type Link struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Anchor        string                 `protobuf:"bytes,2,opt,name=anchor,proto3" json:"anchor,omitempty"`
+	DisplayText   string                 `protobuf:"bytes,3,opt,name=display_text,json=displayText,proto3" json:"display_text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Link) Reset() {
+	*x = Link{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Link) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Link) ProtoMessage() {}
+
+func (x *Link) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Link.ProtoReflect.Descriptor instead.
+func (*Link) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Link) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Link) GetAnchor() string {
+	if x != nil {
+		return x.Anchor
+	}
+	return ""
+}
+
+func (x *Link) GetDisplayText() string {
+	if x != nil {
+		return x.DisplayText
+	}
+	return ""
+}
+
+type Provenance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revid         int32                  `protobuf:"varint,1,opt,name=revid,proto3" json:"revid,omitempty"`
+	FetchedAt     string                 `protobuf:"bytes,2,opt,name=fetched_at,json=fetchedAt,proto3" json:"fetched_at,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Provenance) Reset() {
+	*x = Provenance{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Provenance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Provenance) ProtoMessage() {}
+
+func (x *Provenance) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Provenance.ProtoReflect.Descriptor instead.
+func (*Provenance) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Provenance) GetRevid() int32 {
+	if x != nil {
+		return x.Revid
+	}
+	return 0
+}
+
+func (x *Provenance) GetFetchedAt() string {
+	if x != nil {
+		return x.FetchedAt
+	}
+	return ""
+}
+
+func (x *Provenance) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type Coordinate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lat           float64                `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon           float64                `protobuf:"fixed64,2,opt,name=lon,proto3" json:"lon,omitempty"`
+	Globe         string                 `protobuf:"bytes,3,opt,name=globe,proto3" json:"globe,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Name          string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	Primary       bool                   `protobuf:"varint,6,opt,name=primary,proto3" json:"primary,omitempty"`
+	Source        string                 `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Coordinate) Reset() {
+	*x = Coordinate{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Coordinate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coordinate) ProtoMessage() {}
+
+func (x *Coordinate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coordinate.ProtoReflect.Descriptor instead.
+func (*Coordinate) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Coordinate) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *Coordinate) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+func (x *Coordinate) GetGlobe() string {
+	if x != nil {
+		return x.Globe
+	}
+	return ""
+}
+
+func (x *Coordinate) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Coordinate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Coordinate) GetPrimary() bool {
+	if x != nil {
+		return x.Primary
+	}
+	return false
+}
+
+func (x *Coordinate) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type PageAssessment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       string                 `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Class         string                 `protobuf:"bytes,2,opt,name=class,proto3" json:"class,omitempty"`
+	Importance    string                 `protobuf:"bytes,3,opt,name=importance,proto3" json:"importance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageAssessment) Reset() {
+	*x = PageAssessment{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageAssessment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageAssessment) ProtoMessage() {}
+
+func (x *PageAssessment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageAssessment.ProtoReflect.Descriptor instead.
+func (*PageAssessment) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PageAssessment) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *PageAssessment) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *PageAssessment) GetImportance() string {
+	if x != nil {
+		return x.Importance
+	}
+	return ""
+}
+
+type SectionRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SectionRef) Reset() {
+	*x = SectionRef{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SectionRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SectionRef) ProtoMessage() {}
+
+func (x *SectionRef) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SectionRef.ProtoReflect.Descriptor instead.
+func (*SectionRef) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SectionRef) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *SectionRef) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type AdjacentSections struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Previous      *SectionRef            `protobuf:"bytes,1,opt,name=previous,proto3" json:"previous,omitempty"`
+	Next          *SectionRef            `protobuf:"bytes,2,opt,name=next,proto3" json:"next,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjacentSections) Reset() {
+	*x = AdjacentSections{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjacentSections) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjacentSections) ProtoMessage() {}
+
+func (x *AdjacentSections) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjacentSections.ProtoReflect.Descriptor instead.
+func (*AdjacentSections) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AdjacentSections) GetPrevious() *SectionRef {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+func (x *AdjacentSections) GetNext() *SectionRef {
+	if x != nil {
+		return x.Next
+	}
+	return nil
+}
+
+type PageSection struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Title          string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Section        *Section               `protobuf:"bytes,2,opt,name=section,proto3" json:"section,omitempty"`
+	ParentSection  *SectionRef            `protobuf:"bytes,3,opt,name=parent_section,json=parentSection,proto3" json:"parent_section,omitempty"`
+	Adjacent       *AdjacentSections      `protobuf:"bytes,4,opt,name=adjacent,proto3" json:"adjacent,omitempty"`
+	Source         *Provenance            `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	ContentHash    string                 `protobuf:"bytes,6,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	InternalTitles []string               `protobuf:"bytes,7,rep,name=internal_titles,json=internalTitles,proto3" json:"internal_titles,omitempty"`
+	ExternalUrls   []string               `protobuf:"bytes,8,rep,name=external_urls,json=externalUrls,proto3" json:"external_urls,omitempty"`
+	LinkDetails    []*Link                `protobuf:"bytes,9,rep,name=link_details,json=linkDetails,proto3" json:"link_details,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PageSection) Reset() {
+	*x = PageSection{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageSection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageSection) ProtoMessage() {}
+
+func (x *PageSection) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageSection.ProtoReflect.Descriptor instead.
+func (*PageSection) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PageSection) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PageSection) GetSection() *Section {
+	if x != nil {
+		return x.Section
+	}
+	return nil
+}
+
+func (x *PageSection) GetParentSection() *SectionRef {
+	if x != nil {
+		return x.ParentSection
+	}
+	return nil
+}
+
+func (x *PageSection) GetAdjacent() *AdjacentSections {
+	if x != nil {
+		return x.Adjacent
+	}
+	return nil
+}
+
+func (x *PageSection) GetSource() *Provenance {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *PageSection) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+func (x *PageSection) GetInternalTitles() []string {
+	if x != nil {
+		return x.InternalTitles
+	}
+	return nil
+}
+
+func (x *PageSection) GetExternalUrls() []string {
+	if x != nil {
+		return x.ExternalUrls
+	}
+	return nil
+}
+
+func (x *PageSection) GetLinkDetails() []*Link {
+	if x != nil {
+		return x.LinkDetails
+	}
+	return nil
+}
+
+type PageFull struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Title          string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Content        string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Links          []string               `protobuf:"bytes,3,rep,name=links,proto3" json:"links,omitempty"`
+	WordCount      int32                  `protobuf:"varint,4,opt,name=word_count,json=wordCount,proto3" json:"word_count,omitempty"`
+	TableWordCount int32                  `protobuf:"varint,5,opt,name=table_word_count,json=tableWordCount,proto3" json:"table_word_count,omitempty"`
+	Warning        *string                `protobuf:"bytes,6,opt,name=warning,proto3,oneof" json:"warning,omitempty"`
+	Source         *Provenance            `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`
+	ContentHash    string                 `protobuf:"bytes,8,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PageFull) Reset() {
+	*x = PageFull{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageFull) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageFull) ProtoMessage() {}
+
+func (x *PageFull) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageFull.ProtoReflect.Descriptor instead.
+func (*PageFull) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PageFull) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PageFull) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *PageFull) GetLinks() []string {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *PageFull) GetWordCount() int32 {
+	if x != nil {
+		return x.WordCount
+	}
+	return 0
+}
+
+func (x *PageFull) GetTableWordCount() int32 {
+	if x != nil {
+		return x.TableWordCount
+	}
+	return 0
+}
+
+func (x *PageFull) GetWarning() string {
+	if x != nil && x.Warning != nil {
+		return *x.Warning
+	}
+	return ""
+}
+
+func (x *PageFull) GetSource() *Provenance {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *PageFull) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+type ChangeCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	SectionIndex  *int32                 `protobuf:"varint,2,opt,name=section_index,json=sectionIndex,proto3,oneof" json:"section_index,omitempty"`
+	Changed       bool                   `protobuf:"varint,3,opt,name=changed,proto3" json:"changed,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	CurrentRevid  int32                  `protobuf:"varint,5,opt,name=current_revid,json=currentRevid,proto3" json:"current_revid,omitempty"`
+	ContentHash   string                 `protobuf:"bytes,6,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeCheck) Reset() {
+	*x = ChangeCheck{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeCheck) ProtoMessage() {}
+
+func (x *ChangeCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeCheck.ProtoReflect.Descriptor instead.
+func (*ChangeCheck) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ChangeCheck) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ChangeCheck) GetSectionIndex() int32 {
+	if x != nil && x.SectionIndex != nil {
+		return *x.SectionIndex
+	}
+	return 0
+}
+
+func (x *ChangeCheck) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+func (x *ChangeCheck) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ChangeCheck) GetCurrentRevid() int32 {
+	if x != nil {
+		return x.CurrentRevid
+	}
+	return 0
+}
+
+func (x *ChangeCheck) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+type CategoryMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Timestamp     *string                `protobuf:"bytes,3,opt,name=timestamp,proto3,oneof" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryMember) Reset() {
+	*x = CategoryMember{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryMember) ProtoMessage() {}
+
+func (x *CategoryMember) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryMember.ProtoReflect.Descriptor instead.
+func (*CategoryMember) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CategoryMember) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CategoryMember) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CategoryMember) GetTimestamp() string {
+	if x != nil && x.Timestamp != nil {
+		return *x.Timestamp
+	}
+	return ""
+}
+
+type CategoryResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Category         string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Members          []*CategoryMember      `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	ParentCategories []string               `protobuf:"bytes,3,rep,name=parent_categories,json=parentCategories,proto3" json:"parent_categories,omitempty"`
+	TotalMembers     int32                  `protobuf:"varint,4,opt,name=total_members,json=totalMembers,proto3" json:"total_members,omitempty"`
+	ContinueToken    *string                `protobuf:"bytes,5,opt,name=continue_token,json=continueToken,proto3,oneof" json:"continue_token,omitempty"`
+	Counts           *CategoryCounts        `protobuf:"bytes,6,opt,name=counts,proto3,oneof" json:"counts,omitempty"`
+	Truncated        bool                   `protobuf:"varint,7,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	LimitClamped     *LimitClamp            `protobuf:"bytes,8,opt,name=limit_clamped,json=limitClamped,proto3,oneof" json:"limit_clamped,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CategoryResponse) Reset() {
+	*x = CategoryResponse{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryResponse) ProtoMessage() {}
+
+func (x *CategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryResponse.ProtoReflect.Descriptor instead.
+func (*CategoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CategoryResponse) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CategoryResponse) GetMembers() []*CategoryMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *CategoryResponse) GetParentCategories() []string {
+	if x != nil {
+		return x.ParentCategories
+	}
+	return nil
+}
+
+func (x *CategoryResponse) GetTotalMembers() int32 {
+	if x != nil {
+		return x.TotalMembers
+	}
+	return 0
+}
+
+func (x *CategoryResponse) GetContinueToken() string {
+	if x != nil && x.ContinueToken != nil {
+		return *x.ContinueToken
+	}
+	return ""
+}
+
+func (x *CategoryResponse) GetCounts() *CategoryCounts {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+func (x *CategoryResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *CategoryResponse) GetLimitClamped() *LimitClamp {
+	if x != nil {
+		return x.LimitClamped
+	}
+	return nil
+}
+
+type CategoryCounts struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pages         int32                  `protobuf:"varint,1,opt,name=pages,proto3" json:"pages,omitempty"`
+	Subcats       int32                  `protobuf:"varint,2,opt,name=subcats,proto3" json:"subcats,omitempty"`
+	Files         int32                  `protobuf:"varint,3,opt,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryCounts) Reset() {
+	*x = CategoryCounts{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryCounts) ProtoMessage() {}
+
+func (x *CategoryCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryCounts.ProtoReflect.Descriptor instead.
+func (*CategoryCounts) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CategoryCounts) GetPages() int32 {
+	if x != nil {
+		return x.Pages
+	}
+	return 0
+}
+
+func (x *CategoryCounts) GetSubcats() int32 {
+	if x != nil {
+		return x.Subcats
+	}
+	return 0
+}
+
+func (x *CategoryCounts) GetFiles() int32 {
+	if x != nil {
+		return x.Files
+	}
+	return 0
+}
+
+type Backlink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Backlink) Reset() {
+	*x = Backlink{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Backlink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Backlink) ProtoMessage() {}
+
+func (x *Backlink) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Backlink.ProtoReflect.Descriptor instead.
+func (*Backlink) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Backlink) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type BacklinksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Backlinks     []*Backlink            `protobuf:"bytes,2,rep,name=backlinks,proto3" json:"backlinks,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	ContinueToken *string                `protobuf:"bytes,4,opt,name=continue_token,json=continueToken,proto3,oneof" json:"continue_token,omitempty"`
+	LimitClamped  *LimitClamp            `protobuf:"bytes,5,opt,name=limit_clamped,json=limitClamped,proto3,oneof" json:"limit_clamped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BacklinksResponse) Reset() {
+	*x = BacklinksResponse{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BacklinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BacklinksResponse) ProtoMessage() {}
+
+func (x *BacklinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BacklinksResponse.ProtoReflect.Descriptor instead.
+func (*BacklinksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *BacklinksResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *BacklinksResponse) GetBacklinks() []*Backlink {
+	if x != nil {
+		return x.Backlinks
+	}
+	return nil
+}
+
+func (x *BacklinksResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *BacklinksResponse) GetContinueToken() string {
+	if x != nil && x.ContinueToken != nil {
+		return *x.ContinueToken
+	}
+	return ""
+}
+
+func (x *BacklinksResponse) GetLimitClamped() *LimitClamp {
+	if x != nil {
+		return x.LimitClamped
+	}
+	return nil
+}
+
+type RevisionInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Timestamp     string                 `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	User          string                 `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevisionInfo) Reset() {
+	*x = RevisionInfo{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevisionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevisionInfo) ProtoMessage() {}
+
+func (x *RevisionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevisionInfo.ProtoReflect.Descriptor instead.
+func (*RevisionInfo) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RevisionInfo) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RevisionInfo) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *RevisionInfo) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+type CompareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	From          *RevisionInfo          `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            *RevisionInfo          `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	DiffSummary   string                 `protobuf:"bytes,4,opt,name=diff_summary,json=diffSummary,proto3" json:"diff_summary,omitempty"`
+	DiffMarkdown  string                 `protobuf:"bytes,5,opt,name=diff_markdown,json=diffMarkdown,proto3" json:"diff_markdown,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareResponse) Reset() {
+	*x = CompareResponse{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareResponse) ProtoMessage() {}
+
+func (x *CompareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareResponse.ProtoReflect.Descriptor instead.
+func (*CompareResponse) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CompareResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CompareResponse) GetFrom() *RevisionInfo {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *CompareResponse) GetTo() *RevisionInfo {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *CompareResponse) GetDiffSummary() string {
+	if x != nil {
+		return x.DiffSummary
+	}
+	return ""
+}
+
+func (x *CompareResponse) GetDiffMarkdown() string {
+	if x != nil {
+		return x.DiffMarkdown
+	}
+	return ""
+}
+
+type SubpageNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Exists        bool                   `protobuf:"varint,3,opt,name=exists,proto3" json:"exists,omitempty"`
+	Children      []*SubpageNode         `protobuf:"bytes,4,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubpageNode) Reset() {
+	*x = SubpageNode{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubpageNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubpageNode) ProtoMessage() {}
+
+func (x *SubpageNode) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubpageNode.ProtoReflect.Descriptor instead.
+func (*SubpageNode) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SubpageNode) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SubpageNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SubpageNode) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+func (x *SubpageNode) GetChildren() []*SubpageNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type SubpagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Subpages      []*SubpageNode         `protobuf:"bytes,2,rep,name=subpages,proto3" json:"subpages,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Truncated     bool                   `protobuf:"varint,4,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubpagesResponse) Reset() {
+	*x = SubpagesResponse{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubpagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubpagesResponse) ProtoMessage() {}
+
+func (x *SubpagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubpagesResponse.ProtoReflect.Descriptor instead.
+func (*SubpagesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SubpagesResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SubpagesResponse) GetSubpages() []*SubpageNode {
+	if x != nil {
+		return x.Subpages
+	}
+	return nil
+}
+
+func (x *SubpagesResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *SubpagesResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+type DiscussionComment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Author        string                 `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	Timestamp     string                 `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	Replies       []*DiscussionComment   `protobuf:"bytes,5,rep,name=replies,proto3" json:"replies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscussionComment) Reset() {
+	*x = DiscussionComment{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscussionComment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscussionComment) ProtoMessage() {}
+
+func (x *DiscussionComment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscussionComment.ProtoReflect.Descriptor instead.
+func (*DiscussionComment) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DiscussionComment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DiscussionComment) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *DiscussionComment) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *DiscussionComment) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *DiscussionComment) GetReplies() []*DiscussionComment {
+	if x != nil {
+		return x.Replies
+	}
+	return nil
+}
+
+type DiscussionThread struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Comments      []*DiscussionComment   `protobuf:"bytes,3,rep,name=comments,proto3" json:"comments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscussionThread) Reset() {
+	*x = DiscussionThread{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscussionThread) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscussionThread) ProtoMessage() {}
+
+func (x *DiscussionThread) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscussionThread.ProtoReflect.Descriptor instead.
+func (*DiscussionThread) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DiscussionThread) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DiscussionThread) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *DiscussionThread) GetComments() []*DiscussionComment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+type DiscussionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Format        string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	Threads       []*DiscussionThread    `protobuf:"bytes,3,rep,name=threads,proto3" json:"threads,omitempty"`
+	TotalThreads  int32                  `protobuf:"varint,4,opt,name=total_threads,json=totalThreads,proto3" json:"total_threads,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscussionsResponse) Reset() {
+	*x = DiscussionsResponse{}
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscussionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscussionsResponse) ProtoMessage() {}
+
+func (x *DiscussionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mediawiki_v1_mediawiki_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscussionsResponse.ProtoReflect.Descriptor instead.
+func (*DiscussionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DiscussionsResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *DiscussionsResponse) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *DiscussionsResponse) GetThreads() []*DiscussionThread {
+	if x != nil {
+		return x.Threads
+	}
+	return nil
+}
+
+func (x *DiscussionsResponse) GetTotalThreads() int32 {
+	if x != nil {
+		return x.TotalThreads
+	}
+	return 0
+}
+
+var File_proto_mediawiki_v1_mediawiki_proto protoreflect.FileDescriptor
+
+const file_proto_mediawiki_v1_mediawiki_proto_rawDesc = "" +
+	"\n" +
+	"\"proto/mediawiki/v1/mediawiki.proto\x12\fmediawiki.v1\x1a\x1cgoogle/protobuf/struct.proto\"H\n" +
+	"\x0fWikiInfoRequest\x12\x19\n" +
+	"\bwiki_url\x18\x01 \x01(\tR\awikiUrl\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\"\x93\x01\n" +
+	"\rSearchRequest\x12\x19\n" +
+	"\bwiki_url\x18\x01 \x01(\tR\awikiUrl\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x14\n" +
+	"\x05query\x18\x03 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vin_category\x18\x05 \x01(\tR\n" +
+	"inCategory\"Z\n" +
+	"\vPageRequest\x12\x19\n" +
+	"\bwiki_url\x18\x01 \x01(\tR\awikiUrl\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\"\x8e\x02\n" +
+	"\x12PageOutlineRequest\x12\x19\n" +
+	"\bwiki_url\x18\x01 \x01(\tR\awikiUrl\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x1f\n" +
+	"\vinclude_toc\x18\x04 \x01(\bR\n" +
+	"includeToc\x12/\n" +
+	"\x13include_readability\x18\x05 \x01(\bR\x12includeReadability\x120\n" +
+	"\x14include_link_details\x18\x06 \x01(\bR\x12includeLinkDetails\x12'\n" +
+	"\x0frelevance_query\x18\a \x01(\tR\x0erelevanceQuery\"\xb8\x01\n" +
+	"\x12PageSectionRequest\x12\x19\n" +
+	"\bwiki_url\x18\x01 \x01(\tR\awikiUrl\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12#\n" +
+	"\rsection_index\x18\x04 \x01(\x05R\fsectionIndex\x120\n" +
+	"\x14include_link_details\x18\x05 \x01(\bR\x12includeLinkDetails\"t\n" +
+	"\x0fPageListRequest\x12\x19\n" +
+	"\bwiki_url\x18\x01 \x01(\tR\awikiUrl\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xdf\x01\n" +
+	"\x0fCategoryRequest\x12\x19\n" +
+	"\bwiki_url\x18\x01 \x01(\tR\awikiUrl\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vmember_type\x18\x05 \x01(\tR\n" +
+	"memberType\x12\x12\n" +
+	"\x04sort\x18\x06 \x01(\tR\x04sort\x12\x1c\n" +
+	"\tdirection\x18\a \x01(\tR\tdirection\x12\x10\n" +
+	"\x03all\x18\b \x01(\bR\x03all\"\xd5\x01\n" +
+	"\x11HasChangedRequest\x12\x19\n" +
+	"\bwiki_url\x18\x01 \x01(\tR\awikiUrl\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x14\n" +
+	"\x05revid\x18\x04 \x01(\x05R\x05revid\x12!\n" +
+	"\fcontent_hash\x18\x05 \x01(\tR\vcontentHash\x12(\n" +
+	"\rsection_index\x18\x06 \x01(\x05H\x00R\fsectionIndex\x88\x01\x01B\x10\n" +
+	"\x0e_section_index\"\xa3\x01\n" +
+	"\x0eCompareRequest\x12\x19\n" +
+	"\bwiki_url\x18\x01 \x01(\tR\awikiUrl\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12#\n" +
+	"\rfrom_revision\x18\x04 \x01(\tR\ffromRevision\x12\x1f\n" +
+	"\vto_revision\x18\x05 \x01(\tR\n" +
+	"toRevision\"\x9e\x02\n" +
+	"\bWikiInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\bbase_url\x18\x02 \x01(\tR\abaseUrl\x12\x1b\n" +
+	"\tmain_page\x18\x03 \x01(\tR\bmainPage\x12\x1a\n" +
+	"\blanguage\x18\x04 \x01(\tR\blanguage\x12#\n" +
+	"\rarticle_count\x18\x05 \x01(\x05R\farticleCount\x12F\n" +
+	"\n" +
+	"namespaces\x18\x06 \x03(\v2&.mediawiki.v1.WikiInfo.NamespacesEntryR\n" +
+	"namespaces\x1a=\n" +
+	"\x0fNamespacesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd7\x01\n" +
+	"\fSearchResult\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
+	"\asnippet\x18\x02 \x01(\tR\asnippet\x12#\n" +
+	"\rsnippet_links\x18\x03 \x03(\tR\fsnippetLinks\x12\x1d\n" +
+	"\n" +
+	"word_count\x18\x04 \x01(\x05R\twordCount\x12!\n" +
+	"\fvia_redirect\x18\x05 \x01(\tR\vviaRedirect\x120\n" +
+	"\x06source\x18\x06 \x01(\v2\x18.mediawiki.v1.ProvenanceR\x06source\"\xef\x01\n" +
+	"\x0eSearchResponse\x124\n" +
+	"\aresults\x18\x01 \x03(\v2\x1a.mediawiki.v1.SearchResultR\aresults\x12\x1d\n" +
+	"\n" +
+	"total_hits\x18\x02 \x01(\x05R\ttotalHits\x12#\n" +
+	"\n" +
+	"suggestion\x18\x03 \x01(\tH\x00R\n" +
+	"suggestion\x88\x01\x01\x12B\n" +
+	"\rlimit_clamped\x18\x04 \x01(\v2\x18.mediawiki.v1.LimitClampH\x01R\flimitClamped\x88\x01\x01B\r\n" +
+	"\v_suggestionB\x10\n" +
+	"\x0e_limit_clamped\"<\n" +
+	"\n" +
+	"LimitClamp\x12\x1c\n" +
+	"\trequested\x18\x01 \x01(\x05R\trequested\x12\x10\n" +
+	"\x03max\x18\x02 \x01(\x05R\x03max\"\xf0\x03\n" +
+	"\aSection\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\tR\x06number\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x14\n" +
+	"\x05level\x18\x04 \x01(\x05R\x05level\x12\x18\n" +
+	"\apreview\x18\x05 \x01(\tR\apreview\x12\x18\n" +
+	"\acontent\x18\x06 \x01(\tR\acontent\x12\x14\n" +
+	"\x05links\x18\a \x03(\tR\x05links\x12\x1d\n" +
+	"\n" +
+	"word_count\x18\b \x01(\x05R\twordCount\x12(\n" +
+	"\x10table_word_count\x18\t \x01(\x05R\x0etableWordCount\x12$\n" +
+	"\vbyte_offset\x18\n" +
+	" \x01(\x05H\x00R\n" +
+	"byteOffset\x88\x01\x01\x12\x1b\n" +
+	"\tbyte_size\x18\v \x01(\x05R\bbyteSize\x127\n" +
+	"\vsubsections\x18\f \x03(\v2\x15.mediawiki.v1.SectionR\vsubsections\x12;\n" +
+	"\vreadability\x18\r \x01(\v2\x19.mediawiki.v1.ReadabilityR\vreadability\x12!\n" +
+	"\trelevance\x18\x0e \x01(\x01H\x01R\trelevance\x88\x01\x01B\x0e\n" +
+	"\f_byte_offsetB\f\n" +
+	"\n" +
+	"_relevance\"\xd6\x01\n" +
+	"\vReadability\x12\x1c\n" +
+	"\tsentences\x18\x01 \x01(\x05R\tsentences\x12\x14\n" +
+	"\x05words\x18\x02 \x01(\x05R\x05words\x12.\n" +
+	"\x13avg_sentence_length\x18\x03 \x01(\x01R\x11avgSentenceLength\x123\n" +
+	"\x16avg_syllables_per_word\x18\x04 \x01(\x01R\x13avgSyllablesPerWord\x12.\n" +
+	"\x13flesch_reading_ease\x18\x05 \x01(\x01R\x11fleschReadingEase\"K\n" +
+	"\tThumbnail\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x05R\x06height\"\xa6\x06\n" +
+	"\vPageOutline\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x16\n" +
+	"\x06exists\x18\x02 \x01(\bR\x06exists\x12\x1f\n" +
+	"\bredirect\x18\x03 \x01(\tH\x00R\bredirect\x88\x01\x01\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x18\n" +
+	"\asummary\x18\x05 \x01(\tR\asummary\x125\n" +
+	"\tthumbnail\x18\x06 \x01(\v2\x17.mediawiki.v1.ThumbnailR\tthumbnail\x12#\n" +
+	"\rsummary_links\x18\a \x03(\tR\fsummaryLinks\x121\n" +
+	"\ainfobox\x18\b \x01(\v2\x17.google.protobuf.StructR\ainfobox\x121\n" +
+	"\bsections\x18\t \x03(\v2\x15.mediawiki.v1.SectionR\bsections\x12\x10\n" +
+	"\x03toc\x18\n" +
+	" \x01(\tR\x03toc\x12\x1e\n" +
+	"\n" +
+	"categories\x18\v \x03(\tR\n" +
+	"categories\x12\x19\n" +
+	"\bsee_also\x18\f \x03(\tR\aseeAlso\x12(\n" +
+	"\x10total_word_count\x18\r \x01(\x05R\x0etotalWordCount\x12>\n" +
+	"\vassessments\x18\x0e \x03(\v2\x1c.mediawiki.v1.PageAssessmentR\vassessments\x12?\n" +
+	"\vcoordinates\x18\x0f \x01(\v2\x18.mediawiki.v1.CoordinateH\x01R\vcoordinates\x88\x01\x01\x120\n" +
+	"\x06source\x18\x10 \x01(\v2\x18.mediawiki.v1.ProvenanceR\x06source\x12'\n" +
+	"\x0finternal_titles\x18\x11 \x03(\tR\x0einternalTitles\x12#\n" +
+	"\rexternal_urls\x18\x12 \x03(\tR\fexternalUrls\x125\n" +
+	"\flink_details\x18\x13 \x03(\v2\x12.mediawiki.v1.LinkR\vlinkDetailsB\v\n" +
+	"\t_redirectB\x0e\n" +
+	"\f_coordinates\"W\n" +
+	"\x04Link\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x16\n" +
+	"\x06anchor\x18\x02 \x01(\tR\x06anchor\x12!\n" +
+	"\fdisplay_text\x18\x03 \x01(\tR\vdisplayText\"S\n" +
+	"\n" +
+	"Provenance\x12\x14\n" +
+	"\x05revid\x18\x01 \x01(\x05R\x05revid\x12\x1d\n" +
+	"\n" +
+	"fetched_at\x18\x02 \x01(\tR\tfetchedAt\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\"\xa0\x01\n" +
+	"\n" +
+	"Coordinate\x12\x10\n" +
+	"\x03lat\x18\x01 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x02 \x01(\x01R\x03lon\x12\x14\n" +
+	"\x05globe\x18\x03 \x01(\tR\x05globe\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\x12\x18\n" +
+	"\aprimary\x18\x06 \x01(\bR\aprimary\x12\x16\n" +
+	"\x06source\x18\a \x01(\tR\x06source\"`\n" +
+	"\x0ePageAssessment\x12\x18\n" +
+	"\aproject\x18\x01 \x01(\tR\aproject\x12\x14\n" +
+	"\x05class\x18\x02 \x01(\tR\x05class\x12\x1e\n" +
+	"\n" +
+	"importance\x18\x03 \x01(\tR\n" +
+	"importance\"8\n" +
+	"\n" +
+	"SectionRef\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\"v\n" +
+	"\x10AdjacentSections\x124\n" +
+	"\bprevious\x18\x01 \x01(\v2\x18.mediawiki.v1.SectionRefR\bprevious\x12,\n" +
+	"\x04next\x18\x02 \x01(\v2\x18.mediawiki.v1.SectionRefR\x04next\"\xab\x03\n" +
+	"\vPageSection\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12/\n" +
+	"\asection\x18\x02 \x01(\v2\x15.mediawiki.v1.SectionR\asection\x12?\n" +
+	"\x0eparent_section\x18\x03 \x01(\v2\x18.mediawiki.v1.SectionRefR\rparentSection\x12:\n" +
+	"\badjacent\x18\x04 \x01(\v2\x1e.mediawiki.v1.AdjacentSectionsR\badjacent\x120\n" +
+	"\x06source\x18\x05 \x01(\v2\x18.mediawiki.v1.ProvenanceR\x06source\x12!\n" +
+	"\fcontent_hash\x18\x06 \x01(\tR\vcontentHash\x12'\n" +
+	"\x0finternal_titles\x18\a \x03(\tR\x0einternalTitles\x12#\n" +
+	"\rexternal_urls\x18\b \x03(\tR\fexternalUrls\x125\n" +
+	"\flink_details\x18\t \x03(\v2\x12.mediawiki.v1.LinkR\vlinkDetails\"\x99\x02\n" +
+	"\bPageFull\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x14\n" +
+	"\x05links\x18\x03 \x03(\tR\x05links\x12\x1d\n" +
+	"\n" +
+	"word_count\x18\x04 \x01(\x05R\twordCount\x12(\n" +
+	"\x10table_word_count\x18\x05 \x01(\x05R\x0etableWordCount\x12\x1d\n" +
+	"\awarning\x18\x06 \x01(\tH\x00R\awarning\x88\x01\x01\x120\n" +
+	"\x06source\x18\a \x01(\v2\x18.mediawiki.v1.ProvenanceR\x06source\x12!\n" +
+	"\fcontent_hash\x18\b \x01(\tR\vcontentHashB\n" +
+	"\n" +
+	"\b_warning\"\xd9\x01\n" +
+	"\vChangeCheck\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12(\n" +
+	"\rsection_index\x18\x02 \x01(\x05H\x00R\fsectionIndex\x88\x01\x01\x12\x18\n" +
+	"\achanged\x18\x03 \x01(\bR\achanged\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12#\n" +
+	"\rcurrent_revid\x18\x05 \x01(\x05R\fcurrentRevid\x12!\n" +
+	"\fcontent_hash\x18\x06 \x01(\tR\vcontentHashB\x10\n" +
+	"\x0e_section_index\"k\n" +
+	"\x0eCategoryMember\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12!\n" +
+	"\ttimestamp\x18\x03 \x01(\tH\x00R\ttimestamp\x88\x01\x01B\f\n" +
+	"\n" +
+	"_timestamp\"\xb1\x03\n" +
+	"\x10CategoryResponse\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x126\n" +
+	"\amembers\x18\x02 \x03(\v2\x1c.mediawiki.v1.CategoryMemberR\amembers\x12+\n" +
+	"\x11parent_categories\x18\x03 \x03(\tR\x10parentCategories\x12#\n" +
+	"\rtotal_members\x18\x04 \x01(\x05R\ftotalMembers\x12*\n" +
+	"\x0econtinue_token\x18\x05 \x01(\tH\x00R\rcontinueToken\x88\x01\x01\x129\n" +
+	"\x06counts\x18\x06 \x01(\v2\x1c.mediawiki.v1.CategoryCountsH\x01R\x06counts\x88\x01\x01\x12\x1c\n" +
+	"\ttruncated\x18\a \x01(\bR\ttruncated\x12B\n" +
+	"\rlimit_clamped\x18\b \x01(\v2\x18.mediawiki.v1.LimitClampH\x02R\flimitClamped\x88\x01\x01B\x11\n" +
+	"\x0f_continue_tokenB\t\n" +
+	"\a_countsB\x10\n" +
+	"\x0e_limit_clamped\"V\n" +
+	"\x0eCategoryCounts\x12\x14\n" +
+	"\x05pages\x18\x01 \x01(\x05R\x05pages\x12\x18\n" +
+	"\asubcats\x18\x02 \x01(\x05R\asubcats\x12\x14\n" +
+	"\x05files\x18\x03 \x01(\x05R\x05files\" \n" +
+	"\bBacklink\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\"\x95\x02\n" +
+	"\x11BacklinksResponse\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x124\n" +
+	"\tbacklinks\x18\x02 \x03(\v2\x16.mediawiki.v1.BacklinkR\tbacklinks\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\x12*\n" +
+	"\x0econtinue_token\x18\x04 \x01(\tH\x00R\rcontinueToken\x88\x01\x01\x12B\n" +
+	"\rlimit_clamped\x18\x05 \x01(\v2\x18.mediawiki.v1.LimitClampH\x01R\flimitClamped\x88\x01\x01B\x11\n" +
+	"\x0f_continue_tokenB\x10\n" +
+	"\x0e_limit_clamped\"P\n" +
+	"\fRevisionInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\tR\ttimestamp\x12\x12\n" +
+	"\x04user\x18\x03 \x01(\tR\x04user\"\xcb\x01\n" +
+	"\x0fCompareResponse\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.mediawiki.v1.RevisionInfoR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.mediawiki.v1.RevisionInfoR\x02to\x12!\n" +
+	"\fdiff_summary\x18\x04 \x01(\tR\vdiffSummary\x12#\n" +
+	"\rdiff_markdown\x18\x05 \x01(\tR\fdiffMarkdown\"\x86\x01\n" +
+	"\vSubpageNode\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06exists\x18\x03 \x01(\bR\x06exists\x125\n" +
+	"\bchildren\x18\x04 \x03(\v2\x19.mediawiki.v1.SubpageNodeR\bchildren\"\x9e\x01\n" +
+	"\x10SubpagesResponse\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x125\n" +
+	"\bsubpages\x18\x02 \x03(\v2\x19.mediawiki.v1.SubpageNodeR\bsubpages\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\x12\x1c\n" +
+	"\ttruncated\x18\x04 \x01(\bR\ttruncated\"\xae\x01\n" +
+	"\x11DiscussionComment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06author\x18\x02 \x01(\tR\x06author\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\tR\ttimestamp\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\x129\n" +
+	"\areplies\x18\x05 \x03(\v2\x1f.mediawiki.v1.DiscussionCommentR\areplies\"u\n" +
+	"\x10DiscussionThread\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12;\n" +
+	"\bcomments\x18\x03 \x03(\v2\x1f.mediawiki.v1.DiscussionCommentR\bcomments\"\xa2\x01\n" +
+	"\x13DiscussionsResponse\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\x128\n" +
+	"\athreads\x18\x03 \x03(\v2\x1e.mediawiki.v1.DiscussionThreadR\athreads\x12#\n" +
+	"\rtotal_threads\x18\x04 \x01(\x05R\ftotalThreads2\xd1\x06\n" +
+	"\tMediaWiki\x12D\n" +
+	"\vGetWikiInfo\x12\x1d.mediawiki.v1.WikiInfoRequest\x1a\x16.mediawiki.v1.WikiInfo\x12C\n" +
+	"\x06Search\x12\x1b.mediawiki.v1.SearchRequest\x1a\x1c.mediawiki.v1.SearchResponse\x12M\n" +
+	"\x0eGetPageOutline\x12 .mediawiki.v1.PageOutlineRequest\x1a\x19.mediawiki.v1.PageOutline\x12M\n" +
+	"\x0eGetPageSection\x12 .mediawiki.v1.PageSectionRequest\x1a\x19.mediawiki.v1.PageSection\x12@\n" +
+	"\vGetPageFull\x12\x19.mediawiki.v1.PageRequest\x1a\x16.mediawiki.v1.PageFull\x12L\n" +
+	"\vGetCategory\x12\x1d.mediawiki.v1.CategoryRequest\x1a\x1e.mediawiki.v1.CategoryResponse\x12N\n" +
+	"\fGetBacklinks\x12\x1d.mediawiki.v1.PageListRequest\x1a\x1f.mediawiki.v1.BacklinksResponse\x12O\n" +
+	"\x10CompareRevisions\x12\x1c.mediawiki.v1.CompareRequest\x1a\x1d.mediawiki.v1.CompareResponse\x12L\n" +
+	"\vGetSubpages\x12\x1d.mediawiki.v1.PageListRequest\x1a\x1e.mediawiki.v1.SubpagesResponse\x12R\n" +
+	"\x0eGetDiscussions\x12\x1d.mediawiki.v1.PageListRequest\x1a!.mediawiki.v1.DiscussionsResponse\x12H\n" +
+	"\n" +
+	"HasChanged\x12\x1f.mediawiki.v1.HasChangedRequest\x1a\x19.mediawiki.v1.ChangeCheckB@Z>github.com/yourusername/mediawiki-mcp/internal/rpc/mediawikipbb\x06proto3"
+
+var (
+	file_proto_mediawiki_v1_mediawiki_proto_rawDescOnce sync.Once
+	file_proto_mediawiki_v1_mediawiki_proto_rawDescData []byte
+)
+
+func file_proto_mediawiki_v1_mediawiki_proto_rawDescGZIP() []byte {
+	file_proto_mediawiki_v1_mediawiki_proto_rawDescOnce.Do(func() {
+		file_proto_mediawiki_v1_mediawiki_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_mediawiki_v1_mediawiki_proto_rawDesc), len(file_proto_mediawiki_v1_mediawiki_proto_rawDesc)))
+	})
+	return file_proto_mediawiki_v1_mediawiki_proto_rawDescData
+}
+
+var file_proto_mediawiki_v1_mediawiki_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
+var file_proto_mediawiki_v1_mediawiki_proto_goTypes = []any{
+	(*WikiInfoRequest)(nil),     // 0: mediawiki.v1.WikiInfoRequest
+	(*SearchRequest)(nil),       // 1: mediawiki.v1.SearchRequest
+	(*PageRequest)(nil),         // 2: mediawiki.v1.PageRequest
+	(*PageOutlineRequest)(nil),  // 3: mediawiki.v1.PageOutlineRequest
+	(*PageSectionRequest)(nil),  // 4: mediawiki.v1.PageSectionRequest
+	(*PageListRequest)(nil),     // 5: mediawiki.v1.PageListRequest
+	(*CategoryRequest)(nil),     // 6: mediawiki.v1.CategoryRequest
+	(*HasChangedRequest)(nil),   // 7: mediawiki.v1.HasChangedRequest
+	(*CompareRequest)(nil),      // 8: mediawiki.v1.CompareRequest
+	(*WikiInfo)(nil),            // 9: mediawiki.v1.WikiInfo
+	(*SearchResult)(nil),        // 10: mediawiki.v1.SearchResult
+	(*SearchResponse)(nil),      // 11: mediawiki.v1.SearchResponse
+	(*LimitClamp)(nil),          // 12: mediawiki.v1.LimitClamp
+	(*Section)(nil),             // 13: mediawiki.v1.Section
+	(*Readability)(nil),         // 14: mediawiki.v1.Readability
+	(*Thumbnail)(nil),           // 15: mediawiki.v1.Thumbnail
+	(*PageOutline)(nil),         // 16: mediawiki.v1.PageOutline
+	(*Link)(nil),                // 17: mediawiki.v1.Link
+	(*Provenance)(nil),          // 18: mediawiki.v1.Provenance
+	(*Coordinate)(nil),          // 19: mediawiki.v1.Coordinate
+	(*PageAssessment)(nil),      // 20: mediawiki.v1.PageAssessment
+	(*SectionRef)(nil),          // 21: mediawiki.v1.SectionRef
+	(*AdjacentSections)(nil),    // 22: mediawiki.v1.AdjacentSections
+	(*PageSection)(nil),         // 23: mediawiki.v1.PageSection
+	(*PageFull)(nil),            // 24: mediawiki.v1.PageFull
+	(*ChangeCheck)(nil),         // 25: mediawiki.v1.ChangeCheck
+	(*CategoryMember)(nil),      // 26: mediawiki.v1.CategoryMember
+	(*CategoryResponse)(nil),    // 27: mediawiki.v1.CategoryResponse
+	(*CategoryCounts)(nil),      // 28: mediawiki.v1.CategoryCounts
+	(*Backlink)(nil),            // 29: mediawiki.v1.Backlink
+	(*BacklinksResponse)(nil),   // 30: mediawiki.v1.BacklinksResponse
+	(*RevisionInfo)(nil),        // 31: mediawiki.v1.RevisionInfo
+	(*CompareResponse)(nil),     // 32: mediawiki.v1.CompareResponse
+	(*SubpageNode)(nil),         // 33: mediawiki.v1.SubpageNode
+	(*SubpagesResponse)(nil),    // 34: mediawiki.v1.SubpagesResponse
+	(*DiscussionComment)(nil),   // 35: mediawiki.v1.DiscussionComment
+	(*DiscussionThread)(nil),    // 36: mediawiki.v1.DiscussionThread
+	(*DiscussionsResponse)(nil), // 37: mediawiki.v1.DiscussionsResponse
+	nil,                         // 38: mediawiki.v1.WikiInfo.NamespacesEntry
+	(*structpb.Struct)(nil),     // 39: google.protobuf.Struct
+}
+var file_proto_mediawiki_v1_mediawiki_proto_depIdxs = []int32{
+	38, // 0: mediawiki.v1.WikiInfo.namespaces:type_name -> mediawiki.v1.WikiInfo.NamespacesEntry
+	18, // 1: mediawiki.v1.SearchResult.source:type_name -> mediawiki.v1.Provenance
+	10, // 2: mediawiki.v1.SearchResponse.results:type_name -> mediawiki.v1.SearchResult
+	12, // 3: mediawiki.v1.SearchResponse.limit_clamped:type_name -> mediawiki.v1.LimitClamp
+	13, // 4: mediawiki.v1.Section.subsections:type_name -> mediawiki.v1.Section
+	14, // 5: mediawiki.v1.Section.readability:type_name -> mediawiki.v1.Readability
+	15, // 6: mediawiki.v1.PageOutline.thumbnail:type_name -> mediawiki.v1.Thumbnail
+	39, // 7: mediawiki.v1.PageOutline.infobox:type_name -> google.protobuf.Struct
+	13, // 8: mediawiki.v1.PageOutline.sections:type_name -> mediawiki.v1.Section
+	20, // 9: mediawiki.v1.PageOutline.assessments:type_name -> mediawiki.v1.PageAssessment
+	19, // 10: mediawiki.v1.PageOutline.coordinates:type_name -> mediawiki.v1.Coordinate
+	18, // 11: mediawiki.v1.PageOutline.source:type_name -> mediawiki.v1.Provenance
+	17, // 12: mediawiki.v1.PageOutline.link_details:type_name -> mediawiki.v1.Link
+	21, // 13: mediawiki.v1.AdjacentSections.previous:type_name -> mediawiki.v1.SectionRef
+	21, // 14: mediawiki.v1.AdjacentSections.next:type_name -> mediawiki.v1.SectionRef
+	13, // 15: mediawiki.v1.PageSection.section:type_name -> mediawiki.v1.Section
+	21, // 16: mediawiki.v1.PageSection.parent_section:type_name -> mediawiki.v1.SectionRef
+	22, // 17: mediawiki.v1.PageSection.adjacent:type_name -> mediawiki.v1.AdjacentSections
+	18, // 18: mediawiki.v1.PageSection.source:type_name -> mediawiki.v1.Provenance
+	17, // 19: mediawiki.v1.PageSection.link_details:type_name -> mediawiki.v1.Link
+	18, // 20: mediawiki.v1.PageFull.source:type_name -> mediawiki.v1.Provenance
+	26, // 21: mediawiki.v1.CategoryResponse.members:type_name -> mediawiki.v1.CategoryMember
+	28, // 22: mediawiki.v1.CategoryResponse.counts:type_name -> mediawiki.v1.CategoryCounts
+	12, // 23: mediawiki.v1.CategoryResponse.limit_clamped:type_name -> mediawiki.v1.LimitClamp
+	29, // 24: mediawiki.v1.BacklinksResponse.backlinks:type_name -> mediawiki.v1.Backlink
+	12, // 25: mediawiki.v1.BacklinksResponse.limit_clamped:type_name -> mediawiki.v1.LimitClamp
+	31, // 26: mediawiki.v1.CompareResponse.from:type_name -> mediawiki.v1.RevisionInfo
+	31, // 27: mediawiki.v1.CompareResponse.to:type_name -> mediawiki.v1.RevisionInfo
+	33, // 28: mediawiki.v1.SubpageNode.children:type_name -> mediawiki.v1.SubpageNode
+	33, // 29: mediawiki.v1.SubpagesResponse.subpages:type_name -> mediawiki.v1.SubpageNode
+	35, // 30: mediawiki.v1.DiscussionComment.replies:type_name -> mediawiki.v1.DiscussionComment
+	35, // 31: mediawiki.v1.DiscussionThread.comments:type_name -> mediawiki.v1.DiscussionComment
+	36, // 32: mediawiki.v1.DiscussionsResponse.threads:type_name -> mediawiki.v1.DiscussionThread
+	0,  // 33: mediawiki.v1.MediaWiki.GetWikiInfo:input_type -> mediawiki.v1.WikiInfoRequest
+	1,  // 34: mediawiki.v1.MediaWiki.Search:input_type -> mediawiki.v1.SearchRequest
+	3,  // 35: mediawiki.v1.MediaWiki.GetPageOutline:input_type -> mediawiki.v1.PageOutlineRequest
+	4,  // 36: mediawiki.v1.MediaWiki.GetPageSection:input_type -> mediawiki.v1.PageSectionRequest
+	2,  // 37: mediawiki.v1.MediaWiki.GetPageFull:input_type -> mediawiki.v1.PageRequest
+	6,  // 38: mediawiki.v1.MediaWiki.GetCategory:input_type -> mediawiki.v1.CategoryRequest
+	5,  // 39: mediawiki.v1.MediaWiki.GetBacklinks:input_type -> mediawiki.v1.PageListRequest
+	8,  // 40: mediawiki.v1.MediaWiki.CompareRevisions:input_type -> mediawiki.v1.CompareRequest
+	5,  // 41: mediawiki.v1.MediaWiki.GetSubpages:input_type -> mediawiki.v1.PageListRequest
+	5,  // 42: mediawiki.v1.MediaWiki.GetDiscussions:input_type -> mediawiki.v1.PageListRequest
+	7,  // 43: mediawiki.v1.MediaWiki.HasChanged:input_type -> mediawiki.v1.HasChangedRequest
+	9,  // 44: mediawiki.v1.MediaWiki.GetWikiInfo:output_type -> mediawiki.v1.WikiInfo
+	11, // 45: mediawiki.v1.MediaWiki.Search:output_type -> mediawiki.v1.SearchResponse
+	16, // 46: mediawiki.v1.MediaWiki.GetPageOutline:output_type -> mediawiki.v1.PageOutline
+	23, // 47: mediawiki.v1.MediaWiki.GetPageSection:output_type -> mediawiki.v1.PageSection
+	24, // 48: mediawiki.v1.MediaWiki.GetPageFull:output_type -> mediawiki.v1.PageFull
+	27, // 49: mediawiki.v1.MediaWiki.GetCategory:output_type -> mediawiki.v1.CategoryResponse
+	30, // 50: mediawiki.v1.MediaWiki.GetBacklinks:output_type -> mediawiki.v1.BacklinksResponse
+	32, // 51: mediawiki.v1.MediaWiki.CompareRevisions:output_type -> mediawiki.v1.CompareResponse
+	34, // 52: mediawiki.v1.MediaWiki.GetSubpages:output_type -> mediawiki.v1.SubpagesResponse
+	37, // 53: mediawiki.v1.MediaWiki.GetDiscussions:output_type -> mediawiki.v1.DiscussionsResponse
+	25, // 54: mediawiki.v1.MediaWiki.HasChanged:output_type -> mediawiki.v1.ChangeCheck
+	44, // [44:55] is the sub-list for method output_type
+	33, // [33:44] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
+}
+
+func init() { file_proto_mediawiki_v1_mediawiki_proto_init() }
+func file_proto_mediawiki_v1_mediawiki_proto_init() {
+	if File_proto_mediawiki_v1_mediawiki_proto != nil {
+		return
+	}
+	file_proto_mediawiki_v1_mediawiki_proto_msgTypes[7].OneofWrappers = []any{}
+	file_proto_mediawiki_v1_mediawiki_proto_msgTypes[11].OneofWrappers = []any{}
+	file_proto_mediawiki_v1_mediawiki_proto_msgTypes[13].OneofWrappers = []any{}
+	file_proto_mediawiki_v1_mediawiki_proto_msgTypes[16].OneofWrappers = []any{}
+	file_proto_mediawiki_v1_mediawiki_proto_msgTypes[24].OneofWrappers = []any{}
+	file_proto_mediawiki_v1_mediawiki_proto_msgTypes[25].OneofWrappers = []any{}
+	file_proto_mediawiki_v1_mediawiki_proto_msgTypes[26].OneofWrappers = []any{}
+	file_proto_mediawiki_v1_mediawiki_proto_msgTypes[27].OneofWrappers = []any{}
+	file_proto_mediawiki_v1_mediawiki_proto_msgTypes[30].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_mediawiki_v1_mediawiki_proto_rawDesc), len(file_proto_mediawiki_v1_mediawiki_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   39,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_mediawiki_v1_mediawiki_proto_goTypes,
+		DependencyIndexes: file_proto_mediawiki_v1_mediawiki_proto_depIdxs,
+		MessageInfos:      file_proto_mediawiki_v1_mediawiki_proto_msgTypes,
+	}.Build()
+	File_proto_mediawiki_v1_mediawiki_proto = out.File
+	file_proto_mediawiki_v1_mediawiki_proto_goTypes = nil
+	file_proto_mediawiki_v1_mediawiki_proto_depIdxs = nil
+}
@@ -0,0 +1,509 @@
+// gRPC interface to the MediaWiki tools. Messages mirror internal/wiki/types.go
+// and field names match the JSON returned by the MCP tools.
+//
+// Regenerate with:
+//   protoc --go_out=. --go_opt=module=github.com/yourusername/mediawiki-mcp \
+//     --go-grpc_out=. --go-grpc_opt=module=github.com/yourusername/mediawiki-mcp \
+//     proto/mediawiki/v1/mediawiki.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: proto/mediawiki/v1/mediawiki.proto
+
+package mediawikipb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MediaWiki_GetWikiInfo_FullMethodName      = "/mediawiki.v1.MediaWiki/GetWikiInfo"
+	MediaWiki_Search_FullMethodName           = "/mediawiki.v1.MediaWiki/Search"
+	MediaWiki_GetPageOutline_FullMethodName   = "/mediawiki.v1.MediaWiki/GetPageOutline"
+	MediaWiki_GetPageSection_FullMethodName   = "/mediawiki.v1.MediaWiki/GetPageSection"
+	MediaWiki_GetPageFull_FullMethodName      = "/mediawiki.v1.MediaWiki/GetPageFull"
+	MediaWiki_GetCategory_FullMethodName      = "/mediawiki.v1.MediaWiki/GetCategory"
+	MediaWiki_GetBacklinks_FullMethodName     = "/mediawiki.v1.MediaWiki/GetBacklinks"
+	MediaWiki_CompareRevisions_FullMethodName = "/mediawiki.v1.MediaWiki/CompareRevisions"
+	MediaWiki_GetSubpages_FullMethodName      = "/mediawiki.v1.MediaWiki/GetSubpages"
+	MediaWiki_GetDiscussions_FullMethodName   = "/mediawiki.v1.MediaWiki/GetDiscussions"
+	MediaWiki_HasChanged_FullMethodName       = "/mediawiki.v1.MediaWiki/HasChanged"
+)
+
+// MediaWikiClient is the client API for MediaWiki service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MediaWikiClient interface {
+	GetWikiInfo(ctx context.Context, in *WikiInfoRequest, opts ...grpc.CallOption) (*WikiInfo, error)
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	GetPageOutline(ctx context.Context, in *PageOutlineRequest, opts ...grpc.CallOption) (*PageOutline, error)
+	GetPageSection(ctx context.Context, in *PageSectionRequest, opts ...grpc.CallOption) (*PageSection, error)
+	GetPageFull(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*PageFull, error)
+	GetCategory(ctx context.Context, in *CategoryRequest, opts ...grpc.CallOption) (*CategoryResponse, error)
+	GetBacklinks(ctx context.Context, in *PageListRequest, opts ...grpc.CallOption) (*BacklinksResponse, error)
+	CompareRevisions(ctx context.Context, in *CompareRequest, opts ...grpc.CallOption) (*CompareResponse, error)
+	GetSubpages(ctx context.Context, in *PageListRequest, opts ...grpc.CallOption) (*SubpagesResponse, error)
+	GetDiscussions(ctx context.Context, in *PageListRequest, opts ...grpc.CallOption) (*DiscussionsResponse, error)
+	HasChanged(ctx context.Context, in *HasChangedRequest, opts ...grpc.CallOption) (*ChangeCheck, error)
+}
+
+type mediaWikiClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMediaWikiClient(cc grpc.ClientConnInterface) MediaWikiClient {
+	return &mediaWikiClient{cc}
+}
+
+func (c *mediaWikiClient) GetWikiInfo(ctx context.Context, in *WikiInfoRequest, opts ...grpc.CallOption) (*WikiInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WikiInfo)
+	err := c.cc.Invoke(ctx, MediaWiki_GetWikiInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaWikiClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, MediaWiki_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaWikiClient) GetPageOutline(ctx context.Context, in *PageOutlineRequest, opts ...grpc.CallOption) (*PageOutline, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PageOutline)
+	err := c.cc.Invoke(ctx, MediaWiki_GetPageOutline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaWikiClient) GetPageSection(ctx context.Context, in *PageSectionRequest, opts ...grpc.CallOption) (*PageSection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PageSection)
+	err := c.cc.Invoke(ctx, MediaWiki_GetPageSection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaWikiClient) GetPageFull(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*PageFull, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PageFull)
+	err := c.cc.Invoke(ctx, MediaWiki_GetPageFull_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaWikiClient) GetCategory(ctx context.Context, in *CategoryRequest, opts ...grpc.CallOption) (*CategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CategoryResponse)
+	err := c.cc.Invoke(ctx, MediaWiki_GetCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaWikiClient) GetBacklinks(ctx context.Context, in *PageListRequest, opts ...grpc.CallOption) (*BacklinksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BacklinksResponse)
+	err := c.cc.Invoke(ctx, MediaWiki_GetBacklinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaWikiClient) CompareRevisions(ctx context.Context, in *CompareRequest, opts ...grpc.CallOption) (*CompareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompareResponse)
+	err := c.cc.Invoke(ctx, MediaWiki_CompareRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaWikiClient) GetSubpages(ctx context.Context, in *PageListRequest, opts ...grpc.CallOption) (*SubpagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubpagesResponse)
+	err := c.cc.Invoke(ctx, MediaWiki_GetSubpages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaWikiClient) GetDiscussions(ctx context.Context, in *PageListRequest, opts ...grpc.CallOption) (*DiscussionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiscussionsResponse)
+	err := c.cc.Invoke(ctx, MediaWiki_GetDiscussions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaWikiClient) HasChanged(ctx context.Context, in *HasChangedRequest, opts ...grpc.CallOption) (*ChangeCheck, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeCheck)
+	err := c.cc.Invoke(ctx, MediaWiki_HasChanged_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MediaWikiServer is the server API for MediaWiki service.
+// All implementations must embed UnimplementedMediaWikiServer
+// for forward compatibility.
+type MediaWikiServer interface {
+	GetWikiInfo(context.Context, *WikiInfoRequest) (*WikiInfo, error)
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	GetPageOutline(context.Context, *PageOutlineRequest) (*PageOutline, error)
+	GetPageSection(context.Context, *PageSectionRequest) (*PageSection, error)
+	GetPageFull(context.Context, *PageRequest) (*PageFull, error)
+	GetCategory(context.Context, *CategoryRequest) (*CategoryResponse, error)
+	GetBacklinks(context.Context, *PageListRequest) (*BacklinksResponse, error)
+	CompareRevisions(context.Context, *CompareRequest) (*CompareResponse, error)
+	GetSubpages(context.Context, *PageListRequest) (*SubpagesResponse, error)
+	GetDiscussions(context.Context, *PageListRequest) (*DiscussionsResponse, error)
+	HasChanged(context.Context, *HasChangedRequest) (*ChangeCheck, error)
+	mustEmbedUnimplementedMediaWikiServer()
+}
+
+// UnimplementedMediaWikiServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMediaWikiServer struct{}
+
+func (UnimplementedMediaWikiServer) GetWikiInfo(context.Context, *WikiInfoRequest) (*WikiInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWikiInfo not implemented")
+}
+func (UnimplementedMediaWikiServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedMediaWikiServer) GetPageOutline(context.Context, *PageOutlineRequest) (*PageOutline, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPageOutline not implemented")
+}
+func (UnimplementedMediaWikiServer) GetPageSection(context.Context, *PageSectionRequest) (*PageSection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPageSection not implemented")
+}
+func (UnimplementedMediaWikiServer) GetPageFull(context.Context, *PageRequest) (*PageFull, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPageFull not implemented")
+}
+func (UnimplementedMediaWikiServer) GetCategory(context.Context, *CategoryRequest) (*CategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategory not implemented")
+}
+func (UnimplementedMediaWikiServer) GetBacklinks(context.Context, *PageListRequest) (*BacklinksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBacklinks not implemented")
+}
+func (UnimplementedMediaWikiServer) CompareRevisions(context.Context, *CompareRequest) (*CompareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareRevisions not implemented")
+}
+func (UnimplementedMediaWikiServer) GetSubpages(context.Context, *PageListRequest) (*SubpagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSubpages not implemented")
+}
+func (UnimplementedMediaWikiServer) GetDiscussions(context.Context, *PageListRequest) (*DiscussionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDiscussions not implemented")
+}
+func (UnimplementedMediaWikiServer) HasChanged(context.Context, *HasChangedRequest) (*ChangeCheck, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HasChanged not implemented")
+}
+func (UnimplementedMediaWikiServer) mustEmbedUnimplementedMediaWikiServer() {}
+func (UnimplementedMediaWikiServer) testEmbeddedByValue()                   {}
+
+// UnsafeMediaWikiServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MediaWikiServer will
+// result in compilation errors.
+type UnsafeMediaWikiServer interface {
+	mustEmbedUnimplementedMediaWikiServer()
+}
+
+func RegisterMediaWikiServer(s grpc.ServiceRegistrar, srv MediaWikiServer) {
+	// If the following call pancis, it indicates UnimplementedMediaWikiServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MediaWiki_ServiceDesc, srv)
+}
+
+func _MediaWiki_GetWikiInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WikiInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaWikiServer).GetWikiInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaWiki_GetWikiInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaWikiServer).GetWikiInfo(ctx, req.(*WikiInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaWiki_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaWikiServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaWiki_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaWikiServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaWiki_GetPageOutline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PageOutlineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaWikiServer).GetPageOutline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaWiki_GetPageOutline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaWikiServer).GetPageOutline(ctx, req.(*PageOutlineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaWiki_GetPageSection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PageSectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaWikiServer).GetPageSection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaWiki_GetPageSection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaWikiServer).GetPageSection(ctx, req.(*PageSectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaWiki_GetPageFull_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaWikiServer).GetPageFull(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaWiki_GetPageFull_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaWikiServer).GetPageFull(ctx, req.(*PageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaWiki_GetCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaWikiServer).GetCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaWiki_GetCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaWikiServer).GetCategory(ctx, req.(*CategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaWiki_GetBacklinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PageListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaWikiServer).GetBacklinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaWiki_GetBacklinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaWikiServer).GetBacklinks(ctx, req.(*PageListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaWiki_CompareRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaWikiServer).CompareRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaWiki_CompareRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaWikiServer).CompareRevisions(ctx, req.(*CompareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaWiki_GetSubpages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PageListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaWikiServer).GetSubpages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaWiki_GetSubpages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaWikiServer).GetSubpages(ctx, req.(*PageListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaWiki_GetDiscussions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PageListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaWikiServer).GetDiscussions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaWiki_GetDiscussions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaWikiServer).GetDiscussions(ctx, req.(*PageListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaWiki_HasChanged_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HasChangedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaWikiServer).HasChanged(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaWiki_HasChanged_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaWikiServer).HasChanged(ctx, req.(*HasChangedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MediaWiki_ServiceDesc is the grpc.ServiceDesc for MediaWiki service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MediaWiki_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "mediawiki.v1.MediaWiki",
+	HandlerType: (*MediaWikiServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetWikiInfo",
+			Handler:    _MediaWiki_GetWikiInfo_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _MediaWiki_Search_Handler,
+		},
+		{
+			MethodName: "GetPageOutline",
+			Handler:    _MediaWiki_GetPageOutline_Handler,
+		},
+		{
+			MethodName: "GetPageSection",
+			Handler:    _MediaWiki_GetPageSection_Handler,
+		},
+		{
+			MethodName: "GetPageFull",
+			Handler:    _MediaWiki_GetPageFull_Handler,
+		},
+		{
+			MethodName: "GetCategory",
+			Handler:    _MediaWiki_GetCategory_Handler,
+		},
+		{
+			MethodName: "GetBacklinks",
+			Handler:    _MediaWiki_GetBacklinks_Handler,
+		},
+		{
+			MethodName: "CompareRevisions",
+			Handler:    _MediaWiki_CompareRevisions_Handler,
+		},
+		{
+			MethodName: "GetSubpages",
+			Handler:    _MediaWiki_GetSubpages_Handler,
+		},
+		{
+			MethodName: "GetDiscussions",
+			Handler:    _MediaWiki_GetDiscussions_Handler,
+		},
+		{
+			MethodName: "HasChanged",
+			Handler:    _MediaWiki_HasChanged_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/mediawiki/v1/mediawiki.proto",
+}
@@ -0,0 +1,227 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	mcpServer "github.com/yourusername/mediawiki-mcp/internal/mcp"
+	pb "github.com/yourusername/mediawiki-mcp/internal/rpc/mediawikipb"
+)
+
+// Server implements the MediaWiki gRPC service on top of the MCP tools.
+// Requests and responses are converted via protojson, whose field names
+// match the tool arguments and results, so every RPC goes through the same
+// handlers (cache, rate limits, content filters) as MCP.
+type Server struct {
+	pb.UnimplementedMediaWikiServer
+	tools *mcpServer.Server
+}
+
+// NewServer creates a gRPC server exposing the tools of an MCP server.
+// With MCP_API_TOKENS set, calls need one of the tokens as "authorization:
+// Bearer <token>" metadata; opts can add transport credentials.
+func NewServer(tools *mcpServer.Server, opts ...grpc.ServerOption) *grpc.Server {
+	s := &Server{tools: tools}
+	srv := grpc.NewServer(append(opts, grpc.UnaryInterceptor(s.authorize))...)
+	pb.RegisterMediaWikiServer(srv, s)
+	return srv
+}
+
+// authorize rejects calls without a token from MCP_API_TOKENS, when it is set
+func (s *Server) authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if s.tools.APIAuthRequired() {
+		var token string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			for _, value := range md.Get("authorization") {
+				if t, ok := strings.CutPrefix(value, "Bearer "); ok {
+					token = t
+				}
+			}
+		}
+		if !s.tools.ValidAPIToken(token) {
+			return nil, status.Error(codes.Unauthenticated, "a token from MCP_API_TOKENS is required")
+		}
+	}
+	return handler(ctx, req)
+}
+
+var (
+	marshalArgs    = protojson.MarshalOptions{UseProtoNames: true}
+	unmarshalReply = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// call invokes a tool with req as its arguments and decodes the result into reply
+func (s *Server) call(ctx context.Context, tool string, req, reply proto.Message) error {
+	if !s.tools.APIToolAllowed(tool) {
+		return status.Errorf(codes.PermissionDenied, "%s is only served over gRPC when MCP_API_TOKENS is set", tool)
+	}
+
+	args, err := marshalArgs.Marshal(req)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := s.tools.CallToolJSON(ctx, tool, args)
+	if err != nil {
+		return toStatus(ctx, err)
+	}
+
+	if err := unmarshalReply.Unmarshal(result, reply); err != nil {
+		return status.Errorf(codes.Internal, "decode %s result: %v", tool, err)
+	}
+	return nil
+}
+
+// toStatus converts a tool error into a gRPC status carrying the structured
+// error code and hint as ErrorInfo details
+func toStatus(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+
+	var toolErr *mcpServer.ToolError
+	if !errors.As(err, &toolErr) {
+		if errors.Is(err, mcpServer.ErrUnknownTool) {
+			return status.Error(codes.Unimplemented, err.Error())
+		}
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp := toolErr.Response
+	st := status.New(errorCode(resp.Error), resp.Message)
+	info := &errdetails.ErrorInfo{
+		Reason:   resp.Error,
+		Domain:   "mediawiki-mcp",
+		Metadata: map[string]string{},
+	}
+	if resp.Hint != "" {
+		info.Metadata["hint"] = resp.Hint
+	}
+	if detailed, err := st.WithDetails(info); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// errorCode maps structured error codes onto gRPC status codes
+func errorCode(code string) codes.Code {
+	switch code {
+	case "missingtitle", "nosuchsection", "section_not_found", "nosuchrevid", "language_version_not_found", "artifact_not_found", "snippet_not_found":
+		return codes.NotFound
+	case "invalidtitle", "badvalue", "paramempty":
+		return codes.InvalidArgument
+	case "maxlag", "ratelimited":
+		return codes.Unavailable
+	case "page_too_large", "page_too_complex", "response_too_large", "no_credentials_for_wiki", "file_too_large", "upload_warning", "onlyauthor":
+		return codes.FailedPrecondition
+	case "feature_unsupported":
+		return codes.Unimplemented
+	case "editconflict", "edit_conflict", "alreadyrolled", "undofailure":
+		return codes.Aborted
+	case "page_exists":
+		return codes.AlreadyExists
+	case "permissiondenied", "protectedpage", "blocked", "autoblocked", "spamblacklist", "namespace_write_forbidden", "loginfailed", "missing_right":
+		return codes.PermissionDenied
+	}
+	if strings.HasPrefix(code, "abusefilter-") {
+		return codes.PermissionDenied
+	}
+	return codes.Internal
+}
+
+func (s *Server) GetWikiInfo(ctx context.Context, req *pb.WikiInfoRequest) (*pb.WikiInfo, error) {
+	reply := &pb.WikiInfo{}
+	if err := s.call(ctx, "wiki_info", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *Server) Search(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error) {
+	reply := &pb.SearchResponse{}
+	if err := s.call(ctx, "wiki_search", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *Server) GetPageOutline(ctx context.Context, req *pb.PageOutlineRequest) (*pb.PageOutline, error) {
+	reply := &pb.PageOutline{}
+	if err := s.call(ctx, "wiki_page_outline", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *Server) GetPageSection(ctx context.Context, req *pb.PageSectionRequest) (*pb.PageSection, error) {
+	reply := &pb.PageSection{}
+	if err := s.call(ctx, "wiki_page_section", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *Server) GetPageFull(ctx context.Context, req *pb.PageRequest) (*pb.PageFull, error) {
+	reply := &pb.PageFull{}
+	if err := s.call(ctx, "wiki_page_full", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *Server) GetCategory(ctx context.Context, req *pb.CategoryRequest) (*pb.CategoryResponse, error) {
+	reply := &pb.CategoryResponse{}
+	if err := s.call(ctx, "wiki_category", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *Server) GetBacklinks(ctx context.Context, req *pb.PageListRequest) (*pb.BacklinksResponse, error) {
+	reply := &pb.BacklinksResponse{}
+	if err := s.call(ctx, "wiki_backlinks", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *Server) CompareRevisions(ctx context.Context, req *pb.CompareRequest) (*pb.CompareResponse, error) {
+	reply := &pb.CompareResponse{}
+	if err := s.call(ctx, "wiki_compare", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *Server) GetSubpages(ctx context.Context, req *pb.PageListRequest) (*pb.SubpagesResponse, error) {
+	reply := &pb.SubpagesResponse{}
+	if err := s.call(ctx, "wiki_subpages", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *Server) GetDiscussions(ctx context.Context, req *pb.PageListRequest) (*pb.DiscussionsResponse, error) {
+	reply := &pb.DiscussionsResponse{}
+	if err := s.call(ctx, "wiki_discussions", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *Server) HasChanged(ctx context.Context, req *pb.HasChangedRequest) (*pb.ChangeCheck, error) {
+	reply := &pb.ChangeCheck{}
+	if err := s.call(ctx, "wiki_has_changed", req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
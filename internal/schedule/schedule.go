@@ -0,0 +1,174 @@
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/yourusername/mediawiki-mcp/internal/jobs"
+)
+
+// ToolCaller invokes a registered tool with JSON arguments
+type ToolCaller interface {
+	CallToolJSON(ctx context.Context, name string, arguments json.RawMessage) (json.RawMessage, error)
+}
+
+// Job is a scheduled tool pipeline
+type Job struct {
+	Name     string `json:"name"`
+	Schedule string `json:"schedule"` // cron expression or descriptor such as "@daily"
+	Steps    []Step `json:"steps"`
+	Sink     Sink   `json:"sink"`
+}
+
+// Step is a single tool call in a job
+type Step struct {
+	Tool      string          `json:"tool"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+// Report is the output of one job run
+type Report struct {
+	Job     string       `json:"job"`
+	RanAt   time.Time    `json:"ran_at"`
+	Results []StepResult `json:"results"`
+}
+
+// StepResult is the outcome of one step
+type StepResult struct {
+	Tool   string          `json:"tool"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// LoadJobs reads job definitions from a JSON file
+func LoadJobs(path string) ([]Job, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read schedule: %w", err)
+	}
+
+	var jobs []Job
+	if err := json.Unmarshal(data, &jobs); err != nil {
+		return nil, fmt.Errorf("parse schedule: %w", err)
+	}
+
+	for _, job := range jobs {
+		if job.Name == "" || len(job.Steps) == 0 {
+			return nil, fmt.Errorf("job %q: name and steps are required", job.Name)
+		}
+		if _, err := cron.ParseStandard(job.Schedule); err != nil {
+			return nil, fmt.Errorf("job %q: invalid schedule %q: %w", job.Name, job.Schedule, err)
+		}
+		if err := job.Sink.validate(); err != nil {
+			return nil, fmt.Errorf("job %q: %w", job.Name, err)
+		}
+	}
+	return jobs, nil
+}
+
+// jobTimeout bounds a single job run
+const jobTimeout = 10 * time.Minute
+
+// reportPriority runs scheduled reports behind interactive jobs
+const reportPriority = -1
+
+// Scheduler submits jobs to the job queue on their cron schedules
+type Scheduler struct {
+	cron  *cron.Cron
+	tools ToolCaller
+	queue *jobs.Queue
+	sinks sinkConfig
+}
+
+// NewScheduler creates a scheduler running jobs through tools on queue.
+// Webhook sinks use userAgent, sign payloads with webhookSecret, and time
+// out after timeout.
+func NewScheduler(tools ToolCaller, queue *jobs.Queue, userAgent, webhookSecret string, timeout time.Duration) *Scheduler {
+	return &Scheduler{
+		cron:  cron.New(),
+		tools: tools,
+		queue: queue,
+		sinks: sinkConfig{userAgent: userAgent, webhookSecret: webhookSecret, timeout: timeout},
+	}
+}
+
+// Add schedules a job
+func (s *Scheduler) Add(job Job) error {
+	if _, err := s.cron.AddFunc(job.Schedule, func() { s.submit(job) }); err != nil {
+		return fmt.Errorf("job %q: invalid schedule %q: %w", job.Name, job.Schedule, err)
+	}
+	return nil
+}
+
+// submit queues a run of job
+func (s *Scheduler) submit(job Job) {
+	_, err := s.queue.Submit(jobs.Spec{
+		Kind:     "scheduled_report",
+		Params:   map[string]string{"job": job.Name},
+		Priority: reportPriority,
+		Run: func(ctx context.Context, task *jobs.Task) (interface{}, error) {
+			return s.Run(ctx, job, task)
+		},
+	})
+	if err != nil {
+		log.Printf("schedule: job %q: submit: %v", job.Name, err)
+	}
+}
+
+// Start begins running scheduled jobs in the background
+func (s *Scheduler) Start() {
+	s.cron.Start()
+}
+
+// Stop stops scheduling and waits for running jobs to finish
+func (s *Scheduler) Stop() {
+	<-s.cron.Stop().Done()
+}
+
+// Run executes a job once and writes its report to the job's sink. task,
+// if non-nil, receives per-step progress.
+func (s *Scheduler) Run(ctx context.Context, job Job, task *jobs.Task) (*Report, error) {
+	ctx, cancel := context.WithTimeout(ctx, jobTimeout)
+	defer cancel()
+
+	if task != nil {
+		task.SetTotal(len(job.Steps))
+	}
+
+	report := &Report{
+		Job:     job.Name,
+		RanAt:   time.Now().UTC(),
+		Results: make([]StepResult, 0, len(job.Steps)),
+	}
+
+	for _, step := range job.Steps {
+		args := step.Arguments
+		if len(args) == 0 {
+			args = json.RawMessage("{}")
+		}
+
+		result := StepResult{Tool: step.Tool}
+		data, err := s.tools.CallToolJSON(ctx, step.Tool, args)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Result = data
+		}
+		report.Results = append(report.Results, result)
+
+		if task != nil {
+			task.SetProgress(len(report.Results), step.Tool)
+		}
+	}
+
+	if err := job.Sink.write(ctx, s.sinks, report); err != nil {
+		return nil, fmt.Errorf("write report: %w", err)
+	}
+	return report, nil
+}
@@ -0,0 +1,89 @@
+package schedule
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/watch"
+)
+
+// Sink is where a job's report is written
+type Sink struct {
+	Type string `json:"type"`           // "file" or "webhook"
+	Path string `json:"path,omitempty"` // file sink; {date} and {time} are expanded
+	URL  string `json:"url,omitempty"`  // webhook sink
+}
+
+// sinkConfig holds deployment settings shared by all sinks
+type sinkConfig struct {
+	userAgent     string
+	webhookSecret string
+	timeout       time.Duration
+}
+
+func (s Sink) validate() error {
+	switch s.Type {
+	case "file":
+		if s.Path == "" {
+			return fmt.Errorf("file sink requires path")
+		}
+	case "webhook":
+		if s.URL == "" {
+			return fmt.Errorf("webhook sink requires url")
+		}
+	default:
+		return fmt.Errorf("unknown sink type %q (want file or webhook)", s.Type)
+	}
+	return nil
+}
+
+// write delivers a report to the sink
+func (s Sink) write(ctx context.Context, cfg sinkConfig, report *Report) error {
+	body, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	switch s.Type {
+	case "file":
+		path := strings.NewReplacer(
+			"{date}", report.RanAt.Format("2006-01-02"),
+			"{time}", report.RanAt.Format("150405"),
+		).Replace(s.Path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		return os.WriteFile(path, body, 0o644)
+
+	case "webhook":
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", cfg.userAgent)
+		if cfg.webhookSecret != "" {
+			req.Header.Set(watch.SignatureHeader, "sha256="+watch.Sign([]byte(cfg.webhookSecret), body))
+		}
+
+		client := &http.Client{Timeout: cfg.timeout}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("webhook status %d", resp.StatusCode)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown sink type %q", s.Type)
+}
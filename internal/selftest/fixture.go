@@ -0,0 +1,81 @@
+package selftest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// FixtureTitle is the one page of the fixture wiki
+const FixtureTitle = "Self-test"
+
+// Sections of the fixture page, as their headings and the text of each
+const (
+	leadText    = "The self-test page checks the server from tool call to wiki response."
+	sectionLine = "Fixture"
+	sectionText = "This section is served by a wiki running inside the self-test."
+)
+
+var fixtureSections = []map[string]any{{
+	"toclevel": 1, "level": "2", "line": sectionLine, "number": "1", "index": "1",
+	"fromtitle": FixtureTitle, "byteoffset": 80, "anchor": sectionLine,
+}}
+
+var fixtureWikitext = "'''" + FixtureTitle + "''' " + strings.TrimPrefix(leadText, "The self-test page ") +
+	"\n\n== " + sectionLine + " ==\n" + sectionText + "\n"
+
+// fixtureWiki answers the MediaWiki API requests the page tools make for
+// the fixture page. Other requests get an API error, which the tools report
+// as they would for any wiki.
+func fixtureWiki() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form := r.Form
+		w.Header().Set("Content-Type", "application/json")
+
+		var resp map[string]any
+		switch {
+		case form.Get("meta") == "siteinfo":
+			resp = map[string]any{"query": map[string]any{
+				"general": map[string]any{
+					"sitename":    "Self-test wiki",
+					"mainpage":    FixtureTitle,
+					"lang":        "en",
+					"generator":   "MediaWiki 1.42.0",
+					"server":      "http://" + r.Host,
+					"articlepath": "/wiki/$1",
+				},
+				"extensions": []any{},
+			}}
+		case form.Get("action") == "parse" && form.Get("page") == FixtureTitle:
+			lead := "<p>" + leadText + "</p>"
+			section := `<h2 id="` + sectionLine + `">` + sectionLine + "</h2><p>" + sectionText + "</p>"
+			text := lead + section
+			switch form.Get("section") {
+			case "0":
+				text = lead
+			case "1":
+				text = section
+			}
+			resp = map[string]any{"parse": map[string]any{
+				"title": FixtureTitle, "pageid": 1, "revid": 1,
+				"text":     text,
+				"sections": fixtureSections,
+				"links":    []any{}, "categories": []any{},
+			}}
+		case form.Get("prop") == "revisions" && form.Get("titles") == FixtureTitle:
+			resp = map[string]any{"query": map[string]any{"pages": []any{map[string]any{
+				"pageid": 1, "ns": 0, "title": FixtureTitle,
+				"revisions": []any{map[string]any{
+					"revid": 1, "timestamp": "2024-01-01T00:00:00Z",
+					"slots": map[string]any{"main": map[string]any{"content": fixtureWikitext}},
+				}},
+			}}}}
+		default:
+			resp = map[string]any{"error": map[string]any{
+				"code": "missingtitle", "info": "The self-test wiki only has the page " + FixtureTitle + ".",
+			}}
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+}
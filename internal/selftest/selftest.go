@@ -0,0 +1,155 @@
+// Package selftest checks the server end to end without network access: it
+// serves the MCP endpoint in-process, calls tools through an MCP client, and
+// answers their wiki requests from a fixture wiki on a loopback port
+package selftest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/yourusername/mediawiki-mcp/config"
+	mcpServer "github.com/yourusername/mediawiki-mcp/internal/mcp"
+	"github.com/yourusername/mediawiki-mcp/internal/profiles"
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// check is a tool call and the test of its result data
+type check struct {
+	tool string
+	args map[string]any
+	test func(data json.RawMessage) error
+}
+
+// checks are the tool calls made, in order
+var checks = []check{
+	{
+		tool: "wiki_page_outline",
+		test: func(data json.RawMessage) error {
+			var outline wiki.PageOutline
+			if err := json.Unmarshal(data, &outline); err != nil {
+				return err
+			}
+			if !strings.Contains(outline.Summary, leadText) {
+				return fmt.Errorf("summary is %q, want the fixture's lead", outline.Summary)
+			}
+			for _, section := range outline.Sections {
+				if section.Title == sectionLine {
+					return nil
+				}
+			}
+			return fmt.Errorf("no section %q in the outline", sectionLine)
+		},
+	},
+	{
+		tool: "wiki_page_section",
+		args: map[string]any{"section_index": 1},
+		test: func(data json.RawMessage) error {
+			var section wiki.PageSection
+			if err := json.Unmarshal(data, &section); err != nil {
+				return err
+			}
+			if section.Section == nil || !strings.Contains(section.Section.Content, sectionText) {
+				return fmt.Errorf("section content is missing the fixture text")
+			}
+			return nil
+		},
+	},
+}
+
+// Run builds the server from cfg, as the server command does but without
+// the database, and makes the checks' tool calls against the fixture wiki.
+// It returns the first failure.
+func Run(ctx context.Context, cfg *config.Config) error {
+	wikiURL, stopWiki, err := serve(fixtureWiki())
+	if err != nil {
+		return fmt.Errorf("start fixture wiki: %w", err)
+	}
+	defer stopWiki()
+
+	server := mcpServer.NewServer(cfg, nil)
+	if cfg.ProfilesFile != "" {
+		list, err := profiles.Load(cfg.ProfilesFile)
+		if err != nil {
+			return fmt.Errorf("wiki profiles: %w", err)
+		}
+		if err := server.SetProfiles(list); err != nil {
+			return fmt.Errorf("wiki profiles: %w", err)
+		}
+	}
+	defer server.Close()
+
+	mcpSrv := server.GetMCPServer()
+	handler := mcp.NewStreamableHTTPHandler(
+		func(*http.Request) *mcp.Server { return mcpSrv },
+		&mcp.StreamableHTTPOptions{Stateless: true, JSONResponse: true},
+	)
+	endpoint, stopServer, err := serve(handler)
+	if err != nil {
+		return fmt.Errorf("start MCP endpoint: %w", err)
+	}
+	defer stopServer()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "selftest", Version: config.Version}, nil)
+	session, err := client.Connect(ctx, &mcp.StreamableClientTransport{Endpoint: endpoint}, nil)
+	if err != nil {
+		return fmt.Errorf("connect to MCP endpoint: %w", err)
+	}
+	defer session.Close()
+
+	for _, c := range checks {
+		args := map[string]any{"wiki_url": wikiURL, "title": FixtureTitle}
+		for k, v := range c.args {
+			args[k] = v
+		}
+		if err := call(ctx, session, c, args); err != nil {
+			return fmt.Errorf("%s: %w", c.tool, err)
+		}
+	}
+	return nil
+}
+
+// call makes a check's tool call and tests its result
+func call(ctx context.Context, session *mcp.ClientSession, c check, args map[string]any) error {
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: c.tool, Arguments: args})
+	if err != nil {
+		return err
+	}
+	if len(result.Content) == 0 {
+		return fmt.Errorf("empty result")
+	}
+	text, ok := result.Content[0].(*mcp.TextContent)
+	if !ok {
+		return fmt.Errorf("result is %T, not text", result.Content[0])
+	}
+
+	var envelope struct {
+		Data    json.RawMessage `json:"data"`
+		Error   string          `json:"error"`
+		Message string          `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(text.Text), &envelope); err != nil {
+		return fmt.Errorf("parse result: %w", err)
+	}
+	if result.IsError {
+		return fmt.Errorf("%s: %s", envelope.Error, envelope.Message)
+	}
+	return c.test(envelope.Data)
+}
+
+// serve serves a handler on a loopback port, returning its URL and a
+// function that stops it
+func serve(handler http.Handler) (string, func(), error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, err
+	}
+	srv := &http.Server{Handler: handler}
+	go srv.Serve(lis)
+	return "http://" + lis.Addr().String(), func() { srv.Close() }, nil
+}
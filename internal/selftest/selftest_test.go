@@ -0,0 +1,19 @@
+package selftest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/config"
+)
+
+func TestRun(t *testing.T) {
+	t.Setenv("MCP_ARTIFACTS_DIR", t.TempDir())
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := Run(ctx, config.Load()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+}
@@ -0,0 +1,278 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // pure-Go SQLite driver
+
+	"github.com/yourusername/mediawiki-mcp/internal/approval"
+	"github.com/yourusername/mediawiki-mcp/internal/jobs"
+	"github.com/yourusername/mediawiki-mcp/internal/watch"
+)
+
+// schema creates the tables on first use; statements are idempotent
+const schema = `
+CREATE TABLE IF NOT EXISTS jobs (
+	id         TEXT PRIMARY KEY,
+	kind       TEXT NOT NULL,
+	status     TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	data       TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS pending_edits (
+	id           TEXT PRIMARY KEY,
+	status       TEXT NOT NULL,
+	submitted_at TIMESTAMP NOT NULL,
+	data         TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS watch_revisions (
+	wiki_url TEXT NOT NULL,
+	title    TEXT NOT NULL,
+	rev_id   INTEGER NOT NULL,
+	PRIMARY KEY (wiki_url, title)
+);
+
+CREATE TABLE IF NOT EXISTS audit_log (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	at          TIMESTAMP NOT NULL,
+	session     TEXT NOT NULL,
+	tool        TEXT NOT NULL,
+	wiki_url    TEXT NOT NULL,
+	title       TEXT NOT NULL,
+	arguments   TEXT NOT NULL,
+	error_code  TEXT NOT NULL,
+	duration_ms INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_at ON audit_log (at);
+CREATE INDEX IF NOT EXISTS audit_log_tool ON audit_log (tool);
+`
+
+// migrations alter tables created by earlier versions. A statement failing
+// with "duplicate column" has already been applied.
+var migrations = []string{
+	`ALTER TABLE audit_log ADD COLUMN ref TEXT NOT NULL DEFAULT ''`,
+	`CREATE INDEX IF NOT EXISTS audit_log_ref ON audit_log (ref)`,
+}
+
+// Store is durable server state (jobs, pending edits, watch state, audit
+// log) in SQLite
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens or creates the database at path
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+
+	// SQLite allows one writer; serializing avoids busy errors
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate database: %w", err)
+	}
+	for _, stmt := range migrations {
+		if _, err := db.Exec(stmt); err != nil && !strings.Contains(err.Error(), "duplicate column") {
+			db.Close()
+			return nil, fmt.Errorf("migrate database: %w", err)
+		}
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// SaveJob upserts a job snapshot (implements jobs.Store)
+func (s *Store) SaveJob(job jobs.Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO jobs (id, kind, status, created_at, data) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET status = excluded.status, data = excluded.data`,
+		job.ID, job.Kind, string(job.Status), job.CreatedAt, string(data))
+	return err
+}
+
+// LoadJobs returns all saved jobs (implements jobs.Store)
+func (s *Store) LoadJobs() ([]jobs.Job, error) {
+	rows, err := s.db.Query(`SELECT data FROM jobs ORDER BY created_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]jobs.Job, 0)
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var job jobs.Job
+		if err := json.Unmarshal([]byte(data), &job); err != nil {
+			return nil, fmt.Errorf("decode job: %w", err)
+		}
+		list = append(list, job)
+	}
+	return list, rows.Err()
+}
+
+// SavePendingEdit records an edit held for review (implements approval.Store)
+func (s *Store) SavePendingEdit(edit approval.Edit) error {
+	data, err := json.Marshal(edit)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO pending_edits (id, status, submitted_at, data) VALUES (?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET status = excluded.status, data = excluded.data`,
+		edit.ID, string(edit.Status), edit.SubmittedAt, string(data))
+	return err
+}
+
+// LoadPendingEdits returns all saved edits (implements approval.Store)
+func (s *Store) LoadPendingEdits() ([]approval.Edit, error) {
+	rows, err := s.db.Query(`SELECT data FROM pending_edits ORDER BY submitted_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]approval.Edit, 0)
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var edit approval.Edit
+		if err := json.Unmarshal([]byte(data), &edit); err != nil {
+			return nil, fmt.Errorf("decode pending edit: %w", err)
+		}
+		list = append(list, edit)
+	}
+	return list, rows.Err()
+}
+
+// SaveRevision records the last seen revision of a watched page
+// (implements watch.StateStore)
+func (s *Store) SaveRevision(page watch.Page, revID int) error {
+	_, err := s.db.Exec(`
+		INSERT INTO watch_revisions (wiki_url, title, rev_id) VALUES (?, ?, ?)
+		ON CONFLICT (wiki_url, title) DO UPDATE SET rev_id = excluded.rev_id`,
+		page.WikiURL, page.Title, revID)
+	return err
+}
+
+// LoadRevisions returns the last seen revision of every watched page
+// (implements watch.StateStore)
+func (s *Store) LoadRevisions() (map[watch.Page]int, error) {
+	rows, err := s.db.Query(`SELECT wiki_url, title, rev_id FROM watch_revisions`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revs := make(map[watch.Page]int)
+	for rows.Next() {
+		var page watch.Page
+		var revID int
+		if err := rows.Scan(&page.WikiURL, &page.Title, &revID); err != nil {
+			return nil, err
+		}
+		revs[page] = revID
+	}
+	return revs, rows.Err()
+}
+
+// AuditRecord is a tool call as recorded in the audit log
+type AuditRecord struct {
+	Ref       string // opaque reference, e.g. linked from edit summaries
+	At        time.Time
+	Session   string
+	Tool      string
+	WikiURL   string
+	Title     string
+	Arguments json.RawMessage
+	ErrorCode string // empty on success
+	Duration  time.Duration
+}
+
+// RecordAudit appends a tool call to the audit log
+func (s *Store) RecordAudit(rec AuditRecord) error {
+	_, err := s.db.Exec(`
+		INSERT INTO audit_log (ref, at, session, tool, wiki_url, title, arguments, error_code, duration_ms)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		rec.Ref, rec.At.UTC(), rec.Session, rec.Tool, rec.WikiURL, rec.Title, string(rec.Arguments), rec.ErrorCode, rec.Duration.Milliseconds())
+	return err
+}
+
+// AuditRecordByRef returns the audit log entry with a reference, or nil
+// when there is none
+func (s *Store) AuditRecordByRef(ref string) (*AuditRecord, error) {
+	if ref == "" {
+		return nil, nil
+	}
+
+	rec := AuditRecord{Ref: ref}
+	var arguments string
+	var durationMS int64
+	err := s.db.QueryRow(`
+		SELECT at, session, tool, wiki_url, title, arguments, error_code, duration_ms
+		FROM audit_log WHERE ref = ? LIMIT 1`, ref).
+		Scan(&rec.At, &rec.Session, &rec.Tool, &rec.WikiURL, &rec.Title, &arguments, &rec.ErrorCode, &durationMS)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	rec.Arguments = json.RawMessage(arguments)
+	rec.Duration = time.Duration(durationMS) * time.Millisecond
+	return &rec, nil
+}
+
+// ToolUsage summarizes audit log entries for one tool
+type ToolUsage struct {
+	Tool         string  `json:"tool"`
+	Calls        int     `json:"calls"`
+	Errors       int     `json:"errors"`
+	AvgLatencyMS float64 `json:"avg_latency_ms"`
+}
+
+// UsageStats aggregates tool calls recorded since the given time
+func (s *Store) UsageStats(since time.Time) ([]ToolUsage, error) {
+	rows, err := s.db.Query(`
+		SELECT tool, COUNT(*), SUM(CASE WHEN error_code != '' THEN 1 ELSE 0 END), AVG(duration_ms)
+		FROM audit_log WHERE at >= ? GROUP BY tool ORDER BY COUNT(*) DESC`, since.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := make([]ToolUsage, 0)
+	for rows.Next() {
+		var u ToolUsage
+		if err := rows.Scan(&u.Tool, &u.Calls, &u.Errors, &u.AvgLatencyMS); err != nil {
+			return nil, err
+		}
+		stats = append(stats, u)
+	}
+	return stats, rows.Err()
+}
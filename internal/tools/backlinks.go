@@ -12,9 +12,10 @@ import (
 // GetBacklinks retrieves pages that link to a given page
 func GetBacklinks(ctx context.Context, client *wiki.Client, wikiURL, title string, limit int) (*wiki.BacklinksResponse, error) {
 	// Check cache
-	cacheKey := wiki.BacklinksCacheKey(wikiURL, title+":"+strconv.Itoa(limit))
-	if cached, ok := client.GetCache().Get(cacheKey); ok {
-		return cached.(*wiki.BacklinksResponse), nil
+	cacheKey := wiki.BacklinksCacheKey(wikiURL, title, limit)
+	var cached wiki.BacklinksResponse
+	if client.GetCachedResult(ctx, cacheKey, &cached) {
+		return &cached, nil
 	}
 
 	// Build API request
@@ -49,7 +50,7 @@ func GetBacklinks(ctx context.Context, client *wiki.Client, wikiURL, title strin
 	}
 
 	// Cache the result
-	client.GetCache().Set(cacheKey, backlinksResp, client.GetCacheTTL())
+	client.GetCache().SetJSON(cacheKey, backlinksResp, client.GetCacheTTL())
 
 	return backlinksResp, nil
 }
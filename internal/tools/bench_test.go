@@ -0,0 +1,70 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/yourusername/mediawiki-mcp/internal/perf"
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// benchSections builds the parse API section list of a long article: n
+// top-level sections, each with two subsections and one sub-subsection
+func benchSections(n int) []wiki.MWSection {
+	sections := make([]wiki.MWSection, 0, n*4)
+	offset := 2000
+	add := func(level int, number, line string) {
+		o := offset
+		sections = append(sections, wiki.MWSection{
+			TocLevel:   level,
+			Level:      strconv.Itoa(level + 1),
+			Line:       line,
+			Number:     number,
+			Index:      strconv.Itoa(len(sections) + 1),
+			ByteOffset: &o,
+			Anchor:     line,
+		})
+		offset += 1500
+	}
+
+	for i := 1; i <= n; i++ {
+		top := strconv.Itoa(i)
+		add(1, top, "Section "+top)
+		add(2, top+".1", "Background "+top)
+		add(3, top+".1.1", "Details "+top)
+		add(2, top+".2", "Aftermath "+top)
+	}
+	return sections
+}
+
+func BenchmarkBuildSectionsTree(b *testing.B) {
+	html, err := os.ReadFile("../wiki/testdata/article_lead.html")
+	if err != nil {
+		b.Fatal(err)
+	}
+	lead, err := wiki.ConvertHTML(context.Background(), string(html))
+	if err != nil {
+		b.Fatal(err)
+	}
+	sections := benchSections(100)
+	pageBytes := 2000 + len(sections)*1500
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if tree := buildSectionsTree(sections, "https://en.wikipedia.org", "Danube", lead, pageBytes); len(tree) != 101 {
+			b.Fatalf("got %d top-level sections, want 101", len(tree))
+		}
+	}
+}
+
+// TestPerformanceBudgets fails when an outline benchmark exceeds its budget.
+// Budgets allow about three times the time and a quarter more allocations
+// than measured when they were set.
+func TestPerformanceBudgets(t *testing.T) {
+	perf.Check(t, []perf.Benchmark{
+		{Name: "BuildSectionsTree", Run: BenchmarkBuildSectionsTree, Budget: perf.Budget{NsPerOp: 600_000, AllocsPerOp: 1_600}},
+	})
+}
@@ -0,0 +1,179 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// maxBlameListRequests bounds how much history wiki_blame lists; at 500
+// revisions per request, the most recent 10,000 revisions are examined
+const maxBlameListRequests = 20
+
+// SnippetNotFoundError represents an error when the text to blame isn't in
+// the current revision of the page
+type SnippetNotFoundError struct {
+	Title   string
+	Snippet string
+}
+
+func (e *SnippetNotFoundError) Error() string {
+	return fmt.Sprintf("the current revision of %q does not contain %q", e.Title, e.Snippet)
+}
+
+// BlameText finds the revision that introduced a snippet of wikitext into a
+// page, by binary search over its history for the oldest revision of the
+// run of revisions that still contain it. Differences in whitespace are
+// ignored. Revisions whose text was deleted count as not containing it.
+func BlameText(ctx context.Context, client *wiki.Client, wikiURL, title, snippet string) (*wiki.BlameResponse, error) {
+	// Check cache
+	cacheKey := wiki.BlameCacheKey(wikiURL, title, snippet)
+	var cached wiki.BlameResponse
+	if client.GetCachedResult(ctx, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	revisions, more, err := listRevisions(ctx, client, wikiURL, title)
+	if err != nil {
+		return nil, err
+	}
+
+	needle := collapseSpace(snippet)
+	texts := make(map[int]string)
+	contains := func(rev int) (bool, error) {
+		text, hidden, err := revisionText(ctx, client, wikiURL, revisions[rev].ID)
+		if err != nil {
+			return false, err
+		}
+		texts[rev] = text
+		return !hidden && strings.Contains(collapseSpace(text), needle), nil
+	}
+
+	// revisions is newest first: the newest must contain the snippet, and
+	// a virtual revision before the oldest doesn't
+	found, err := contains(0)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &SnippetNotFoundError{Title: title, Snippet: snippet}
+	}
+	newer, older := 0, len(revisions)
+	for older-newer > 1 {
+		mid := (newer + older) / 2
+		found, err := contains(mid)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			newer = mid
+		} else {
+			older = mid
+		}
+	}
+
+	rev := revisions[newer]
+	result := &wiki.BlameResponse{
+		Title:            title,
+		Snippet:          snippet,
+		Revision:         rev.RevisionInfo,
+		Comment:          rev.Comment,
+		RevisionsChecked: len(texts),
+		Truncated:        newer == len(revisions)-1 && more,
+	}
+	if rev.ParentID == 0 {
+		result.DiffMarkdown = addedDiff(texts[newer])
+	} else if result.DiffMarkdown, err = diffRevisions(ctx, client, wikiURL, rev.ParentID, rev.ID); err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	client.GetCache().SetJSON(cacheKey, result, client.GetCacheTTL())
+
+	return result, nil
+}
+
+// listedRevision is a revision in a page's history, without its content
+type listedRevision struct {
+	wiki.RevisionInfo
+	ParentID int
+	Comment  string
+}
+
+// listRevisions lists a page's revisions without their content, newest
+// first, and reports whether older ones were left out
+func listRevisions(ctx context.Context, client *wiki.Client, wikiURL, title string) ([]listedRevision, bool, error) {
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("titles", title)
+	params.Set("prop", "revisions")
+	params.Set("rvprop", "ids|timestamp|user|comment")
+	params.Set("rvlimit", "max")
+	params.Set("redirects", "1")
+
+	var revisions []listedRevision
+	for request := 0; request < maxBlameListRequests; request++ {
+		resp, err := client.MakeRequest(ctx, wikiURL, params)
+		if err != nil {
+			return nil, false, fmt.Errorf("list revisions: %w", err)
+		}
+		if resp.Query == nil || len(resp.Query.Pages) == 0 {
+			return nil, false, fmt.Errorf("no pages found")
+		}
+
+		page := resp.Query.Pages[0]
+		if page.Missing {
+			return nil, false, &wiki.APIError{Code: "missingtitle", Message: fmt.Sprintf("page %q does not exist", title)}
+		}
+		for _, rev := range page.Revisions {
+			revisions = append(revisions, listedRevision{
+				RevisionInfo: wiki.RevisionInfo{ID: rev.RevID, Timestamp: rev.Timestamp, User: rev.User},
+				ParentID:     rev.ParentID,
+				Comment:      rev.Comment,
+			})
+		}
+
+		rvcontinue := resp.Continue["rvcontinue"]
+		if rvcontinue == "" {
+			break
+		}
+		if request == maxBlameListRequests-1 {
+			return revisions, true, nil
+		}
+		params.Set("rvcontinue", rvcontinue)
+	}
+
+	if len(revisions) == 0 {
+		return nil, false, fmt.Errorf("no revisions found")
+	}
+	return revisions, false, nil
+}
+
+// revisionText returns the wikitext of a revision, or whether it was hidden
+func revisionText(ctx context.Context, client *wiki.Client, wikiURL string, revID int) (string, bool, error) {
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("revids", strconv.Itoa(revID))
+	params.Set("prop", "revisions")
+	params.Set("rvprop", "content")
+	params.Set("rvslots", "main")
+
+	resp, err := client.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return "", false, fmt.Errorf("get revision %d: %w", revID, err)
+	}
+	if resp.Query == nil || len(resp.Query.Pages) == 0 || len(resp.Query.Pages[0].Revisions) == 0 {
+		return "", false, &wiki.APIError{Code: "nosuchrevid", Message: fmt.Sprintf("revision %d does not exist", revID)}
+	}
+	rev := resp.Query.Pages[0].Revisions[0]
+	return rev.Text(), rev.Hidden(), nil
+}
+
+// collapseSpace replaces each run of whitespace with a single space
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
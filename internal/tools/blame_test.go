@@ -0,0 +1,73 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+func TestBlameText(t *testing.T) {
+	// Revision i+1 has texts[i]; the sentence arrives in revision 4, is
+	// reworded in 6 (only whitespace), and the rest are unrelated edits
+	var texts []string
+	for i := range 10 {
+		text := fmt.Sprintf("Intro %d.", i)
+		if i >= 3 {
+			text += " It was founded in 1901."
+		}
+		if i >= 5 {
+			text = strings.Replace(text, "founded in", "founded  in", 1)
+		}
+		texts = append(texts, text)
+	}
+
+	fetched := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case q.Get("action") == "compare":
+			fmt.Fprintf(w, `{"compare":{"fromrevid":%s,"torevid":%s,"body":"<tr><td>diff</td></tr>"}}`, q.Get("fromrev"), q.Get("torev"))
+		case q.Get("revids") != "":
+			fetched++
+			var id int
+			fmt.Sscan(q.Get("revids"), &id)
+			fmt.Fprintf(w, `{"query":{"pages":[{"title":"Town","revisions":[{"revid":%d,"slots":{"main":{"content":%q}}}]}]}}`, id, texts[id-1])
+		default:
+			var revisions []string
+			for id := len(texts); id >= 1; id-- {
+				revisions = append(revisions, fmt.Sprintf(`{"revid":%d,"parentid":%d,"timestamp":"2026-01-%02dT00:00:00Z","user":"U%d","comment":"edit %d"}`, id, id-1, id, id, id))
+			}
+			fmt.Fprintf(w, `{"query":{"pages":[{"pageid":1,"title":"Town","revisions":[%s]}]}}`, strings.Join(revisions, ","))
+		}
+	}))
+	defer srv.Close()
+	client := wiki.NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	ctx := context.Background()
+
+	result, err := BlameText(ctx, client, srv.URL, "Town", "It was founded in\n1901.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Revision.ID != 4 || result.Revision.User != "U4" || result.Comment != "edit 4" {
+		t.Errorf("revision = %+v (%q), want 4 by U4", result.Revision, result.Comment)
+	}
+	if result.Truncated || result.DiffMarkdown == "" {
+		t.Errorf("truncated = %v, diff = %q", result.Truncated, result.DiffMarkdown)
+	}
+	if result.RevisionsChecked != fetched || fetched > 5 {
+		t.Errorf("checked %d revisions (fetched %d), want at most 5", result.RevisionsChecked, fetched)
+	}
+
+	_, err = BlameText(ctx, client, srv.URL, "Town", "founded in 1902")
+	var notFound *SnippetNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("err = %v, want SnippetNotFoundError", err)
+	}
+}
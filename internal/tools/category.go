@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"fmt"
+	"math"
 	"net/url"
 	"strconv"
 	"strings"
@@ -10,12 +11,39 @@ import (
 	"github.com/yourusername/mediawiki-mcp/internal/wiki"
 )
 
+// CategoryOptions filters and orders category members. Empty fields use the
+// wiki's defaults: all member types, by sort key, ascending.
+type CategoryOptions struct {
+	MemberType string // "page", "subcat" or "file"
+	Sort       string // "sortkey" or "timestamp"
+	Direction  string // "asc" or "desc"
+
+	// All follows continuations to the end of the category, up to limit
+	// members. Progress, if set, is called after each batch with the
+	// members fetched so far and the number expected, which is limit when
+	// the wiki doesn't report the category's size.
+	All      bool
+	Progress func(fetched, total int)
+}
+
+// categoryTypeNamespaces are the namespaces holding each member type that
+// lives in a single namespace
+var categoryTypeNamespaces = map[string]string{
+	"file":   "6",
+	"subcat": "14",
+}
+
+// maxCategoryRequests bounds the requests made to fill a page of members
+// when some have to be filtered out client-side
+const maxCategoryRequests = 5
+
 // GetCategory retrieves pages in a category
-func GetCategory(ctx context.Context, client *wiki.Client, wikiURL, category string, limit int) (*wiki.CategoryResponse, error) {
+func GetCategory(ctx context.Context, client *wiki.Client, wikiURL, category string, limit int, opts CategoryOptions) (*wiki.CategoryResponse, error) {
 	// Check cache
-	cacheKey := wiki.CategoryCacheKey(wikiURL, category+":"+strconv.Itoa(limit))
-	if cached, ok := client.GetCache().Get(cacheKey); ok {
-		return cached.(*wiki.CategoryResponse), nil
+	cacheKey := wiki.CategoryCacheKey(wikiURL, category, limit, opts.MemberType, opts.Sort, opts.Direction, opts.All)
+	var cached wiki.CategoryResponse
+	if client.GetCachedResult(ctx, cacheKey, &cached) {
+		return &cached, nil
 	}
 
 	// Ensure category has "Category:" prefix
@@ -30,75 +58,161 @@ func GetCategory(ctx context.Context, client *wiki.Client, wikiURL, category str
 	params.Set("cmtitle", category)
 	params.Set("cmlimit", strconv.Itoa(limit))
 	params.Set("cmprop", "title|type")
+	if opts.Sort == "timestamp" {
+		params.Set("cmsort", "timestamp")
+		params.Set("cmprop", "title|type|timestamp")
+		// cmtype is ignored when sorting by timestamp, so filter files and
+		// subcategories by namespace; pages are filtered below
+		if ns, ok := categoryTypeNamespaces[opts.MemberType]; ok {
+			params.Set("cmnamespace", ns)
+		}
+	} else {
+		if opts.Sort != "" {
+			params.Set("cmsort", opts.Sort)
+		}
+		if opts.MemberType != "" {
+			params.Set("cmtype", opts.MemberType)
+		}
+	}
+	if opts.Direction != "" {
+		params.Set("cmdir", opts.Direction)
+	}
 
-	resp, err := client.MakeRequest(ctx, wikiURL, params)
-	if err != nil {
-		return nil, fmt.Errorf("get category: %w", err)
+	// Get parent categories and the true member counts. Non-fatal: without
+	// them the total is just what was returned.
+	parents, counts, infoErr := getCategoryInfo(ctx, client, wikiURL, category)
+	if infoErr != nil {
+		wiki.AddWarning(ctx, "parent_categories", infoErr)
+	}
+	total := categoryTotal(counts, opts.MemberType)
+	expected := limit
+	if total >= 0 {
+		expected = min(total, limit)
 	}
 
-	if resp.Query == nil {
-		return nil, fmt.Errorf("empty query response")
+	// Build members list. Continuations are followed only while filtering
+	// out members of other types leaves the page short, unless all members
+	// were asked for.
+	maxRequests := maxCategoryRequests
+	if opts.All {
+		maxRequests = math.MaxInt
+		params.Set("cmlimit", "max")
 	}
+	members := make([]wiki.CategoryMember, 0, expected)
+	complete := false
+	for request := 0; request < maxRequests && len(members) < limit; request++ {
+		resp, err := client.MakeRequest(ctx, wikiURL, params)
+		if err != nil {
+			return nil, fmt.Errorf("get category: %w", err)
+		}
 
-	// Build members list
-	members := make([]wiki.CategoryMember, 0, len(resp.Query.Categorymembers))
-	for _, member := range resp.Query.Categorymembers {
-		memberType := "page"
-		if member.Type == "subcat" {
-			memberType = "subcat"
+		if resp.Query == nil {
+			return nil, fmt.Errorf("empty query response")
 		}
 
-		members = append(members, wiki.CategoryMember{
-			Title: member.Title,
-			Type:  memberType,
-		})
-	}
+		read := 0
+		for _, member := range resp.Query.Categorymembers {
+			read++
+			memberType := "page"
+			if member.Type == "subcat" || member.Type == "file" {
+				memberType = member.Type
+			}
+			if opts.MemberType != "" && memberType != opts.MemberType {
+				continue
+			}
+
+			members = append(members, wiki.CategoryMember{
+				Title:     member.Title,
+				Type:      memberType,
+				Timestamp: member.Timestamp,
+			})
+			if len(members) == limit {
+				break
+			}
+		}
+		if opts.Progress != nil {
+			opts.Progress(len(members), max(expected, len(members)))
+		}
 
-	// Get parent categories
-	parentCategories, err := getParentCategories(ctx, client, wikiURL, category)
-	if err != nil {
-		// Non-fatal, continue without parent categories
-		parentCategories = []string{}
+		cmcontinue := resp.Continue["cmcontinue"]
+		if cmcontinue == "" {
+			complete = read == len(resp.Query.Categorymembers)
+			break
+		}
+		params.Set("cmcontinue", cmcontinue)
 	}
 
 	// Build response
 	categoryResp := &wiki.CategoryResponse{
 		Category:         strings.TrimPrefix(category, "Category:"),
 		Members:          members,
-		ParentCategories: parentCategories,
-		TotalMembers:     len(members),
+		ParentCategories: []string{},
+		TotalMembers:     max(total, len(members)),
+		Truncated:        opts.All && !complete,
+	}
+	if infoErr == nil {
+		categoryResp.ParentCategories = parents
+		categoryResp.Counts = counts
 	}
 
-	// Cache the result
-	client.GetCache().Set(cacheKey, categoryResp, client.GetCacheTTL())
+	// Cache the result unless parts of it are missing
+	if infoErr == nil {
+		client.GetCache().SetJSON(cacheKey, categoryResp, client.GetCacheTTL())
+	}
 
 	return categoryResp, nil
 }
 
-// getParentCategories retrieves parent categories for a given category
-func getParentCategories(ctx context.Context, client *wiki.Client, wikiURL, category string) ([]string, error) {
+// categoryTotal returns the number of members of a type from a category's
+// counts, or -1 if they are unknown
+func categoryTotal(counts *wiki.CategoryCounts, memberType string) int {
+	if counts == nil {
+		return -1
+	}
+	switch memberType {
+	case "page":
+		return counts.Pages
+	case "subcat":
+		return counts.Subcats
+	case "file":
+		return counts.Files
+	}
+	return counts.Pages + counts.Subcats + counts.Files
+}
+
+// getCategoryInfo retrieves parent categories for a given category and its
+// member counts, which are nil when the wiki doesn't report them
+func getCategoryInfo(ctx context.Context, client *wiki.Client, wikiURL, category string) ([]string, *wiki.CategoryCounts, error) {
 	params := url.Values{}
 	params.Set("action", "query")
 	params.Set("titles", category)
-	params.Set("prop", "categories")
+	params.Set("prop", "categories|categoryinfo")
 	params.Set("cllimit", "10")
 
 	resp, err := client.MakeRequest(ctx, wikiURL, params)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if resp.Query == nil || len(resp.Query.Pages) == 0 {
-		return []string{}, nil
+		return []string{}, nil, nil
 	}
 
 	parents := make([]string, 0)
+	var counts *wiki.CategoryCounts
 	for _, page := range resp.Query.Pages {
 		for _, cat := range page.Categories {
 			catName := strings.TrimPrefix(cat.Title, "Category:")
 			parents = append(parents, catName)
 		}
+		if info := page.CategoryInfo; info != nil {
+			counts = &wiki.CategoryCounts{
+				Pages:   info.Pages,
+				Subcats: info.Subcats,
+				Files:   info.Files,
+			}
+		}
 	}
 
-	return parents, nil
+	return parents, counts, nil
 }
@@ -0,0 +1,78 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// newCategoryWiki serves a category of n pages, three per request
+func newCategoryWiki(t *testing.T, n int) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("list") != "categorymembers" {
+			fmt.Fprintf(w, `{"query":{"pages":[{"title":"Category:Test","categoryinfo":{"size":%d,"pages":%d}}]}}`, n, n)
+			return
+		}
+
+		start, _ := strconv.Atoi(q.Get("cmcontinue"))
+		end := min(start+3, n)
+		members := make([]string, 0, end-start)
+		for i := start; i < end; i++ {
+			members = append(members, fmt.Sprintf(`{"title":"Page %d","type":"page"}`, i))
+		}
+		next := ""
+		if end < n {
+			next = fmt.Sprintf(`,"continue":{"cmcontinue":"%d"}`, end)
+		}
+		fmt.Fprintf(w, `{"query":{"categorymembers":[%s]}%s}`, strings.Join(members, ","), next)
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestCategoryAll(t *testing.T) {
+	wikiURL := newCategoryWiki(t, 10)
+	client := wiki.NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	ctx := context.Background()
+
+	var progress []string
+	all, err := GetCategory(ctx, client, wikiURL, "Test", 100, CategoryOptions{
+		All:      true,
+		Progress: func(fetched, total int) { progress = append(progress, fmt.Sprintf("%d/%d", fetched, total)) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all.Members) != 10 || all.Truncated {
+		t.Errorf("got %d members, truncated %v; want all 10", len(all.Members), all.Truncated)
+	}
+	if got := strings.Join(progress, " "); got != "3/10 6/10 9/10 10/10" {
+		t.Errorf("progress = %s, want 3/10 6/10 9/10 10/10", got)
+	}
+
+	capped, err := GetCategory(ctx, client, wikiURL, "Test", 7, CategoryOptions{All: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(capped.Members) != 7 || !capped.Truncated || capped.TotalMembers != 10 {
+		t.Errorf("got %d of %d members, truncated %v; want 7 of 10, truncated", len(capped.Members), capped.TotalMembers, capped.Truncated)
+	}
+
+	// Without all, a page of members stops at the first batch
+	page, err := GetCategory(ctx, client, wikiURL, "Test", 3, CategoryOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Members) != 3 || page.Truncated {
+		t.Errorf("got %d members, truncated %v; want 3, not truncated", len(page.Members), page.Truncated)
+	}
+}
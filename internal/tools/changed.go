@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// Reasons given by HasChanged
+const (
+	ChangeSameRevision     = "same_revision"     // the page is still at the given revision
+	ChangeNewRevision      = "new_revision"      // the page has been edited; no hash was given to compare
+	ChangeContentChanged   = "content_changed"   // the content's hash differs
+	ChangeContentUnchanged = "content_unchanged" // the page was edited, but not this content
+	ChangePageMissing      = "page_missing"      // the page no longer exists
+)
+
+// ChangeOptions describes a copy of a page, or of one of its sections,
+// fetched earlier. At least one of RevID and Hash is needed.
+type ChangeOptions struct {
+	RevID    int    // source.revid of the copy
+	Hash     string // content_hash of the copy
+	Section  *int   // section index, when the copy is a section
+	MaxBytes int    // size limit for fetching the full page, as for GetPageFull
+}
+
+// ChangeCheck reports whether content has changed since it was fetched
+type ChangeCheck struct {
+	Title        string `json:"title"`
+	Section      *int   `json:"section_index,omitempty"`
+	Changed      bool   `json:"changed"`
+	Reason       string `json:"reason"`
+	CurrentRevID int    `json:"current_revid"`
+	ContentHash  string `json:"content_hash,omitempty"` // current hash, when the content was fetched to compare
+}
+
+// HasChanged reports whether a page or section differs from a copy fetched
+// earlier. An unchanged revision ID is answered with a single revision
+// lookup; the content is only fetched and hashed when the page was edited
+// and a hash was given, to tell whether the edit touched it.
+func HasChanged(ctx context.Context, client *wiki.Client, wikiURL, title string, opts ChangeOptions) (*ChangeCheck, error) {
+	revisions, err := GetLatestRevisions(ctx, client, wikiURL, []string{title})
+	if err != nil {
+		return nil, fmt.Errorf("check for changes: %w", err)
+	}
+
+	check := &ChangeCheck{Title: title, Section: opts.Section, CurrentRevID: revisions[title]}
+	switch {
+	case check.CurrentRevID == 0:
+		check.Changed, check.Reason = true, ChangePageMissing
+		return check, nil
+	case opts.RevID == check.CurrentRevID:
+		check.Reason = ChangeSameRevision
+		return check, nil
+	case opts.Hash == "":
+		check.Changed, check.Reason = true, ChangeNewRevision
+		return check, nil
+	}
+
+	hash, err := currentContentHash(ctx, client, wikiURL, title, opts, check.CurrentRevID)
+	if err != nil {
+		return nil, err
+	}
+	check.ContentHash = hash
+	check.Changed = hash != opts.Hash
+	check.Reason = ChangeContentUnchanged
+	if check.Changed {
+		check.Reason = ChangeContentChanged
+	}
+	return check, nil
+}
+
+// currentContentHash returns the hash of the content at revID, dropping
+// cached copies of the page rendered from an older revision
+func currentContentHash(ctx context.Context, client *wiki.Client, wikiURL, title string, opts ChangeOptions, revID int) (string, error) {
+	fetch := func() (string, *wiki.Provenance, error) {
+		if opts.Section != nil {
+			section, err := GetPageSection(ctx, client, wikiURL, title, *opts.Section, SectionOptions{})
+			if err != nil {
+				return "", nil, err
+			}
+			return section.ContentHash, section.Source, nil
+		}
+		full, err := GetPageFull(ctx, client, wikiURL, title, opts.MaxBytes)
+		if err != nil {
+			return "", nil, err
+		}
+		return full.ContentHash, full.Source, nil
+	}
+
+	hash, source, err := fetch()
+	if err != nil {
+		return "", err
+	}
+	if hash == "" || source == nil || source.RevID < revID {
+		client.GetCache().InvalidatePage(wikiURL, title)
+		hash, _, err = fetch()
+	}
+	return hash, err
+}
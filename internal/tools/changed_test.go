@@ -0,0 +1,65 @@
+package tools
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+func TestHasChanged(t *testing.T) {
+	client, wikiURL, requests := newTestWiki(t)
+	ctx := context.Background()
+	section := 1
+
+	current, err := GetPageSection(ctx, client, wikiURL, "Test", section, SectionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		opts        ChangeOptions
+		wantChanged bool
+		wantReason  string
+	}{
+		{"same revision", ChangeOptions{RevID: 42}, false, ChangeSameRevision},
+		{"new revision", ChangeOptions{RevID: 41}, true, ChangeNewRevision},
+		{"edit elsewhere", ChangeOptions{RevID: 41, Hash: current.ContentHash, Section: &section}, false, ChangeContentUnchanged},
+		{"edited", ChangeOptions{RevID: 41, Hash: "0123", Section: &section}, true, ChangeContentChanged},
+		{"hash only", ChangeOptions{Hash: current.ContentHash, Section: &section}, false, ChangeContentUnchanged},
+	}
+	for _, tt := range tests {
+		check, err := HasChanged(ctx, client, wikiURL, "Test", tt.opts)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if check.Changed != tt.wantChanged || check.Reason != tt.wantReason || check.CurrentRevID != 42 {
+			t.Errorf("%s: got changed=%v reason=%s revid=%d, want changed=%v reason=%s revid=42",
+				tt.name, check.Changed, check.Reason, check.CurrentRevID, tt.wantChanged, tt.wantReason)
+		}
+	}
+
+	// Matching revisions are answered without fetching content
+	before := atomic.LoadInt64(requests)
+	if _, err := HasChanged(ctx, client, wikiURL, "Test", ChangeOptions{RevID: 42, Hash: "0123"}); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt64(requests) - before; n != 1 {
+		t.Errorf("same revision check made %d requests, want 1", n)
+	}
+
+	// A cached copy from an older revision is not compared
+	stale := *current
+	stale.ContentHash = "stale"
+	stale.Source = &wiki.Provenance{RevID: 41}
+	client.GetCache().SetJSON(wiki.SectionCacheKey(wikiURL, "Test", section), &stale, client.GetCacheTTL())
+	check, err := HasChanged(ctx, client, wikiURL, "Test", ChangeOptions{RevID: 41, Hash: current.ContentHash, Section: &section})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if check.Changed || check.ContentHash != current.ContentHash {
+		t.Errorf("compared against the stale cached copy: %+v", check)
+	}
+}
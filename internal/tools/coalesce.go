@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// inflight holds the fetches in progress, keyed by the cache key of the
+// result each will cache
+var inflight singleflight.Group
+
+// fetched is a shared fetch's result, serialized before the caller that
+// started the fetch can modify it, with the warnings raised making it
+type fetched struct {
+	value    any
+	data     []byte
+	warnings []wiki.Warning
+}
+
+// coalesce runs fetch once for concurrent calls with the same key, the
+// cache key of the result fetch caches. Composed tools look up the same
+// results as direct calls, such as the outline a section fetch validates
+// its index against, so a section call racing an outline call for the same
+// title makes one set of upstream requests instead of two.
+//
+// Calls that join another's fetch get their own copy of the result, made
+// as the cache makes them, and its warnings. A caller whose ctx ends stops
+// waiting; if the fetch it joined failed because the caller that started it
+// went away, it fetches on its own. A panicking fetch fails every caller
+// with an error.
+func coalesce[T any](ctx context.Context, key string, fetch func(ctx context.Context) (*T, error)) (*T, error) {
+	started := false
+	ch := inflight.DoChan(key, func() (result any, err error) {
+		started = true
+		// The fetch runs on its own goroutine, where a panic would take
+		// the process down rather than fail the call
+		defer func() {
+			if r := recover(); r != nil {
+				result, err = fetched{}, fmt.Errorf("fetch %s: panic: %v", key, r)
+			}
+		}()
+		ctx := wiki.WithWarnings(ctx)
+		value, err := fetch(ctx)
+		if err != nil {
+			return fetched{}, err
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fetched{}, err
+		}
+		return fetched{value: value, data: data, warnings: wiki.Warnings(ctx)}, nil
+	})
+
+	var res singleflight.Result
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res = <-ch:
+	}
+	if started {
+		// Warnings reached ctx directly
+		value, _ := res.Val.(fetched).value.(*T)
+		return value, res.Err
+	}
+
+	if res.Err != nil {
+		if errors.Is(res.Err, context.Canceled) || errors.Is(res.Err, context.DeadlineExceeded) {
+			return fetch(ctx)
+		}
+		return nil, res.Err
+	}
+	shared := res.Val.(fetched)
+	value := new(T)
+	if err := json.Unmarshal(shared.data, value); err != nil {
+		return nil, err
+	}
+	wiki.AddWarnings(ctx, shared.warnings)
+	return value, nil
+}
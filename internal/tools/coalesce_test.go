@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+func TestOutlineAndSectionShareFetches(t *testing.T) {
+	var outlines int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case q.Get("action") == "parse" && q.Get("prop") == "sections|categories|links":
+			atomic.AddInt64(&outlines, 1)
+			time.Sleep(100 * time.Millisecond) // long enough for the calls to overlap
+			fmt.Fprint(w, `{"parse":{"title":"Test","revid":42,"sections":[
+				{"toclevel":1,"level":"2","line":"History","number":"1","index":"1","byteoffset":5}
+			]}}`)
+		case q.Get("action") == "parse":
+			fmt.Fprintf(w, `{"parse":{"title":"Test","revid":42,"text":{"*":"<p>Section %s text</p>"}}}`, q.Get("section"))
+		default:
+			fmt.Fprint(w, `{"query":{"pages":[{"title":"Test","revisions":[{"slots":{"main":{"content":"Lead\n== History ==\nText"}}}]}]}}`)
+		}
+	}))
+	defer srv.Close()
+	client := wiki.NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	var outline *wiki.PageOutline
+	var sections [2]*wiki.PageSection
+	var errs [3]error
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		outline, errs[0] = GetPageOutline(ctx, client, srv.URL, "Test", OutlineOptions{})
+	}()
+	for i := range sections {
+		go func() {
+			defer wg.Done()
+			sections[i], errs[i+1] = GetPageSection(ctx, client, srv.URL, "Test", 1, SectionOptions{})
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs[:]...); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt64(&outlines); n != 1 {
+		t.Errorf("outline fetched %d times, want once for all three calls", n)
+	}
+	if outline.Title != "Test" || sections[0].Section.Title != "History" || sections[1].Section.Title != "History" {
+		t.Errorf("unexpected results: %+v %+v %+v", outline, sections[0].Section, sections[1].Section)
+	}
+	if sections[0] == sections[1] {
+		t.Error("concurrent section calls share one result value")
+	}
+}
+
+func TestCoalesceAfterCancelledStart(t *testing.T) {
+	release := make(chan struct{})
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderDone := make(chan error)
+	go func() {
+		_, err := coalesce(leaderCtx, "key", func(ctx context.Context) (*string, error) {
+			<-release
+			return nil, ctx.Err()
+		})
+		leaderDone <- err
+	}()
+	time.Sleep(20 * time.Millisecond) // let the first call start the fetch
+
+	followerDone := make(chan error)
+	var got *string
+	go func() {
+		var err error
+		got, err = coalesce(context.Background(), "key", func(ctx context.Context) (*string, error) {
+			value := "fetched"
+			return &value, nil
+		})
+		followerDone <- err
+	}()
+	time.Sleep(20 * time.Millisecond) // let the second call join it
+
+	cancel()
+	if err := <-leaderDone; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled caller got %v", err)
+	}
+	close(release)
+	if err := <-followerDone; err != nil || got == nil || *got != "fetched" {
+		t.Errorf("joined caller got %v, %v; want its own fetch", got, err)
+	}
+}
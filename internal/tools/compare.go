@@ -2,30 +2,64 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/yourusername/mediawiki-mcp/internal/wiki"
 )
 
-// CompareRevisions compares two revisions of a page
-func CompareRevisions(ctx context.Context, client *wiki.Client, wikiURL, title, fromRev, toRev string) (*wiki.CompareResponse, error) {
-	// Build API request
-	params := url.Values{}
-	params.Set("action", "compare")
-	params.Set("fromtitle", title)
+// revisionTimeLayouts are the timestamp forms a revision can be given as.
+// A date alone means the end of that day, so it selects the version the
+// page had on that day.
+var revisionTimeLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02T15:04", "2006-01-02 15:04"}
 
-	// Handle special revision specifiers
-	if fromRev == "prev" || fromRev == "current" {
-		params.Set("fromrelative", fromRev)
-	} else {
-		params.Set("fromrev", fromRev)
+// CompareRevisions compares two revisions of a page. Each revision is a
+// revision ID, a timestamp or date, whose revision is the page's version at
+// that time, or relative: fromRev may be "prev" (the revision before toRev)
+// or "current", and toRev "prev" or "next" (relative to fromRev) or
+// "current". "cur" is the same as "current".
+func CompareRevisions(ctx context.Context, client *wiki.Client, wikiURL, title, fromRev, toRev string) (*wiki.CompareResponse, error) {
+	from, err := resolveRevision(ctx, client, wikiURL, title, fromRev)
+	if err != nil {
+		return nil, err
+	}
+	to, err := resolveRevision(ctx, client, wikiURL, title, toRev)
+	if err != nil {
+		return nil, err
 	}
 
-	if toRev == "prev" || toRev == "current" || toRev == "next" {
-		params.Set("torelative", toRev)
-	} else {
-		params.Set("torev", toRev)
+	// Build API request. The API only has relative "to" revisions: a
+	// "from" of prev compares the "to" revision with the one before it,
+	// which the API shows the right way round.
+	params := url.Values{}
+	params.Set("action", "compare")
+	switch {
+	case from == "prev" && (to == "prev" || to == "next"):
+		return nil, fmt.Errorf("from_revision %q needs a to_revision that is 'current', a revision ID, or a timestamp", fromRev)
+	case from == "prev" && to == "current":
+		params.Set("fromtitle", title)
+		params.Set("torelative", "prev")
+	case from == "prev":
+		params.Set("fromrev", to)
+		params.Set("torelative", "prev")
+	default:
+		if from == "current" {
+			params.Set("fromtitle", title)
+		} else {
+			params.Set("fromrev", from)
+		}
+		switch to {
+		case "prev", "next":
+			params.Set("torelative", to)
+		case "current":
+			params.Set("torelative", "cur")
+		default:
+			params.Set("torev", to)
+		}
 	}
 
 	params.Set("prop", "diff|ids|timestamp|user|comment")
@@ -40,24 +74,189 @@ func CompareRevisions(ctx context.Context, client *wiki.Client, wikiURL, title,
 	}
 
 	// Convert HTML diff to markdown (simplified)
-	diffMarkdown, err := wiki.HTMLToMarkdown(resp.Compare.Body)
+	diffMarkdown, err := wiki.HTMLToMarkdown(ctx, resp.Compare.DiffBody())
+	if ctx.Err() != nil {
+		return nil, err
+	}
 	if err != nil {
-		diffMarkdown = resp.Compare.Body // Fallback to raw HTML
+		diffMarkdown = resp.Compare.DiffBody() // Fallback to raw HTML
 	}
 
 	// Build response
+	stats := wiki.ParseDiffStats(resp.Compare.DiffBody())
 	compareResp := &wiki.CompareResponse{
 		Title: title,
 		From: wiki.RevisionInfo{
-			ID: resp.Compare.FromRevID,
-			// Note: timestamp and user would need additional API call
+			ID:        resp.Compare.FromRevID,
+			Timestamp: resp.Compare.FromTimestamp,
+			User:      resp.Compare.FromUser,
 		},
 		To: wiki.RevisionInfo{
-			ID: resp.Compare.ToRevID,
+			ID:        resp.Compare.ToRevID,
+			Timestamp: resp.Compare.ToTimestamp,
+			User:      resp.Compare.ToUser,
 		},
-		DiffSummary:  "Changes between revisions",
+		DiffSummary:  stats.Summary(),
+		DiffStats:    stats,
 		DiffMarkdown: diffMarkdown,
 	}
 
 	return compareResp, nil
 }
+
+// resolveRevision turns a revision as CompareRevisions takes it into a
+// revision ID or one of "prev", "next", and "current"
+func resolveRevision(ctx context.Context, client *wiki.Client, wikiURL, title, rev string) (string, error) {
+	rev = strings.TrimSpace(rev)
+	switch strings.ToLower(rev) {
+	case "prev", "next", "current":
+		return strings.ToLower(rev), nil
+	case "cur":
+		return "current", nil
+	}
+	if _, err := strconv.Atoi(rev); err == nil {
+		return rev, nil
+	}
+
+	at, ok := parseRevisionTime(rev)
+	if !ok {
+		return "", fmt.Errorf("revision %q: want a revision ID, a timestamp such as 2024-01-01 or 2024-01-01T12:00:00Z, 'prev', 'next', or 'current'", rev)
+	}
+	revID, err := revisionAsOf(ctx, client, wikiURL, title, at)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(revID), nil
+}
+
+// parseRevisionTime parses a timestamp or date, in UTC unless it has a zone
+func parseRevisionTime(s string) (time.Time, bool) {
+	if day, err := time.Parse("2006-01-02", s); err == nil {
+		return day.Add(24*time.Hour - time.Second), true
+	}
+	for _, layout := range revisionTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// revisionAsOf returns the ID of the revision that was current at a time:
+// the newest one made at or before it
+func revisionAsOf(ctx context.Context, client *wiki.Client, wikiURL, title string, at time.Time) (int, error) {
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("titles", title)
+	params.Set("prop", "revisions")
+	params.Set("rvprop", "ids|timestamp")
+	params.Set("rvstart", at.UTC().Format(time.RFC3339))
+	params.Set("rvdir", "older")
+	params.Set("rvlimit", "1")
+	params.Set("redirects", "1")
+
+	resp, err := client.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return 0, fmt.Errorf("find revision as of %s: %w", at.UTC().Format(time.RFC3339), err)
+	}
+	if resp.Query == nil || len(resp.Query.Pages) == 0 {
+		return 0, fmt.Errorf("no pages found")
+	}
+
+	page := resp.Query.Pages[0]
+	if page.Missing {
+		return 0, &wiki.APIError{Code: "missingtitle", Message: fmt.Sprintf("page %q does not exist", title)}
+	}
+	if len(page.Revisions) == 0 {
+		return 0, &wiki.APIError{Code: "nosuchrevid", Message: fmt.Sprintf("page %q has no revision at or before %s", title, at.UTC().Format(time.RFC3339))}
+	}
+	return page.Revisions[0].RevID, nil
+}
+
+// diffRevisions renders the diff between two revisions as Markdown
+func diffRevisions(ctx context.Context, client *wiki.Client, wikiURL string, fromRev, toRev int) (string, error) {
+	params := url.Values{}
+	params.Set("action", "compare")
+	params.Set("fromrev", strconv.Itoa(fromRev))
+	params.Set("torev", strconv.Itoa(toRev))
+	params.Set("prop", "diff")
+
+	resp, err := client.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return "", fmt.Errorf("compare revisions: %w", err)
+	}
+
+	if resp.Compare == nil {
+		return "", fmt.Errorf("empty compare response")
+	}
+
+	diffMarkdown, err := wiki.HTMLToMarkdown(ctx, resp.Compare.DiffBody())
+	if ctx.Err() != nil {
+		return "", err
+	}
+	if err != nil {
+		return resp.Compare.DiffBody(), nil
+	}
+	return diffMarkdown, nil
+}
+
+// DiffProposedEdit renders the diff an edit would make to the current
+// revision of a page, as Markdown, without saving it. New pages and new
+// sections have nothing to compare against, so their text is shown as added.
+// An undo is shown as the undone revisions in reverse, which is what it
+// does when later edits left their lines alone.
+func DiffProposedEdit(ctx context.Context, client *wiki.Client, wikiURL string, edit wiki.EditParams) (string, error) {
+	if edit.Undo > 0 {
+		return diffRevisions(ctx, client, wikiURL, edit.Undo, edit.UndoAfter)
+	}
+	if edit.Section == "new" {
+		if edit.SectionTitle != "" {
+			return addedDiff("== " + edit.SectionTitle + " ==\n" + edit.Text), nil
+		}
+		return addedDiff(edit.Text), nil
+	}
+
+	params := url.Values{}
+	params.Set("action", "compare")
+	params.Set("fromtitle", edit.Title)
+	params.Set("torelative", "cur")
+	params.Set("toslots", "main")
+	params.Set("totext-main", edit.Text)
+	params.Set("tocontentmodel-main", "wikitext")
+	if edit.Section != "" {
+		params.Set("tosection-main", edit.Section)
+	}
+	params.Set("prop", "diff")
+
+	// The proposed text can exceed URL length limits
+	resp, err := client.MakePostRequest(ctx, wikiURL, params)
+	var apiErr *wiki.APIError
+	if errors.As(err, &apiErr) && apiErr.Code == "missingtitle" {
+		return addedDiff(edit.Text), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("diff proposed edit: %w", err)
+	}
+
+	if resp.Compare == nil {
+		return "", fmt.Errorf("empty compare response")
+	}
+
+	diffMarkdown, err := wiki.HTMLToMarkdown(ctx, resp.Compare.DiffBody())
+	if ctx.Err() != nil {
+		return "", err
+	}
+	if err != nil {
+		return resp.Compare.DiffBody(), nil
+	}
+	return diffMarkdown, nil
+}
+
+// addedDiff marks every line of text as added
+func addedDiff(text string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = "+ " + line
+	}
+	return strings.Join(lines, "\n")
+}
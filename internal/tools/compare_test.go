@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+func TestCompareRevisions(t *testing.T) {
+	var compared string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch q.Get("action") {
+		case "query":
+			// Revision 7 was made on 2024-01-01, 9 later
+			if q.Get("rvstart") == "2024-01-01T23:59:59Z" && q.Get("rvdir") == "older" && q.Get("rvlimit") == "1" {
+				fmt.Fprint(w, `{"query":{"pages":[{"pageid":1,"ns":0,"title":"Town","revisions":[{"revid":7,"timestamp":"2024-01-01T10:00:00Z"}]}]}}`)
+			} else {
+				fmt.Fprint(w, `{"query":{"pages":[{"pageid":1,"ns":0,"title":"Town"}]}}`)
+			}
+		case "compare":
+			compared = fmt.Sprintf("fromtitle=%s fromrev=%s torev=%s torelative=%s", q.Get("fromtitle"), q.Get("fromrev"), q.Get("torev"), q.Get("torelative"))
+			fmt.Fprint(w, `{"compare":{"fromrevid":7,"fromtimestamp":"2024-01-01T10:00:00Z","fromuser":"A","torevid":9,"totimestamp":"2024-03-01T10:00:00Z","touser":"B","body":""}}`)
+		}
+	}))
+	defer srv.Close()
+	client := wiki.NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	ctx := context.Background()
+
+	for _, tc := range []struct{ from, to, want string }{
+		{"prev", "current", "fromtitle=Town fromrev= torev= torelative=prev"},
+		{"2024-01-01", "cur", "fromtitle= fromrev=7 torev= torelative=cur"},
+		{"prev", "9", "fromtitle= fromrev=9 torev= torelative=prev"},
+		{"5", "2024-01-01", "fromtitle= fromrev=5 torev=7 torelative="},
+		{"current", "prev", "fromtitle=Town fromrev= torev= torelative=prev"},
+	} {
+		result, err := CompareRevisions(ctx, client, srv.URL, "Town", tc.from, tc.to)
+		if err != nil {
+			t.Errorf("%s..%s: %v", tc.from, tc.to, err)
+			continue
+		}
+		if compared != tc.want {
+			t.Errorf("%s..%s: compared %s, want %s", tc.from, tc.to, compared, tc.want)
+		}
+		if result.From.ID != 7 || result.From.User != "A" || result.To.Timestamp.IsZero() {
+			t.Errorf("%s..%s: result = %+v", tc.from, tc.to, result)
+		}
+	}
+
+	if _, err := CompareRevisions(ctx, client, srv.URL, "Town", "2020-05-01", "current"); err == nil {
+		t.Error("compare from before the page existed succeeded")
+	}
+	if _, err := CompareRevisions(ctx, client, srv.URL, "Town", "prev", "next"); err == nil {
+		t.Error("compare prev..next succeeded")
+	}
+}
@@ -0,0 +1,95 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// GetPageCoordinates retrieves a page's coordinates, from GeoData where the
+// wiki has it and otherwise from the page's {{coord}} templates
+func GetPageCoordinates(ctx context.Context, client *wiki.Client, wikiURL, title string) (*wiki.CoordinatesResponse, error) {
+	// Check cache
+	cacheKey := wiki.CoordinatesCacheKey(wikiURL, title)
+	var cached wiki.CoordinatesResponse
+	if client.GetCachedResult(ctx, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	coords, err := getGeoDataCoordinates(ctx, client, wikiURL, title)
+	if err != nil {
+		return nil, fmt.Errorf("get coordinates: %w", err)
+	}
+	if len(coords) == 0 {
+		wikitext, err := getPageWikitext(ctx, client, wikiURL, title)
+		if err != nil {
+			return nil, fmt.Errorf("get coordinates: %w", err)
+		}
+		coords = wiki.ExtractCoordinates(wikitext)
+	}
+
+	result := &wiki.CoordinatesResponse{
+		Title:       title,
+		Coordinates: coords,
+	}
+
+	// Cache the result
+	client.GetCache().SetJSON(cacheKey, result, client.GetCacheTTL())
+
+	return result, nil
+}
+
+// getGeoDataCoordinates retrieves the coordinates GeoData indexed for a
+// page, or none when the wiki lacks the GeoData extension
+func getGeoDataCoordinates(ctx context.Context, client *wiki.Client, wikiURL, title string) ([]wiki.Coordinate, error) {
+	caps, err := client.GetCapabilities(ctx, wikiURL)
+	if err != nil || !caps.HasExtension("GeoData") {
+		return nil, nil
+	}
+
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("titles", title)
+	params.Set("prop", "coordinates")
+	params.Set("coprop", "type|name|globe")
+	params.Set("coprimary", "all")
+	params.Set("colimit", "max")
+	params.Set("redirects", "1")
+
+	resp, err := client.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Query == nil {
+		return nil, fmt.Errorf("empty query response")
+	}
+
+	coords := make([]wiki.Coordinate, 0)
+	for _, page := range resp.Query.Pages {
+		for _, c := range page.Coordinates {
+			coords = append(coords, wiki.Coordinate{
+				Lat:     c.Lat,
+				Lon:     c.Lon,
+				Globe:   c.Globe,
+				Type:    c.Type,
+				Name:    c.Name,
+				Primary: bool(c.Primary),
+				Source:  "geodata",
+			})
+		}
+	}
+	return coords, nil
+}
+
+// primaryCoordinate returns the coordinate marked primary, if any
+func primaryCoordinate(coords []wiki.Coordinate) *wiki.Coordinate {
+	for i := range coords {
+		if coords[i].Primary {
+			return &coords[i]
+		}
+	}
+	return nil
+}
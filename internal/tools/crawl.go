@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// CrawlOptions bounds a category crawl
+type CrawlOptions struct {
+	MaxDepth int // subcategory levels to descend (0 = the category only)
+	MaxPages int
+}
+
+// CrawlTarget is a category waiting to be listed
+type CrawlTarget struct {
+	Title string `json:"title"`
+	Depth int    `json:"depth"`
+}
+
+// CrawlState is the resumable position of a crawl: the categories still to
+// list (the one in progress first), its continuation token, the categories
+// already queued, and the pages found so far
+type CrawlState struct {
+	Queue    []CrawlTarget     `json:"queue"`
+	Continue string            `json:"continue,omitempty"`
+	Seen     map[string]bool   `json:"seen_categories"`
+	Result   *wiki.CrawlResult `json:"result"`
+}
+
+// NewCrawlState returns the initial state for crawling category
+func NewCrawlState(wikiURL, category string) *CrawlState {
+	root := categoryTitle(category)
+	return &CrawlState{
+		Queue: []CrawlTarget{{Title: root}},
+		Seen:  map[string]bool{root: true},
+		Result: &wiki.CrawlResult{
+			WikiURL:    wikiURL,
+			Category:   strings.TrimPrefix(root, "Category:"),
+			Pages:      make([]string, 0),
+			Categories: make([]string, 0),
+		},
+	}
+}
+
+// CrawlCategory walks a category tree breadth-first from state, collecting
+// member pages across continuation batches. checkpoint, if set, is called
+// after each batch with the updated state so an interrupted crawl can be
+// resumed by passing the last checkpoint back in.
+func CrawlCategory(ctx context.Context, client *wiki.Client, wikiURL string, state *CrawlState, opts CrawlOptions, checkpoint func(*CrawlState)) (*wiki.CrawlResult, error) {
+	result := state.Result
+	seenPages := make(map[string]bool, len(result.Pages))
+	for _, page := range result.Pages {
+		seenPages[page] = true
+	}
+
+	for len(state.Queue) > 0 {
+		current := state.Queue[0]
+
+		params := url.Values{}
+		params.Set("action", "query")
+		params.Set("list", "categorymembers")
+		params.Set("cmtitle", current.Title)
+		params.Set("cmlimit", "max")
+		params.Set("cmprop", "title|type")
+		if state.Continue != "" {
+			params.Set("cmcontinue", state.Continue)
+		}
+
+		resp, err := client.MakeRequest(ctx, wikiURL, params)
+		if err != nil {
+			return nil, fmt.Errorf("crawl %s: %w", current.Title, err)
+		}
+
+		if resp.Query != nil {
+			for _, member := range resp.Query.Categorymembers {
+				if member.Type == "subcat" {
+					if current.Depth < opts.MaxDepth && !state.Seen[member.Title] {
+						state.Seen[member.Title] = true
+						state.Queue = append(state.Queue, CrawlTarget{Title: member.Title, Depth: current.Depth + 1})
+					}
+					continue
+				}
+				if seenPages[member.Title] {
+					continue
+				}
+				seenPages[member.Title] = true
+				result.Pages = append(result.Pages, member.Title)
+
+				if opts.MaxPages > 0 && len(result.Pages) >= opts.MaxPages {
+					result.TotalPages = len(result.Pages)
+					result.Truncated = true
+					return result, nil
+				}
+			}
+		}
+
+		// Advance to the next batch, or the next category when this one is done
+		state.Continue = resp.Continue["cmcontinue"]
+		if state.Continue == "" {
+			result.Categories = append(result.Categories, strings.TrimPrefix(current.Title, "Category:"))
+			state.Queue = state.Queue[1:]
+		}
+		result.TotalPages = len(result.Pages)
+
+		if checkpoint != nil {
+			checkpoint(state)
+		}
+	}
+
+	result.TotalPages = len(result.Pages)
+	return result, nil
+}
+
+// categoryTitle ensures a category name has the "Category:" prefix
+func categoryTitle(category string) string {
+	if strings.HasPrefix(category, "Category:") {
+		return category
+	}
+	return "Category:" + category
+}
@@ -0,0 +1,187 @@
+package tools
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/yourusername/mediawiki-mcp/internal/artifacts"
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// Dataset formats
+const (
+	DatasetCSV   = "csv"
+	DatasetJSONL = "jsonl"
+)
+
+// datasetPreviewRows is the number of rows shown in a dataset result
+const datasetPreviewRows = 5
+
+// DatasetOptions selects what to extract from each page: the Table'th
+// wikitable when Table is set, else the first use of Template, else the
+// first infobox
+type DatasetOptions struct {
+	Template string
+	Table    int // 1-based
+}
+
+// DatasetRow is one extracted record with its provenance
+type DatasetRow struct {
+	Page   string
+	RevID  int
+	Values map[string]string
+}
+
+// Dataset is data extracted from a set of pages. Columns lists the data
+// columns in order of first appearance; every row also has the page and
+// revid provenance columns.
+type Dataset struct {
+	Columns      []string
+	Rows         []DatasetRow
+	PagesScanned int
+	PagesMatched int // pages the infobox, template, or table was found on
+}
+
+// DatasetResult is the outcome of a dataset extraction job
+type DatasetResult struct {
+	WikiURL      string              `json:"wiki_url"`
+	Category     string              `json:"category"`
+	Source       string              `json:"source"` // what was extracted, e.g. "infobox" or "table 2"
+	Columns      []string            `json:"columns"`
+	PagesScanned int                 `json:"pages_scanned"`
+	PagesMatched int                 `json:"pages_matched"`
+	Artifact     artifacts.Artifact  `json:"artifact"`
+	Preview      []map[string]string `json:"preview"`
+}
+
+// ExtractDataset extracts an infobox, template, or table from each page,
+// fetching them in batches. progress, if set, is called with the number of
+// pages done after each batch.
+func ExtractDataset(ctx context.Context, client *wiki.Client, wikiURL string, titles []string, opts DatasetOptions, progress func(done int)) (*Dataset, error) {
+	dataset := &Dataset{Columns: make([]string, 0), Rows: make([]DatasetRow, 0)}
+	seenColumns := make(map[string]bool)
+	addColumn := func(name string) string {
+		// Keep data columns apart from the provenance columns
+		if name == "page" || name == "revid" {
+			name += "_"
+		}
+		if !seenColumns[name] {
+			seenColumns[name] = true
+			dataset.Columns = append(dataset.Columns, name)
+		}
+		return name
+	}
+
+	for start := 0; start < len(titles); start += maxTitlesPerQuery {
+		batch := titles[start:min(start+maxTitlesPerQuery, len(titles))]
+		revisions, err := getPagesRevisions(ctx, client, wikiURL, batch)
+		if err != nil {
+			return nil, fmt.Errorf("extract dataset: %w", err)
+		}
+
+		for _, title := range batch {
+			rev, ok := revisions[title]
+			if !ok {
+				continue
+			}
+			dataset.PagesScanned++
+
+			if opts.Table > 0 {
+				tables := wiki.ExtractTables(rev.Text)
+				if len(tables) < opts.Table {
+					continue
+				}
+				dataset.PagesMatched++
+				table := tables[opts.Table-1]
+				for _, cells := range table.Rows {
+					row := DatasetRow{Page: title, RevID: rev.RevID, Values: make(map[string]string, len(cells))}
+					for i, cell := range cells {
+						header := "col" + strconv.Itoa(i+1)
+						if i < len(table.Headers) && table.Headers[i] != "" {
+							header = table.Headers[i]
+						}
+						row.Values[addColumn(header)] = cell
+					}
+					dataset.Rows = append(dataset.Rows, row)
+				}
+				continue
+			}
+
+			infobox := wiki.FindInfobox(rev.Text, opts.Template)
+			if infobox == nil {
+				continue
+			}
+			dataset.PagesMatched++
+			row := DatasetRow{Page: title, RevID: rev.RevID, Values: make(map[string]string, len(infobox.Names))}
+			for _, name := range infobox.Names {
+				row.Values[addColumn(name)] = infobox.Fields[name]
+			}
+			dataset.Rows = append(dataset.Rows, row)
+		}
+
+		if progress != nil {
+			progress(start + len(batch))
+		}
+	}
+
+	return dataset, nil
+}
+
+// WriteDataset writes a dataset as CSV, with a header row, or as JSON
+// Lines, one object per row. The page and revid columns come first.
+func WriteDataset(w io.Writer, format string, dataset *Dataset) error {
+	switch format {
+	case DatasetCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(append([]string{"page", "revid"}, dataset.Columns...)); err != nil {
+			return err
+		}
+		record := make([]string, 2+len(dataset.Columns))
+		for _, row := range dataset.Rows {
+			record[0], record[1] = row.Page, strconv.Itoa(row.RevID)
+			for i, column := range dataset.Columns {
+				record[2+i] = row.Values[column]
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+
+	case DatasetJSONL:
+		enc := json.NewEncoder(w)
+		for _, row := range dataset.Rows {
+			record := make(map[string]interface{}, 2+len(row.Values))
+			for column, value := range row.Values {
+				record[column] = value
+			}
+			record["page"] = row.Page
+			record["revid"] = row.RevID
+			if err := enc.Encode(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown dataset format %q", format)
+}
+
+// Preview returns the first rows of a dataset as column -> value maps
+func (d *Dataset) Preview() []map[string]string {
+	preview := make([]map[string]string, 0, datasetPreviewRows)
+	for _, row := range d.Rows[:min(len(d.Rows), datasetPreviewRows)] {
+		values := make(map[string]string, 2+len(row.Values))
+		for column, value := range row.Values {
+			values[column] = value
+		}
+		values["page"] = row.Page
+		values["revid"] = strconv.Itoa(row.RevID)
+		preview = append(preview, values)
+	}
+	return preview
+}
@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+func TestExtractDataset(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"query":{"pages":[
+			{"title":"Alpha","revisions":[{"revid":11,"slots":{"main":{"content":"{{Infobox city\n| name = Alpha\n| page = [[Home]]\n}}\n{|\n! Year !! Population\n|-\n| 1900 || 10\n|-\n| 2000 || 20\n|}"}}}]},
+			{"title":"Beta","revisions":[{"revid":22,"slots":{"main":{"content":"{{Infobox city\n| name = Beta\n| area = 5\n}}"}}}]}
+		]}}`)
+	}))
+	defer srv.Close()
+	client := wiki.NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	ctx := context.Background()
+
+	dataset, err := ExtractDataset(ctx, client, srv.URL, []string{"Alpha", "Beta"}, DatasetOptions{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(dataset.Columns); got != "[name page_ area]" {
+		t.Errorf("columns = %s, want [name page_ area]", got)
+	}
+	if dataset.PagesScanned != 2 || dataset.PagesMatched != 2 || len(dataset.Rows) != 2 {
+		t.Fatalf("got %d rows from %d/%d pages, want 2 from 2/2", len(dataset.Rows), dataset.PagesMatched, dataset.PagesScanned)
+	}
+
+	var csv bytes.Buffer
+	if err := WriteDataset(&csv, DatasetCSV, dataset); err != nil {
+		t.Fatal(err)
+	}
+	want := "page,revid,name,page_,area\nAlpha,11,Alpha,Home,\nBeta,22,Beta,,5\n"
+	if csv.String() != want {
+		t.Errorf("csv =\n%s\nwant\n%s", csv.String(), want)
+	}
+
+	tables, err := ExtractDataset(ctx, client, srv.URL, []string{"Alpha", "Beta"}, DatasetOptions{Table: 1}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tables.PagesMatched != 1 || len(tables.Rows) != 2 {
+		t.Fatalf("got %d rows from %d pages, want 2 from 1", len(tables.Rows), tables.PagesMatched)
+	}
+
+	var jsonl bytes.Buffer
+	if err := WriteDataset(&jsonl, DatasetJSONL, tables); err != nil {
+		t.Fatal(err)
+	}
+	var first map[string]interface{}
+	if err := json.NewDecoder(&jsonl).Decode(&first); err != nil {
+		t.Fatal(err)
+	}
+	if first["page"] != "Alpha" || first["revid"] != float64(11) || first["Year"] != "1900" || first["Population"] != "10" {
+		t.Errorf("first jsonl row = %v", first)
+	}
+}
@@ -0,0 +1,151 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"hash/fnv"
+	"math/bits"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// DedupOptions controls near-duplicate detection
+type DedupOptions struct {
+	MaxDistance  int // simhash bits that may differ for two pages to count as duplicates
+	MaxPageBytes int
+}
+
+// shingleSize is the number of consecutive words hashed as one feature
+const shingleSize = 3
+
+// markdownLinkTarget matches the URL part of a Markdown link, which differs
+// between mirrors even when the text is identical
+var markdownLinkTarget = regexp.MustCompile(`\]\([^)]*\)`)
+
+// pageFingerprint is the content signature of a fetched page
+type pageFingerprint struct {
+	ref     wiki.PageRef
+	sum     [sha256.Size]byte
+	simhash uint64
+}
+
+// FindDuplicates fetches pages and groups those whose content is identical or
+// nearly so. Pages earlier in the list are preferred as canonical sources, so
+// callers should order them by wiki preference. Pages that can't be fetched
+// are reported as skipped. progress, if set, is called after each page.
+func FindDuplicates(ctx context.Context, client *wiki.Client, pages []wiki.PageRef, opts DedupOptions, progress func(done int)) (*wiki.DedupResult, error) {
+	result := &wiki.DedupResult{
+		Groups:    make([]wiki.DuplicateGroup, 0),
+		Canonical: make([]wiki.PageRef, 0),
+	}
+
+	prints := make([]pageFingerprint, 0, len(pages))
+	for i, ref := range pages {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		page, err := GetPageFull(ctx, client, ref.WikiURL, ref.Title, opts.MaxPageBytes)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			result.Skipped = append(result.Skipped, ref)
+		} else {
+			words := contentWords(page.Content)
+			prints = append(prints, pageFingerprint{
+				ref:     ref,
+				sum:     sha256.Sum256([]byte(strings.Join(words, " "))),
+				simhash: simhash(words),
+			})
+		}
+
+		if progress != nil {
+			progress(i + 1)
+		}
+	}
+	result.PagesChecked = len(prints)
+
+	// Assign each page to the first earlier canonical page it matches
+	var groups []*wiki.DuplicateGroup
+	var canon []pageFingerprint
+	for _, fp := range prints {
+		matched := false
+		for i, c := range canon {
+			distance := bits.OnesCount64(fp.simhash ^ c.simhash)
+			exact := fp.sum == c.sum
+			if !exact && distance > opts.MaxDistance {
+				continue
+			}
+			if groups[i] == nil {
+				groups[i] = &wiki.DuplicateGroup{Canonical: c.ref}
+			}
+			groups[i].Duplicates = append(groups[i].Duplicates, wiki.DuplicatePage{
+				PageRef:  fp.ref,
+				Distance: distance,
+				Exact:    exact,
+			})
+			matched = true
+			break
+		}
+		if !matched {
+			canon = append(canon, fp)
+			groups = append(groups, nil)
+			result.Canonical = append(result.Canonical, fp.ref)
+		}
+	}
+
+	for _, group := range groups {
+		if group != nil {
+			result.Groups = append(result.Groups, *group)
+		}
+	}
+	result.UniquePages = len(canon)
+
+	return result, nil
+}
+
+// contentWords normalizes Markdown into lowercase words, dropping link
+// targets and punctuation so formatting differences between mirrors vanish
+func contentWords(markdown string) []string {
+	text := markdownLinkTarget.ReplaceAllString(markdown, "]")
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// simhash computes a 64-bit similarity hash over word shingles. Texts that
+// share most of their shingles produce hashes with a small Hamming distance.
+func simhash(words []string) uint64 {
+	var weights [64]int
+	add := func(feature string) {
+		h := fnv.New64a()
+		h.Write([]byte(feature))
+		sum := h.Sum64()
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	if len(words) < shingleSize {
+		add(strings.Join(words, " "))
+	}
+	for i := 0; i+shingleSize <= len(words); i++ {
+		add(strings.Join(words[i:i+shingleSize], " "))
+	}
+
+	var hash uint64
+	for bit, weight := range weights {
+		if weight > 0 {
+			hash |= 1 << bit
+		}
+	}
+	return hash
+}
@@ -0,0 +1,256 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// GetDiscussions retrieves the threads of a talk page as structured comments,
+// using StructuredDiscussions (Flow) boards or DiscussionTools where available
+func GetDiscussions(ctx context.Context, client *wiki.Client, wikiURL, title string, limit int) (*wiki.DiscussionsResponse, error) {
+	// Check cache
+	cacheKey := wiki.DiscussionsCacheKey(wikiURL, title, limit)
+	var cached wiki.DiscussionsResponse
+	if client.GetCachedResult(ctx, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	caps, err := client.GetCapabilities(ctx, wikiURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *wiki.DiscussionsResponse
+	var lastErr error
+
+	// Flow boards replace the talk page entirely, so try them first
+	if caps.HasExtension("StructuredDiscussions") || caps.HasExtension("Flow") {
+		result, lastErr = getFlowDiscussions(ctx, client, wikiURL, title, limit)
+	}
+
+	if result == nil && caps.HasExtension("DiscussionTools") {
+		result, lastErr = getDiscussionToolsThreads(ctx, client, wikiURL, title, limit)
+	}
+
+	// A cancelled call may have left comments unconverted
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		if lastErr != nil {
+			var apiErr *wiki.APIError
+			if errors.As(lastErr, &apiErr) && apiErr.Code == "missingtitle" {
+				return nil, lastErr
+			}
+		}
+		return nil, &FeatureUnsupportedError{
+			Feature: "structured discussions",
+			WikiURL: wikiURL,
+			Cause:   lastErr,
+		}
+	}
+
+	// Cache the result
+	client.GetCache().SetJSON(cacheKey, result, client.GetCacheTTL())
+
+	return result, nil
+}
+
+// getDiscussionToolsThreads reads threads via action=discussiontoolspageinfo
+func getDiscussionToolsThreads(ctx context.Context, client *wiki.Client, wikiURL, title string, limit int) (*wiki.DiscussionsResponse, error) {
+	params := url.Values{}
+	params.Set("action", "discussiontoolspageinfo")
+	params.Set("page", title)
+	params.Set("prop", "threaditemshtml")
+
+	resp, err := client.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("get discussiontools page info: %w", err)
+	}
+
+	if resp.DiscussionToolsPageInfo == nil {
+		return nil, fmt.Errorf("empty discussiontools response")
+	}
+
+	items := resp.DiscussionToolsPageInfo.ThreadItemsHTML
+	threads := make([]*wiki.DiscussionThread, 0, len(items))
+	for _, item := range items {
+		if len(threads) >= limit {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		thread := &wiki.DiscussionThread{
+			ID:       item.ID,
+			Title:    htmlToText(ctx, item.HTML),
+			Comments: make([]*wiki.DiscussionComment, 0, len(item.Replies)),
+		}
+		if item.Type != "heading" {
+			// Comments before the first heading form an untitled thread
+			thread.Title = ""
+			thread.Comments = append(thread.Comments, convertThreadItem(ctx, item))
+		} else {
+			for _, reply := range item.Replies {
+				thread.Comments = append(thread.Comments, convertThreadItem(ctx, reply))
+			}
+		}
+
+		threads = append(threads, thread)
+	}
+
+	return &wiki.DiscussionsResponse{
+		Title:        title,
+		Format:       "discussiontools",
+		Threads:      threads,
+		TotalThreads: len(items),
+	}, nil
+}
+
+// convertThreadItem converts a DiscussionTools comment and its replies
+func convertThreadItem(ctx context.Context, item wiki.MWThreadItem) *wiki.DiscussionComment {
+	comment := &wiki.DiscussionComment{
+		ID:        item.ID,
+		Author:    item.Author,
+		Timestamp: item.Timestamp,
+		Content:   htmlToText(ctx, item.HTML),
+	}
+	for _, reply := range item.Replies {
+		if reply.Type == "heading" {
+			continue
+		}
+		comment.Replies = append(comment.Replies, convertThreadItem(ctx, reply))
+	}
+	return comment
+}
+
+// getFlowDiscussions reads a StructuredDiscussions board via action=flow
+func getFlowDiscussions(ctx context.Context, client *wiki.Client, wikiURL, title string, limit int) (*wiki.DiscussionsResponse, error) {
+	params := url.Values{}
+	params.Set("action", "flow")
+	params.Set("submodule", "view-topiclist")
+	params.Set("page", title)
+	params.Set("vtlformat", "html")
+	params.Set("vtllimit", strconv.Itoa(min(limit, 100)))
+
+	resp, err := client.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("get flow topics: %w", err)
+	}
+
+	if resp.Flow == nil {
+		return nil, fmt.Errorf("empty flow response")
+	}
+
+	topicList := resp.Flow.ViewTopicList.Result.TopicList
+	threads := make([]*wiki.DiscussionThread, 0, len(topicList.Roots))
+	for _, rootID := range topicList.Roots {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		rev, ok := latestFlowRevision(topicList, rootID)
+		if !ok {
+			continue
+		}
+
+		thread := &wiki.DiscussionThread{
+			ID:       rootID,
+			Title:    htmlToText(ctx, rev.Content.Content),
+			Comments: make([]*wiki.DiscussionComment, 0, len(rev.Replies)),
+		}
+		for _, replyID := range rev.Replies {
+			if comment := convertFlowPost(ctx, topicList, replyID, 0); comment != nil {
+				thread.Comments = append(thread.Comments, comment)
+			}
+		}
+
+		threads = append(threads, thread)
+	}
+
+	return &wiki.DiscussionsResponse{
+		Title:        title,
+		Format:       "flow",
+		Threads:      threads,
+		TotalThreads: len(threads),
+	}, nil
+}
+
+// maxFlowDepth bounds recursion through malformed or cyclic reply graphs
+const maxFlowDepth = 50
+
+// convertFlowPost converts a Flow post and its replies
+func convertFlowPost(ctx context.Context, topicList wiki.MWFlowTopicList, postID string, depth int) *wiki.DiscussionComment {
+	if depth > maxFlowDepth {
+		return nil
+	}
+
+	rev, ok := latestFlowRevision(topicList, postID)
+	if !ok {
+		return nil
+	}
+
+	comment := &wiki.DiscussionComment{
+		ID:        postID,
+		Author:    rev.Author.Name,
+		Timestamp: flowTimestamp(rev.Timestamp),
+		Content:   htmlToText(ctx, rev.Content.Content),
+	}
+	for _, replyID := range rev.Replies {
+		if reply := convertFlowPost(ctx, topicList, replyID, depth+1); reply != nil {
+			comment.Replies = append(comment.Replies, reply)
+		}
+	}
+	return comment
+}
+
+// latestFlowRevision returns the current revision of a Flow post
+func latestFlowRevision(topicList wiki.MWFlowTopicList, postID string) (wiki.MWFlowRevision, bool) {
+	revIDs := topicList.Posts[postID]
+	if len(revIDs) == 0 {
+		return wiki.MWFlowRevision{}, false
+	}
+	rev, ok := topicList.Revisions[revIDs[0]]
+	return rev, ok
+}
+
+// flowTimestamp converts Flow's "20060102150405" timestamps to RFC 3339
+func flowTimestamp(ts string) string {
+	t, err := time.Parse("20060102150405", ts)
+	if err != nil {
+		return ts
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// htmlToText converts a fragment of comment HTML to Markdown, falling back
+// to the raw HTML if conversion fails
+func htmlToText(ctx context.Context, html string) string {
+	markdown, err := wiki.HTMLToMarkdown(ctx, html)
+	if err != nil {
+		return html
+	}
+	return markdown
+}
+
+// FeatureUnsupportedError is returned when a wiki lacks the extension a tool needs
+type FeatureUnsupportedError struct {
+	Feature string
+	WikiURL string
+	Cause   error
+}
+
+func (e *FeatureUnsupportedError) Error() string {
+	msg := fmt.Sprintf("%s not supported by %s", e.Feature, e.WikiURL)
+	if e.Cause != nil {
+		msg += ": " + e.Cause.Error()
+	}
+	return msg
+}
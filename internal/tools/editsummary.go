@@ -0,0 +1,94 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// maxEditSummaryChars is MediaWiki's limit on edit summary length
+const maxEditSummaryChars = 500
+
+// EditAttribution identifies an agent edit so wiki communities can tell
+// automated edits apart and trace them back to the tool call that made them
+type EditAttribution struct {
+	Tool     string // write tool that made the edit
+	Client   string // MCP client name and version, when known
+	AuditRef string // audit log reference of the tool call
+	AuditURL string // link to the audit record, when one is configured
+}
+
+// String renders the attribution, e.g. "via MediaWikiMCP wiki_edit_page for
+// ExampleClient/1.2; audit 3f9a0c2b71de"
+func (a EditAttribution) String() string {
+	parts := []string{"via MediaWikiMCP"}
+	if a.Tool != "" {
+		parts = append(parts, a.Tool)
+	}
+	if a.Client != "" {
+		parts = append(parts, "for "+a.Client)
+	}
+	text := strings.Join(parts, " ")
+	if audit := a.audit(); audit != "" {
+		text += "; audit " + audit
+	}
+	return text
+}
+
+// audit returns the link to the audit record, or its bare reference
+func (a EditAttribution) audit() string {
+	if a.AuditURL != "" {
+		return a.AuditURL
+	}
+	return a.AuditRef
+}
+
+// FormatEditSummary fills an edit summary template. The template's
+// placeholders are {summary}, {attribution}, {tool}, {client}, and {audit};
+// a template without {summary} gets the summary prepended. The summary is
+// shortened as needed so the result, attribution included, fits MediaWiki's
+// limit.
+func FormatEditSummary(template, summary string, attr EditAttribution) string {
+	if !strings.Contains(template, "{summary}") {
+		template = "{summary} " + template
+	}
+	filled := strings.NewReplacer(
+		"{attribution}", attr.String(),
+		"{tool}", attr.Tool,
+		"{client}", attr.Client,
+		"{audit}", attr.audit(),
+	).Replace(template)
+
+	summary = strings.TrimSpace(summary)
+	budget := maxEditSummaryChars - utf8.RuneCountInString(strings.ReplaceAll(filled, "{summary}", ""))
+	if utf8.RuneCountInString(summary) > budget {
+		summary = truncateRunes(summary, budget-1) + "…"
+	}
+	return strings.TrimSpace(strings.ReplaceAll(filled, "{summary}", summary))
+}
+
+// truncateRunes returns at most n runes of s
+func truncateRunes(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	for i := range s {
+		if n == 0 {
+			return strings.TrimSpace(s[:i])
+		}
+		n--
+	}
+	return s
+}
+
+// BotFlag reports whether an edit should be marked as a bot edit: when the
+// operator asked for it and the account holds the bot right. The wiki would
+// silently ignore the flag otherwise, so responses can report it truthfully.
+func BotFlag(ctx context.Context, client *wiki.Client, wikiURL string, requested bool) (bool, error) {
+	if !requested {
+		return false, nil
+	}
+	return client.HasRight(ctx, wikiURL, "bot")
+}
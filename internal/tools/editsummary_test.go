@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestFormatEditSummary(t *testing.T) {
+	attr := EditAttribution{
+		Tool:     "wiki_edit_page",
+		Client:   "ExampleClient/1.2",
+		AuditRef: "3f9a0c2b71de4a10",
+	}
+
+	tests := []struct {
+		template, summary string
+		attr              EditAttribution
+		want              string
+	}{
+		{
+			"{summary} ({attribution})", "Fix typo", attr,
+			"Fix typo (via MediaWikiMCP wiki_edit_page for ExampleClient/1.2; audit 3f9a0c2b71de4a10)",
+		},
+		{
+			"{summary} ({attribution})", "Fix typo", EditAttribution{Tool: "wiki_edit_page"},
+			"Fix typo (via MediaWikiMCP wiki_edit_page)",
+		},
+		{
+			"[[Project:Bots|Bot]]: {summary} – {audit}", "Update table",
+			EditAttribution{AuditRef: "ab12", AuditURL: "https://mcp.example.org/audit/ab12"},
+			"[[Project:Bots|Bot]]: Update table – https://mcp.example.org/audit/ab12",
+		},
+		{
+			"(by {client})", "Add source", attr,
+			"Add source (by ExampleClient/1.2)",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := FormatEditSummary(tt.template, tt.summary, tt.attr); got != tt.want {
+			t.Errorf("FormatEditSummary(%q, %q) = %q, want %q", tt.template, tt.summary, got, tt.want)
+		}
+	}
+
+	// Long summaries are shortened so the attribution survives
+	got := FormatEditSummary("{summary} ({attribution})", strings.Repeat("word ", 200), attr)
+	if n := utf8.RuneCountInString(got); n > maxEditSummaryChars {
+		t.Errorf("summary has %d characters, want at most %d", n, maxEditSummaryChars)
+	}
+	if !strings.HasSuffix(got, "…"+" ("+attr.String()+")") {
+		t.Errorf("long summary = %q, want it truncated before the attribution", got)
+	}
+}
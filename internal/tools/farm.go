@@ -0,0 +1,208 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// FarmOptions filters the wikis of a farm
+type FarmOptions struct {
+	Project       string // only wikis of this project, e.g. "wiktionary"
+	Language      string // only wikis in this language
+	IncludeClosed bool   // include closed (read-only) and private wikis
+}
+
+// ListFarmWikis enumerates the member wikis of a wiki farm or family,
+// using the SiteMatrix extension where available and otherwise the farm's
+// local interwiki prefixes
+func ListFarmWikis(ctx context.Context, client *wiki.Client, farmURL string, limit int, opts FarmOptions) (*wiki.FarmResponse, error) {
+	members, err := getFarmMembers(ctx, client, farmURL)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &wiki.FarmResponse{
+		FarmURL: members.FarmURL,
+		Source:  members.Source,
+		Wikis:   make([]wiki.FarmWiki, 0),
+	}
+	for _, w := range members.Wikis {
+		if !opts.IncludeClosed && (w.Closed || w.Private) {
+			continue
+		}
+		if opts.Project != "" && !strings.EqualFold(w.Project, opts.Project) {
+			continue
+		}
+		if opts.Language != "" && !strings.EqualFold(w.Language, opts.Language) {
+			continue
+		}
+
+		result.Total++
+		if len(result.Wikis) < limit {
+			result.Wikis = append(result.Wikis, w)
+		}
+	}
+	result.Truncated = result.Total > len(result.Wikis)
+
+	return result, nil
+}
+
+// getFarmMembers retrieves (and caches) every wiki of a farm, unfiltered
+func getFarmMembers(ctx context.Context, client *wiki.Client, farmURL string) (*wiki.FarmResponse, error) {
+	// Check cache
+	cacheKey := wiki.FarmCacheKey(farmURL)
+	var cached wiki.FarmResponse
+	if client.GetCachedResult(ctx, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	caps, err := client.GetCapabilities(ctx, farmURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Members are assumed to share the farm's URL layout
+	apiPath, err := client.APIPath(ctx, farmURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *wiki.FarmResponse
+	if caps.HasExtension("SiteMatrix") {
+		result, err = getSiteMatrixWikis(ctx, client, farmURL, apiPath)
+	} else {
+		result, err = getInterwikiWikis(ctx, client, farmURL, apiPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Wikis) == 0 {
+		return nil, &FeatureUnsupportedError{
+			Feature: "wiki farm discovery (SiteMatrix or local interwikis)",
+			WikiURL: farmURL,
+		}
+	}
+	result.FarmURL = farmURL
+
+	// Cache the result
+	client.GetCache().SetJSON(cacheKey, result, client.GetCacheTTLInfo())
+
+	return result, nil
+}
+
+// getSiteMatrixWikis lists wikis from Special:SiteMatrix
+func getSiteMatrixWikis(ctx context.Context, client *wiki.Client, farmURL, apiPath string) (*wiki.FarmResponse, error) {
+	result := &wiki.FarmResponse{Source: "sitematrix"}
+
+	params := url.Values{}
+	params.Set("action", "sitematrix")
+	params.Set("smlimit", "max")
+	params.Set("smsiteprop", "url|dbname|code|sitename|lang")
+	params.Set("smlangprop", "code|name|site")
+
+	for {
+		resp, err := client.MakeRequest(ctx, farmURL, params)
+		if err != nil {
+			return nil, fmt.Errorf("get site matrix: %w", err)
+		}
+
+		if resp.SiteMatrix == nil {
+			return nil, fmt.Errorf("empty sitematrix response")
+		}
+
+		for _, lang := range resp.SiteMatrix.Languages {
+			for _, site := range lang.Site {
+				result.Wikis = append(result.Wikis, siteMatrixWiki(site, lang.Code, apiPath))
+			}
+		}
+		for _, site := range resp.SiteMatrix.Specials {
+			result.Wikis = append(result.Wikis, siteMatrixWiki(site, site.Lang, apiPath))
+		}
+
+		smcontinue := resp.Continue["smcontinue"]
+		if smcontinue == "" {
+			break
+		}
+		params.Set("smcontinue", smcontinue)
+	}
+
+	return result, nil
+}
+
+func siteMatrixWiki(site wiki.MWSiteMatrixSite, lang, apiPath string) wiki.FarmWiki {
+	return wiki.FarmWiki{
+		Name:     site.SiteName,
+		WikiURL:  site.URL,
+		APIURL:   site.URL + apiPath,
+		DBName:   site.DBName,
+		Language: lang,
+		Project:  site.Code,
+		Closed:   bool(site.Closed),
+		Private:  bool(site.Private),
+	}
+}
+
+// getInterwikiWikis lists the wikis behind a farm's local interwiki
+// prefixes, which farms without SiteMatrix typically define for each member
+func getInterwikiWikis(ctx context.Context, client *wiki.Client, farmURL, apiPath string) (*wiki.FarmResponse, error) {
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("meta", "siteinfo")
+	params.Set("siprop", "interwikimap")
+	params.Set("sifilteriw", "local")
+
+	resp, err := client.MakeRequest(ctx, farmURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("get interwiki map: %w", err)
+	}
+
+	if resp.Query == nil {
+		return nil, fmt.Errorf("empty query response")
+	}
+
+	result := &wiki.FarmResponse{Source: "interwiki"}
+	seen := make(map[string]bool)
+	for _, iw := range resp.Query.InterwikiMap {
+		wikiURL := interwikiBaseURL(iw.URL)
+		if wikiURL == "" || seen[wikiURL] {
+			continue
+		}
+		seen[wikiURL] = true
+
+		apiURL := iw.API
+		if apiURL == "" {
+			apiURL = wikiURL + apiPath
+		}
+		result.Wikis = append(result.Wikis, wiki.FarmWiki{
+			Name:     iw.Prefix,
+			WikiURL:  wikiURL,
+			APIURL:   apiURL,
+			Language: iw.Language,
+		})
+	}
+
+	return result, nil
+}
+
+// interwikiBaseURL derives a wiki's base URL from an interwiki URL pattern
+// such as "https://example.org/wiki/$1", or "" when it isn't a web URL
+func interwikiBaseURL(pattern string) string {
+	if strings.HasPrefix(pattern, "//") {
+		pattern = "https:" + pattern
+	}
+	u, err := url.Parse(strings.Replace(pattern, "$1", "", 1))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+
+	base := u.Scheme + "://" + u.Host
+	// Keep a path prefix in front of the article path, e.g. "/en" in "/en/wiki/$1"
+	if i := strings.Index(u.Path, "/wiki/"); i > 0 {
+		base += u.Path[:i]
+	}
+	return base
+}
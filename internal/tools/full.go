@@ -8,12 +8,30 @@ import (
 	"github.com/yourusername/mediawiki-mcp/internal/wiki"
 )
 
-// GetPageFull retrieves the entire content of a page
-func GetPageFull(ctx context.Context, client *wiki.Client, wikiURL, title string) (*wiki.PageFull, error) {
+// GetPageFull retrieves the entire content of a page. Pages whose wikitext
+// exceeds maxBytes are refused with a PageTooLargeError (0 disables the check).
+func GetPageFull(ctx context.Context, client *wiki.Client, wikiURL, title string, maxBytes int) (*wiki.PageFull, error) {
 	// Check cache
 	cacheKey := wiki.PageCacheKey(wikiURL, title)
-	if cached, ok := client.GetCache().Get(cacheKey); ok {
-		return cached.(*wiki.PageFull), nil
+	var cached wiki.PageFull
+	if client.GetCachedResult(ctx, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	// Pre-flight size check to avoid converting multi-megabyte pages
+	if maxBytes > 0 {
+		length, err := getPageLength(ctx, client, wikiURL, title)
+		if err == nil && length > maxBytes {
+			tooLarge := &PageTooLargeError{
+				Title:    title,
+				Length:   length,
+				MaxBytes: maxBytes,
+			}
+			if outline, err := GetPageOutline(ctx, client, wikiURL, title, OutlineOptions{}); err == nil {
+				tooLarge.Outline = outline
+			}
+			return nil, tooLarge
+		}
 	}
 
 	// Build API request
@@ -35,7 +53,7 @@ func GetPageFull(ctx context.Context, client *wiki.Client, wikiURL, title string
 	}
 
 	// Convert HTML to Markdown
-	markdown, err := wiki.HTMLToMarkdown(resp.Parse.Text.Content)
+	converted, err := client.ConvertHTML(ctx, wikiURL, resp.Parse.Text.Content)
 	if err != nil {
 		return nil, fmt.Errorf("convert to markdown: %w", err)
 	}
@@ -46,15 +64,18 @@ func GetPageFull(ctx context.Context, client *wiki.Client, wikiURL, title string
 		links = append(links, link.Title)
 	}
 
-	// Count words
-	wordCount := wiki.CountWords(markdown)
+	// Count prose words only, so tables and navigation don't inflate the size warning
+	wordCount := converted.ProseWordCount
 
 	// Build response
 	pageFull := &wiki.PageFull{
-		Title:     resp.Parse.Title,
-		Content:   markdown,
-		Links:     links,
-		WordCount: wordCount,
+		Title:          resp.Parse.Title,
+		Content:        converted.Markdown,
+		Links:          links,
+		WordCount:      wordCount,
+		TableWordCount: converted.TableWordCount,
+		ContentHash:    wiki.ContentHash(converted.Markdown),
+		Source:         client.NewProvenance(ctx, wikiURL, resp.Parse.Title, resp.Parse.RevID),
 	}
 
 	// Add warning for large pages
@@ -64,7 +85,46 @@ func GetPageFull(ctx context.Context, client *wiki.Client, wikiURL, title string
 	}
 
 	// Cache the result
-	client.GetCache().Set(cacheKey, pageFull, client.GetCacheTTL())
+	client.GetCache().SetJSON(cacheKey, pageFull, client.GetCacheTTL())
 
 	return pageFull, nil
 }
+
+// getPageLength returns the size of a page's current wikitext in bytes
+func getPageLength(ctx context.Context, client *wiki.Client, wikiURL, title string) (int, error) {
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("titles", title)
+	params.Set("prop", "info")
+	params.Set("redirects", "1")
+
+	resp, err := client.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return 0, err
+	}
+
+	if resp.Query == nil || len(resp.Query.Pages) == 0 {
+		return 0, fmt.Errorf("no pages found")
+	}
+
+	for _, page := range resp.Query.Pages {
+		if page.Missing {
+			return 0, fmt.Errorf("page missing")
+		}
+		return page.Length, nil
+	}
+
+	return 0, fmt.Errorf("no pages found")
+}
+
+// PageTooLargeError is returned when a page exceeds the configured size limit
+type PageTooLargeError struct {
+	Title    string
+	Length   int
+	MaxBytes int
+	Outline  *wiki.PageOutline
+}
+
+func (e *PageTooLargeError) Error() string {
+	return fmt.Sprintf("page %q is %d bytes, exceeding the %d byte limit for full retrieval", e.Title, e.Length, e.MaxBytes)
+}
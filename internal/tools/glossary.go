@@ -0,0 +1,174 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// GetPageGlossary retrieves the glossary entries defined on a page:
+// definition lists, glossary templates, and abbreviation expansions
+func GetPageGlossary(ctx context.Context, client *wiki.Client, wikiURL, title string, limit int) (*wiki.GlossaryResponse, error) {
+	entries, err := getPageGlossary(ctx, client, wikiURL, title)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &wiki.GlossaryResponse{
+		Title:        title,
+		Entries:      entries,
+		TotalEntries: len(entries),
+		PagesScanned: 1,
+	}
+	truncateGlossary(result, limit)
+	return result, nil
+}
+
+// GetCategoryGlossary retrieves the glossary entries defined on up to
+// maxPages pages in a category, each marked with its page
+func GetCategoryGlossary(ctx context.Context, client *wiki.Client, wikiURL, category string, maxPages, limit int) (*wiki.GlossaryResponse, error) {
+	members, err := GetCategory(ctx, client, wikiURL, category, maxPages, CategoryOptions{MemberType: "page"})
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make([]string, 0, len(members.Members))
+	for _, m := range members.Members {
+		titles = append(titles, m.Title)
+	}
+
+	// Fetch the wikitext of pages whose glossaries aren't cached, in batches
+	glossaries := make(map[string][]wiki.GlossaryEntry, len(titles))
+	var uncached []string
+	for _, title := range titles {
+		var entries []wiki.GlossaryEntry
+		if client.GetCache().GetJSON(wiki.GlossaryCacheKey(wikiURL, title), &entries) {
+			glossaries[title] = entries
+		} else {
+			uncached = append(uncached, title)
+		}
+	}
+	wikitexts, err := getPagesWikitext(ctx, client, wikiURL, uncached)
+	if err != nil {
+		return nil, fmt.Errorf("get glossary: %w", err)
+	}
+	for title, text := range wikitexts {
+		entries := wiki.ExtractGlossary(text)
+		client.GetCache().SetJSON(wiki.GlossaryCacheKey(wikiURL, title), entries, client.GetCacheTTL())
+		glossaries[title] = entries
+	}
+
+	result := &wiki.GlossaryResponse{
+		Category:     members.Category,
+		Entries:      make([]wiki.GlossaryEntry, 0),
+		PagesScanned: len(titles),
+	}
+	for _, title := range titles {
+		for _, entry := range glossaries[title] {
+			entry.Page = title
+			result.Entries = append(result.Entries, entry)
+		}
+	}
+	result.TotalEntries = len(result.Entries)
+	truncateGlossary(result, limit)
+	return result, nil
+}
+
+func getPageGlossary(ctx context.Context, client *wiki.Client, wikiURL, title string) ([]wiki.GlossaryEntry, error) {
+	// Check cache
+	cacheKey := wiki.GlossaryCacheKey(wikiURL, title)
+	var cached []wiki.GlossaryEntry
+	if client.GetCachedResult(ctx, cacheKey, &cached) {
+		return cached, nil
+	}
+
+	wikitext, err := getPageWikitext(ctx, client, wikiURL, title)
+	if err != nil {
+		return nil, fmt.Errorf("get glossary: %w", err)
+	}
+	entries := wiki.ExtractGlossary(wikitext)
+
+	// Cache the result
+	client.GetCache().SetJSON(cacheKey, entries, client.GetCacheTTL())
+
+	return entries, nil
+}
+
+func truncateGlossary(result *wiki.GlossaryResponse, limit int) {
+	if result.Entries == nil {
+		result.Entries = make([]wiki.GlossaryEntry, 0)
+	}
+	if limit > 0 && len(result.Entries) > limit {
+		result.Entries = result.Entries[:limit]
+		result.Truncated = true
+	}
+}
+
+// getPagesWikitext fetches the current wikitext of several pages, keyed by
+// title. Missing pages are left out.
+func getPagesWikitext(ctx context.Context, client *wiki.Client, wikiURL string, titles []string) (map[string]string, error) {
+	revisions, err := getPagesRevisions(ctx, client, wikiURL, titles)
+	if err != nil {
+		return nil, err
+	}
+
+	wikitexts := make(map[string]string, len(revisions))
+	for title, rev := range revisions {
+		wikitexts[title] = rev.Text
+	}
+	return wikitexts, nil
+}
+
+// pageRevision is the current revision of a page
+type pageRevision struct {
+	RevID int
+	Text  string
+}
+
+// getPagesRevisions fetches the current revision of several pages, keyed by
+// title. Missing pages are left out.
+func getPagesRevisions(ctx context.Context, client *wiki.Client, wikiURL string, titles []string) (map[string]pageRevision, error) {
+	revisions := make(map[string]pageRevision, len(titles))
+
+	for start := 0; start < len(titles); start += maxTitlesPerQuery {
+		end := start + maxTitlesPerQuery
+		if end > len(titles) {
+			end = len(titles)
+		}
+
+		params := url.Values{}
+		params.Set("action", "query")
+		params.Set("titles", strings.Join(titles[start:end], "|"))
+		params.Set("prop", "revisions")
+		params.Set("rvprop", "ids|content")
+		params.Set("rvslots", "main")
+
+		// Large batches are split across responses with rvcontinue
+		for {
+			resp, err := client.MakeRequest(ctx, wikiURL, params)
+			if err != nil {
+				return nil, err
+			}
+			if resp.Query == nil {
+				return nil, fmt.Errorf("empty query response")
+			}
+
+			for _, page := range resp.Query.Pages {
+				if len(page.Revisions) > 0 {
+					revisions[page.Title] = pageRevision{RevID: page.Revisions[0].RevID, Text: page.Revisions[0].Text()}
+				}
+			}
+
+			rvcontinue := resp.Continue["rvcontinue"]
+			if rvcontinue == "" {
+				break
+			}
+			params.Set("rvcontinue", rvcontinue)
+		}
+	}
+
+	return revisions, nil
+}
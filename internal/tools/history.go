@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// historyBatchSize is the API limit on revisions per request when their
+// content is included
+const historyBatchSize = 50
+
+// SearchPageHistory scans the wikitext of a page's revisions, oldest first,
+// for a plain text or regular expression pattern and returns the revisions
+// in which it appeared or disappeared. At most maxRevisions revisions are
+// scanned; revisions whose text was deleted are skipped.
+func SearchPageHistory(ctx context.Context, client *wiki.Client, wikiURL, title, pattern string, regex bool, maxRevisions int) (*wiki.HistorySearchResponse, error) {
+	matches := func(text string) bool { return strings.Contains(text, pattern) }
+	if regex {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+		matches = re.MatchString
+	}
+
+	// Check cache
+	cacheKey := wiki.HistorySearchCacheKey(wikiURL, title, pattern, regex, maxRevisions)
+	var cached wiki.HistorySearchResponse
+	if client.GetCachedResult(ctx, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("titles", title)
+	params.Set("prop", "revisions")
+	params.Set("rvprop", "ids|timestamp|user|comment|content")
+	params.Set("rvslots", "main")
+	params.Set("rvdir", "newer")
+	params.Set("redirects", "1")
+
+	result := &wiki.HistorySearchResponse{
+		Title:   title,
+		Pattern: pattern,
+		Changes: []wiki.HistoryChange{},
+	}
+	for result.RevisionsScanned < maxRevisions {
+		params.Set("rvlimit", strconv.Itoa(min(historyBatchSize, maxRevisions-result.RevisionsScanned)))
+
+		resp, err := client.MakeRequest(ctx, wikiURL, params)
+		if err != nil {
+			return nil, fmt.Errorf("search page history: %w", err)
+		}
+		if resp.Query == nil || len(resp.Query.Pages) == 0 {
+			return nil, fmt.Errorf("no pages found")
+		}
+
+		page := resp.Query.Pages[0]
+		if page.Missing {
+			return nil, &wiki.APIError{Code: "missingtitle", Message: fmt.Sprintf("page %q does not exist", title)}
+		}
+		result.Title = page.Title
+
+		for _, rev := range page.Revisions {
+			result.RevisionsScanned++
+			if rev.Hidden() {
+				continue
+			}
+			if present := matches(rev.Text()); present != result.Present {
+				change := "added"
+				if !present {
+					change = "removed"
+				}
+				result.Changes = append(result.Changes, wiki.HistoryChange{
+					Change: change,
+					RevisionInfo: wiki.RevisionInfo{
+						ID:        rev.RevID,
+						Timestamp: rev.Timestamp,
+						User:      rev.User,
+					},
+					Comment: rev.Comment,
+				})
+				result.Present = present
+			}
+		}
+
+		rvcontinue := resp.Continue["rvcontinue"]
+		if rvcontinue == "" {
+			break
+		}
+		if result.RevisionsScanned >= maxRevisions || len(page.Revisions) == 0 {
+			result.Truncated = true
+			break
+		}
+		params.Set("rvcontinue", rvcontinue)
+	}
+
+	// Cache the result
+	client.GetCache().SetJSON(cacheKey, result, client.GetCacheTTL())
+
+	return result, nil
+}
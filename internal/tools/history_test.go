@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+func TestSearchPageHistory(t *testing.T) {
+	// Five revisions, served two at a time: the claim is added in 2,
+	// removed in 3, the text of 4 is hidden, and it is restored in 5
+	texts := []string{"Intro.", "Intro. Founded in 1901.", "Intro.", "", "Intro. Founded in 1901."}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start, limit := 0, 0
+		fmt.Sscan(r.URL.Query().Get("rvcontinue"), &start)
+		fmt.Sscan(r.URL.Query().Get("rvlimit"), &limit)
+		end := min(start+2, start+limit, len(texts))
+
+		revisions := ""
+		for i := start; i < end; i++ {
+			if i > start {
+				revisions += ","
+			}
+			main := fmt.Sprintf(`{"content":%q}`, texts[i])
+			if i == 3 {
+				main = `{"texthidden":true}`
+			}
+			revisions += fmt.Sprintf(`{"revid":%d,"timestamp":"2026-01-0%dT00:00:00Z","user":"U%d","comment":"edit %d","slots":{"main":%s}}`, i+1, i+1, i+1, i+1, main)
+		}
+		cont := ""
+		if end < len(texts) {
+			cont = fmt.Sprintf(`"continue":{"rvcontinue":"%d","continue":"||"},`, end)
+		}
+		fmt.Fprintf(w, `{%s"query":{"pages":[{"pageid":1,"ns":0,"title":"Town","revisions":[%s]}]}}`, cont, revisions)
+	}))
+	defer srv.Close()
+	client := wiki.NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	ctx := context.Background()
+
+	result, err := SearchPageHistory(ctx, client, srv.URL, "Town", "Founded in 1901", false, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, c := range result.Changes {
+		got = append(got, fmt.Sprintf("%s@%d", c.Change, c.ID))
+	}
+	if fmt.Sprint(got) != "[added@2 removed@3 added@5]" {
+		t.Errorf("changes = %v, want [added@2 removed@3 added@5]", got)
+	}
+	if !result.Present || result.Truncated || result.RevisionsScanned != 5 {
+		t.Errorf("present = %v, truncated = %v, scanned = %d; want true, false, 5", result.Present, result.Truncated, result.RevisionsScanned)
+	}
+	if result.Changes[0].User != "U2" || result.Changes[0].Comment != "edit 2" {
+		t.Errorf("first change = %+v", result.Changes[0])
+	}
+
+	capped, err := SearchPageHistory(ctx, client, srv.URL, "Town", `Founded in \d+`, true, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(capped.Changes) != 2 || !capped.Truncated || capped.Present || capped.RevisionsScanned != 3 {
+		t.Errorf("capped = %+v, want 2 changes, truncated after 3 revisions", capped)
+	}
+
+	if _, err := SearchPageHistory(ctx, client, srv.URL, "Town", "(", true, 3); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
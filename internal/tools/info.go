@@ -12,9 +12,10 @@ import (
 // GetWikiInfo retrieves metadata about a wiki
 func GetWikiInfo(ctx context.Context, client *wiki.Client, wikiURL string) (*wiki.WikiInfo, error) {
 	// Check cache
-	cacheKey := wiki.InfoCacheKey(wikiURL)
-	if cached, ok := client.GetCache().Get(cacheKey); ok {
-		return cached.(*wiki.WikiInfo), nil
+	cacheKey := wiki.InfoCacheKey(wikiURL, wiki.LanguageFromContext(ctx))
+	var cached wiki.WikiInfo
+	if client.GetCachedResult(ctx, cacheKey, &cached) {
+		return &cached, nil
 	}
 
 	// Build API request
@@ -55,7 +56,7 @@ func GetWikiInfo(ctx context.Context, client *wiki.Client, wikiURL string) (*wik
 	}
 
 	// Cache the result
-	client.GetCache().Set(cacheKey, info, client.GetCacheTTLInfo())
+	client.GetCache().SetJSON(cacheKey, info, client.GetCacheTTLInfo())
 
 	return info, nil
 }
@@ -0,0 +1,127 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// LanguageVersionNotFoundError is returned when an article has no version
+// in the requested language
+type LanguageVersionNotFoundError struct {
+	Title     string
+	Language  string
+	Available []string
+}
+
+func (e *LanguageVersionNotFoundError) Error() string {
+	return fmt.Sprintf("%q has no %s version (%d other languages available)", e.Title, e.Language, len(e.Available))
+}
+
+// CompareLanguages compares an article with its version in targetLanguage,
+// found through the article's language links. Sections are aligned with
+// wiki.AlignSections, so the result lists which sections have a
+// counterpart and which exist in only one version: the translation gaps.
+func CompareLanguages(ctx context.Context, client *wiki.Client, wikiURL, title, targetLanguage string) (*wiki.LanguageComparison, error) {
+	targetLanguage = wiki.NormalizeLanguage(targetLanguage)
+	if targetLanguage == "" {
+		return nil, fmt.Errorf("target_language must be a language code such as 'de'")
+	}
+
+	// Check cache
+	cacheKey := wiki.LanguageComparisonCacheKey(wikiURL, title, targetLanguage)
+	var cached wiki.LanguageComparison
+	if client.GetCachedResult(ctx, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	// The source text, language links, and wiki language in one request
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("titles", title)
+	params.Set("prop", "revisions|langlinks")
+	params.Set("rvprop", "content")
+	params.Set("rvslots", "main")
+	params.Set("llprop", "url")
+	params.Set("lllimit", "max")
+	params.Set("meta", "siteinfo")
+	params.Set("siprop", "general")
+	params.Set("redirects", "1")
+
+	resp, err := client.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("get language links: %w", err)
+	}
+
+	if resp.Query == nil || len(resp.Query.Pages) == 0 {
+		return nil, fmt.Errorf("no pages found")
+	}
+
+	page := resp.Query.Pages[0]
+	if page.Missing {
+		return nil, &wiki.APIError{Code: "missingtitle", Message: fmt.Sprintf("page %q does not exist", title)}
+	}
+	if len(page.Revisions) == 0 {
+		return nil, fmt.Errorf("no revisions found")
+	}
+	sourceText := page.Revisions[0].Text()
+
+	source := wiki.LanguageVersion{
+		WikiURL: wikiURL,
+		Title:   page.Title,
+		Bytes:   len(sourceText),
+	}
+	if resp.Query.General != nil {
+		source.Language = resp.Query.General.Lang
+	}
+
+	var link *wiki.MWLangLink
+	available := make([]string, 0, len(page.LangLinks))
+	for i, ll := range page.LangLinks {
+		if strings.EqualFold(ll.Lang, targetLanguage) {
+			link = &page.LangLinks[i]
+		}
+		available = append(available, ll.Lang)
+	}
+	if link == nil {
+		sort.Strings(available)
+		return nil, &LanguageVersionNotFoundError{Title: page.Title, Language: targetLanguage, Available: available}
+	}
+
+	target := wiki.LanguageVersion{
+		Language: link.Lang,
+		WikiURL:  interwikiBaseURL(link.URL),
+		Title:    link.Title,
+		URL:      link.URL,
+	}
+	if target.WikiURL == "" {
+		return nil, fmt.Errorf("cannot resolve the wiki of %s", link.URL)
+	}
+
+	targetText, err := getPageWikitext(ctx, client, target.WikiURL, target.Title)
+	if err != nil {
+		return nil, fmt.Errorf("get %s version: %w", target.Language, err)
+	}
+	target.Bytes = len(targetText)
+
+	source.Sections = len(wiki.SplitSections(sourceText))
+	target.Sections = len(wiki.SplitSections(targetText))
+
+	aligned, missingInTarget, missingInSource := wiki.AlignSections(sourceText, targetText)
+	result := &wiki.LanguageComparison{
+		Source:          source,
+		Target:          target,
+		Aligned:         aligned,
+		MissingInTarget: missingInTarget,
+		MissingInSource: missingInSource,
+	}
+
+	// Cache the result
+	client.GetCache().SetJSON(cacheKey, result, client.GetCacheTTL())
+
+	return result, nil
+}
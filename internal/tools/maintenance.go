@@ -0,0 +1,150 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// reportKind says how to read a maintenance report's value column
+type reportKind int
+
+const (
+	reportListed   reportKind = iota // just titles
+	reportWanted                     // value is the number of links to a missing page
+	reportRedirect                   // extra columns name the redirect target
+)
+
+// maintenanceReports are the special page reports wiki_maintenance_report
+// exposes, by their API names (Special:UnusedFiles is "Unusedimages")
+var maintenanceReports = map[string]reportKind{
+	"Unusedimages":    reportListed,
+	"Wantedfiles":     reportWanted,
+	"Wantedtemplates": reportWanted,
+	"Wantedpages":     reportWanted,
+	"BrokenRedirects": reportRedirect,
+	"DoubleRedirects": reportRedirect,
+}
+
+// MaintenanceReports returns the names of the supported reports, sorted
+func MaintenanceReports() []string {
+	names := make([]string, 0, len(maintenanceReports))
+	for name := range maintenanceReports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetMaintenanceReport retrieves a page of one of the wiki's special page
+// reports. Expensive reports are served from the wiki's cached copy, which
+// it updates periodically; the response says when.
+func GetMaintenanceReport(ctx context.Context, client *wiki.Client, wikiURL, report string, limit, offset int) (*wiki.MaintenanceReport, error) {
+	kind, ok := maintenanceReports[report]
+	if !ok {
+		return nil, fmt.Errorf("unknown report %q, want one of %s", report, strings.Join(MaintenanceReports(), ", "))
+	}
+
+	// Check cache
+	cacheKey := wiki.MaintenanceReportCacheKey(wikiURL, report, limit, offset)
+	var cached wiki.MaintenanceReport
+	if client.GetCachedResult(ctx, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("list", "querypage")
+	params.Set("qppage", report)
+	params.Set("qplimit", strconv.Itoa(limit))
+	if offset > 0 {
+		params.Set("qpoffset", strconv.Itoa(offset))
+	}
+
+	resp, err := client.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("get report: %w", err)
+	}
+
+	if resp.Query == nil || resp.Query.QueryPage == nil {
+		return nil, fmt.Errorf("empty querypage response")
+	}
+	page := resp.Query.QueryPage
+
+	result := &wiki.MaintenanceReport{
+		Report:   report,
+		Entries:  make([]wiki.MaintenanceEntry, 0, len(page.Results)),
+		Cached:   bool(page.Cached),
+		CachedAt: page.CachedTimestamp,
+	}
+	var namespaces map[string]string
+	for _, row := range page.Results {
+		entry := wiki.MaintenanceEntry{Title: row.Title, Timestamp: row.Timestamp}
+		switch kind {
+		case reportWanted:
+			entry.Links, _ = strconv.Atoi(rawString(row.Value))
+		case reportRedirect:
+			// Only uncached reports carry the target
+			if len(row.DatabaseResult) > 0 && namespaces == nil {
+				namespaces = namespaceNames(ctx, client, wikiURL)
+			}
+			entry.Target = resultTitle(row.DatabaseResult, namespaces, "rd_namespace", "rd_title")
+			if entry.Target == "" {
+				entry.Target = resultTitle(row.DatabaseResult, namespaces, "b_namespace", "b_title")
+				entry.FinalTarget = resultTitle(row.DatabaseResult, namespaces, "c_namespace", "c_title")
+			}
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+
+	if next, err := strconv.Atoi(resp.Continue["qpoffset"]); err == nil {
+		result.NextOffset = &next
+	}
+
+	// Cache the result unless targets are missing their namespaces
+	if len(wiki.Warnings(ctx)) == 0 {
+		client.GetCache().SetJSON(cacheKey, result, client.GetCacheTTL())
+	}
+
+	return result, nil
+}
+
+// rawString returns a JSON string's value, or the raw text of any other
+// JSON value, such as a number
+func rawString(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// resultTitle builds a page title from a namespace and title column of a
+// report row, or returns "" if the row lacks them
+func resultTitle(row map[string]json.RawMessage, namespaces map[string]string, nsColumn, titleColumn string) string {
+	title := strings.ReplaceAll(rawString(row[titleColumn]), "_", " ")
+	if title == "" {
+		return ""
+	}
+	if name := namespaces[rawString(row[nsColumn])]; name != "" {
+		return name + ":" + title
+	}
+	return title
+}
+
+// namespaceNames returns the wiki's namespace names by ID, or none if they
+// can't be fetched, leaving titles without their namespace
+func namespaceNames(ctx context.Context, client *wiki.Client, wikiURL string) map[string]string {
+	info, err := GetWikiInfo(ctx, client, wikiURL)
+	if err != nil {
+		wiki.AddWarning(ctx, "namespaces", err)
+		return map[string]string{}
+	}
+	return info.Namespaces
+}
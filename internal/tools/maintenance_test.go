@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+func TestGetMaintenanceReport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case q.Get("meta") == "siteinfo":
+			fmt.Fprint(w, `{"query":{"general":{"sitename":"Test"},"namespaces":{"0":{"id":0,"name":""},"10":{"id":10,"name":"Template"}}}}`)
+		case q.Get("qppage") == "Wantedtemplates":
+			// Cached reports send values as strings and offsets as numbers
+			fmt.Fprint(w, `{"continue":{"qpoffset":2,"continue":"-||"},"query":{"querypage":{"name":"Wantedtemplates","cached":true,"cachedtimestamp":"2026-10-01T04:00:00Z","results":[
+				{"value":"120","ns":10,"title":"Template:Missing"},
+				{"value":"3","ns":10,"title":"Template:Rare"}
+			]}}}`)
+		case q.Get("qppage") == "BrokenRedirects":
+			fmt.Fprint(w, `{"query":{"querypage":{"name":"BrokenRedirects","results":[
+				{"value":0,"ns":0,"title":"Old name","databaseResult":{"rd_namespace":10,"rd_title":"Gone_template"}},
+				{"value":0,"ns":0,"title":"Cached row"}
+			]}}}`)
+		case q.Get("qppage") == "DoubleRedirects":
+			fmt.Fprint(w, `{"query":{"querypage":{"name":"DoubleRedirects","results":[
+				{"value":0,"ns":0,"title":"A","databaseResult":{"b_namespace":"0","b_title":"B","c_namespace":"0","c_title":"C_page"}}
+			]}}}`)
+		}
+	}))
+	defer srv.Close()
+	client := wiki.NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	ctx := context.Background()
+
+	wanted, err := GetMaintenanceReport(ctx, client, srv.URL, "Wantedtemplates", 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(wanted.Entries) != 2 || wanted.Entries[0].Links != 120 || wanted.Entries[1].Title != "Template:Rare" {
+		t.Errorf("entries = %+v", wanted.Entries)
+	}
+	if !wanted.Cached || wanted.CachedAt != "2026-10-01T04:00:00Z" {
+		t.Errorf("cached = %v at %q, want cached at 2026-10-01T04:00:00Z", wanted.Cached, wanted.CachedAt)
+	}
+	if wanted.NextOffset == nil || *wanted.NextOffset != 2 {
+		t.Errorf("next_offset = %v, want 2", wanted.NextOffset)
+	}
+
+	broken, err := GetMaintenanceReport(ctx, client, srv.URL, "BrokenRedirects", 50, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(broken.Entries) != 2 || broken.Entries[0].Target != "Template:Gone template" || broken.Entries[1].Target != "" {
+		t.Errorf("entries = %+v, want the first targeting Template:Gone template", broken.Entries)
+	}
+	if broken.NextOffset != nil {
+		t.Errorf("next_offset = %d on the last page", *broken.NextOffset)
+	}
+
+	double, err := GetMaintenanceReport(ctx, client, srv.URL, "DoubleRedirects", 50, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(double.Entries) != 1 || double.Entries[0].Target != "B" || double.Entries[0].FinalTarget != "C page" {
+		t.Errorf("entries = %+v, want A -> B -> C page", double.Entries)
+	}
+
+	if _, err := GetMaintenanceReport(ctx, client, srv.URL, "Allpages", 50, 0); err == nil {
+		t.Error("unsupported report accepted")
+	}
+}
@@ -2,21 +2,120 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/yourusername/mediawiki-mcp/internal/wiki"
 )
 
+// OutlineOptions controls optional additions to an outline response
+type OutlineOptions struct {
+	IncludeTOC         bool   // render a numbered Markdown table of contents
+	IncludeReadability bool   // measure each section's readability from the wikitext
+	IncludeLinkDetails bool   // return the lead's links with their anchors and text
+	RelevanceQuery     string // score each section's heading and preview against this
+}
+
 // GetPageOutline retrieves page structure without full content
-func GetPageOutline(ctx context.Context, client *wiki.Client, wikiURL, title string) (*wiki.PageOutline, error) {
+func GetPageOutline(ctx context.Context, client *wiki.Client, wikiURL, title string, opts OutlineOptions) (*wiki.PageOutline, error) {
+	outline, err := getBaseOutline(ctx, client, wikiURL, title)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply options to a copy so the cached outline stays untouched
+	result := *outline
+	if !opts.IncludeLinkDetails {
+		result.LinkDetails = nil
+	}
+	if opts.IncludeTOC {
+		result.TOC = RenderTOC(outline.Sections)
+	}
+	if opts.IncludeReadability {
+		text, err := getPageWikitext(ctx, client, wikiURL, outline.Title)
+		if err != nil {
+			return nil, fmt.Errorf("get wikitext: %w", err)
+		}
+		result.Sections = measureSections(outline.Sections, text)
+	}
+	if opts.RelevanceQuery != "" {
+		result.Sections = scoreSections(result.Sections, opts.RelevanceQuery)
+	}
+	return &result, nil
+}
+
+// scoreSections returns a copy of a section tree with each section's
+// relevance to a query, scored across all sections at every level
+func scoreSections(sections []*wiki.Section, query string) []*wiki.Section {
+	flat := flattenSections(sections)
+	docs := make([]wiki.RelevanceDoc, len(flat))
+	for i, sec := range flat {
+		docs[i] = wiki.RelevanceDoc{Heading: sec.Title, Text: sec.Preview}
+	}
+	scores := wiki.ScoreRelevance(query, docs)
+	bySection := make(map[*wiki.Section]float64, len(flat))
+	for i, sec := range flat {
+		bySection[sec] = scores[i]
+	}
+
+	var annotate func(sections []*wiki.Section) []*wiki.Section
+	annotate = func(sections []*wiki.Section) []*wiki.Section {
+		scored := make([]*wiki.Section, len(sections))
+		for i, sec := range sections {
+			copied := *sec
+			score := bySection[sec]
+			copied.Relevance = &score
+			copied.Subsections = annotate(sec.Subsections)
+			scored[i] = &copied
+		}
+		return scored
+	}
+	return annotate(sections)
+}
+
+// measureSections returns a copy of a section tree with each section's
+// readability, measured on its wikitext up to its first subsection
+func measureSections(sections []*wiki.Section, text string) []*wiki.Section {
+	measured := make([]*wiki.Section, len(sections))
+	for i, sec := range sections {
+		copied := *sec
+		if sec.ByteOffset != nil && *sec.ByteOffset < len(text) {
+			start := *sec.ByteOffset
+			end := start + sec.ByteSize
+			if len(sec.Subsections) > 0 && sec.Subsections[0].ByteOffset != nil {
+				end = *sec.Subsections[0].ByteOffset
+			}
+			end = max(start, min(end, len(text)))
+			copied.Readability = wiki.MeasureReadability(text[start:end])
+		}
+		copied.Subsections = measureSections(sec.Subsections, text)
+		measured[i] = &copied
+	}
+	return measured
+}
+
+// getBaseOutline retrieves (and caches) the outline without optional additions
+func getBaseOutline(ctx context.Context, client *wiki.Client, wikiURL, title string) (*wiki.PageOutline, error) {
 	// Check cache
-	cacheKey := wiki.PageCacheKey(wikiURL, title+":outline")
-	if cached, ok := client.GetCache().Get(cacheKey); ok {
-		return cached.(*wiki.PageOutline), nil
+	cacheKey := wiki.OutlineCacheKey(wikiURL, title)
+	var cached wiki.PageOutline
+	if client.GetCachedResult(ctx, cacheKey, &cached) {
+		return &cached, nil
 	}
+	return coalesce(ctx, cacheKey, func(ctx context.Context) (*wiki.PageOutline, error) {
+		return fetchBaseOutline(ctx, client, wikiURL, title, cacheKey)
+	})
+}
+
+// fetchBaseOutline builds the outline from the wiki and caches it under
+// cacheKey
+func fetchBaseOutline(ctx context.Context, client *wiki.Client, wikiURL, title, cacheKey string) (*wiki.PageOutline, error) {
+	ctx = wiki.WithWarnings(ctx)
 
 	// First, get the page structure (sections, categories, links) - NO section parameter
 	params := url.Values{}
@@ -48,19 +147,66 @@ func GetPageOutline(ctx context.Context, client *wiki.Client, wikiURL, title str
 	}
 
 	// Convert lead section HTML to Markdown
-	leadMarkdown, err := wiki.HTMLToMarkdown(leadResp.Parse.Text.Content)
+	lead, err := client.ConvertHTML(ctx, wikiURL, leadResp.Parse.Text.Content)
 	if err != nil {
 		return nil, fmt.Errorf("convert lead to markdown: %w", err)
 	}
-
-	// Extract links from lead
-	summaryLinks := wiki.ExtractLinks(leadResp.Parse.Text.Content)
+	leadMarkdown := lead.Markdown
 
 	// Create summary (first paragraph)
 	summary := wiki.ExtractPreview(leadMarkdown, 100)
 
+	// Wikimedia projects serve a pre-cleaned summary and thumbnail via REST
+	var description string
+	var thumbnail *wiki.Thumbnail
+	if client.ServesREST(wikiURL) {
+		if restSummary, err := client.GetRESTSummary(ctx, wikiURL, title); err != nil {
+			wiki.AddWarning(ctx, "rest_summary", err)
+		} else {
+			if restSummary.Extract != "" {
+				summary = restSummary.Extract
+			}
+			description = restSummary.Description
+			if restSummary.Thumbnail != nil {
+				thumbnail = &wiki.Thumbnail{
+					URL:    restSummary.Thumbnail.Source,
+					Width:  restSummary.Thumbnail.Width,
+					Height: restSummary.Thumbnail.Height,
+				}
+			}
+		}
+	}
+
+	// Get wikitext for the infobox and section sizes
+	var infobox map[string]any
+	var coords []wiki.Coordinate
+	pageBytes := 0
+	wikitext, wikitextErr := getPageWikitext(ctx, client, wikiURL, title)
+	var backendErr *wiki.BackendUnsupportedError
+	if wikitextErr == nil {
+		infobox = wiki.ExtractInfobox(wikitext)
+		pageBytes = len(wikitext)
+	} else if !errors.As(wikitextErr, &backendErr) {
+		// Offline backends have no wikitext, so there is nothing missing
+		wiki.AddWarning(ctx, "infobox", wikitextErr)
+	}
+
+	// Location of the page's subject, from GeoData or the wikitext
+	geo, geoErr := getGeoDataCoordinates(ctx, client, wikiURL, title)
+	if geoErr != nil {
+		wiki.AddWarning(ctx, "coordinates", geoErr)
+	}
+	if len(geo) > 0 {
+		coords = geo
+	} else if wikitextErr == nil {
+		coords = wiki.ExtractCoordinates(wikitext)
+	}
+
+	// WikiProject ratings, where the wiki tracks them
+	assessments := getPageAssessments(ctx, client, wikiURL, title)
+
 	// Build sections tree
-	sections := buildSectionsTree(resp.Parse.Sections, wikiURL, title, leadMarkdown)
+	sections := buildSectionsTree(resp.Parse.Sections, wikiURL, title, lead, pageBytes)
 
 	// Extract categories
 	categories := make([]string, 0, len(resp.Parse.Categories))
@@ -74,39 +220,49 @@ func GetPageOutline(ctx context.Context, client *wiki.Client, wikiURL, title str
 	seeAlso := extractSeeAlsoLinks(resp.Parse.Links)
 
 	// Calculate total word count
-	totalWords := wiki.CountWords(leadMarkdown)
+	// (the lead is part of the tree unless the page has no headings)
+	totalWords := 0
+	if len(sections) == 0 {
+		totalWords = lead.ProseWordCount
+	}
 	for _, section := range sections {
 		totalWords += section.WordCount
 		totalWords += countSubsectionWords(section)
 	}
 
-	// Get infobox from wikitext
-	var infobox map[string]any
-	if wikitext, err := getPageWikitext(ctx, client, wikiURL, title); err == nil {
-		infobox = wiki.ExtractInfobox(wikitext)
-	}
-
 	// Build response
 	outline := &wiki.PageOutline{
 		Title:          resp.Parse.Title,
 		Exists:         true,
+		Description:    description,
 		Summary:        summary,
-		SummaryLinks:   summaryLinks,
+		Thumbnail:      thumbnail,
+		SummaryLinks:   slices.Clone(lead.Links),
+		InternalTitles: lead.Links,
+		ExternalURLs:   lead.ExternalURLs,
+		LinkDetails:    lead.LinkDetails,
 		Infobox:        infobox,
+		Coordinates:    primaryCoordinate(coords),
+		Assessments:    assessments,
 		Sections:       sections,
 		Categories:     categories,
 		SeeAlso:        seeAlso,
 		TotalWordCount: totalWords,
+		Source:         client.NewProvenance(ctx, wikiURL, resp.Parse.Title, resp.Parse.RevID),
 	}
 
-	// Cache the result
-	client.GetCache().Set(cacheKey, outline, client.GetCacheTTL())
+	// Cache the result unless parts of it are missing. The REST summary only
+	// adds to what the wiki parsed, so the outline is cached without it.
+	if !slices.ContainsFunc(wiki.Warnings(ctx), func(w wiki.Warning) bool { return w.Enrichment != "rest_summary" }) {
+		client.GetCache().SetJSON(cacheKey, outline, client.GetCacheTTL())
+	}
 
 	return outline, nil
 }
 
-// buildSectionsTree builds a hierarchical section structure
-func buildSectionsTree(mwSections []wiki.MWSection, wikiURL, title, leadContent string) []*wiki.Section {
+// buildSectionsTree builds a hierarchical section structure. pageBytes is the
+// wikitext length used to size the final section (0 if unknown).
+func buildSectionsTree(mwSections []wiki.MWSection, wikiURL, title string, lead *wiki.ConvertedContent, pageBytes int) []*wiki.Section {
 	if len(mwSections) == 0 {
 		return []*wiki.Section{}
 	}
@@ -115,29 +271,37 @@ func buildSectionsTree(mwSections []wiki.MWSection, wikiURL, title, leadContent
 
 	// Add lead section
 	leadSection := &wiki.Section{
-		Index:     0,
-		Title:     "Lead",
-		Level:     1,
-		Preview:   wiki.ExtractPreview(leadContent, 50),
-		WordCount: wiki.CountWords(leadContent),
+		Index:          0,
+		Title:          "Lead",
+		Level:          1,
+		Preview:        wiki.ExtractPreview(lead.Markdown, 50),
+		WordCount:      lead.ProseWordCount,
+		TableWordCount: lead.TableWordCount,
+	}
+	if offset := mwSections[0].ByteOffset; offset != nil {
+		zero := 0
+		leadSection.ByteOffset = &zero
+		leadSection.ByteSize = *offset
 	}
 	sections = append(sections, leadSection)
 
 	// Build section hierarchy
 	stack := []*wiki.Section{}
 
-	for _, mwSec := range mwSections {
+	for i, mwSec := range mwSections {
 		index, _ := strconv.Atoi(mwSec.Index)
 		level := mwSec.TocLevel
 
 		section := &wiki.Section{
 			Index:       index,
+			Number:      mwSec.Number,
 			Title:       mwSec.Line,
 			Level:       level + 1, // Adjust level (+1 because lead is 1)
 			Preview:     "",        // Will be filled if we fetch content
 			WordCount:   0,         // Estimated or fetch later
 			Subsections: []*wiki.Section{},
 		}
+		section.ByteOffset, section.ByteSize = sectionSpan(mwSections, i, pageBytes)
 
 		// Pop stack until we find the parent level
 		for len(stack) > 0 && stack[len(stack)-1].Level >= section.Level {
@@ -159,6 +323,55 @@ func buildSectionsTree(mwSections []wiki.MWSection, wikiURL, title, leadContent
 	return sections
 }
 
+// sectionSpan returns the byte offset of a section and the size of its
+// wikitext including subsections, i.e. what fetching the section returns.
+// Sections transcluded from other pages have no offset.
+func sectionSpan(mwSections []wiki.MWSection, i, pageBytes int) (*int, int) {
+	sec := mwSections[i]
+	if sec.ByteOffset == nil {
+		return nil, 0
+	}
+
+	offset := *sec.ByteOffset
+	for _, next := range mwSections[i+1:] {
+		if next.TocLevel <= sec.TocLevel && next.ByteOffset != nil {
+			return &offset, *next.ByteOffset - offset
+		}
+	}
+
+	if pageBytes > offset {
+		return &offset, pageBytes - offset
+	}
+	return &offset, 0
+}
+
+// RenderTOC renders a section tree as a nested Markdown list numbered like
+// MediaWiki's own table of contents. The lead section is omitted.
+func RenderTOC(sections []*wiki.Section) string {
+	var b strings.Builder
+
+	var render func(secs []*wiki.Section, depth int)
+	render = func(secs []*wiki.Section, depth int) {
+		for _, sec := range secs {
+			if sec.Index == 0 {
+				continue
+			}
+			b.WriteString(strings.Repeat("  ", depth))
+			b.WriteString("- ")
+			if sec.Number != "" {
+				b.WriteString(sec.Number)
+				b.WriteString(" ")
+			}
+			b.WriteString(sec.Title)
+			b.WriteString("\n")
+			render(sec.Subsections, depth+1)
+		}
+	}
+
+	render(sections, 0)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
 // countSubsectionWords recursively counts words in subsections
 func countSubsectionWords(section *wiki.Section) int {
 	count := 0
@@ -226,9 +439,55 @@ func getPageWikitext(ctx context.Context, client *wiki.Client, wikiURL, title st
 	// Get the first (and only) page
 	for _, page := range resp.Query.Pages {
 		if len(page.Revisions) > 0 {
-			return page.Revisions[0].Content, nil
+			return page.Revisions[0].Text(), nil
 		}
 	}
 
 	return "", fmt.Errorf("no revisions found")
 }
+
+// getPageAssessments returns a page's WikiProject assessments sorted by
+// project, or nil when the wiki lacks the PageAssessments extension or the
+// page is unassessed. Failures are non-fatal: they also yield nil, with a
+// warning.
+func getPageAssessments(ctx context.Context, client *wiki.Client, wikiURL, title string) []wiki.PageAssessment {
+	caps, err := client.GetCapabilities(ctx, wikiURL)
+	if err != nil {
+		wiki.AddWarning(ctx, "assessments", err)
+		return nil
+	}
+	if !caps.HasExtension("PageAssessments") {
+		return nil
+	}
+
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("titles", title)
+	params.Set("prop", "pageassessments")
+	params.Set("palimit", "max")
+	params.Set("redirects", "1")
+
+	resp, err := client.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		wiki.AddWarning(ctx, "assessments", err)
+		return nil
+	}
+	if resp.Query == nil {
+		return nil
+	}
+
+	var assessments []wiki.PageAssessment
+	for _, page := range resp.Query.Pages {
+		for project, rating := range page.PageAssessments {
+			assessments = append(assessments, wiki.PageAssessment{
+				Project:    project,
+				Class:      rating.Class,
+				Importance: rating.Importance,
+			})
+		}
+	}
+	sort.Slice(assessments, func(i, j int) bool {
+		return assessments[i].Project < assessments[j].Project
+	})
+	return assessments
+}
@@ -0,0 +1,272 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// newTestWiki serves a two-section page and counts API requests
+func newTestWiki(t *testing.T) (*wiki.Client, string, *int64) {
+	t.Helper()
+
+	var requests int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&requests, 1)
+		q := r.URL.Query()
+
+		switch {
+		case q.Get("action") == "parse" && q.Get("prop") == "sections|categories|links":
+			fmt.Fprint(w, `{"parse":{"title":"Test","revid":42,"sections":[
+				{"toclevel":1,"level":"2","line":"History","number":"1","index":"1","byteoffset":5},
+				{"toclevel":2,"level":"3","line":"Early years","number":"1.1","index":"2","byteoffset":24}
+			]}}`)
+		case q.Get("action") == "parse":
+			fmt.Fprintf(w, `{"parse":{"title":"Test","revid":42,"text":{"*":"<p>Section %s text with a <a href=\"/wiki/Link\">link</a>, its <a href=\"/wiki/Link#Early_life\">early life</a>, and a <a class=\"external\" href=\"https://example.com/source\">source</a>.</p>"}}}`, q.Get("section"))
+		case q.Get("prop") == "info":
+			fmt.Fprint(w, `{"query":{"pages":[{"title":"Test","lastrevid":42}]}}`)
+		case q.Get("meta") == "siteinfo":
+			fmt.Fprint(w, `{"query":{"general":{"server":"//wiki.example.org","articlepath":"/wiki/$1"},"extensions":[]}}`)
+		default:
+			fmt.Fprint(w, `{"query":{"pages":[{"title":"Test","revisions":[{"slots":{"main":{"content":"Lead\n== History ==\nText\n=== Early years ===\nMore"}}}]}]}}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client := wiki.NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	return client, srv.URL, &requests
+}
+
+func TestCachedOutlineIsNotAliased(t *testing.T) {
+	client, wikiURL, requests := newTestWiki(t)
+	ctx := context.Background()
+
+	first, err := GetPageOutline(ctx, client, wikiURL, "Test", OutlineOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.Sections) != 2 || len(first.Sections[1].Subsections) != 1 {
+		t.Fatalf("unexpected section tree: %+v", first.Sections)
+	}
+	fetched := atomic.LoadInt64(requests)
+
+	// Mutate everything a caller might touch
+	first.Title = "Changed"
+	first.Sections[1].Title = "Changed"
+	first.Sections[1].Subsections = append(first.Sections[1].Subsections, &wiki.Section{Title: "Extra"})
+	first.Sections = first.Sections[:0]
+	first.SummaryLinks = append(first.SummaryLinks[:0], "Changed")
+
+	second, err := GetPageOutline(ctx, client, wikiURL, "Test", OutlineOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt64(requests); n != fetched {
+		t.Fatalf("second outline made %d requests, want it served from cache", n-fetched)
+	}
+	if second.Title != "Test" {
+		t.Errorf("title = %q, want %q", second.Title, "Test")
+	}
+	if len(second.Sections) != 2 || second.Sections[1].Title != "History" {
+		t.Fatalf("cached sections were modified: %+v", second.Sections)
+	}
+	if len(second.Sections[1].Subsections) != 1 {
+		t.Errorf("cached subsections were modified: %+v", second.Sections[1].Subsections)
+	}
+	if len(second.SummaryLinks) != 1 || second.SummaryLinks[0] != "Link" {
+		t.Errorf("cached summary links were modified: %v", second.SummaryLinks)
+	}
+}
+
+func TestLinksSplitAndDetailed(t *testing.T) {
+	client, wikiURL, _ := newTestWiki(t)
+	ctx := context.Background()
+
+	outline, err := GetPageOutline(ctx, client, wikiURL, "Test", OutlineOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(outline.InternalTitles, []string{"Link"}) {
+		t.Errorf("outline internal_titles = %v, want [Link]", outline.InternalTitles)
+	}
+	if !reflect.DeepEqual(outline.ExternalURLs, []string{"https://example.com/source"}) {
+		t.Errorf("outline external_urls = %v, want the source", outline.ExternalURLs)
+	}
+
+	section, err := GetPageSection(ctx, client, wikiURL, "Test", 1, SectionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(section.ExternalURLs, []string{"https://example.com/source"}) {
+		t.Errorf("section external_urls = %v, want the source", section.ExternalURLs)
+	}
+	if outline.LinkDetails != nil || section.LinkDetails != nil {
+		t.Error("link details returned without include_link_details")
+	}
+
+	want := []wiki.Link{{Title: "Link", DisplayText: "link"}, {Title: "Link", Anchor: "Early life", DisplayText: "early life"}}
+	outline, err = GetPageOutline(ctx, client, wikiURL, "Test", OutlineOptions{IncludeLinkDetails: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(outline.LinkDetails, want) {
+		t.Errorf("outline link_details = %+v, want %+v", outline.LinkDetails, want)
+	}
+	section, err = GetPageSection(ctx, client, wikiURL, "Test", 1, SectionOptions{IncludeLinkDetails: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(section.LinkDetails, want) {
+		t.Errorf("section link_details = %+v, want %+v", section.LinkDetails, want)
+	}
+}
+
+func TestOutlineReadability(t *testing.T) {
+	client, wikiURL, _ := newTestWiki(t)
+	ctx := context.Background()
+
+	outline, err := GetPageOutline(ctx, client, wikiURL, "Test", OutlineOptions{IncludeReadability: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sections := flattenSections(outline.Sections)
+	if len(sections) != 3 {
+		t.Fatalf("got %d sections, want 3", len(sections))
+	}
+	// Each section is measured without its subsections
+	for _, section := range sections {
+		if section.Readability == nil || section.Readability.Words != 1 || section.Readability.Sentences != 1 {
+			t.Errorf("%s readability = %+v, want 1 sentence of 1 word", section.Title, section.Readability)
+		}
+	}
+
+	plain, err := GetPageOutline(ctx, client, wikiURL, "Test", OutlineOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, section := range flattenSections(plain.Sections) {
+		if section.Readability != nil {
+			t.Errorf("cached outline has readability for %s", section.Title)
+		}
+	}
+}
+
+func TestOutlineRelevance(t *testing.T) {
+	client, wikiURL, _ := newTestWiki(t)
+	ctx := context.Background()
+
+	plain, err := GetPageOutline(ctx, client, wikiURL, "Test", OutlineOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := flattenSections(plain.Sections)[1].Title
+
+	outline, err := GetPageOutline(ctx, client, wikiURL, "Test", OutlineOptions{RelevanceQuery: target})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, section := range flattenSections(outline.Sections) {
+		if section.Relevance == nil {
+			t.Fatalf("%s has no relevance", section.Title)
+		}
+		if section.Title == target && *section.Relevance != 1 {
+			t.Errorf("%s relevance = %v, want 1", section.Title, *section.Relevance)
+		}
+	}
+	for _, section := range flattenSections(plain.Sections) {
+		if section.Relevance != nil {
+			t.Errorf("cached outline has relevance for %s", section.Title)
+		}
+	}
+}
+
+func TestProvenance(t *testing.T) {
+	client, wikiURL, _ := newTestWiki(t)
+	ctx := context.Background()
+
+	outline, err := GetPageOutline(ctx, client, wikiURL, "Test", OutlineOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := wiki.Provenance{RevID: 42, FetchedAt: outline.Source.FetchedAt, URL: "http://wiki.example.org/wiki/Test"}
+	if *outline.Source != want {
+		t.Errorf("outline source = %+v, want %+v", *outline.Source, want)
+	}
+	if outline.Source.FetchedAt.IsZero() {
+		t.Error("outline source has no fetch time")
+	}
+
+	section, err := GetPageSection(ctx, client, wikiURL, "Test", 1, SectionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if section.Source == nil || section.Source.RevID != 42 || section.Source.URL != want.URL {
+		t.Errorf("section source = %+v, want revid 42 at %s", section.Source, want.URL)
+	}
+
+	// A cached copy keeps the time it was fetched from the wiki
+	cached, err := GetPageOutline(ctx, client, wikiURL, "Test", OutlineOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cached.Source.FetchedAt.Equal(outline.Source.FetchedAt) {
+		t.Errorf("cached fetched_at = %v, want %v", cached.Source.FetchedAt, outline.Source.FetchedAt)
+	}
+}
+
+// TestCachedValuesConcurrentUse is meant for -race: callers mutating their
+// results while others read the same cache entries must not share memory
+func TestCachedValuesConcurrentUse(t *testing.T) {
+	client, wikiURL, _ := newTestWiki(t)
+	ctx := context.Background()
+
+	if _, err := GetPageSection(ctx, client, wikiURL, "Test", 2, SectionOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			outline, err := GetPageOutline(ctx, client, wikiURL, "Test", OutlineOptions{IncludeTOC: i%2 == 0})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			for _, section := range flattenSections(outline.Sections) {
+				section.Title = fmt.Sprintf("caller %d", i)
+				section.Subsections = nil
+			}
+
+			section, err := GetPageSection(ctx, client, wikiURL, "Test", 2, SectionOptions{})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if section.Section.Title != "Early years" {
+				t.Errorf("section title = %q, want %q", section.Section.Title, "Early years")
+			}
+			section.Section.Content = ""
+			section.Section.Links = append(section.Section.Links, "Extra")
+		}(i)
+	}
+	wg.Wait()
+
+	outline, err := GetPageOutline(ctx, client, wikiURL, "Test", OutlineOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outline.Sections[1].Title != "History" || outline.Sections[1].Subsections[0].Title != "Early years" {
+		t.Errorf("cached outline was modified: %+v", outline.Sections)
+	}
+}
@@ -0,0 +1,56 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// PreviewEdit renders the text an edit would save, as Markdown, without
+// saving it: the whole page, the section, or the new section with its
+// heading. The text an undo saves is the wiki's to work out, so undos have
+// no preview.
+func PreviewEdit(ctx context.Context, client *wiki.Client, wikiURL string, edit wiki.EditParams) (string, error) {
+	if edit.Undo > 0 {
+		return "", nil
+	}
+	text := edit.Text
+	if edit.Section == "new" && edit.SectionTitle != "" {
+		text = "== " + edit.SectionTitle + " ==\n" + text
+	}
+	return PreviewWikitext(ctx, client, wikiURL, edit.Title, text)
+}
+
+// PreviewWikitext renders wikitext as it would appear on the page title,
+// with action=parse, and returns it as Markdown. The pre-save transform is
+// applied first, so signatures and substitutions show as they would be
+// saved.
+func PreviewWikitext(ctx context.Context, client *wiki.Client, wikiURL, title, text string) (string, error) {
+	params := url.Values{}
+	params.Set("action", "parse")
+	params.Set("title", title)
+	params.Set("text", text)
+	params.Set("contentmodel", "wikitext")
+	params.Set("pst", "1")
+	params.Set("prop", "text")
+	params.Set("disableeditsection", "1")
+	params.Set("disabletoc", "1")
+
+	// The text can exceed URL length limits
+	resp, err := client.MakePostRequest(ctx, wikiURL, params)
+	if err != nil {
+		return "", fmt.Errorf("preview: %w", err)
+	}
+
+	if resp.Parse == nil {
+		return "", fmt.Errorf("empty parse response")
+	}
+
+	converted, err := client.ConvertHTML(ctx, wikiURL, resp.Parse.Text.Content)
+	if err != nil {
+		return "", fmt.Errorf("convert to markdown: %w", err)
+	}
+	return converted.Markdown, nil
+}
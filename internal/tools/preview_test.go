@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+func TestPreviewEdit(t *testing.T) {
+	var parsed string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("action") != "parse" {
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		if r.Method != http.MethodPost || r.Form.Get("pst") != "1" || r.Form.Get("title") != "Talk:Town" {
+			t.Errorf("parse request = %s %v", r.Method, r.Form)
+		}
+		parsed = r.Form.Get("text")
+		fmt.Fprint(w, `{"parse":{"title":"Talk:Town","pageid":0,"text":"<h2>Population</h2><p>The <b>census</b> figure is out of date.</p>"}}`)
+	}))
+	defer srv.Close()
+	client := wiki.NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	ctx := context.Background()
+
+	preview, err := PreviewEdit(ctx, client, srv.URL, wiki.EditParams{
+		Title:        "Talk:Town",
+		Section:      "new",
+		SectionTitle: "Population",
+		Text:         "The '''census''' figure is out of date. ~~~~",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed != "== Population ==\nThe '''census''' figure is out of date. ~~~~" {
+		t.Errorf("parsed text = %q", parsed)
+	}
+	if !strings.Contains(preview, "**census**") {
+		t.Errorf("preview = %q, want Markdown", preview)
+	}
+
+	// The wiki works out an undo's text, so there's nothing to render
+	parsed = ""
+	preview, err = PreviewEdit(ctx, client, srv.URL, wiki.EditParams{Title: "Talk:Town", Undo: 11, UndoAfter: 10})
+	if err != nil || preview != "" || parsed != "" {
+		t.Errorf("undo preview = %q, %v", preview, err)
+	}
+}
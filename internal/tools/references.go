@@ -0,0 +1,48 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// GetReferences retrieves a page's references with their scholarly
+// identifiers (DOI, ISBN, PMID, PMC, arXiv) normalized, plus the distinct
+// identifiers across all references
+func GetReferences(ctx context.Context, client *wiki.Client, wikiURL, title string) (*wiki.ReferencesResponse, error) {
+	// Check cache
+	cacheKey := wiki.ReferencesCacheKey(wikiURL, title)
+	var cached wiki.ReferencesResponse
+	if client.GetCachedResult(ctx, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	wikitext, err := getPageWikitext(ctx, client, wikiURL, title)
+	if err != nil {
+		return nil, fmt.Errorf("get references: %w", err)
+	}
+
+	refs := wiki.ExtractReferences(wikitext)
+	result := &wiki.ReferencesResponse{
+		Title:           title,
+		References:      refs,
+		Identifiers:     make([]wiki.Identifier, 0),
+		TotalReferences: len(refs),
+	}
+
+	seen := make(map[wiki.Identifier]bool)
+	for _, ref := range refs {
+		for _, id := range ref.Identifiers {
+			if !seen[id] {
+				seen[id] = true
+				result.Identifiers = append(result.Identifiers, id)
+			}
+		}
+	}
+
+	// Cache the result
+	client.GetCache().SetJSON(cacheKey, result, client.GetCacheTTL())
+
+	return result, nil
+}
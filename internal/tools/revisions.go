@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// maxTitlesPerQuery is the API limit on titles per request for normal users
+const maxTitlesPerQuery = 50
+
+// GetLatestRevisions returns the current revision ID of each title, keyed by
+// the title as given. Missing pages map to 0.
+func GetLatestRevisions(ctx context.Context, client *wiki.Client, wikiURL string, titles []string) (map[string]int, error) {
+	revisions := make(map[string]int, len(titles))
+
+	for start := 0; start < len(titles); start += maxTitlesPerQuery {
+		end := start + maxTitlesPerQuery
+		if end > len(titles) {
+			end = len(titles)
+		}
+		batch := titles[start:end]
+
+		params := url.Values{}
+		params.Set("action", "query")
+		params.Set("titles", strings.Join(batch, "|"))
+		params.Set("prop", "info")
+
+		resp, err := client.MakeRequest(ctx, wikiURL, params)
+		if err != nil {
+			return nil, fmt.Errorf("get latest revisions: %w", err)
+		}
+		if resp.Query == nil {
+			return nil, fmt.Errorf("empty query response")
+		}
+
+		// Map API-normalized titles back to the requested ones
+		requested := make(map[string]string, len(batch))
+		for _, title := range batch {
+			requested[title] = title
+		}
+		for _, n := range resp.Query.Normalized {
+			requested[n.To] = n.From
+		}
+
+		for _, page := range resp.Query.Pages {
+			title, ok := requested[page.Title]
+			if !ok {
+				title = page.Title
+			}
+			if page.Missing {
+				revisions[title] = 0
+				continue
+			}
+			revisions[title] = page.LastRevID
+		}
+	}
+
+	return revisions, nil
+}
@@ -0,0 +1,43 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// GetInfoboxSchema infers the implicit schema of the infoboxes in a
+// category from its first sampleSize pages: the union of their fields, with
+// fill rates, value types, and examples. With template set, only that
+// template is read, e.g. "Infobox settlement" or a data template like
+// "Chembox"; otherwise each page's first infobox is.
+func GetInfoboxSchema(ctx context.Context, client *wiki.Client, wikiURL, category, template string, sampleSize, examples int) (*wiki.InfoboxSchema, error) {
+	members, err := GetCategory(ctx, client, wikiURL, category, sampleSize, CategoryOptions{MemberType: "page"})
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make([]string, 0, len(members.Members))
+	for _, m := range members.Members {
+		titles = append(titles, m.Title)
+	}
+
+	wikitexts, err := getPagesWikitext(ctx, client, wikiURL, titles)
+	if err != nil {
+		return nil, fmt.Errorf("get infobox schema: %w", err)
+	}
+
+	infoboxes := make([]*wiki.Infobox, 0, len(titles))
+	for _, title := range titles {
+		if text, ok := wikitexts[title]; ok {
+			infoboxes = append(infoboxes, wiki.FindInfobox(text, template))
+		}
+	}
+
+	schema := wiki.InferInfoboxSchema(infoboxes, examples)
+	schema.Category = members.Category
+	schema.Template = template
+	schema.PagesSampled = len(infoboxes)
+	return schema, nil
+}
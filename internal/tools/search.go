@@ -5,24 +5,52 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/yourusername/mediawiki-mcp/internal/wiki"
 )
 
+// SearchOptions narrows a search
+type SearchOptions struct {
+	InCategory string // only return pages in this category
+}
+
 // SearchWiki searches for pages by keyword
-func SearchWiki(ctx context.Context, client *wiki.Client, wikiURL, query string, limit int) (*wiki.SearchResponse, error) {
+func SearchWiki(ctx context.Context, client *wiki.Client, wikiURL, query string, limit int, opts SearchOptions) (*wiki.SearchResponse, error) {
 	// Check cache
-	cacheKey := wiki.SearchCacheKey(wikiURL, query+":"+strconv.Itoa(limit))
-	if cached, ok := client.GetCache().Get(cacheKey); ok {
-		return cached.(*wiki.SearchResponse), nil
+	cacheKey := wiki.SearchCacheKey(wikiURL, query, limit, opts.InCategory)
+	var cached wiki.SearchResponse
+	if client.GetCachedResult(ctx, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	srsearch := query
+	srlimit := limit
+	filterCategory := ""
+	if opts.InCategory != "" {
+		category := strings.TrimPrefix(opts.InCategory, "Category:")
+		if caps, err := client.GetCapabilities(ctx, wikiURL); err == nil && caps.HasExtension("CirrusSearch") {
+			// CirrusSearch intersects natively
+			srsearch = fmt.Sprintf("%s incategory:%q", query, category)
+		} else {
+			// Over-fetch and filter by membership afterwards
+			filterCategory = "Category:" + category
+			srlimit = limit * 5
+			if srlimit < 50 {
+				srlimit = 50
+			}
+			if srlimit > 500 {
+				srlimit = 500
+			}
+		}
 	}
 
 	// Build API request
 	params := url.Values{}
 	params.Set("action", "query")
 	params.Set("list", "search")
-	params.Set("srsearch", query)
-	params.Set("srlimit", strconv.Itoa(limit))
+	params.Set("srsearch", srsearch)
+	params.Set("srlimit", strconv.Itoa(srlimit))
 	params.Set("srprop", "snippet|wordcount")
 
 	// Make request
@@ -35,27 +63,43 @@ func SearchWiki(ctx context.Context, client *wiki.Client, wikiURL, query string,
 		return nil, fmt.Errorf("empty query response")
 	}
 
-	// Build response
-	searchResp := &wiki.SearchResponse{
-		Results:   make([]wiki.SearchResult, 0, len(resp.Query.Search)),
-		TotalHits: len(resp.Query.Search),
+	// Collapse redirects into their targets; a failed lookup just leaves
+	// them uncollapsed
+	hits := resp.Query.Search
+	viaRedirect := make(map[string]string)
+	revisions := make(map[string]int)
+	degraded := false
+	if collapsed, via, revs, err := collapseRedirects(ctx, client, wikiURL, hits); err != nil {
+		wiki.AddWarning(ctx, "redirect_collapse", err)
+		degraded = true
+	} else {
+		hits, viaRedirect, revisions = collapsed, via, revs
 	}
 
-	for _, result := range resp.Query.Search {
-		// Convert HTML snippet to markdown
-		markdown, err := wiki.HTMLToMarkdown(result.Snippet)
+	if filterCategory != "" {
+		hits, err = filterSearchByCategory(ctx, client, wikiURL, hits, filterCategory, limit)
 		if err != nil {
-			markdown = result.Snippet // fallback to raw HTML
+			return nil, fmt.Errorf("filter by category: %w", err)
 		}
+	}
+
+	// Build response
+	searchResp := &wiki.SearchResponse{
+		Results:   make([]wiki.SearchResult, 0, len(hits)),
+		TotalHits: len(hits),
+	}
 
+	for _, result := range hits {
 		// Extract links from snippet
 		links := wiki.ExtractLinks(result.Snippet)
 
 		searchResp.Results = append(searchResp.Results, wiki.SearchResult{
 			Title:        result.Title,
-			Snippet:      markdown,
+			Snippet:      wiki.CleanSnippet(result.Snippet),
 			SnippetLinks: links,
 			WordCount:    result.WordCount,
+			ViaRedirect:  viaRedirect[result.Title],
+			Source:       client.NewProvenance(ctx, wikiURL, result.Title, revisions[result.Title]),
 		})
 	}
 
@@ -64,8 +108,134 @@ func SearchWiki(ctx context.Context, client *wiki.Client, wikiURL, query string,
 		searchResp.Suggestion = &resp.Query.SearchInfo.Suggestion
 	}
 
-	// Cache the result (short TTL for search)
-	client.GetCache().Set(cacheKey, searchResp, 1*60) // 1 minute
+	// Cache the result (short TTL for search) unless parts of it are missing
+	if !degraded {
+		client.GetCache().SetJSON(cacheKey, searchResp, 1*60) // 1 minute
+	}
 
 	return searchResp, nil
 }
+
+// collapseRedirects replaces search hits on redirects with their targets
+// and merges hits on the same page, keeping the best-ranked position and
+// the target's own snippet when it matched too. It returns the merged hits,
+// the redirect's title for those that came from a redirect, and the current
+// revision ID of each page.
+func collapseRedirects(ctx context.Context, client *wiki.Client, wikiURL string, hits []wiki.MWSearchResult) ([]wiki.MWSearchResult, map[string]string, map[string]int, error) {
+	targets := make(map[string]string)
+	revisions := make(map[string]int)
+
+	for start := 0; start < len(hits); start += maxTitlesPerQuery {
+		end := start + maxTitlesPerQuery
+		if end > len(hits) {
+			end = len(hits)
+		}
+
+		titles := make([]string, 0, end-start)
+		for _, hit := range hits[start:end] {
+			titles = append(titles, hit.Title)
+		}
+
+		params := url.Values{}
+		params.Set("action", "query")
+		params.Set("titles", strings.Join(titles, "|"))
+		params.Set("prop", "info")
+		params.Set("redirects", "1")
+
+		resp, err := client.MakeRequest(ctx, wikiURL, params)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if resp.Query == nil {
+			continue
+		}
+
+		for _, r := range resp.Query.Redirects {
+			targets[r.From] = r.To
+		}
+		for _, page := range resp.Query.Pages {
+			revisions[page.Title] = page.LastRevID
+		}
+	}
+
+	collapsed := make([]wiki.MWSearchResult, 0, len(hits))
+	via := make(map[string]string)
+	position := make(map[string]int)
+	for _, hit := range hits {
+		target, redirected := targets[hit.Title]
+		if !redirected {
+			target = hit.Title
+		}
+
+		i, seen := position[target]
+		switch {
+		case !seen:
+			position[target] = len(collapsed)
+			if redirected {
+				via[target] = hit.Title
+			}
+			hit.Title = target
+			collapsed = append(collapsed, hit)
+		case !redirected:
+			// The target itself matched after one of its redirects: keep
+			// the redirect's rank but the target's snippet
+			hit.Title = target
+			collapsed[i] = hit
+		case via[target] == "":
+			via[target] = hit.Title
+		}
+	}
+
+	return collapsed, via, revisions, nil
+}
+
+// filterSearchByCategory keeps the search hits that belong to a category,
+// checking membership in batches with prop=categories
+func filterSearchByCategory(ctx context.Context, client *wiki.Client, wikiURL string, hits []wiki.MWSearchResult, category string, limit int) ([]wiki.MWSearchResult, error) {
+	members := make(map[string]bool)
+
+	for start := 0; start < len(hits); start += 50 {
+		end := start + 50
+		if end > len(hits) {
+			end = len(hits)
+		}
+
+		titles := make([]string, 0, end-start)
+		for _, hit := range hits[start:end] {
+			titles = append(titles, hit.Title)
+		}
+
+		params := url.Values{}
+		params.Set("action", "query")
+		params.Set("titles", strings.Join(titles, "|"))
+		params.Set("prop", "categories")
+		params.Set("clcategories", category)
+		params.Set("cllimit", "max")
+
+		resp, err := client.MakeRequest(ctx, wikiURL, params)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Query == nil {
+			continue
+		}
+
+		for _, page := range resp.Query.Pages {
+			if len(page.Categories) > 0 {
+				members[page.Title] = true
+			}
+		}
+	}
+
+	filtered := make([]wiki.MWSearchResult, 0, limit)
+	for _, hit := range hits {
+		if members[hit.Title] {
+			filtered = append(filtered, hit)
+			if len(filtered) >= limit {
+				break
+			}
+		}
+	}
+
+	return filtered, nil
+}
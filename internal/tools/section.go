@@ -2,6 +2,7 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -9,16 +10,41 @@ import (
 	"github.com/yourusername/mediawiki-mcp/internal/wiki"
 )
 
+// SectionOptions controls optional additions to a section response
+type SectionOptions struct {
+	IncludeLinkDetails bool // return the section's links with their anchors and text
+}
+
 // GetPageSection retrieves a specific section of a page
-func GetPageSection(ctx context.Context, client *wiki.Client, wikiURL, title string, sectionIndex int) (*wiki.PageSection, error) {
+func GetPageSection(ctx context.Context, client *wiki.Client, wikiURL, title string, sectionIndex int, opts SectionOptions) (*wiki.PageSection, error) {
+	section, err := getPageSection(ctx, client, wikiURL, title, sectionIndex)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.IncludeLinkDetails {
+		section.LinkDetails = nil
+	}
+	return section, nil
+}
+
+// getPageSection retrieves a section with every optional part, as cached
+func getPageSection(ctx context.Context, client *wiki.Client, wikiURL, title string, sectionIndex int) (*wiki.PageSection, error) {
 	// Check cache
-	cacheKey := wiki.SectionCacheKey(wikiURL, title, strconv.Itoa(sectionIndex))
-	if cached, ok := client.GetCache().Get(cacheKey); ok {
-		return cached.(*wiki.PageSection), nil
+	cacheKey := wiki.SectionCacheKey(wikiURL, title, sectionIndex)
+	var cached wiki.PageSection
+	if client.GetCachedResult(ctx, cacheKey, &cached) {
+		return &cached, nil
 	}
+	return coalesce(ctx, cacheKey, func(ctx context.Context) (*wiki.PageSection, error) {
+		return fetchPageSection(ctx, client, wikiURL, title, sectionIndex, cacheKey)
+	})
+}
 
+// fetchPageSection builds a section from the wiki and caches it under
+// cacheKey
+func fetchPageSection(ctx context.Context, client *wiki.Client, wikiURL, title string, sectionIndex int, cacheKey string) (*wiki.PageSection, error) {
 	// First, get the page structure to validate section and get context
-	outline, err := GetPageOutline(ctx, client, wikiURL, title)
+	outline, err := GetPageOutline(ctx, client, wikiURL, title, OutlineOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("get page outline: %w", err)
 	}
@@ -66,48 +92,31 @@ func GetPageSection(ctx context.Context, client *wiki.Client, wikiURL, title str
 	}
 
 	// Fetch the section content
-	params := url.Values{}
-	params.Set("action", "parse")
-	params.Set("page", title)
-	params.Set("section", strconv.Itoa(sectionIndex))
-	params.Set("prop", "text|links")
-	params.Set("disableeditsection", "1")
-
-	resp, err := client.MakeRequest(ctx, wikiURL, params)
-	if err != nil {
-		return nil, fmt.Errorf("get section: %w", err)
-	}
-
-	if resp.Parse == nil {
-		return nil, fmt.Errorf("empty parse response")
-	}
-
-	// Convert HTML to Markdown
-	markdown, err := wiki.HTMLToMarkdown(resp.Parse.Text.Content)
+	converted, links, revID, err := fetchSectionContent(ctx, client, wikiURL, title, sectionIndex)
 	if err != nil {
-		return nil, fmt.Errorf("convert to markdown: %w", err)
-	}
-
-	// Extract links
-	links := make([]string, 0, len(resp.Parse.Links))
-	for _, link := range resp.Parse.Links {
-		links = append(links, link.Title)
+		return nil, err
 	}
 
 	// Build the section with content
 	section := &wiki.Section{
-		Index:     targetSection.Index,
-		Title:     targetSection.Title,
-		Level:     targetSection.Level,
-		Content:   markdown,
-		Links:     links,
-		WordCount: wiki.CountWords(markdown),
+		Index:          targetSection.Index,
+		Title:          targetSection.Title,
+		Level:          targetSection.Level,
+		Content:        converted.Markdown,
+		Links:          links,
+		WordCount:      converted.ProseWordCount,
+		TableWordCount: converted.TableWordCount,
 	}
 
 	// Build response
 	pageSection := &wiki.PageSection{
-		Title:   title,
-		Section: section,
+		Title:          title,
+		Section:        section,
+		InternalTitles: links,
+		ExternalURLs:   converted.ExternalURLs,
+		LinkDetails:    converted.LinkDetails,
+		ContentHash:    wiki.ContentHash(converted.Markdown),
+		Source:         client.NewProvenance(ctx, wikiURL, outline.Title, revID),
 	}
 
 	// Add parent info
@@ -154,11 +163,80 @@ func GetPageSection(ctx context.Context, client *wiki.Client, wikiURL, title str
 	}
 
 	// Cache the result
-	client.GetCache().Set(cacheKey, pageSection, client.GetCacheTTL())
+	client.GetCache().SetJSON(cacheKey, pageSection, client.GetCacheTTL())
 
 	return pageSection, nil
 }
 
+// fetchSectionContent returns the converted content and links for a single
+// section and the revision they came from, using the REST mobile-sections
+// endpoint on Wikimedia projects that still serve it
+func fetchSectionContent(ctx context.Context, client *wiki.Client, wikiURL, title string, sectionIndex int) (*wiki.ConvertedContent, []string, int, error) {
+	if client.ServesREST(wikiURL) {
+		if html, revID, ok := getMobileSectionHTML(ctx, client, wikiURL, title, sectionIndex); ok {
+			converted, err := client.ConvertHTML(ctx, wikiURL, html)
+			if err == nil {
+				return converted, converted.Links, revID, nil
+			}
+			// The parse API would return the same HTML
+			var complexErr *wiki.PageTooComplexError
+			if errors.As(err, &complexErr) {
+				return nil, nil, 0, err
+			}
+		}
+	}
+
+	params := url.Values{}
+	params.Set("action", "parse")
+	params.Set("page", title)
+	params.Set("section", strconv.Itoa(sectionIndex))
+	params.Set("prop", "text|links")
+	params.Set("disableeditsection", "1")
+
+	resp, err := client.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("get section: %w", err)
+	}
+
+	if resp.Parse == nil {
+		return nil, nil, 0, fmt.Errorf("empty parse response")
+	}
+
+	// Convert HTML to Markdown
+	converted, err := client.ConvertHTML(ctx, wikiURL, resp.Parse.Text.Content)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("convert to markdown: %w", err)
+	}
+
+	// Extract links
+	links := make([]string, 0, len(resp.Parse.Links))
+	for _, link := range resp.Parse.Links {
+		links = append(links, link.Title)
+	}
+
+	return converted, links, resp.Parse.RevID, nil
+}
+
+// getMobileSectionHTML looks up a section in the (cached) mobile-sections
+// response, returning its HTML and the revision it was rendered from
+func getMobileSectionHTML(ctx context.Context, client *wiki.Client, wikiURL, title string, sectionIndex int) (string, int, bool) {
+	cacheKey := wiki.MobileSectionsCacheKey(wikiURL, title)
+
+	sections := &wiki.RESTMobileSections{}
+	if !client.GetCache().GetJSON(cacheKey, sections) {
+		fetched, err := client.GetRESTMobileSections(ctx, wikiURL, title)
+		if err != nil {
+			return "", 0, false
+		}
+		sections = fetched
+		client.GetCache().SetJSON(cacheKey, sections, client.GetCacheTTL())
+	}
+
+	html, ok := sections.SectionHTML(sectionIndex)
+	revID, _ := strconv.Atoi(sections.Lead.Revision)
+	return html, revID, ok
+}
+
 // flattenSections converts a tree of sections to a flat list
 func flattenSections(sections []*wiki.Section) []*wiki.Section {
 	result := make([]*wiki.Section, 0)
@@ -180,9 +258,13 @@ func flattenSections(sections []*wiki.Section) []*wiki.Section {
 // SectionNotFoundError represents an error when a section doesn't exist
 type SectionNotFoundError struct {
 	SectionIndex      int
+	SectionTitle      string // set when the section was requested by heading
 	AvailableSections int
 }
 
 func (e *SectionNotFoundError) Error() string {
+	if e.SectionTitle != "" {
+		return fmt.Sprintf("section %q does not exist (page has %d sections)", e.SectionTitle, e.AvailableSections)
+	}
 	return fmt.Sprintf("section index %d does not exist (page has %d sections)", e.SectionIndex, e.AvailableSections)
 }
@@ -0,0 +1,140 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// sisterProjects maps the standard Wikimedia interwiki prefixes, long and
+// short, to the sister project they point to
+var sisterProjects = map[string]string{
+	"wiktionary": "wiktionary", "wikt": "wiktionary",
+	"wikisource": "wikisource", "s": "wikisource",
+	"commons": "commons", "c": "commons",
+	"wikiquote": "wikiquote", "q": "wikiquote",
+	"wikibooks": "wikibooks", "b": "wikibooks",
+	"wikinews": "wikinews", "n": "wikinews",
+	"wikiversity": "wikiversity", "v": "wikiversity",
+	"wikivoyage": "wikivoyage", "voy": "wikivoyage",
+	"wikispecies": "wikispecies", "species": "wikispecies",
+	"wikidata": "wikidata", "d": "wikidata",
+	"wikipedia": "wikipedia", "w": "wikipedia",
+	"meta": "meta", "m": "meta",
+	"mediawikiwiki": "mediawiki", "mw": "mediawiki",
+}
+
+// GetSisterLinks retrieves a page's links to sister projects such as
+// Wiktionary, Wikisource, and Commons, optionally only those to project.
+// Sister-project templates render as interwiki links, so they are included.
+func GetSisterLinks(ctx context.Context, client *wiki.Client, wikiURL, title, project string) (*wiki.SisterLinksResponse, error) {
+	links, err := getSisterLinks(ctx, client, wikiURL, title)
+	if err != nil || project == "" {
+		return links, err
+	}
+
+	result := &wiki.SisterLinksResponse{
+		Title: links.Title,
+		Links: make([]wiki.SisterLink, 0),
+	}
+	for _, link := range links.Links {
+		if strings.EqualFold(link.Project, project) {
+			result.Links = append(result.Links, link)
+		}
+	}
+	return result, nil
+}
+
+// getSisterLinks retrieves (and caches) all of a page's sister-project links
+func getSisterLinks(ctx context.Context, client *wiki.Client, wikiURL, title string) (*wiki.SisterLinksResponse, error) {
+	// Check cache
+	cacheKey := wiki.SisterLinksCacheKey(wikiURL, title)
+	var cached wiki.SisterLinksResponse
+	if client.GetCachedResult(ctx, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("titles", title)
+	params.Set("prop", "iwlinks")
+	params.Set("iwprop", "url")
+	params.Set("iwlimit", "max")
+	params.Set("redirects", "1")
+
+	result := &wiki.SisterLinksResponse{
+		Title: title,
+		Links: make([]wiki.SisterLink, 0),
+	}
+	seen := make(map[string]bool)
+	for {
+		resp, err := client.MakeRequest(ctx, wikiURL, params)
+		if err != nil {
+			return nil, fmt.Errorf("get sister links: %w", err)
+		}
+
+		if resp.Query == nil || len(resp.Query.Pages) == 0 {
+			return nil, fmt.Errorf("no pages found")
+		}
+
+		for _, page := range resp.Query.Pages {
+			if page.Missing {
+				return nil, fmt.Errorf("page missing")
+			}
+			result.Title = page.Title
+
+			for _, iw := range page.IWLinks {
+				link, ok := sisterLink(iw)
+				if !ok || seen[link.URL] {
+					continue
+				}
+				seen[link.URL] = true
+				result.Links = append(result.Links, link)
+			}
+		}
+
+		iwcontinue := resp.Continue["iwcontinue"]
+		if iwcontinue == "" {
+			break
+		}
+		params.Set("iwcontinue", iwcontinue)
+	}
+
+	sort.SliceStable(result.Links, func(i, j int) bool {
+		return result.Links[i].Project < result.Links[j].Project
+	})
+
+	// Cache the result
+	client.GetCache().SetJSON(cacheKey, result, client.GetCacheTTL())
+
+	return result, nil
+}
+
+// sisterLink converts an interwiki link to a sister-project link, reporting
+// false for prefixes that aren't sister projects
+func sisterLink(iw wiki.MWInterwikiLink) (wiki.SisterLink, bool) {
+	project, ok := sisterProjects[strings.ToLower(iw.Prefix)]
+	if !ok || iw.URL == "" {
+		return wiki.SisterLink{}, false
+	}
+
+	link := wiki.SisterLink{
+		Project: project,
+		Prefix:  iw.Prefix,
+		Title:   iw.Title,
+		URL:     iw.URL,
+	}
+
+	// Resolve the target wiki and page so agents can call other tools on it
+	if u, err := url.Parse(iw.URL); err == nil {
+		if i := strings.Index(u.Path, "/wiki/"); i >= 0 {
+			link.WikiURL = interwikiBaseURL(iw.URL)
+			link.Title = strings.ReplaceAll(u.Path[i+len("/wiki/"):], "_", " ")
+		}
+	}
+	return link, true
+}
@@ -0,0 +1,137 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// GetSubpages lists the subpages of a page (e.g. "Project:Docs/...") as a tree
+func GetSubpages(ctx context.Context, client *wiki.Client, wikiURL, title string, limit int) (*wiki.SubpagesResponse, error) {
+	// Check cache
+	cacheKey := wiki.SubpagesCacheKey(wikiURL, title, limit)
+	var cached wiki.SubpagesResponse
+	if client.GetCachedResult(ctx, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	// Resolve the namespace and normalized title of the root page
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("titles", title)
+	params.Set("prop", "info")
+
+	resp, err := client.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("resolve title: %w", err)
+	}
+
+	if resp.Query == nil || len(resp.Query.Pages) == 0 {
+		return nil, fmt.Errorf("empty query response")
+	}
+
+	root := resp.Query.Pages[0]
+	prefix := root.Title
+	if root.Ns != 0 {
+		// allpages prefixes exclude the namespace name
+		if idx := strings.Index(prefix, ":"); idx != -1 {
+			prefix = prefix[idx+1:]
+		}
+	}
+	prefix += "/"
+
+	// Enumerate subpages, following continuation up to the limit
+	titles := make([]string, 0)
+	truncated := false
+	apcontinue := ""
+	for {
+		listParams := url.Values{}
+		listParams.Set("action", "query")
+		listParams.Set("list", "allpages")
+		listParams.Set("apprefix", prefix)
+		listParams.Set("apnamespace", strconv.Itoa(root.Ns))
+		listParams.Set("aplimit", strconv.Itoa(min(limit-len(titles), 500)))
+		if apcontinue != "" {
+			listParams.Set("apcontinue", apcontinue)
+		}
+
+		listResp, err := client.MakeRequest(ctx, wikiURL, listParams)
+		if err != nil {
+			return nil, fmt.Errorf("list subpages: %w", err)
+		}
+
+		if listResp.Query == nil {
+			break
+		}
+
+		for _, page := range listResp.Query.Allpages {
+			titles = append(titles, page.Title)
+		}
+
+		apcontinue = listResp.Continue["apcontinue"]
+		if apcontinue == "" {
+			break
+		}
+		if len(titles) >= limit {
+			truncated = true
+			break
+		}
+	}
+
+	// Build response
+	subpagesResp := &wiki.SubpagesResponse{
+		Title:      root.Title,
+		Subpages:   buildSubpageTree(root.Title, titles),
+		TotalCount: len(titles),
+		Truncated:  truncated,
+	}
+
+	// Cache the result
+	client.GetCache().SetJSON(cacheKey, subpagesResp, client.GetCacheTTL())
+
+	return subpagesResp, nil
+}
+
+// buildSubpageTree nests subpage titles by their "/" path segments. Missing
+// intermediate pages (e.g. "A/B" when only "A/B/C" exists) are included with
+// Exists set to false.
+func buildSubpageTree(rootTitle string, titles []string) []*wiki.SubpageNode {
+	roots := make([]*wiki.SubpageNode, 0)
+	nodes := make(map[string]*wiki.SubpageNode)
+
+	var ensure func(title string) *wiki.SubpageNode
+	ensure = func(title string) *wiki.SubpageNode {
+		if node, ok := nodes[title]; ok {
+			return node
+		}
+
+		idx := strings.LastIndex(title, "/")
+		node := &wiki.SubpageNode{
+			Title: title,
+			Name:  title[idx+1:],
+		}
+		nodes[title] = node
+
+		parentTitle := title[:idx]
+		if parentTitle == rootTitle {
+			roots = append(roots, node)
+		} else {
+			parent := ensure(parentTitle)
+			parent.Children = append(parent.Children, node)
+		}
+		return node
+	}
+
+	for _, title := range titles {
+		if !strings.HasPrefix(title, rootTitle+"/") {
+			continue
+		}
+		ensure(title).Exists = true
+	}
+
+	return roots
+}
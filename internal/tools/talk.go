@@ -0,0 +1,73 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// TalkPages returns the talk page of a title and the page it is about,
+// named as the wiki names its namespaces. Every subject namespace has its
+// talk namespace after it, so a talk page is its own talk page. Special
+// pages and media files have none.
+func TalkPages(ctx context.Context, client *wiki.Client, wikiURL, title string) (talk, subject string, err error) {
+	if strings.TrimSpace(title) == "" || strings.Contains(title, "|") {
+		return "", "", fmt.Errorf("invalid title %q", title)
+	}
+
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("titles", title)
+	params.Set("meta", "siteinfo")
+	params.Set("siprop", "namespaces")
+
+	resp, err := client.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return "", "", fmt.Errorf("resolve talk page: %w", err)
+	}
+	if resp.Query == nil || len(resp.Query.Pages) == 0 {
+		return "", "", fmt.Errorf("no pages found")
+	}
+
+	page := resp.Query.Pages[0]
+	if page.Invalid {
+		return "", "", &wiki.APIError{Code: "invalidtitle", Message: fmt.Sprintf("invalid title %q: %s", title, page.InvalidReason)}
+	}
+	if page.Ns < 0 {
+		return "", "", fmt.Errorf("%q is a special or media page, which has no talk page", page.Title)
+	}
+
+	// The normalized title has the namespace's local name as its prefix
+	name := page.Title
+	if page.Ns != 0 {
+		_, name, _ = strings.Cut(page.Title, ":")
+	}
+	subjectNS, talkNS := page.Ns, page.Ns+1
+	if page.Ns%2 == 1 {
+		subjectNS, talkNS = page.Ns-1, page.Ns
+	}
+
+	prefix := func(id int) (string, bool) {
+		if id == 0 {
+			return "", true
+		}
+		ns, ok := resp.Query.Namespaces[strconv.Itoa(id)]
+		if !ok || ns.Name == "" {
+			return "", false
+		}
+		return ns.Name + ":", true
+	}
+	talkPrefix, ok := prefix(talkNS)
+	if !ok {
+		return "", "", fmt.Errorf("%s has no talk namespace for %q", wikiURL, page.Title)
+	}
+	subjectPrefix, ok := prefix(subjectNS)
+	if !ok {
+		return "", "", fmt.Errorf("%s has no subject namespace for %q", wikiURL, page.Title)
+	}
+	return talkPrefix + name, subjectPrefix + name, nil
+}
@@ -0,0 +1,44 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+func TestTalkPages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ns := map[string]int{"Albert_Einstein": 0, "Diskussion:Albert Einstein": 1, "Hilfe:Bearbeiten": 12, "Spezial:Suche": -1}
+		title := r.URL.Query().Get("titles")
+		normalized := map[string]string{"Albert_Einstein": "Albert Einstein"}[title]
+		if normalized == "" {
+			normalized = title
+		}
+		fmt.Fprintf(w, `{"query":{"pages":[{"ns":%d,"title":%q,"missing":true}],"namespaces":{
+			"-1":{"id":-1,"name":"Spezial"},"0":{"id":0,"name":""},"1":{"id":1,"name":"Diskussion"},
+			"12":{"id":12,"name":"Hilfe"},"13":{"id":13,"name":"Hilfe Diskussion"}}}}`, ns[title], normalized)
+	}))
+	defer srv.Close()
+	client := wiki.NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	ctx := context.Background()
+
+	for _, tc := range []struct{ title, talk, subject string }{
+		{"Albert_Einstein", "Diskussion:Albert Einstein", "Albert Einstein"},
+		{"Diskussion:Albert Einstein", "Diskussion:Albert Einstein", "Albert Einstein"},
+		{"Hilfe:Bearbeiten", "Hilfe Diskussion:Bearbeiten", "Hilfe:Bearbeiten"},
+	} {
+		talk, subject, err := TalkPages(ctx, client, srv.URL, tc.title)
+		if err != nil || talk != tc.talk || subject != tc.subject {
+			t.Errorf("TalkPages(%q) = %q, %q, %v, want %q, %q", tc.title, talk, subject, err, tc.talk, tc.subject)
+		}
+	}
+
+	if _, _, err := TalkPages(ctx, client, srv.URL, "Spezial:Suche"); err == nil {
+		t.Error("TalkPages of a special page succeeded")
+	}
+}
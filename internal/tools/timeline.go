@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// GetPageTimeline extracts the dated statements on a page, or in one of its
+// sections (such as "History") and that section's subsections, as a
+// chronological list of events
+func GetPageTimeline(ctx context.Context, client *wiki.Client, wikiURL, title, section string, limit, maxBytes int) (*wiki.TimelineResponse, error) {
+	result, err := getPageTimeline(ctx, client, wikiURL, title, section, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit > 0 && len(result.Events) > limit {
+		result.Events = result.Events[:limit]
+		result.Truncated = true
+	}
+	return result, nil
+}
+
+func getPageTimeline(ctx context.Context, client *wiki.Client, wikiURL, title, section string, maxBytes int) (*wiki.TimelineResponse, error) {
+	// Check cache
+	cacheKey := wiki.TimelineCacheKey(wikiURL, title, section)
+	var cached wiki.TimelineResponse
+	if client.GetCachedResult(ctx, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	page, err := GetPageFull(ctx, client, wikiURL, title, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Statements before the first heading belong to the lead
+	content, heading := page.Content, "Lead"
+	if section != "" {
+		var ok bool
+		if content, ok = wiki.MarkdownSection(page.Content, section); !ok {
+			notFound := &SectionNotFoundError{SectionTitle: section}
+			if outline, err := GetPageOutline(ctx, client, wikiURL, title, OutlineOptions{}); err == nil {
+				notFound.AvailableSections = len(flattenSections(outline.Sections))
+			}
+			return nil, notFound
+		}
+		heading = section
+	}
+
+	events := wiki.ExtractTimeline(content, heading)
+	result := &wiki.TimelineResponse{
+		Title:       page.Title,
+		Section:     section,
+		Events:      events,
+		TotalEvents: len(events),
+	}
+
+	// Cache the result
+	client.GetCache().SetJSON(cacheKey, result, client.GetCacheTTL())
+
+	return result, nil
+}
@@ -0,0 +1,144 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// TitlesExist checks up to maxTitlesPerQuery titles in one query, reporting
+// for each whether it exists, its namespace, and where it redirects to.
+// Redirect chains are followed to their final target.
+func TitlesExist(ctx context.Context, client *wiki.Client, wikiURL string, titles []string) (*wiki.TitlesExistResponse, error) {
+	if len(titles) == 0 {
+		return nil, fmt.Errorf("titles is required")
+	}
+	if len(titles) > maxTitlesPerQuery {
+		return nil, fmt.Errorf("at most %d titles can be checked at once, got %d", maxTitlesPerQuery, len(titles))
+	}
+	for _, title := range titles {
+		if strings.Contains(title, "|") {
+			return nil, fmt.Errorf("title %q contains |, which titles can't", title)
+		}
+	}
+
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("titles", strings.Join(titles, "|"))
+	params.Set("prop", "info")
+	params.Set("redirects", "1")
+	params.Set("meta", "siteinfo")
+	params.Set("siprop", "namespaces")
+
+	resp, err := client.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("check titles: %w", err)
+	}
+	if resp.Query == nil {
+		return nil, fmt.Errorf("empty query response")
+	}
+	query := resp.Query
+
+	normalized := make(map[string]string, len(query.Normalized))
+	for _, m := range query.Normalized {
+		normalized[m.From] = m.To
+	}
+	type redirect struct{ to, fragment string }
+	redirects := make(map[string]redirect, len(query.Redirects))
+	for _, m := range query.Redirects {
+		redirects[m.From] = redirect{m.To, m.ToFragment}
+	}
+	interwiki := make(map[string]string, len(query.Interwiki))
+	for _, iw := range query.Interwiki {
+		interwiki[iw.Title] = iw.IW
+	}
+	pages := make(map[string]int, len(query.Pages))
+	for i, page := range query.Pages {
+		pages[page.Title] = i
+	}
+	namespaces := make(map[string]int)
+	names := make(map[int]string)
+	for _, ns := range query.Namespaces {
+		names[ns.ID] = ns.Name
+		if ns.Name != "" {
+			namespaces[ns.Name] = ns.ID
+		}
+	}
+
+	result := &wiki.TitlesExistResponse{Titles: make([]wiki.TitleStatus, 0, len(titles))}
+	for _, title := range titles {
+		status := wiki.TitleStatus{Title: title}
+		name := title
+		if to, ok := normalized[title]; ok {
+			name = to
+			status.Normalized = to
+		}
+
+		if prefix, ok := interwiki[name]; ok {
+			status.Interwiki = prefix
+			result.Titles = append(result.Titles, status)
+			result.MissingCount++
+			continue
+		}
+
+		// Follow redirects to the final target, guarding against loops
+		target, fragment := name, ""
+		for seen := map[string]bool{name: true}; ; {
+			m, ok := redirects[target]
+			if !ok || seen[m.to] {
+				break
+			}
+			target, fragment = m.to, m.fragment
+			seen[target] = true
+		}
+
+		if target != name {
+			status.Exists = true
+			status.Namespace = titleNamespace(name, namespaces)
+			status.RedirectTarget = target
+			if fragment != "" {
+				status.RedirectTarget += "#" + fragment
+			}
+			if i, ok := pages[target]; ok {
+				status.TargetExists = !bool(query.Pages[i].Missing) && !bool(query.Pages[i].Invalid)
+			}
+		} else if i, ok := pages[name]; ok {
+			page := query.Pages[i]
+			if page.Invalid {
+				status.Invalid = page.InvalidReason
+				if status.Invalid == "" {
+					status.Invalid = "invalid title"
+				}
+			} else {
+				status.Exists = !bool(page.Missing)
+				status.Namespace = page.Ns
+				status.PageID = page.PageID
+			}
+		}
+		if status.Invalid == "" {
+			status.NamespaceName = names[status.Namespace]
+		}
+
+		if status.Exists {
+			result.ExistCount++
+		} else {
+			result.MissingCount++
+		}
+		result.Titles = append(result.Titles, status)
+	}
+	return result, nil
+}
+
+// titleNamespace returns the namespace of a normalized title from its
+// prefix, which is the main namespace unless it names another
+func titleNamespace(title string, namespaces map[string]int) int {
+	if prefix, _, ok := strings.Cut(title, ":"); ok {
+		if id, ok := namespaces[prefix]; ok {
+			return id
+		}
+	}
+	return 0
+}
@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+func TestTitlesExist(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("titles") != "" {
+			requests++
+		}
+		fmt.Fprint(w, `{"query":{
+			"normalized":[{"from":"category:physics","to":"Category:Physics"}],
+			"redirects":[
+				{"from":"Old name","to":"Middle name"},
+				{"from":"Middle name","to":"Current name","tofragment":"History"},
+				{"from":"Broken","to":"Nowhere"}
+			],
+			"interwiki":[{"title":"en:Foo","iw":"en"}],
+			"pages":[
+				{"pageid":1,"ns":14,"title":"Category:Physics"},
+				{"pageid":2,"ns":0,"title":"Current name"},
+				{"ns":0,"title":"Nowhere","missing":true},
+				{"ns":0,"title":"Missing page","missing":true},
+				{"title":"Bad<title","invalidreason":"The requested page title contains invalid characters: \"<\".","invalid":true}
+			],
+			"namespaces":{"0":{"id":0,"name":""},"14":{"id":14,"name":"Category"}}
+		}}`)
+	}))
+	defer srv.Close()
+
+	client := wiki.NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	titles := []string{"category:physics", "Old name", "Broken", "Missing page", "Bad<title", "en:Foo"}
+	result, err := TitlesExist(context.Background(), client, srv.URL, titles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 1 {
+		t.Errorf("made %d title queries, want 1", requests)
+	}
+
+	want := []wiki.TitleStatus{
+		{Title: "category:physics", Normalized: "Category:Physics", Exists: true, Namespace: 14, NamespaceName: "Category", PageID: 1},
+		{Title: "Old name", Exists: true, RedirectTarget: "Current name#History", TargetExists: true},
+		{Title: "Broken", Exists: true, RedirectTarget: "Nowhere"},
+		{Title: "Missing page"},
+		{Title: "Bad<title", Invalid: `The requested page title contains invalid characters: "<".`},
+		{Title: "en:Foo", Interwiki: "en"},
+	}
+	if !reflect.DeepEqual(result.Titles, want) {
+		t.Errorf("Titles =\n%+v\nwant\n%+v", result.Titles, want)
+	}
+	if result.ExistCount != 3 || result.MissingCount != 3 {
+		t.Errorf("ExistCount, MissingCount = %d, %d, want 3, 3", result.ExistCount, result.MissingCount)
+	}
+
+	tooMany := strings.Split(strings.Repeat("Page|", 51), "|")[:51]
+	if _, err := TitlesExist(context.Background(), client, srv.URL, tooMany); err == nil {
+		t.Error("TitlesExist accepted 51 titles")
+	}
+}
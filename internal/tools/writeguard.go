@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// WriteForbiddenError is returned when a write targets a title outside the
+// namespaces and prefixes writes are restricted to
+type WriteForbiddenError struct {
+	Title           string
+	AllowedPrefixes []string
+}
+
+func (e *WriteForbiddenError) Error() string {
+	return fmt.Sprintf("writes to %q are not allowed; allowed prefixes: %s", e.Title, strings.Join(e.AllowedPrefixes, ", "))
+}
+
+// CheckWriteAllowed checks a title against the allowed write prefixes, such
+// as "Draft:" for a namespace or "User:Bot/" for the subpages of a page. An
+// empty list allows every title. Matching works on the title as given, with
+// no API call: namespace names compare case-insensitively and the first
+// letter of the page name is capitalized, as wikis do, but namespace
+// aliases like "Project:" are not resolved.
+func CheckWriteAllowed(title string, allowed []string) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	normalized := normalizeWriteTitle(title)
+	for _, prefix := range allowed {
+		if hasWritePrefix(normalized, normalizeWriteTitle(prefix)) {
+			return nil
+		}
+	}
+	return &WriteForbiddenError{Title: title, AllowedPrefixes: allowed}
+}
+
+// normalizeWriteTitle converts underscores to spaces, drops a leading colon,
+// and capitalizes the page name after a namespace
+func normalizeWriteTitle(title string) string {
+	title = strings.TrimPrefix(strings.TrimSpace(strings.ReplaceAll(title, "_", " ")), ":")
+	namespace, name, ok := strings.Cut(title, ":")
+	if !ok {
+		return capitalizeFirst(strings.TrimSpace(title))
+	}
+	return strings.TrimSpace(namespace) + ":" + capitalizeFirst(strings.TrimSpace(name))
+}
+
+// hasWritePrefix reports whether a title starts with a prefix, comparing the
+// namespace part case-insensitively
+func hasWritePrefix(title, prefix string) bool {
+	prefixNS, prefixName, prefixHasNS := strings.Cut(prefix, ":")
+	if !prefixHasNS {
+		return strings.HasPrefix(title, prefix)
+	}
+	titleNS, titleName, titleHasNS := strings.Cut(title, ":")
+	return titleHasNS && strings.EqualFold(titleNS, prefixNS) && strings.HasPrefix(titleName, prefixName)
+}
+
+// capitalizeFirst uppercases the first letter of s
+func capitalizeFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// CheckPageMissing checks that a page to be created doesn't exist yet,
+// redirects included, returning a *wiki.PageExistsError if it does. The
+// edit itself is made with createonly, which catches pages created since.
+func CheckPageMissing(ctx context.Context, client *wiki.Client, wikiURL, title string) error {
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("titles", title)
+	params.Set("prop", "info")
+
+	resp, err := client.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return fmt.Errorf("check page exists: %w", err)
+	}
+	if resp.Query == nil || len(resp.Query.Pages) == 0 {
+		return fmt.Errorf("no pages found")
+	}
+
+	page := resp.Query.Pages[0]
+	if page.Invalid {
+		return &wiki.APIError{Code: "invalidtitle", Message: fmt.Sprintf("%q is not a valid page title: %s", title, page.InvalidReason)}
+	}
+	if page.Missing {
+		return nil
+	}
+	return &wiki.PageExistsError{Title: page.Title, PageID: page.PageID, Redirect: bool(page.Redirect)}
+}
@@ -0,0 +1,82 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+func TestCheckWriteAllowed(t *testing.T) {
+	allowed := []string{"Draft:", "User:Bot/"}
+
+	tests := []struct {
+		title string
+		ok    bool
+	}{
+		{"Draft:New article", true},
+		{"draft:new_article", true},
+		{"Draft talk:New article", false},
+		{"User:Bot/Reports", true},
+		{"User:Bot/Reports/2026", true},
+		{"user:bot/Reports", true},
+		{"User:Bot", false},
+		{"User:Botanist/Notes", false},
+		{"User:Alice", false},
+		{"Main Page", false},
+		{"Star Wars: Draft: A", false},
+		{":Draft:Leading colon", true},
+	}
+
+	for _, tt := range tests {
+		err := CheckWriteAllowed(tt.title, allowed)
+		var forbidden *WriteForbiddenError
+		if tt.ok && err != nil {
+			t.Errorf("CheckWriteAllowed(%q) = %v, want allowed", tt.title, err)
+		}
+		if !tt.ok && !errors.As(err, &forbidden) {
+			t.Errorf("CheckWriteAllowed(%q) = %v, want WriteForbiddenError", tt.title, err)
+		}
+	}
+
+	if err := CheckWriteAllowed("Anything", nil); err != nil {
+		t.Errorf("CheckWriteAllowed with no restrictions = %v, want allowed", err)
+	}
+}
+
+func TestCheckPageMissing(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch title := r.Form.Get("titles"); title {
+		case "Existing":
+			fmt.Fprint(w, `{"query":{"pages":[{"pageid":12,"ns":0,"title":"Existing"}]}}`)
+		case "Old name":
+			fmt.Fprint(w, `{"query":{"pages":[{"pageid":13,"ns":0,"title":"Old name","redirect":true}]}}`)
+		default:
+			fmt.Fprintf(w, `{"query":{"pages":[{"ns":0,"title":%q,"missing":true}]}}`, title)
+		}
+	}))
+	defer srv.Close()
+
+	client := wiki.NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	ctx := context.Background()
+
+	if err := CheckPageMissing(ctx, client, srv.URL, "New page"); err != nil {
+		t.Errorf("CheckPageMissing(missing page) = %v", err)
+	}
+
+	var existsErr *wiki.PageExistsError
+	err := CheckPageMissing(ctx, client, srv.URL, "Existing")
+	if !errors.As(err, &existsErr) || existsErr.PageID != 12 || existsErr.Redirect {
+		t.Errorf("CheckPageMissing(existing page) = %v, want a PageExistsError for page 12", err)
+	}
+	err = CheckPageMissing(ctx, client, srv.URL, "Old name")
+	if !errors.As(err, &existsErr) || !existsErr.Redirect {
+		t.Errorf("CheckPageMissing(redirect) = %v, want a PageExistsError for a redirect", err)
+	}
+}
@@ -0,0 +1,145 @@
+package watch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// DefaultEventStreamURL is the public Wikimedia recent changes feed
+const DefaultEventStreamURL = "https://stream.wikimedia.org/v2/stream/recentchange"
+
+// maxEventBytes bounds a single SSE line
+const maxEventBytes = 1 << 20
+
+// recentChange is the subset of a recentchange event we use
+type recentChange struct {
+	Type      string `json:"type"` // edit, new, log, categorize
+	Title     string `json:"title"`
+	ServerURL string `json:"server_url"`
+	Revision  struct {
+		Old int `json:"old"`
+		New int `json:"new"`
+	} `json:"revision"`
+}
+
+// EventStream consumes the Wikimedia EventStreams recentchange feed to
+// invalidate cached pages and deliver watch notifications in near real time
+type EventStream struct {
+	url        string
+	userAgent  string
+	client     *wiki.Client
+	watcher    *Watcher // may be nil
+	httpClient *http.Client
+}
+
+// NewEventStream creates a consumer of the SSE feed at streamURL. watcher
+// may be nil when only cache invalidation is wanted.
+func NewEventStream(streamURL, userAgent string, client *wiki.Client, watcher *Watcher) *EventStream {
+	return &EventStream{
+		url:        streamURL,
+		userAgent:  userAgent,
+		client:     client,
+		watcher:    watcher,
+		httpClient: &http.Client{}, // no timeout: the response is a long-lived stream
+	}
+}
+
+// Run consumes the stream until ctx is cancelled, reconnecting with
+// exponential backoff and resuming from the last event ID
+func (e *EventStream) Run(ctx context.Context) {
+	var lastID string
+	delay := time.Second
+
+	for {
+		connected, err := e.consume(ctx, &lastID)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			delay = time.Second
+		}
+		log.Printf("eventstream: %v; reconnecting in %s", err, delay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		if delay < time.Minute {
+			delay *= 2
+		}
+	}
+}
+
+// consume reads events from one connection, reporting whether it connected
+func (e *EventStream) consume(ctx context.Context, lastID *string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.url, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("User-Agent", e.userAgent)
+	if *lastID != "" {
+		req.Header.Set("Last-Event-ID", *lastID)
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("stream status %d", resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), maxEventBytes)
+
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			// Blank line dispatches the buffered event
+			if data.Len() > 0 {
+				e.handle(ctx, data.String())
+				data.Reset()
+			}
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		case strings.HasPrefix(line, "id:"):
+			*lastID = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return true, err
+	}
+	return true, fmt.Errorf("stream closed")
+}
+
+// handle applies a single recentchange event
+func (e *EventStream) handle(ctx context.Context, data string) {
+	var event recentChange
+	if err := json.Unmarshal([]byte(data), &event); err != nil || event.Title == "" || event.ServerURL == "" {
+		return
+	}
+
+	e.client.GetCache().InvalidatePage(event.ServerURL, event.Title)
+
+	if e.watcher != nil && (event.Type == "edit" || event.Type == "new") && event.Revision.New > 0 {
+		e.watcher.Update(ctx, event.ServerURL, event.Title, event.Revision.Old, event.Revision.New)
+	}
+}
@@ -0,0 +1,221 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/tools"
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
+)
+
+// Page identifies a watched page
+type Page struct {
+	WikiURL string
+	Title   string
+}
+
+// Change describes a new revision of a watched page
+type Change struct {
+	WikiURL     string    `json:"wiki_url"`
+	Title       string    `json:"title"`
+	OldRevID    int       `json:"old_revid"`
+	NewRevID    int       `json:"new_revid"`
+	DiffSummary string    `json:"diff_summary,omitempty"`
+	DetectedAt  time.Time `json:"detected_at"`
+}
+
+// Notifier receives page changes detected by a Watcher
+type Notifier interface {
+	Notify(ctx context.Context, change Change)
+}
+
+// StateStore persists the last seen revision of watched pages so changes
+// made while the server was down are reported after a restart
+type StateStore interface {
+	LoadRevisions() (map[Page]int, error)
+	SaveRevision(page Page, revID int) error
+}
+
+// Watcher polls watched pages for new revisions and notifies on changes
+type Watcher struct {
+	client    *wiki.Client
+	pages     []Page
+	interval  time.Duration
+	notifiers []Notifier
+
+	// Wikimedia wikis are updated from EventStreams instead of polling
+	streamed bool
+	polled   bool
+
+	store StateStore // may be nil
+
+	mu   sync.Mutex
+	revs map[Page]int
+}
+
+// NewWatcher creates a watcher polling pages every interval
+func NewWatcher(client *wiki.Client, pages []Page, interval time.Duration) *Watcher {
+	return &Watcher{
+		client:   client,
+		pages:    pages,
+		interval: interval,
+		revs:     make(map[Page]int),
+	}
+}
+
+// AddNotifier registers a receiver for page changes
+func (w *Watcher) AddNotifier(n Notifier) {
+	w.notifiers = append(w.notifiers, n)
+}
+
+// SetStateStore restores last seen revisions from store and persists them
+// there from now on
+func (w *Watcher) SetStateStore(store StateStore) error {
+	revs, err := store.LoadRevisions()
+	if err != nil {
+		return fmt.Errorf("load watch state: %w", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, page := range w.pages {
+		if revID, ok := revs[page]; ok {
+			w.revs[page] = revID
+		}
+	}
+	w.store = store
+	return nil
+}
+
+// UseEventStream stops polling Wikimedia wikis after the initial poll; their
+// changes are delivered via Update from an EventStream instead
+func (w *Watcher) UseEventStream() {
+	w.streamed = true
+}
+
+// Run polls until ctx is cancelled. The first poll records the current
+// revisions without notifying.
+func (w *Watcher) Run(ctx context.Context) {
+	w.poll(ctx)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.poll(ctx)
+		}
+	}
+}
+
+// poll checks every watched wiki once
+func (w *Watcher) poll(ctx context.Context) {
+	byWiki := make(map[string][]string)
+	for _, page := range w.pages {
+		if w.streamed && w.polled && wiki.IsWikimediaHost(page.WikiURL) {
+			continue
+		}
+		byWiki[page.WikiURL] = append(byWiki[page.WikiURL], page.Title)
+	}
+
+	for wikiURL, titles := range byWiki {
+		revisions, err := tools.GetLatestRevisions(ctx, w.client, wikiURL, titles)
+		if err != nil {
+			log.Printf("watch: poll %s: %v", wikiURL, err)
+			continue
+		}
+
+		for title, revID := range revisions {
+			w.observe(ctx, Page{WikiURL: wikiURL, Title: title}, revID)
+		}
+	}
+	w.polled = true
+}
+
+// Update reports a new revision of a page from an external feed. Pages that
+// are not watched are ignored.
+func (w *Watcher) Update(ctx context.Context, wikiURL, title string, oldRevID, newRevID int) {
+	for _, page := range w.pages {
+		if !samePage(page, wikiURL, title) {
+			continue
+		}
+
+		// Seed the previous revision if the initial poll missed the page
+		w.mu.Lock()
+		if _, seen := w.revs[page]; !seen {
+			w.revs[page] = oldRevID
+		}
+		w.mu.Unlock()
+
+		w.observe(ctx, page, newRevID)
+		return
+	}
+}
+
+// samePage reports whether a watched page matches a wiki URL and title,
+// ignoring trailing slashes and underscores vs. spaces
+func samePage(page Page, wikiURL, title string) bool {
+	return strings.TrimSuffix(page.WikiURL, "/") == strings.TrimSuffix(wikiURL, "/") &&
+		strings.ReplaceAll(page.Title, "_", " ") == strings.ReplaceAll(title, "_", " ")
+}
+
+// observe records a page's current revision and notifies if it changed
+func (w *Watcher) observe(ctx context.Context, page Page, revID int) {
+	w.mu.Lock()
+	oldRevID, seen := w.revs[page]
+	w.revs[page] = revID
+	w.mu.Unlock()
+
+	if seen && revID == oldRevID {
+		return
+	}
+	if w.store != nil {
+		if err := w.store.SaveRevision(page, revID); err != nil {
+			log.Printf("watch: save state for %s: %v", page.Title, err)
+		}
+	}
+	if !seen {
+		return
+	}
+
+	change := Change{
+		WikiURL:    page.WikiURL,
+		Title:      page.Title,
+		OldRevID:   oldRevID,
+		NewRevID:   revID,
+		DetectedAt: time.Now().UTC(),
+	}
+	if oldRevID > 0 && revID > 0 {
+		compare, err := tools.CompareRevisions(ctx, w.client, page.WikiURL, page.Title, fmt.Sprint(oldRevID), fmt.Sprint(revID))
+		if err == nil {
+			change.DiffSummary = compare.DiffSummary
+		}
+	}
+
+	for _, n := range w.notifiers {
+		n.Notify(ctx, change)
+	}
+}
+
+// ParsePages parses watch list lines of the form "<wiki_url> <title>"
+func ParsePages(lines []string) ([]Page, error) {
+	pages := make([]Page, 0, len(lines))
+	for _, line := range lines {
+		fields := strings.SplitN(strings.TrimSpace(line), " ", 2)
+		if len(fields) != 2 || strings.TrimSpace(fields[1]) == "" {
+			return nil, fmt.Errorf("invalid watch entry %q: want \"<wiki_url> <title>\"", line)
+		}
+		pages = append(pages, Page{
+			WikiURL: fields[0],
+			Title:   strings.TrimSpace(fields[1]),
+		})
+	}
+	return pages, nil
+}
@@ -0,0 +1,117 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of the request body, keyed
+// with the webhook secret, as "sha256=<hex>"
+const SignatureHeader = "X-MediaWiki-MCP-Signature"
+
+// webhookRetries is the number of delivery attempts per URL
+const webhookRetries = 4
+
+// webhookPayload is the JSON body POSTed to webhook URLs
+type webhookPayload struct {
+	Event string `json:"event"`
+	Change
+}
+
+// WebhookNotifier POSTs signed change payloads to configured URLs
+type WebhookNotifier struct {
+	urls       []string
+	secret     []byte
+	userAgent  string
+	httpClient *http.Client
+	backoff    time.Duration
+}
+
+// NewWebhookNotifier creates a notifier delivering to urls. Payloads are
+// signed when secret is non-empty.
+func NewWebhookNotifier(urls []string, secret, userAgent string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		urls:       urls,
+		secret:     []byte(secret),
+		userAgent:  userAgent,
+		httpClient: &http.Client{Timeout: timeout},
+		backoff:    time.Second,
+	}
+}
+
+// Notify delivers a change to every URL in the background, retrying
+// failed deliveries with exponential backoff
+func (n *WebhookNotifier) Notify(ctx context.Context, change Change) {
+	body, err := json.Marshal(webhookPayload{Event: "page_changed", Change: change})
+	if err != nil {
+		log.Printf("webhook: encode payload: %v", err)
+		return
+	}
+
+	for _, url := range n.urls {
+		go func(url string) {
+			if err := n.deliver(ctx, url, body); err != nil {
+				log.Printf("webhook: deliver %s to %s: %v", change.Title, url, err)
+			}
+		}(url)
+	}
+}
+
+// deliver POSTs body to url, retrying on network errors, 429, and 5xx
+func (n *WebhookNotifier) deliver(ctx context.Context, url string, body []byte) error {
+	var lastErr error
+	delay := n.backoff
+
+	for attempt := 0; attempt < webhookRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+			delay *= 2
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", n.userAgent)
+		if len(n.secret) > 0 {
+			req.Header.Set(SignatureHeader, "sha256="+Sign(n.secret, body))
+		}
+
+		resp, err := n.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("status %d", resp.StatusCode)
+		if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+			return lastErr
+		}
+	}
+
+	return fmt.Errorf("giving up after %d attempts: %w", webhookRetries, lastErr)
+}
+
+// Sign returns the hex HMAC-SHA256 of body keyed with secret
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
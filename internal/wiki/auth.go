@@ -0,0 +1,256 @@
+package wiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// Auth is how the client authenticates to a wiki: with a bot password from
+// Special:BotPasswords, which it logs in with, or with the access token of
+// an OAuth 2.0 owner-only consumer from Special:OAuthConsumerRegistration,
+// which it sends with every request. Either gives reads the account's access,
+// as private wikis require, and is what write actions are made with.
+type Auth struct {
+	Username string // bot password username, "Account@BotName"
+	Password string
+
+	AccessToken string // OAuth 2.0 owner-only consumer; used instead of a bot password
+}
+
+// usesLogin reports whether the account needs a login session
+func (a Auth) usesLogin() bool {
+	return a.AccessToken == ""
+}
+
+// NoCredentialsError is returned for a write action on a wiki no account is
+// configured for, instead of making it anonymously
+type NoCredentialsError struct {
+	WikiURL string
+	Profile string // the wiki's profile, to add credentials to; empty if it has none
+}
+
+func (e *NoCredentialsError) Error() string {
+	if e.Profile != "" {
+		return fmt.Sprintf("no credentials for %s: add a bot password or OAuth token to its profile %q", e.WikiURL, e.Profile)
+	}
+	return fmt.Sprintf("no credentials for %s: add a profile for it with a bot password or OAuth token", e.WikiURL)
+}
+
+// sessionErrorCodes are the API errors a write gets when the session it
+// was made in has lapsed: its CSRF token is no longer valid, or the wiki no
+// longer sees the account as logged in
+var sessionErrorCodes = map[string]bool{
+	"badtoken":         true,
+	"assertuserfailed": true,
+	"assertbotfailed":  true,
+	"notloggedin":      true,
+}
+
+// account is a wiki's Auth and the client's session with it
+type account struct {
+	auth Auth
+
+	mu       sync.Mutex // held while logging in and fetching tokens
+	session  int        // counts logins, to tell sessions apart
+	loggedIn bool
+	tokens   map[string]string // by type; reused until the wiki rejects them
+}
+
+// authKey marks the context of requests made while holding an account's
+// lock, to log in and fetch its token, which skip the session check
+type authKey struct{}
+
+// SetAuth sets how the client authenticates to a wiki. Bot passwords log
+// in before the first request.
+func (c *Client) SetAuth(wikiURL string, auth Auth) {
+	c.accountsMu.Lock()
+	defer c.accountsMu.Unlock()
+	c.accounts[backendKey(wikiURL)] = &account{auth: auth}
+}
+
+// HasAuth reports whether the client authenticates to a wiki
+func (c *Client) HasAuth(wikiURL string) bool {
+	return c.account(wikiURL) != nil
+}
+
+func (c *Client) account(wikiURL string) *account {
+	c.accountsMu.Lock()
+	defer c.accountsMu.Unlock()
+	return c.accounts[backendKey(wikiURL)]
+}
+
+// authorize adds a wiki's OAuth access token to a request
+func (c *Client) authorize(req *http.Request, wikiURL string) {
+	if a := c.account(wikiURL); a != nil && a.auth.AccessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+a.auth.AccessToken)
+	}
+}
+
+// authenticatedRequest makes an API request in the account's session,
+// logging in first if needed. A read the wiki refuses because the session
+// lapsed (readapidenied) is retried once in a new session.
+func (c *Client) authenticatedRequest(ctx context.Context, wikiURL string, do func() (*mwResponse, error)) (*mwResponse, error) {
+	a := c.account(wikiURL)
+	if a == nil || !a.auth.usesLogin() || ctx.Value(authKey{}) != nil {
+		return do()
+	}
+
+	for attempt := 0; ; attempt++ {
+		session, err := c.ensureLogin(ctx, wikiURL, a)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := do()
+		var apiErr *APIError
+		if attempt == 0 && errors.As(err, &apiErr) && apiErr.Code == "readapidenied" {
+			a.reset(session)
+			continue
+		}
+		return resp, err
+	}
+}
+
+// writeRequest makes a write action with the wiki's account: it adds the
+// session's CSRF token and assert=user, so a lapsed session fails instead
+// of acting anonymously. When the wiki rejects the session, it logs in
+// again, fetches a new token, and retries once. Wikis without an account
+// get a NoCredentialsError.
+func (c *Client) writeRequest(ctx context.Context, wikiURL string, params url.Values) (*mwResponse, error) {
+	return c.writeRequestWith(ctx, wikiURL, params, writeOptions{})
+}
+
+// writeOptions are what some write actions need beyond writeRequest
+type writeOptions struct {
+	file      *uploadFile // sent with the parameters as multipart/form-data, as action=upload takes
+	tokenType string      // token the action takes instead of the CSRF token, such as "rollback"
+}
+
+// writeRequestWith is writeRequest with options
+func (c *Client) writeRequestWith(ctx context.Context, wikiURL string, params url.Values, opts writeOptions) (*mwResponse, error) {
+	tokenType := opts.tokenType
+	if tokenType == "" {
+		tokenType = "csrf"
+	}
+
+	a := c.account(wikiURL)
+	if a == nil {
+		return nil, &NoCredentialsError{WikiURL: wikiURL}
+	}
+
+	for attempt := 0; ; attempt++ {
+		token, session, err := c.sessionToken(ctx, wikiURL, a, tokenType)
+		if err != nil {
+			return nil, err
+		}
+		params.Set("assert", "user")
+		params.Set("token", token)
+
+		resp, err := c.negotiateRequest(ctx, http.MethodPost, wikiURL, params, opts.file)
+		var apiErr *APIError
+		if attempt == 0 && errors.As(err, &apiErr) && sessionErrorCodes[apiErr.Code] {
+			a.reset(session)
+			continue
+		}
+		return resp, err
+	}
+}
+
+// sessionToken returns the account's token of a type, such as "csrf", and
+// its session, logging in and fetching the token first if needed
+func (c *Client) sessionToken(ctx context.Context, wikiURL string, a *account, tokenType string) (string, int, error) {
+	if _, err := c.ensureLogin(ctx, wikiURL, a); err != nil {
+		return "", 0, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.tokens[tokenType] == "" {
+		token, err := c.getToken(context.WithValue(ctx, authKey{}, true), wikiURL, tokenType)
+		if err != nil {
+			return "", 0, err
+		}
+		if a.tokens == nil {
+			a.tokens = make(map[string]string)
+		}
+		a.tokens[tokenType] = token
+	}
+	return a.tokens[tokenType], a.session, nil
+}
+
+// ensureLogin logs an account in unless its session already is, returning
+// the session. Accounts with an access token need no login.
+func (c *Client) ensureLogin(ctx context.Context, wikiURL string, a *account) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.loggedIn || !a.auth.usesLogin() {
+		return a.session, nil
+	}
+	if err := c.login(ctx, wikiURL, a.auth); err != nil {
+		return 0, err
+	}
+	a.session++
+	a.loggedIn = true
+	a.tokens = nil
+	return a.session, nil
+}
+
+// reset forgets a session the wiki rejected, so the next request logs in
+// and fetches a token again. A request rejected in an older session leaves
+// a session another one already renewed alone.
+func (a *account) reset(session int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.session == session {
+		if a.auth.usesLogin() {
+			a.loggedIn = false
+		} else {
+			a.session++
+		}
+		a.tokens = nil
+	}
+}
+
+// login logs in with action=login, which takes bot passwords. The session
+// cookie it sets is kept in the client's cookie jar.
+func (c *Client) login(ctx context.Context, wikiURL string, auth Auth) error {
+	ctx = context.WithValue(ctx, authKey{}, true)
+
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("meta", "tokens")
+	params.Set("type", "login")
+
+	resp, err := c.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return fmt.Errorf("get login token: %w", err)
+	}
+	if resp.Query == nil || resp.Query.Tokens == nil || resp.Query.Tokens.LoginToken == "" {
+		return fmt.Errorf("empty login token response")
+	}
+
+	params = url.Values{}
+	params.Set("action", "login")
+	params.Set("lgname", auth.Username)
+	params.Set("lgpassword", auth.Password)
+	params.Set("lgtoken", resp.Query.Tokens.LoginToken)
+
+	resp, err = c.MakePostRequest(ctx, wikiURL, params)
+	if err != nil {
+		return fmt.Errorf("log in: %w", err)
+	}
+	if resp.Login == nil {
+		return fmt.Errorf("empty login response")
+	}
+	if resp.Login.Result != "Success" {
+		message := string(resp.Login.Reason)
+		if message == "" {
+			message = resp.Login.Result
+		}
+		return &APIError{Code: "loginfailed", Message: fmt.Sprintf("login to %s as %s failed: %s", wikiURL, auth.Username, message)}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package wiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestEditLogsIn(t *testing.T) {
+	var logins, edits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		session, _ := r.Cookie("session")
+		switch {
+		case r.Form.Get("action") == "" || r.Form.Get("meta") == "siteinfo":
+			fmt.Fprint(w, `{}`)
+		case r.Form.Get("type") == "login":
+			fmt.Fprint(w, `{"query":{"tokens":{"logintoken":"lt+\\"}}}`)
+		case r.Form.Get("action") == "login":
+			logins++
+			if r.Form.Get("lgname") != "Bot@mcp" || r.Form.Get("lgpassword") != "secret" || r.Form.Get("lgtoken") != `lt+\` {
+				fmt.Fprint(w, `{"login":{"result":"Failed","reason":{"code":"wrongpassword","text":"Incorrect username or password."}}}`)
+				return
+			}
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "bot"})
+			fmt.Fprint(w, `{"login":{"result":"Success","lgusername":"Bot"}}`)
+		case r.Form.Get("type") == "csrf":
+			fmt.Fprint(w, `{"query":{"tokens":{"csrftoken":"ct+\\"}}}`)
+		case r.Form.Get("action") == "edit":
+			edits++
+			if session == nil || r.Form.Get("assert") != "user" {
+				t.Errorf("edit without a session (cookie %v, assert %q)", session, r.Form.Get("assert"))
+			}
+			fmt.Fprint(w, `{"edit":{"result":"Success","title":"Page","pageid":1,"newrevid":2}}`)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	edit := EditParams{Title: "Page", Text: "text", Summary: "test"}
+
+	var noCredentials *NoCredentialsError
+	if _, err := client.Edit(context.Background(), srv.URL, edit); !errors.As(err, &noCredentials) {
+		t.Fatalf("Edit without credentials error = %v, want NoCredentialsError", err)
+	}
+	if edits != 0 {
+		t.Fatal("Edit without credentials reached the wiki")
+	}
+
+	client.SetAuth(srv.URL, Auth{Username: "Bot@mcp", Password: "secret"})
+	for range 2 {
+		if _, err := client.Edit(context.Background(), srv.URL, edit); err != nil {
+			t.Fatalf("Edit: %v", err)
+		}
+	}
+	if logins != 1 || edits != 2 {
+		t.Errorf("logins = %d, edits = %d, want one login for two edits", logins, edits)
+	}
+
+	other := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	other.SetAuth(srv.URL+"/", Auth{Username: "Bot@mcp", Password: "wrong"})
+	var apiErr *APIError
+	_, err := other.Edit(context.Background(), srv.URL, edit)
+	if !errors.As(err, &apiErr) || apiErr.Code != "loginfailed" || apiErr.Message != "login to "+srv.URL+" as Bot@mcp failed: Incorrect username or password." {
+		t.Errorf("Edit with a wrong password error = %v, want loginfailed", err)
+	}
+}
+
+func TestEditRenewsLapsedSession(t *testing.T) {
+	var logins, tokens, edits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch {
+		case r.Form.Get("action") == "" || r.Form.Get("meta") == "siteinfo":
+			fmt.Fprint(w, `{}`)
+		case r.Form.Get("type") == "login":
+			fmt.Fprint(w, `{"query":{"tokens":{"logintoken":"lt"}}}`)
+		case r.Form.Get("action") == "login":
+			logins++
+			fmt.Fprint(w, `{"login":{"result":"Success"}}`)
+		case r.Form.Get("type") == "csrf":
+			tokens++
+			fmt.Fprintf(w, `{"query":{"tokens":{"csrftoken":"token%d"}}}`, tokens)
+		case r.Form.Get("action") == "edit":
+			edits++
+			// The first session expires after one edit; the second never does
+			switch {
+			case r.Form.Get("title") == "Fails":
+				fmt.Fprint(w, `{"error":{"code":"badtoken","info":"Invalid CSRF token."}}`)
+			case r.Form.Get("token") == "token1" && edits > 1:
+				fmt.Fprint(w, `{"error":{"code":"assertuserfailed","info":"You are no longer logged in."}}`)
+			default:
+				fmt.Fprint(w, `{"edit":{"result":"Success","title":"Page"}}`)
+			}
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	client.SetAuth(srv.URL, Auth{Username: "Bot@mcp", Password: "secret"})
+	edit := EditParams{Title: "Page", Text: "text", Summary: "test"}
+
+	for i := range 3 {
+		if _, err := client.Edit(context.Background(), srv.URL, edit); err != nil {
+			t.Fatalf("Edit %d: %v", i+1, err)
+		}
+	}
+	if logins != 2 || tokens != 2 || edits != 4 {
+		t.Errorf("logins = %d, tokens = %d, edits = %d, want a second session after one retried edit", logins, tokens, edits)
+	}
+
+	edit.Title = "Fails"
+	var apiErr *APIError
+	_, err := client.Edit(context.Background(), srv.URL, edit)
+	if !errors.As(err, &apiErr) || apiErr.Code != "badtoken" {
+		t.Errorf("Edit rejected twice error = %v, want badtoken", err)
+	}
+	if edits != 6 {
+		t.Errorf("edits = %d, want a rejected edit to be retried once", edits)
+	}
+}
+
+func TestPrivateWikiReads(t *testing.T) {
+	var logins int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		session, _ := r.Cookie("session")
+		authorized := r.Header.Get("Authorization") == "Bearer oauth" || session != nil && session.Value == fmt.Sprint(logins)
+		switch {
+		case r.Form.Get("action") == "" || r.Form.Get("meta") == "siteinfo":
+			fmt.Fprint(w, `{}`)
+		case r.Form.Get("type") == "login":
+			fmt.Fprint(w, `{"query":{"tokens":{"logintoken":"lt"}}}`)
+		case r.Form.Get("action") == "login":
+			logins++
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: fmt.Sprint(logins)})
+			fmt.Fprint(w, `{"login":{"result":"Success"}}`)
+		case !authorized:
+			fmt.Fprint(w, `{"error":{"code":"readapidenied","info":"You need read permission to use this module."}}`)
+		default:
+			fmt.Fprint(w, `{"query":{"pages":[{"pageid":1,"title":"Secret"}]}}`)
+		}
+	}))
+	defer srv.Close()
+	params := func() url.Values { return url.Values{"action": {"query"}, "titles": {"Secret"}} }
+
+	anonymous := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	var apiErr *APIError
+	if _, err := anonymous.MakeRequest(context.Background(), srv.URL, params()); !errors.As(err, &apiErr) || apiErr.Code != "readapidenied" {
+		t.Errorf("anonymous read error = %v, want readapidenied", err)
+	}
+
+	oauth := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	oauth.SetAuth(srv.URL, Auth{AccessToken: "oauth"})
+	if _, err := oauth.MakeRequest(context.Background(), srv.URL, params()); err != nil || logins != 0 {
+		t.Errorf("OAuth read error = %v after %d logins, want a read without logging in", err, logins)
+	}
+
+	bot := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	bot.SetAuth(srv.URL, Auth{Username: "Bot@mcp", Password: "secret"})
+	if _, err := bot.MakeRequest(context.Background(), srv.URL, params()); err != nil || logins != 1 {
+		t.Errorf("bot password read error = %v after %d logins, want one login", err, logins)
+	}
+
+	// Another login invalidates the bot's session; its next read logs in again
+	logins++
+	if _, err := bot.MakeRequest(context.Background(), srv.URL, params()); err != nil || logins != 3 {
+		t.Errorf("read after the session lapsed error = %v after %d logins, want a new login", err, logins)
+	}
+}
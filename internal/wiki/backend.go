@@ -0,0 +1,247 @@
+package wiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Backend answers API requests for a wiki that isn't a MediaWiki server
+// reached over HTTP, such as a local ZIM archive or a Confluence space. A
+// backend emulates the requests the tools make of it and fails others with
+// a BackendUnsupportedError.
+type Backend interface {
+	// Name identifies the kind of backend, such as "zim"
+	Name() string
+
+	request(ctx context.Context, params url.Values) (*mwResponse, error)
+}
+
+// remoteBackend is a backend that reaches a server over HTTP. It makes its
+// requests with the client's HTTP client and User-Agent, and they are rate
+// limited like those to MediaWiki servers.
+type remoteBackend interface {
+	Backend
+	useHTTP(client *http.Client, userAgent string)
+}
+
+// pageURLBackend is a backend whose pages live at URLs other than the wiki's
+// article path
+type pageURLBackend interface {
+	Backend
+	pageURL(title string) string
+}
+
+// BackendUnsupportedError is returned for a request a wiki's backend can't
+// answer
+type BackendUnsupportedError struct {
+	Backend string
+	WikiURL string
+	Request string // the action and module, e.g. "query list=search"
+}
+
+func (e *BackendUnsupportedError) Error() string {
+	return fmt.Sprintf("%s is served from a %s backend, which doesn't support %s", e.WikiURL, e.Backend, e.Request)
+}
+
+// unsupportedRequest describes a request for a BackendUnsupportedError by its
+// action and the query modules it uses
+func unsupportedRequest(b Backend, wikiURL string, params url.Values) *BackendUnsupportedError {
+	request := params.Get("action")
+	for _, module := range []string{"prop", "list", "meta", "generator"} {
+		if v := params.Get(module); v != "" {
+			request += " " + module + "=" + v
+		}
+	}
+	return &BackendUnsupportedError{Backend: b.Name(), WikiURL: wikiURL, Request: request}
+}
+
+// SetBackend serves a wiki from a backend instead of its API over HTTP
+func (c *Client) SetBackend(wikiURL string, b Backend) {
+	c.backendsMu.Lock()
+	defer c.backendsMu.Unlock()
+	c.backends[backendKey(wikiURL)] = b
+	if remote, ok := b.(remoteBackend); ok {
+		remote.useHTTP(c.httpClient, c.userAgent)
+	}
+}
+
+// backend returns the backend serving a wiki, or nil for one reached over
+// HTTP
+func (c *Client) backend(wikiURL string) Backend {
+	c.backendsMu.RLock()
+	defer c.backendsMu.RUnlock()
+	return c.backends[backendKey(wikiURL)]
+}
+
+// ServesREST reports whether a wiki's Wikimedia REST API can be used: it is
+// a Wikimedia project that isn't served from a backend
+func (c *Client) ServesREST(wikiURL string) bool {
+	return IsWikimediaHost(wikiURL) && c.backend(wikiURL) == nil
+}
+
+func backendKey(wikiURL string) string {
+	return strings.TrimRight(wikiURL, "/")
+}
+
+var (
+	// htmlHeading matches a heading element
+	htmlHeading = regexp.MustCompile(`(?is)<h([1-6])\b[^>]*>(.*?)</h[1-6]\s*>`)
+
+	// htmlID matches an id attribute
+	htmlID = regexp.MustCompile(`\bid="([^"]*)"`)
+
+	// htmlTag matches a tag, to reduce markup to its text
+	htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+	// wikiHref matches a link to a page, keeping its encoded title
+	wikiHref = regexp.MustCompile(`href="/wiki/([^"#]*)`)
+)
+
+// backendPage is a page as a backend serves it, with links between pages in
+// its HTML rewritten to the /wiki/ form MediaWiki renders, which the
+// converter recognizes
+type backendPage struct {
+	Title  string
+	RevID  int
+	Length int // in bytes of the page's source, or of its HTML without one
+	HTML   string
+}
+
+// parsePage answers action=parse for a page or one of its sections with its
+// HTML, sections, and links
+func parsePage(page *backendPage, params url.Values) (*mwResponse, error) {
+	sections, spans := htmlSections(page.HTML, page.Title)
+
+	text := page.HTML
+	if s := params.Get("section"); s != "" {
+		index, err := strconv.Atoi(s)
+		if err != nil || index < 0 || index > len(sections) {
+			return nil, &APIError{Code: "nosuchsection", Message: fmt.Sprintf("There is no section %s.", s)}
+		}
+		text = page.HTML[spans[index][0]:spans[index][1]]
+	}
+
+	parse := &mwParse{Title: page.Title, RevID: page.RevID, Text: mwText{Content: text}}
+	for _, prop := range strings.Split(params.Get("prop"), "|") {
+		switch prop {
+		case "sections":
+			parse.Sections = sections
+		case "links":
+			parse.Links = htmlLinks(text)
+		}
+	}
+	return &mwResponse{Parse: parse}, nil
+}
+
+// pageInfo answers prop=info for a request's titles, separated by "|",
+// looking each up with find. Titles find answers for under another title,
+// such as the target of a redirect, are listed as redirects.
+func pageInfo(ctx context.Context, titles string, find func(ctx context.Context, title string) (*backendPage, error)) (*mwResponse, error) {
+	query := &mwQuery{Pages: mwPages{}}
+	for _, title := range strings.Split(titles, "|") {
+		page := mwPage{Title: title}
+		found, err := find(ctx, title)
+		var apiErr *APIError
+		switch {
+		case errors.As(err, &apiErr) && apiErr.Code == "missingtitle":
+			page.Missing = true
+		case err != nil:
+			return nil, err
+		default:
+			if found.Title != title {
+				query.Redirects = append(query.Redirects, mwTitleMapping{From: title, To: found.Title})
+			}
+			page.Title = found.Title
+			page.Length = found.Length
+			page.LastRevID = found.RevID
+		}
+		query.Pages = append(query.Pages, page)
+	}
+	return &mwResponse{Query: query}, nil
+}
+
+// htmlSections lists the sections of a page body and the span of HTML each
+// covers, indexed like section numbers: the lead first, then each heading
+// with its subsections
+func htmlSections(body, title string) ([]MWSection, [][2]int) {
+	matches := htmlHeading.FindAllStringSubmatchIndex(body, -1)
+	sections := make([]MWSection, 0, len(matches))
+	levels := make([]int, len(matches))
+	spans := make([][2]int, len(matches)+1)
+
+	var open []int     // levels of the headings enclosing the current one
+	var counters []int // section numbers per TOC level
+	for i, m := range matches {
+		level, _ := strconv.Atoi(body[m[2]:m[3]])
+		levels[i] = level
+		for len(open) > 0 && open[len(open)-1] >= level {
+			open = open[:len(open)-1]
+		}
+		open = append(open, level)
+		tocLevel := len(open)
+		counters = append(counters[:min(len(counters), tocLevel)], make([]int, max(0, tocLevel-len(counters)))...)
+		counters[tocLevel-1]++
+		number := make([]string, tocLevel)
+		for j, n := range counters[:tocLevel] {
+			number[j] = strconv.Itoa(n)
+		}
+
+		line := strings.TrimSpace(html.UnescapeString(htmlTag.ReplaceAllString(body[m[4]:m[5]], "")))
+		anchor := strings.ReplaceAll(line, " ", "_")
+		if id := htmlID.FindStringSubmatch(body[m[0]:m[1]]); id != nil && id[1] != "" {
+			anchor = html.UnescapeString(id[1])
+		}
+		sections = append(sections, MWSection{
+			TocLevel:  tocLevel,
+			Level:     strconv.Itoa(level),
+			Line:      line,
+			Number:    strings.Join(number, "."),
+			Index:     strconv.Itoa(i + 1),
+			FromTitle: title,
+			Anchor:    anchor,
+		})
+	}
+
+	// The lead runs to the first heading; a section to the next heading at
+	// its level or above
+	spans[0] = [2]int{0, len(body)}
+	if len(matches) > 0 {
+		spans[0][1] = matches[0][0]
+	}
+	for i, m := range matches {
+		end := len(body)
+		for j := i + 1; j < len(matches); j++ {
+			if levels[j] <= levels[i] {
+				end = matches[j][0]
+				break
+			}
+		}
+		spans[i+1] = [2]int{m[0], end}
+	}
+	return sections, spans
+}
+
+// htmlLinks lists the distinct pages an HTML fragment links to
+func htmlLinks(fragment string) []MWLink {
+	links := []MWLink{}
+	seen := make(map[string]bool)
+	for _, m := range wikiHref.FindAllStringSubmatch(fragment, -1) {
+		title := m[1]
+		if decoded, err := url.PathUnescape(title); err == nil {
+			title = decoded
+		}
+		title = strings.ReplaceAll(html.UnescapeString(title), "_", " ")
+		if title != "" && !seen[title] {
+			seen[title] = true
+			links = append(links, MWLink{Title: title})
+		}
+	}
+	return links
+}
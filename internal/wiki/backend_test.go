@@ -0,0 +1,39 @@
+package wiki
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHTMLSections(t *testing.T) {
+	body := `<p>Lead</p>` +
+		`<h2 id="History">History</h2><p>Old</p>` +
+		`<h3><span id="Middle_Ages">Middle &amp; Ages</span></h3><p>Trade</p>` +
+		`<h2 id="Geography">Geography</h2><p>Hills <a href="/wiki/Danube">river</a></p>`
+
+	sections, spans := htmlSections(body, "Ulm")
+
+	var got [][3]string
+	for _, s := range sections {
+		got = append(got, [3]string{s.Index, s.Number, s.Line})
+	}
+	want := [][3]string{{"1", "1", "History"}, {"2", "1.1", "Middle & Ages"}, {"3", "2", "Geography"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sections = %v, want %v", got, want)
+	}
+	if sections[1].Anchor != "Middle_Ages" || sections[1].TocLevel != 2 || sections[1].Level != "3" {
+		t.Errorf("subsection = %+v", sections[1])
+	}
+
+	section := func(i int) string { return body[spans[i][0]:spans[i][1]] }
+	if section(0) != "<p>Lead</p>" {
+		t.Errorf("lead = %q", section(0))
+	}
+	if s := section(1); !strings.Contains(s, "Trade") || strings.Contains(s, "Hills") {
+		t.Errorf("section 1 = %q, want it to run through its subsection only", s)
+	}
+	if links := htmlLinks(section(3)); len(links) != 1 || links[0].Title != "Danube" {
+		t.Errorf("htmlLinks(section 3) = %v", links)
+	}
+}
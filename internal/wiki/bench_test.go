@@ -0,0 +1,142 @@
+package wiki
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/mediawiki-mcp/internal/perf"
+)
+
+// articleSections is the number of body sections in the benchmark article,
+// which puts it near the size of a long featured article (~300 KB of HTML)
+const articleSections = 60
+
+// readFixture returns a file from testdata
+func readFixture(tb testing.TB, name string) string {
+	tb.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return string(data)
+}
+
+// articleHTML assembles parser output for a large article from the lead,
+// section, and footer fixtures
+func articleHTML(tb testing.TB) string {
+	tb.Helper()
+	section := readFixture(tb, "article_section.html")
+
+	var sb strings.Builder
+	sb.WriteString(readFixture(tb, "article_lead.html"))
+	for n := 1; n <= articleSections; n++ {
+		sb.WriteString(strings.ReplaceAll(section, "{{n}}", strconv.Itoa(n)))
+	}
+	sb.WriteString(readFixture(tb, "article_footer.html"))
+	return sb.String()
+}
+
+// articleWikitext returns the infobox fixture followed by a long body
+func articleWikitext(tb testing.TB) string {
+	tb.Helper()
+	wikitext := readFixture(tb, "article.wikitext")
+	lines := strings.SplitAfter(wikitext, "\n")
+	lead := lines[len(lines)-2]
+
+	var sb strings.Builder
+	sb.WriteString(wikitext)
+	for n := 1; n <= articleSections; n++ {
+		fmt.Fprintf(&sb, "\n== Section %d ==\n%s\n{{Main|Danube regulation %d}}\n%s", n, lead, n, lead)
+	}
+	return sb.String()
+}
+
+func BenchmarkHTMLToMarkdown(b *testing.B) {
+	html := articleHTML(b)
+	b.SetBytes(int64(len(html)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := HTMLToMarkdown(context.Background(), html); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkConvertHTML measures what a section fetch does with the page:
+// conversion and link extraction
+func BenchmarkConvertHTML(b *testing.B) {
+	html := articleHTML(b)
+	b.SetBytes(int64(len(html)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		converted, err := ConvertHTML(context.Background(), html)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(converted.Links) == 0 {
+			b.Fatal("no links extracted")
+		}
+	}
+}
+
+func BenchmarkContentHash(b *testing.B) {
+	markdown, err := HTMLToMarkdown(context.Background(), articleHTML(b))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(markdown)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		ContentHash(markdown)
+	}
+}
+
+func BenchmarkExtractLinks(b *testing.B) {
+	html := articleHTML(b)
+	b.SetBytes(int64(len(html)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if links := ExtractLinks(html); len(links) == 0 {
+			b.Fatal("no links extracted")
+		}
+	}
+}
+
+func BenchmarkExtractInfobox(b *testing.B) {
+	wikitext := articleWikitext(b)
+	b.SetBytes(int64(len(wikitext)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if infobox := ExtractInfobox(wikitext); infobox == nil {
+			b.Fatal("no infobox extracted")
+		}
+	}
+}
+
+// TestPerformanceBudgets fails when a conversion benchmark exceeds its
+// budget. Budgets allow about three times the time and a quarter more
+// allocations than measured when they were set.
+func TestPerformanceBudgets(t *testing.T) {
+	perf.Check(t, []perf.Benchmark{
+		{Name: "HTMLToMarkdown", Run: BenchmarkHTMLToMarkdown, Budget: perf.Budget{NsPerOp: 270_000_000, AllocsPerOp: 520_000}},
+		{Name: "ConvertHTML", Run: BenchmarkConvertHTML, Budget: perf.Budget{NsPerOp: 270_000_000, AllocsPerOp: 520_000}},
+		{Name: "ContentHash", Run: BenchmarkContentHash, Budget: perf.Budget{NsPerOp: 1_500_000, AllocsPerOp: 10}},
+		{Name: "ExtractLinks", Run: BenchmarkExtractLinks, Budget: perf.Budget{NsPerOp: 27_000_000, AllocsPerOp: 36_000}},
+		{Name: "ExtractInfobox", Run: BenchmarkExtractInfobox, Budget: perf.Budget{NsPerOp: 2_000_000, AllocsPerOp: 4_000}},
+	})
+}
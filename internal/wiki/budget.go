@@ -0,0 +1,31 @@
+package wiki
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/time/rate"
+)
+
+type budgetKey struct{}
+
+// WithRateBudget returns a context whose API requests additionally wait on
+// limiter, so a background job can be held to a share of the per-wiki limit
+func WithRateBudget(ctx context.Context, limiter *rate.Limiter) context.Context {
+	if limiter == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, budgetKey{}, limiter)
+}
+
+// waitBudget blocks until the context's rate budget, if any, allows a request
+func waitBudget(ctx context.Context) error {
+	limiter, ok := ctx.Value(budgetKey{}).(*rate.Limiter)
+	if !ok {
+		return nil
+	}
+	if err := limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate budget wait: %w", err)
+	}
+	return nil
+}
@@ -1,28 +1,50 @@
 package wiki
 
 import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// DefaultCacheCleanupInterval is how often expired entries are removed
+const DefaultCacheCleanupInterval = time.Minute
+
 // Cache is a simple in-memory TTL cache
 type Cache struct {
 	items map[string]*cacheItem
 	mu    sync.RWMutex
+
+	compressMin atomic.Int64 // size from which values are compressed; 0 disables
+
+	// The cleanup goroutine takes new intervals from interval, stops when
+	// stop is closed, and closes done on exit
+	interval  chan time.Duration
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
 type cacheItem struct {
 	value      interface{}
+	stored     time.Time
 	expiration time.Time
 }
 
 // NewCache creates a new cache instance
 func NewCache() *Cache {
 	c := &Cache{
-		items: make(map[string]*cacheItem),
+		items:    make(map[string]*cacheItem),
+		interval: make(chan time.Duration),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
 	}
+	c.compressMin.Store(DefaultCacheCompressMin)
 
-	// Start cleanup goroutine
+	// Start cleanup goroutine; Close stops it
 	go c.cleanupLoop()
 
 	return c
@@ -30,32 +52,90 @@ func NewCache() *Cache {
 
 // Get retrieves a value from cache
 func (c *Cache) Get(key string) (interface{}, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	value, _, ok := c.GetWithAge(key)
+	return value, ok
+}
 
+// GetWithAge retrieves a value from cache with the time since it was stored
+func (c *Cache) GetWithAge(key string) (interface{}, time.Duration, bool) {
+	c.mu.RLock()
 	item, exists := c.items[key]
+	c.mu.RUnlock()
 	if !exists {
-		return nil, false
+		return nil, 0, false
 	}
 
-	if time.Now().After(item.expiration) {
-		return nil, false
+	now := time.Now()
+	if now.After(item.expiration) {
+		return nil, 0, false
 	}
 
-	return item.value, true
+	// Items are never modified once stored, so this needs no lock
+	if compressed, ok := item.value.(*compressedValue); ok {
+		value, err := compressed.decompress()
+		if err != nil {
+			return nil, 0, false
+		}
+		return value, now.Sub(item.stored), true
+	}
+	return item.value, now.Sub(item.stored), true
 }
 
-// Set stores a value in cache with TTL
+// Set stores a value in cache with TTL. Byte slices and strings of at least
+// the compression threshold are stored compressed.
 func (c *Cache) Set(key string, value interface{}, ttl time.Duration) {
+	value = compressValue(value, int(c.compressMin.Load()))
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	c.items[key] = &cacheItem{
 		value:      value,
+		stored:     time.Now(),
 		expiration: time.Now().Add(ttl),
 	}
 }
 
+// GetJSON decodes a value stored with SetJSON into v, reporting whether
+// there was a usable entry. Each call yields an independent copy.
+func (c *Cache) GetJSON(key string, v interface{}) bool {
+	_, ok := c.GetJSONWithAge(key, v)
+	return ok
+}
+
+// GetJSONWithAge is GetJSON that also returns the time since the entry was
+// stored
+func (c *Cache) GetJSONWithAge(key string, v interface{}) (time.Duration, bool) {
+	cached, age, ok := c.GetWithAge(key)
+	if !ok {
+		return 0, false
+	}
+	data, ok := cached.([]byte)
+	if !ok {
+		return 0, false
+	}
+	return age, json.Unmarshal(data, v) == nil
+}
+
+// SetJSON stores a serialized snapshot of v, so neither later changes to v
+// nor to values returned by GetJSON can alter what other callers see
+func (c *Cache) SetJSON(key string, value interface{}, ttl time.Duration) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	c.Set(key, data, ttl)
+}
+
+// Len returns the number of entries, including expired ones not yet
+// cleaned up
+func (c *Cache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.items)
+}
+
 // Delete removes a value from cache
 func (c *Cache) Delete(key string) {
 	c.mu.Lock()
@@ -64,13 +144,72 @@ func (c *Cache) Delete(key string) {
 	delete(c.items, key)
 }
 
-// cleanupLoop periodically removes expired items
+// DeletePrefix removes every value whose key starts with prefix
+func (c *Cache) DeletePrefix(prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.items {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.items, key)
+		}
+	}
+}
+
+// InvalidatePage removes cached content derived from a page (outline, full
+// content, sections, discussions, tool results) after it has been edited
+func (c *Cache) InvalidatePage(wikiURL, title string) {
+	c.Delete(PageCacheKey(wikiURL, title))
+	c.Delete(OutlineCacheKey(wikiURL, title))
+	c.Delete(MobileSectionsCacheKey(wikiURL, title))
+	c.Delete(SisterLinksCacheKey(wikiURL, title))
+	c.Delete(ReferencesCacheKey(wikiURL, title))
+	c.Delete(CoordinatesCacheKey(wikiURL, title))
+	c.Delete(GlossaryCacheKey(wikiURL, title))
+	c.DeletePrefix(CacheKey("timeline", normalizeWikiURL(wikiURL), normalizeTitle(title)) + ":")
+	c.DeletePrefix(CacheKey("languages", normalizeWikiURL(wikiURL), normalizeTitle(title)) + ":")
+	for _, kind := range []string{"section", "discussions", "tool"} {
+		c.DeletePrefix(CacheKey(kind, normalizeWikiURL(wikiURL), normalizeTitle(title)) + ":")
+	}
+}
+
+// SetCleanupInterval sets how often expired entries are removed, starting
+// a new interval now. Intervals of 0 or less are ignored, as is a call after
+// Close.
+func (c *Cache) SetCleanupInterval(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	select {
+	case c.interval <- d:
+	case <-c.done:
+	}
+}
+
+// Close stops the cleanup goroutine and waits for it to exit. The cache
+// remains usable, but expired entries are no longer removed, only skipped.
+// Closing a closed cache does nothing.
+func (c *Cache) Close() {
+	c.closeOnce.Do(func() { close(c.stop) })
+	<-c.done
+}
+
+// cleanupLoop periodically removes expired items until the cache is closed
 func (c *Cache) cleanupLoop() {
-	ticker := time.NewTicker(1 * time.Minute)
+	defer close(c.done)
+
+	ticker := time.NewTicker(DefaultCacheCleanupInterval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		c.cleanup()
+	for {
+		select {
+		case <-ticker.C:
+			c.cleanup()
+		case d := <-c.interval:
+			ticker.Reset(d)
+		case <-c.stop:
+			return
+		}
 	}
 }
 
@@ -86,39 +225,119 @@ func (c *Cache) cleanup() {
 	}
 }
 
-// CacheKey generates a cache key for a request
+// CacheKey joins key parts with ':' after escaping each one, so a part
+// containing ':' (as titles often do) can't collide with another key
 func CacheKey(parts ...string) string {
-	key := ""
+	escaped := make([]string, len(parts))
 	for i, part := range parts {
-		if i > 0 {
-			key += ":"
-		}
-		key += part
+		escaped[i] = url.QueryEscape(part)
 	}
-	return key
+	return strings.Join(escaped, ":")
 }
 
-// Helper for common cache key patterns
+// normalizeWikiURL drops a trailing slash so both spellings share entries
+func normalizeWikiURL(wikiURL string) string {
+	return strings.TrimRight(wikiURL, "/")
+}
+
+// normalizeTitle treats underscores and spaces alike, as MediaWiki does
+func normalizeTitle(title string) string {
+	return strings.TrimSpace(strings.ReplaceAll(title, "_", " "))
+}
+
+// Helpers for common cache key patterns. Every parameter that shapes the
+// upstream request or the converted result is part of the key.
+
 func PageCacheKey(wikiURL, title string) string {
-	return CacheKey("page", wikiURL, title)
+	return CacheKey("page", normalizeWikiURL(wikiURL), normalizeTitle(title))
+}
+
+func OutlineCacheKey(wikiURL, title string) string {
+	return CacheKey("outline", normalizeWikiURL(wikiURL), normalizeTitle(title))
+}
+
+func SectionCacheKey(wikiURL, title string, sectionIndex int) string {
+	return CacheKey("section", normalizeWikiURL(wikiURL), normalizeTitle(title), strconv.Itoa(sectionIndex))
+}
+
+func SearchCacheKey(wikiURL, query string, limit int, inCategory string) string {
+	return CacheKey("search", normalizeWikiURL(wikiURL), query, strconv.Itoa(limit), normalizeTitle(inCategory))
+}
+
+func InfoCacheKey(wikiURL, lang string) string {
+	return CacheKey("info", normalizeWikiURL(wikiURL), lang)
+}
+
+func CategoryCacheKey(wikiURL, category string, limit int, memberType, sort, direction string, all bool) string {
+	return CacheKey("category", normalizeWikiURL(wikiURL), normalizeTitle(category), strconv.Itoa(limit), memberType, sort, direction, strconv.FormatBool(all))
+}
+
+func MaintenanceReportCacheKey(wikiURL, report string, limit, offset int) string {
+	return CacheKey("report", normalizeWikiURL(wikiURL), report, strconv.Itoa(limit), strconv.Itoa(offset))
+}
+
+func BacklinksCacheKey(wikiURL, title string, limit int) string {
+	return CacheKey("backlinks", normalizeWikiURL(wikiURL), normalizeTitle(title), strconv.Itoa(limit))
+}
+
+func MobileSectionsCacheKey(wikiURL, title string) string {
+	return CacheKey("mobilesections", normalizeWikiURL(wikiURL), normalizeTitle(title))
+}
+
+func CoordinatesCacheKey(wikiURL, title string) string {
+	return CacheKey("coordinates", normalizeWikiURL(wikiURL), normalizeTitle(title))
+}
+
+func TimelineCacheKey(wikiURL, title, section string) string {
+	return CacheKey("timeline", normalizeWikiURL(wikiURL), normalizeTitle(title), strings.ToLower(strings.TrimSpace(section)))
+}
+
+func LanguageComparisonCacheKey(wikiURL, title, language string) string {
+	return CacheKey("languages", normalizeWikiURL(wikiURL), normalizeTitle(title), strings.ToLower(language))
+}
+
+func GlossaryCacheKey(wikiURL, title string) string {
+	return CacheKey("glossary", normalizeWikiURL(wikiURL), normalizeTitle(title))
+}
+
+func ReferencesCacheKey(wikiURL, title string) string {
+	return CacheKey("references", normalizeWikiURL(wikiURL), normalizeTitle(title))
+}
+
+func SisterLinksCacheKey(wikiURL, title string) string {
+	return CacheKey("sister", normalizeWikiURL(wikiURL), normalizeTitle(title))
+}
+
+func HistorySearchCacheKey(wikiURL, title, pattern string, regex bool, maxRevisions int) string {
+	return CacheKey("historysearch", normalizeWikiURL(wikiURL), normalizeTitle(title), pattern, strconv.FormatBool(regex), strconv.Itoa(maxRevisions))
+}
+
+func BlameCacheKey(wikiURL, title, snippet string) string {
+	return CacheKey("blame", normalizeWikiURL(wikiURL), normalizeTitle(title), snippet)
+}
+
+func FarmCacheKey(wikiURL string) string {
+	return CacheKey("farm", normalizeWikiURL(wikiURL))
 }
 
-func SectionCacheKey(wikiURL, title, sectionIndex string) string {
-	return CacheKey("section", wikiURL, title, sectionIndex)
+func UserRightsCacheKey(wikiURL string) string {
+	return CacheKey("userrights", normalizeWikiURL(wikiURL))
 }
 
-func SearchCacheKey(wikiURL, query string) string {
-	return CacheKey("search", wikiURL, query)
+func CapabilitiesCacheKey(wikiURL string) string {
+	return CacheKey("capabilities", normalizeWikiURL(wikiURL))
 }
 
-func InfoCacheKey(wikiURL string) string {
-	return CacheKey("info", wikiURL)
+func SubpagesCacheKey(wikiURL, title string, limit int) string {
+	return CacheKey("subpages", normalizeWikiURL(wikiURL), normalizeTitle(title), strconv.Itoa(limit))
 }
 
-func CategoryCacheKey(wikiURL, category string) string {
-	return CacheKey("category", wikiURL, category)
+func DiscussionsCacheKey(wikiURL, title string, limit int) string {
+	return CacheKey("discussions", normalizeWikiURL(wikiURL), normalizeTitle(title), strconv.Itoa(limit))
 }
 
-func BacklinksCacheKey(wikiURL, title string) string {
-	return CacheKey("backlinks", wikiURL, title)
+// ToolResultCacheKey keys a whole tool result by the page it concerns and a
+// hash of the tool name and canonical arguments
+func ToolResultCacheKey(wikiURL, title, argsHash string) string {
+	return CacheKey("tool", normalizeWikiURL(wikiURL), normalizeTitle(title), argsHash)
 }
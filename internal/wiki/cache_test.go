@@ -0,0 +1,46 @@
+package wiki
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheCleanupInterval(t *testing.T) {
+	c := NewCache()
+	defer c.Close()
+	c.SetCleanupInterval(5 * time.Millisecond)
+
+	c.Set("short", "value", time.Millisecond)
+	c.Set("long", "value", time.Hour)
+	deadline := time.Now().Add(time.Second)
+	for c.Len() > 1 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if n := c.Len(); n != 1 {
+		t.Errorf("Len() = %d after cleanup, want 1", n)
+	}
+}
+
+func TestCacheClose(t *testing.T) {
+	c := NewCache()
+	c.Close()
+
+	// Closing again, and setting an interval once closed, return at once
+	done := make(chan struct{})
+	go func() {
+		c.Close()
+		c.SetCleanupInterval(time.Second)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Close or SetCleanupInterval blocked after Close")
+	}
+
+	// The cache is still usable
+	c.Set("key", "value", time.Hour)
+	if v, ok := c.Get("key"); !ok || v != "value" {
+		t.Errorf("Get after Close = %v, %v", v, ok)
+	}
+}
@@ -0,0 +1,82 @@
+package wiki
+
+import (
+	"bytes"
+	"compress/flate"
+	"io"
+	"sync"
+)
+
+// DefaultCacheCompressMin is the size in bytes from which cached values are
+// compressed. Smaller values, such as search results, barely shrink.
+const DefaultCacheCompressMin = 4096
+
+// compressedValue is a byte slice or string stored DEFLATE-compressed
+type compressedValue struct {
+	data   []byte
+	size   int  // uncompressed length
+	isText bool // a string rather than a byte slice
+}
+
+// flateWriters reuses compressors, which are expensive to allocate
+var flateWriters = sync.Pool{
+	New: func() any {
+		w, _ := flate.NewWriter(nil, flate.BestSpeed)
+		return w
+	},
+}
+
+// SetCompressThreshold sets the size in bytes from which byte slice and
+// string values are stored compressed; 0 disables compression. Entries
+// already stored are unaffected.
+func (c *Cache) SetCompressThreshold(minBytes int) {
+	c.compressMin.Store(int64(minBytes))
+}
+
+// compressValue returns a compressedValue for byte slices and strings of at
+// least minBytes, or value unchanged
+func compressValue(value interface{}, minBytes int) interface{} {
+	var raw []byte
+	isText := false
+	switch v := value.(type) {
+	case []byte:
+		raw = v
+	case string:
+		raw, isText = []byte(v), true
+	default:
+		return value
+	}
+	if minBytes <= 0 || len(raw) < minBytes {
+		return value
+	}
+
+	var buf bytes.Buffer
+	w := flateWriters.Get().(*flate.Writer)
+	defer flateWriters.Put(w)
+	w.Reset(&buf)
+	if _, err := w.Write(raw); err != nil {
+		return value
+	}
+	if err := w.Close(); err != nil {
+		return value
+	}
+	// Incompressible data is kept as is
+	if buf.Len() >= len(raw) {
+		return value
+	}
+	return &compressedValue{data: bytes.Clone(buf.Bytes()), size: len(raw), isText: isText}
+}
+
+// decompress returns the original byte slice or string
+func (v *compressedValue) decompress() (interface{}, error) {
+	r := flate.NewReader(bytes.NewReader(v.data))
+	defer r.Close()
+	raw := make([]byte, v.size)
+	if _, err := io.ReadFull(r, raw); err != nil {
+		return nil, err
+	}
+	if v.isText {
+		return string(raw), nil
+	}
+	return raw, nil
+}
@@ -0,0 +1,37 @@
+package wiki
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCacheCompression(t *testing.T) {
+	c := NewCache()
+	page := strings.Repeat("## History\n\nThe town was founded in 1901 by settlers. ", 200)
+
+	c.Set("text", page, time.Minute)
+	c.SetJSON("json", PageFull{Title: "Town", Content: page}, time.Minute)
+	c.Set("small", "short", time.Minute)
+
+	if _, ok := c.items["text"].value.(*compressedValue); !ok {
+		t.Errorf("large string stored as %T, want compressed", c.items["text"].value)
+	}
+	if _, ok := c.items["small"].value.(string); !ok {
+		t.Errorf("small string stored as %T, want uncompressed", c.items["small"].value)
+	}
+
+	if got, ok := c.Get("text"); !ok || got != page {
+		t.Errorf("Get(text) = %.20q, %v; want the original string", got, ok)
+	}
+	var decoded PageFull
+	if !c.GetJSON("json", &decoded) || decoded.Content != page {
+		t.Errorf("GetJSON(json) = %.20q, want the original content", decoded.Content)
+	}
+
+	c.SetCompressThreshold(0)
+	c.Set("text", page, time.Minute)
+	if _, ok := c.items["text"].value.(string); !ok {
+		t.Errorf("with compression disabled, stored as %T", c.items["text"].value)
+	}
+}
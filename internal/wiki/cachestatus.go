@@ -0,0 +1,80 @@
+package wiki
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CacheStatus says whether a response was served from cache, and if so how
+// old the cached copy is
+type CacheStatus struct {
+	Hit bool `json:"hit"`
+	Age int  `json:"age"` // seconds since the cached copy was fetched
+}
+
+type cacheStatusKey struct{}
+
+// cacheRecorder keeps the outcome of a call's first cache lookup, which is
+// the tool's lookup of its whole result
+type cacheRecorder struct {
+	mu       sync.Mutex
+	recorded bool
+	status   CacheStatus
+}
+
+// WithCacheStatus returns a context that records whether the result of the
+// call made under it came from cache
+func WithCacheStatus(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheStatusKey{}, &cacheRecorder{})
+}
+
+// RecordCacheLookup records the outcome of a lookup of a call's whole
+// result. Only the first lookup in a call counts, so lookups made while
+// computing a missed result don't mark it as cached.
+func RecordCacheLookup(ctx context.Context, hit bool, age time.Duration) {
+	r, ok := ctx.Value(cacheStatusKey{}).(*cacheRecorder)
+	if !ok {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.recorded {
+		return
+	}
+	r.recorded = true
+	if hit {
+		r.status = CacheStatus{Hit: true, Age: int(age / time.Second)}
+	}
+}
+
+// CacheStatusFrom returns the cache status recorded under ctx
+func CacheStatusFrom(ctx context.Context) CacheStatus {
+	r, ok := ctx.Value(cacheStatusKey{}).(*cacheRecorder)
+	if !ok {
+		return CacheStatus{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.status
+}
+
+// CacheLookedUp reports whether the call made under ctx looked up its result
+// in the cache at all; tools that don't cache never do
+func CacheLookedUp(ctx context.Context) bool {
+	r, ok := ctx.Value(cacheStatusKey{}).(*cacheRecorder)
+	if !ok {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.recorded
+}
+
+// GetCachedResult looks up a tool's whole result in the cache like
+// Cache.GetJSON, recording the outcome as the call's cache status
+func (c *Client) GetCachedResult(ctx context.Context, key string, v interface{}) bool {
+	age, ok := c.cache.GetJSONWithAge(key, v)
+	RecordCacheLookup(ctx, ok, age)
+	return ok
+}
@@ -0,0 +1,107 @@
+package wiki
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Capabilities describes optional features a wiki supports
+type Capabilities struct {
+	Generator  string          // MediaWiki version string, e.g. "MediaWiki 1.42.0"
+	Extensions map[string]bool // installed extension names
+
+	// ArticlePath is the absolute URL of a page with "$1" in place of the
+	// title, e.g. "https://en.wikipedia.org/wiki/$1"
+	ArticlePath string
+
+	// UnsupportedParams are common request parameters the wiki rejected,
+	// which MakeRequest leaves out
+	UnsupportedParams []string
+
+	// NoMobileSections is set once the REST API turned down a
+	// page/mobile-sections request, as Wikimedia wikis do since retiring
+	// the endpoint, so sections are parsed without asking it again
+	NoMobileSections bool
+}
+
+// HasExtension reports whether an extension is installed
+func (c *Capabilities) HasExtension(name string) bool {
+	return c.Extensions[name]
+}
+
+// GetCapabilities discovers and caches the optional features of a wiki
+func (c *Client) GetCapabilities(ctx context.Context, wikiURL string) (*Capabilities, error) {
+	cacheKey := CapabilitiesCacheKey(wikiURL)
+	var cached Capabilities
+	if c.cache.GetJSON(cacheKey, &cached) && cached.Extensions != nil {
+		return &cached, nil
+	}
+
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("meta", "siteinfo")
+	params.Set("siprop", "general|extensions")
+
+	resp, err := c.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("get capabilities: %w", err)
+	}
+
+	if resp.Query == nil {
+		return nil, fmt.Errorf("empty query response")
+	}
+
+	caps := &Capabilities{
+		Extensions:        make(map[string]bool, len(resp.Query.Extensions)),
+		UnsupportedParams: c.unsupportedParams(wikiURL),
+		NoMobileSections:  !c.servesMobileSections(wikiURL),
+	}
+	if resp.Query.General != nil {
+		caps.Generator = resp.Query.General.Generator
+		caps.ArticlePath = articlePath(wikiURL, resp.Query.General.Server, resp.Query.General.ArticlePath)
+	}
+	for _, ext := range resp.Query.Extensions {
+		caps.Extensions[ext.Name] = true
+	}
+
+	c.cache.SetJSON(cacheKey, caps, c.cacheTTLInfo)
+
+	return caps, nil
+}
+
+// unsupportedParams returns the request parameters a wiki is known to reject
+func (c *Client) unsupportedParams(wikiURL string) []string {
+	var caps Capabilities
+	c.cache.GetJSON(CapabilitiesCacheKey(wikiURL), &caps)
+	return caps.UnsupportedParams
+}
+
+// servesMobileSections reports whether page/mobile-sections may be asked
+// for on a wiki
+func (c *Client) servesMobileSections(wikiURL string) bool {
+	var caps Capabilities
+	c.cache.GetJSON(CapabilitiesCacheKey(wikiURL), &caps)
+	return !caps.NoMobileSections
+}
+
+// rememberNoMobileSections records that a wiki doesn't serve
+// page/mobile-sections. Like the rejected parameters, it is kept for the
+// info cache TTL.
+func (c *Client) rememberNoMobileSections(wikiURL string) {
+	cacheKey := CapabilitiesCacheKey(wikiURL)
+	var caps Capabilities
+	c.cache.GetJSON(cacheKey, &caps)
+	caps.NoMobileSections = true
+	c.cache.SetJSON(cacheKey, caps, c.cacheTTLInfo)
+}
+
+// rememberUnsupportedParams records the request parameters a wiki rejects.
+// The entry may hold only these until the wiki's features are discovered.
+func (c *Client) rememberUnsupportedParams(wikiURL string, params []string) {
+	cacheKey := CapabilitiesCacheKey(wikiURL)
+	var caps Capabilities
+	c.cache.GetJSON(cacheKey, &caps)
+	caps.UnsupportedParams = params
+	c.cache.SetJSON(cacheKey, caps, c.cacheTTLInfo)
+}
@@ -4,9 +4,12 @@ import (
 	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"net/http/cookiejar"
 	"net/url"
 	"strings"
 	"sync"
@@ -23,53 +26,81 @@ type Client struct {
 	cacheTTL     time.Duration
 	cacheTTLInfo time.Duration
 
-	// Rate limiters per wiki domain
-	limiters  map[string]*rate.Limiter
-	limiterMu sync.RWMutex
-	rateLimit rate.Limit
+	// Log once when the placeholder User-Agent is sent to a Wikimedia wiki
+	placeholderUA     bool
+	placeholderUAOnce sync.Once
+
+	// Largest decoded response body accepted (0 disables the limit)
+	maxResponseBytes int64
+
+	// Largest and deepest HTML converted to Markdown (0 disables a limit)
+	maxHTMLNodes int
+	maxHTMLDepth int
+
+	// Request schedulers per wiki domain
+	schedulers       map[string]*domainScheduler
+	schedulerMu      sync.RWMutex
+	rateLimit        rate.Limit
+	interactiveShare float64
 
 	// API path cache per wiki domain
 	apiPaths   map[string]string
 	apiPathsMu sync.RWMutex
+
+	// Wikis served from a backend instead of over HTTP
+	backends   map[string]Backend
+	backendsMu sync.RWMutex
+
+	// Accounts write actions are made with, per wiki
+	accounts   map[string]*account
+	accountsMu sync.Mutex
 }
 
 // NewClient creates a new MediaWiki API client
 func NewClient(userAgent string, timeout time.Duration, rateLimit float64, cacheTTL, cacheTTLInfo time.Duration) *Client {
+	// Session cookies tie CSRF tokens to the session they were issued in
+	jar, _ := cookiejar.New(nil)
 	return &Client{
 		httpClient: &http.Client{
 			Timeout: timeout,
+			Jar:     jar,
 		},
-		userAgent:    userAgent,
-		cache:        NewCache(),
-		cacheTTL:     cacheTTL,
-		cacheTTLInfo: cacheTTLInfo,
-		limiters:     make(map[string]*rate.Limiter),
-		rateLimit:    rate.Limit(rateLimit),
-		apiPaths:     make(map[string]string),
+		userAgent:        userAgent,
+		cache:            NewCache(),
+		cacheTTL:         cacheTTL,
+		cacheTTLInfo:     cacheTTLInfo,
+		schedulers:       make(map[string]*domainScheduler),
+		rateLimit:        rate.Limit(rateLimit),
+		interactiveShare: DefaultInteractiveShare,
+		maxHTMLNodes:     DefaultMaxHTMLNodes,
+		maxHTMLDepth:     DefaultMaxHTMLDepth,
+		apiPaths:         make(map[string]string),
+		backends:         make(map[string]Backend),
+		accounts:         make(map[string]*account),
 	}
 }
 
-// getLimiter returns a rate limiter for a wiki domain
-func (c *Client) getLimiter(wikiURL string) *rate.Limiter {
-	c.limiterMu.RLock()
-	limiter, exists := c.limiters[wikiURL]
-	c.limiterMu.RUnlock()
-
-	if exists {
-		return limiter
-	}
+// SetMaxResponseBytes sets the largest response body, after decompression,
+// the client will read from a wiki (0 disables the limit)
+func (c *Client) SetMaxResponseBytes(n int64) {
+	c.maxResponseBytes = n
+}
 
-	c.limiterMu.Lock()
-	defer c.limiterMu.Unlock()
+// WarnPlaceholderUserAgent makes the client log a warning the first time it
+// sends its User-Agent, which lacks operator contact info, to a Wikimedia wiki
+func (c *Client) WarnPlaceholderUserAgent() {
+	c.placeholderUA = true
+}
 
-	// Double-check after acquiring write lock
-	if limiter, exists := c.limiters[wikiURL]; exists {
-		return limiter
+// checkUserAgent warns about a placeholder User-Agent on Wikimedia wikis
+func (c *Client) checkUserAgent(wikiURL string) {
+	if !c.placeholderUA || !IsWikimediaHost(wikiURL) {
+		return
 	}
-
-	limiter = rate.NewLimiter(c.rateLimit, 1)
-	c.limiters[wikiURL] = limiter
-	return limiter
+	c.placeholderUAOnce.Do(func() {
+		log.Printf("Warning: sending the default User-Agent without contact info to %s; "+
+			"Wikimedia's User-Agent policy requires it, so set MCP_CONTACT_EMAIL or MCP_USER_AGENT", wikiURL)
+	})
 }
 
 // getAPIPath discovers and caches the API path for a wiki
@@ -96,7 +127,9 @@ func (c *Client) getAPIPath(ctx context.Context, wikiURL string) (string, error)
 		}
 
 		req.Header.Set("User-Agent", c.userAgent)
+		c.authorize(req, wikiURL)
 
+		countUpstreamCall(ctx)
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
 			continue
@@ -115,14 +148,108 @@ func (c *Client) getAPIPath(ctx context.Context, wikiURL string) (string, error)
 	return "", fmt.Errorf("could not find valid API endpoint for %s (tried %v)", wikiURL, paths)
 }
 
-// MakeRequest makes an HTTP GET request to the MediaWiki API
+// APIPath returns the path of a wiki's api.php, such as "/w/api.php"
+func (c *Client) APIPath(ctx context.Context, wikiURL string) (string, error) {
+	return c.getAPIPath(ctx, wikiURL)
+}
+
+// optionalParams are the common parameters MakeRequest adds that some old
+// or locked-down wikis reject. They are dropped per wiki as they are refused.
+var optionalParams = []string{"formatversion", "utf8", "maxlag", "errorformat"}
+
+// paramRejectionCodes are the API error codes for unrecognized parameters
+// or parameter values
+var paramRejectionCodes = map[string]bool{
+	"badvalue":           true,
+	"unrecognizedparams": true,
+	"unrecognizedvalues": true,
+}
+
+// MakeRequest makes an HTTP GET request to the MediaWiki API. When the wiki
+// rejects one of the common parameters it adds, the request is retried
+// without it and the wiki's quirk is remembered with its capabilities.
 func (c *Client) MakeRequest(ctx context.Context, wikiURL string, params url.Values) (*mwResponse, error) {
-	// Apply rate limiting
-	limiter := c.getLimiter(wikiURL)
-	if err := limiter.Wait(ctx); err != nil {
-		return nil, fmt.Errorf("rate limit wait: %w", err)
+	return c.negotiateRequest(ctx, http.MethodGet, wikiURL, params, nil)
+}
+
+// MakePostRequest makes an HTTP POST request to the MediaWiki API, as write
+// actions and large parameters require. It negotiates parameters like
+// MakeRequest.
+func (c *Client) MakePostRequest(ctx context.Context, wikiURL string, params url.Values) (*mwResponse, error) {
+	return c.negotiateRequest(ctx, http.MethodPost, wikiURL, params, nil)
+}
+
+// negotiateRequest makes a request, dropping common parameters the wiki
+// rejects. Requests to wikis with an Auth are made in its session. Wikis
+// served from a backend get the request from it instead, rate limited if
+// the backend reaches a server. A file, for POST only, is sent with the
+// parameters as multipart/form-data.
+func (c *Client) negotiateRequest(ctx context.Context, method, wikiURL string, params url.Values, file *uploadFile) (*mwResponse, error) {
+	if b := c.backend(wikiURL); b != nil {
+		if _, ok := b.(remoteBackend); ok {
+			if err := c.waitTurn(ctx, wikiURL); err != nil {
+				return nil, err
+			}
+		}
+		return b.request(ctx, params)
 	}
 
+	return c.authenticatedRequest(ctx, wikiURL, func() (*mwResponse, error) {
+		unsupported := c.unsupportedParams(wikiURL)
+		for {
+			resp, err := c.makeRequest(ctx, method, wikiURL, params, file, unsupported)
+			rejected := rejectedParams(err, params)
+			if len(rejected) == 0 {
+				return resp, err
+			}
+			unsupported = append(unsupported, rejected...)
+			c.rememberUnsupportedParams(wikiURL, unsupported)
+		}
+	})
+}
+
+// rejectedParams returns the optional parameters in a failed request that
+// the error blames. A bare HTTP 400, as some proxies return for unknown
+// parameters, blames all of them.
+func rejectedParams(err error, params url.Values) []string {
+	var sent []string
+	for _, param := range optionalParams {
+		if params.Has(param) {
+			sent = append(sent, param)
+		}
+	}
+
+	var apiErr *APIError
+	var statusErr *httpStatusError
+	switch {
+	case errors.As(err, &apiErr):
+		if !paramRejectionCodes[apiErr.Code] && !strings.HasPrefix(apiErr.Code, "unknown_") {
+			return nil
+		}
+		text := strings.ToLower(apiErr.Code + " " + apiErr.Message)
+		text = strings.ReplaceAll(text, "errorlang", "errorformat")
+		var rejected []string
+		for _, param := range sent {
+			if strings.Contains(text, param) {
+				rejected = append(rejected, param)
+			}
+		}
+		return rejected
+	case errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusBadRequest:
+		return sent
+	}
+	return nil
+}
+
+// makeRequest makes a single API request, leaving out unsupported params
+func (c *Client) makeRequest(ctx context.Context, method, wikiURL string, params url.Values, file *uploadFile, unsupported []string) (*mwResponse, error) {
+	// Apply rate limiting (job budget first, then the per-wiki limit)
+	if err := c.waitTurn(ctx, wikiURL); err != nil {
+		return nil, err
+	}
+
+	c.checkUserAgent(wikiURL)
+
 	// Discover API path
 	apiPath, err := c.getAPIPath(ctx, wikiURL)
 	if err != nil {
@@ -138,18 +265,50 @@ func (c *Client) MakeRequest(ctx context.Context, wikiURL string, params url.Val
 	params.Set("utf8", "1")
 	params.Set("maxlag", "5")
 
-	fullURL := apiURL + "?" + params.Encode()
+	// Localize error messages and interface text when a language was requested
+	if lang := LanguageFromContext(ctx); lang != "" {
+		params.Set("uselang", lang)
+		params.Set("errorformat", "plaintext")
+		params.Set("errorlang", "uselang")
+	}
+
+	for _, param := range unsupported {
+		params.Del(param)
+		if param == "errorformat" {
+			params.Del("errorlang")
+		}
+	}
 
-	// Create request
-	req, err := http.NewRequestWithContext(ctx, "GET", fullURL, nil)
+	// Create request, with the parameters in the body for POST
+	var req *http.Request
+	if method == http.MethodPost && file != nil {
+		var body io.Reader
+		var contentType string
+		body, contentType, err = file.encode(params)
+		if err == nil {
+			req, err = http.NewRequestWithContext(ctx, method, apiURL, body)
+		}
+		if err == nil {
+			req.Header.Set("Content-Type", contentType)
+		}
+	} else if method == http.MethodPost {
+		req, err = http.NewRequestWithContext(ctx, method, apiURL, strings.NewReader(params.Encode()))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	} else {
+		req, err = http.NewRequestWithContext(ctx, method, apiURL+"?"+params.Encode(), nil)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
 
 	req.Header.Set("User-Agent", c.userAgent)
 	req.Header.Set("Accept-Encoding", "gzip")
+	c.authorize(req, wikiURL)
 
 	// Make request
+	countUpstreamCall(ctx)
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("http request: %w", err)
@@ -161,24 +320,19 @@ func (c *Client) MakeRequest(ctx context.Context, wikiURL string, params url.Val
 		if strings.Contains(resp.Header.Get("Content-Encoding"), "gzip") {
 			bodyStr = "(compressed error response)"
 		} else {
-			body, _ := io.ReadAll(resp.Body)
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
 			bodyStr = string(body)
 		}
-		return nil, fmt.Errorf("http status %d: %s", resp.StatusCode, bodyStr)
+		return nil, &httpStatusError{StatusCode: resp.StatusCode, Body: bodyStr}
 	}
 
-	// Handle gzip encoding
-	reader := resp.Body
-	if strings.Contains(resp.Header.Get("Content-Encoding"), "gzip") {
-		gzReader, err := gzip.NewReader(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("gzip reader: %w", err)
-		}
-		defer gzReader.Close()
-		reader = gzReader
+	reader, err := c.responseReader(wikiURL, resp)
+	if err != nil {
+		return nil, err
 	}
+	defer reader.Close()
 
-	// Parse response
+	// Parse response, streaming from the body
 	var mwResp mwResponse
 	if err := json.NewDecoder(reader).Decode(&mwResp); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
@@ -186,25 +340,125 @@ func (c *Client) MakeRequest(ctx context.Context, wikiURL string, params url.Val
 
 	// Check for API errors
 	if mwResp.Error != nil {
-		return nil, &APIError{
-			Code:    mwResp.Error.Code,
-			Message: mwResp.Error.Info,
-		}
+		return nil, newAPIError(mwResp.Error.Code, mwResp.Error.Info, mwResp.Error.mwErrorData)
+	}
+
+	// errorformat=plaintext reports errors as a list instead
+	if len(mwResp.Errors) > 0 {
+		e := mwResp.Errors[0]
+		return nil, newAPIError(e.Code, e.Text, e.Data)
 	}
 
 	return &mwResp, nil
 }
 
+// maxErrorBodyBytes is how much of a non-200 response body is kept for the
+// error message
+const maxErrorBodyBytes = 4096
+
+// responseReader returns the decompressed body of a response, failing with a
+// ResponseTooLargeError once more than the client's limit has been read. A
+// Content-Length over the limit fails before anything is read.
+func (c *Client) responseReader(wikiURL string, resp *http.Response) (io.ReadCloser, error) {
+	tooLarge := &ResponseTooLargeError{WikiURL: wikiURL, MaxBytes: c.maxResponseBytes}
+	if c.maxResponseBytes > 0 && resp.ContentLength > c.maxResponseBytes {
+		return nil, tooLarge
+	}
+
+	reader := resp.Body
+	if strings.Contains(resp.Header.Get("Content-Encoding"), "gzip") {
+		gzReader, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("gzip reader: %w", err)
+		}
+		reader = gzReader
+	}
+	if c.maxResponseBytes <= 0 {
+		return reader, nil
+	}
+
+	return &limitedBody{
+		ReadCloser: reader,
+		limit:      io.LimitReader(reader, c.maxResponseBytes+1),
+		max:        c.maxResponseBytes,
+		err:        tooLarge,
+	}, nil
+}
+
+// limitedBody fails reads with err once more than max bytes have been read.
+// It reads one byte past the limit so a body of exactly max bytes succeeds.
+type limitedBody struct {
+	io.ReadCloser
+	limit io.Reader
+	read  int64
+	max   int64
+	err   error
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.limit.Read(p)
+	b.read += int64(n)
+	if b.read > b.max {
+		return n, b.err
+	}
+	return n, err
+}
+
+// ResponseTooLargeError is returned when a wiki's response exceeds the
+// configured size limit
+type ResponseTooLargeError struct {
+	WikiURL  string
+	MaxBytes int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response from %s exceeds the %d byte limit", e.WikiURL, e.MaxBytes)
+}
+
+// httpStatusError is a non-200 response from the API
+type httpStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("http status %d: %s", e.StatusCode, e.Body)
+}
+
 // APIError represents a MediaWiki API error
 type APIError struct {
 	Code    string
 	Message string
+
+	// Details reported by write-protection extensions, when present
+	AbuseFilter          *AbuseFilterInfo
+	Block                *BlockInfo
+	SpamBlacklistMatches []string
+}
+
+func newAPIError(code, message string, data mwErrorData) *APIError {
+	apiErr := &APIError{
+		Code:        code,
+		Message:     message,
+		AbuseFilter: data.AbuseFilter,
+		Block:       data.BlockInfo,
+	}
+	if data.SpamBlacklist != nil {
+		apiErr.SpamBlacklistMatches = data.SpamBlacklist.Matches
+	}
+	return apiErr
 }
 
 func (e *APIError) Error() string {
 	return fmt.Sprintf("mediawiki api error: %s: %s", e.Code, e.Message)
 }
 
+// Close stops the client's background work, the cache cleanup. The client
+// remains usable.
+func (c *Client) Close() {
+	c.cache.Close()
+}
+
 // GetCache returns the cache instance
 func (c *Client) GetCache() *Cache {
 	return c.cache
@@ -0,0 +1,99 @@
+package wiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMakeRequestDropsRejectedParams(t *testing.T) {
+	var requests []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("action") == "" || q.Get("meta") == "siteinfo" {
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		requests = append(requests, q)
+		switch {
+		case q.Has("formatversion"):
+			fmt.Fprint(w, `{"error":{"code":"badvalue","info":"Unrecognized value for parameter \"formatversion\": 2."}}`)
+		case q.Has("utf8"):
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+		default:
+			fmt.Fprint(w, `{"query":{"pages":{"1":{"pageid":1,"title":"Page","redirect":""}}}}`)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	params := func() url.Values {
+		return url.Values{"action": {"query"}, "titles": {"Page"}}
+	}
+
+	resp, err := client.MakeRequest(context.Background(), srv.URL, params())
+	if err != nil {
+		t.Fatalf("MakeRequest: %v", err)
+	}
+	if len(resp.Query.Pages) != 1 || !resp.Query.Pages[0].Redirect {
+		t.Errorf("legacy format pages = %+v, want one redirect", resp.Query.Pages)
+	}
+	if len(requests) != 3 {
+		t.Errorf("got %d requests, want 3 (two rejected)", len(requests))
+	}
+
+	want := []string{"formatversion", "utf8", "maxlag"}
+	if got := client.unsupportedParams(srv.URL); !reflect.DeepEqual(got, want) {
+		t.Errorf("unsupported params = %v, want %v", got, want)
+	}
+
+	// The quirks are remembered, so the next request succeeds first time
+	requests = nil
+	if _, err := client.MakeRequest(context.Background(), srv.URL, params()); err != nil {
+		t.Fatalf("MakeRequest: %v", err)
+	}
+	if len(requests) != 1 {
+		t.Errorf("got %d requests after negotiation, want 1", len(requests))
+	}
+
+	// Other API errors are returned as they are
+	if got := rejectedParams(&APIError{Code: "missingtitle", Message: "formatversion"}, params()); got != nil {
+		t.Errorf("rejectedParams(missingtitle) = %v, want none", got)
+	}
+}
+
+func TestMakeRequestLimitsResponseSize(t *testing.T) {
+	body := `{"query":{"pages":[{"pageid":1,"title":"` + strings.Repeat("x", 1000) + `"}]}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("meta") == "siteinfo" {
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		// Streamed without a Content-Length, so the limit applies while decoding
+		w.(http.Flusher).Flush()
+		fmt.Fprint(w, body)
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	params := url.Values{"action": {"query"}, "titles": {"Page"}}
+
+	client.SetMaxResponseBytes(int64(len(body)))
+	if _, err := client.MakeRequest(context.Background(), srv.URL, params); err != nil {
+		t.Fatalf("MakeRequest at the limit: %v", err)
+	}
+
+	client.SetMaxResponseBytes(100)
+	_, err := client.MakeRequest(context.Background(), srv.URL, params)
+	var tooLarge *ResponseTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.MaxBytes != 100 {
+		t.Errorf("MakeRequest over the limit = %v, want ResponseTooLargeError", err)
+	}
+}
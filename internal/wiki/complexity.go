@@ -0,0 +1,94 @@
+package wiki
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Default limits on the HTML the client converts. A long Wikipedia article
+// has a few tens of thousands of elements nested a dozen or so deep.
+const (
+	DefaultMaxHTMLNodes = 200000
+	DefaultMaxHTMLDepth = 100
+)
+
+// SetParseLimits sets the most elements and the deepest element nesting of
+// HTML the client converts to Markdown (0 disables a limit). Parsing and
+// converting pages past them, such as tables nested hundreds deep, takes
+// seconds of CPU.
+func (c *Client) SetParseLimits(maxNodes, maxDepth int) {
+	c.maxHTMLNodes = maxNodes
+	c.maxHTMLDepth = maxDepth
+}
+
+// ConvertHTML is ConvertHTML for HTML from wikiURL, with links resolved
+// against the wiki's article path and the client's parse limits applied.
+// HTML past them fails with a PageTooComplexError before it is parsed.
+func (c *Client) ConvertHTML(ctx context.Context, wikiURL, rawHTML string) (*ConvertedContent, error) {
+	if err := checkComplexity(rawHTML, c.maxHTMLNodes, c.maxHTMLDepth); err != nil {
+		return nil, err
+	}
+	return convertHTML(ctx, rawHTML, c.linkResolver(ctx, wikiURL))
+}
+
+// PageTooComplexError is returned when a page's HTML has more elements, or
+// nests them deeper, than the client converts
+type PageTooComplexError struct {
+	Nodes    int // elements counted, up to the first limit exceeded
+	Depth    int // deepest nesting seen
+	MaxNodes int
+	MaxDepth int
+}
+
+func (e *PageTooComplexError) Error() string {
+	if e.MaxDepth > 0 && e.Depth > e.MaxDepth {
+		return fmt.Sprintf("page HTML nests elements more than %d deep", e.MaxDepth)
+	}
+	return fmt.Sprintf("page HTML has more than %d elements", e.MaxNodes)
+}
+
+// checkComplexity counts elements and their nesting with the tokenizer,
+// which runs in linear time without building a tree, and stops at the first
+// limit exceeded. Parser output closes every element it opens, so end tags
+// are enough to track depth.
+func checkComplexity(rawHTML string, maxNodes, maxDepth int) error {
+	if maxNodes <= 0 && maxDepth <= 0 {
+		return nil
+	}
+
+	z := html.NewTokenizer(strings.NewReader(rawHTML))
+	nodes, depth, deepest := 0, 0, 0
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return nil
+		case html.SelfClosingTagToken:
+			nodes++
+		case html.StartTagToken:
+			nodes++
+			name, _ := z.TagName()
+			if !voidElements[string(name)] {
+				depth++
+				deepest = max(deepest, depth)
+			}
+		case html.EndTagToken:
+			depth = max(depth-1, 0)
+		default:
+			continue
+		}
+
+		if (maxNodes > 0 && nodes > maxNodes) || (maxDepth > 0 && deepest > maxDepth) {
+			return &PageTooComplexError{Nodes: nodes, Depth: deepest, MaxNodes: maxNodes, MaxDepth: maxDepth}
+		}
+	}
+}
+
+// voidElements have no end tag, so don't add to the nesting
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"source": true, "track": true, "wbr": true,
+}
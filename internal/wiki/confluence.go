@@ -0,0 +1,285 @@
+package wiki
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var (
+	// confluencePagePath matches the path of a page in a space, as Confluence
+	// Cloud ("/spaces/KEY/pages/123/Title") or Server and Data Center
+	// ("/display/KEY/Title") link to it, keeping the space key and title
+	confluencePagePath = regexp.MustCompile(`^/(?:spaces/([^/]+)/pages/\d+|display/([^/]+))/([^/?#]+)`)
+
+	// confluenceHighlight matches the markers around matched terms in search
+	// excerpts
+	confluenceHighlight = regexp.MustCompile(`@@@(end)?hl@@@`)
+)
+
+// ConfluenceConfig identifies a Confluence space and the credentials to
+// read it with
+type ConfluenceConfig struct {
+	BaseURL  string // e.g. "https://example.atlassian.net/wiki"
+	Space    string // space key
+	Username string // with Token as an API token; empty for a personal access token
+	Token    string
+}
+
+// ConfluenceBackend serves a wiki from a Confluence space through the
+// Confluence REST API, so the page and search tools work on it as they do on
+// MediaWiki. Pages are read from their rendered view, with links between
+// pages of the space rewritten to wiki links. Confluence has no wikitext and
+// its history differs from MediaWiki's, so requests for those fail with a
+// BackendUnsupportedError.
+type ConfluenceBackend struct {
+	config  ConfluenceConfig
+	wikiURL string
+
+	httpClient *http.Client
+	userAgent  string
+}
+
+// NewConfluenceBackend returns a backend serving a Confluence space as wikiURL
+func NewConfluenceBackend(config ConfluenceConfig, wikiURL string) (*ConfluenceBackend, error) {
+	base, err := url.Parse(config.BaseURL)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid Confluence base URL %q", config.BaseURL)
+	}
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+	return &ConfluenceBackend{config: config, wikiURL: wikiURL, httpClient: http.DefaultClient}, nil
+}
+
+// Name identifies the backend in errors
+func (b *ConfluenceBackend) Name() string {
+	return "confluence"
+}
+
+func (b *ConfluenceBackend) useHTTP(client *http.Client, userAgent string) {
+	b.httpClient = client
+	b.userAgent = userAgent
+}
+
+func (b *ConfluenceBackend) pageURL(title string) string {
+	return b.config.BaseURL + "/display/" + url.PathEscape(b.config.Space) + "/" + url.QueryEscape(title)
+}
+
+func (b *ConfluenceBackend) request(ctx context.Context, params url.Values) (*mwResponse, error) {
+	query := params.Get("action") == "query" && params.Get("generator") == ""
+	switch {
+	case params.Get("action") == "parse" && params.Get("page") != "":
+		page, err := b.page(ctx, params.Get("page"))
+		if err != nil {
+			return nil, err
+		}
+		return parsePage(page, params)
+	case query && params.Get("meta") == "siteinfo":
+		return b.siteInfo(), nil
+	case query && params.Get("titles") != "" && params.Get("prop") == "info" && params.Get("list") == "":
+		return pageInfo(ctx, params.Get("titles"), b.page)
+	case query && params.Get("list") == "search" && params.Get("prop") == "":
+		return b.search(ctx, params)
+	}
+	return nil, unsupportedRequest(b, b.wikiURL, params)
+}
+
+// siteInfo describes the space as a wiki whose pages live at the wiki's
+// usual article path
+func (b *ConfluenceBackend) siteInfo() *mwResponse {
+	return &mwResponse{Query: &mwQuery{
+		General: &mwGeneral{
+			Sitename:    b.config.Space,
+			Generator:   "Confluence",
+			Server:      strings.TrimRight(b.wikiURL, "/"),
+			ArticlePath: "/wiki/$1",
+		},
+		Extensions: []mwExtension{},
+	}}
+}
+
+// confluenceContent is a page as the content API returns it
+type confluenceContent struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Version struct {
+		Number int `json:"number"`
+	} `json:"version"`
+	Body struct {
+		View struct {
+			Value string `json:"value"`
+		} `json:"view"`
+	} `json:"body"`
+}
+
+// page reads a page of the space by its exact title. Its revision ID is its
+// Confluence version number and its size that of its HTML.
+func (b *ConfluenceBackend) page(ctx context.Context, title string) (*backendPage, error) {
+	params := url.Values{}
+	params.Set("spaceKey", b.config.Space)
+	params.Set("title", title)
+	params.Set("type", "page")
+	params.Set("expand", "body.view,version")
+	params.Set("limit", "1")
+
+	var resp struct {
+		Results []confluenceContent `json:"results"`
+	}
+	if err := b.get(ctx, "/rest/api/content", params, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Results) == 0 {
+		return nil, &APIError{Code: "missingtitle", Message: fmt.Sprintf("The page %q isn't in the %s space.", title, b.config.Space)}
+	}
+
+	content := resp.Results[0]
+	body, err := b.body(content.Body.View.Value)
+	if err != nil {
+		return nil, fmt.Errorf("read %q from Confluence: %w", title, err)
+	}
+	return &backendPage{
+		Title:  content.Title,
+		RevID:  content.Version.Number,
+		Length: len(content.Body.View.Value),
+		HTML:   body,
+	}, nil
+}
+
+// search answers list=search with a CQL text search of the space. Matched
+// terms in excerpts are marked up like MediaWiki's snippets.
+func (b *ConfluenceBackend) search(ctx context.Context, params url.Values) (*mwResponse, error) {
+	limit := 10
+	if n, err := strconv.Atoi(params.Get("srlimit")); err == nil && n > 0 {
+		limit = n
+	}
+	query := url.Values{}
+	query.Set("cql", fmt.Sprintf("space = %s and type = page and text ~ %s",
+		cqlString(b.config.Space), cqlString(params.Get("srsearch"))))
+	query.Set("limit", strconv.Itoa(limit))
+
+	var resp struct {
+		Results []struct {
+			Content struct {
+				Title string `json:"title"`
+			} `json:"content"`
+			Excerpt string `json:"excerpt"`
+		} `json:"results"`
+	}
+	if err := b.get(ctx, "/rest/api/search", query, &resp); err != nil {
+		return nil, err
+	}
+
+	results := make([]MWSearchResult, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		snippet := confluenceHighlight.ReplaceAllStringFunc(html.EscapeString(r.Excerpt), func(marker string) string {
+			if marker == "@@@endhl@@@" {
+				return "</span>"
+			}
+			return `<span class="searchmatch">`
+		})
+		results = append(results, MWSearchResult{Title: r.Content.Title, Snippet: snippet})
+	}
+	return &mwResponse{Query: &mwQuery{Search: results}}, nil
+}
+
+// get calls a REST API endpoint and decodes its JSON response
+func (b *ConfluenceBackend) get(ctx context.Context, path string, params url.Values, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.config.BaseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if b.userAgent != "" {
+		req.Header.Set("User-Agent", b.userAgent)
+	}
+	switch {
+	case b.config.Username != "":
+		req.SetBasicAuth(b.config.Username, b.config.Token)
+	case b.config.Token != "":
+		req.Header.Set("Authorization", "Bearer "+b.config.Token)
+	}
+
+	countUpstreamCall(ctx)
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Confluence request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read Confluence response: %w", err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return &APIError{Code: "permissiondenied", Message: fmt.Sprintf("Confluence refused access to the %s space (HTTP %d).", b.config.Space, resp.StatusCode)}
+	case resp.StatusCode != http.StatusOK:
+		return &httpStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("parse Confluence response: %w", err)
+	}
+	return nil
+}
+
+// body rewrites links in a page's rendered view: those to pages of the space
+// to the /wiki/ form MediaWiki renders, and other relative ones to absolute
+// URLs on the Confluence server
+func (b *ConfluenceBackend) body(view string) (string, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader("<body>" + view + "</body>"))
+	if err != nil {
+		return "", err
+	}
+	base, _ := url.Parse(b.config.BaseURL)
+	doc.Find("a[href], img[src]").Each(func(_ int, el *goquery.Selection) {
+		attr := "href"
+		if goquery.NodeName(el) == "img" {
+			attr = "src"
+		}
+		ref, _ := el.Attr(attr)
+		target, err := url.Parse(ref)
+		if err != nil || (target.Host != "" && target.Host != base.Host) || (target.Host == "" && !strings.HasPrefix(target.Path, "/")) {
+			return
+		}
+		path := strings.TrimPrefix(target.Path, base.Path)
+		if title := b.hrefTitle(path); attr == "href" && title != "" {
+			link := "/wiki/" + strings.ReplaceAll(title, " ", "_")
+			if target.Fragment != "" {
+				link += "#" + target.Fragment
+			}
+			el.SetAttr("href", link)
+			return
+		}
+		if target.Host == "" {
+			el.SetAttr(attr, base.ResolveReference(target).String())
+		}
+	})
+	return doc.Find("body").Html()
+}
+
+// hrefTitle returns the title of the page of the space at a path relative
+// to the base URL, or "" for other paths
+func (b *ConfluenceBackend) hrefTitle(path string) string {
+	m := confluencePagePath.FindStringSubmatch(path)
+	if m == nil || !strings.EqualFold(m[1]+m[2], b.config.Space) {
+		return ""
+	}
+	title, err := url.QueryUnescape(m[3])
+	if err != nil {
+		return ""
+	}
+	return title
+}
+
+// cqlString quotes a string for a CQL query
+func cqlString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,129 @@
+package wiki
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func newConfluenceServer(t *testing.T) *ConfluenceBackend {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, token, ok := r.BasicAuth(); !ok || user != "bot@example.org" || token != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/wiki/rest/api/content":
+			if r.URL.Query().Get("spaceKey") != "ENG" || r.URL.Query().Get("title") != "Deploys" {
+				w.Write([]byte(`{"results": []}`))
+				return
+			}
+			w.Write([]byte(`{"results": [{"id": "42", "title": "Deploys", "version": {"number": 7}, "body": {"view": {"value": ` +
+				`"<p>See <a href=\"/wiki/spaces/ENG/pages/43/On+call#Rota\">on call</a> and <a href=\"/wiki/download/attachments/42/a.png\">a</a>.</p>` +
+				`<h1>Steps</h1><p>Run it.</p><h2>Rollback</h2><p>Revert.</p>"}}}]}`))
+		case "/wiki/rest/api/search":
+			if !strings.Contains(r.URL.Query().Get("cql"), `text ~ "deploy \"prod\""`) {
+				t.Errorf("cql = %q", r.URL.Query().Get("cql"))
+			}
+			w.Write([]byte(`{"results": [{"content": {"title": "Deploys"}, "excerpt": "How to @@@hl@@@deploy@@@endhl@@@ <safely>"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	b, err := NewConfluenceBackend(ConfluenceConfig{
+		BaseURL:  server.URL + "/wiki/",
+		Space:    "ENG",
+		Username: "bot@example.org",
+		Token:    "secret",
+	}, "https://eng.example.org")
+	if err != nil {
+		t.Fatalf("NewConfluenceBackend() error = %v", err)
+	}
+	return b
+}
+
+func TestConfluenceParse(t *testing.T) {
+	b := newConfluenceServer(t)
+	params := url.Values{"action": {"parse"}, "page": {"Deploys"}, "prop": {"text|sections|links"}}
+
+	resp, err := b.request(context.Background(), params)
+	if err != nil {
+		t.Fatalf("request() error = %v", err)
+	}
+	parse := resp.Parse
+	if parse.Title != "Deploys" || parse.RevID != 7 {
+		t.Errorf("parse = %q rev %d, want Deploys rev 7", parse.Title, parse.RevID)
+	}
+	if len(parse.Sections) != 2 || parse.Sections[1].Line != "Rollback" || parse.Sections[1].Number != "1.1" {
+		t.Errorf("sections = %+v", parse.Sections)
+	}
+	if len(parse.Links) != 1 || parse.Links[0].Title != "On call" {
+		t.Errorf("links = %+v, want On call", parse.Links)
+	}
+	if !strings.Contains(parse.Text.Content, `href="/wiki/On_call#Rota"`) ||
+		!strings.Contains(parse.Text.Content, `href="`+b.config.BaseURL+`/download/attachments/42/a.png"`) {
+		t.Errorf("text = %s, want page links rewritten and attachments absolute", parse.Text.Content)
+	}
+
+	params.Set("section", "2")
+	resp, err = b.request(context.Background(), params)
+	if err != nil || strings.Contains(resp.Parse.Text.Content, "Run it") || !strings.Contains(resp.Parse.Text.Content, "Revert") {
+		t.Errorf("section 2 = %+v, %v", resp, err)
+	}
+
+	var apiErr *APIError
+	_, err = b.request(context.Background(), url.Values{"action": {"parse"}, "page": {"Missing"}})
+	if !errors.As(err, &apiErr) || apiErr.Code != "missingtitle" {
+		t.Errorf("missing page error = %v, want missingtitle", err)
+	}
+}
+
+func TestConfluenceSearchAndInfo(t *testing.T) {
+	b := newConfluenceServer(t)
+
+	resp, err := b.request(context.Background(), url.Values{"action": {"query"}, "list": {"search"}, "srsearch": {`deploy "prod"`}})
+	if err != nil {
+		t.Fatalf("search error = %v", err)
+	}
+	if len(resp.Query.Search) != 1 || resp.Query.Search[0].Snippet != `How to <span class="searchmatch">deploy</span> &lt;safely&gt;` {
+		t.Errorf("search = %+v", resp.Query.Search)
+	}
+
+	resp, err = b.request(context.Background(), url.Values{"action": {"query"}, "prop": {"info"}, "titles": {"Deploys|Missing"}})
+	if err != nil {
+		t.Fatalf("info error = %v", err)
+	}
+	pages := resp.Query.Pages
+	if len(pages) != 2 || pages[0].LastRevID != 7 || !bool(pages[1].Missing) {
+		t.Errorf("info = %+v", pages)
+	}
+
+	var unsupported *BackendUnsupportedError
+	_, err = b.request(context.Background(), url.Values{"action": {"query"}, "prop": {"revisions"}, "titles": {"Deploys"}})
+	if !errors.As(err, &unsupported) || unsupported.Backend != "confluence" {
+		t.Errorf("revisions error = %v, want BackendUnsupportedError", err)
+	}
+
+	if got := b.pageURL("On call"); got != b.config.BaseURL+"/display/ENG/On+call" {
+		t.Errorf("pageURL() = %q", got)
+	}
+}
+
+func TestConfluenceUnauthorized(t *testing.T) {
+	b := newConfluenceServer(t)
+	b.config.Token = "wrong"
+
+	var apiErr *APIError
+	_, err := b.request(context.Background(), url.Values{"action": {"parse"}, "page": {"Deploys"}})
+	if !errors.As(err, &apiErr) || apiErr.Code != "permissiondenied" {
+		t.Errorf("error = %v, want permissiondenied", err)
+	}
+}
@@ -0,0 +1,118 @@
+package wiki
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wikitext"
+)
+
+// ExtractCoordinates extracts the {{coord}} templates in wikitext, including
+// those inside infoboxes. Coordinates displayed at the top of the page
+// (display=title) are marked primary.
+func ExtractCoordinates(text string) []Coordinate {
+	coords := make([]Coordinate, 0)
+	wikitext.Find(wikitext.Parse(text), func(t *wikitext.Template) bool {
+		// Collect every match; returning false keeps the search going
+		if name := t.NormalizedName(); name == "coord" || name == "coor" {
+			if c, ok := parseCoordTemplate(t); ok {
+				coords = append(coords, c)
+			}
+		}
+		return false
+	})
+	return coords
+}
+
+// coordRenderer renders {{coord}} arguments as plain text
+var coordRenderer = &wikitext.Renderer{}
+
+// coordTypeParam strips the population or size suffix from a coordinate
+// type, as in "city(100000)"
+var coordTypeParam = regexp.MustCompile(`\(.*\)$`)
+
+// parseCoordTemplate reads {{coord}} in any of its forms: decimal degrees
+// ({{coord|51.5|-0.12}}), or degrees with optional minutes and seconds
+// followed by a hemisphere ({{coord|51|30|N|0|7|W}})
+func parseCoordTemplate(t *wikitext.Template) (Coordinate, bool) {
+	c := Coordinate{Globe: "earth", Source: "template"}
+
+	var numbers []string
+	for _, value := range t.Positional() {
+		arg := strings.TrimSpace(coordRenderer.Text(value))
+		if !strings.Contains(arg, ":") {
+			numbers = append(numbers, arg)
+			continue
+		}
+		// Coordinate parameters like "type:city_region:US_globe:moon"
+		for _, param := range strings.Split(arg, "_") {
+			key, val, _ := strings.Cut(param, ":")
+			switch strings.ToLower(key) {
+			case "type":
+				c.Type = coordTypeParam.ReplaceAllString(val, "")
+			case "globe":
+				c.Globe = strings.ToLower(val)
+			}
+		}
+	}
+
+	lat, rest, ok := parseCoordAxis(numbers, "N", "S")
+	if !ok {
+		return Coordinate{}, false
+	}
+	lon, _, ok := parseCoordAxis(rest, "E", "W")
+	if !ok {
+		return Coordinate{}, false
+	}
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return Coordinate{}, false
+	}
+	c.Lat, c.Lon = roundCoord(lat), roundCoord(lon)
+
+	c.Name = strings.TrimSpace(coordRenderer.ArgText(t, "name"))
+	display := strings.ToLower(strings.ReplaceAll(coordRenderer.ArgText(t, "display"), " ", ""))
+	c.Primary = strings.Contains(display, "title") || display == "t" || display == "it" || display == "ti"
+	return c, true
+}
+
+// parseCoordAxis reads one latitude or longitude from the front of args:
+// up to three degree/minute/second numbers ending in a hemisphere letter,
+// or a single decimal when no hemisphere follows. It returns the value and
+// the remaining arguments.
+func parseCoordAxis(args []string, positive, negative string) (float64, []string, bool) {
+	for i := 1; i <= 3 && i < len(args); i++ {
+		hemisphere := strings.ToUpper(args[i])
+		if hemisphere != positive && hemisphere != negative {
+			continue
+		}
+
+		value := 0.0
+		for j, part := range args[:i] {
+			n, err := strconv.ParseFloat(part, 64)
+			if err != nil {
+				return 0, nil, false
+			}
+			value += n / math.Pow(60, float64(j))
+		}
+		if hemisphere == negative {
+			value = -value
+		}
+		return value, args[i+1:], true
+	}
+
+	if len(args) == 0 {
+		return 0, nil, false
+	}
+	value, err := strconv.ParseFloat(args[0], 64)
+	if err != nil {
+		return 0, nil, false
+	}
+	return value, args[1:], true
+}
+
+// roundCoord rounds to six decimal places, about 10 cm
+func roundCoord(v float64) float64 {
+	return math.Round(v*1e6) / 1e6
+}
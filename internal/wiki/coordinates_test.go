@@ -0,0 +1,57 @@
+package wiki
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractCoordinates(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []Coordinate
+	}{
+		{
+			name: "decimal",
+			text: `{{coord|51.5074|-0.1278|display=title}}`,
+			want: []Coordinate{{Lat: 51.5074, Lon: -0.1278, Globe: "earth", Primary: true, Source: "template"}},
+		},
+		{
+			name: "degrees minutes seconds",
+			text: `{{Coord|48|51|29.6|N|2|17|40.2|E|type:landmark_region:FR|display=inline,title}}`,
+			want: []Coordinate{{Lat: 48.858222, Lon: 2.2945, Globe: "earth", Type: "landmark", Primary: true, Source: "template"}},
+		},
+		{
+			name: "degrees minutes southern western",
+			text: `{{coord|22|54|S|43|12|W|type:city(6748000)}}`,
+			want: []Coordinate{{Lat: -22.9, Lon: -43.2, Globe: "earth", Type: "city", Source: "template"}},
+		},
+		{
+			name: "other globe in infobox",
+			text: "{{Infobox crater\n| coordinates = {{coord|0.67|N|23.47|E|globe:moon_type:landmark|name=Tranquility Base}}\n}}",
+			want: []Coordinate{{Lat: 0.67, Lon: 23.47, Globe: "moon", Type: "landmark", Name: "Tranquility Base", Source: "template"}},
+		},
+		{
+			name: "several",
+			text: "{{coord|10|20|display=it}} and {{coord|-30|40}}",
+			want: []Coordinate{
+				{Lat: 10, Lon: 20, Globe: "earth", Primary: true, Source: "template"},
+				{Lat: -30, Lon: 40, Globe: "earth", Source: "template"},
+			},
+		},
+		{
+			name: "invalid",
+			text: `{{coord|95|20}} {{coord|abc|def}} {{coord|10}} {{coord missing}}`,
+			want: []Coordinate{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractCoordinates(tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
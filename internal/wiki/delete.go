@@ -0,0 +1,51 @@
+package wiki
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// DeleteResult is the outcome of a page deletion
+type DeleteResult struct {
+	Title  string `json:"title"`
+	Reason string `json:"reason,omitempty"`
+	LogID  int    `json:"log_id,omitempty"`
+	LogURL string `json:"log_url,omitempty"` // the deletion log entry
+}
+
+// Delete deletes a page and invalidates its cached content. Like Edit, it
+// is made with the wiki's Auth; the account needs the delete right, which
+// callers should check first with RequireRight for a clearer error than
+// the wiki's.
+func (c *Client) Delete(ctx context.Context, wikiURL, title, reason string) (*DeleteResult, error) {
+	if b := c.backend(wikiURL); b != nil {
+		return nil, &BackendUnsupportedError{Backend: b.Name(), WikiURL: wikiURL, Request: "delete"}
+	}
+
+	params := url.Values{}
+	params.Set("action", "delete")
+	params.Set("title", title)
+	params.Set("reason", reason)
+
+	resp, err := c.writeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("delete page: %w", err)
+	}
+	if resp.Delete == nil {
+		return nil, fmt.Errorf("empty delete response")
+	}
+
+	c.cache.InvalidatePage(wikiURL, resp.Delete.Title)
+
+	result := &DeleteResult{
+		Title:  resp.Delete.Title,
+		Reason: resp.Delete.Reason,
+		LogID:  resp.Delete.LogID,
+	}
+	if result.LogID > 0 {
+		result.LogURL = c.PageURL(ctx, wikiURL, "Special:Redirect/logid/"+strconv.Itoa(result.LogID))
+	}
+	return result, nil
+}
@@ -0,0 +1,50 @@
+package wiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDelete(t *testing.T) {
+	deleted := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch {
+		case r.Form.Get("type") == "csrf":
+			fmt.Fprint(w, `{"query":{"tokens":{"csrftoken":"token"}}}`)
+		case r.Form.Get("meta") == "userinfo":
+			fmt.Fprint(w, `{"query":{"userinfo":{"name":"Bot","rights":["read","edit"]}}}`)
+		case r.Form.Get("action") == "delete":
+			deleted = true
+			fmt.Fprint(w, `{"delete":{"title":"Spam","reason":"Vandalism","logid":99}}`)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	client.SetAuth(srv.URL, Auth{AccessToken: "oauth"})
+
+	err := client.RequireRight(context.Background(), srv.URL, "delete", "delete pages")
+	var rightErr *MissingRightError
+	if !errors.As(err, &rightErr) || rightErr.Right != "delete" {
+		t.Errorf("RequireRight = %v, want a MissingRightError for delete", err)
+	}
+	if err := client.RequireRight(context.Background(), srv.URL, "edit", "edit pages"); err != nil {
+		t.Errorf("RequireRight(edit) = %v", err)
+	}
+
+	result, err := client.Delete(context.Background(), srv.URL, "Spam", "Vandalism")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !deleted || result.Title != "Spam" || result.LogID != 99 || result.LogURL == "" {
+		t.Errorf("result = %+v, want Spam deleted with log entry 99", result)
+	}
+}
@@ -0,0 +1,36 @@
+package wiki
+
+import "strings"
+
+// OmitSections returns markdown with some of its sections replaced by a
+// placeholder. omit is called with each ATX heading's position among the
+// headings (from 1, like section indexes), level, and text, and returns the
+// placeholder for a section to leave out. A section runs to the next heading
+// of the same or a higher level, so its subsections go with it.
+func OmitSections(markdown string, omit func(ordinal, level int, heading string) (string, bool)) string {
+	lines := strings.Split(markdown, "\n")
+	out := make([]string, 0, len(lines))
+	ordinal, omitting := 0, 0 // omitting is the level of the section left out, or 0
+	for _, line := range lines {
+		level, heading := markdownHeading(line)
+		if level == 0 {
+			if omitting == 0 {
+				out = append(out, line)
+			}
+			continue
+		}
+
+		ordinal++
+		if omitting != 0 && level > omitting {
+			continue
+		}
+		omitting = 0
+		if placeholder, ok := omit(ordinal, level, heading); ok {
+			out = append(out, line, placeholder, "")
+			omitting = level
+			continue
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
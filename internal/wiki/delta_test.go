@@ -0,0 +1,25 @@
+package wiki
+
+import "testing"
+
+func TestOmitSections(t *testing.T) {
+	markdown := "Lead text.\n\n## History\n\nOld times.\n\n### Early\n\nVery old.\n\n## Geography\n\nHills.\n\n### Early\n\nRocks."
+
+	var seen []int
+	got := OmitSections(markdown, func(ordinal, level int, heading string) (string, bool) {
+		seen = append(seen, ordinal)
+		if heading == "History" {
+			return "[omitted]", true
+		}
+		return "", false
+	})
+
+	want := "Lead text.\n\n## History\n[omitted]\n\n## Geography\n\nHills.\n\n### Early\n\nRocks."
+	if got != want {
+		t.Errorf("OmitSections() = %q, want %q", got, want)
+	}
+	// The subsection of an omitted section is never offered
+	if len(seen) != 3 || seen[0] != 1 || seen[1] != 3 || seen[2] != 4 {
+		t.Errorf("omit called for headings %v, want [1 3 4]", seen)
+	}
+}
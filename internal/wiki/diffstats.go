@@ -0,0 +1,148 @@
+package wiki
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// DiffStats summarizes the changes in a diff between two revisions
+type DiffStats struct {
+	LinesAdded        int      `json:"lines_added"`
+	LinesRemoved      int      `json:"lines_removed"`
+	WordsAdded        int      `json:"words_added"`
+	WordsRemoved      int      `json:"words_removed"`
+	Sections          []string `json:"sections,omitempty"` // headings of the sections changed, where the diff shows them
+	ReferencesChanged bool     `json:"references_changed"`
+	InfoboxChanged    bool     `json:"infobox_changed"`
+}
+
+// diffHeading matches a wikitext section heading line
+var diffHeading = regexp.MustCompile(`^(={1,6})\s*(.+?)\s*={1,6}\s*$`)
+
+// referenceMarkup matches the markup of citations and reference lists
+var referenceMarkup = regexp.MustCompile(`(?i)<ref[\s>/]|</ref>|<references|\{\{\s*(reflist|cite|citation|sfn|harvnb)`)
+
+// ParseDiffStats computes DiffStats from the HTML table rows of an
+// action=compare diff. A changed line counts as one line removed and one
+// added, with only the words that changed within it. The diff shows a few
+// lines of context around each change, so a change's section is known when
+// its heading is among them, or is itself changed; an infobox change is
+// likewise one inside an infobox the hunk shows the start of.
+func ParseDiffStats(diffHTML string) DiffStats {
+	var stats DiffStats
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader("<table>" + diffHTML + "</table>"))
+	if err != nil {
+		return stats
+	}
+
+	var section string
+	inInfobox := false
+	seen := make(map[string]bool)
+	addSection := func(heading string) {
+		if heading != "" && !seen[heading] {
+			seen[heading] = true
+			stats.Sections = append(stats.Sections, heading)
+		}
+	}
+
+	doc.Find("tr").Each(func(_ int, row *goquery.Selection) {
+		// A line number row starts a hunk, after which nothing is
+		// known about where the diff is
+		if row.Find("td.diff-lineno").Length() > 0 {
+			section, inInfobox = "", false
+			return
+		}
+
+		deleted := row.Find("td.diff-deletedline")
+		added := row.Find("td.diff-addedline")
+		context := row.Find("td.diff-context").Last()
+
+		// The line as it is after the change, for tracking where the hunk is
+		line := context.Text()
+		if added.Length() > 0 {
+			line = added.Text()
+		}
+		if m := diffHeading.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			section = m[2]
+		}
+		opensInfobox := strings.Contains(strings.ToLower(line), "{{infobox")
+		if opensInfobox {
+			inInfobox = true
+		}
+
+		if deleted.Length() > 0 || added.Length() > 0 {
+			paired := deleted.Length() > 0 && added.Length() > 0
+			removedText := changedText(deleted, "del", paired)
+			addedText := changedText(added, "ins", paired)
+			if deleted.Length() > 0 {
+				stats.LinesRemoved++
+				stats.WordsRemoved += len(strings.Fields(removedText))
+				if m := diffHeading.FindStringSubmatch(strings.TrimSpace(deleted.Text())); m != nil {
+					addSection(m[2])
+				}
+			}
+			if added.Length() > 0 {
+				stats.LinesAdded++
+				stats.WordsAdded += len(strings.Fields(addedText))
+			}
+			addSection(section)
+			if referenceMarkup.MatchString(removedText + "\n" + addedText) {
+				stats.ReferencesChanged = true
+			}
+			if inInfobox || strings.Contains(strings.ToLower(deleted.Text()), "{{infobox") {
+				stats.InfoboxChanged = true
+			}
+		}
+
+		if inInfobox && !opensInfobox && strings.HasPrefix(strings.TrimSpace(line), "}}") {
+			inInfobox = false
+		}
+	})
+
+	return stats
+}
+
+// changedText returns the text a diff cell changed: all of it for a line
+// added or removed whole, or its inline changes (ins or del elements) for
+// a line paired with its other version, which has none when the change
+// only added or only removed text
+func changedText(cell *goquery.Selection, inline string, paired bool) string {
+	if cell.Length() == 0 {
+		return ""
+	}
+	if !paired {
+		return cell.Text()
+	}
+	changes := cell.Find(inline + ".diffchange")
+	parts := make([]string, 0, changes.Length())
+	changes.Each(func(_ int, change *goquery.Selection) {
+		parts = append(parts, change.Text())
+	})
+	return strings.Join(parts, " ")
+}
+
+// Summary describes the stats in a sentence, e.g. "+3/-1 lines, +42/-7
+// words in History, Career; references changed"
+func (s DiffStats) Summary() string {
+	if s.LinesAdded == 0 && s.LinesRemoved == 0 {
+		return "No changes to the text"
+	}
+	summary := fmt.Sprintf("+%d/-%d lines, +%d/-%d words", s.LinesAdded, s.LinesRemoved, s.WordsAdded, s.WordsRemoved)
+	if len(s.Sections) > 0 {
+		summary += " in " + strings.Join(s.Sections, ", ")
+	}
+	var notes []string
+	if s.ReferencesChanged {
+		notes = append(notes, "references changed")
+	}
+	if s.InfoboxChanged {
+		notes = append(notes, "infobox changed")
+	}
+	if len(notes) > 0 {
+		summary += "; " + strings.Join(notes, "; ")
+	}
+	return summary
+}
@@ -0,0 +1,59 @@
+package wiki
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDiffStats(t *testing.T) {
+	row := func(deleted, added string) string {
+		html := "<tr>"
+		if deleted != "" {
+			html += `<td class="diff-marker" data-marker="−"></td><td class="diff-deletedline diff-side-deleted"><div>` + deleted + `</div></td>`
+		} else {
+			html += `<td colspan="2" class="diff-empty diff-side-deleted"></td>`
+		}
+		if added != "" {
+			html += `<td class="diff-marker" data-marker="+"></td><td class="diff-addedline diff-side-added"><div>` + added + `</div></td>`
+		} else {
+			html += `<td colspan="2" class="diff-empty diff-side-added"></td>`
+		}
+		return html + "</tr>"
+	}
+	context := func(line string) string {
+		return `<tr><td class="diff-marker"></td><td class="diff-context diff-side-deleted"><div>` + line + `</div></td><td class="diff-marker"></td><td class="diff-context diff-side-added"><div>` + line + `</div></td></tr>`
+	}
+	lineno := `<tr><td colspan="2" class="diff-lineno">Line 1:</td><td colspan="2" class="diff-lineno">Line 1:</td></tr>`
+
+	diff := lineno +
+		context("{{Infobox settlement") +
+		row(`| population = <del class="diffchange diffchange-inline">1,000</del>`, `| population = <ins class="diffchange diffchange-inline">1,200</ins>`) +
+		context("}}") +
+		lineno +
+		context("== History ==") +
+		row("", "The town was founded in 1901.&lt;ref&gt;{{cite book|title=Annals}}&lt;/ref&gt;") +
+		lineno +
+		context("It has a school.") +
+		row(`It has a <del class="diffchange diffchange-inline">small</del> harbour.`, `It has a harbour.`)
+
+	got := ParseDiffStats(diff)
+	want := DiffStats{
+		LinesAdded:        3,
+		LinesRemoved:      2,
+		WordsAdded:        8,
+		WordsRemoved:      2,
+		Sections:          []string{"History"},
+		ReferencesChanged: true,
+		InfoboxChanged:    true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseDiffStats = %+v, want %+v", got, want)
+	}
+	if summary := got.Summary(); summary != "+3/-2 lines, +8/-2 words in History; references changed; infobox changed" {
+		t.Errorf("Summary = %q", summary)
+	}
+
+	if summary := ParseDiffStats("").Summary(); summary != "No changes to the text" {
+		t.Errorf("empty Summary = %q", summary)
+	}
+}
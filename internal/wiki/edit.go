@@ -0,0 +1,273 @@
+package wiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// EditParams describes a change to a page made with action=edit
+type EditParams struct {
+	Title     string `json:"title"`
+	Text      string `json:"text"`
+	Section   string `json:"section,omitempty"` // section index, "new", or empty for the whole page
+	Summary   string `json:"summary"`
+	Bot       bool   `json:"bot,omitempty"`
+	BaseRevID int    `json:"base_revid,omitempty"` // revision the edit was based on, for conflict detection
+
+	SectionTitle string `json:"section_title,omitempty"` // heading of a new section
+	CreateOnly   bool   `json:"create_only,omitempty"`   // fail instead of editing a page that exists
+	NoCreate     bool   `json:"no_create,omitempty"`     // fail instead of creating a page that doesn't exist
+
+	// Topic posts a new section as a discussion topic with DiscussionTools
+	// (see AddTopic) instead of action=edit
+	Topic bool `json:"topic,omitempty"`
+
+	// Undo reverts a revision instead of setting Text, and UndoAfter, when
+	// set, the revisions between it and Undo too
+	Undo      int `json:"undo,omitempty"`
+	UndoAfter int `json:"undo_after,omitempty"`
+}
+
+// PageExistsError is returned for a page creation, or a move, when the
+// title already exists, so it isn't overwritten
+type PageExistsError struct {
+	Title    string
+	PageID   int  // 0 when the wiki refused the creation without saying
+	Redirect bool // the title is a redirect
+}
+
+func (e *PageExistsError) Error() string {
+	if e.Redirect {
+		return fmt.Sprintf("page %q already exists as a redirect", e.Title)
+	}
+	return fmt.Sprintf("page %q already exists", e.Title)
+}
+
+// EditConflictError is a write based on a revision the page has moved on
+// from, refused so it doesn't overwrite the newer change
+type EditConflictError struct {
+	Title     string
+	BaseRevID int
+	Current   *RevisionDetails // the page's current revision; nil if it couldn't be looked up
+
+	// Saved is the write, when the wiki can't refuse it on a base revision
+	// and the page changed between the check and the write. Current is
+	// then the revision the write was saved on top of.
+	Saved interface{}
+}
+
+func (e *EditConflictError) Error() string {
+	if e.Saved != nil && e.Current != nil {
+		return fmt.Sprintf("page %q changed since revision %d and the write was saved on top of revision %d by %s", e.Title, e.BaseRevID, e.Current.ID, e.Current.User)
+	}
+	if e.Saved != nil {
+		return fmt.Sprintf("page %q changed since revision %d and the write was saved on top of the change", e.Title, e.BaseRevID)
+	}
+	if e.Current != nil {
+		return fmt.Sprintf("page %q changed since revision %d: it is now at revision %d by %s", e.Title, e.BaseRevID, e.Current.ID, e.Current.User)
+	}
+	return fmt.Sprintf("page %q changed since revision %d", e.Title, e.BaseRevID)
+}
+
+// EditResult is the outcome of a saved edit
+type EditResult struct {
+	Title     string `json:"title"`
+	PageID    int    `json:"page_id"`
+	OldRevID  int    `json:"old_revid,omitempty"`
+	NewRevID  int    `json:"new_revid,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+	NoChange  bool   `json:"no_change,omitempty"`
+	NewPage   bool   `json:"new_page,omitempty"`
+	DiffURL   string `json:"diff_url,omitempty"` // the change on the wiki; empty when nothing changed
+}
+
+// GetCSRFToken fetches a token for write actions from the wiki. Edits
+// reuse the token of their account's session instead (see writeRequest).
+func (c *Client) GetCSRFToken(ctx context.Context, wikiURL string) (string, error) {
+	return c.getToken(ctx, wikiURL, "csrf")
+}
+
+// getToken fetches a token of a type, "csrf" or "rollback", from the wiki
+func (c *Client) getToken(ctx context.Context, wikiURL, tokenType string) (string, error) {
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("meta", "tokens")
+	params.Set("type", tokenType)
+
+	resp, err := c.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return "", fmt.Errorf("get %s token: %w", tokenType, err)
+	}
+
+	var token string
+	if resp.Query != nil && resp.Query.Tokens != nil {
+		switch tokenType {
+		case "csrf":
+			token = resp.Query.Tokens.CSRFToken
+		case "rollback":
+			token = resp.Query.Tokens.RollbackToken
+		}
+	}
+	if token == "" {
+		return "", fmt.Errorf("empty token response")
+	}
+	return token, nil
+}
+
+// Edit saves a page edit and invalidates the page's cached content. It is
+// made with the wiki's Auth (see writeRequest); wikis without one get a
+// NoCredentialsError rather than an anonymous edit.
+func (c *Client) Edit(ctx context.Context, wikiURL string, edit EditParams) (*EditResult, error) {
+	if b := c.backend(wikiURL); b != nil {
+		return nil, &BackendUnsupportedError{Backend: b.Name(), WikiURL: wikiURL, Request: "edit"}
+	}
+	// The wiki doesn't check new sections against a base revision, since
+	// it can add them whatever changed, so they are checked before saving
+	// and against the revision they were added to after
+	checkBase := edit.BaseRevID > 0 && (edit.Section == "new" || edit.Topic)
+	if checkBase {
+		if err := c.CheckBaseRevision(ctx, wikiURL, edit.Title, edit.BaseRevID); err != nil {
+			return nil, err
+		}
+	}
+	if edit.Topic {
+		result, err := c.AddTopic(ctx, wikiURL, edit)
+		if err != nil || !checkBase || result.NewRevID == 0 {
+			return result, err
+		}
+		revisions, err := c.GetRevisions(ctx, wikiURL, result.NewRevID)
+		if err != nil {
+			AddWarning(ctx, "base_revid", err)
+			return result, nil
+		}
+		if err := c.SavedOnBase(ctx, wikiURL, edit.Title, edit.BaseRevID, revisions[0].ParentID, result); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	params := url.Values{}
+	params.Set("action", "edit")
+	params.Set("title", edit.Title)
+	if edit.Undo > 0 {
+		params.Set("undo", strconv.Itoa(edit.Undo))
+		if edit.UndoAfter > 0 {
+			params.Set("undoafter", strconv.Itoa(edit.UndoAfter))
+		}
+	} else {
+		params.Set("text", edit.Text)
+	}
+	params.Set("summary", edit.Summary)
+	if edit.Section != "" {
+		params.Set("section", edit.Section)
+	}
+	if edit.SectionTitle != "" {
+		params.Set("sectiontitle", edit.SectionTitle)
+	}
+	if edit.Bot {
+		params.Set("bot", "1")
+	}
+	if edit.BaseRevID > 0 && !checkBase {
+		// The wiki detects conflicts from the base revision's timestamp
+		base, err := c.GetRevisions(ctx, wikiURL, edit.BaseRevID)
+		if err != nil {
+			return nil, fmt.Errorf("get base revision: %w", err)
+		}
+		params.Set("baserevid", strconv.Itoa(edit.BaseRevID))
+		params.Set("basetimestamp", base[0].Timestamp.UTC().Format(time.RFC3339))
+	}
+	if edit.CreateOnly {
+		params.Set("createonly", "1")
+	}
+	if edit.NoCreate {
+		params.Set("nocreate", "1")
+	}
+
+	resp, err := c.writeRequest(ctx, wikiURL, params)
+	var apiErr *APIError
+	if edit.CreateOnly && errors.As(err, &apiErr) && apiErr.Code == "articleexists" {
+		return nil, &PageExistsError{Title: edit.Title}
+	}
+	if errors.As(err, &apiErr) && apiErr.Code == "editconflict" {
+		conflict := &EditConflictError{Title: edit.Title, BaseRevID: edit.BaseRevID}
+		conflict.Current, _ = c.LatestRevision(ctx, wikiURL, edit.Title)
+		return nil, conflict
+	}
+	if err != nil {
+		return nil, fmt.Errorf("edit page: %w", err)
+	}
+
+	if resp.Edit == nil {
+		return nil, fmt.Errorf("empty edit response")
+	}
+	if resp.Edit.Result != "Success" {
+		return nil, &APIError{Code: "editfailed", Message: fmt.Sprintf("edit of %s was not saved (result %q)", edit.Title, resp.Edit.Result)}
+	}
+
+	c.cache.InvalidatePage(wikiURL, edit.Title)
+
+	result := &EditResult{
+		Title:     resp.Edit.Title,
+		PageID:    resp.Edit.PageID,
+		OldRevID:  resp.Edit.OldRevID,
+		NewRevID:  resp.Edit.NewRevID,
+		Timestamp: resp.Edit.NewTimestamp,
+		NoChange:  bool(resp.Edit.NoChange),
+		NewPage:   bool(resp.Edit.New),
+	}
+	if result.NewRevID > 0 {
+		result.DiffURL = c.DiffURL(ctx, wikiURL, result.OldRevID, result.NewRevID)
+	}
+	if checkBase && !result.NoChange {
+		if err := c.SavedOnBase(ctx, wikiURL, edit.Title, edit.BaseRevID, result.OldRevID, result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// DiffURL returns the URL of the change between two revisions, as
+// Special:Diff shows it. Without an old revision, it shows newRevID
+// against the revision before it, or the whole page for its first one.
+func (c *Client) DiffURL(ctx context.Context, wikiURL string, oldRevID, newRevID int) string {
+	target := "Special:Diff/" + strconv.Itoa(newRevID)
+	if oldRevID > 0 {
+		target = "Special:Diff/" + strconv.Itoa(oldRevID) + "/" + strconv.Itoa(newRevID)
+	}
+	return c.PageURL(ctx, wikiURL, target)
+}
+
+// CheckBaseRevision fails with an EditConflictError when a page's current
+// revision isn't baseRevID, for writes the wiki can't check a base
+// revision for itself, such as moves and deletions. The page can still
+// change between the check and the write; SavedOnBase finds that after the
+// write where the write leaves a revision to compare.
+func (c *Client) CheckBaseRevision(ctx context.Context, wikiURL, title string, baseRevID int) error {
+	current, err := c.LatestRevision(ctx, wikiURL, title)
+	if err != nil {
+		return err
+	}
+	if current.ID != baseRevID {
+		return &EditConflictError{Title: title, BaseRevID: baseRevID, Current: current}
+	}
+	return nil
+}
+
+// SavedOnBase fails with an EditConflictError carrying saved when
+// parentRevID, the revision a write was saved on top of, isn't baseRevID:
+// the page changed between CheckBaseRevision and a write the wiki couldn't
+// refuse for it
+func (c *Client) SavedOnBase(ctx context.Context, wikiURL, title string, baseRevID, parentRevID int, saved interface{}) error {
+	if parentRevID == baseRevID {
+		return nil
+	}
+	conflict := &EditConflictError{Title: title, BaseRevID: baseRevID, Saved: saved}
+	if revisions, err := c.GetRevisions(ctx, wikiURL, parentRevID); err == nil {
+		conflict.Current = &revisions[0]
+	}
+	return conflict
+}
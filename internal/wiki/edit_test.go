@@ -0,0 +1,194 @@
+package wiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestEditDiffURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch {
+		case r.Form.Get("meta") == "siteinfo":
+			fmt.Fprint(w, `{"query":{"general":{"sitename":"Test","generator":"MediaWiki 1.42.0","server":"https://wiki.example.org","articlepath":"/wiki/$1"}}}`)
+		case r.Form.Get("type") == "csrf":
+			fmt.Fprint(w, `{"query":{"tokens":{"csrftoken":"token"}}}`)
+		case r.Form.Get("title") == "New page":
+			fmt.Fprint(w, `{"edit":{"result":"Success","title":"New page","new":true,"oldrevid":0,"newrevid":7}}`)
+		case r.Form.Get("title") == "Unchanged":
+			fmt.Fprint(w, `{"edit":{"result":"Success","title":"Unchanged","nochange":true}}`)
+		default:
+			fmt.Fprint(w, `{"edit":{"result":"Success","title":"Page","oldrevid":5,"newrevid":6}}`)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	client.SetAuth(srv.URL, Auth{AccessToken: "oauth"})
+
+	tests := map[string]string{
+		"Page":      "https://wiki.example.org/wiki/Special:Diff/5/6",
+		"New page":  "https://wiki.example.org/wiki/Special:Diff/7",
+		"Unchanged": "",
+	}
+	for title, want := range tests {
+		result, err := client.Edit(context.Background(), srv.URL, EditParams{Title: title, Text: "text", Summary: "test"})
+		if err != nil {
+			t.Fatalf("Edit %s: %v", title, err)
+		}
+		if result.DiffURL != want {
+			t.Errorf("Edit %s DiffURL = %q, want %q", title, result.DiffURL, want)
+		}
+	}
+}
+
+func TestEditCreateOnly(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch {
+		case r.Form.Get("type") == "csrf":
+			fmt.Fprint(w, `{"query":{"tokens":{"csrftoken":"token"}}}`)
+		case r.Form.Get("action") == "edit" && r.Form.Get("createonly") == "1":
+			fmt.Fprint(w, `{"error":{"code":"articleexists","info":"The article you tried to create has been created already."}}`)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	client.SetAuth(srv.URL, Auth{AccessToken: "oauth"})
+
+	_, err := client.Edit(context.Background(), srv.URL, EditParams{Title: "Taken", Text: "text", Summary: "test", CreateOnly: true})
+	var existsErr *PageExistsError
+	if !errors.As(err, &existsErr) || existsErr.Title != "Taken" {
+		t.Errorf("Edit = %v, want a PageExistsError for Taken", err)
+	}
+}
+
+func TestEditNewSection(t *testing.T) {
+	var form url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch {
+		case r.Form.Get("type") == "csrf":
+			fmt.Fprint(w, `{"query":{"tokens":{"csrftoken":"token"}}}`)
+		case r.Form.Get("action") == "edit":
+			form = r.PostForm
+			fmt.Fprint(w, `{"edit":{"result":"Success","title":"Talk:Page","oldrevid":5,"newrevid":6}}`)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	client.SetAuth(srv.URL, Auth{AccessToken: "oauth"})
+
+	_, err := client.Edit(context.Background(), srv.URL, EditParams{
+		Title: "Talk:Page", Text: "Comment", Summary: "test", Section: "new", SectionTitle: "Question", NoCreate: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]string{"section": "new", "sectiontitle": "Question", "nocreate": "1", "createonly": ""} {
+		if got := form.Get(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestEditConflict(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch {
+		case r.Form.Get("type") == "csrf":
+			fmt.Fprint(w, `{"query":{"tokens":{"csrftoken":"token"}}}`)
+		case r.Form.Get("revids") == "5":
+			fmt.Fprint(w, `{"query":{"pages":[{"pageid":1,"ns":0,"title":"Town","revisions":[{"revid":5,"parentid":4,"timestamp":"2026-01-01T00:00:00Z","user":"A"}]}]}}`)
+		case r.Form.Get("prop") == "revisions":
+			fmt.Fprint(w, `{"query":{"pages":[{"pageid":1,"ns":0,"title":"Town","revisions":[{"revid":7,"parentid":6,"timestamp":"2026-01-03T00:00:00Z","user":"B"}]}]}}`)
+		case r.Form.Get("action") == "edit":
+			if r.Form.Get("baserevid") != "5" || r.Form.Get("basetimestamp") != "2026-01-01T00:00:00Z" {
+				t.Errorf("baserevid = %q, basetimestamp = %q", r.Form.Get("baserevid"), r.Form.Get("basetimestamp"))
+			}
+			fmt.Fprint(w, `{"error":{"code":"editconflict","info":"Edit conflict."}}`)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	client.SetAuth(srv.URL, Auth{AccessToken: "oauth"})
+	ctx := context.Background()
+
+	_, err := client.Edit(ctx, srv.URL, EditParams{Title: "Town", Text: "New", Summary: "s", BaseRevID: 5})
+	var conflict *EditConflictError
+	if !errors.As(err, &conflict) || conflict.BaseRevID != 5 || conflict.Current == nil || conflict.Current.ID != 7 {
+		t.Fatalf("Edit = %v, want a conflict with revision 7 current", err)
+	}
+
+	err = client.CheckBaseRevision(ctx, srv.URL, "Town", 5)
+	if !errors.As(err, &conflict) || conflict.Current.User != "B" {
+		t.Errorf("CheckBaseRevision(5) = %v, want a conflict", err)
+	}
+	if err := client.CheckBaseRevision(ctx, srv.URL, "Town", 7); err != nil {
+		t.Errorf("CheckBaseRevision(7) = %v", err)
+	}
+}
+
+func TestEditNewSectionBaseRevision(t *testing.T) {
+	var oldRevID int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch {
+		case r.Form.Get("type") == "csrf":
+			fmt.Fprint(w, `{"query":{"tokens":{"csrftoken":"token"}}}`)
+		case r.Form.Get("revids") == "6":
+			fmt.Fprint(w, `{"query":{"pages":[{"pageid":1,"ns":1,"title":"Talk:Town","revisions":[{"revid":6,"parentid":5,"timestamp":"2026-01-02T00:00:00Z","user":"B"}]}]}}`)
+		case r.Form.Get("prop") == "revisions":
+			fmt.Fprint(w, `{"query":{"pages":[{"pageid":1,"ns":1,"title":"Talk:Town","revisions":[{"revid":5,"parentid":4,"timestamp":"2026-01-01T00:00:00Z","user":"A"}]}]}}`)
+		case r.Form.Get("action") == "edit":
+			if r.Form.Has("baserevid") {
+				t.Errorf("baserevid = %q, which the wiki ignores for new sections", r.Form.Get("baserevid"))
+			}
+			fmt.Fprintf(w, `{"edit":{"result":"Success","title":"Talk:Town","oldrevid":%d,"newrevid":7}}`, oldRevID)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	client.SetAuth(srv.URL, Auth{AccessToken: "oauth"})
+	ctx := context.Background()
+	edit := EditParams{Title: "Talk:Town", Text: "Comment", Summary: "s", Section: "new", SectionTitle: "Question", BaseRevID: 5}
+
+	oldRevID = 5
+	if _, err := client.Edit(ctx, srv.URL, edit); err != nil {
+		t.Fatalf("Edit on the base revision = %v", err)
+	}
+
+	// Revision 6 was saved between the check and the edit
+	oldRevID = 6
+	_, err := client.Edit(ctx, srv.URL, edit)
+	var conflict *EditConflictError
+	if !errors.As(err, &conflict) || conflict.Saved == nil || conflict.Current == nil || conflict.Current.ID != 6 {
+		t.Fatalf("Edit = %v, want a conflict with the edit saved on top of revision 6", err)
+	}
+	if saved, ok := conflict.Saved.(*EditResult); !ok || saved.NewRevID != 7 {
+		t.Errorf("Saved = %#v, want the edit's result", conflict.Saved)
+	}
+
+	edit.BaseRevID = 4
+	if _, err := client.Edit(ctx, srv.URL, edit); !errors.As(err, &conflict) || conflict.Saved != nil {
+		t.Errorf("Edit on a stale base = %v, want a conflict before saving", err)
+	}
+}
@@ -0,0 +1,135 @@
+package wiki
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// The parsers below take content from remote wikis, which may be malformed
+// or hostile. The fuzz targets check they never panic and keep their
+// output invariants; run one with, e.g.
+//
+//	go test ./internal/wiki -run '^$' -fuzz FuzzHTMLToMarkdown -fuzztime 1m
+
+func FuzzHTMLToMarkdown(f *testing.F) {
+	for _, name := range []string{"article_lead.html", "article_section.html", "article_footer.html"} {
+		f.Add(readFixture(f, name))
+	}
+	f.Add(`<p>Text<sup class="reference"><a href="#cite_note-1">[1]</a></sup></p>`)
+	f.Add(`<table class="infobox"><tr><th>Born</th><td>1879</td></tr>`)
+	f.Add(`<div class="navbox"><ul><li><a href="/wiki/A">A</a></li></div></p></table>`)
+	f.Add("<p>\n\n\n\n</p><pre>\r\n\t</pre>")
+	f.Add(strings.Repeat("<div><b>", 300) + "deep")
+
+	f.Fuzz(func(t *testing.T, html string) {
+		md, err := HTMLToMarkdown(context.Background(), html)
+		if err != nil {
+			return
+		}
+		if md != strings.TrimSpace(md) {
+			t.Errorf("markdown has surrounding whitespace: %q", md)
+		}
+		if strings.Contains(md, "\n\n\n") {
+			t.Errorf("markdown has more than one blank line in a row: %q", md)
+		}
+		if utf8.ValidString(html) && !utf8.ValidString(md) {
+			t.Errorf("markdown of valid UTF-8 is invalid: %q", md)
+		}
+	})
+}
+
+func FuzzExtractInfobox(f *testing.F) {
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "infobox", "*.wikitext"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, fixture := range fixtures {
+		text, err := os.ReadFile(fixture)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(string(text))
+	}
+	f.Add(readFixture(f, "article.wikitext"))
+	f.Add("{{Infobox person|name={{nowrap|{{lang|fr|x}}}}|born={{birth date|1879|3")
+	f.Add("{{Infobox|a=[[b|c]]|{{!}}|=|d=<ref>{{cite|}}</ref>}}")
+	f.Add(strings.Repeat("{{Infobox|", 5000))
+	f.Add("{{Infobox|a=" + strings.Repeat("<ref name=x>", 5000) + "}}")
+
+	f.Fuzz(func(t *testing.T, text string) {
+		infobox := ExtractInfobox(text)
+		if infobox == nil {
+			return
+		}
+		if len(infobox) == 0 {
+			t.Error("empty infobox returned instead of nil")
+		}
+		for name, value := range infobox {
+			if name == "" {
+				t.Error("field with an empty name")
+			}
+			s, ok := value.(string)
+			if !ok {
+				t.Errorf("field %q is %T, want string", name, value)
+				continue
+			}
+			if strings.Contains(s, "\n") {
+				t.Errorf("field %q spans lines: %q", name, s)
+			}
+		}
+	})
+}
+
+func FuzzExtractTitleFromHref(f *testing.F) {
+	f.Add("/wiki/Go_(programming_language)")
+	f.Add("/wiki/Go#History")
+	f.Add("/w/index.php?title=Go&action=edit&redlink=1")
+	f.Add("/w/index.php?title=Talk:Go#Top")
+	f.Add("https://example.org/?title=")
+	f.Add("#cite_note-1")
+
+	f.Fuzz(func(t *testing.T, href string) {
+		title := extractTitleFromHref(href)
+		if strings.ContainsAny(title, "_#") {
+			t.Errorf("title %q of %q has an underscore or anchor", title, href)
+		}
+		if title != "" && !strings.HasPrefix(href, "/wiki/") && !strings.Contains(href, "title=") {
+			t.Errorf("title %q from %q, which isn't a page link", title, href)
+		}
+	})
+}
+
+func FuzzMWTextUnmarshal(f *testing.F) {
+	f.Add([]byte(`"<p>Text</p>"`))
+	f.Add([]byte(`{"*":"<p>Text</p>"}`))
+	f.Add([]byte(`{"*":1}`))
+	f.Add([]byte(`null`))
+	f.Add([]byte(`["a"]`))
+	f.Add([]byte(`"\ud800"`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var text mwText
+		if err := text.UnmarshalJSON(data); err != nil {
+			return
+		}
+
+		var s string
+		if json.Unmarshal(data, &s) == nil && text.Content != s {
+			t.Errorf("string %s decoded as %q, want %q", data, text.Content, s)
+		}
+
+		// Decoding through an enclosing struct must agree
+		var parse mwParse
+		wrapped := append(append([]byte(`{"text":`), data...), '}')
+		if err := json.Unmarshal(wrapped, &parse); err != nil {
+			t.Errorf("%s decodes alone but not in a parse result: %v", data, err)
+		} else if parse.Text.Content != text.Content {
+			t.Errorf("%s decodes as %q alone but %q in a parse result", data, text.Content, parse.Text.Content)
+		}
+	})
+}
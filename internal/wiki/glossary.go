@@ -0,0 +1,250 @@
+package wiki
+
+import (
+	"html"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wikitext"
+)
+
+// Glossary entry sources
+const (
+	GlossaryDefinitionList = "definition_list" // ;term :definition, or <dl>
+	GlossaryTemplate       = "template"        // {{term}}/{{defn}} or a term=/definition= template
+	GlossaryAbbreviation   = "abbreviation"    // {{abbr|NASA|National Aeronautics and Space Administration}}
+)
+
+// ExtractGlossary extracts term/definition pairs from wikitext: definition
+// lists (";term" lines followed by ":definition" lines, or ";term : definition"
+// on one line), <dl> markup, {{term}}/{{defn}} glossaries, templates with
+// term and definition parameters, and {{abbr}} expansions. A term with
+// several definitions yields one entry with them joined by "; ". Terms
+// without a definition, like ";" pseudo-headings, are skipped.
+func ExtractGlossary(text string) []GlossaryEntry {
+	g := &glossary{seen: make(map[string]bool)}
+	g.visit(wikitext.Parse(text))
+	g.flush()
+	return g.entries
+}
+
+// glossaryDefinitionParams name a template's definition, in order of preference
+var glossaryDefinitionParams = []string{"definition", "defn", "meaning", "description", "expansion"}
+
+// glossaryAbbreviations are templates taking an abbreviation and its expansion
+var glossaryAbbreviations = map[string]bool{
+	"abbr": true, "abbrlink": true, "abbreviation": true, "acronym": true,
+}
+
+// glossaryRenderer renders terms and definitions as plain text
+var glossaryRenderer = &wikitext.Renderer{
+	Templates: map[string]wikitext.TemplateFunc{
+		"abbr": func(r *wikitext.Renderer, t *wikitext.Template) string { return r.ArgText(t, "1") },
+		"!":    func(*wikitext.Renderer, *wikitext.Template) string { return "|" },
+	},
+}
+
+// glossary accumulates entries, holding the current term until its
+// definitions end
+type glossary struct {
+	entries     []GlossaryEntry
+	seen        map[string]bool
+	term        string
+	source      string
+	definitions []string
+	inList      bool // the term came from ";" markup, so the next plain line ends it
+}
+
+func (g *glossary) startTerm(term, source string) {
+	g.flush()
+	g.term, g.source = cleanGlossaryText(term), source
+}
+
+func (g *glossary) define(definition string) {
+	if definition = cleanGlossaryText(definition); definition != "" && g.term != "" {
+		g.definitions = append(g.definitions, definition)
+	}
+}
+
+// flush emits the current term if it has a definition
+func (g *glossary) flush() {
+	if g.term != "" && len(g.definitions) > 0 {
+		g.add(g.term, strings.Join(g.definitions, "; "), g.source)
+	}
+	g.term, g.source, g.definitions, g.inList = "", "", nil, false
+}
+
+func (g *glossary) add(term, definition, source string) {
+	term, definition = cleanGlossaryText(term), cleanGlossaryText(definition)
+	key := strings.ToLower(term) + "\x00" + definition
+	if term == "" || definition == "" || g.seen[key] {
+		return
+	}
+	g.seen[key] = true
+	if g.entries == nil {
+		g.entries = make([]GlossaryEntry, 0)
+	}
+	g.entries = append(g.entries, GlossaryEntry{Term: term, Definition: definition, Source: source})
+}
+
+// visit scans nodes line by line. Definition list markup only counts at the
+// start of a line; templates and tags elsewhere are searched for glossary
+// templates and nested lists.
+func (g *glossary) visit(nodes []wikitext.Node) {
+	for _, line := range splitLines(nodes) {
+		marker := lineMarker(line)
+		switch {
+		case strings.HasPrefix(marker, ";"):
+			g.definitionTerm(line)
+			continue
+		case strings.HasPrefix(marker, ":") && g.inList:
+			g.define(glossaryRenderer.Text(trimMarker(line)))
+			continue
+		case g.inList:
+			// Anything else, including a blank line, ends the list
+			g.flush()
+		}
+
+		for _, n := range line {
+			switch n := n.(type) {
+			case *wikitext.Template:
+				g.template(n)
+			case *wikitext.Tag:
+				g.tag(n)
+			}
+		}
+	}
+}
+
+// definitionTerm handles a ";term" line, which may carry its definition
+// after a colon outside links and templates
+func (g *glossary) definitionTerm(line []wikitext.Node) {
+	line = trimMarker(line)
+	for i, n := range line {
+		text, ok := n.(*wikitext.Text)
+		if !ok {
+			continue
+		}
+		colon := strings.Index(text.Value, ":")
+		if colon < 0 || strings.HasPrefix(text.Value[colon:], "://") {
+			continue
+		}
+		term := append(append([]wikitext.Node{}, line[:i]...), &wikitext.Text{Value: text.Value[:colon]})
+		definition := append([]wikitext.Node{&wikitext.Text{Value: text.Value[colon+1:]}}, line[i+1:]...)
+		g.startTerm(glossaryRenderer.Text(term), GlossaryDefinitionList)
+		g.define(glossaryRenderer.Text(definition))
+		g.inList = true
+		return
+	}
+	g.startTerm(glossaryRenderer.Text(line), GlossaryDefinitionList)
+	g.inList = true
+}
+
+func (g *glossary) template(t *wikitext.Template) {
+	name := t.NormalizedName()
+	switch {
+	case name == "term":
+		// {{term|term=x}} or {{term|x}}; content= overrides the display text
+		term := glossaryRenderer.ArgText(t, "content")
+		if term == "" {
+			term = glossaryRenderer.ArgText(t, "term")
+		}
+		if term == "" {
+			term = glossaryRenderer.ArgText(t, "1")
+		}
+		g.startTerm(term, GlossaryTemplate)
+		return
+
+	case name == "defn":
+		definition := glossaryRenderer.ArgText(t, "defn")
+		if definition == "" {
+			definition = glossaryRenderer.ArgText(t, "1")
+		}
+		g.define(definition)
+		return
+
+	case glossaryAbbreviations[name]:
+		g.add(glossaryRenderer.ArgText(t, "1"), glossaryRenderer.ArgText(t, "2"), GlossaryAbbreviation)
+		return
+
+	case name == "glossary end":
+		g.flush()
+		return
+	}
+
+	if term := glossaryRenderer.ArgText(t, "term"); term != "" {
+		for _, param := range glossaryDefinitionParams {
+			if definition := glossaryRenderer.ArgText(t, param); definition != "" {
+				g.add(term, definition, GlossaryTemplate)
+				return
+			}
+		}
+	}
+
+	for _, p := range t.Params {
+		g.visit(p.Value)
+	}
+}
+
+func (g *glossary) tag(t *wikitext.Tag) {
+	switch t.Name {
+	case "dt":
+		g.startTerm(glossaryRenderer.Text(t.Content), GlossaryDefinitionList)
+	case "dd":
+		g.define(glossaryRenderer.Text(t.Content))
+	case "dl":
+		g.flush()
+		g.visit(t.Content)
+		g.flush()
+	case "ref", "nowiki", "pre", "syntaxhighlight", "source", "math":
+	default:
+		g.visit(t.Content)
+	}
+}
+
+// splitLines splits nodes at the newlines in their text
+func splitLines(nodes []wikitext.Node) [][]wikitext.Node {
+	lines := [][]wikitext.Node{nil}
+	for _, n := range nodes {
+		text, ok := n.(*wikitext.Text)
+		if !ok {
+			lines[len(lines)-1] = append(lines[len(lines)-1], n)
+			continue
+		}
+		for i, part := range strings.Split(text.Value, "\n") {
+			if i > 0 {
+				lines = append(lines, nil)
+			}
+			if part != "" {
+				lines[len(lines)-1] = append(lines[len(lines)-1], &wikitext.Text{Value: part})
+			}
+		}
+	}
+	return lines
+}
+
+// lineMarker returns the list markup (";", ":", "*", "#") starting a line
+func lineMarker(line []wikitext.Node) string {
+	if len(line) == 0 {
+		return ""
+	}
+	text, ok := line[0].(*wikitext.Text)
+	if !ok {
+		return ""
+	}
+	return text.Value[:len(text.Value)-len(strings.TrimLeft(text.Value, ";:*#"))]
+}
+
+// trimMarker drops the list markup from the start of a line
+func trimMarker(line []wikitext.Node) []wikitext.Node {
+	marker := lineMarker(line)
+	if marker == "" {
+		return line
+	}
+	rest := line[0].(*wikitext.Text).Value[len(marker):]
+	return append([]wikitext.Node{&wikitext.Text{Value: rest}}, line[1:]...)
+}
+
+// cleanGlossaryText collapses rendered text to a single line
+func cleanGlossaryText(text string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(text)), " ")
+}
@@ -0,0 +1,56 @@
+package wiki
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractGlossary(t *testing.T) {
+	text := `== Terms ==
+; [[Service level agreement|SLA]] : A commitment between a provider and a client.
+; RPO
+: Recovery point objective.
+: The maximum tolerable data loss, see [[Backup#Policy|backup policy]].
+
+; Not a term, just a heading
+
+{{glossary}}
+{{term|term=Canary release|content=Canary}}
+{{defn|1=Rolling a change out to a small subset of users first.}}
+{{term|Toil}}
+{{defn|defn=Manual, repetitive operational work.}}
+{{glossary end}}
+
+<dl>
+<dt>MTTR</dt>
+<dd>Mean time to recovery</dd>
+</dl>
+
+The {{abbr|CI|Continuous integration}} server runs every commit.
+{{Glossary entry|term=On-call|definition=Being available to respond to incidents.}}
+{{Infobox team|notes=
+;Runbook: A documented procedure for an operational task.
+}}
+; Homepage : http://example.org`
+
+	want := []GlossaryEntry{
+		{Term: "SLA", Definition: "A commitment between a provider and a client.", Source: GlossaryDefinitionList},
+		{Term: "RPO", Definition: "Recovery point objective.; The maximum tolerable data loss, see backup policy.", Source: GlossaryDefinitionList},
+		{Term: "Canary", Definition: "Rolling a change out to a small subset of users first.", Source: GlossaryTemplate},
+		{Term: "Toil", Definition: "Manual, repetitive operational work.", Source: GlossaryTemplate},
+		{Term: "MTTR", Definition: "Mean time to recovery", Source: GlossaryDefinitionList},
+		{Term: "CI", Definition: "Continuous integration", Source: GlossaryAbbreviation},
+		{Term: "On-call", Definition: "Being available to respond to incidents.", Source: GlossaryTemplate},
+		{Term: "Runbook", Definition: "A documented procedure for an operational task.", Source: GlossaryDefinitionList},
+		{Term: "Homepage", Definition: "http://example.org", Source: GlossaryDefinitionList},
+	}
+
+	got := ExtractGlossary(text)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractGlossary:\ngot:  %+v\nwant: %+v", got, want)
+	}
+
+	if got := ExtractGlossary("No glossary here."); got != nil {
+		t.Errorf("ExtractGlossary of plain text = %+v, want nil", got)
+	}
+}
@@ -0,0 +1,52 @@
+package wiki
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+)
+
+// maxPooledBuffer is the largest buffer kept for reuse, so one huge page
+// doesn't pin its memory
+const maxPooledBuffer = 4 << 20
+
+// hashBuffers holds the buffers normalized content is written to for
+// hashing, which would otherwise be a page-sized allocation per hash
+var hashBuffers = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// ContentHash returns the SHA-256 of Markdown content, in hex, after
+// normalizing line endings, trailing whitespace, and runs of blank lines, so
+// it changes only when the text does
+func ContentHash(markdown string) string {
+	b := hashBuffers.Get().(*bytes.Buffer)
+	defer func() {
+		if b.Cap() <= maxPooledBuffer {
+			b.Reset()
+			hashBuffers.Put(b)
+		}
+	}()
+
+	blank := false
+	for line := range strings.SplitSeq(strings.TrimSpace(markdown), "\n") {
+		line = strings.TrimRight(strings.TrimSuffix(line, "\r"), " \t")
+		if line == "" {
+			blank = true
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+			if blank {
+				b.WriteByte('\n')
+			}
+		}
+		blank = false
+		b.WriteString(line)
+	}
+
+	sum := sha256.Sum256(b.Bytes())
+	return hex.EncodeToString(sum[:])
+}
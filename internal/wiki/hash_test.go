@@ -0,0 +1,33 @@
+package wiki
+
+import "testing"
+
+func TestContentHash(t *testing.T) {
+	base := ContentHash("# Title\n\nFirst paragraph.\n\nSecond paragraph.")
+
+	same := []string{
+		"# Title\r\n\r\nFirst paragraph.\r\n\r\nSecond paragraph.",
+		"# Title  \n\n\n\nFirst paragraph.\t\n\nSecond paragraph.\n",
+		"\n# Title\n\nFirst paragraph.\n\nSecond paragraph.\n\n",
+	}
+	for _, md := range same {
+		if got := ContentHash(md); got != base {
+			t.Errorf("ContentHash(%q) differs from the normalized text", md)
+		}
+	}
+
+	different := []string{
+		"# Title\n\nFirst paragraph.\nSecond paragraph.",
+		"# Title\n\nFirst  paragraph.\n\nSecond paragraph.",
+		"# Title\n\nFirst paragraph.\n\nSecond paragraph!",
+	}
+	for _, md := range different {
+		if got := ContentHash(md); got == base {
+			t.Errorf("ContentHash(%q) matches a different text", md)
+		}
+	}
+
+	if len(base) != 64 {
+		t.Errorf("hash %q is not hex SHA-256", base)
+	}
+}
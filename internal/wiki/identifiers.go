@@ -0,0 +1,253 @@
+package wiki
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wikitext"
+)
+
+// Scholarly identifier types
+const (
+	IdentifierDOI   = "doi"
+	IdentifierISBN  = "isbn"
+	IdentifierPMID  = "pmid"
+	IdentifierPMC   = "pmc"
+	IdentifierArXiv = "arxiv"
+)
+
+// identifierParams maps citation template parameters to identifier types
+var identifierParams = map[string]string{
+	"doi":   IdentifierDOI,
+	"isbn":  IdentifierISBN,
+	"isbn2": IdentifierISBN,
+	"pmid":  IdentifierPMID,
+	"pmc":   IdentifierPMC,
+	"arxiv": IdentifierArXiv,
+}
+
+// identifierTemplates are the templates that format a single identifier,
+// like {{doi|10.1000/182}}
+var identifierTemplates = map[string]string{
+	"doi":   IdentifierDOI,
+	"isbn":  IdentifierISBN,
+	"pmid":  IdentifierPMID,
+	"pmc":   IdentifierPMC,
+	"arxiv": IdentifierArXiv,
+}
+
+// identifierPatterns find identifiers in free text and URLs: magic links
+// like "ISBN 0-306-40615-2", prefixed forms like "doi:10.1000/182", and
+// resolver links
+var identifierPatterns = []struct {
+	kind    string
+	pattern *regexp.Regexp
+}{
+	{IdentifierISBN, regexp.MustCompile(`\bISBN(?:-1[03])?:?\s+((?:97[89][\s-]?)?(?:\d[\s-]?){9}[\dXx])\b`)},
+	{IdentifierPMID, regexp.MustCompile(`\bPMID:?\s+(\d{1,9})\b`)},
+	{IdentifierDOI, regexp.MustCompile(`(?i)\bdoi:\s*(10\.\d{4,9}/[^\s\]|<>"{}]+)`)},
+	{IdentifierDOI, regexp.MustCompile(`(?i)\b(?:dx\.)?doi\.org/(10\.\d{4,9}/[^\s\]|<>"{}]+)`)},
+	{IdentifierArXiv, regexp.MustCompile(`(?i)\barxiv:\s*([a-z.-]+/\d{7}|\d{4}\.\d{4,5})(v\d+)?`)},
+	{IdentifierArXiv, regexp.MustCompile(`(?i)\barxiv\.org/(?:abs|pdf)/([a-z.-]+/\d{7}|\d{4}\.\d{4,5})(v\d+)?`)},
+	{IdentifierPMID, regexp.MustCompile(`(?i)\bpubmed\.ncbi\.nlm\.nih\.gov/(\d{1,9})\b`)},
+	{IdentifierPMC, regexp.MustCompile(`(?i)\bncbi\.nlm\.nih\.gov/pmc/articles/(PMC\d+)`)},
+}
+
+var (
+	doiSyntax       = regexp.MustCompile(`^10\.\d{4,9}/\S+$`)
+	pmidSyntax      = regexp.MustCompile(`^\d{1,9}$`)
+	pmcSyntax       = regexp.MustCompile(`^\d{1,9}$`)
+	arxivSyntax     = regexp.MustCompile(`^(?:\d{4}\.\d{4,5}|[a-z-]+(?:\.[A-Z]{2})?/\d{7})$`)
+	arxivVersion    = regexp.MustCompile(`v\d+$`)
+	doiResolverPath = regexp.MustCompile(`(?i)^(?:https?://)?(?:dx\.)?doi\.org/`)
+)
+
+// NormalizeIdentifier validates an identifier of the given type and returns
+// it in canonical form with a resolver URL. ISBNs are converted to
+// ISBN-13, DOIs lowercased, and arXiv versions dropped. It reports false
+// for malformed identifiers, including ISBNs with a bad check digit.
+func NormalizeIdentifier(kind, value string) (Identifier, bool) {
+	value = strings.TrimSpace(value)
+	var normalized, resolver string
+
+	switch kind {
+	case IdentifierDOI:
+		value = doiResolverPath.ReplaceAllString(value, "")
+		value = strings.TrimPrefix(strings.TrimPrefix(value, "doi:"), "DOI:")
+		normalized = strings.ToLower(trimTrailingPunctuation(value))
+		if !doiSyntax.MatchString(normalized) {
+			return Identifier{}, false
+		}
+		resolver = "https://doi.org/" + normalized
+
+	case IdentifierISBN:
+		isbn, ok := normalizeISBN(value)
+		if !ok {
+			return Identifier{}, false
+		}
+		normalized = isbn
+		resolver = "https://openlibrary.org/isbn/" + isbn
+
+	case IdentifierPMID:
+		normalized = value
+		if !pmidSyntax.MatchString(normalized) {
+			return Identifier{}, false
+		}
+		resolver = "https://pubmed.ncbi.nlm.nih.gov/" + normalized + "/"
+
+	case IdentifierPMC:
+		digits := strings.TrimPrefix(strings.ToUpper(value), "PMC")
+		if !pmcSyntax.MatchString(digits) {
+			return Identifier{}, false
+		}
+		normalized = "PMC" + digits
+		resolver = "https://www.ncbi.nlm.nih.gov/pmc/articles/" + normalized + "/"
+
+	case IdentifierArXiv:
+		if len(value) > 6 && strings.EqualFold(value[:6], "arxiv:") {
+			value = value[6:]
+		}
+		normalized = arxivVersion.ReplaceAllString(strings.TrimSpace(value), "")
+		if !arxivSyntax.MatchString(normalized) {
+			return Identifier{}, false
+		}
+		resolver = "https://arxiv.org/abs/" + normalized
+
+	default:
+		return Identifier{}, false
+	}
+
+	return Identifier{Type: kind, Value: normalized, URL: resolver}, true
+}
+
+// trimTrailingPunctuation drops sentence punctuation that follows an
+// identifier in running text, keeping balanced closing parentheses
+func trimTrailingPunctuation(s string) string {
+	for {
+		trimmed := strings.TrimRight(s, ".,;:")
+		if strings.HasSuffix(trimmed, ")") && strings.Count(trimmed, "(") < strings.Count(trimmed, ")") {
+			trimmed = strings.TrimSuffix(trimmed, ")")
+		}
+		if trimmed == s {
+			return s
+		}
+		s = trimmed
+	}
+}
+
+// normalizeISBN validates an ISBN-10 or ISBN-13 and returns it as ISBN-13
+// digits
+func normalizeISBN(value string) (string, bool) {
+	var digits []byte
+scan:
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case c >= '0' && c <= '9':
+			digits = append(digits, c)
+		case c == 'X' || c == 'x':
+			digits = append(digits, 'X')
+		case c == '-' || c == ' ':
+		case len(digits) > 0:
+			// Trailing text such as "(paperback)"
+			break scan
+		}
+	}
+
+	switch len(digits) {
+	case 10:
+		sum := 0
+		for i, d := range digits {
+			v := int(d - '0')
+			if d == 'X' {
+				if i != 9 {
+					return "", false
+				}
+				v = 10
+			}
+			sum += (10 - i) * v
+		}
+		if sum%11 != 0 {
+			return "", false
+		}
+		isbn13 := append([]byte("978"), digits[:9]...)
+		return string(append(isbn13, isbn13CheckDigit(isbn13))), true
+
+	case 13:
+		if strings.IndexByte(string(digits), 'X') >= 0 || isbn13CheckDigit(digits[:12]) != digits[12] {
+			return "", false
+		}
+		return string(digits), true
+	}
+	return "", false
+}
+
+// isbn13CheckDigit computes the check digit for the first 12 ISBN-13 digits
+func isbn13CheckDigit(digits []byte) byte {
+	sum := 0
+	for i, d := range digits[:12] {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += weight * int(d-'0')
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// referenceIdentifiers collects the identifiers in a reference: citation
+// template parameters, identifier templates, and identifiers in text and
+// URLs. Each appears once, in order of first appearance.
+func referenceIdentifiers(content []wikitext.Node) []Identifier {
+	ids := make([]Identifier, 0)
+	seen := make(map[string]bool)
+	add := func(kind, value string) {
+		id, ok := NormalizeIdentifier(kind, value)
+		if ok && !seen[id.Type+":"+id.Value] {
+			seen[id.Type+":"+id.Value] = true
+			ids = append(ids, id)
+		}
+	}
+	scan := func(text string) {
+		for _, p := range identifierPatterns {
+			for _, m := range p.pattern.FindAllStringSubmatch(text, -1) {
+				add(p.kind, m[1])
+			}
+		}
+	}
+
+	var visit func(nodes []wikitext.Node)
+	visit = func(nodes []wikitext.Node) {
+		for _, n := range nodes {
+			switch n := n.(type) {
+			case *wikitext.Text:
+				scan(n.Value)
+			case *wikitext.ExternalLink:
+				scan(n.URL)
+				visit(n.Text)
+			case *wikitext.Link:
+				visit(n.Text)
+			case *wikitext.Tag:
+				visit(n.Content)
+			case *wikitext.Template:
+				if kind, ok := identifierTemplates[n.NormalizedName()]; ok {
+					for _, value := range n.Positional() {
+						add(kind, referenceRenderer.Text(value))
+					}
+					continue
+				}
+				for _, p := range n.Params {
+					name := strings.ToLower(p.Name)
+					if kind, ok := identifierParams[name]; ok {
+						add(kind, referenceRenderer.Text(p.Value))
+					} else if name == "eprint" && n.NormalizedName() == "cite arxiv" {
+						add(IdentifierArXiv, referenceRenderer.Text(p.Value))
+					} else {
+						visit(p.Value)
+					}
+				}
+			}
+		}
+	}
+	visit(content)
+	return ids
+}
@@ -1,147 +1,257 @@
 package wiki
 
 import (
+	"fmt"
+	"html"
 	"regexp"
+	"strconv"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wikitext"
 )
 
 // ExtractInfobox extracts infobox data from wikitext
-func ExtractInfobox(wikitext string) map[string]any {
-	// Find the first infobox template
-	infoboxRegex := regexp.MustCompile(`(?s)\{\{Infobox[^\}]*?\n(.*?)\n\}\}`)
-	matches := infoboxRegex.FindStringSubmatch(wikitext)
-
-	if len(matches) < 2 {
+func ExtractInfobox(text string) map[string]any {
+	infobox := FindInfobox(text, "")
+	if infobox == nil {
 		return nil
 	}
 
-	infoboxContent := matches[1]
-
-	// Parse key-value pairs
-	result := make(map[string]any)
+	result := make(map[string]any, len(infobox.Fields))
+	for name, value := range infobox.Fields {
+		result[name] = value
+	}
+	return result
+}
 
-	// Split by lines starting with |
-	lines := strings.Split(infoboxContent, "\n")
+// Infobox is an infobox (or other data template) and its named parameters,
+// rendered as plain text
+type Infobox struct {
+	Template string            // template name as written, without "Template:"
+	Names    []string          // parameter names in template order
+	Fields   map[string]string // parameter values, empty when left blank
+}
 
-	var currentKey string
-	var currentValue strings.Builder
+// FindInfobox returns the first infobox in wikitext, or the first use of
+// template when it is set, e.g. "Infobox settlement" or "Chembox". It
+// returns nil when there is none or it has no named parameters.
+func FindInfobox(text, template string) *Infobox {
+	start := infoboxStart
+	if template != "" {
+		start = templateStart(template)
+	}
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
+	// Parse only from the first balanced template onwards, instead of
+	// tokenizing the whole article. An unbalanced opening is parsed to the
+	// end of the text, so only the first few are tried.
+	var found *wikitext.Template
+	for _, loc := range start.FindAllStringIndex(text, maxInfoboxCandidates) {
+		if found = wikitext.TemplateAt(text, loc[0]); found != nil {
+			break
+		}
+	}
+	if found == nil {
+		return nil
+	}
 
-		// Skip empty lines
-		if line == "" {
+	infobox := &Infobox{
+		Template: templateName(found.Name),
+		Fields:   make(map[string]string),
+	}
+	for _, param := range found.Params {
+		if param.Name == "" {
 			continue
 		}
-
-		// Check if this is a new key-value pair
-		if strings.HasPrefix(line, "|") {
-			// Save previous key-value if exists
-			if currentKey != "" {
-				result[currentKey] = cleanInfoboxValue(currentValue.String())
-			}
-
-			// Parse new key-value
-			line = strings.TrimPrefix(line, "|")
-			parts := strings.SplitN(line, "=", 2)
-
-			if len(parts) == 2 {
-				currentKey = strings.TrimSpace(parts[0])
-				currentValue.Reset()
-				currentValue.WriteString(strings.TrimSpace(parts[1]))
-			} else {
-				// Line without = is a continuation
-				if currentKey != "" {
-					currentValue.WriteString(" ")
-					currentValue.WriteString(line)
-				}
-			}
-		} else {
-			// Continuation of previous value
-			if currentKey != "" {
-				currentValue.WriteString(" ")
-				currentValue.WriteString(line)
-			}
+		if _, seen := infobox.Fields[param.Name]; !seen {
+			infobox.Names = append(infobox.Names, param.Name)
 		}
+		infobox.Fields[param.Name] = cleanInfoboxValue(infoboxRenderer.Text(param.Value))
 	}
 
-	// Save last key-value
-	if currentKey != "" {
-		result[currentKey] = cleanInfoboxValue(currentValue.String())
-	}
-
-	if len(result) == 0 {
+	if len(infobox.Fields) == 0 {
 		return nil
 	}
 
-	return result
+	return infobox
 }
 
-// cleanInfoboxValue cleans up wikitext in infobox values
-func cleanInfoboxValue(value string) string {
-	value = strings.TrimSpace(value)
-
-	// Remove wiki links but keep the display text
-	// [[Link]] -> Link
-	// [[Link|Display]] -> Display
-	linkRegex := regexp.MustCompile(`\[\[([^\|\]]+)(?:\|([^\]]+))?\]\]`)
-	value = linkRegex.ReplaceAllStringFunc(value, func(match string) string {
-		parts := linkRegex.FindStringSubmatch(match)
-		if len(parts) > 2 && parts[2] != "" {
-			return parts[2]
+// maxInfoboxCandidates is how many template openings FindInfobox tries
+const maxInfoboxCandidates = 16
+
+// templateName returns a template name with underscores as spaces and
+// without a "Template:" prefix
+func templateName(name string) string {
+	name = strings.TrimSpace(strings.ReplaceAll(name, "_", " "))
+	if prefix, rest, ok := strings.Cut(name, ":"); ok && strings.EqualFold(strings.TrimSpace(prefix), "template") {
+		name = strings.TrimSpace(rest)
+	}
+	return name
+}
+
+// templateStart matches the opening of a template by name, which like page
+// titles ignores the case of its first letter and treats underscores as
+// spaces
+func templateStart(name string) *regexp.Regexp {
+	var pattern strings.Builder
+	for i, word := range strings.Fields(templateName(name)) {
+		if i > 0 {
+			pattern.WriteString(`[ _]+`)
+		}
+		if i == 0 {
+			r, size := utf8.DecodeRuneInString(word)
+			pattern.WriteString("[" + regexp.QuoteMeta(strings.ToUpper(string(r))+strings.ToLower(string(r))) + "]")
+			word = word[size:]
 		}
-		return parts[1]
-	})
+		pattern.WriteString(regexp.QuoteMeta(word))
+	}
+	return regexp.MustCompile(`\{\{\s*(?i:template:)?` + pattern.String() + `\s*[|}]`)
+}
 
-	// Handle common templates
-	value = cleanCommonTemplates(value)
+// infoboxStart matches the opening of an infobox template
+var infoboxStart = regexp.MustCompile(`(?i)\{\{\s*(?:template:)?infobox`)
 
-	// Remove remaining template syntax (simple approach)
-	templateRegex := regexp.MustCompile(`\{\{[^\}]+\}\}`)
-	value = templateRegex.ReplaceAllString(value, "")
+// listMarker matches wikitext list bullets at the start of a line
+var listMarker = regexp.MustCompile(`^[*#:;]+\s*`)
 
-	// Clean up HTML tags
-	htmlRegex := regexp.MustCompile(`<[^>]+>`)
-	value = htmlRegex.ReplaceAllString(value, "")
+// cleanInfoboxValue turns rendered parameter text into a single line, with
+// list items and line breaks separated by commas
+func cleanInfoboxValue(value string) string {
+	value = html.UnescapeString(value)
 
-	// Remove formatting markup
-	value = strings.ReplaceAll(value, "'''", "")
-	value = strings.ReplaceAll(value, "''", "")
+	items := make([]string, 0)
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.Join(strings.Fields(listMarker.ReplaceAllString(strings.TrimSpace(line), "")), " ")
+		if line != "" {
+			items = append(items, line)
+		}
+	}
 
-	// Clean up whitespace
-	value = strings.TrimSpace(value)
-	value = regexp.MustCompile(`\s+`).ReplaceAllString(value, " ")
+	return strings.Join(items, ", ")
+}
 
-	return value
+// infoboxRenderer renders infobox values, expanding the templates commonly
+// used in them and dropping all others (citations, coordinates, ages)
+var infoboxRenderer = &wikitext.Renderer{
+	Templates: map[string]wikitext.TemplateFunc{
+		// {{birth date|1879|3|14}} -> 1879-03-14
+		"birth date":         dateTemplate,
+		"death date":         dateTemplate,
+		"birth date and age": dateTemplate,
+		"death date and age": dateTemplate,
+		"start date":         dateTemplate,
+		"end date":           dateTemplate,
+		"start date and age": dateTemplate,
+		"film date":          dateTemplate,
+		"circa":              prefixTemplate("circa "),
+		"c.":                 prefixTemplate("circa "),
+		"flag":               argTemplate("1"),
+		"flagcountry":        argTemplate("1"),
+		"flagu":              argTemplate("1"),
+		"nowrap":             argTemplate("1"),
+		"nobr":               argTemplate("1"),
+		"small":              argTemplate("1"),
+		"abbr":               argTemplate("1"),
+		"url":                argTemplate("1"),
+		"official website":   argTemplate("1"),
+		"ill":                argTemplate("1"),
+		"lang":               argTemplate("2"),
+		"native name":        argTemplate("2"),
+		"transl":             argTemplate("2"),
+		"plainlist":          argTemplate("1"),
+		"plain list":         argTemplate("1"),
+		"flatlist":           argTemplate("1"),
+		"unbulleted list":    listTemplate,
+		"ubl":                listTemplate,
+		"hlist":              listTemplate,
+		"bulleted list":      listTemplate,
+		"blist":              listTemplate,
+		"native name list":   nativeNameListTemplate,
+		"convert":            convertTemplate,
+		"cvt":                convertTemplate,
+		"marriage":           marriageTemplate,
+		"!":                  func(*wikitext.Renderer, *wikitext.Template) string { return "|" },
+	},
 }
 
-// cleanCommonTemplates handles common MediaWiki templates
-func cleanCommonTemplates(value string) string {
-	// {{birth date|1879|3|14}} -> 1879-03-14
-	birthDateRegex := regexp.MustCompile(`\{\{birth date\|(\d+)\|(\d+)\|(\d+)[^\}]*\}\}`)
-	value = birthDateRegex.ReplaceAllString(value, "$1-$2-$3")
+// argTemplate renders a template as one of its parameters
+func argTemplate(name string) wikitext.TemplateFunc {
+	return func(r *wikitext.Renderer, t *wikitext.Template) string {
+		return r.ArgText(t, name)
+	}
+}
 
-	// {{death date|1955|4|18}} -> 1955-04-18
-	deathDateRegex := regexp.MustCompile(`\{\{death date\|(\d+)\|(\d+)\|(\d+)[^\}]*\}\}`)
-	value = deathDateRegex.ReplaceAllString(value, "$1-$2-$3")
+// prefixTemplate renders the first parameter after a fixed prefix
+func prefixTemplate(prefix string) wikitext.TemplateFunc {
+	return func(r *wikitext.Renderer, t *wikitext.Template) string {
+		return prefix + r.ArgText(t, "1")
+	}
+}
 
-	// {{age|1879|3|14}} -> remove (age calculation not useful)
-	ageRegex := regexp.MustCompile(`\{\{age\|[^\}]+\}\}`)
-	value = ageRegex.ReplaceAllString(value, "")
+// dateTemplate renders {{... date|year|month|day}} as an ISO date
+func dateTemplate(r *wikitext.Renderer, t *wikitext.Template) string {
+	parts := make([]string, 0, 3)
+	for i, name := range []string{"1", "2", "3"} {
+		value := r.ArgText(t, name)
+		if value == "" {
+			break
+		}
+		if n, err := strconv.Atoi(value); err == nil && i > 0 {
+			value = fmt.Sprintf("%02d", n)
+		}
+		parts = append(parts, value)
+	}
+	return strings.Join(parts, "-")
+}
 
-	// {{circa|1900}} -> circa 1900
-	circaRegex := regexp.MustCompile(`\{\{circa\|([^\}]+)\}\}`)
-	value = circaRegex.ReplaceAllString(value, "circa $1")
+// listTemplate renders each positional parameter as a list item
+func listTemplate(r *wikitext.Renderer, t *wikitext.Template) string {
+	items := make([]string, 0)
+	for _, value := range t.Positional() {
+		if item := strings.TrimSpace(r.Text(value)); item != "" {
+			items = append(items, item)
+		}
+	}
+	return strings.Join(items, "\n")
+}
 
-	// {{flag|USA}} -> USA
-	flagRegex := regexp.MustCompile(`\{\{flag\|([^\}]+)\}\}`)
-	value = flagRegex.ReplaceAllString(value, "$1")
+// nativeNameListTemplate renders the name1, name2, ... parameters
+func nativeNameListTemplate(r *wikitext.Renderer, t *wikitext.Template) string {
+	items := make([]string, 0)
+	for i := 1; ; i++ {
+		value, ok := t.Arg("name" + strconv.Itoa(i))
+		if !ok {
+			break
+		}
+		if item := strings.TrimSpace(r.Text(value)); item != "" {
+			items = append(items, item)
+		}
+	}
+	return strings.Join(items, "\n")
+}
 
-	// {{coord|...}} -> remove (coordinates not useful in text)
-	coordRegex := regexp.MustCompile(`\{\{coord\|[^\}]+\}\}`)
-	value = coordRegex.ReplaceAllString(value, "")
+// convertRanges are {{convert}} separators between two values
+var convertRanges = map[string]string{"-": "–", "–": "–", "to": " to ", "and": " and ", "or": " or "}
+
+// convertTemplate renders {{convert|2850|km|mi}} as "2850 km", keeping
+// the original value and unit
+func convertTemplate(r *wikitext.Renderer, t *wikitext.Template) string {
+	value := r.ArgText(t, "1")
+	unit := r.ArgText(t, "2")
+	if sep, ok := convertRanges[unit]; ok {
+		value += sep + r.ArgText(t, "3")
+		unit = r.ArgText(t, "4")
+	}
+	return strings.TrimSpace(value + " " + unit)
+}
 
-	return value
+// marriageTemplate renders {{marriage|Name|1903}} as "Name (m. 1903)"
+func marriageTemplate(r *wikitext.Renderer, t *wikitext.Template) string {
+	name := r.ArgText(t, "1")
+	if year := r.ArgText(t, "2"); year != "" {
+		return name + " (m. " + year + ")"
+	}
+	return name
 }
 
 // ExtractInfoboxFromHTML extracts infobox from parsed HTML
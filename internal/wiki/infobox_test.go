@@ -0,0 +1,76 @@
+package wiki
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestExtractInfobox checks each testdata/infobox/*.wikitext fixture against
+// the JSON next to it
+func TestExtractInfobox(t *testing.T) {
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "infobox", "*.wikitext"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixtures) == 0 {
+		t.Fatal("no fixtures found")
+	}
+
+	for _, fixture := range fixtures {
+		name := strings.TrimSuffix(filepath.Base(fixture), ".wikitext")
+		t.Run(name, func(t *testing.T) {
+			text, err := os.ReadFile(fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+			golden, err := os.ReadFile(strings.TrimSuffix(fixture, ".wikitext") + ".json")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var want map[string]any
+			if err := json.Unmarshal(golden, &want); err != nil {
+				t.Fatal(err)
+			}
+
+			got := ExtractInfobox(string(text))
+			if !reflect.DeepEqual(got, want) {
+				gotJSON, _ := json.MarshalIndent(got, "", "  ")
+				t.Errorf("infobox mismatch\ngot:  %s\nwant: %s", gotJSON, golden)
+			}
+		})
+	}
+}
+
+func TestFindInfoboxByTemplate(t *testing.T) {
+	text := `{{Short description|Chemical compound}}
+{{Infobox drug
+| name = Aspirin
+}}
+{{chembox
+| Name =
+| Formula = C9H8O4
+}}`
+
+	first := FindInfobox(text, "")
+	if first == nil || first.Template != "Infobox drug" || first.Fields["name"] != "Aspirin" {
+		t.Errorf("FindInfobox first = %+v, want Infobox drug", first)
+	}
+
+	for _, template := range []string{"Chembox", "chembox", "Template:Chembox"} {
+		got := FindInfobox(text, template)
+		want := &Infobox{Template: "chembox", Names: []string{"Name", "Formula"}, Fields: map[string]string{"Name": "", "Formula": "C9H8O4"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("FindInfobox(%q) = %+v, want %+v", template, got, want)
+		}
+	}
+
+	// A longer template name that starts the same doesn't match
+	if got := FindInfobox("{{Infobox drug class|name=NSAID}}", "Infobox drug"); got != nil {
+		t.Errorf("FindInfobox matched a different template: %+v", got)
+	}
+}
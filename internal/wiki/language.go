@@ -0,0 +1,38 @@
+package wiki
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+type languageKey struct{}
+
+// languageCodeRegex matches BCP 47-style language codes (e.g. "de", "pt-br")
+var languageCodeRegex = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// WithLanguage returns a context that requests localized API responses.
+// Invalid language codes are ignored.
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	lang = NormalizeLanguage(lang)
+	if lang == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, languageKey{}, lang)
+}
+
+// LanguageFromContext returns the requested language, or "" if none was set
+func LanguageFromContext(ctx context.Context) string {
+	lang, _ := ctx.Value(languageKey{}).(string)
+	return lang
+}
+
+// NormalizeLanguage lowercases and validates a language code, returning ""
+// for anything that isn't a plausible code
+func NormalizeLanguage(lang string) string {
+	lang = strings.TrimSpace(lang)
+	if !languageCodeRegex.MatchString(lang) {
+		return ""
+	}
+	return strings.ToLower(lang)
+}
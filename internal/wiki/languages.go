@@ -0,0 +1,211 @@
+package wiki
+
+import (
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wikitext"
+)
+
+// minAlignmentScore is the score below which sections are not paired
+const minAlignmentScore = 0.2
+
+var (
+	wikitextHeading = regexp.MustCompile(`(?m)^(={2,6})[ \t]*(.+?)[ \t]*(={2,6})[ \t]*$`)
+
+	// Language-independent features of section text
+	featureNumber = regexp.MustCompile(`\b\d{3,}\b`)
+	featureURL    = regexp.MustCompile(`https?://[^\s|\]}<"]+`)
+	featureFile   = regexp.MustCompile(`(?i)\[\[\s*[^:\]|]+:\s*([^|\]]+\.(?:jpe?g|png|svg|gif|tiff?|webm|ogg|ogv|pdf))\s*[|\]]`)
+	featureName   = regexp.MustCompile(`\p{Lu}\p{Ll}{3,}`)
+)
+
+// plainRenderer renders wikitext as plain text, dropping templates
+var plainRenderer = &wikitext.Renderer{}
+
+// standardHeadings maps the boilerplate end sections of common languages to
+// a shared kind, since their headings share no text across languages
+var standardHeadings = map[string]string{
+	// References
+	"references": "references", "notes and references": "references", "citations": "references", "footnotes": "references",
+	"einzelnachweise": "references", "quellen": "references", "belege": "references",
+	"références": "references", "notes et références": "references",
+	"referencias": "references", "notas y referencias": "references",
+	"riferimenti": "references", "note e riferimenti": "references",
+	"referenties": "references", "bronnen": "references", "referências": "references",
+	// Notes
+	"notes": "notes", "anmerkungen": "notes", "notas": "notes", "note": "notes", "noten": "notes",
+	// External links
+	"external links": "external_links", "weblinks": "external_links", "liens externes": "external_links",
+	"enlaces externos": "external_links", "collegamenti esterni": "external_links",
+	"externe links": "external_links", "externe link": "external_links", "ligações externas": "external_links",
+	// See also
+	"see also": "see_also", "siehe auch": "see_also", "voir aussi": "see_also", "véase también": "see_also",
+	"vease tambien": "see_also", "voci correlate": "see_also", "zie ook": "see_also", "ver também": "see_also",
+	// Further reading
+	"further reading": "bibliography", "bibliography": "bibliography", "literature": "bibliography",
+	"literatur": "bibliography", "bibliographie": "bibliography", "bibliografía": "bibliography",
+	"bibliografia": "bibliography", "literatuur": "bibliography",
+}
+
+// languageSection is a section of wikitext with its alignment features
+type languageSection struct {
+	LanguageSection
+	kind     string          // standard heading kind, if any
+	strong   map[string]bool // numbers, URLs, and files
+	names    map[string]bool // capitalized words
+	position float64         // 0 for the first section, 1 for the last
+}
+
+// SplitSections returns the headed sections of wikitext in page order, with
+// the bytes each holds before its first subsection. The lead is left out.
+func SplitSections(text string) []LanguageSection {
+	parsed := splitSections(text)
+	sections := make([]LanguageSection, len(parsed))
+	for i, s := range parsed {
+		sections[i] = s.LanguageSection
+	}
+	return sections
+}
+
+func splitSections(text string) []languageSection {
+	matches := wikitextHeading.FindAllStringSubmatchIndex(text, -1)
+	sections := make([]languageSection, 0, len(matches))
+	for i, m := range matches {
+		end := len(text)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		body := text[m[1]:end]
+
+		level := min(m[3]-m[2], m[7]-m[6])
+		title := strings.TrimSpace(plainRenderer.Text(wikitext.Parse(text[m[4]:m[5]])))
+		section := languageSection{
+			LanguageSection: LanguageSection{Title: title, Level: level, Bytes: len(strings.TrimSpace(body))},
+			kind:            standardHeadings[strings.ToLower(title)],
+			strong:          make(map[string]bool),
+			names:           make(map[string]bool),
+		}
+		for _, n := range featureNumber.FindAllString(body, -1) {
+			section.strong["n:"+n] = true
+		}
+		for _, u := range featureURL.FindAllString(body, -1) {
+			section.strong["u:"+strings.ToLower(strings.TrimRight(u, ".,;:)"))] = true
+		}
+		for _, f := range featureFile.FindAllStringSubmatch(body, -1) {
+			section.strong["f:"+strings.ToLower(strings.ReplaceAll(strings.TrimSpace(f[1]), "_", " "))] = true
+		}
+		for _, name := range featureName.FindAllString(body, -1) {
+			section.names[name] = true
+		}
+		sections = append(sections, section)
+	}
+
+	for i := range sections {
+		if len(sections) > 1 {
+			sections[i].position = float64(i) / float64(len(sections)-1)
+		}
+	}
+	return sections
+}
+
+// AlignSections pairs the sections of two language versions of an article
+// and returns those left unpaired on each side. Headings are in different
+// languages, so sections are matched on what survives translation: numbers
+// and years, URLs, file names, and proper names, with standard end
+// sections (references, external links, ...) matched by heading. Pairs keep
+// page order, and position only breaks ties between content matches.
+func AlignSections(source, target string) (aligned []SectionAlignment, missingInTarget, missingInSource []LanguageSection) {
+	src, tgt := splitSections(source), splitSections(target)
+
+	// Best order-preserving pairing by total score
+	scores := make([][]float64, len(src))
+	best := make([][]float64, len(src)+1)
+	best[len(src)] = make([]float64, len(tgt)+1)
+	for i := range src {
+		scores[i] = make([]float64, len(tgt))
+		for j := range tgt {
+			scores[i][j] = alignmentScore(&src[i], &tgt[j])
+		}
+	}
+	for i := len(src) - 1; i >= 0; i-- {
+		best[i] = make([]float64, len(tgt)+1)
+		for j := len(tgt) - 1; j >= 0; j-- {
+			best[i][j] = math.Max(best[i+1][j], best[i][j+1])
+			if scores[i][j] >= minAlignmentScore {
+				best[i][j] = math.Max(best[i][j], best[i+1][j+1]+scores[i][j])
+			}
+		}
+	}
+
+	aligned = make([]SectionAlignment, 0)
+	missingInTarget = make([]LanguageSection, 0)
+	missingInSource = make([]LanguageSection, 0)
+	i, j := 0, 0
+	for i < len(src) && j < len(tgt) {
+		switch {
+		case scores[i][j] >= minAlignmentScore && best[i][j] == best[i+1][j+1]+scores[i][j]:
+			basis := "shared_content"
+			if src[i].kind != "" {
+				basis = "standard_heading"
+			}
+			aligned = append(aligned, SectionAlignment{
+				Source:     src[i].LanguageSection,
+				Target:     tgt[j].LanguageSection,
+				Confidence: math.Round(scores[i][j]*100) / 100,
+				Basis:      basis,
+			})
+			i++
+			j++
+		case best[i][j] == best[i+1][j]:
+			missingInTarget = append(missingInTarget, src[i].LanguageSection)
+			i++
+		default:
+			missingInSource = append(missingInSource, tgt[j].LanguageSection)
+			j++
+		}
+	}
+	for ; i < len(src); i++ {
+		missingInTarget = append(missingInTarget, src[i].LanguageSection)
+	}
+	for ; j < len(tgt); j++ {
+		missingInSource = append(missingInSource, tgt[j].LanguageSection)
+	}
+	return aligned, missingInTarget, missingInSource
+}
+
+// alignmentScore rates how likely two sections cover the same material,
+// from 0 to 1
+func alignmentScore(a, b *languageSection) float64 {
+	if a.kind != "" || b.kind != "" {
+		if a.kind == b.kind {
+			return 1
+		}
+		return 0
+	}
+
+	strong, sharedStrong := dice(a.strong, b.strong)
+	names, sharedNames := dice(a.names, b.names)
+	if sharedStrong == 0 && sharedNames < 2 {
+		return 0
+	}
+
+	score := 0.7*strong + 0.3*names + 0.15*(1-math.Abs(a.position-b.position))
+	return math.Min(score, 1)
+}
+
+// dice returns the Dice coefficient of two sets and the size of their
+// intersection
+func dice(a, b map[string]bool) (float64, int) {
+	if len(a) == 0 || len(b) == 0 {
+		return 0, 0
+	}
+	shared := 0
+	for k := range a {
+		if b[k] {
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(a)+len(b)), shared
+}
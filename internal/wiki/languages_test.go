@@ -0,0 +1,98 @@
+package wiki
+
+import (
+	"testing"
+)
+
+func TestAlignSections(t *testing.T) {
+	english := `Berlin is the capital of Germany.
+
+== History ==
+Founded around 1237, Berlin became the capital of Prussia in 1701. [[File:Berlin 1688.jpg|thumb]]
+
+=== Division ===
+After 1945 the city was divided; the [[Berlin Wall]] stood from 1961 to 1989 between Mitte and Kreuzberg.
+
+== Economy ==
+Siemens and Deutsche Bahn are headquartered here. Tourism brought 12000000 visitors in 2019.
+
+== See also ==
+* [[List of people from Berlin]]
+
+== References ==
+<references />`
+
+	german := `Berlin ist die Hauptstadt Deutschlands.
+
+== Geschichte ==
+Die erste urkundliche Erwähnung stammt von 1237; 1701 wurde Berlin Hauptstadt Preußens. [[Datei:Berlin 1688.jpg|mini]]
+
+=== Teilung ===
+Nach 1945 wurde die Stadt geteilt; die [[Berliner Mauer]] stand von 1961 bis 1989 zwischen Mitte und Kreuzberg.
+
+== Klima ==
+Das Klima ist gemäßigt, mit einer Jahresmitteltemperatur von 9,5 Grad.
+
+== Einzelnachweise ==
+<references />
+
+== Weblinks ==
+* https://www.berlin.de`
+
+	aligned, missingInTarget, missingInSource := AlignSections(english, german)
+
+	pairs := make(map[string]string)
+	for _, a := range aligned {
+		pairs[a.Source.Title] = a.Target.Title
+		if a.Confidence <= 0 || a.Confidence > 1 {
+			t.Errorf("confidence of %q = %v, want in (0, 1]", a.Source.Title, a.Confidence)
+		}
+	}
+	want := map[string]string{
+		"History":    "Geschichte",
+		"Division":   "Teilung",
+		"References": "Einzelnachweise",
+	}
+	if len(pairs) != len(want) {
+		t.Errorf("aligned %v, want %v", pairs, want)
+	}
+	for source, target := range want {
+		if pairs[source] != target {
+			t.Errorf("%q aligned with %q, want %q", source, pairs[source], target)
+		}
+	}
+
+	if got := titles(missingInTarget); got != "Economy, See also" {
+		t.Errorf("missing in target = %s, want Economy, See also", got)
+	}
+	if got := titles(missingInSource); got != "Klima, Weblinks" {
+		t.Errorf("missing in source = %s, want Klima, Weblinks", got)
+	}
+
+	for _, a := range aligned {
+		if a.Source.Title == "Division" && a.Source.Level != 3 {
+			t.Errorf("Division level = %d, want 3", a.Source.Level)
+		}
+		if a.Source.Title == "References" && a.Basis != "standard_heading" {
+			t.Errorf("References basis = %q, want standard_heading", a.Basis)
+		}
+	}
+}
+
+func TestSplitSectionsRendersHeadings(t *testing.T) {
+	sections := SplitSections("Lead\n== [[Early life|Youth]] and ''career'' ==\nText\n")
+	if len(sections) != 1 || sections[0].Title != "Youth and career" || sections[0].Bytes != 4 {
+		t.Errorf("SplitSections = %+v, want one section \"Youth and career\" of 4 bytes", sections)
+	}
+}
+
+func titles(sections []LanguageSection) string {
+	s := ""
+	for i, section := range sections {
+		if i > 0 {
+			s += ", "
+		}
+		s += section.Title
+	}
+	return s
+}
@@ -0,0 +1,75 @@
+package wiki
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// linkResolver sorts the hrefs in a wiki's HTML into page titles and
+// external URLs using the wiki's article path, so links resolve on wikis
+// that serve pages from /index.php/Title, /view/Title, or full URLs rather
+// than /wiki/Title
+type linkResolver struct {
+	base *url.URL // article path URL that relative hrefs resolve against
+
+	// Path around the title in the article path, when the title is in the
+	// path rather than the query
+	prefix, suffix string
+	inPath         bool
+}
+
+// newLinkResolver returns a resolver for an absolute article path such as
+// "https://en.wikipedia.org/wiki/$1", or nil if it can't be parsed
+func newLinkResolver(articlePath string) *linkResolver {
+	base, err := url.Parse(articlePath)
+	if err != nil || base.Host == "" || (base.Scheme != "http" && base.Scheme != "https") {
+		return nil
+	}
+	r := &linkResolver{base: base}
+	r.prefix, r.suffix, r.inPath = strings.Cut(base.Path, "$1")
+	return r
+}
+
+// resolve returns the page title an href links to on the wiki, or its
+// absolute URL if it leaves the wiki. Both are empty for fragments, other
+// schemes, and links to wiki pages that aren't articles, such as history
+// views.
+func (r *linkResolver) resolve(href string) (title, external string) {
+	if href == "" || strings.HasPrefix(href, "#") {
+		return "", ""
+	}
+	u, err := r.base.Parse(href)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", ""
+	}
+	if !strings.EqualFold(u.Host, r.base.Host) {
+		u.Fragment = ""
+		return "", u.String()
+	}
+
+	// index.php?title=Title, which also serves redlinks on every wiki
+	query := u.Query()
+	if t := query.Get("title"); t != "" {
+		if action := query.Get("action"); action != "" && action != "view" && !query.Has("redlink") {
+			return "", ""
+		}
+		return decodeTitle(t), ""
+	}
+
+	if r.inPath && u.RawQuery == "" && len(u.Path) > len(r.prefix)+len(r.suffix) &&
+		strings.HasPrefix(u.Path, r.prefix) && strings.HasSuffix(u.Path, r.suffix) {
+		return decodeTitle(u.Path[len(r.prefix) : len(u.Path)-len(r.suffix)]), ""
+	}
+	return "", ""
+}
+
+// linkResolver returns the resolver for a wiki's article path, or nil when
+// it is unknown and links are resolved as on Wikimedia wikis
+func (c *Client) linkResolver(ctx context.Context, wikiURL string) *linkResolver {
+	caps, err := c.GetCapabilities(ctx, wikiURL)
+	if err != nil || caps.ArticlePath == "" {
+		return nil
+	}
+	return newLinkResolver(caps.ArticlePath)
+}
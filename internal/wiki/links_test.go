@@ -0,0 +1,66 @@
+package wiki
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLinkResolver(t *testing.T) {
+	tests := []struct {
+		articlePath, href, title, external string
+	}{
+		{"https://en.wikipedia.org/wiki/$1", "/wiki/Go_(programming_language)#History", "Go (programming language)", ""},
+		{"https://en.wikipedia.org/wiki/$1", "./Caf%C3%A9", "Café", ""},
+		{"https://en.wikipedia.org/wiki/$1", "https://en.wikipedia.org/wiki/AT%26T", "AT&T", ""},
+		{"https://en.wikipedia.org/wiki/$1", "//de.wikipedia.org/wiki/Go#Top", "", "https://de.wikipedia.org/wiki/Go"},
+		{"https://en.wikipedia.org/wiki/$1", "/w/index.php?title=Missing&action=edit&redlink=1", "Missing", ""},
+		{"https://en.wikipedia.org/wiki/$1", "/w/index.php?title=Go&action=history", "", ""},
+		{"https://en.wikipedia.org/wiki/$1", "/static/images/logo.png", "", ""},
+		{"https://en.wikipedia.org/wiki/$1", "#cite_note-1", "", ""},
+		{"https://en.wikipedia.org/wiki/$1", "mailto:someone@example.org", "", ""},
+		{"http://localhost/index.php/$1", "/index.php/Main_Page", "Main Page", ""},
+		{"http://localhost/index.php/$1", "/wiki/Main_Page", "", ""},
+		{"https://example.org/view/$1", "/view/Help:Contents", "Help:Contents", ""},
+		{"https://example.org/view/$1", "http://source.example.com/paper.pdf", "", "http://source.example.com/paper.pdf"},
+		{"https://example.org/index.php?title=$1", "/index.php?title=Main_Page", "Main Page", ""},
+	}
+	for _, tt := range tests {
+		r := newLinkResolver(tt.articlePath)
+		if r == nil {
+			t.Fatalf("no resolver for %q", tt.articlePath)
+		}
+		title, external := r.resolve(tt.href)
+		if title != tt.title || external != tt.external {
+			t.Errorf("resolve(%q) with %q = %q, %q, want %q, %q", tt.href, tt.articlePath, title, external, tt.title, tt.external)
+		}
+	}
+
+	if r := newLinkResolver("/wiki/$1"); r != nil {
+		t.Error("resolver for an article path without a server")
+	}
+}
+
+func TestClientConvertHTMLUsesArticlePath(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"query":{"general":{"server":"http://%s","articlepath":"/index.php/$1"},"extensions":[]}}`, r.Host)
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	html := `<p><a href="/index.php/Main_Page">home</a> and <a href="https://example.com/">elsewhere</a></p>`
+	content, err := client.ConvertHTML(context.Background(), srv.URL, html)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Main Page"}; !reflect.DeepEqual(content.Links, want) {
+		t.Errorf("links = %q, want %q", content.Links, want)
+	}
+	if want := []string{"https://example.com/"}; !reflect.DeepEqual(content.ExternalURLs, want) {
+		t.Errorf("external urls = %q, want %q", content.ExternalURLs, want)
+	}
+}
@@ -0,0 +1,136 @@
+package wiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// MoveParams describes a page rename made with action=move
+type MoveParams struct {
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Reason     string `json:"reason"`
+	NoRedirect bool   `json:"no_redirect,omitempty"` // don't leave a redirect at the old title; needs the suppressredirect right
+	MoveTalk   bool   `json:"move_talk,omitempty"`   // move the talk page along with the page
+}
+
+// MoveResult is the outcome of a page move
+type MoveResult struct {
+	From            string    `json:"from"`
+	To              string    `json:"to"`
+	Reason          string    `json:"reason,omitempty"`
+	RedirectCreated bool      `json:"redirect_created"` // false when no_redirect was honoured
+	TalkFrom        string    `json:"talk_from,omitempty"`
+	TalkTo          string    `json:"talk_to,omitempty"`
+	TalkError       string    `json:"talk_error,omitempty"` // why the talk page wasn't moved, when the page was
+	URL             string    `json:"url"`                  // the page at its new title
+	Log             *LogEntry `json:"log,omitempty"`        // the move log entry; nil if it couldn't be read
+}
+
+// LogEntry is an entry of a wiki log, such as the move log
+type LogEntry struct {
+	LogID     int    `json:"log_id"`
+	Type      string `json:"type"`
+	Action    string `json:"action"`
+	Title     string `json:"title"`
+	User      string `json:"user"`
+	Timestamp string `json:"timestamp"`
+	Comment   string `json:"comment,omitempty"`
+	URL       string `json:"url"` // the entry on Special:Log
+}
+
+// Move renames a page and invalidates the cached content of its titles.
+// Like Edit, it is made with the wiki's Auth. A destination that already
+// exists gives a *PageExistsError. The move log entry is looked up
+// afterwards; the move stands if that lookup fails, and Log is left nil.
+func (c *Client) Move(ctx context.Context, wikiURL string, move MoveParams) (*MoveResult, error) {
+	if b := c.backend(wikiURL); b != nil {
+		return nil, &BackendUnsupportedError{Backend: b.Name(), WikiURL: wikiURL, Request: "move"}
+	}
+
+	params := url.Values{}
+	params.Set("action", "move")
+	params.Set("from", move.From)
+	params.Set("to", move.To)
+	params.Set("reason", move.Reason)
+	if move.NoRedirect {
+		params.Set("noredirect", "1")
+	}
+	if move.MoveTalk {
+		params.Set("movetalk", "1")
+	}
+
+	resp, err := c.writeRequest(ctx, wikiURL, params)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Code == "articleexists" {
+		return nil, &PageExistsError{Title: move.To}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("move page: %w", err)
+	}
+	if resp.Move == nil {
+		return nil, fmt.Errorf("empty move response")
+	}
+
+	c.cache.InvalidatePage(wikiURL, resp.Move.From)
+	c.cache.InvalidatePage(wikiURL, resp.Move.To)
+	if resp.Move.TalkTo != "" {
+		c.cache.InvalidatePage(wikiURL, resp.Move.TalkFrom)
+		c.cache.InvalidatePage(wikiURL, resp.Move.TalkTo)
+	}
+
+	result := &MoveResult{
+		From:            resp.Move.From,
+		To:              resp.Move.To,
+		Reason:          resp.Move.Reason,
+		RedirectCreated: bool(resp.Move.RedirectCreated),
+		TalkFrom:        resp.Move.TalkFrom,
+		TalkTo:          resp.Move.TalkTo,
+		TalkError:       resp.Move.TalkMoveErrorInfo,
+		URL:             c.PageURL(ctx, wikiURL, resp.Move.To),
+	}
+	if len(resp.Move.TalkMoveErrors) > 0 {
+		result.TalkError = resp.Move.TalkMoveErrors[0].Text
+	}
+	result.Log, _ = c.moveLogEntry(ctx, wikiURL, result.From, result.To)
+	return result, nil
+}
+
+// moveLogEntry returns the latest move of from to to. Move log entries are
+// filed under the old title.
+func (c *Client) moveLogEntry(ctx context.Context, wikiURL, from, to string) (*LogEntry, error) {
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("list", "logevents")
+	params.Set("letype", "move")
+	params.Set("letitle", from)
+	params.Set("leprop", "ids|title|type|user|timestamp|comment|details")
+	params.Set("lelimit", "5")
+
+	resp, err := c.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Query == nil {
+		return nil, fmt.Errorf("empty log response")
+	}
+	for _, event := range resp.Query.LogEvents {
+		if target, _ := event.Params["target_title"].(string); target != to {
+			continue
+		}
+		return &LogEntry{
+			LogID:     event.LogID,
+			Type:      event.Type,
+			Action:    event.Action,
+			Title:     event.Title,
+			User:      event.User,
+			Timestamp: event.Timestamp,
+			Comment:   event.Comment,
+			URL:       c.PageURL(ctx, wikiURL, "Special:Redirect/logid/"+strconv.Itoa(event.LogID)),
+		}, nil
+	}
+	return nil, fmt.Errorf("no move log entry for %s", from)
+}
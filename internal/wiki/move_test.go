@@ -0,0 +1,61 @@
+package wiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestMove(t *testing.T) {
+	var form url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch {
+		case r.Form.Get("type") == "csrf":
+			fmt.Fprint(w, `{"query":{"tokens":{"csrftoken":"token"}}}`)
+		case r.Form.Get("action") == "move" && r.Form.Get("to") == "Taken":
+			fmt.Fprint(w, `{"error":{"code":"articleexists","info":"A page of that name already exists."}}`)
+		case r.Form.Get("action") == "move":
+			form = r.PostForm
+			fmt.Fprint(w, `{"move":{"from":"Old","to":"New","reason":"Rename","redirectcreated":true,`+
+				`"talkfrom":"Talk:Old","talkto":"Talk:New"}}`)
+		case r.Form.Get("list") == "logevents":
+			fmt.Fprint(w, `{"query":{"logevents":[`+
+				`{"logid":12,"title":"Old","type":"move","action":"move","user":"Bot","timestamp":"2026-01-02T03:04:05Z","comment":"Rename","params":{"target_ns":0,"target_title":"New"}},`+
+				`{"logid":7,"title":"Old","type":"move","action":"move","user":"Someone","params":{"target_ns":0,"target_title":"Older"}}]}}`)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	client.SetAuth(srv.URL, Auth{AccessToken: "oauth"})
+
+	result, err := client.Move(context.Background(), srv.URL, MoveParams{From: "Old", To: "New", Reason: "Rename", MoveTalk: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]string{"from": "Old", "to": "New", "movetalk": "1", "noredirect": ""} {
+		if got := form.Get(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+	if !result.RedirectCreated || result.TalkTo != "Talk:New" {
+		t.Errorf("result = %+v, want a redirect and the talk page moved", result)
+	}
+	if result.Log == nil || result.Log.LogID != 12 || result.Log.User != "Bot" {
+		t.Errorf("log = %+v, want entry 12 by Bot", result.Log)
+	}
+
+	_, err = client.Move(context.Background(), srv.URL, MoveParams{From: "Old", To: "Taken", Reason: "Rename"})
+	var existsErr *PageExistsError
+	if !errors.As(err, &existsErr) || existsErr.Title != "Taken" {
+		t.Errorf("Move = %v, want a PageExistsError for Taken", err)
+	}
+}
@@ -1,35 +1,82 @@
 package wiki
 
 import (
+	"context"
+	"io"
+	"net/url"
 	"regexp"
 	"strings"
+	"sync"
+	"unicode"
 
 	md "github.com/JohannesKaufmann/html-to-markdown"
+	"github.com/JohannesKaufmann/html-to-markdown/escape"
 	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+	"golang.org/x/net/html"
 )
 
-var (
-	// Converter is a shared HTML to Markdown converter
-	converter *md.Converter
-)
+// conversionCancelled is panicked by a converter's hooks to abandon a
+// conversion once its context is done, and recovered by convert
+type conversionCancelled struct {
+	err error
+}
+
+// checkCancelled stops a conversion once ctx is done
+func checkCancelled(ctx context.Context) {
+	if err := ctx.Err(); err != nil {
+		panic(conversionCancelled{err})
+	}
+}
+
+// pooledConverter is an HTML to Markdown converter whose hooks stop the
+// conversion once ctx is done. Building one allocates its rule tables, so
+// converters are reused through converterPool, with ctx set for each use.
+type pooledConverter struct {
+	*md.Converter
+	ctx context.Context
+}
+
+// converterPool holds idle converters
+var converterPool = sync.Pool{
+	New: func() any { return newConverter() },
+}
+
+// newConverter returns a converter for MediaWiki HTML. The converter has no
+// cancellation of its own, so the check runs in the hooks it calls for
+// text, links, images, spans, and reference markers.
+func newConverter() *pooledConverter {
+	c := &pooledConverter{}
 
-func init() {
 	// Initialize converter with MediaWiki-friendly options
-	converter = md.NewConverter("", true, &md.Options{
+	c.Converter = md.NewConverter("", true, &md.Options{
 		HeadingStyle:     "atx", // Use # style headings
 		HorizontalRule:   "---",
 		BulletListMarker: "-",
 		CodeBlockStyle:   "fenced", // Use ``` for code blocks
 		StrongDelimiter:  "**",
 		EmDelimiter:      "*",
+		GetAbsoluteURL: func(selec *goquery.Selection, rawURL, domain string) string {
+			checkCancelled(c.ctx)
+			return md.DefaultGetAbsoluteURL(selec, rawURL, domain)
+		},
 	})
 
 	// Add custom rules for MediaWiki-specific elements
-	converter.AddRules(
+	c.AddRules(
+		// Text, as the CommonMark rule converts it
+		md.Rule{
+			Filter: []string{"#text"},
+			Replacement: func(content string, selec *goquery.Selection, opt *md.Options) *string {
+				checkCancelled(c.ctx)
+				return markdownText(content, selec, opt)
+			},
+		},
 		// Remove edit section links
 		md.Rule{
 			Filter: []string{"span"},
 			AdvancedReplacement: func(content string, selec *goquery.Selection, opt *md.Options) (md.AdvancedResult, bool) {
+				checkCancelled(c.ctx)
 				if selec.HasClass("mw-editsection") {
 					return md.AdvancedResult{Markdown: ""}, true
 				}
@@ -40,6 +87,7 @@ func init() {
 		md.Rule{
 			Filter: []string{"sup"},
 			AdvancedReplacement: func(content string, selec *goquery.Selection, opt *md.Options) (md.AdvancedResult, bool) {
+				checkCancelled(c.ctx)
 				if selec.HasClass("reference") {
 					// Keep reference numbers in a cleaner format
 					text := selec.Text()
@@ -49,25 +97,325 @@ func init() {
 			},
 		},
 	)
+	return c
+}
+
+// convert converts a document to Markdown, returning ctx's error if it is
+// done first
+func convert(ctx context.Context, selection *goquery.Selection) (markdown string, err error) {
+	c := converterPool.Get().(*pooledConverter)
+	c.ctx = ctx
+	defer func() {
+		c.ctx = nil
+		converterPool.Put(c)
+		if r := recover(); r != nil {
+			cancelled, ok := r.(conversionCancelled)
+			if !ok {
+				panic(r)
+			}
+			err = cancelled.err
+		}
+	}()
+	return c.Convert(selection), nil
+}
+
+var (
+	tabs           = regexp.MustCompile(`\t+`)
+	multipleSpaces = regexp.MustCompile(`  +`)
+)
+
+// markdownSpecial are the characters escape.MarkdownCharacters may escape;
+// text without any of them is left as it is
+const markdownSpecial = "\\#-*_.+>`|[]"
+
+// markdownText converts a text node exactly as the CommonMark plugin's
+// rule does, which it replaces. That rule builds goquery selections and
+// compiles selectors for every text node, and looks at all the node's
+// earlier siblings; this reads the node and its neighbours directly and
+// skips the regular expressions where they can't match.
+func markdownText(content string, selec *goquery.Selection, opt *md.Options) *string {
+	n := selec.Get(0)
+	text := n.Data
+	if strings.TrimSpace(text) == "" {
+		text = ""
+		return &text
+	}
+	if strings.IndexByte(text, '\t') >= 0 {
+		text = tabs.ReplaceAllString(text, " ")
+	}
+	if strings.Contains(text, "  ") {
+		text = multipleSpaces.ReplaceAllString(text, " ")
+	}
+	if opt.EscapeMode == "basic" && strings.ContainsAny(text, markdownSpecial) {
+		text = escape.MarkdownCharacters(text)
+	}
+
+	// Text starting a list item with a nested list is trimmed of spaces, so
+	// as not to break the list's indentation
+	if startsListItem(n) {
+		text = strings.Trim(text, " ")
+	}
+	return &text
+}
+
+// startsListItem reports whether a text node is in a list or list item,
+// followed by a nested list, with no text in elements before it
+func startsListItem(n *html.Node) bool {
+	parent := n.Parent
+	if parent == nil || parent.Type != html.ElementNode || (parent.Data != "li" && parent.Data != "ol" && parent.Data != "ul") {
+		return false
+	}
+	next := n.NextSibling
+	for next != nil && next.Type != html.ElementNode {
+		next = next.NextSibling
+	}
+	if next == nil || (next.Data != "ul" && next.Data != "ol") {
+		return false
+	}
+	for prev := n.PrevSibling; prev != nil; prev = prev.PrevSibling {
+		if prev.Type == html.ElementNode && hasText(prev) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasText reports whether an element contains any text but whitespace
+func hasText(n *html.Node) bool {
+	for d := range n.Descendants() {
+		if d.Type == html.TextNode && strings.TrimSpace(d.Data) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// noiseMatcher matches page furniture that is never article content
+var noiseMatcher = cascadia.MustCompile("style, script, link, .mw-editsection, .navbox, .vertical-navbox, .navbox-styles, " +
+	"[role=navigation], .printfooter, .catlinks, .mw-empty-elt")
+
+// linkMatcher matches links
+var linkMatcher = cascadia.MustCompile("a")
+
+// ConvertedContent is Markdown together with word counts taken from the
+// cleaned HTML it was converted from
+type ConvertedContent struct {
+	Markdown       string
+	ProseWordCount int      // running text, excluding tables, captions, and reference markers
+	TableWordCount int      // text inside tables (including infoboxes)
+	Links          []string // titles of linked pages, as ExtractLinks returns them
+	ExternalURLs   []string // links leaving the wiki, such as cited sources
+	LinkDetails    []Link   // links to pages with their anchors and text, one per title and anchor
+}
+
+// ConvertHTML strips navigation noise from MediaWiki HTML, converts it to
+// Markdown, and counts prose and table words separately. Converting a long
+// article is CPU-bound, so it stops with ctx's error once ctx is done.
+func ConvertHTML(ctx context.Context, rawHTML string) (*ConvertedContent, error) {
+	return convertHTML(ctx, rawHTML, nil)
+}
+
+// convertHTML is ConvertHTML with links resolved by resolver
+func convertHTML(ctx context.Context, rawHTML string, resolver *linkResolver) (*ConvertedContent, error) {
+	doc, err := goquery.NewDocumentFromReader(contextReader{ctx, strings.NewReader(rawHTML)})
+	if err != nil {
+		return nil, err
+	}
+
+	// Links come from the whole document, navboxes included, so callers
+	// needn't parse it again with ExtractLinks
+	links, external, details := extractLinks(doc.Selection, resolver)
+
+	// Pre-clean: drop navboxes, styles, and other furniture
+	doc.FindMatcher(noiseMatcher).Remove()
+	for _, node := range doc.Nodes {
+		flattenDeepNesting(node, 0)
+		sanitizeHTML(node, false)
+	}
+
+	prose, tables := countWordsByKind(doc.Selection)
+
+	markdown, err := convert(ctx, doc.Selection)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConvertedContent{
+		Markdown:       cleanupMarkdown(markdown),
+		ProseWordCount: prose,
+		TableWordCount: tables,
+		Links:          links,
+		ExternalURLs:   external,
+		LinkDetails:    details,
+	}, nil
+}
+
+// contextReader fails reads once ctx is done, so parsing stops partway
+// through a long document
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
 }
 
 // HTMLToMarkdown converts MediaWiki HTML to Markdown
-func HTMLToMarkdown(html string) (string, error) {
-	markdown, err := converter.ConvertString(html)
+func HTMLToMarkdown(ctx context.Context, html string) (string, error) {
+	content, err := ConvertHTML(ctx, html)
 	if err != nil {
 		return "", err
 	}
 
-	// Clean up the markdown
-	markdown = cleanupMarkdown(markdown)
+	return content.Markdown, nil
+}
+
+// maxNestingDepth is the deepest element nesting converted as markup.
+// Article HTML stays far below it, and Markdown conversion slows down
+// quadratically with depth.
+const maxNestingDepth = 128
+
+// flattenDeepNesting replaces elements nested deeper than maxNestingDepth
+// below n with their text
+func flattenDeepNesting(n *html.Node, depth int) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode {
+			continue
+		}
+		if depth < maxNestingDepth {
+			flattenDeepNesting(child, depth+1)
+			continue
+		}
+
+		var text strings.Builder
+		for descendant := range child.Descendants() {
+			if descendant.Type == html.TextNode {
+				text.WriteString(descendant.Data)
+			}
+		}
+		flat := &html.Node{Type: html.TextNode, Data: text.String()}
+		n.InsertBefore(flat, child)
+		n.RemoveChild(child)
+		child = flat
+	}
+}
+
+// blockElements separate words when their text is concatenated
+var blockElements = map[string]bool{
+	"p": true, "div": true, "li": true, "dd": true, "dt": true, "br": true,
+	"td": true, "th": true, "tr": true, "caption": true, "figcaption": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"blockquote": true, "pre": true,
+}
+
+// captionClasses mark image captions outside of tables
+var captionClasses = []string{"thumbcaption", "gallerytext"}
+
+// countWordsByKind counts words in prose and in tables, skipping image
+// captions and reference markers entirely
+func countWordsByKind(selection *goquery.Selection) (prose, tables int) {
+	var proseText, tableText wordCounter
+
+	var walk func(n *html.Node, inTable bool)
+	walk = func(n *html.Node, inTable bool) {
+		if n.Type == html.TextNode {
+			if inTable {
+				tableText.write(n.Data)
+			} else {
+				proseText.write(n.Data)
+			}
+			return
+		}
+
+		if n.Type == html.ElementNode {
+			if isCaptionOrReference(n) {
+				return
+			}
+			if n.Data == "table" {
+				inTable = true
+			}
+			if blockElements[n.Data] {
+				proseText.breakWord()
+				tableText.breakWord()
+			}
+		}
+
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child, inTable)
+		}
+	}
+
+	for _, node := range selection.Nodes {
+		walk(node, false)
+	}
+
+	return proseText.words, tableText.words
+}
+
+// wordCounter counts the words strings.Fields would find in text written
+// in pieces, without keeping the text
+type wordCounter struct {
+	words  int
+	inWord bool
+}
+
+func (c *wordCounter) write(text string) {
+	for _, r := range text {
+		switch {
+		case unicode.IsSpace(r):
+			c.inWord = false
+		case !c.inWord:
+			c.words++
+			c.inWord = true
+		}
+	}
+}
+
+// breakWord ends the current word, as a space written between two
+// elements' text would
+func (c *wordCounter) breakWord() {
+	c.inWord = false
+}
 
-	return markdown, nil
+// isCaptionOrReference reports whether an element is an image caption or a
+// citation marker, neither of which count as words
+func isCaptionOrReference(n *html.Node) bool {
+	if n.Data == "figcaption" {
+		return true
+	}
+
+	for _, attr := range n.Attr {
+		if attr.Key != "class" {
+			continue
+		}
+		classes := strings.Fields(attr.Val)
+		for _, class := range classes {
+			if n.Data == "sup" && class == "reference" {
+				return true
+			}
+			for _, caption := range captionClasses {
+				if class == caption {
+					return true
+				}
+			}
+		}
+	}
+	return false
 }
 
+// excessNewlines matches runs of more than two newlines
+var excessNewlines = regexp.MustCompile(`\n{3,}`)
+
 // cleanupMarkdown performs post-conversion cleanup
 func cleanupMarkdown(md string) string {
 	// Remove excessive newlines (more than 2 consecutive)
-	md = regexp.MustCompile(`\n{3,}`).ReplaceAllString(md, "\n\n")
+	if strings.Contains(md, "\n\n\n") {
+		md = excessNewlines.ReplaceAllString(md, "\n\n")
+	}
 
 	// Trim whitespace
 	md = strings.TrimSpace(md)
@@ -81,18 +429,42 @@ func ExtractLinks(html string) []string {
 	if err != nil {
 		return nil
 	}
+	links, _, _ := extractLinks(doc.Selection, nil)
+	return links
+}
 
+// extractLinks extracts the titles of pages linked below a selection, the
+// URLs of external links, and the page links with their anchors and text,
+// each deduplicated in document order. Without a resolver, pages are
+// expected under /wiki/ or index.php?title=.
+func extractLinks(selection *goquery.Selection, resolver *linkResolver) (titles, external []string, details []Link) {
 	links := make([]string, 0)
 	seen := make(map[string]bool)
+	external = make([]string, 0)
+	seenURLs := make(map[string]bool)
+	details = make([]Link, 0)
+	seenDetails := make(map[Link]bool)
 
-	doc.Find("a").Each(func(i int, s *goquery.Selection) {
+	selection.FindMatcher(linkMatcher).Each(func(i int, s *goquery.Selection) {
 		href, exists := s.Attr("href")
 		if !exists {
 			return
 		}
 
-		// Extract page title from href
-		title := extractTitleFromHref(href)
+		var title, link string
+		if resolver != nil {
+			title, link = resolver.resolve(href)
+		} else if link = externalURL(href); link == "" {
+			title = extractTitleFromHref(href)
+		}
+
+		if link != "" {
+			if !seenURLs[link] {
+				seenURLs[link] = true
+				external = append(external, link)
+			}
+			return
+		}
 		if title == "" {
 			return
 		}
@@ -102,9 +474,40 @@ func ExtractLinks(html string) []string {
 			seen[title] = true
 			links = append(links, title)
 		}
+		if key := (Link{Title: title, Anchor: hrefAnchor(href)}); !seenDetails[key] {
+			seenDetails[key] = true
+			key.DisplayText = strings.Join(strings.Fields(s.Text()), " ")
+			details = append(details, key)
+		}
 	})
 
-	return links
+	return links, external, details
+}
+
+// hrefAnchor returns the section or other fragment an href points to, in
+// the form section titles take: decoded, with spaces for underscores
+func hrefAnchor(href string) string {
+	_, fragment, ok := strings.Cut(href, "#")
+	if !ok {
+		return ""
+	}
+	if decoded, err := url.PathUnescape(fragment); err == nil {
+		fragment = decoded
+	}
+	return strings.ReplaceAll(fragment, "_", " ")
+}
+
+// externalURL returns href as an absolute URL if it leaves the wiki, or ""
+// for links within it. MediaWiki writes interwiki and some external links
+// protocol-relative; those get https.
+func externalURL(href string) string {
+	switch {
+	case strings.HasPrefix(href, "//"):
+		return "https:" + href
+	case strings.HasPrefix(href, "http://"), strings.HasPrefix(href, "https://"):
+		return href
+	}
+	return ""
 }
 
 // extractTitleFromHref extracts the page title from a MediaWiki href
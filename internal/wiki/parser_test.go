@@ -0,0 +1,148 @@
+package wiki
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	md "github.com/JohannesKaufmann/html-to-markdown"
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestConvertHTMLCancelled(t *testing.T) {
+	html := articleHTML(t)
+
+	full := time.Now()
+	if _, err := ConvertHTML(context.Background(), html); err != nil {
+		t.Fatal(err)
+	}
+	elapsed := time.Since(full)
+
+	// Cancelled partway, the conversion stops instead of running to the end
+	ctx, cancel := context.WithTimeout(context.Background(), elapsed/10)
+	defer cancel()
+	start := time.Now()
+	_, err := ConvertHTML(ctx, html)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if stopped := time.Since(start); stopped > elapsed/2 {
+		t.Errorf("cancelled conversion took %s of the full %s", stopped, elapsed)
+	}
+
+	// A conversion that panics for another reason still panics
+	defer func() {
+		if recover() == nil {
+			t.Error("panic was swallowed")
+		}
+	}()
+	convert(context.Background(), nil)
+}
+
+func TestMarkdownTextMatchesCommonMark(t *testing.T) {
+	commonmark := md.NewConverter("", true, nil)
+	fast := md.NewConverter("", true, nil)
+	fast.AddRules(md.Rule{Filter: []string{"#text"}, Replacement: markdownText})
+
+	for _, html := range []string{
+		"<p>plain text. With periods and 3 numbers.</p>",
+		"<p>  spaced \t\tout\ttext  </p>",
+		"<p># not heading\n1. not list\n- nope\n> quote *a* _b_ `c` |d| [e] \\f ---</p>",
+		"<ul><li>  item  <ul><li>sub</li></ul></li><li><b>x</b>  y <ol><li>1. no</li></ol></li></ul>",
+		"<ol><li> <span></span> text <ul><li>a</li></ul></li></ol>",
+		"<ul>  lead <li>x</li> tail  <ul><li>y</li></ul></ul>",
+		"<div>   </div><p>\n</p>",
+	} {
+		want := commonmark.Convert(mustParse(t, html).Selection)
+		if got := fast.Convert(mustParse(t, html).Selection); got != want {
+			t.Errorf("%q: got %q, want %q", html, got, want)
+		}
+	}
+}
+
+func mustParse(t *testing.T, html string) *goquery.Document {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestCheckComplexity(t *testing.T) {
+	// The article fixture is well inside the defaults
+	if err := checkComplexity(articleHTML(t), DefaultMaxHTMLNodes, DefaultMaxHTMLDepth); err != nil {
+		t.Errorf("article rejected: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		html      string
+		wantDepth bool // depth limit exceeded, else node limit
+	}{
+		{"nested tables", strings.Repeat("<table><tr><td>", 40) + "x", true},
+		{"flat elements", strings.Repeat("<p>x</p>", 200), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkComplexity(tt.html, 100, 100)
+			var complexErr *PageTooComplexError
+			if !errors.As(err, &complexErr) {
+				t.Fatalf("err = %v, want PageTooComplexError", err)
+			}
+			if gotDepth := complexErr.Depth > complexErr.MaxDepth; gotDepth != tt.wantDepth {
+				t.Errorf("depth %d exceeded = %v, want %v (%v)", complexErr.Depth, gotDepth, tt.wantDepth, err)
+			}
+		})
+	}
+
+	// Void elements and closed elements don't add to the depth
+	shallow := strings.Repeat("<br><img src=x><div><span>x</span></div>", 20)
+	if err := checkComplexity(shallow, 0, 3); err != nil {
+		t.Errorf("shallow HTML rejected: %v", err)
+	}
+	if err := checkComplexity(strings.Repeat("<div>", 1000), 0, 0); err != nil {
+		t.Errorf("disabled limits rejected HTML: %v", err)
+	}
+}
+
+func TestExtractLinksSplitsExternal(t *testing.T) {
+	html := `<p><a href="/wiki/Go_(programming_language)#History">Go</a>
+		<a class="external text" href="https://go.dev/doc/">docs</a>
+		<a class="extiw" href="//de.wikipedia.org/wiki/Go">de</a>
+		<a href="http://example.org/?title=Not_a_page">site</a>
+		<a href="/wiki/Go_(programming_language)">again</a>
+		<a href="https://go.dev/doc/">docs again</a></p>`
+
+	content, err := ConvertHTML(context.Background(), html)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Go (programming language)"}; !reflect.DeepEqual(content.Links, want) {
+		t.Errorf("links = %q, want %q", content.Links, want)
+	}
+	want := []string{"https://go.dev/doc/", "https://de.wikipedia.org/wiki/Go", "http://example.org/?title=Not_a_page"}
+	if !reflect.DeepEqual(content.ExternalURLs, want) {
+		t.Errorf("external urls = %q, want %q", content.ExternalURLs, want)
+	}
+}
+
+func TestHrefAnchor(t *testing.T) {
+	tests := map[string]string{
+		"/wiki/Go":                        "",
+		"/wiki/Go#History":                "History",
+		"/wiki/Einstein#Early_life":       "Early life",
+		"./Caf%C3%A9#Caf%C3%A9_culture":   "Café culture",
+		"/w/index.php?title=Go#Top":       "Top",
+		"/wiki/Go#100%_pure":              "100% pure",
+		"https://example.org/page#anchor": "anchor",
+	}
+	for href, want := range tests {
+		if got := hrefAnchor(href); got != want {
+			t.Errorf("hrefAnchor(%q) = %q, want %q", href, got, want)
+		}
+	}
+}
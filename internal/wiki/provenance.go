@@ -0,0 +1,70 @@
+package wiki
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Provenance identifies where content came from, so it can be cited and
+// checked against the wiki later
+type Provenance struct {
+	RevID     int       `json:"revid"`      // revision the content was rendered from; 0 if unknown
+	FetchedAt time.Time `json:"fetched_at"` // when it was fetched from the wiki, not from the cache
+	URL       string    `json:"url"`        // canonical URL of the page
+}
+
+// NewProvenance records that content of title at revision revID is being
+// fetched now
+func (c *Client) NewProvenance(ctx context.Context, wikiURL, title string, revID int) *Provenance {
+	return &Provenance{
+		RevID:     revID,
+		FetchedAt: time.Now().UTC().Truncate(time.Second),
+		URL:       c.PageURL(ctx, wikiURL, title),
+	}
+}
+
+// PageURL returns the canonical URL of a page, from the wiki's article path
+// when it is known and its index.php otherwise. Backends whose pages live
+// elsewhere, such as Confluence, give the URL themselves.
+func (c *Client) PageURL(ctx context.Context, wikiURL, title string) string {
+	if b, ok := c.backend(wikiURL).(pageURLBackend); ok {
+		return b.pageURL(title)
+	}
+	name := strings.ReplaceAll(title, " ", "_")
+	if caps, err := c.GetCapabilities(ctx, wikiURL); err == nil && caps.ArticlePath != "" {
+		return strings.Replace(caps.ArticlePath, "$1", escapeTitle(name), 1)
+	}
+	return strings.TrimSuffix(wikiURL, "api.php") + "index.php?title=" + url.QueryEscape(name)
+}
+
+// titleEscaper adjusts url.PathEscape output to the characters MediaWiki
+// leaves unencoded in page URLs
+var titleEscaper = strings.NewReplacer(
+	"%2F", "/", "%21", "!", "%2A", "*", "%28", "(", "%29", ")",
+	"&", "%26", "=", "%3D", "+", "%2B",
+)
+
+// escapeTitle encodes a page title for an article path the way MediaWiki
+// does, so URLs match the ones the wiki links to
+func escapeTitle(title string) string {
+	return titleEscaper.Replace(url.PathEscape(title))
+}
+
+// articlePath joins a wiki's server and article path from siteinfo into an
+// absolute URL template, taking the scheme of protocol-relative servers
+// from wikiURL
+func articlePath(wikiURL, server, path string) string {
+	if server == "" || path == "" {
+		return ""
+	}
+	if strings.HasPrefix(server, "//") {
+		scheme := "https"
+		if u, err := url.Parse(wikiURL); err == nil && u.Scheme != "" {
+			scheme = u.Scheme
+		}
+		server = scheme + ":" + server
+	}
+	return server + path
+}
@@ -0,0 +1,33 @@
+package wiki
+
+import "testing"
+
+func TestArticlePath(t *testing.T) {
+	tests := []struct {
+		wikiURL, server, path, want string
+	}{
+		{"https://en.wikipedia.org/w/api.php", "//en.wikipedia.org", "/wiki/$1", "https://en.wikipedia.org/wiki/$1"},
+		{"http://localhost/api.php", "http://localhost", "/index.php/$1", "http://localhost/index.php/$1"},
+		{"https://example.org/w/api.php", "", "/wiki/$1", ""},
+	}
+	for _, tt := range tests {
+		if got := articlePath(tt.wikiURL, tt.server, tt.path); got != tt.want {
+			t.Errorf("articlePath(%q, %q, %q) = %q, want %q", tt.wikiURL, tt.server, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestEscapeTitle(t *testing.T) {
+	tests := map[string]string{
+		"Main_Page":          "Main_Page",
+		"User:Example/Notes": "User:Example/Notes",
+		"C++_(language)":     "C%2B%2B_(language)",
+		"AT&T":               "AT%26T",
+		"Café":               "Caf%C3%A9",
+	}
+	for title, want := range tests {
+		if got := escapeTitle(title); got != want {
+			t.Errorf("escapeTitle(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package wiki
+
+import (
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wikitext"
+)
+
+// readabilityWord matches a word: letters with inner apostrophes or hyphens
+var readabilityWord = regexp.MustCompile(`\p{L}[\p{L}\p{M}'’-]*`)
+
+// readabilityVowels are the letters counted as vowels when estimating syllables
+const readabilityVowels = "aeiouyàáâãäåæèéêëìíîïòóôõöøùúûüýÿœ"
+
+// Readability holds basic readability metrics for a stretch of prose
+type Readability struct {
+	Sentences           int     `json:"sentences"`
+	Words               int     `json:"words"`
+	AvgSentenceLength   float64 `json:"avg_sentence_length"` // words per sentence
+	AvgSyllablesPerWord float64 `json:"avg_syllables_per_word"`
+	FleschReadingEase   float64 `json:"flesch_reading_ease"` // higher is easier; below 30 is very difficult
+}
+
+// MeasureReadability computes readability metrics for wikitext, or returns
+// nil when it holds no prose. Markup, references, templates, headings, and
+// tables are left out; list items without closing punctuation count as
+// sentences. Syllables are estimated from vowel groups, so the Flesch
+// reading ease is an approximation, and its scale is calibrated for
+// English.
+func MeasureReadability(text string) *Readability {
+	prose := readableProse(text)
+
+	words := readabilityWord.FindAllString(prose, -1)
+	if len(words) == 0 {
+		return nil
+	}
+
+	sentences := 0
+	for _, line := range strings.Split(prose, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || !readabilityWord.MatchString(line) {
+			continue
+		}
+		sentences += len(splitSentences(line))
+	}
+
+	syllables := 0
+	for _, word := range words {
+		syllables += countSyllables(word)
+	}
+
+	wordsPerSentence := float64(len(words)) / float64(sentences)
+	syllablesPerWord := float64(syllables) / float64(len(words))
+	return &Readability{
+		Sentences:           sentences,
+		Words:               len(words),
+		AvgSentenceLength:   round1(wordsPerSentence),
+		AvgSyllablesPerWord: math.Round(syllablesPerWord*100) / 100,
+		FleschReadingEase:   round1(206.835 - 1.015*wordsPerSentence - 84.6*syllablesPerWord),
+	}
+}
+
+// readableProse renders wikitext as plain text lines of prose, dropping
+// headings and tables and stripping list markers
+func readableProse(text string) string {
+	var lines []string
+	inTable := 0
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "{|"):
+			inTable++
+			continue
+		case strings.HasPrefix(trimmed, "|}"):
+			if inTable > 0 {
+				inTable--
+			}
+			continue
+		case inTable > 0, wikitextHeading.MatchString(trimmed):
+			continue
+		}
+		lines = append(lines, strings.TrimLeft(trimmed, "*#:; "))
+	}
+
+	return plainRenderer.Text(wikitext.Parse(strings.Join(lines, "\n")))
+}
+
+// countSyllables estimates a word's syllables as its vowel groups, less a
+// silent final "e", and at least one
+func countSyllables(word string) int {
+	word = strings.ToLower(word)
+	count := 0
+	prevVowel := false
+	for _, r := range word {
+		vowel := strings.ContainsRune(readabilityVowels, r)
+		if vowel && !prevVowel {
+			count++
+		}
+		prevVowel = vowel
+	}
+	if strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") && count > 1 {
+		count--
+	}
+	return max(count, 1)
+}
+
+// round1 rounds to one decimal place
+func round1(f float64) float64 {
+	return math.Round(f*10) / 10
+}
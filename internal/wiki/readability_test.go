@@ -0,0 +1,47 @@
+package wiki
+
+import (
+	"testing"
+)
+
+func TestMeasureReadability(t *testing.T) {
+	got := MeasureReadability("The cat sat on the [[mat]].<ref>{{cite web|title=Cats}}</ref> It was happy.")
+	want := Readability{Sentences: 2, Words: 9, AvgSentenceLength: 4.5, AvgSyllablesPerWord: 1.11, FleschReadingEase: 108.3}
+	if got == nil || *got != want {
+		t.Errorf("MeasureReadability = %+v, want %+v", got, want)
+	}
+
+	tests := []struct {
+		name      string
+		text      string
+		sentences int
+		words     int
+	}{
+		{"abbreviations and decimals", "Prices rose by 3.5 percent, e.g. in rent. Wages fell.", 2, 10},
+		{"initials", "It was built by J. Smith in the north. He left.", 2, 11},
+		{"question and exclamation", "Why? Because it works! Fine.", 3, 5},
+		{"list items", "Members:\n* Alice Jones\n* Bob Smith", 3, 5},
+		{"heading and table", "== Results ==\n{| class=\"wikitable\"\n! Team !! Points\n|-\n| Red || 3\n|}\nRed won.", 1, 2},
+		{"templates", "{{Infobox person\n| name = Ada\n| born = 1815\n}}\nAda wrote notes.", 1, 3},
+	}
+	for _, tt := range tests {
+		got := MeasureReadability(tt.text)
+		if got == nil || got.Sentences != tt.sentences || got.Words != tt.words {
+			t.Errorf("%s: MeasureReadability = %+v, want %d sentences, %d words", tt.name, got, tt.sentences, tt.words)
+		}
+	}
+
+	if got := MeasureReadability("== Heading ==\n{{Reflist}}\n[[Category:Cats]]"); got != nil {
+		t.Errorf("MeasureReadability without prose = %+v, want nil", got)
+	}
+}
+
+func TestCountSyllables(t *testing.T) {
+	for word, want := range map[string]int{
+		"cat": 1, "happy": 2, "table": 2, "make": 1, "the": 1, "readability": 5, "Übersetzung": 4,
+	} {
+		if got := countSyllables(word); got != want {
+			t.Errorf("countSyllables(%q) = %d, want %d", word, got, want)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package wiki
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wikitext"
+)
+
+// ExtractReferences extracts a page's citations from its wikitext: the
+// contents of <ref> tags, plus citation templates outside them such as
+// bibliography entries. Scholarly identifiers in each are normalized.
+func ExtractReferences(text string) []Reference {
+	refs := make([]Reference, 0)
+	var visit func(nodes []wikitext.Node)
+	visit = func(nodes []wikitext.Node) {
+		for _, n := range nodes {
+			switch n := n.(type) {
+			case *wikitext.Tag:
+				if n.Name == "ref" {
+					// <ref name="x"/> reuses a reference defined elsewhere
+					if len(n.Content) > 0 {
+						refs = append(refs, buildReference(n.Content, refName(n.Attrs)))
+					}
+					continue
+				}
+				visit(n.Content)
+			case *wikitext.Template:
+				if isCitation(n) {
+					refs = append(refs, buildReference([]wikitext.Node{n}, ""))
+					continue
+				}
+				// List-defined references live in {{reflist|refs=...}}
+				for _, p := range n.Params {
+					visit(p.Value)
+				}
+			}
+		}
+	}
+	visit(wikitext.Parse(text))
+
+	for i := range refs {
+		refs[i].Index = i + 1
+	}
+	return refs
+}
+
+// refNameAttr matches the name attribute of a <ref> tag
+var refNameAttr = regexp.MustCompile(`(?i)name\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'/>]+))`)
+
+func refName(attrs string) string {
+	m := refNameAttr.FindStringSubmatch(attrs)
+	if m == nil {
+		return ""
+	}
+	return strings.TrimSpace(m[1] + m[2] + m[3])
+}
+
+// isCitation reports whether a template is a citation, like {{cite book}}
+// or {{citation}}
+func isCitation(t *wikitext.Template) bool {
+	name := t.NormalizedName()
+	return strings.HasPrefix(name, "cite ") || name == "citation" || name == "vcite" ||
+		strings.HasPrefix(name, "vcite ")
+}
+
+// citationSources are citation parameters naming where a work appeared, in
+// order of preference
+var citationSources = []string{"journal", "work", "website", "newspaper", "magazine", "periodical", "publisher"}
+
+// buildReference describes the citation in a reference's content
+func buildReference(content []wikitext.Node, name string) Reference {
+	ref := Reference{
+		Name:        name,
+		Text:        cleanReferenceText(referenceRenderer.Text(content)),
+		Identifiers: referenceIdentifiers(content),
+	}
+	if cite := wikitext.Find(content, isCitation); cite != nil {
+		ref.Template = cite.NormalizedName()
+		ref.Title = cleanReferenceText(referenceRenderer.ArgText(cite, "title"))
+		ref.URL = strings.TrimSpace(referenceRenderer.ArgText(cite, "url"))
+	}
+	return ref
+}
+
+// cleanReferenceText collapses rendered text to a single line
+func cleanReferenceText(text string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(text)), " ")
+}
+
+// referenceRenderer renders references as plain text, formatting citation
+// templates as "Authors. Title. Source. Date" and identifier templates as
+// the identifier
+var referenceRenderer = &wikitext.Renderer{
+	Templates: map[string]wikitext.TemplateFunc{
+		"doi":   prefixTemplate("doi:"),
+		"isbn":  prefixTemplate("ISBN "),
+		"pmid":  prefixTemplate("PMID "),
+		"pmc":   prefixTemplate("PMC"),
+		"arxiv": prefixTemplate("arXiv:"),
+		"!":     func(*wikitext.Renderer, *wikitext.Template) string { return "|" },
+	},
+	Fallback: func(r *wikitext.Renderer, t *wikitext.Template) string {
+		if isCitation(t) {
+			return citationText(r, t)
+		}
+		return ""
+	},
+}
+
+// citationText formats a citation template's main fields
+func citationText(r *wikitext.Renderer, t *wikitext.Template) string {
+	var parts []string
+	if authors := citationAuthors(r, t); authors != "" {
+		parts = append(parts, authors)
+	}
+	if title := r.ArgText(t, "title"); title != "" {
+		parts = append(parts, title)
+	}
+	for _, name := range citationSources {
+		if source := r.ArgText(t, name); source != "" {
+			parts = append(parts, source)
+			break
+		}
+	}
+	if date := r.ArgText(t, "date"); date != "" {
+		parts = append(parts, date)
+	} else if year := r.ArgText(t, "year"); year != "" {
+		parts = append(parts, year)
+	}
+	return strings.Join(parts, ". ")
+}
+
+// citationAuthors joins a citation's author names, given as last/first,
+// last1/first1, ... or author, author1, ...
+func citationAuthors(r *wikitext.Renderer, t *wikitext.Template) string {
+	var authors []string
+	for i := 1; ; i++ {
+		suffixes := []string{strconv.Itoa(i)}
+		if i == 1 {
+			suffixes = []string{"", "1"}
+		}
+
+		var name, first string
+		for _, suffix := range suffixes {
+			if name == "" {
+				name = r.ArgText(t, "last"+suffix)
+			}
+			if name == "" {
+				name = r.ArgText(t, "author"+suffix)
+			}
+			if first == "" {
+				first = r.ArgText(t, "first"+suffix)
+			}
+		}
+		if name == "" {
+			break
+		}
+		if first != "" {
+			name += ", " + first
+		}
+		authors = append(authors, name)
+	}
+	return strings.Join(authors, "; ")
+}
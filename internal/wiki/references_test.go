@@ -0,0 +1,119 @@
+package wiki
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeIdentifier(t *testing.T) {
+	tests := []struct {
+		kind, value string
+		want        string // normalized value, "" when invalid
+	}{
+		{IdentifierDOI, "10.1000/XYZ123", "10.1000/xyz123"},
+		{IdentifierDOI, "https://doi.org/10.1000/182.", "10.1000/182"},
+		{IdentifierDOI, "doi:10.1016/0370-2693(80)90806-7", "10.1016/0370-2693(80)90806-7"},
+		{IdentifierDOI, "10.1000/182)", "10.1000/182"},
+		{IdentifierDOI, "11.1000/182", ""},
+		{IdentifierISBN, "0-306-40615-2", "9780306406157"},
+		{IdentifierISBN, "978-0-306-40615-7", "9780306406157"},
+		{IdentifierISBN, "ISBN 0-8044-2957-X", "9780804429573"},
+		{IdentifierISBN, "978-0-306-40615-7 (paperback)", "9780306406157"},
+		{IdentifierISBN, "0-306-40615-3", ""},
+		{IdentifierISBN, "978-0-306-40615-8", ""},
+		{IdentifierPMID, "17322060", "17322060"},
+		{IdentifierPMID, "PMID17322060", ""},
+		{IdentifierPMC, "pmc1234567", "PMC1234567"},
+		{IdentifierPMC, "1234567", "PMC1234567"},
+		{IdentifierArXiv, "arXiv:1706.03762v5", "1706.03762"},
+		{IdentifierArXiv, "hep-th/9711200", "hep-th/9711200"},
+		{IdentifierArXiv, "math.GT/0309136", "math.GT/0309136"},
+		{IdentifierArXiv, "not-an-id", ""},
+	}
+
+	for _, tt := range tests {
+		id, ok := NormalizeIdentifier(tt.kind, tt.value)
+		if tt.want == "" {
+			if ok {
+				t.Errorf("NormalizeIdentifier(%s, %q) = %q, want invalid", tt.kind, tt.value, id.Value)
+			}
+			continue
+		}
+		if !ok || id.Value != tt.want {
+			t.Errorf("NormalizeIdentifier(%s, %q) = %q, %v, want %q", tt.kind, tt.value, id.Value, ok, tt.want)
+		}
+	}
+}
+
+func TestExtractReferences(t *testing.T) {
+	text := `Lead text.<ref name="vaswani">{{cite arXiv |last1=Vaswani |first1=Ashish |last2=Shazeer |first2=Noam |title=Attention Is All You Need |eprint=1706.03762 |year=2017}}</ref>
+Reused.<ref name="vaswani" />
+Book.<ref>{{Cite book|author=Knuth, Donald|title=The Art of Computer Programming|publisher=Addison-Wesley|isbn=0-201-03801-3|year=1968}}</ref>
+Journal.<ref>{{cite journal |title=A study |journal=Nature |doi=10.1038/NATURE12373 |pmid=23903748 |pmc=PMC3745555 |url=https://doi.org/10.1038/nature12373}}</ref>
+Plain.<ref>Smith, J. ''Plain citation''. ISBN 978-0-306-40615-7. See [https://arxiv.org/abs/hep-th/9711200v3 the paper].</ref>
+
+== Further reading ==
+* {{cite web |title=Web page |url=https://example.org |website=Example}}
+{{reflist|refs=
+<ref name="ld">{{doi|10.1000/182}}</ref>
+}}`
+
+	refs := ExtractReferences(text)
+	if len(refs) != 6 {
+		t.Fatalf("got %d references, want 6: %+v", len(refs), refs)
+	}
+
+	want := []Reference{
+		{
+			Index: 1, Name: "vaswani", Template: "cite arxiv", Title: "Attention Is All You Need",
+			Text: "Vaswani, Ashish; Shazeer, Noam. Attention Is All You Need. 2017",
+			Identifiers: []Identifier{
+				{Type: IdentifierArXiv, Value: "1706.03762", URL: "https://arxiv.org/abs/1706.03762"},
+			},
+		},
+		{
+			Index: 2, Template: "cite book", Title: "The Art of Computer Programming",
+			Text: "Knuth, Donald. The Art of Computer Programming. Addison-Wesley. 1968",
+			Identifiers: []Identifier{
+				{Type: IdentifierISBN, Value: "9780201038019", URL: "https://openlibrary.org/isbn/9780201038019"},
+			},
+		},
+		{
+			Index: 3, Template: "cite journal", Title: "A study", URL: "https://doi.org/10.1038/nature12373",
+			Text: "A study. Nature",
+			Identifiers: []Identifier{
+				{Type: IdentifierDOI, Value: "10.1038/nature12373", URL: "https://doi.org/10.1038/nature12373"},
+				{Type: IdentifierPMID, Value: "23903748", URL: "https://pubmed.ncbi.nlm.nih.gov/23903748/"},
+				{Type: IdentifierPMC, Value: "PMC3745555", URL: "https://www.ncbi.nlm.nih.gov/pmc/articles/PMC3745555/"},
+			},
+		},
+		{
+			Index: 4,
+			Text:  "Smith, J. Plain citation. ISBN 978-0-306-40615-7. See the paper.",
+			Identifiers: []Identifier{
+				{Type: IdentifierISBN, Value: "9780306406157", URL: "https://openlibrary.org/isbn/9780306406157"},
+				{Type: IdentifierArXiv, Value: "hep-th/9711200", URL: "https://arxiv.org/abs/hep-th/9711200"},
+			},
+		},
+		{
+			Index: 5, Template: "cite web", Title: "Web page", URL: "https://example.org",
+			Text: "Web page. Example",
+		},
+		{
+			Index: 6, Name: "ld",
+			Text: "doi:10.1000/182",
+			Identifiers: []Identifier{
+				{Type: IdentifierDOI, Value: "10.1000/182", URL: "https://doi.org/10.1000/182"},
+			},
+		},
+	}
+
+	for i := range want {
+		if len(want[i].Identifiers) == 0 {
+			want[i].Identifiers = []Identifier{}
+		}
+		if !reflect.DeepEqual(refs[i], want[i]) {
+			t.Errorf("reference %d:\ngot:  %+v\nwant: %+v", i+1, refs[i], want[i])
+		}
+	}
+}
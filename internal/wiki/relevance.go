@@ -0,0 +1,99 @@
+package wiki
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// BM25 parameters: term frequency saturation and length normalization
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// relevanceHeadingWeight is how many times a heading's words count relative
+// to the words of the text under it
+const relevanceHeadingWeight = 3
+
+// RelevanceDoc is a stretch of a page scored against a query: a heading
+// and the text that opens its section
+type RelevanceDoc struct {
+	Heading string
+	Text    string
+}
+
+// ScoreRelevance scores each document against a query with BM25, heading
+// words weighted above text words, and scales the scores so the best match
+// is 1. Scores are 0 when nothing matches. Words are compared lowercased
+// with plural s dropped.
+func ScoreRelevance(query string, docs []RelevanceDoc) []float64 {
+	scores := make([]float64, len(docs))
+	terms := relevanceTerms(query)
+	if len(terms) == 0 || len(docs) == 0 {
+		return scores
+	}
+
+	// Term frequencies per document, and document frequencies per term
+	freqs := make([]map[string]int, len(docs))
+	lengths := make([]int, len(docs))
+	docFreq := make(map[string]int)
+	total := 0
+	for i, doc := range docs {
+		freqs[i] = make(map[string]int)
+		for _, word := range relevanceTerms(doc.Heading) {
+			freqs[i][word] += relevanceHeadingWeight
+			lengths[i] += relevanceHeadingWeight
+		}
+		for _, word := range relevanceTerms(doc.Text) {
+			freqs[i][word]++
+			lengths[i]++
+		}
+		for word := range freqs[i] {
+			docFreq[word]++
+		}
+		total += lengths[i]
+	}
+	avgLength := max(float64(total)/float64(len(docs)), 1)
+
+	seen := make(map[string]bool)
+	best := 0.0
+	for _, term := range terms {
+		if seen[term] || docFreq[term] == 0 {
+			continue
+		}
+		seen[term] = true
+		n := float64(docFreq[term])
+		idf := math.Log(1 + (float64(len(docs))-n+0.5)/(n+0.5))
+		for i := range docs {
+			tf := float64(freqs[i][term])
+			if tf == 0 {
+				continue
+			}
+			norm := bm25K1 * (1 - bm25B + bm25B*float64(lengths[i])/avgLength)
+			scores[i] += idf * tf * (bm25K1 + 1) / (tf + norm)
+			best = max(best, scores[i])
+		}
+	}
+
+	if best > 0 {
+		for i := range scores {
+			scores[i] = math.Round(scores[i]/best*1000) / 1000
+		}
+	}
+	return scores
+}
+
+// relevanceTerms splits text into lowercase words, dropping a plural s so
+// "battles" matches "battle"
+func relevanceTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		if len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+			words[i] = word[:len(word)-1]
+		}
+	}
+	return words
+}
@@ -0,0 +1,29 @@
+package wiki
+
+import "testing"
+
+func TestScoreRelevance(t *testing.T) {
+	docs := []RelevanceDoc{
+		{Heading: "Early life", Text: "Born in a small town, she studied music."},
+		{Heading: "Battles", Text: "The siege lasted a year before the battle of the river."},
+		{Heading: "Legacy", Text: "Historians still debate the battle."},
+		{Heading: "References"},
+	}
+
+	scores := ScoreRelevance("Which battle?", docs)
+	if scores[1] != 1 {
+		t.Errorf("heading match scored %v, want 1", scores[1])
+	}
+	if scores[2] <= 0 || scores[2] >= scores[1] {
+		t.Errorf("text match scored %v, want between 0 and %v", scores[2], scores[1])
+	}
+	if scores[0] != 0 || scores[3] != 0 {
+		t.Errorf("unrelated sections scored %v and %v, want 0", scores[0], scores[3])
+	}
+
+	for i, score := range ScoreRelevance("spaceflight", docs) {
+		if score != 0 {
+			t.Errorf("doc %d scored %v for an unmatched query, want 0", i, score)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package wiki
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// wikimediaDomains lists the host suffixes served by the Wikimedia REST API
+var wikimediaDomains = []string{
+	"wikipedia.org",
+	"wikimedia.org",
+	"wiktionary.org",
+	"wikibooks.org",
+	"wikiquote.org",
+	"wikisource.org",
+	"wikinews.org",
+	"wikiversity.org",
+	"wikivoyage.org",
+}
+
+// IsWikimediaHost reports whether a wiki URL points at a Wikimedia project
+// that exposes the REST API under /api/rest_v1
+func IsWikimediaHost(wikiURL string) bool {
+	u, err := url.Parse(wikiURL)
+	if err != nil {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, domain := range wikimediaDomains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// MakeRESTRequest makes an HTTP GET request to the Wikimedia REST API and
+// decodes the JSON response into out
+func (c *Client) MakeRESTRequest(ctx context.Context, wikiURL, path string, out interface{}) error {
+	// Apply rate limiting (shared with the action API)
+	if err := c.waitTurn(ctx, wikiURL); err != nil {
+		return err
+	}
+
+	c.checkUserAgent(wikiURL)
+
+	fullURL := strings.TrimSuffix(wikiURL, "/") + "/api/rest_v1" + path
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fullURL, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set("Accept", "application/json")
+	c.authorize(req, wikiURL)
+	if lang := LanguageFromContext(ctx); lang != "" {
+		req.Header.Set("Accept-Language", lang)
+	}
+
+	countUpstreamCall(ctx)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("http request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("rest api %s: %w", path, &httpStatusError{StatusCode: resp.StatusCode, Body: http.StatusText(resp.StatusCode)})
+	}
+
+	reader, err := c.responseReader(wikiURL, resp)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	if err := json.NewDecoder(reader).Decode(out); err != nil {
+		return fmt.Errorf("decode rest response: %w", err)
+	}
+
+	return nil
+}
+
+// GetRESTSummary fetches the pre-cleaned page summary (page/summary endpoint)
+func (c *Client) GetRESTSummary(ctx context.Context, wikiURL, title string) (*RESTSummary, error) {
+	var summary RESTSummary
+	if err := c.MakeRESTRequest(ctx, wikiURL, "/page/summary/"+restTitle(title), &summary); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// ErrNoMobileSections is returned by GetRESTMobileSections for a wiki known
+// not to serve the endpoint
+var ErrNoMobileSections = errors.New("wiki doesn't serve page/mobile-sections")
+
+// GetRESTMobileSections fetches sectioned page HTML (page/mobile-sections
+// endpoint). Wikimedia has retired the endpoint, so once a wiki answers it
+// with a client error, it isn't asked again until its capabilities expire.
+func (c *Client) GetRESTMobileSections(ctx context.Context, wikiURL, title string) (*RESTMobileSections, error) {
+	if !c.servesMobileSections(wikiURL) {
+		return nil, ErrNoMobileSections
+	}
+	var sections RESTMobileSections
+	if err := c.MakeRESTRequest(ctx, wikiURL, "/page/mobile-sections/"+restTitle(title), &sections); err != nil {
+		var statusErr *httpStatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode >= 400 && statusErr.StatusCode < 500 &&
+			statusErr.StatusCode != http.StatusTooManyRequests {
+			c.rememberNoMobileSections(wikiURL)
+		}
+		return nil, err
+	}
+	return &sections, nil
+}
+
+// restTitle encodes a page title for use as a REST API path segment
+func restTitle(title string) string {
+	return url.PathEscape(strings.ReplaceAll(title, " ", "_"))
+}
+
+// RESTSummary is the page/summary response from the Wikimedia REST API
+type RESTSummary struct {
+	Type        string         `json:"type"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Extract     string         `json:"extract"`
+	ExtractHTML string         `json:"extract_html"`
+	Thumbnail   *RESTThumbnail `json:"thumbnail"`
+	Revision    string         `json:"revision"`
+}
+
+// RESTThumbnail is a thumbnail image reference from the REST API
+type RESTThumbnail struct {
+	Source string `json:"source"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// RESTMobileSections is the page/mobile-sections response from the REST API
+type RESTMobileSections struct {
+	Lead struct {
+		DisplayTitle string              `json:"displaytitle"`
+		Revision     string              `json:"revision"`
+		Sections     []RESTMobileSection `json:"sections"`
+	} `json:"lead"`
+	Remaining struct {
+		Sections []RESTMobileSection `json:"sections"`
+	} `json:"remaining"`
+}
+
+// RESTMobileSection is a single section of a mobile-sections response
+type RESTMobileSection struct {
+	ID       int    `json:"id"`
+	TocLevel int    `json:"toclevel"`
+	Line     string `json:"line"`
+	Anchor   string `json:"anchor"`
+	Text     string `json:"text"`
+}
+
+// AllSections returns the lead and remaining sections in document order
+func (m *RESTMobileSections) AllSections() []RESTMobileSection {
+	all := make([]RESTMobileSection, 0, len(m.Lead.Sections)+len(m.Remaining.Sections))
+	all = append(all, m.Lead.Sections...)
+	all = append(all, m.Remaining.Sections...)
+	return all
+}
+
+// SectionHTML returns the HTML of a section including its subsections,
+// matching the semantics of action=parse&section=N
+func (m *RESTMobileSections) SectionHTML(index int) (string, bool) {
+	all := m.AllSections()
+	for i, sec := range all {
+		if sec.ID != index {
+			continue
+		}
+
+		var b strings.Builder
+		b.WriteString(sec.Text)
+		if index == 0 {
+			return b.String(), true
+		}
+
+		for _, sub := range all[i+1:] {
+			if sub.TocLevel <= sec.TocLevel {
+				break
+			}
+			level := sub.TocLevel + 1
+			if level > 6 {
+				level = 6
+			}
+			fmt.Fprintf(&b, "\n<h%d>%s</h%d>\n%s", level, sub.Line, level, sub.Text)
+		}
+		return b.String(), true
+	}
+	return "", false
+}
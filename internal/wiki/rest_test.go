@@ -0,0 +1,48 @@
+package wiki
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetRESTMobileSectionsRemembersRetiredEndpoint(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		wantAsked  int // requests made by two calls
+		wantMarked bool
+	}{
+		{"retired", http.StatusNotFound, 1, true},
+		{"forbidden", http.StatusForbidden, 1, true},
+		{"rate limited", http.StatusTooManyRequests, 2, false},
+		{"server error", http.StatusServiceUnavailable, 2, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asked := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				asked++
+				http.Error(w, "unavailable", tt.status)
+			}))
+			defer srv.Close()
+
+			client := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+			for range 2 {
+				if _, err := client.GetRESTMobileSections(context.Background(), srv.URL, "Town"); err == nil {
+					t.Fatal("GetRESTMobileSections succeeded, want an error")
+				}
+			}
+			if asked != tt.wantAsked {
+				t.Errorf("got %d requests, want %d", asked, tt.wantAsked)
+			}
+			_, err := client.GetRESTMobileSections(context.Background(), srv.URL, "Other page")
+			if marked := errors.Is(err, ErrNoMobileSections); marked != tt.wantMarked {
+				t.Errorf("error %v, want ErrNoMobileSections %v", err, tt.wantMarked)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package wiki
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// RevisionDetails describes a revision, as reverts and edit conflicts
+// report it
+type RevisionDetails struct {
+	ID        int       `json:"id"`
+	ParentID  int       `json:"parent_id"`
+	Title     string    `json:"title"`
+	Timestamp time.Time `json:"timestamp"`
+	User      string    `json:"user"`
+	Comment   string    `json:"comment,omitempty"`
+	Size      int       `json:"size"`
+	DiffURL   string    `json:"diff_url"` // the change the revision made
+}
+
+// GetRevisions returns the details of revisions, in the order given. A
+// revision that doesn't exist, or was deleted, fails with nosuchrevid.
+func (c *Client) GetRevisions(ctx context.Context, wikiURL string, revIDs ...int) ([]RevisionDetails, error) {
+	ids := make([]string, len(revIDs))
+	for i, id := range revIDs {
+		ids[i] = strconv.Itoa(id)
+	}
+
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("revids", strings.Join(ids, "|"))
+	params.Set("prop", "revisions")
+	params.Set("rvprop", "ids|timestamp|user|comment|size")
+
+	resp, err := c.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("get revisions: %w", err)
+	}
+
+	found := make(map[int]RevisionDetails, len(revIDs))
+	if resp.Query != nil {
+		for _, page := range resp.Query.Pages {
+			for _, rev := range page.Revisions {
+				found[rev.RevID] = RevisionDetails{
+					ID:        rev.RevID,
+					ParentID:  rev.ParentID,
+					Title:     page.Title,
+					Timestamp: rev.Timestamp,
+					User:      rev.User,
+					Comment:   rev.Comment,
+					Size:      rev.Size,
+				}
+			}
+		}
+	}
+
+	revisions := make([]RevisionDetails, len(revIDs))
+	for i, id := range revIDs {
+		rev, ok := found[id]
+		if !ok {
+			return nil, &APIError{Code: "nosuchrevid", Message: fmt.Sprintf("revision %d does not exist", id)}
+		}
+		rev.DiffURL = c.DiffURL(ctx, wikiURL, rev.ParentID, rev.ID)
+		revisions[i] = rev
+	}
+	return revisions, nil
+}
+
+// LatestRevision returns the details of a page's current revision
+func (c *Client) LatestRevision(ctx context.Context, wikiURL, title string) (*RevisionDetails, error) {
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("titles", title)
+	params.Set("prop", "revisions")
+	params.Set("rvprop", "ids|timestamp|user|comment|size")
+	params.Set("rvlimit", "1")
+
+	resp, err := c.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("get latest revision: %w", err)
+	}
+	if resp.Query == nil || len(resp.Query.Pages) == 0 {
+		return nil, fmt.Errorf("no pages found")
+	}
+
+	page := resp.Query.Pages[0]
+	if page.Missing || len(page.Revisions) == 0 {
+		return nil, &APIError{Code: "missingtitle", Message: fmt.Sprintf("page %q does not exist", title)}
+	}
+	rev := page.Revisions[0]
+	return &RevisionDetails{
+		ID:        rev.RevID,
+		ParentID:  rev.ParentID,
+		Title:     page.Title,
+		Timestamp: rev.Timestamp,
+		User:      rev.User,
+		Comment:   rev.Comment,
+		Size:      rev.Size,
+		DiffURL:   c.DiffURL(ctx, wikiURL, rev.ParentID, rev.RevID),
+	}, nil
+}
+
+// RollbackResult is the outcome of a rollback
+type RollbackResult struct {
+	Title     string `json:"title"`
+	PageID    int    `json:"page_id"`
+	Summary   string `json:"summary"`
+	RevID     int    `json:"revid"`      // the rollback's own revision
+	OldRevID  int    `json:"old_revid"`  // the latest revision before the rollback
+	LastRevID int    `json:"last_revid"` // the revision restored
+	DiffURL   string `json:"diff_url"`
+
+	Reverted *RevisionDetails `json:"reverted,omitempty"` // details of OldRevID
+	Restored *RevisionDetails `json:"restored,omitempty"` // details of LastRevID
+}
+
+// Rollback reverts the latest consecutive edits of user to a page with
+// action=rollback, restoring the revision before them, and invalidates the
+// page's cached content. It takes a rollback token instead of the CSRF
+// token, and the account needs the rollback right, which callers should
+// check first with RequireRight. An empty summary leaves the wiki's own.
+// The result has the details of the reverted and restored revisions.
+func (c *Client) Rollback(ctx context.Context, wikiURL, title, user, summary string, markBot bool) (*RollbackResult, error) {
+	if b := c.backend(wikiURL); b != nil {
+		return nil, &BackendUnsupportedError{Backend: b.Name(), WikiURL: wikiURL, Request: "rollback"}
+	}
+
+	params := url.Values{}
+	params.Set("action", "rollback")
+	params.Set("title", title)
+	params.Set("user", user)
+	if summary != "" {
+		params.Set("summary", summary)
+	}
+	if markBot {
+		params.Set("markbot", "1")
+	}
+
+	resp, err := c.writeRequestWith(ctx, wikiURL, params, writeOptions{tokenType: "rollback"})
+	if err != nil {
+		return nil, fmt.Errorf("roll back: %w", err)
+	}
+	if resp.Rollback == nil {
+		return nil, fmt.Errorf("empty rollback response")
+	}
+
+	c.cache.InvalidatePage(wikiURL, resp.Rollback.Title)
+
+	result := &RollbackResult{
+		Title:     resp.Rollback.Title,
+		PageID:    resp.Rollback.PageID,
+		Summary:   resp.Rollback.Summary,
+		RevID:     resp.Rollback.RevID,
+		OldRevID:  resp.Rollback.OldRevID,
+		LastRevID: resp.Rollback.LastRevID,
+		DiffURL:   c.DiffURL(ctx, wikiURL, resp.Rollback.OldRevID, resp.Rollback.RevID),
+	}
+	// The rollback is done; the details are left out if they can't be had
+	if result.OldRevID > 0 && result.LastRevID > 0 {
+		if revisions, err := c.GetRevisions(ctx, wikiURL, result.OldRevID, result.LastRevID); err == nil {
+			result.Reverted, result.Restored = &revisions[0], &revisions[1]
+		}
+	}
+	return result, nil
+}
+
+// RollbackTarget finds what a rollback of user's edits to a page would do,
+// without making it: the latest revision, which it reverts, and the last
+// revision by someone else, which it restores. It fails as the rollback
+// would, with alreadyrolled when someone else edited the page last and with
+// onlyauthor when user wrote every revision.
+func (c *Client) RollbackTarget(ctx context.Context, wikiURL, title, user string) (reverted, restored *RevisionDetails, err error) {
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("titles", title)
+	params.Set("prop", "revisions")
+	params.Set("rvprop", "ids|timestamp|user|comment|size")
+	params.Set("rvlimit", "max")
+
+	resp, err := c.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get page history: %w", err)
+	}
+	if resp.Query == nil || len(resp.Query.Pages) == 0 {
+		return nil, nil, fmt.Errorf("no pages found")
+	}
+
+	page := resp.Query.Pages[0]
+	if page.Missing || len(page.Revisions) == 0 {
+		return nil, nil, &APIError{Code: "missingtitle", Message: fmt.Sprintf("page %q does not exist", title)}
+	}
+	details := func(rev mwRevision) *RevisionDetails {
+		return &RevisionDetails{
+			ID:        rev.RevID,
+			ParentID:  rev.ParentID,
+			Title:     page.Title,
+			Timestamp: rev.Timestamp,
+			User:      rev.User,
+			Comment:   rev.Comment,
+			Size:      rev.Size,
+			DiffURL:   c.DiffURL(ctx, wikiURL, rev.ParentID, rev.RevID),
+		}
+	}
+
+	// User names compare as the wiki stores them, with spaces and an
+	// uppercase first letter
+	normalize := func(name string) string {
+		name = strings.ReplaceAll(strings.TrimSpace(name), "_", " ")
+		if name == "" {
+			return name
+		}
+		first, size := utf8.DecodeRuneInString(name)
+		return string(unicode.ToUpper(first)) + name[size:]
+	}
+	user = normalize(user)
+
+	latest := page.Revisions[0]
+	if normalize(latest.User) != user {
+		return nil, nil, &APIError{Code: "alreadyrolled", Message: fmt.Sprintf("the latest edit to %q is by %s, not %s", page.Title, latest.User, user)}
+	}
+	for _, rev := range page.Revisions[1:] {
+		if normalize(rev.User) != user {
+			return details(latest), details(rev), nil
+		}
+	}
+	if len(resp.Continue) > 0 {
+		return nil, nil, fmt.Errorf("the latest %d edits to %q are all by %s; the revision a rollback restores is further back", len(page.Revisions), page.Title, user)
+	}
+	return nil, nil, &APIError{Code: "onlyauthor", Message: fmt.Sprintf("%s is the only author of %q", user, page.Title)}
+}
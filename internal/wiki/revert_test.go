@@ -0,0 +1,94 @@
+package wiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRollback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch {
+		case r.Form.Get("meta") == "tokens":
+			fmt.Fprintf(w, `{"query":{"tokens":{"%stoken":"%s-token"}}}`, r.Form.Get("type"), r.Form.Get("type"))
+		case r.Form.Get("action") == "rollback":
+			if r.Form.Get("token") != "rollback-token" || r.Form.Get("user") != "Vandal" {
+				t.Errorf("rollback token = %q, user = %q", r.Form.Get("token"), r.Form.Get("user"))
+			}
+			fmt.Fprint(w, `{"rollback":{"title":"Town","pageid":1,"summary":"Reverted","revid":12,"old_revid":11,"last_revid":9}}`)
+		case r.Form.Get("revids") == "11|9":
+			fmt.Fprint(w, `{"query":{"pages":[{"pageid":1,"ns":0,"title":"Town","revisions":[
+				{"revid":11,"parentid":10,"timestamp":"2026-01-02T00:00:00Z","user":"Vandal","comment":"lol","size":10},
+				{"revid":9,"parentid":8,"timestamp":"2026-01-01T00:00:00Z","user":"Editor","comment":"fix","size":900}]}]}}`)
+		case r.Form.Get("revids") != "":
+			fmt.Fprint(w, `{"query":{"badrevids":{"404":{"revid":404}}}}`)
+		case r.Form.Get("action") == "edit":
+			if r.Form.Get("undo") != "11" || r.Form.Get("undoafter") != "10" || r.Form.Has("text") || r.Form.Get("token") != "csrf-token" {
+				t.Errorf("undo edit = %v", r.Form)
+			}
+			fmt.Fprint(w, `{"edit":{"result":"Success","pageid":1,"title":"Town","oldrevid":12,"newrevid":13}}`)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	client.SetAuth(srv.URL, Auth{AccessToken: "oauth"})
+	ctx := context.Background()
+
+	result, err := client.Rollback(ctx, srv.URL, "Town", "Vandal", "Reverted", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RevID != 12 || result.Reverted == nil || result.Reverted.User != "Vandal" || result.Restored == nil || result.Restored.User != "Editor" {
+		t.Errorf("result = %+v, want revision 11 by Vandal reverted to 9 by Editor", result)
+	}
+
+	// The CSRF token is fetched separately from the rollback token
+	if _, err := client.Edit(ctx, srv.URL, EditParams{Title: "Town", Summary: "Undo", Undo: 11, UndoAfter: 10}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.GetRevisions(ctx, srv.URL, 404)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "nosuchrevid" {
+		t.Errorf("GetRevisions(404) = %v, want nosuchrevid", err)
+	}
+}
+
+func TestRollbackTarget(t *testing.T) {
+	history := map[string]string{
+		"Town":   `[{"revid":12,"parentid":11,"user":"Vandal"},{"revid":11,"parentid":9,"user":"Vandal"},{"revid":9,"parentid":8,"user":"Editor"}]`,
+		"Hamlet": `[{"revid":5,"parentid":4,"user":"Editor"}]`,
+		"Stub":   `[{"revid":2,"parentid":1,"user":"Vandal"},{"revid":1,"parentid":0,"user":"Vandal"}]`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		title := r.URL.Query().Get("titles")
+		fmt.Fprintf(w, `{"query":{"pages":[{"pageid":1,"ns":0,"title":%q,"revisions":%s}]}}`, title, history[title])
+	}))
+	defer srv.Close()
+	client := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	ctx := context.Background()
+
+	reverted, restored, err := client.RollbackTarget(ctx, srv.URL, "Town", "vandal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reverted.ID != 12 || restored.ID != 9 || restored.User != "Editor" {
+		t.Errorf("RollbackTarget = %+v, %+v, want 12 reverted to 9", reverted, restored)
+	}
+
+	for title, code := range map[string]string{"Hamlet": "alreadyrolled", "Stub": "onlyauthor"} {
+		_, _, err := client.RollbackTarget(ctx, srv.URL, title, "Vandal")
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != code {
+			t.Errorf("RollbackTarget(%s) = %v, want %s", title, err, code)
+		}
+	}
+}
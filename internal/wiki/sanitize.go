@@ -0,0 +1,132 @@
+package wiki
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// activeElements are removed with everything inside them: they run code,
+// embed other documents, or take input, and none of it is article text.
+// Several are parsed as raw text, so their content would otherwise reach
+// the Markdown as literal tags.
+var activeElements = map[string]bool{
+	"script": true, "style": true, "link": true, "meta": true, "base": true,
+	"iframe": true, "frame": true, "frameset": true, "noframes": true,
+	"object": true, "embed": true, "applet": true, "noembed": true, "param": true,
+	"noscript": true, "template": true, "svg": true, "canvas": true,
+	"audio": true, "video": true, "source": true, "track": true,
+	"form": true, "input": true, "button": true, "select": true, "textarea": true,
+}
+
+// allowedElements are the elements kept as markup. Other elements are
+// replaced by their children, which is all the converter would make of
+// them anyway.
+var allowedElements = map[string]bool{
+	"html": true, "head": true, "body": true,
+	"p": true, "div": true, "span": true, "br": true, "hr": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"ul": true, "ol": true, "li": true, "dl": true, "dt": true, "dd": true,
+	"a": true, "img": true, "figure": true, "figcaption": true,
+	"b": true, "strong": true, "i": true, "em": true, "u": true, "s": true,
+	"del": true, "ins": true, "small": true, "big": true, "mark": true, "blockquote": true,
+	"sup": true, "sub": true, "abbr": true, "cite": true, "q": true, "dfn": true,
+	"code": true, "kbd": true, "samp": true, "tt": true, "var": true, "pre": true,
+	"table": true, "caption": true, "thead": true, "tbody": true, "tfoot": true,
+	"tr": true, "th": true, "td": true,
+	"section": true, "article": true, "aside": true, "header": true, "footer": true,
+}
+
+// codeElements are converted to code spans and blocks, whose text is shown
+// as written rather than read as markup
+var codeElements = map[string]bool{"code": true, "kbd": true, "samp": true, "tt": true, "pre": true}
+
+// allowedAttributes are the attributes conversion and word counting read;
+// event handlers, styles, and the rest are dropped
+var allowedAttributes = map[string]bool{
+	"href": true, "src": true, "alt": true, "title": true, "class": true,
+	"id": true, "lang": true, "dir": true, "colspan": true, "rowspan": true,
+}
+
+// urlAttributes hold URLs, which must be relative or use a safe scheme
+var urlAttributes = map[string]bool{"href": true, "src": true}
+
+// safeSchemes are the URL schemes kept in links and images: those MediaWiki
+// links to by default, without javascript: or data:
+var safeSchemes = map[string]bool{
+	"http": true, "https": true, "ftp": true, "mailto": true,
+	"irc": true, "ircs": true, "news": true, "tel": true,
+}
+
+// tagLike matches a < that Markdown renderers would read as the start of a
+// tag, comment, or processing instruction
+var tagLike = regexp.MustCompile(`<([A-Za-z/!?])`)
+
+// sanitizeHTML reduces the tree below n to markup that is safe to pass on
+// as Markdown, since some clients render Markdown as HTML. Active content
+// is removed, elements outside allowedElements are replaced by their
+// children, attributes outside allowedAttributes are dropped, and URLs with
+// other schemes are removed. Text that would read as a tag, as in an
+// article about HTML, is escaped everywhere but code.
+func sanitizeHTML(n *html.Node, inCode bool) {
+	for child := n.FirstChild; child != nil; {
+		next := child.NextSibling
+		switch child.Type {
+		case html.TextNode:
+			if !inCode && strings.IndexByte(child.Data, '<') >= 0 {
+				child.Data = tagLike.ReplaceAllString(child.Data, "&lt;$1")
+			}
+		case html.CommentNode, html.DoctypeNode:
+			n.RemoveChild(child)
+		case html.ElementNode:
+			name := strings.ToLower(child.Data)
+			if activeElements[name] {
+				n.RemoveChild(child)
+				break
+			}
+			sanitizeHTML(child, inCode || codeElements[name])
+			if !allowedElements[name] || child.Namespace != "" {
+				// The children are already sanitized; move them up
+				// in place of the element, and carry on after them
+				for grandchild := child.FirstChild; grandchild != nil; grandchild = child.FirstChild {
+					child.RemoveChild(grandchild)
+					n.InsertBefore(grandchild, child)
+				}
+				n.RemoveChild(child)
+				break
+			}
+			child.Attr = sanitizeAttributes(child.Attr)
+		}
+		child = next
+	}
+}
+
+// sanitizeAttributes returns the allowed attributes of attrs, without URLs
+// that are neither relative nor use one of safeSchemes
+func sanitizeAttributes(attrs []html.Attribute) []html.Attribute {
+	kept := attrs[:0]
+	for _, attr := range attrs {
+		key := strings.ToLower(attr.Key)
+		if attr.Namespace != "" || !allowedAttributes[key] {
+			continue
+		}
+		if urlAttributes[key] && !safeURL(attr.Val) {
+			continue
+		}
+		kept = append(kept, attr)
+	}
+	return kept
+}
+
+// safeURL reports whether raw is relative or uses one of safeSchemes.
+// URLs that don't parse, such as those with control characters hiding a
+// scheme, are unsafe.
+func safeURL(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "" || safeSchemes[strings.ToLower(u.Scheme)]
+}
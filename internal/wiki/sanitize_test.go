@@ -0,0 +1,87 @@
+package wiki
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestConvertHTMLSanitizes(t *testing.T) {
+	tests := []struct {
+		name    string
+		html    string
+		want    string
+		notWant []string
+	}{
+		{
+			name:    "embedded document",
+			html:    `<p>a<iframe src="https://example.org"><script>alert(1)</script></iframe>b</p>`,
+			want:    "ab",
+			notWant: []string{"script", "example.org"},
+		},
+		{
+			name:    "raw text element",
+			html:    `<p>a<xmp><img src=x onerror=alert(1)></xmp>b</p>`,
+			want:    "&lt;img src=x onerror=alert(1)>",
+			notWant: []string{"<img"},
+		},
+		{
+			name:    "unknown element and event handler",
+			html:    `<div onclick="alert(1)">a <blink onmouseover="alert(1)">b</blink></div>`,
+			want:    "a b",
+			notWant: []string{"alert", "blink"},
+		},
+		{
+			name:    "unsafe URLs",
+			html:    `<p><a href="javascript:alert(1)">a</a><a href=" JavaScript:alert(1)">b</a><img src="data:image/svg+xml,x" alt="c"><a href="/wiki/Go">Go</a></p>`,
+			want:    "[Go](/wiki/Go)",
+			notWant: []string{"javascript", "JavaScript", "data:"},
+		},
+		{
+			name:    "escaped tags in text",
+			html:    `<p>Write &lt;br&gt; for a break, if a &lt; b</p>`,
+			want:    "Write &lt;br> for a break, if a < b",
+			notWant: []string{"<br"},
+		},
+		{
+			name: "escaped tags in code",
+			html: `<pre>&lt;script&gt;&lt;/script&gt;</pre><p><code>&lt;br&gt;</code></p>`,
+			want: "```\n<script></script>\n```\n\n`<br>`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markdown, err := HTMLToMarkdown(context.Background(), tt.html)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(markdown, tt.want) {
+				t.Errorf("markdown = %q, want it to contain %q", markdown, tt.want)
+			}
+			for _, bad := range tt.notWant {
+				if strings.Contains(markdown, bad) {
+					t.Errorf("markdown = %q, contains %q", markdown, bad)
+				}
+			}
+		})
+	}
+}
+
+func TestSafeURL(t *testing.T) {
+	for raw, want := range map[string]bool{
+		"/wiki/Go":                true,
+		"//upload.example.org/x":  true,
+		"#History":                true,
+		"https://example.org":     true,
+		"mailto:info@example.org": true,
+		"javascript:alert(1)":     false,
+		"JAVASCRIPT:alert(1)":     false,
+		"java\tscript:alert(1)":   false,
+		"data:text/html,<p>x</p>": false,
+		"vbscript:msgbox(1)":      false,
+	} {
+		if got := safeURL(raw); got != want {
+			t.Errorf("safeURL(%q) = %v, want %v", raw, got, want)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package wiki
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// DefaultInteractiveShare is the share of each wiki's rate limit reserved
+// for interactive calls unless SetInteractiveShare changes it
+const DefaultInteractiveShare = 0.5
+
+// maxInteractiveShare keeps some capacity for background work
+const maxInteractiveShare = 0.9
+
+type backgroundKey struct{}
+
+// WithBackground marks a context's API requests as background work, such
+// as jobs and page watching, which yields to interactive calls
+func WithBackground(ctx context.Context) context.Context {
+	return context.WithValue(ctx, backgroundKey{}, true)
+}
+
+// IsBackground reports whether a context is marked as background work
+func IsBackground(ctx context.Context) bool {
+	background, _ := ctx.Value(backgroundKey{}).(bool)
+	return background
+}
+
+// domainScheduler spaces out requests to one wiki at its rate limit in two
+// tiers. Interactive requests book the next free slot, queueing only behind
+// each other and at most one background request. Background requests only
+// take a slot when none is booked, and are capped at the rest of the limit
+// after the interactive share, so interactive calls always find capacity.
+type domainScheduler struct {
+	mu       sync.Mutex
+	interval time.Duration // between requests; 0 = unlimited
+	next     time.Time     // earliest time the next request may be sent
+
+	background *rate.Limiter // cap on background requests; nil = none
+}
+
+func newDomainScheduler(limit rate.Limit, interactiveShare float64) *domainScheduler {
+	d := &domainScheduler{}
+	if limit <= 0 || limit == rate.Inf {
+		return d
+	}
+	d.interval = time.Duration(float64(time.Second) / float64(limit))
+	if interactiveShare > 0 {
+		d.background = rate.NewLimiter(limit*rate.Limit(1-interactiveShare), 1)
+	}
+	return d
+}
+
+// wait blocks until a request may be sent, giving interactive requests
+// priority over background ones
+func (d *domainScheduler) wait(ctx context.Context, background bool) error {
+	if d.interval == 0 {
+		return nil
+	}
+	if background {
+		return d.waitBackground(ctx)
+	}
+
+	d.mu.Lock()
+	now := time.Now()
+	slot := d.next
+	if slot.Before(now) {
+		slot = now
+	}
+	d.next = slot.Add(d.interval)
+	d.mu.Unlock()
+
+	if err := sleepUntil(ctx, slot); err != nil {
+		d.release(slot)
+		return err
+	}
+	return nil
+}
+
+// release gives back a slot booked by a request that was cancelled before
+// its turn, unless later requests have been booked behind it
+func (d *domainScheduler) release(slot time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.next.Equal(slot.Add(d.interval)) {
+		d.next = slot
+	}
+}
+
+func (d *domainScheduler) waitBackground(ctx context.Context) error {
+	if d.background != nil {
+		if err := d.background.Wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	for {
+		d.mu.Lock()
+		now := time.Now()
+		if !d.next.After(now) {
+			d.next = now.Add(d.interval)
+			d.mu.Unlock()
+			return nil
+		}
+		slot := d.next
+		d.mu.Unlock()
+
+		// Retry once the booked slots have passed; interactive requests
+		// booked meanwhile go first
+		if err := sleepUntil(ctx, slot); err != nil {
+			return err
+		}
+	}
+}
+
+// sleepUntil waits for t or until ctx is done
+func sleepUntil(ctx context.Context, t time.Time) error {
+	d := time.Until(t)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// waitTurn blocks until a request to wikiURL may be sent: first on the
+// context's job budget, if any, then on the wiki's scheduler
+func (c *Client) waitTurn(ctx context.Context, wikiURL string) error {
+	if err := waitBudget(ctx); err != nil {
+		return err
+	}
+	if err := c.getScheduler(wikiURL).wait(ctx, IsBackground(ctx)); err != nil {
+		return fmt.Errorf("rate limit wait: %w", err)
+	}
+	return nil
+}
+
+// getScheduler returns the request scheduler for a wiki domain
+func (c *Client) getScheduler(wikiURL string) *domainScheduler {
+	c.schedulerMu.RLock()
+	scheduler, exists := c.schedulers[wikiURL]
+	c.schedulerMu.RUnlock()
+
+	if exists {
+		return scheduler
+	}
+
+	c.schedulerMu.Lock()
+	defer c.schedulerMu.Unlock()
+
+	// Double-check after acquiring write lock
+	if scheduler, exists := c.schedulers[wikiURL]; exists {
+		return scheduler
+	}
+
+	scheduler = newDomainScheduler(c.rateLimit, c.interactiveShare)
+	c.schedulers[wikiURL] = scheduler
+	return scheduler
+}
+
+// SetInteractiveShare sets the share of each wiki's rate limit reserved for
+// interactive calls, from 0 to 0.9. Background requests are limited to the
+// rest; 0 lets them use any idle capacity.
+func (c *Client) SetInteractiveShare(share float64) {
+	c.schedulerMu.Lock()
+	defer c.schedulerMu.Unlock()
+	c.interactiveShare = min(max(share, 0), maxInteractiveShare)
+	c.schedulers = make(map[string]*domainScheduler)
+}
@@ -0,0 +1,84 @@
+package wiki
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSchedulerInteractiveFirst(t *testing.T) {
+	d := newDomainScheduler(100, 0) // one request per 10ms
+	ctx := context.Background()
+
+	// Slots are booked for the next 30ms
+	d.next = time.Now().Add(30 * time.Millisecond)
+
+	done := make(chan time.Time)
+	go func() {
+		if err := d.wait(WithBackground(ctx), true); err != nil {
+			t.Error(err)
+		}
+		done <- time.Now()
+	}()
+
+	// An interactive call arriving while the background request waits
+	// still goes first
+	time.Sleep(5 * time.Millisecond)
+	if err := d.wait(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	interactive := time.Now()
+	if background := <-done; !background.After(interactive) {
+		t.Errorf("background request sent %s before the interactive one", interactive.Sub(background))
+	}
+}
+
+func TestSchedulerBackgroundShare(t *testing.T) {
+	d := newDomainScheduler(100, 0.5)
+	ctx := WithBackground(context.Background())
+
+	// Background requests get half of 100/s: 6 requests take at least 100ms
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if err := d.wait(ctx, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("6 background requests took %s, want at least 100ms", elapsed)
+	}
+
+	unlimited := newDomainScheduler(0, 0.5)
+	start = time.Now()
+	for i := 0; i < 100; i++ {
+		unlimited.wait(ctx, true)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("unlimited scheduler waited %s", elapsed)
+	}
+}
+
+func TestSchedulerCancelReleasesSlot(t *testing.T) {
+	d := newDomainScheduler(20, 0) // one request per 50ms
+	ctx := context.Background()
+
+	// The first request goes at once and books the next slot 50ms away
+	if err := d.wait(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	cancelled, cancel := context.WithTimeout(ctx, 5*time.Millisecond)
+	defer cancel()
+	if err := d.wait(cancelled, false); err == nil {
+		t.Fatal("cancelled wait succeeded")
+	}
+
+	// The cancelled request's slot goes to the next one instead of
+	// pushing it back a further 50ms
+	start := time.Now()
+	if err := d.wait(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 75*time.Millisecond {
+		t.Errorf("request after a cancelled one waited %s, want at most 50ms", elapsed)
+	}
+}
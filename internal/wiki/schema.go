@@ -0,0 +1,166 @@
+package wiki
+
+import (
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Value types inferred for infobox fields
+const (
+	FieldTypeNumber = "number"
+	FieldTypeDate   = "date"
+	FieldTypeURL    = "url"
+	FieldTypeText   = "text"
+)
+
+// maxExampleLength is the length examples are truncated to
+const maxExampleLength = 100
+
+var (
+	fieldNumber = regexp.MustCompile(`^[-+−]?\d[\d,. ]*(?:%|\s?[\p{L}²³]{1,4})?$`)
+	fieldURL    = regexp.MustCompile(`^(?:https?:)?//\S+$|^www\.\S+$`)
+	fieldDate   = regexp.MustCompile(`(?i)^(?:c\.\s*)?(?:` +
+		`\d{4}-\d{2}(?:-\d{2})?` + // ISO
+		`|\d{1,2}\.?\s+` + fieldMonths + `\.?,?\s+\d{1,4}` + // 17 December 1944
+		`|` + fieldMonths + `\.?\s+(?:\d{1,2},?\s+)?\d{1,4}` + // December 17, 1944
+		`|\d{1,2}[./]\d{1,2}[./]\d{2,4}` + // 17.12.1944
+		`)(?:\s*\(.*\))?$`) // (aged 70)
+)
+
+// fieldMonths matches month names and abbreviations in English, German,
+// French, and Spanish
+const fieldMonths = `(?:jan(?:uary|uar|vier)?|feb(?:ruary|ruar)?|févr(?:ier)?|mar(?:ch|s)?|märz|apr(?:il)?|avr(?:il)?|may|mai|jun(?:e|i)?|juin|jul(?:y|i)?|juil(?:let)?|aug(?:ust)?|août|sep(?:t(?:ember)?)?|oct(?:ober)?|okt(?:ober)?|nov(?:ember)?|dec(?:ember)?|dez(?:ember)?|déc(?:embre)?|enero|febrero|marzo|abril|mayo|junio|julio|agosto|septiembre|octubre|noviembre|diciembre)`
+
+// InfoboxSchema is the implicit schema of the infoboxes on a set of pages
+type InfoboxSchema struct {
+	Category         string          `json:"category,omitempty"`
+	Template         string          `json:"template,omitempty"` // the template sampled, if one was requested
+	PagesSampled     int             `json:"pages_sampled"`
+	PagesWithInfobox int             `json:"pages_with_infobox"`
+	Templates        []TemplateUsage `json:"templates"`
+	Fields           []InfoboxField  `json:"fields"`
+}
+
+// TemplateUsage counts the pages using an infobox template
+type TemplateUsage struct {
+	Name  string `json:"name"`
+	Pages int    `json:"pages"`
+}
+
+// InfoboxField describes one infobox parameter across the sampled pages
+type InfoboxField struct {
+	Name     string   `json:"name"`
+	Present  int      `json:"present"`   // infoboxes with the parameter
+	Filled   int      `json:"filled"`    // infoboxes with a non-empty value
+	FillRate float64  `json:"fill_rate"` // filled / pages with an infobox
+	Type     string   `json:"type"`      // number, date, url, or text
+	Examples []string `json:"examples"`
+}
+
+// InferInfoboxSchema merges infoboxes into the union of their fields, with
+// how often each is filled, its inferred value type, and up to examples
+// distinct example values. Fields are ordered by fill rate, most filled
+// first. A field's type is the one at least 80% of its values have.
+func InferInfoboxSchema(infoboxes []*Infobox, examples int) *InfoboxSchema {
+	schema := &InfoboxSchema{
+		Templates: make([]TemplateUsage, 0),
+		Fields:    make([]InfoboxField, 0),
+	}
+
+	templates := make(map[string]int)
+	fields := make(map[string]*InfoboxField)
+	types := make(map[string]map[string]int)
+	for _, infobox := range infoboxes {
+		if infobox == nil {
+			continue
+		}
+		schema.PagesWithInfobox++
+		templates[capitalizeTemplate(infobox.Template)]++
+
+		for name, value := range infobox.Fields {
+			field, ok := fields[name]
+			if !ok {
+				field = &InfoboxField{Name: name, Examples: make([]string, 0)}
+				fields[name] = field
+				types[name] = make(map[string]int)
+			}
+			field.Present++
+			if value == "" {
+				continue
+			}
+			field.Filled++
+			types[name][valueType(value)]++
+
+			example := truncateExample(value)
+			if len(field.Examples) < examples && !slices.Contains(field.Examples, example) {
+				field.Examples = append(field.Examples, example)
+			}
+		}
+	}
+
+	for name, pages := range templates {
+		schema.Templates = append(schema.Templates, TemplateUsage{Name: name, Pages: pages})
+	}
+	sort.Slice(schema.Templates, func(i, j int) bool {
+		a, b := schema.Templates[i], schema.Templates[j]
+		if a.Pages != b.Pages {
+			return a.Pages > b.Pages
+		}
+		return a.Name < b.Name
+	})
+
+	for name, field := range fields {
+		field.FillRate = math.Round(float64(field.Filled)/float64(schema.PagesWithInfobox)*100) / 100
+		field.Type = FieldTypeText
+		for kind, count := range types[name] {
+			if field.Filled > 0 && float64(count) >= 0.8*float64(field.Filled) {
+				field.Type = kind
+			}
+		}
+		schema.Fields = append(schema.Fields, *field)
+	}
+	sort.Slice(schema.Fields, func(i, j int) bool {
+		a, b := schema.Fields[i], schema.Fields[j]
+		if a.Filled != b.Filled {
+			return a.Filled > b.Filled
+		}
+		return a.Name < b.Name
+	})
+
+	return schema
+}
+
+// valueType infers the type of a single field value
+func valueType(value string) string {
+	switch {
+	case fieldURL.MatchString(value):
+		return FieldTypeURL
+	case fieldDate.MatchString(value):
+		return FieldTypeDate
+	case fieldNumber.MatchString(value):
+		return FieldTypeNumber
+	}
+	return FieldTypeText
+}
+
+// capitalizeTemplate uppercases a template name's first letter, since
+// "infobox person" and "Infobox person" are the same template
+func capitalizeTemplate(name string) string {
+	if name == "" {
+		return name
+	}
+	r := []rune(name)
+	return strings.ToUpper(string(r[0])) + string(r[1:])
+}
+
+// truncateExample shortens long values to maxExampleLength runes
+func truncateExample(value string) string {
+	r := []rune(value)
+	if len(r) <= maxExampleLength {
+		return value
+	}
+	return string(r[:maxExampleLength-1]) + "…"
+}
@@ -0,0 +1,56 @@
+package wiki
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInferInfoboxSchema(t *testing.T) {
+	infoboxes := []*Infobox{
+		{Template: "Infobox person", Fields: map[string]string{"name": "Ada Lovelace", "birth_date": "10 December 1815", "website": ""}},
+		{Template: "infobox person", Fields: map[string]string{"name": "Charles Babbage", "birth_date": "December 26, 1791", "height": "1.8 m"}},
+		{Template: "Infobox scientist", Fields: map[string]string{"name": "Alan Turing", "birth_date": "1912-06-23", "website": "https://turing.org.uk"}},
+		nil, // a page without an infobox
+	}
+
+	schema := InferInfoboxSchema(infoboxes, 2)
+
+	if schema.PagesWithInfobox != 3 {
+		t.Errorf("pages with infobox = %d, want 3", schema.PagesWithInfobox)
+	}
+	wantTemplates := []TemplateUsage{{Name: "Infobox person", Pages: 2}, {Name: "Infobox scientist", Pages: 1}}
+	if !reflect.DeepEqual(schema.Templates, wantTemplates) {
+		t.Errorf("templates = %+v, want %+v", schema.Templates, wantTemplates)
+	}
+
+	want := []InfoboxField{
+		{Name: "birth_date", Present: 3, Filled: 3, FillRate: 1, Type: FieldTypeDate, Examples: []string{"10 December 1815", "December 26, 1791"}},
+		{Name: "name", Present: 3, Filled: 3, FillRate: 1, Type: FieldTypeText, Examples: []string{"Ada Lovelace", "Charles Babbage"}},
+		{Name: "height", Present: 1, Filled: 1, FillRate: 0.33, Type: FieldTypeNumber, Examples: []string{"1.8 m"}},
+		{Name: "website", Present: 2, Filled: 1, FillRate: 0.33, Type: FieldTypeURL, Examples: []string{"https://turing.org.uk"}},
+	}
+	if !reflect.DeepEqual(schema.Fields, want) {
+		t.Errorf("fields:\ngot:  %+v\nwant: %+v", schema.Fields, want)
+	}
+}
+
+func TestValueType(t *testing.T) {
+	for value, want := range map[string]string{
+		"1,234,567":            FieldTypeNumber,
+		"−40":                  FieldTypeNumber,
+		"12.5%":                FieldTypeNumber,
+		"891.8 km²":            FieldTypeNumber,
+		"1944-12-17":           FieldTypeDate,
+		"17. Dezember 1944":    FieldTypeDate,
+		"March 1950":           FieldTypeDate,
+		"17.12.1944":           FieldTypeDate,
+		"5 May 1821 (aged 51)": FieldTypeDate,
+		"www.example.org":      FieldTypeURL,
+		"Paris, France":        FieldTypeText,
+		"Mayor":                FieldTypeText,
+	} {
+		if got := valueType(value); got != want {
+			t.Errorf("valueType(%q) = %s, want %s", value, got, want)
+		}
+	}
+}
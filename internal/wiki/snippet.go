@@ -0,0 +1,60 @@
+package wiki
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	// searchMatch matches the spans search highlights matched terms with
+	searchMatch = regexp.MustCompile(`(?is)<span[^>]*class="[^"]*\bsearchmatch\b[^"]*"[^>]*>(.*?)</span>`)
+
+	// snippetTag matches any remaining tag, or one cut off at the end of a
+	// truncated snippet
+	snippetTag = regexp.MustCompile(`<[^>]*(?:>|$)`)
+)
+
+// CleanSnippet converts a search snippet to Markdown: matched terms are
+// bolded, other tags stripped, entities decoded, and whitespace collapsed.
+// Snippets are short HTML fragments, so this avoids the full page converter.
+func CleanSnippet(snippet string) string {
+	var sb strings.Builder
+	last := 0
+	for _, m := range searchMatch.FindAllStringSubmatchIndex(snippet, -1) {
+		sb.WriteString(cleanSnippetText(snippet[last:m[0]]))
+		sb.WriteString(boldSnippetTerm(cleanSnippetText(snippet[m[2]:m[3]])))
+		last = m[1]
+	}
+	sb.WriteString(cleanSnippetText(snippet[last:]))
+
+	// Adjacent matches run together: "**quantum****mechanics**"
+	cleaned := strings.ReplaceAll(sb.String(), "****", "")
+	return strings.Join(strings.Fields(cleaned), " ")
+}
+
+// cleanSnippetText strips tags, leaving a space for block elements like
+// <br>, and decodes entities. Asterisks in the text
+// are escaped so they can't pair with the bold markers around matched terms.
+func cleanSnippetText(s string) string {
+	s = snippetTag.ReplaceAllStringFunc(s, func(tag string) string {
+		name := strings.Trim(strings.ToLower(strings.Fields(tag + " ")[0]), "</>")
+		if blockElements[name] {
+			return " "
+		}
+		return ""
+	})
+	s = html.UnescapeString(s)
+	return strings.ReplaceAll(s, "*", `\*`)
+}
+
+// boldSnippetTerm bolds a matched term, keeping surrounding whitespace
+// outside the markers so the Markdown stays valid
+func boldSnippetTerm(term string) string {
+	trimmed := strings.TrimSpace(term)
+	if trimmed == "" {
+		return term
+	}
+	start := strings.Index(term, trimmed)
+	return term[:start] + "**" + trimmed + "**" + term[start+len(trimmed):]
+}
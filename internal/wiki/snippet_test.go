@@ -0,0 +1,49 @@
+package wiki
+
+import "testing"
+
+func TestCleanSnippet(t *testing.T) {
+	tests := []struct {
+		snippet, want string
+	}{
+		{
+			`capital of <span class="searchmatch">France</span> and`,
+			`capital of **France** and`,
+		},
+		{
+			`<span class="searchmatch">quantum</span> <span class="searchmatch">mechanics</span> is`,
+			`**quantum** **mechanics** is`,
+		},
+		{
+			`<span class="searchmatch">Wiki</span><span class="searchmatch">pedia</span>`,
+			`**Wikipedia**`,
+		},
+		{
+			`the <span class="searchmatch">term </span>here`,
+			`the **term** here`,
+		},
+		{
+			`Rock &amp; roll &quot;hits&quot; &#8211; 1950s&nbsp;era`,
+			"Rock & roll \"hits\" – 1950s era",
+		},
+		{
+			`a <b>bold</b> <i>word</i><br/>then &lt;tag&gt;`,
+			`a bold word then <tag>`,
+		},
+		{
+			"rated 5* by\n\ncritics",
+			`rated 5\* by critics`,
+		},
+		{
+			`cut off at the end <span class="searchma`,
+			`cut off at the end`,
+		},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := CleanSnippet(tt.snippet); got != tt.want {
+			t.Errorf("CleanSnippet(%q) = %q, want %q", tt.snippet, got, tt.want)
+		}
+	}
+}
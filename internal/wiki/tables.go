@@ -0,0 +1,178 @@
+package wiki
+
+import (
+	"strings"
+
+	"github.com/yourusername/mediawiki-mcp/internal/wikitext"
+)
+
+// Table is a wikitext table with its cells rendered as plain text
+type Table struct {
+	Caption string     `json:"caption,omitempty"`
+	Headers []string   `json:"headers"`
+	Rows    [][]string `json:"rows"`
+}
+
+// ExtractTables returns the top-level tables in wikitext ({| ... |}) in page
+// order. The first row is the header when it is all header cells (!).
+// Cell attributes are dropped, and rowspan and colspan are not expanded,
+// so rows of merged cells come out short. Nested tables are skipped.
+func ExtractTables(text string) []Table {
+	var tables []Table
+	var current *tableBuilder
+	depth := 0
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "{|"):
+			depth++
+			if depth == 1 {
+				current = &tableBuilder{}
+			}
+			continue
+		case strings.HasPrefix(trimmed, "|}"):
+			if depth == 1 && current != nil {
+				tables = append(tables, current.finish())
+				current = nil
+			}
+			if depth > 0 {
+				depth--
+			}
+			continue
+		}
+		if depth != 1 || current == nil {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "|+"):
+			current.caption = tableCell(trimmed[2:])
+		case strings.HasPrefix(trimmed, "|-"):
+			current.endRow()
+		case strings.HasPrefix(trimmed, "!"):
+			for _, cell := range splitCells(trimmed[1:], "!!") {
+				current.add(tableCell(cell), true)
+			}
+		case strings.HasPrefix(trimmed, "|"):
+			for _, cell := range splitCells(trimmed[1:], "||") {
+				current.add(tableCell(cell), false)
+			}
+		default:
+			// Continuation of the previous cell's content
+			current.extend(trimmed)
+		}
+	}
+
+	return tables
+}
+
+// tableBuilder collects the rows of a table being parsed
+type tableBuilder struct {
+	caption   string
+	rows      [][]string
+	row       []string
+	allHeader bool // whether the current row has only header cells
+	header    []string
+}
+
+func (b *tableBuilder) add(cell string, header bool) {
+	if len(b.row) == 0 {
+		b.allHeader = header
+	} else if !header {
+		b.allHeader = false
+	}
+	b.row = append(b.row, cell)
+}
+
+func (b *tableBuilder) extend(text string) {
+	if len(b.row) == 0 || text == "" {
+		return
+	}
+	last := len(b.row) - 1
+	if extra := tableCell(text); extra != "" {
+		b.row[last] = strings.TrimSpace(b.row[last] + " " + extra)
+	}
+}
+
+func (b *tableBuilder) endRow() {
+	if len(b.row) == 0 {
+		return
+	}
+	if b.allHeader && b.header == nil && len(b.rows) == 0 {
+		b.header = b.row
+	} else {
+		b.rows = append(b.rows, b.row)
+	}
+	b.row = nil
+}
+
+func (b *tableBuilder) finish() Table {
+	b.endRow()
+	table := Table{Caption: b.caption, Headers: b.header, Rows: b.rows}
+	if table.Headers == nil {
+		table.Headers = []string{}
+	}
+	if table.Rows == nil {
+		table.Rows = [][]string{}
+	}
+	return table
+}
+
+// splitCells splits a table line into cells on sep, ignoring separators
+// inside links and templates
+func splitCells(line, sep string) []string {
+	var cells []string
+	depth := 0
+	start := 0
+	for i := 0; i < len(line); i++ {
+		switch {
+		case strings.HasPrefix(line[i:], "[[") || strings.HasPrefix(line[i:], "{{"):
+			depth++
+			i++
+		case strings.HasPrefix(line[i:], "]]") || strings.HasPrefix(line[i:], "}}"):
+			if depth > 0 {
+				depth--
+			}
+			i++
+		case depth == 0 && strings.HasPrefix(line[i:], sep):
+			cells = append(cells, line[start:i])
+			start = i + len(sep)
+			i += len(sep) - 1
+		}
+	}
+	return append(cells, line[start:])
+}
+
+// tableCell renders a cell as plain text the way infobox values are,
+// dropping attributes such as style="..." before a single "|"
+func tableCell(cell string) string {
+	if i := cellAttributeEnd(cell); i >= 0 {
+		cell = cell[i+1:]
+	}
+	return cleanInfoboxValue(infoboxRenderer.Text(wikitext.Parse(strings.TrimSpace(cell))))
+}
+
+// cellAttributeEnd returns the index of the "|" ending a cell's attributes,
+// or -1 when it has none. Pipes inside links and templates don't count.
+func cellAttributeEnd(cell string) int {
+	depth := 0
+	for i := 0; i < len(cell); i++ {
+		switch {
+		case strings.HasPrefix(cell[i:], "[[") || strings.HasPrefix(cell[i:], "{{"):
+			depth++
+			i++
+		case strings.HasPrefix(cell[i:], "]]") || strings.HasPrefix(cell[i:], "}}"):
+			if depth > 0 {
+				depth--
+			}
+			i++
+		case depth == 0 && cell[i] == '|':
+			if strings.Contains(cell[:i], "=") {
+				return i
+			}
+			return -1
+		}
+	}
+	return -1
+}
@@ -0,0 +1,52 @@
+package wiki
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractTables(t *testing.T) {
+	text := `Intro.
+{| class="wikitable sortable"
+|+ Largest cities
+|-
+! Rank !! City !! Population
+|-
+| 1 || [[Berlin]] || 3,878,100
+|-
+| 2 || [[Hamburg|Hamburg, Germany]] || style="text-align:right" | 1,892,122<ref>Census</ref>
+|-
+| 3
+| [[Munich]]
+| {{nts|1512491}}
+|}
+
+{| class="infobox"
+| Not || a || header
+|-
+| x
+{| class="nested"
+| ignored
+|}
+|}`
+
+	got := ExtractTables(text)
+	want := []Table{
+		{
+			Caption: "Largest cities",
+			Headers: []string{"Rank", "City", "Population"},
+			Rows: [][]string{
+				{"1", "Berlin", "3,878,100"},
+				{"2", "Hamburg, Germany", "1,892,122"},
+				{"3", "Munich", ""},
+			},
+		},
+		{
+			Headers: []string{},
+			Rows:    [][]string{{"Not", "a", "header"}, {"x"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractTables:\ngot:  %q\nwant: %q", got, want)
+	}
+}
@@ -0,0 +1,54 @@
+package wiki
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// AddTopic starts a discussion topic on a talk page with DiscussionTools'
+// action=discussiontoolsedit, as the wiki's "Add topic" tool does: the
+// edit's SectionTitle is the heading and its Text the first comment, which
+// the wiki signs if it isn't signed already. The account is subscribed to
+// replies where the wiki subscribes new topics. Like Edit, it invalidates
+// the page's cached content.
+func (c *Client) AddTopic(ctx context.Context, wikiURL string, edit EditParams) (*EditResult, error) {
+	if b := c.backend(wikiURL); b != nil {
+		return nil, &BackendUnsupportedError{Backend: b.Name(), WikiURL: wikiURL, Request: "add topic"}
+	}
+
+	params := url.Values{}
+	params.Set("action", "discussiontoolsedit")
+	params.Set("paction", "addtopic")
+	params.Set("page", edit.Title)
+	params.Set("sectiontitle", edit.SectionTitle)
+	params.Set("wikitext", edit.Text)
+	params.Set("summary", edit.Summary)
+	params.Set("autosubscribe", "default")
+	if edit.Bot {
+		params.Set("bot", "1")
+	}
+
+	resp, err := c.writeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("add topic: %w", err)
+	}
+	if resp.DiscussionToolsEdit == nil {
+		return nil, fmt.Errorf("empty discussiontoolsedit response")
+	}
+	if !strings.EqualFold(resp.DiscussionToolsEdit.Result, "success") {
+		return nil, &APIError{Code: "editfailed", Message: fmt.Sprintf("topic on %s was not saved (result %q)", edit.Title, resp.DiscussionToolsEdit.Result)}
+	}
+
+	c.cache.InvalidatePage(wikiURL, edit.Title)
+
+	result := &EditResult{
+		Title:    edit.Title,
+		NewRevID: resp.DiscussionToolsEdit.NewRevID,
+	}
+	if result.NewRevID > 0 {
+		result.DiffURL = c.DiffURL(ctx, wikiURL, 0, result.NewRevID)
+	}
+	return result, nil
+}
@@ -0,0 +1,48 @@
+package wiki
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAddTopic(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch {
+		case r.Form.Get("meta") == "tokens":
+			fmt.Fprint(w, `{"query":{"tokens":{"csrftoken":"csrf-token"}}}`)
+		case r.Form.Get("action") == "discussiontoolsedit":
+			if r.Form.Get("paction") != "addtopic" || r.Form.Get("page") != "Talk:Town" || r.Form.Get("sectiontitle") != "Population" ||
+				r.Form.Get("wikitext") != "Out of date. ~~~~" || r.Form.Get("token") != "csrf-token" {
+				t.Errorf("discussiontoolsedit = %v", r.Form)
+			}
+			fmt.Fprint(w, `{"discussiontoolsedit":{"result":"success","newrevid":31}}`)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	client.SetAuth(srv.URL, Auth{AccessToken: "oauth"})
+
+	// Edit posts topics with DiscussionTools, so held edits do too
+	result, err := client.Edit(context.Background(), srv.URL, EditParams{
+		Title:        "Talk:Town",
+		Text:         "Out of date. ~~~~",
+		Section:      "new",
+		SectionTitle: "Population",
+		Summary:      "/* Population */ new section",
+		Topic:        true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.NewRevID != 31 || result.DiffURL == "" {
+		t.Errorf("result = %+v", result)
+	}
+}
@@ -0,0 +1,267 @@
+package wiki
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExtractTimeline finds dated statements in Markdown and returns them in
+// chronological order. Each sentence (or table row) yields at most one
+// event, dated by the first date it mentions; statements before the first
+// heading are attributed to section. Dates are recognized in English.
+func ExtractTimeline(markdown, section string) []TimelineEvent {
+	events := make([]TimelineEvent, 0)
+	seen := make(map[string]bool)
+	add := func(statement string) {
+		statement = cleanStatement(statement)
+		if statement == "" || seen[statement] {
+			return
+		}
+		if date, ok := findDate(statement); ok {
+			seen[statement] = true
+			date.Event = statement
+			date.Section = section
+			events = append(events, date)
+		}
+	}
+
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#"):
+			if _, heading := markdownHeading(line); heading != "" {
+				section = heading
+			}
+		case strings.HasPrefix(line, "|"):
+			// Table rows are often timelines themselves: one event per row
+			if !tableSeparator.MatchString(line) {
+				add(strings.Join(tableCells(line), " – "))
+			}
+		default:
+			for _, sentence := range splitSentences(cleanStatement(markdownListItem.ReplaceAllString(line, ""))) {
+				add(sentence)
+			}
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].sortKey < events[j].sortKey
+	})
+	return events
+}
+
+// MarkdownSection returns the part of a Markdown document under the first
+// heading matching title (case-insensitively), including its subsections
+func MarkdownSection(markdown, title string) (string, bool) {
+	lines := strings.Split(markdown, "\n")
+	for i, line := range lines {
+		level, heading := markdownHeading(line)
+		if level == 0 || !strings.EqualFold(heading, strings.TrimSpace(title)) {
+			continue
+		}
+		end := len(lines)
+		for j := i + 1; j < len(lines); j++ {
+			if next, _ := markdownHeading(lines[j]); next > 0 && next <= level {
+				end = j
+				break
+			}
+		}
+		return strings.Join(lines[i:end], "\n"), true
+	}
+	return "", false
+}
+
+// markdownHeading returns the level and text of an ATX heading line, or 0
+// if the line isn't a heading
+func markdownHeading(line string) (int, string) {
+	line = strings.TrimSpace(line)
+	level := len(line) - len(strings.TrimLeft(line, "#"))
+	if level == 0 || level > 6 || len(line) > level && line[level] != ' ' {
+		return 0, ""
+	}
+	return level, strings.TrimSpace(line[level:])
+}
+
+var (
+	// markdownListItem matches a Markdown list bullet or number
+	markdownListItem = regexp.MustCompile(`^(?:[-*+]|\d+\.)\s+`)
+
+	// tableSeparator matches a Markdown table's header separator row
+	tableSeparator = regexp.MustCompile(`^\|[\s|:-]+\|$`)
+
+	// markdownLink matches [text](target), keeping the text
+	markdownLink = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+
+	// footnoteMarker matches reference markers like [1] or [note 2]
+	footnoteMarker = regexp.MustCompile(`\[(?:\d+|[a-z]+ \d+)\]`)
+)
+
+func tableCells(row string) []string {
+	var cells []string
+	for _, cell := range strings.Split(strings.Trim(row, "|"), "|") {
+		if cell = strings.TrimSpace(cell); cell != "" {
+			cells = append(cells, cell)
+		}
+	}
+	return cells
+}
+
+// cleanStatement strips Markdown formatting and footnote markers
+func cleanStatement(s string) string {
+	s = markdownLink.ReplaceAllString(s, "$1")
+	s = footnoteMarker.ReplaceAllString(s, "")
+	s = strings.NewReplacer("**", "", "__", "", "*", "", "`", "").Replace(s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// sentenceAbbreviations end in a period without ending a sentence
+var sentenceAbbreviations = map[string]bool{
+	"c.": true, "ca.": true, "st.": true, "mr.": true, "mrs.": true, "dr.": true,
+	"jr.": true, "sr.": true, "no.": true, "vol.": true, "e.g.": true, "i.e.": true,
+	"u.s.": true, "u.k.": true, "jan.": true, "feb.": true, "aug.": true, "sept.": true,
+	"sep.": true, "oct.": true, "nov.": true, "dec.": true, "vs.": true, "approx.": true,
+}
+
+// splitSentences splits a paragraph after sentence-ending punctuation
+// followed by a capital letter or digit
+func splitSentences(text string) []string {
+	var sentences []string
+	words := strings.Fields(text)
+	start := 0
+	for i := 0; i < len(words)-1; i++ {
+		word := words[i]
+		if !strings.HasSuffix(word, ".") && !strings.HasSuffix(word, "!") && !strings.HasSuffix(word, "?") {
+			continue
+		}
+		if sentenceAbbreviations[strings.ToLower(word)] || isInitial(word) {
+			continue
+		}
+		if next := words[i+1]; next[0] >= 'A' && next[0] <= 'Z' || next[0] >= '0' && next[0] <= '9' {
+			sentences = append(sentences, strings.Join(words[start:i+1], " "))
+			start = i + 1
+		}
+	}
+	if start < len(words) {
+		sentences = append(sentences, strings.Join(words[start:], " "))
+	}
+	return sentences
+}
+
+// isInitial reports whether a word is an initial like "J."
+func isInitial(word string) bool {
+	return len(word) == 2 && word[0] >= 'A' && word[0] <= 'Z'
+}
+
+var monthNames = map[string]int{
+	"january": 1, "february": 2, "march": 3, "april": 4, "may": 5, "june": 6,
+	"july": 7, "august": 8, "september": 9, "october": 10, "november": 11, "december": 12,
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "jun": 6, "jul": 7, "aug": 8,
+	"sep": 9, "sept": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+const monthPattern = `(January|February|March|April|May|June|July|August|September|October|November|December|Jan|Feb|Mar|Apr|Jun|Jul|Aug|Sept?|Oct|Nov|Dec)\.?`
+
+// Date patterns, most specific first. Bare years need a preposition or
+// the start of the statement before them, so numbers like "1500 metres"
+// aren't taken for years.
+var (
+	isoDate        = regexp.MustCompile(`\b(\d{4})-(\d{2})-(\d{2})\b`)
+	dayMonthYear   = regexp.MustCompile(`\b(\d{1,2}) ` + monthPattern + `,? (\d{3,4})\b`)
+	monthDayYear   = regexp.MustCompile(`\b` + monthPattern + ` (\d{1,2}),? (\d{3,4})\b`)
+	monthYear      = regexp.MustCompile(`\b` + monthPattern + `,? (\d{3,4})\b`)
+	eraYear        = regexp.MustCompile(`\b(\d{1,4}) ?(BC|BCE|AD|CE)\b`)
+	decade         = regexp.MustCompile(`\b(\d{3})0s\b`)
+	century        = regexp.MustCompile(`(?i)\b(\d{1,2})(?:st|nd|rd|th) century( BC| BCE)?\b`)
+	contextualYear = regexp.MustCompile(`(?i)(?:^|\b(?:in|on|by|since|until|till|from|during|around|circa|c\.|ca\.|early|mid|late|before|after|between|of|to|and)[ -]|–)(\d{3,4})\b`)
+)
+
+// findDate returns the first date in a statement, with a sortable key
+func findDate(s string) (TimelineEvent, bool) {
+	type match struct {
+		at   int
+		date TimelineEvent
+	}
+	var best *match
+	consider := func(loc []int, text string, year, month, day int) {
+		if best == nil || loc[0] < best.at {
+			best = &match{at: loc[0], date: newTimelineDate(text, year, month, day)}
+		}
+	}
+
+	if m := isoDate.FindStringSubmatchIndex(s); m != nil {
+		consider(m, s[m[0]:m[1]], atoi(s[m[2]:m[3]]), atoi(s[m[4]:m[5]]), atoi(s[m[6]:m[7]]))
+	}
+	if m := dayMonthYear.FindStringSubmatchIndex(s); m != nil {
+		consider(m, s[m[0]:m[1]], atoi(s[m[6]:m[7]]), month(s[m[4]:m[5]]), atoi(s[m[2]:m[3]]))
+	}
+	if m := monthDayYear.FindStringSubmatchIndex(s); m != nil {
+		consider(m, s[m[0]:m[1]], atoi(s[m[6]:m[7]]), month(s[m[2]:m[3]]), atoi(s[m[4]:m[5]]))
+	}
+	if m := monthYear.FindStringSubmatchIndex(s); m != nil {
+		consider(m, s[m[0]:m[1]], atoi(s[m[4]:m[5]]), month(s[m[2]:m[3]]), 0)
+	}
+	if m := eraYear.FindStringSubmatchIndex(s); m != nil {
+		year := atoi(s[m[2]:m[3]])
+		if era := s[m[4]:m[5]]; era == "BC" || era == "BCE" {
+			year = -year
+		}
+		consider(m, s[m[0]:m[1]], year, 0, 0)
+	}
+	if m := decade.FindStringSubmatchIndex(s); m != nil {
+		consider(m, s[m[0]:m[1]], atoi(s[m[2]:m[3]])*10, 0, 0)
+	}
+	if m := century.FindStringSubmatchIndex(s); m != nil {
+		n := atoi(s[m[2]:m[3]])
+		year := (n - 1) * 100
+		if m[4] >= 0 {
+			year = -n * 100
+		}
+		consider(m, s[m[0]:m[1]], year, 0, 0)
+	}
+	if m := contextualYear.FindStringSubmatchIndex(s); m != nil {
+		year := atoi(s[m[2]:m[3]])
+		if year >= 100 && year <= 2100 {
+			consider([]int{m[2], m[3]}, s[m[2]:m[3]], year, 0, 0)
+		}
+	}
+
+	if best == nil {
+		return TimelineEvent{}, false
+	}
+	return best.date, true
+}
+
+// newTimelineDate formats a date as ISO 8601 at the precision given (month
+// and day are 0 when unknown), with a key that sorts chronologically
+func newTimelineDate(text string, year, month, day int) TimelineEvent {
+	var date string
+	if year < 0 {
+		date = fmt.Sprintf("-%04d", -year)
+	} else {
+		date = fmt.Sprintf("%04d", year)
+	}
+	if month > 0 {
+		date += fmt.Sprintf("-%02d", month)
+		if day > 0 {
+			date += fmt.Sprintf("-%02d", day)
+		}
+	}
+	return TimelineEvent{
+		Date:     date,
+		DateText: text,
+		sortKey:  year*10000 + month*100 + day,
+	}
+}
+
+func month(name string) int {
+	return monthNames[strings.ToLower(strings.TrimSuffix(name, "."))]
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
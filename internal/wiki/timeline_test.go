@@ -0,0 +1,88 @@
+package wiki
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindDate(t *testing.T) {
+	tests := []struct {
+		text string
+		date string // "" when no date
+	}{
+		{"He was born on 14 March 1879 in Ulm.", "1879-03-14"},
+		{"On March 14, 1879, he was born.", "1879-03-14"},
+		{"The bridge opened in June 1932.", "1932-06"},
+		{"The site was excavated 2004-07-21.", "2004-07-21"},
+		{"In 1905 he published four papers.", "1905"},
+		{"It was rebuilt from 1950–1955.", "1950"},
+		{"The city was founded in 753 BC.", "-0753"},
+		{"Trade grew in the 1990s.", "1990"},
+		{"It dates to the 12th century.", "1100"},
+		{"It dates to the 5th century BC.", "-0500"},
+		{"The peak rises 2500 metres above the valley.", ""},
+		{"She may return.", ""},
+	}
+
+	for _, tt := range tests {
+		got, ok := findDate(tt.text)
+		if tt.date == "" {
+			if ok {
+				t.Errorf("findDate(%q) = %q, want no date", tt.text, got.Date)
+			}
+			continue
+		}
+		if !ok || got.Date != tt.date {
+			t.Errorf("findDate(%q) = %q, %v, want %q", tt.text, got.Date, ok, tt.date)
+		}
+	}
+}
+
+func TestExtractTimeline(t *testing.T) {
+	markdown := `Ulm is a city in Germany. It was first mentioned in 854.
+
+## History
+
+### Middle Ages
+
+In 1376 the city council began building the **Minster**.[1] Construction stopped in 1543. See [the minster](https://example.org/Ulm_Minster).
+
+- 1890: The spire was completed.
+
+### Modern era
+
+| Year | Event |
+| --- | --- |
+| 17 December 1944 | Air raid destroys much of the old town |
+
+## Geography
+
+The Danube flows through the city.`
+
+	want := []TimelineEvent{
+		{Date: "0854", DateText: "854", Event: "It was first mentioned in 854.", Section: "Lead"},
+		{Date: "1376", DateText: "1376", Event: "In 1376 the city council began building the Minster.", Section: "Middle Ages"},
+		{Date: "1543", DateText: "1543", Event: "Construction stopped in 1543.", Section: "Middle Ages"},
+		{Date: "1890", DateText: "1890", Event: "1890: The spire was completed.", Section: "Middle Ages"},
+		{Date: "1944-12-17", DateText: "17 December 1944", Event: "17 December 1944 – Air raid destroys much of the old town", Section: "Modern era"},
+	}
+
+	got := ExtractTimeline(markdown, "Lead")
+	for i := range got {
+		got[i].sortKey = 0
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractTimeline:\ngot:  %+v\nwant: %+v", got, want)
+	}
+
+	history, ok := MarkdownSection(markdown, "history")
+	if !ok {
+		t.Fatal("MarkdownSection: History not found")
+	}
+	if events := ExtractTimeline(history, "History"); len(events) != 4 {
+		t.Errorf("History section: got %d events, want 4: %+v", len(events), events)
+	}
+	if _, ok := MarkdownSection(markdown, "Economy"); ok {
+		t.Error("MarkdownSection: found a missing section")
+	}
+}
@@ -1,8 +1,10 @@
 package wiki
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 )
 
@@ -18,29 +20,45 @@ type WikiInfo struct {
 
 // SearchResult represents a single search result
 type SearchResult struct {
-	Title        string   `json:"title"`
-	Snippet      string   `json:"snippet"`
-	SnippetLinks []string `json:"snippet_links"`
-	WordCount    int      `json:"word_count"`
+	Title        string      `json:"title"`
+	Snippet      string      `json:"snippet"`
+	SnippetLinks []string    `json:"snippet_links"`
+	WordCount    int         `json:"word_count"`
+	ViaRedirect  string      `json:"via_redirect,omitempty"` // a redirect to this page that also matched, collapsed into this result
+	Source       *Provenance `json:"source,omitempty"`
 }
 
 // SearchResponse contains search results
 type SearchResponse struct {
-	Results    []SearchResult `json:"results"`
-	TotalHits  int            `json:"total_hits"`
-	Suggestion *string        `json:"suggestion,omitempty"`
+	Results      []SearchResult `json:"results"`
+	TotalHits    int            `json:"total_hits"`
+	Suggestion   *string        `json:"suggestion,omitempty"`
+	LimitClamped *LimitClamp    `json:"limit_clamped,omitempty"`
+}
+
+// LimitClamp reports that a requested limit was lowered to the server's
+// maximum, which is why fewer items may be returned than asked for
+type LimitClamp struct {
+	Requested int `json:"requested"`
+	Max       int `json:"max"`
 }
 
 // Section represents a page section
 type Section struct {
-	Index       int        `json:"index"`
-	Title       string     `json:"title"`
-	Level       int        `json:"level"`
-	Preview     string     `json:"preview,omitempty"`
-	Content     string     `json:"content,omitempty"`
-	Links       []string   `json:"links,omitempty"`
-	WordCount   int        `json:"word_count"`
-	Subsections []*Section `json:"subsections,omitempty"`
+	Index          int          `json:"index"`
+	Number         string       `json:"number,omitempty"`
+	Title          string       `json:"title"`
+	Level          int          `json:"level"`
+	Preview        string       `json:"preview,omitempty"`
+	Content        string       `json:"content,omitempty"`
+	Links          []string     `json:"links,omitempty"`
+	WordCount      int          `json:"word_count"`
+	TableWordCount int          `json:"table_word_count,omitempty"`
+	ByteOffset     *int         `json:"byte_offset,omitempty"`
+	ByteSize       int          `json:"byte_size,omitempty"`   // wikitext bytes including subsections
+	Readability    *Readability `json:"readability,omitempty"` // of the section's own text, without subsections
+	Relevance      *float64     `json:"relevance,omitempty"`   // match with relevance_query, from 0 to 1 for the best match
+	Subsections    []*Section   `json:"subsections,omitempty"`
 }
 
 // PageOutline contains page structure without full content
@@ -48,13 +66,43 @@ type PageOutline struct {
 	Title          string                 `json:"title"`
 	Exists         bool                   `json:"exists"`
 	Redirect       *string                `json:"redirect,omitempty"`
+	Description    string                 `json:"description,omitempty"`
 	Summary        string                 `json:"summary"`
-	SummaryLinks   []string               `json:"summary_links"`
+	Thumbnail      *Thumbnail             `json:"thumbnail,omitempty"`
+	SummaryLinks   []string               `json:"summary_links"`          // same as InternalTitles, kept for existing clients
+	InternalTitles []string               `json:"internal_titles"`        // pages the lead links to
+	ExternalURLs   []string               `json:"external_urls"`          // links in the lead that leave the wiki
+	LinkDetails    []Link                 `json:"link_details,omitempty"` // only with include_link_details
 	Infobox        map[string]interface{} `json:"infobox,omitempty"`
+	Coordinates    *Coordinate            `json:"coordinates,omitempty"` // primary location, if any
+	Assessments    []PageAssessment       `json:"assessments,omitempty"`
 	Sections       []*Section             `json:"sections"`
+	TOC            string                 `json:"toc,omitempty"`
 	Categories     []string               `json:"categories"`
 	SeeAlso        []string               `json:"see_also"`
 	TotalWordCount int                    `json:"total_word_count"`
+	Source         *Provenance            `json:"source,omitempty"`
+}
+
+// Link is a link to a page on the wiki, keeping the section it points to
+type Link struct {
+	Title       string `json:"title"`
+	Anchor      string `json:"anchor,omitempty"`       // section or other fragment, e.g. "Early life"
+	DisplayText string `json:"display_text,omitempty"` // text of the link on the page
+}
+
+// PageAssessment is a WikiProject's quality and importance rating of a page
+type PageAssessment struct {
+	Project    string `json:"project"`
+	Class      string `json:"class,omitempty"`      // e.g. "FA", "GA", "B", "Stub"
+	Importance string `json:"importance,omitempty"` // e.g. "Top", "High", "Low"
+}
+
+// Thumbnail is a representative image for a page
+type Thumbnail struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
 }
 
 // PageSection contains full content of a specific section
@@ -75,21 +123,46 @@ type PageSection struct {
 			Title string `json:"title"`
 		} `json:"next,omitempty"`
 	} `json:"adjacent,omitempty"`
+	InternalTitles []string    `json:"internal_titles"`        // pages the section links to
+	ExternalURLs   []string    `json:"external_urls"`          // links in the section that leave the wiki
+	LinkDetails    []Link      `json:"link_details,omitempty"` // only with include_link_details
+	ContentHash    string      `json:"content_hash"`           // see ContentHash
+	Source         *Provenance `json:"source,omitempty"`
+
+	// Set when the section's content was left out because the session was
+	// sent it before
+	PriorContent *PriorContent `json:"prior_content,omitempty"`
 }
 
 // PageFull contains entire page content
 type PageFull struct {
-	Title     string   `json:"title"`
-	Content   string   `json:"content"`
-	Links     []string `json:"links"`
-	WordCount int      `json:"word_count"`
-	Warning   *string  `json:"warning,omitempty"`
+	Title          string      `json:"title"`
+	Content        string      `json:"content"`
+	Links          []string    `json:"links"`
+	WordCount      int         `json:"word_count"`
+	TableWordCount int         `json:"table_word_count"`
+	Warning        *string     `json:"warning,omitempty"`
+	ContentHash    string      `json:"content_hash"` // see ContentHash
+	Source         *Provenance `json:"source,omitempty"`
+
+	// Content the session was sent before and that was left out of Content
+	PriorContent []PriorContent `json:"prior_content,omitempty"`
+}
+
+// PriorContent refers to content sent earlier in the same session, by the
+// content_hash it was sent with
+type PriorContent struct {
+	ContentID    string `json:"content_id"`
+	Tool         string `json:"tool"`                    // the tool that sent it
+	SectionIndex *int   `json:"section_index,omitempty"` // unset for a whole page
+	SectionTitle string `json:"section_title,omitempty"`
 }
 
 // CategoryMember represents a member of a category
 type CategoryMember struct {
-	Title string `json:"title"`
-	Type  string `json:"type"` // "page" or "subcat"
+	Title     string `json:"title"`
+	Type      string `json:"type"`                // "page", "subcat" or "file"
+	Timestamp string `json:"timestamp,omitempty"` // when it was added, if sorted by timestamp
 }
 
 // CategoryResponse contains category information
@@ -97,8 +170,208 @@ type CategoryResponse struct {
 	Category         string           `json:"category"`
 	Members          []CategoryMember `json:"members"`
 	ParentCategories []string         `json:"parent_categories,omitempty"`
-	TotalMembers     int              `json:"total_members"`
+	TotalMembers     int              `json:"total_members"` // every member, not just those returned
+	Counts           *CategoryCounts  `json:"counts,omitempty"`
 	ContinueToken    *string          `json:"continue_token,omitempty"`
+	Truncated        bool             `json:"truncated,omitempty"` // all members were asked for, but the cap was reached
+	LimitClamped     *LimitClamp      `json:"limit_clamped,omitempty"`
+}
+
+// CategoryCounts breaks a category's size down by member type
+type CategoryCounts struct {
+	Pages   int `json:"pages"`
+	Subcats int `json:"subcats"`
+	Files   int `json:"files"`
+}
+
+// CrawlResult contains the pages found by crawling a category tree
+type CrawlResult struct {
+	WikiURL    string   `json:"wiki_url"`
+	Category   string   `json:"category"`
+	Pages      []string `json:"pages"`
+	Categories []string `json:"categories"` // categories visited
+	TotalPages int      `json:"total_pages"`
+	Truncated  bool     `json:"truncated"`
+}
+
+// Coordinate is a geographic location given on a page
+type Coordinate struct {
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+	Globe   string  `json:"globe"`          // "earth" unless the page is about another body
+	Type    string  `json:"type,omitempty"` // e.g. "city", "landmark", "mountain"
+	Name    string  `json:"name,omitempty"`
+	Primary bool    `json:"primary"` // the location of the page's subject, rather than one it mentions
+	Source  string  `json:"source"`  // "geodata" or "template"
+}
+
+// CoordinatesResponse lists the coordinates on a page
+type CoordinatesResponse struct {
+	Title       string       `json:"title"`
+	Coordinates []Coordinate `json:"coordinates"`
+}
+
+// TimelineEvent is a dated statement on a page
+type TimelineEvent struct {
+	Date     string `json:"date"`      // ISO 8601 at the precision given: "1879", "1879-03" or "1879-03-14"; BC years are negative
+	DateText string `json:"date_text"` // the date as written, e.g. "14 March 1879" or "1990s"
+	Event    string `json:"event"`
+	Section  string `json:"section"`
+	sortKey  int
+}
+
+// TimelineResponse lists a page's dated events in chronological order
+type TimelineResponse struct {
+	Title       string          `json:"title"`
+	Section     string          `json:"section,omitempty"` // the section searched, if not the whole page
+	Events      []TimelineEvent `json:"events"`
+	TotalEvents int             `json:"total_events"`
+	Truncated   bool            `json:"truncated"`
+}
+
+// GlossaryEntry is a term and its definition
+type GlossaryEntry struct {
+	Term       string `json:"term"`
+	Definition string `json:"definition"`
+	Source     string `json:"source"`         // "definition_list", "template" or "abbreviation"
+	Page       string `json:"page,omitempty"` // the page defining it, when scanning a category
+}
+
+// GlossaryResponse lists the glossary entries on a page or in a category
+type GlossaryResponse struct {
+	Title        string          `json:"title,omitempty"`
+	Category     string          `json:"category,omitempty"`
+	Entries      []GlossaryEntry `json:"entries"`
+	TotalEntries int             `json:"total_entries"`
+	PagesScanned int             `json:"pages_scanned"`
+	Truncated    bool            `json:"truncated"`
+}
+
+// Reference is a citation on a page
+type Reference struct {
+	Index       int          `json:"index"`              // 1-based, in page order
+	Name        string       `json:"name,omitempty"`     // <ref name="...">
+	Template    string       `json:"template,omitempty"` // citation template, e.g. "cite journal"
+	Title       string       `json:"title,omitempty"`
+	URL         string       `json:"url,omitempty"`
+	Text        string       `json:"text"`
+	Identifiers []Identifier `json:"identifiers,omitempty"`
+}
+
+// Identifier is a normalized scholarly identifier with a resolver URL
+type Identifier struct {
+	Type  string `json:"type"` // "doi", "isbn", "pmid", "pmc", or "arxiv"
+	Value string `json:"value"`
+	URL   string `json:"url"`
+}
+
+// ReferencesResponse lists a page's references and the distinct
+// identifiers cited in them
+type ReferencesResponse struct {
+	Title           string       `json:"title"`
+	References      []Reference  `json:"references,omitempty"`
+	Identifiers     []Identifier `json:"identifiers"`
+	TotalReferences int          `json:"total_references"`
+}
+
+// SisterLink is a link from a page to a sister project such as Wiktionary
+type SisterLink struct {
+	Project string `json:"project"` // e.g. "wiktionary", "wikisource", "commons"
+	Prefix  string `json:"prefix"`  // interwiki prefix as written, e.g. "wikt"
+	Title   string `json:"title"`   // title on the target wiki
+	URL     string `json:"url"`
+	WikiURL string `json:"wiki_url,omitempty"` // base URL of the target wiki, for use with other tools
+}
+
+// SisterLinksResponse lists a page's sister-project links
+type SisterLinksResponse struct {
+	Title string       `json:"title"`
+	Links []SisterLink `json:"links"`
+}
+
+// LanguageVersion is one language's version of an article
+type LanguageVersion struct {
+	Language string `json:"language,omitempty"`
+	WikiURL  string `json:"wiki_url"`
+	Title    string `json:"title"`
+	URL      string `json:"url,omitempty"`
+	Bytes    int    `json:"bytes"`
+	Sections int    `json:"sections"`
+}
+
+// LanguageSection is a section of one language version
+type LanguageSection struct {
+	Title string `json:"title"`
+	Level int    `json:"level"`
+	Bytes int    `json:"bytes"` // wikitext bytes before the first subsection
+}
+
+// SectionAlignment pairs sections of two language versions that cover the
+// same material
+type SectionAlignment struct {
+	Source     LanguageSection `json:"source"`
+	Target     LanguageSection `json:"target"`
+	Confidence float64         `json:"confidence"`
+	Basis      string          `json:"basis"` // "standard_heading" or "shared_content"
+}
+
+// LanguageComparison reports how two language versions of an article differ
+// in coverage
+type LanguageComparison struct {
+	Source          LanguageVersion    `json:"source"`
+	Target          LanguageVersion    `json:"target"`
+	Aligned         []SectionAlignment `json:"aligned"`
+	MissingInTarget []LanguageSection  `json:"missing_in_target"`
+	MissingInSource []LanguageSection  `json:"missing_in_source"`
+}
+
+// FarmWiki is a member wiki of a wiki farm or family
+type FarmWiki struct {
+	Name     string `json:"name"`
+	WikiURL  string `json:"wiki_url"`
+	APIURL   string `json:"api_url"`
+	DBName   string `json:"dbname,omitempty"`
+	Language string `json:"language,omitempty"`
+	Project  string `json:"project,omitempty"` // e.g. "wiki", "wiktionary", or a special wiki's code
+	Closed   bool   `json:"closed,omitempty"`
+	Private  bool   `json:"private,omitempty"`
+}
+
+// FarmResponse lists the wikis of a farm
+type FarmResponse struct {
+	FarmURL   string     `json:"farm_url"`
+	Source    string     `json:"source"` // "sitematrix" or "interwiki"
+	Wikis     []FarmWiki `json:"wikis"`
+	Total     int        `json:"total"` // matching wikis, before the limit
+	Truncated bool       `json:"truncated"`
+}
+
+// PageRef identifies a page on a particular wiki
+type PageRef struct {
+	WikiURL string `json:"wiki_url"`
+	Title   string `json:"title"`
+}
+
+// DuplicatePage is a page whose content matches a group's canonical page
+type DuplicatePage struct {
+	PageRef
+	Distance int  `json:"distance"` // simhash Hamming distance to the canonical page
+	Exact    bool `json:"exact"`    // identical normalized content
+}
+
+// DuplicateGroup is a set of near-identical pages and the source to keep
+type DuplicateGroup struct {
+	Canonical  PageRef         `json:"canonical"`
+	Duplicates []DuplicatePage `json:"duplicates"`
+}
+
+// DedupResult reports near-duplicate pages found across wikis
+type DedupResult struct {
+	PagesChecked int              `json:"pages_checked"`
+	UniquePages  int              `json:"unique_pages"`
+	Groups       []DuplicateGroup `json:"groups"`
+	Canonical    []PageRef        `json:"canonical"` // one page per distinct content, in source order
+	Skipped      []PageRef        `json:"skipped,omitempty"`
 }
 
 // Backlink represents a page that links to another
@@ -108,10 +381,72 @@ type Backlink struct {
 
 // BacklinksResponse contains backlinks information
 type BacklinksResponse struct {
-	Title         string     `json:"title"`
-	Backlinks     []Backlink `json:"backlinks"`
-	TotalCount    int        `json:"total_count"`
-	ContinueToken *string    `json:"continue_token,omitempty"`
+	Title         string      `json:"title"`
+	Backlinks     []Backlink  `json:"backlinks"`
+	TotalCount    int         `json:"total_count"`
+	ContinueToken *string     `json:"continue_token,omitempty"`
+	LimitClamped  *LimitClamp `json:"limit_clamped,omitempty"`
+}
+
+// SubpageNode is a page in a subpage hierarchy
+type SubpageNode struct {
+	Title    string         `json:"title"`
+	Name     string         `json:"name"` // last path segment
+	Exists   bool           `json:"exists"`
+	Children []*SubpageNode `json:"children,omitempty"`
+}
+
+// SubpagesResponse contains the subpage tree below a page
+type SubpagesResponse struct {
+	Title      string         `json:"title"`
+	Subpages   []*SubpageNode `json:"subpages"`
+	TotalCount int            `json:"total_count"`
+	Truncated  bool           `json:"truncated"`
+}
+
+// TitleStatus says whether a title exists on a wiki, and where it leads
+type TitleStatus struct {
+	Title          string `json:"title"`                     // as given
+	Normalized     string `json:"normalized,omitempty"`      // the wiki's form of the title, when it differs
+	Exists         bool   `json:"exists"`                    // true for redirects, which are pages
+	Namespace      int    `json:"namespace"`                 // namespace number
+	NamespaceName  string `json:"namespace_name"`            // local name, empty for the main namespace
+	PageID         int    `json:"page_id,omitempty"`         // omitted for redirects
+	RedirectTarget string `json:"redirect_target,omitempty"` // final target of a redirect, with any #section
+	TargetExists   bool   `json:"target_exists,omitempty"`   // the redirect target exists
+	Interwiki      string `json:"interwiki,omitempty"`       // prefix of the wiki the title points to instead
+	Invalid        string `json:"invalid,omitempty"`         // why the title is invalid
+}
+
+// TitlesExistResponse reports on a batch of titles, in the order given
+type TitlesExistResponse struct {
+	Titles       []TitleStatus `json:"titles"`
+	ExistCount   int           `json:"exist_count"`
+	MissingCount int           `json:"missing_count"`
+}
+
+// DiscussionComment is a single comment in a discussion thread
+type DiscussionComment struct {
+	ID        string               `json:"id,omitempty"`
+	Author    string               `json:"author"`
+	Timestamp string               `json:"timestamp,omitempty"`
+	Content   string               `json:"content"`
+	Replies   []*DiscussionComment `json:"replies,omitempty"`
+}
+
+// DiscussionThread is a topic with its reply tree
+type DiscussionThread struct {
+	ID       string               `json:"id,omitempty"`
+	Title    string               `json:"title"`
+	Comments []*DiscussionComment `json:"comments"`
+}
+
+// DiscussionsResponse contains structured discussion threads of a talk page
+type DiscussionsResponse struct {
+	Title        string              `json:"title"`
+	Format       string              `json:"format"` // "discussiontools" or "flow"
+	Threads      []*DiscussionThread `json:"threads"`
+	TotalThreads int                 `json:"total_threads"`
 }
 
 // RevisionInfo contains information about a revision
@@ -127,46 +462,333 @@ type CompareResponse struct {
 	From         RevisionInfo `json:"from"`
 	To           RevisionInfo `json:"to"`
 	DiffSummary  string       `json:"diff_summary"`
+	DiffStats    DiffStats    `json:"diff_stats"`
 	DiffMarkdown string       `json:"diff_markdown"`
 }
 
+// HistorySearchResponse lists the revisions of a page in which a pattern
+// appeared in or disappeared from its wikitext, oldest first
+type HistorySearchResponse struct {
+	Title            string          `json:"title"`
+	Pattern          string          `json:"pattern"`
+	Changes          []HistoryChange `json:"changes"`
+	Present          bool            `json:"present"` // in the last revision scanned
+	RevisionsScanned int             `json:"revisions_scanned"`
+	Truncated        bool            `json:"truncated"` // stopped before the current revision
+}
+
+// HistoryChange is a revision that added or removed a searched pattern
+type HistoryChange struct {
+	Change string `json:"change"` // "added" or "removed"
+	RevisionInfo
+	Comment string `json:"comment,omitempty"`
+}
+
+// BlameResponse identifies the edit that introduced a piece of text into a page
+type BlameResponse struct {
+	Title            string       `json:"title"`
+	Snippet          string       `json:"snippet"`
+	Revision         RevisionInfo `json:"revision"`
+	Comment          string       `json:"comment,omitempty"`
+	DiffMarkdown     string       `json:"diff_markdown"`
+	RevisionsChecked int          `json:"revisions_checked"`
+	Truncated        bool         `json:"truncated"` // the text predates the revisions examined; revision is the oldest of them
+}
+
+// MaintenanceReport is a page of one of the wiki's special page reports,
+// such as unused files or broken redirects
+type MaintenanceReport struct {
+	Report     string             `json:"report"`
+	Entries    []MaintenanceEntry `json:"entries"`
+	Cached     bool               `json:"cached"`                // from the wiki's periodically updated copy of the report
+	CachedAt   string             `json:"cached_at,omitempty"`   // when the wiki last updated it
+	NextOffset *int               `json:"next_offset,omitempty"` // pass as offset for the next page
+}
+
+// MaintenanceEntry is a page listed in a maintenance report
+type MaintenanceEntry struct {
+	Title       string `json:"title"`
+	Links       int    `json:"links,omitempty"`        // wanted reports: links to the missing page
+	Target      string `json:"target,omitempty"`       // redirect reports: where the redirect points
+	FinalTarget string `json:"final_target,omitempty"` // double redirects: where the second redirect points
+	Timestamp   string `json:"timestamp,omitempty"`    // unused files: when the file was uploaded
+}
+
 // MediaWiki API response structures (internal use)
 
 type mwResponse struct {
-	Query   *mwQuery   `json:"query"`
-	Parse   *mwParse   `json:"parse"`
-	Compare *mwCompare `json:"compare"`
-	Error   *mwError   `json:"error"`
+	Continue                mwContinue                 `json:"continue"`
+	Query                   *mwQuery                   `json:"query"`
+	Parse                   *mwParse                   `json:"parse"`
+	Compare                 *mwCompare                 `json:"compare"`
+	Edit                    *mwEdit                    `json:"edit"`
+	Move                    *mwMove                    `json:"move"`
+	Delete                  *mwDelete                  `json:"delete"`
+	Upload                  *mwUpload                  `json:"upload"`
+	Rollback                *mwRollback                `json:"rollback"`
+	Login                   *mwLogin                   `json:"login"`
+	DiscussionToolsPageInfo *mwDiscussionToolsPageInfo `json:"discussiontoolspageinfo"`
+	DiscussionToolsEdit     *mwDiscussionToolsEdit     `json:"discussiontoolsedit"`
+	Flow                    *mwFlow                    `json:"flow"`
+	SiteMatrix              *mwSiteMatrix              `json:"sitematrix"`
+	Error                   *mwError                   `json:"error"`
+	Errors                  []mwErrorMessage           `json:"errors"`
 }
 
 type mwQuery struct {
 	General         *mwGeneral             `json:"general"`
 	Namespaces      map[string]mwNamespace `json:"namespaces"`
 	Statistics      *mwStatistics          `json:"statistics"`
-	Search          []mwSearchResult       `json:"search"`
+	Search          []MWSearchResult       `json:"search"`
 	SearchInfo      *mwSearchInfo          `json:"searchinfo"`
-	Pages           map[string]mwPage      `json:"pages"`
+	Pages           mwPages                `json:"pages"`
 	Backlinks       []mwBacklink           `json:"backlinks"`
 	Categorymembers []mwCategoryMember     `json:"categorymembers"`
+	Extensions      []mwExtension          `json:"extensions"`
+	Allpages        []mwAllPage            `json:"allpages"`
+	Normalized      []mwTitleMapping       `json:"normalized"`
+	Redirects       []mwTitleMapping       `json:"redirects"`
+	Interwiki       []mwInterwikiTitle     `json:"interwiki"`
+	InterwikiMap    []mwInterwiki          `json:"interwikimap"`
+	UserInfo        *mwUserInfo            `json:"userinfo"`
+	Tokens          *mwTokens              `json:"tokens"`
+	QueryPage       *mwQueryPage           `json:"querypage"`
+	LogEvents       []mwLogEvent           `json:"logevents"`
+}
+
+// mwContinue holds the parameters that continue a query. Offsets such as
+// sroffset and qpoffset come as numbers, the rest as strings; all are kept
+// as strings to send back.
+type mwContinue map[string]string
+
+func (c *mwContinue) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*c = make(mwContinue, len(raw))
+	for key, value := range raw {
+		var s string
+		if json.Unmarshal(value, &s) != nil {
+			s = string(value)
+		}
+		(*c)[key] = s
+	}
+	return nil
+}
+
+// mwQueryPage is a special page report (list=querypage)
+type mwQueryPage struct {
+	Name            string              `json:"name"`
+	Cached          mwBool              `json:"cached"`
+	CachedTimestamp string              `json:"cachedtimestamp"`
+	Results         []mwQueryPageResult `json:"results"`
+}
+
+type mwQueryPageResult struct {
+	Ns        int             `json:"ns"`
+	Title     string          `json:"title"`
+	Value     json.RawMessage `json:"value"` // a number, sent as a string or a number
+	Timestamp string          `json:"timestamp"`
+
+	// Extra columns of uncached reports, e.g. the redirect target
+	DatabaseResult map[string]json.RawMessage `json:"databaseResult"`
+}
+
+type mwTokens struct {
+	CSRFToken     string `json:"csrftoken"`
+	LoginToken    string `json:"logintoken"`
+	RollbackToken string `json:"rollbacktoken"`
+}
+
+// mwLogin is the result of action=login
+type mwLogin struct {
+	Result     string    `json:"result"`
+	Reason     mwMessage `json:"reason"`
+	LgUsername string    `json:"lgusername"`
+}
+
+// mwMessage is a message the API sends as a string in the legacy error
+// format and as an object with its code and text in the others
+type mwMessage string
+
+func (m *mwMessage) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*m = mwMessage(text)
+		return nil
+	}
+	var message struct {
+		Code string `json:"code"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(data, &message); err != nil {
+		return err
+	}
+	if message.Text == "" {
+		message.Text = message.Code
+	}
+	*m = mwMessage(message.Text)
+	return nil
+}
+
+// mwEdit is the result of action=edit
+type mwEdit struct {
+	Result       string `json:"result"`
+	PageID       int    `json:"pageid"`
+	Title        string `json:"title"`
+	OldRevID     int    `json:"oldrevid"`
+	NewRevID     int    `json:"newrevid"`
+	NewTimestamp string `json:"newtimestamp"`
+	NoChange     mwBool `json:"nochange"`
+	New          mwBool `json:"new"`
+}
+
+// mwMove is the result of action=move. Talk page fields are set when the
+// talk page was moved too, or failed to move.
+type mwMove struct {
+	From            string           `json:"from"`
+	To              string           `json:"to"`
+	Reason          string           `json:"reason"`
+	RedirectCreated mwBool           `json:"redirectcreated"`
+	TalkFrom        string           `json:"talkfrom"`
+	TalkTo          string           `json:"talkto"`
+	TalkMoveErrors  []mwErrorMessage `json:"talkmove-errors"`
+
+	// Without errorformat, a talk page failure comes as a code and info
+	TalkMoveErrorCode string `json:"talkmove-error-code"`
+	TalkMoveErrorInfo string `json:"talkmove-error-info"`
+}
+
+// mwDelete is the result of action=delete
+type mwDelete struct {
+	Title  string `json:"title"`
+	Reason string `json:"reason"`
+	LogID  int    `json:"logid"`
+}
+
+// mwRollback is the result of action=rollback
+type mwRollback struct {
+	Title     string `json:"title"`
+	PageID    int    `json:"pageid"`
+	Summary   string `json:"summary"`
+	RevID     int    `json:"revid"`
+	OldRevID  int    `json:"old_revid"`
+	LastRevID int    `json:"last_revid"`
+}
+
+// mwUpload is the result of action=upload. Result is "Warning", with
+// Warnings, when the upload was held back.
+type mwUpload struct {
+	Result    string                     `json:"result"`
+	Filename  string                     `json:"filename"`
+	Warnings  map[string]json.RawMessage `json:"warnings"`
+	ImageInfo *mwUploadImageInfo         `json:"imageinfo"`
+}
+
+// mwUploadImageInfo describes an uploaded file
+type mwUploadImageInfo struct {
+	URL            string `json:"url"`
+	DescriptionURL string `json:"descriptionurl"`
+	Size           int    `json:"size"`
+	Width          int    `json:"width"`
+	Height         int    `json:"height"`
+	MIME           string `json:"mime"`
+	SHA1           string `json:"sha1"`
+}
+
+// mwLogEvent is a log entry (list=logevents)
+type mwLogEvent struct {
+	LogID     int                    `json:"logid"`
+	Title     string                 `json:"title"`
+	Type      string                 `json:"type"`
+	Action    string                 `json:"action"`
+	User      string                 `json:"user"`
+	Timestamp string                 `json:"timestamp"`
+	Comment   string                 `json:"comment"`
+	Params    map[string]interface{} `json:"params"`
+}
+
+// mwUserInfo is the account requests are made as (meta=userinfo)
+type mwUserInfo struct {
+	ID           int                               `json:"id"`
+	Name         string                            `json:"name"`
+	Anon         mwBool                            `json:"anon"`
+	Rights       []string                          `json:"rights"`
+	Groups       []string                          `json:"groups"`
+	EditCount    int                               `json:"editcount"`
+	Registration string                            `json:"registration"`
+	RateLimits   map[string]map[string]mwRateLimit `json:"ratelimits"`
+
+	BlockID        int    `json:"blockid"`
+	BlockedBy      string `json:"blockedby"`
+	BlockReason    string `json:"blockreason"`
+	BlockExpiry    string `json:"blockexpiry"`
+	BlockPartial   mwBool `json:"blockpartial"`
+	BlockTimestamp string `json:"blockedtimestamp"`
+}
+
+// mwRateLimit is a rate limit on an action, by the kind of user it applies
+// to (uiprop=ratelimits)
+type mwRateLimit struct {
+	Hits    int `json:"hits"`
+	Seconds int `json:"seconds"`
 }
 
 type mwGeneral struct {
-	Sitename string `json:"sitename"`
-	Base     string `json:"base"`
-	MainPage string `json:"mainpage"`
-	Lang     string `json:"lang"`
+	Sitename    string `json:"sitename"`
+	Base        string `json:"base"`
+	MainPage    string `json:"mainpage"`
+	Lang        string `json:"lang"`
+	Generator   string `json:"generator"`
+	Server      string `json:"server"`      // e.g. "https://en.wikipedia.org" or "//en.wikipedia.org"
+	ArticlePath string `json:"articlepath"` // e.g. "/wiki/$1"
+}
+
+// mwTitleMapping records a title rewritten by the API (normalization or redirect)
+type mwTitleMapping struct {
+	From       string `json:"from"`
+	To         string `json:"to"`
+	ToFragment string `json:"tofragment"`
+}
+
+// mwInterwikiTitle is a queried title that points to another wiki
+type mwInterwikiTitle struct {
+	Title string `json:"title"`
+	IW    string `json:"iw"`
+}
+
+type mwExtension struct {
+	Name string `json:"name"`
 }
 
 type mwNamespace struct {
-	ID   int    `json:"id"`
-	Name string `json:"*"`
+	ID   int
+	Name string
+}
+
+// UnmarshalJSON reads the name from "name" (formatversion=2) or "*" (older
+// formats)
+func (n *mwNamespace) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+		Star string `json:"*"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	n.ID = raw.ID
+	n.Name = cmp.Or(raw.Name, raw.Star)
+	return nil
 }
 
 type mwStatistics struct {
 	Articles int `json:"articles"`
 }
 
-type mwSearchResult struct {
+// MWSearchResult represents a raw search hit (exported for use in tools)
+type MWSearchResult struct {
 	Title     string `json:"title"`
 	Snippet   string `json:"snippet"`
 	WordCount int    `json:"wordcount"`
@@ -176,18 +798,124 @@ type mwSearchInfo struct {
 	Suggestion string `json:"suggestion"`
 }
 
+// mwBool decodes an API boolean in either format: true or false with
+// formatversion=2, or an empty string when true (and omitted when false) in
+// the legacy format
+type mwBool bool
+
+func (b *mwBool) UnmarshalJSON(data []byte) error {
+	*b = string(data) != "false" && string(data) != "null"
+	return nil
+}
+
+// mwPages holds query pages; formatversion=2 returns a list, older
+// formats return an object keyed by page ID
+type mwPages []mwPage
+
+// UnmarshalJSON accepts both the list and object forms of query pages
+func (p *mwPages) UnmarshalJSON(data []byte) error {
+	var list []mwPage
+	if err := json.Unmarshal(data, &list); err == nil {
+		*p = list
+		return nil
+	}
+
+	var byID map[string]mwPage
+	if err := json.Unmarshal(data, &byID); err != nil {
+		return err
+	}
+	pages := make(mwPages, 0, len(byID))
+	for _, page := range byID {
+		pages = append(pages, page)
+	}
+	*p = pages
+	return nil
+}
+
 type mwPage struct {
-	PageID     int          `json:"pageid"`
-	Title      string       `json:"title"`
-	Missing    bool         `json:"missing"`
-	Redirect   bool         `json:"redirect"`
-	Revisions  []mwRevision `json:"revisions"`
-	Categories []mwCategory `json:"categories"`
-	Links      []MWLink     `json:"links"`
+	PageID          int                         `json:"pageid"`
+	Ns              int                         `json:"ns"`
+	Title           string                      `json:"title"`
+	Missing         mwBool                      `json:"missing"`
+	Invalid         mwBool                      `json:"invalid"`
+	InvalidReason   string                      `json:"invalidreason"`
+	Redirect        mwBool                      `json:"redirect"`
+	Length          int                         `json:"length"`
+	LastRevID       int                         `json:"lastrevid"`
+	Revisions       []mwRevision                `json:"revisions"`
+	Categories      []mwCategory                `json:"categories"`
+	Links           []MWLink                    `json:"links"`
+	CategoryInfo    *mwCategoryInfo             `json:"categoryinfo"`
+	PageAssessments map[string]mwPageAssessment `json:"pageassessments"`
+	IWLinks         []MWInterwikiLink           `json:"iwlinks"`
+	LangLinks       []MWLangLink                `json:"langlinks"`
+	Coordinates     []mwCoordinate              `json:"coordinates"`
+}
+
+type mwCoordinate struct {
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+	Primary mwBool  `json:"primary"`
+	Globe   string  `json:"globe"`
+	Type    string  `json:"type"`
+	Name    string  `json:"name"`
+}
+
+// MWLangLink is a link to the same article in another language
+type MWLangLink struct {
+	Lang  string `json:"lang"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// MWInterwikiLink is an interwiki link from a page
+type MWInterwikiLink struct {
+	Prefix string `json:"prefix"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+}
+
+type mwPageAssessment struct {
+	Class      string `json:"class"`
+	Importance string `json:"importance"`
+}
+
+type mwCategoryInfo struct {
+	Size    int `json:"size"`
+	Pages   int `json:"pages"`
+	Files   int `json:"files"`
+	Subcats int `json:"subcats"`
 }
 
 type mwRevision struct {
-	Content string `json:"*"`
+	RevID      int       `json:"revid"`
+	ParentID   int       `json:"parentid"`
+	Timestamp  time.Time `json:"timestamp"`
+	User       string    `json:"user"`
+	Comment    string    `json:"comment"`
+	Size       int       `json:"size"`
+	Content    string    `json:"*"`
+	TextHidden mwBool    `json:"texthidden"`
+	Slots      map[string]struct {
+		Content    string `json:"content"`
+		TextHidden mwBool `json:"texthidden"`
+	} `json:"slots"`
+}
+
+// Hidden reports whether the revision's text was deleted or suppressed
+func (r mwRevision) Hidden() bool {
+	if main, ok := r.Slots["main"]; ok {
+		return bool(main.TextHidden)
+	}
+	return bool(r.TextHidden)
+}
+
+// Text returns the revision's main-slot content in either response format
+func (r mwRevision) Text() string {
+	if main, ok := r.Slots["main"]; ok {
+		return main.Content
+	}
+	return r.Content
 }
 
 type mwCategory struct {
@@ -202,6 +930,7 @@ type MWLink struct {
 type mwParse struct {
 	Title      string       `json:"title"`
 	PageID     int          `json:"pageid"`
+	RevID      int          `json:"revid"`
 	Text       mwText       `json:"text"`
 	Sections   []MWSection  `json:"sections"`
 	Categories []mwCategory `json:"categories"`
@@ -236,37 +965,204 @@ func (t *mwText) UnmarshalJSON(data []byte) error {
 
 // MWSection represents a MediaWiki section (exported for use in tools)
 type MWSection struct {
-	TocLevel int    `json:"toclevel"`
-	Level    string `json:"level"`
-	Line     string `json:"line"`
-	Number   string `json:"number"`
-	Index    string `json:"index"`
+	TocLevel   int    `json:"toclevel"`
+	Level      string `json:"level"`
+	Line       string `json:"line"`
+	Number     string `json:"number"`
+	Index      string `json:"index"`
+	FromTitle  string `json:"fromtitle"`
+	ByteOffset *int   `json:"byteoffset"` // null for sections transcluded from templates
+	Anchor     string `json:"anchor"`
 }
 
 type mwProperties struct {
 	WikibaseItem string `json:"wikibase_item"`
 }
 
-type mwBacklink struct {
+type mwAllPage struct {
 	PageID int    `json:"pageid"`
+	Ns     int    `json:"ns"`
 	Title  string `json:"title"`
 }
 
-type mwCategoryMember struct {
+type mwBacklink struct {
 	PageID int    `json:"pageid"`
 	Title  string `json:"title"`
-	Type   string `json:"type"`
+}
+
+type mwCategoryMember struct {
+	PageID    int    `json:"pageid"`
+	Title     string `json:"title"`
+	Type      string `json:"type"`
+	Timestamp string `json:"timestamp"`
 }
 
 type mwCompare struct {
-	FromID    int    `json:"fromid"`
-	FromRevID int    `json:"fromrevid"`
-	ToID      int    `json:"toid"`
-	ToRevID   int    `json:"torevid"`
-	Body      string `json:"*"`
+	FromID        int       `json:"fromid"`
+	FromRevID     int       `json:"fromrevid"`
+	FromTimestamp time.Time `json:"fromtimestamp"`
+	FromUser      string    `json:"fromuser"`
+	ToID          int       `json:"toid"`
+	ToRevID       int       `json:"torevid"`
+	ToTimestamp   time.Time `json:"totimestamp"`
+	ToUser        string    `json:"touser"`
+	Body          string    `json:"*"`
+	BodyV2        string    `json:"body"` // formatversion=2 name of Body
+}
+
+// DiffBody returns the HTML diff rows in either format version
+func (c *mwCompare) DiffBody() string {
+	if c.BodyV2 != "" {
+		return c.BodyV2
+	}
+	return c.Body
 }
 
 type mwError struct {
 	Code string `json:"code"`
 	Info string `json:"info"`
+	mwErrorData
+}
+
+// mwErrorMessage is an error entry when errorformat=plaintext is used
+type mwErrorMessage struct {
+	Code   string      `json:"code"`
+	Text   string      `json:"text"`
+	Module string      `json:"module"`
+	Data   mwErrorData `json:"data"`
+}
+
+// mwErrorData carries extension-specific details attached to write errors
+type mwErrorData struct {
+	AbuseFilter   *AbuseFilterInfo `json:"abusefilter"`
+	BlockInfo     *BlockInfo       `json:"blockinfo"`
+	SpamBlacklist *struct {
+		Matches []string `json:"matches"`
+	} `json:"spamblacklist"`
+}
+
+// AbuseFilterInfo describes the AbuseFilter rule that stopped an action
+type AbuseFilterInfo struct {
+	ID          json.Number `json:"id"`
+	Description string      `json:"description"`
+	Actions     []string    `json:"actions"`
+}
+
+// BlockInfo describes a block preventing the current user from acting
+type BlockInfo struct {
+	BlockID      int    `json:"blockid"`
+	BlockedBy    string `json:"blockedby"`
+	BlockReason  string `json:"blockreason"`
+	BlockExpiry  string `json:"blockexpiry"`
+	BlockPartial bool   `json:"blockpartial"`
+}
+
+// mwDiscussionToolsEdit is the result of action=discussiontoolsedit
+type mwDiscussionToolsEdit struct {
+	Result   string `json:"result"`
+	NewRevID int    `json:"newrevid"`
+}
+
+type mwDiscussionToolsPageInfo struct {
+	ThreadItemsHTML []MWThreadItem `json:"threaditemshtml"`
+}
+
+// MWThreadItem is a heading or comment from DiscussionTools (exported for use in tools)
+type MWThreadItem struct {
+	Type      string         `json:"type"` // "heading" or "comment"
+	ID        string         `json:"id"`
+	Level     int            `json:"level"`
+	Author    string         `json:"author"`
+	Timestamp string         `json:"timestamp"`
+	HTML      string         `json:"html"`
+	Replies   []MWThreadItem `json:"replies"`
+}
+
+type mwFlow struct {
+	ViewTopicList struct {
+		Result struct {
+			TopicList MWFlowTopicList `json:"topiclist"`
+		} `json:"result"`
+	} `json:"view-topiclist"`
+}
+
+// MWFlowTopicList is a StructuredDiscussions board (exported for use in tools)
+type MWFlowTopicList struct {
+	Roots     []string                  `json:"roots"`
+	Posts     map[string][]string       `json:"posts"`
+	Revisions map[string]MWFlowRevision `json:"revisions"`
+}
+
+// MWFlowRevision is a revision of a StructuredDiscussions post
+type MWFlowRevision struct {
+	PostID  string `json:"postId"`
+	Content struct {
+		Content string `json:"content"`
+		Format  string `json:"format"`
+	} `json:"content"`
+	Author struct {
+		Name string `json:"name"`
+	} `json:"author"`
+	Timestamp string   `json:"timestamp"`
+	Replies   []string `json:"replies"`
+}
+
+type mwInterwiki struct {
+	Prefix   string `json:"prefix"`
+	Local    mwBool `json:"local"`
+	URL      string `json:"url"`
+	API      string `json:"api"`
+	Language string `json:"language"`
+}
+
+// mwSiteMatrix is the SiteMatrix extension's response: numbered language
+// groups alongside "count" and a "specials" list
+type mwSiteMatrix struct {
+	Languages []mwSiteMatrixLanguage
+	Specials  []MWSiteMatrixSite
+}
+
+type mwSiteMatrixLanguage struct {
+	Code string             `json:"code"`
+	Name string             `json:"name"`
+	Site []MWSiteMatrixSite `json:"site"`
+}
+
+// MWSiteMatrixSite is a wiki listed by SiteMatrix
+type MWSiteMatrixSite struct {
+	URL      string `json:"url"`
+	DBName   string `json:"dbname"`
+	Code     string `json:"code"`
+	Lang     string `json:"lang"`
+	SiteName string `json:"sitename"`
+	Closed   mwBool `json:"closed"`
+	Private  mwBool `json:"private"`
+}
+
+func (m *mwSiteMatrix) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	for key, value := range raw {
+		switch key {
+		case "count":
+		case "specials":
+			if err := json.Unmarshal(value, &m.Specials); err != nil {
+				return err
+			}
+		default:
+			var lang mwSiteMatrixLanguage
+			if err := json.Unmarshal(value, &lang); err != nil {
+				return err
+			}
+			m.Languages = append(m.Languages, lang)
+		}
+	}
+
+	sort.Slice(m.Languages, func(i, j int) bool {
+		return m.Languages[i].Code < m.Languages[j].Code
+	})
+	return nil
 }
@@ -0,0 +1,261 @@
+package wiki
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"syscall"
+)
+
+// UploadParams describes a file upload
+type UploadParams struct {
+	Filename       string // name on the wiki, without the File: prefix
+	Data           []byte
+	Description    string // wikitext for the file page's summary
+	License        string // wikitext for its licensing section, usually a license template
+	Comment        string // upload log comment
+	IgnoreWarnings bool   // upload despite warnings such as an existing file or a duplicate
+}
+
+// UploadResult is the outcome of a file upload
+type UploadResult struct {
+	Filename       string `json:"filename"`
+	Title          string `json:"title"`
+	URL            string `json:"url,omitempty"`             // the file itself
+	DescriptionURL string `json:"description_url,omitempty"` // its file page
+	Size           int    `json:"size,omitempty"`
+	Width          int    `json:"width,omitempty"`
+	Height         int    `json:"height,omitempty"`
+	MIME           string `json:"mime,omitempty"`
+	SHA1           string `json:"sha1,omitempty"`
+}
+
+// UploadWarningError is an upload the wiki held back because of warnings,
+// such as a file with the same name or content. Warnings maps the warning
+// codes to what they concern, often the conflicting file.
+type UploadWarningError struct {
+	Filename string
+	Warnings map[string]string
+}
+
+func (e *UploadWarningError) Error() string {
+	codes := make([]string, 0, len(e.Warnings))
+	for code := range e.Warnings {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return fmt.Sprintf("upload of %q not saved because of warnings: %s", e.Filename, strings.Join(codes, ", "))
+}
+
+// FileTooLargeError is a file larger than the upload limit
+type FileTooLargeError struct {
+	Source   string // URL or argument the file came from
+	MaxBytes int64
+}
+
+func (e *FileTooLargeError) Error() string {
+	return fmt.Sprintf("file from %s exceeds the %d byte upload limit", e.Source, e.MaxBytes)
+}
+
+// uploadFile is a file sent with a write action
+type uploadFile struct {
+	filename string
+	data     []byte
+}
+
+// encode returns params and the file as a multipart/form-data body, with
+// its content type. The file comes last, as the API documentation asks.
+func (f *uploadFile) encode(params url.Values) (io.Reader, string, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range params[key] {
+			if err := w.WriteField(key, value); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+
+	part, err := w.CreateFormFile("file", f.filename)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := part.Write(f.data); err != nil {
+		return nil, "", err
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return &body, w.FormDataContentType(), nil
+}
+
+// Upload uploads a file with action=upload and invalidates its file page's
+// cached content. Like Edit, it is made with the wiki's Auth; the account
+// needs the upload right, which callers should check first with
+// RequireRight. The file page gets the description and license as text, in
+// the sections Special:Upload uses. An upload the wiki warns about fails
+// with an UploadWarningError unless IgnoreWarnings is set.
+func (c *Client) Upload(ctx context.Context, wikiURL string, upload UploadParams) (*UploadResult, error) {
+	if b := c.backend(wikiURL); b != nil {
+		return nil, &BackendUnsupportedError{Backend: b.Name(), WikiURL: wikiURL, Request: "upload"}
+	}
+
+	params := url.Values{}
+	params.Set("action", "upload")
+	params.Set("filename", upload.Filename)
+	params.Set("comment", upload.Comment)
+	params.Set("text", UploadPageText(upload.Description, upload.License))
+	if upload.IgnoreWarnings {
+		params.Set("ignorewarnings", "1")
+	}
+
+	file := &uploadFile{filename: upload.Filename, data: upload.Data}
+	resp, err := c.writeRequestWith(ctx, wikiURL, params, writeOptions{file: file})
+	if err != nil {
+		return nil, fmt.Errorf("upload file: %w", err)
+	}
+	if resp.Upload == nil {
+		return nil, fmt.Errorf("empty upload response")
+	}
+	if resp.Upload.Result != "Success" {
+		warnings := make(map[string]string, len(resp.Upload.Warnings))
+		for code, raw := range resp.Upload.Warnings {
+			warnings[code] = warningText(raw)
+		}
+		return nil, &UploadWarningError{Filename: upload.Filename, Warnings: warnings}
+	}
+
+	filename := resp.Upload.Filename
+	if filename == "" {
+		filename = upload.Filename
+	}
+	title := "File:" + filename
+	c.cache.InvalidatePage(wikiURL, title)
+
+	result := &UploadResult{Filename: filename, Title: title}
+	if info := resp.Upload.ImageInfo; info != nil {
+		result.URL = info.URL
+		result.DescriptionURL = info.DescriptionURL
+		result.Size = info.Size
+		result.Width = info.Width
+		result.Height = info.Height
+		result.MIME = info.MIME
+		result.SHA1 = info.SHA1
+	}
+	if result.DescriptionURL == "" {
+		result.DescriptionURL = c.PageURL(ctx, wikiURL, title)
+	}
+	return result, nil
+}
+
+// UploadPageText returns the text Upload gives a new file page
+func UploadPageText(description, license string) string {
+	var b strings.Builder
+	if description = strings.TrimSpace(description); description != "" {
+		b.WriteString("== Summary ==\n")
+		b.WriteString(description)
+		b.WriteString("\n")
+	}
+	if license = strings.TrimSpace(license); license != "" {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("== Licensing ==\n")
+		b.WriteString(license)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// warningText renders an upload warning's value, which is a file name, a
+// list of them, or something else depending on the warning
+func warningText(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var list []string
+	if json.Unmarshal(raw, &list) == nil {
+		return strings.Join(list, ", ")
+	}
+	return string(raw)
+}
+
+// errPrivateAddress is a file URL that resolves to an address on the
+// server's own network
+var errPrivateAddress = errors.New("file URLs must not point at loopback, private, or link-local addresses")
+
+// FetchFile downloads a file to upload from an http or https URL, failing
+// with a FileTooLargeError past maxBytes. The file would be published on a
+// wiki, so addresses on the server's own network are refused, including
+// where a redirect or DNS answer leads.
+func (c *Client) FetchFile(ctx context.Context, rawURL string, maxBytes int64) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("file URL %q: want an http or https URL", rawURL)
+	}
+
+	dialer := &net.Dialer{Control: func(network, address string, _ syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+		if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+			return errPrivateAddress
+		}
+		return nil
+	}}
+	httpClient := &http.Client{
+		Timeout:   c.httpClient.Timeout,
+		Transport: &http.Transport{DialContext: dialer.DialContext},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch file: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch file: %s returned HTTP %d", rawURL, resp.StatusCode)
+	}
+	if resp.ContentLength > maxBytes {
+		return nil, &FileTooLargeError{Source: rawURL, MaxBytes: maxBytes}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("fetch file: %w", err)
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, &FileTooLargeError{Source: rawURL, MaxBytes: maxBytes}
+	}
+	return data, nil
+}
+
+// publicIP reports whether ip is a globally routable address
+func publicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast()
+}
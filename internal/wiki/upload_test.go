@@ -0,0 +1,87 @@
+package wiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUpload(t *testing.T) {
+	var uploaded, text string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Errorf("parse multipart: %v", err)
+			}
+		} else {
+			r.ParseForm()
+		}
+		switch {
+		case r.Form.Get("type") == "csrf":
+			fmt.Fprint(w, `{"query":{"tokens":{"csrftoken":"token"}}}`)
+		case r.Form.Get("action") == "upload" && r.Form.Get("ignorewarnings") == "":
+			fmt.Fprint(w, `{"upload":{"result":"Warning","warnings":{"exists":"Logo.png","duplicate":["Old logo.png"]},"filekey":"abc"}}`)
+		case r.Form.Get("action") == "upload":
+			if r.Form.Get("token") != "token" {
+				t.Errorf("token = %q", r.Form.Get("token"))
+			}
+			f, header, err := r.FormFile("file")
+			if err != nil {
+				t.Fatalf("file part: %v", err)
+			}
+			data, _ := io.ReadAll(f)
+			uploaded = header.Filename + ":" + string(data)
+			text = r.Form.Get("text")
+			fmt.Fprint(w, `{"upload":{"result":"Success","filename":"Logo.png","imageinfo":{"url":"https://example.org/images/Logo.png","size":4,"mime":"image/png","sha1":"abc"}}}`)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	client.SetAuth(srv.URL, Auth{AccessToken: "oauth"})
+
+	params := UploadParams{Filename: "Logo.png", Data: []byte("\x89PNG"), Description: "The logo", License: "{{cc-by-4.0}}"}
+	_, err := client.Upload(context.Background(), srv.URL, params)
+	var warnErr *UploadWarningError
+	if !errors.As(err, &warnErr) || warnErr.Warnings["exists"] != "Logo.png" || warnErr.Warnings["duplicate"] != "Old logo.png" {
+		t.Fatalf("Upload = %v, want warnings for exists and duplicate", err)
+	}
+
+	params.IgnoreWarnings = true
+	result, err := client.Upload(context.Background(), srv.URL, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uploaded != "Logo.png:\x89PNG" {
+		t.Errorf("uploaded %q", uploaded)
+	}
+	if want := "== Summary ==\nThe logo\n\n== Licensing ==\n{{cc-by-4.0}}\n"; text != want {
+		t.Errorf("text = %q, want %q", text, want)
+	}
+	if result.Title != "File:Logo.png" || result.Size != 4 || result.DescriptionURL == "" {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestFetchFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "secret")
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	if _, err := client.FetchFile(context.Background(), srv.URL, 1024); !errors.Is(err, errPrivateAddress) {
+		t.Errorf("FetchFile(loopback) = %v, want it refused", err)
+	}
+	if _, err := client.FetchFile(context.Background(), "file:///etc/passwd", 1024); err == nil {
+		t.Error("FetchFile(file://) succeeded")
+	}
+}
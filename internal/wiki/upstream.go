@@ -0,0 +1,30 @@
+package wiki
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type upstreamCallsKey struct{}
+
+// WithUpstreamCalls returns a context that counts the HTTP requests made to
+// wikis under it, including retries and API path probes
+func WithUpstreamCalls(ctx context.Context) context.Context {
+	return context.WithValue(ctx, upstreamCallsKey{}, new(atomic.Int64))
+}
+
+// UpstreamCalls returns the number of requests made to wikis under ctx
+func UpstreamCalls(ctx context.Context) int {
+	n, ok := ctx.Value(upstreamCallsKey{}).(*atomic.Int64)
+	if !ok {
+		return 0
+	}
+	return int(n.Load())
+}
+
+// countUpstreamCall counts a request made to a wiki
+func countUpstreamCall(ctx context.Context) {
+	if n, ok := ctx.Value(upstreamCallsKey{}).(*atomic.Int64); ok {
+		n.Add(1)
+	}
+}
@@ -0,0 +1,34 @@
+package wiki
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestUpstreamCalls(t *testing.T) {
+	var requests atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fmt.Fprint(w, `{"query":{"general":{"sitename":"Test","generator":"MediaWiki 1.42.0"}}}`)
+	}))
+	defer srv.Close()
+
+	client := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	ctx := WithUpstreamCalls(context.Background())
+	for range 2 {
+		if _, err := client.GetCapabilities(ctx, srv.URL); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := UpstreamCalls(ctx), int(requests.Load()); got != want || got == 0 {
+		t.Errorf("UpstreamCalls = %d, want the %d requests the wiki got", got, want)
+	}
+	if UpstreamCalls(context.Background()) != 0 {
+		t.Error("UpstreamCalls counted without a counter")
+	}
+}
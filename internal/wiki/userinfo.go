@@ -0,0 +1,177 @@
+package wiki
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// GetUserRights returns the rights of the account requests are made as,
+// cached like other wiki info. On wikis with an Auth that is its account.
+func (c *Client) GetUserRights(ctx context.Context, wikiURL string) ([]string, error) {
+	cacheKey := UserRightsCacheKey(wikiURL)
+	var rights []string
+	if c.cache.GetJSON(cacheKey, &rights) {
+		return rights, nil
+	}
+
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("meta", "userinfo")
+	params.Set("uiprop", "rights")
+
+	resp, err := c.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("get user rights: %w", err)
+	}
+
+	if resp.Query == nil || resp.Query.UserInfo == nil {
+		return nil, fmt.Errorf("empty userinfo response")
+	}
+
+	rights = resp.Query.UserInfo.Rights
+	if rights == nil {
+		rights = []string{}
+	}
+	c.cache.SetJSON(cacheKey, rights, c.cacheTTLInfo)
+
+	return rights, nil
+}
+
+// HasRight reports whether the account requests are made as holds a right
+func (c *Client) HasRight(ctx context.Context, wikiURL, right string) (bool, error) {
+	rights, err := c.GetUserRights(ctx, wikiURL)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range rights {
+		if r == right {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// MissingRightError is returned when the account requests are made as
+// lacks a right an action needs, checked before the action is attempted
+type MissingRightError struct {
+	WikiURL string
+	Right   string // e.g. "delete"
+	Action  string // what was refused, e.g. "delete pages"
+}
+
+func (e *MissingRightError) Error() string {
+	return fmt.Sprintf("the account for %s lacks the %q right needed to %s", e.WikiURL, e.Right, e.Action)
+}
+
+// RequireRight returns a *MissingRightError unless the account requests
+// are made as holds right
+func (c *Client) RequireRight(ctx context.Context, wikiURL, right, action string) error {
+	ok, err := c.HasRight(ctx, wikiURL, right)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &MissingRightError{WikiURL: wikiURL, Right: right, Action: action}
+	}
+	return nil
+}
+
+// UserInfo describes the account requests to a wiki are made as
+type UserInfo struct {
+	WikiURL       string     `json:"wiki_url"`
+	Authenticated bool       `json:"authenticated"` // whether the server has credentials for the wiki
+	Anonymous     bool       `json:"anonymous"`     // the wiki sees requests as a logged-out user
+	Name          string     `json:"name"`          // the user name, or the server's IP address when anonymous
+	ID            int        `json:"id,omitempty"`
+	Groups        []string   `json:"groups"`
+	Rights        []string   `json:"rights"`
+	EditCount     int        `json:"edit_count,omitempty"`
+	Registration  *time.Time `json:"registration,omitempty"`
+	Block         *UserBlock `json:"block,omitempty"`
+
+	// RateLimits maps actions such as "edit" and "move" to the limits on
+	// them by the kind of user they apply to ("user", "ip", "newbie", ...).
+	// Accounts with the noratelimit right have none.
+	RateLimits map[string]map[string]RateLimit `json:"rate_limits,omitempty"`
+}
+
+// UserBlock is a block on an account
+type UserBlock struct {
+	ID      int    `json:"id"`
+	By      string `json:"by"`
+	Reason  string `json:"reason,omitempty"`
+	Since   string `json:"since,omitempty"`
+	Expiry  string `json:"expiry"`  // a timestamp, or "infinite"
+	Partial bool   `json:"partial"` // only some pages or namespaces are blocked
+}
+
+// RateLimit is a number of actions allowed in a period
+type RateLimit struct {
+	Hits    int `json:"hits"`
+	Seconds int `json:"seconds"`
+}
+
+// WhoAmI returns the account requests to a wiki are made as, with its
+// groups, rights, rate limits, and any block. Unlike GetUserRights it isn't
+// served from the cache, and it refreshes the cached rights.
+func (c *Client) WhoAmI(ctx context.Context, wikiURL string) (*UserInfo, error) {
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("meta", "userinfo")
+	params.Set("uiprop", "groups|rights|ratelimits|editcount|registration|blockinfo")
+
+	resp, err := c.MakeRequest(ctx, wikiURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("get user info: %w", err)
+	}
+
+	if resp.Query == nil || resp.Query.UserInfo == nil {
+		return nil, fmt.Errorf("empty userinfo response")
+	}
+
+	ui := resp.Query.UserInfo
+	info := &UserInfo{
+		WikiURL:       wikiURL,
+		Authenticated: c.HasAuth(wikiURL),
+		Anonymous:     bool(ui.Anon),
+		Name:          ui.Name,
+		ID:            ui.ID,
+		Groups:        ui.Groups,
+		Rights:        ui.Rights,
+		EditCount:     ui.EditCount,
+	}
+	if info.Groups == nil {
+		info.Groups = []string{}
+	}
+	if info.Rights == nil {
+		info.Rights = []string{}
+	}
+	if registered, err := time.Parse(time.RFC3339, ui.Registration); err == nil {
+		info.Registration = &registered
+	}
+	if ui.BlockID > 0 {
+		info.Block = &UserBlock{
+			ID:      ui.BlockID,
+			By:      ui.BlockedBy,
+			Reason:  ui.BlockReason,
+			Since:   ui.BlockTimestamp,
+			Expiry:  ui.BlockExpiry,
+			Partial: bool(ui.BlockPartial),
+		}
+	}
+	if len(ui.RateLimits) > 0 {
+		info.RateLimits = make(map[string]map[string]RateLimit, len(ui.RateLimits))
+		for action, limits := range ui.RateLimits {
+			info.RateLimits[action] = make(map[string]RateLimit, len(limits))
+			for kind, limit := range limits {
+				info.RateLimits[action][kind] = RateLimit{Hits: limit.Hits, Seconds: limit.Seconds}
+			}
+		}
+	}
+
+	c.cache.SetJSON(UserRightsCacheKey(wikiURL), info.Rights, c.cacheTTLInfo)
+
+	return info, nil
+}
@@ -0,0 +1,51 @@
+package wiki
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWhoAmI(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("meta") != "userinfo" {
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		requests++
+		fmt.Fprint(w, `{"query":{"userinfo":{"id":42,"name":"ExampleBot","groups":["*","user","bot"],
+			"rights":["read","edit","bot"],"editcount":1200,"registration":"2020-05-01T12:00:00Z",
+			"ratelimits":{"edit":{"user":{"hits":90,"seconds":60}}},
+			"blockid":7,"blockedby":"Admin","blockreason":"Testing","blockexpiry":"infinite","blockpartial":true}}}`)
+	}))
+	defer srv.Close()
+	client := NewClient("test", 5*time.Second, 1000, time.Minute, time.Minute)
+	client.SetAuth(srv.URL, Auth{AccessToken: "oauth"})
+	ctx := context.Background()
+
+	info, err := client.WhoAmI(ctx, srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.Authenticated || info.Anonymous || info.Name != "ExampleBot" || len(info.Groups) != 3 || info.EditCount != 1200 {
+		t.Errorf("info = %+v", info)
+	}
+	if info.Registration == nil || info.Registration.Year() != 2020 {
+		t.Errorf("registration = %v", info.Registration)
+	}
+	if limit := info.RateLimits["edit"]["user"]; limit.Hits != 90 || limit.Seconds != 60 {
+		t.Errorf("edit rate limit = %+v", limit)
+	}
+	if info.Block == nil || !info.Block.Partial || info.Block.Expiry != "infinite" {
+		t.Errorf("block = %+v", info.Block)
+	}
+
+	// The rights are cached for RequireRight
+	if err := client.RequireRight(ctx, srv.URL, "bot", "mark edits as bot"); err != nil || requests != 1 {
+		t.Errorf("RequireRight = %v after %d requests, want the cached rights", err, requests)
+	}
+}
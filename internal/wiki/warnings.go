@@ -0,0 +1,79 @@
+package wiki
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// Warning records an optional part of a response, such as the infobox or
+// parent categories, that was left out because fetching it failed
+type Warning struct {
+	Enrichment string `json:"enrichment"`
+	Reason     string `json:"reason"`
+}
+
+type warningsKey struct{}
+
+// warningList collects a call's warnings, passing them on to the list of
+// the enclosing call, if any
+type warningList struct {
+	mu     sync.Mutex
+	items  []Warning
+	parent *warningList
+}
+
+func (l *warningList) add(w Warning) {
+	for ; l != nil; l = l.parent {
+		l.mu.Lock()
+		seen := false
+		for _, item := range l.items {
+			if item == w {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			l.items = append(l.items, w)
+		}
+		l.mu.Unlock()
+	}
+}
+
+// WithWarnings returns a context that collects the warnings added under it.
+// They are also added to the warnings of ctx, so a function can tell
+// whether its own result is complete (to skip caching it, say) while the
+// caller still sees everything.
+func WithWarnings(ctx context.Context) context.Context {
+	parent, _ := ctx.Value(warningsKey{}).(*warningList)
+	return context.WithValue(ctx, warningsKey{}, &warningList{parent: parent})
+}
+
+// AddWarning records that an enrichment was skipped because of err. It does
+// nothing when the context doesn't collect warnings.
+func AddWarning(ctx context.Context, enrichment string, err error) {
+	if l, ok := ctx.Value(warningsKey{}).(*warningList); ok {
+		l.add(Warning{Enrichment: enrichment, Reason: strings.TrimSpace(err.Error())})
+	}
+}
+
+// Warnings returns the warnings collected under ctx, in the order added
+func Warnings(ctx context.Context) []Warning {
+	l, ok := ctx.Value(warningsKey{}).(*warningList)
+	if !ok {
+		return []Warning{}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Warning{}, l.items...)
+}
+
+// AddWarnings records warnings collected under another context, such as
+// those of a fetch whose result this call shares
+func AddWarnings(ctx context.Context, warnings []Warning) {
+	if l, ok := ctx.Value(warningsKey{}).(*warningList); ok {
+		for _, w := range warnings {
+			l.add(w)
+		}
+	}
+}
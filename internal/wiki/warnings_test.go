@@ -0,0 +1,30 @@
+package wiki
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWarnings(t *testing.T) {
+	ctx := context.Background()
+	AddWarning(ctx, "ignored", errors.New("no collector"))
+	if got := Warnings(ctx); got == nil || len(got) != 0 {
+		t.Errorf("Warnings without a collector = %#v, want empty", got)
+	}
+
+	call := WithWarnings(ctx)
+	inner := WithWarnings(call)
+	AddWarning(inner, "infobox", errors.New("timeout"))
+	AddWarning(inner, "infobox", errors.New("timeout"))
+	AddWarning(call, "parent_categories", errors.New("maxlag"))
+
+	if got, want := Warnings(inner), []Warning{{"infobox", "timeout"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("inner warnings = %v, want %v", got, want)
+	}
+	want := []Warning{{"infobox", "timeout"}, {"parent_categories", "maxlag"}}
+	if got := Warnings(call); !reflect.DeepEqual(got, want) {
+		t.Errorf("call warnings = %v, want %v", got, want)
+	}
+}
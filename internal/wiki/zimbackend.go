@@ -0,0 +1,172 @@
+package wiki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/yourusername/mediawiki-mcp/internal/zim"
+)
+
+// zimNamespaces are the namespaces articles are kept in: "C" in current
+// archives, "A" in those written before 2021
+var zimNamespaces = []byte{'C', 'A'}
+
+// ZIMBackend serves a wiki from a ZIM archive, such as a Kiwix export of
+// Wikipedia, for offline deployments. Parse requests are answered from the
+// archive's rendered pages, so the page tools work as they do online. The
+// archive has no wikitext, history, or search index, so requests for those
+// fail with a BackendUnsupportedError.
+type ZIMBackend struct {
+	archive *zim.Archive
+	wikiURL string
+}
+
+// NewZIMBackend opens a ZIM archive to serve as wikiURL
+func NewZIMBackend(path, wikiURL string) (*ZIMBackend, error) {
+	archive, err := zim.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &ZIMBackend{archive: archive, wikiURL: wikiURL}, nil
+}
+
+// Name identifies the backend in errors
+func (b *ZIMBackend) Name() string {
+	return "zim"
+}
+
+// Close closes the archive
+func (b *ZIMBackend) Close() error {
+	return b.archive.Close()
+}
+
+func (b *ZIMBackend) request(ctx context.Context, params url.Values) (*mwResponse, error) {
+	switch {
+	case params.Get("action") == "parse" && params.Get("page") != "":
+		page, err := b.page(ctx, params.Get("page"))
+		if err != nil {
+			return nil, err
+		}
+		return parsePage(page, params)
+	case params.Get("action") == "query" && params.Get("meta") == "siteinfo":
+		return b.siteInfo(), nil
+	case params.Get("action") == "query" && params.Get("titles") != "" &&
+		params.Get("prop") == "info" && params.Get("list") == "" && params.Get("generator") == "":
+		return pageInfo(ctx, params.Get("titles"), b.page)
+	}
+	return nil, unsupportedRequest(b, b.wikiURL, params)
+}
+
+// page reads a page from the archive. Its size is that of its HTML, since
+// the archive has no wikitext.
+func (b *ZIMBackend) page(_ context.Context, title string) (*backendPage, error) {
+	entry, content, err := b.article(title)
+	if err != nil {
+		return nil, err
+	}
+	body, err := zimBody(content)
+	if err != nil {
+		return nil, fmt.Errorf("read %q from archive: %w", title, err)
+	}
+	return &backendPage{Title: entry.Title, Length: len(content), HTML: body}, nil
+}
+
+// siteInfo describes the archive as a wiki whose pages live at the wiki's
+// usual article path
+func (b *ZIMBackend) siteInfo() *mwResponse {
+	general := &mwGeneral{
+		Sitename:    b.archive.Metadata("Title"),
+		Lang:        b.archive.Metadata("Language"),
+		Generator:   "ZIM archive",
+		Server:      strings.TrimRight(b.wikiURL, "/"),
+		ArticlePath: "/wiki/$1",
+	}
+	if main, err := b.archive.MainPage(); err == nil {
+		if main, err = b.archive.Resolve(main); err == nil {
+			general.MainPage = main.Title
+		}
+	}
+	return &mwResponse{Query: &mwQuery{General: general, Extensions: []mwExtension{}}}
+}
+
+// article finds a page by title, following redirects, and returns its
+// entry and HTML
+func (b *ZIMBackend) article(title string) (*zim.Entry, []byte, error) {
+	path := strings.ReplaceAll(strings.TrimSpace(title), " ", "_")
+	if r, size := utf8.DecodeRuneInString(path); r != utf8.RuneError {
+		path = string(unicode.ToUpper(r)) + path[size:]
+	}
+
+	for _, ns := range zimNamespaces {
+		entry, err := b.archive.Lookup(ns, path)
+		if errors.Is(err, zim.ErrNotFound) {
+			entry, err = b.archive.LookupTitle(ns, strings.ReplaceAll(path, "_", " "))
+		}
+		if errors.Is(err, zim.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if entry, err = b.archive.Resolve(entry); err != nil {
+			return nil, nil, err
+		}
+		content, err := b.archive.Content(entry)
+		if err != nil {
+			return nil, nil, err
+		}
+		return entry, content, nil
+	}
+	return nil, nil, &APIError{Code: "missingtitle", Message: fmt.Sprintf("The page %q isn't in the archive.", title)}
+}
+
+// zimBody returns the body of an archived page with scripts, styles, and the
+// title heading removed and links between pages rewritten to the /wiki/ form
+// MediaWiki renders, which the converter recognizes
+func zimBody(content []byte) (string, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(content)))
+	if err != nil {
+		return "", err
+	}
+	doc.Find("script, style, link, meta, noscript, h1").Remove()
+	doc.Find("a[href]").Each(func(_ int, a *goquery.Selection) {
+		href, _ := a.Attr("href")
+		if title := zimHrefTitle(href); title != "" {
+			a.SetAttr("href", "/wiki/"+title)
+		}
+	})
+	return doc.Find("body").Html()
+}
+
+// zimHrefTitle returns the title part of a relative link to another page
+// in the archive, such as "./Albert_Einstein#Life" or "../A/Ulm", or ""
+// for other links
+func zimHrefTitle(href string) string {
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(href, "/") || externalURL(href) != "" {
+		return ""
+	}
+	for _, scheme := range []string{"mailto:", "tel:", "javascript:", "data:"} {
+		if strings.HasPrefix(strings.ToLower(href), scheme) {
+			return ""
+		}
+	}
+	for {
+		switch {
+		case strings.HasPrefix(href, "./"):
+			href = href[2:]
+		case strings.HasPrefix(href, "../"):
+			href = href[3:]
+		case strings.HasPrefix(href, "A/"), strings.HasPrefix(href, "C/"):
+			href = href[2:]
+		default:
+			return href
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package wiki
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestZIMHrefTitle(t *testing.T) {
+	tests := map[string]string{
+		"./Albert_Einstein":       "Albert_Einstein",
+		"Ulm#History":             "Ulm#History",
+		"../A/Danube":             "Danube",
+		"Category:Cities":         "Category:Cities",
+		"#cite_note-1":            "",
+		"https://example.org/x":   "",
+		"//upload.wikimedia.org/": "",
+		"mailto:a@example.org":    "",
+		"/absolute":               "",
+	}
+	for href, want := range tests {
+		if got := zimHrefTitle(href); got != want {
+			t.Errorf("zimHrefTitle(%q) = %q, want %q", href, got, want)
+		}
+	}
+}
+
+func TestZIMBody(t *testing.T) {
+	page := `<html><head><script>x()</script></head><body><h1>Ulm</h1>` +
+		`<p>On the <a href="./Danube">Danube</a> (<a href="https://example.org">site</a>).</p></body></html>`
+
+	body, err := zimBody([]byte(page))
+	if err != nil {
+		t.Fatalf("zimBody() error = %v", err)
+	}
+	if strings.Contains(body, "<h1>") || strings.Contains(body, "script") {
+		t.Errorf("zimBody() kept the title or a script: %s", body)
+	}
+	if !strings.Contains(body, `href="/wiki/Danube"`) || !strings.Contains(body, `href="https://example.org"`) {
+		t.Errorf("zimBody() links = %s, want page links rewritten and external ones kept", body)
+	}
+}
@@ -0,0 +1,438 @@
+package wikitext
+
+import (
+	"strings"
+)
+
+// rawTags are extension tags whose content is not wikitext
+var rawTags = map[string]bool{
+	"nowiki": true, "pre": true, "math": true, "chem": true, "ce": true,
+	"syntaxhighlight": true, "source": true, "score": true, "timeline": true,
+	"templatedata": true, "graph": true, "mapframe": true, "maplink": true,
+}
+
+// voidTags never have content or a closing tag
+var voidTags = map[string]bool{
+	"br": true, "hr": true, "wbr": true, "img": true,
+}
+
+// markupChars are the characters that can start or end a construct, or
+// stop an enclosing one
+const markupChars = "{}[]<|\n"
+
+// externalSchemes start a bracketed external link
+var externalSchemes = []string{"http://", "https://", "//", "ftp://", "mailto:", "irc://", "news:"}
+
+// maxDepth bounds nesting of templates and links, like MediaWiki's own
+// expansion depth limit; deeper markup is kept as text
+const maxDepth = 100
+
+// parser is a recursive-descent wikitext tokenizer. Markup that turns out
+// to be unbalanced is kept as text, so parsing never fails.
+type parser struct {
+	src   string
+	pos   int
+	depth int
+
+	// Offsets just past the last "}}" and "]]", beyond which no template or
+	// link can close
+	lastTemplateEnd, lastLinkEnd int
+
+	// Templates and links already parsed, by start offset, so markup is
+	// scanned once even when unbalanced markup around it is rescanned
+	memo map[int]parsed
+
+	// Offsets of the last closing tag of each tag name seen, -1 for none,
+	// so unclosed tags don't each scan to the end
+	lastClose map[string]int
+}
+
+// parsed is a memoized construct; node is nil when it was unbalanced
+type parsed struct {
+	node Node
+	end  int
+}
+
+// Parse tokenizes wikitext
+func Parse(src string) []Node {
+	p := &parser{
+		src:             src,
+		memo:            make(map[int]parsed),
+		lastTemplateEnd: strings.LastIndex(src, "}}"),
+		lastLinkEnd:     strings.LastIndex(src, "]]"),
+	}
+	return p.nodes(func() bool { return false })
+}
+
+// TemplateAt parses the template starting at offset i of src, without
+// tokenizing the rest of the document. It returns nil when there is no
+// balanced template at i.
+func TemplateAt(src string, i int) *Template {
+	p := &parser{
+		src:             src,
+		pos:             i,
+		memo:            make(map[int]parsed),
+		lastTemplateEnd: strings.LastIndex(src, "}}"),
+		lastLinkEnd:     strings.LastIndex(src, "]]"),
+	}
+	if !p.at("{{") || p.at("{{{") {
+		return nil
+	}
+	t, _ := p.template().(*Template)
+	return t
+}
+
+// nodes parses until stop reports true or the input ends
+func (p *parser) nodes(stop func() bool) []Node {
+	var result []Node
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			result = append(result, &Text{Value: text.String()})
+			text.Reset()
+		}
+	}
+
+	for p.pos < len(p.src) && !stop() {
+		var n Node
+		switch {
+		case p.at("{{{"):
+			n = p.parameter()
+		case p.at("{{") && p.pos < p.lastTemplateEnd && p.depth < maxDepth:
+			n = p.memoized(p.template)
+		case p.at("[[") && p.pos < p.lastLinkEnd && p.depth < maxDepth:
+			n = p.memoized(p.link)
+		case p.at("["):
+			n = p.externalLink()
+		case p.at("<!--"):
+			n = p.comment()
+		case p.at("<"):
+			n = p.tag()
+		}
+
+		if n == nil {
+			// Copy text up to the next character that could start or end markup
+			end := len(p.src)
+			if next := strings.IndexAny(p.src[p.pos+1:], markupChars); next >= 0 {
+				end = p.pos + 1 + next
+			}
+			text.WriteString(p.src[p.pos:end])
+			p.pos = end
+			continue
+		}
+		flush()
+		result = append(result, n)
+	}
+
+	flush()
+	return result
+}
+
+// memoized runs parse at the current offset unless its result is known
+func (p *parser) memoized(parse func() Node) Node {
+	start := p.pos
+	if m, ok := p.memo[start]; ok {
+		if m.node != nil {
+			p.pos = m.end
+		}
+		return m.node
+	}
+
+	n := parse()
+	p.memo[start] = parsed{node: n, end: p.pos}
+	return n
+}
+
+func (p *parser) at(s string) bool {
+	return strings.HasPrefix(p.src[p.pos:], s)
+}
+
+// parameter consumes a {{{template parameter}}} as text
+func (p *parser) parameter() Node {
+	end := strings.Index(p.src[p.pos:], "}}}")
+	if end < 0 {
+		return nil
+	}
+	value := p.src[p.pos : p.pos+end+3]
+	p.pos += end + 3
+	return &Text{Value: value}
+}
+
+// template parses {{name|params}}
+func (p *parser) template() Node {
+	start := p.pos
+	p.pos += 2
+	p.depth++
+	defer func() { p.depth-- }()
+
+	atEnd := func() bool { return p.at("|") || p.at("}}") }
+	nameStart := p.pos
+	p.nodes(atEnd)
+	t := &Template{Name: strings.TrimSpace(p.src[nameStart:p.pos])}
+
+	for p.at("|") {
+		p.pos++
+		t.Params = append(t.Params, splitParam(p.nodes(atEnd)))
+	}
+
+	if !p.at("}}") {
+		p.pos = start
+		return nil
+	}
+	p.pos += 2
+	return t
+}
+
+// splitParam separates a named parameter's name from its value. A
+// parameter is named when a '=' appears in its leading text, before any
+// nested template, link, or tag.
+func splitParam(value []Node) Param {
+	for i, n := range value {
+		switch n := n.(type) {
+		case *Comment:
+			continue
+		case *Text:
+			name, rest, ok := strings.Cut(n.Value, "=")
+			if !ok {
+				continue
+			}
+			var prefix strings.Builder
+			for _, before := range value[:i] {
+				if t, ok := before.(*Text); ok {
+					prefix.WriteString(t.Value)
+				}
+			}
+			prefix.WriteString(name)
+
+			remaining := make([]Node, 0, len(value)-i)
+			if rest != "" {
+				remaining = append(remaining, &Text{Value: rest})
+			}
+			remaining = append(remaining, value[i+1:]...)
+			return Param{Name: strings.TrimSpace(prefix.String()), Value: remaining}
+		}
+		break
+	}
+	return Param{Value: value}
+}
+
+// link parses [[target|text]]
+func (p *parser) link() Node {
+	start := p.pos
+	p.pos += 2
+	p.depth++
+	defer func() { p.depth-- }()
+
+	targetStart := p.pos
+	p.nodes(func() bool { return p.at("|") || p.at("]]") || p.at("\n") })
+	target := p.src[targetStart:p.pos]
+	if p.pos >= len(p.src) || p.at("\n") || strings.TrimSpace(target) == "" {
+		p.pos = start
+		return nil
+	}
+
+	l := &Link{Target: strings.TrimSpace(target)}
+	if p.at("|") {
+		p.pos++
+		l.Text = p.nodes(func() bool { return p.at("]]") })
+		if l.Text == nil {
+			l.Text = []Node{}
+		}
+	}
+
+	if !p.at("]]") {
+		p.pos = start
+		return nil
+	}
+	p.pos += 2
+	return l
+}
+
+// externalLink parses [url text]
+func (p *parser) externalLink() Node {
+	start := p.pos
+	p.pos++
+
+	isURL := false
+	for _, scheme := range externalSchemes {
+		if hasPrefixFold(p.src[p.pos:], scheme) {
+			isURL = true
+			break
+		}
+	}
+	if !isURL {
+		p.pos = start
+		return nil
+	}
+
+	end := strings.IndexAny(p.src[p.pos:], " ]\n")
+	if end < 0 {
+		p.pos = start
+		return nil
+	}
+	l := &ExternalLink{URL: p.src[p.pos : p.pos+end]}
+	p.pos += end
+
+	if p.at(" ") {
+		p.pos++
+		l.Text = p.nodes(func() bool { return p.at("]") || p.at("\n") })
+	}
+	if !p.at("]") {
+		p.pos = start
+		return nil
+	}
+	p.pos++
+	return l
+}
+
+// comment parses <!-- comment -->; an unterminated comment runs to the end
+func (p *parser) comment() Node {
+	p.pos += 4
+	end := strings.Index(p.src[p.pos:], "-->")
+	if end < 0 {
+		c := &Comment{Value: p.src[p.pos:]}
+		p.pos = len(p.src)
+		return c
+	}
+	c := &Comment{Value: p.src[p.pos : p.pos+end]}
+	p.pos += end + 3
+	return c
+}
+
+// tag parses an HTML or extension tag with its content up to the matching
+// closing tag. Content is tokenized separately so markup inside a tag can't
+// close constructs outside it, as with MediaWiki's own extension tags.
+func (p *parser) tag() Node {
+	closing := p.at("</")
+	nameStart := p.pos + 1
+	if closing {
+		nameStart++
+	}
+
+	nameEnd := nameStart
+	for nameEnd < len(p.src) && isNameByte(p.src[nameEnd]) {
+		nameEnd++
+	}
+	if nameEnd == nameStart || !isLetter(p.src[nameStart]) {
+		return nil
+	}
+	gt := strings.IndexByte(p.src[nameEnd:], '>')
+	if gt < 0 || strings.IndexByte(p.src[nameEnd:nameEnd+gt], '<') >= 0 {
+		return nil
+	}
+
+	t := &Tag{Name: strings.ToLower(p.src[nameStart:nameEnd])}
+	attrs := p.src[nameEnd : nameEnd+gt]
+	p.pos = nameEnd + gt + 1
+
+	if closing {
+		t.Closing = true
+		return t
+	}
+	if strings.HasSuffix(attrs, "/") {
+		t.Attrs = strings.TrimSpace(strings.TrimSuffix(attrs, "/"))
+		t.SelfClosing = true
+		return t
+	}
+	t.Attrs = strings.TrimSpace(attrs)
+	if voidTags[t.Name] {
+		t.SelfClosing = true
+		return t
+	}
+
+	contentEnd, after := -1, -1
+	if p.pos <= p.lastCloseOf(t.Name) {
+		contentEnd, after = matchingClose(p.src, p.pos, t.Name)
+	}
+	if contentEnd < 0 {
+		t.SelfClosing = true
+		return t
+	}
+
+	content := p.src[p.pos:contentEnd]
+	if rawTags[t.Name] {
+		t.Content = []Node{&Text{Value: content}}
+	} else {
+		t.Content = Parse(content)
+	}
+	p.pos = after
+	return t
+}
+
+// lastCloseOf returns the offset of the last closing tag for name, or -1
+func (p *parser) lastCloseOf(name string) int {
+	if last, ok := p.lastClose[name]; ok {
+		return last
+	}
+	last := -1
+	closeTag := "</" + name
+	for i := len(p.src) - len(closeTag); i >= 0; i-- {
+		if p.src[i] == '<' && hasPrefixFold(p.src[i:], closeTag) && tagBoundary(p.src, i+len(closeTag)) {
+			last = i
+			break
+		}
+	}
+	if p.lastClose == nil {
+		p.lastClose = make(map[string]int)
+	}
+	p.lastClose[name] = last
+	return last
+}
+
+// matchingClose finds the closing tag for name starting at pos, allowing
+// nested tags of the same name. It returns where the content ends and where
+// the closing tag ends, or -1 when there is none.
+func matchingClose(src string, pos int, name string) (int, int) {
+	open, closeTag := "<"+name, "</"+name
+	depth := 0
+	for i := pos; i < len(src); {
+		next := strings.IndexByte(src[i:], '<')
+		if next < 0 {
+			return -1, -1
+		}
+		i += next
+
+		switch {
+		case hasPrefixFold(src[i:], closeTag) && tagBoundary(src, i+len(closeTag)):
+			gt := strings.IndexByte(src[i:], '>')
+			if gt < 0 {
+				return -1, -1
+			}
+			if depth == 0 {
+				return i, i + gt + 1
+			}
+			depth--
+			i += gt + 1
+		case !rawTags[name] && hasPrefixFold(src[i:], open) && tagBoundary(src, i+len(open)):
+			gt := strings.IndexByte(src[i:], '>')
+			if gt < 0 {
+				return -1, -1
+			}
+			if src[i+gt-1] != '/' {
+				depth++
+			}
+			i += gt + 1
+		default:
+			i++
+		}
+	}
+	return -1, -1
+}
+
+// hasPrefixFold is strings.HasPrefix ignoring ASCII case
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// tagBoundary reports whether a tag name ends at i
+func tagBoundary(s string, i int) bool {
+	return i >= len(s) || !isNameByte(s[i])
+}
+
+func isLetter(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
+
+func isNameByte(b byte) bool {
+	return isLetter(b) || b >= '0' && b <= '9'
+}
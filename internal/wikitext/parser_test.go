@@ -0,0 +1,132 @@
+package wikitext
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// dump renders nodes compactly for comparison in tests
+func dump(nodes []Node) string {
+	parts := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case *Text:
+			parts = append(parts, fmt.Sprintf("%q", n.Value))
+		case *Template:
+			params := []string{n.Name}
+			for _, p := range n.Params {
+				params = append(params, p.Name+"="+dump(p.Value))
+			}
+			parts = append(parts, "T("+strings.Join(params, " | ")+")")
+		case *Link:
+			if n.Text == nil {
+				parts = append(parts, "L("+n.Target+")")
+			} else {
+				parts = append(parts, "L("+n.Target+" | "+dump(n.Text)+")")
+			}
+		case *ExternalLink:
+			parts = append(parts, "X("+n.URL+" | "+dump(n.Text)+")")
+		case *Tag:
+			switch {
+			case n.Closing:
+				parts = append(parts, "</"+n.Name+">")
+			case n.SelfClosing:
+				parts = append(parts, "<"+n.Name+"/>")
+			default:
+				parts = append(parts, "<"+n.Name+">("+dump(n.Content)+")")
+			}
+		case *Comment:
+			parts = append(parts, "C")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"text", "plain ''text''", `"plain ''text''"`},
+		{"template", "{{a|b|c=d}}", `T(a | ="b" | c="d")`},
+		{"nested templates", "{{a|b={{c|d={{e}}}}|f}}", `T(a | b=T(c | d=T(e)) | ="f")`},
+		{"piped link in template", "{{a|[[b|c]]|d=[[e|f]]}}", `T(a | =L(b | "c") | d=L(e | "f"))`},
+		{"equals after link stays positional", "{{a|[[b]]=c}}", `T(a | =L(b) "=c")`},
+		{"equals in nested template", "{{a|{{b|c=d}}}}", `T(a | =T(b | c="d"))`},
+		{"ref hides pipes and braces", "{{a|b=x<ref>{{c|d}}|}}</ref>}}", `T(a | b="x" <ref>(T(c | ="d") "|}}"))`},
+		{"named ref", `x<ref name="n" />y`, `"x" <ref/> "y"`},
+		{"unclosed template", "{{a|b", `"{{a|b"`},
+		{"unclosed template inside template", "{{a|{{b}}", `"{{a|" T(b)`},
+		{"stray closers", "a }} b ]] c", `"a }} b ]] c"`},
+		{"link trail", "[[bus]]es", `L(bus) "es"`},
+		{"link with newline", "[[a\nb]]", `"[[a\nb]]"`},
+		{"file with nested link", "[[File:x.jpg|thumb|A [[b]] c]]", `L(File:x.jpg | "thumb|A " L(b) " c")`},
+		{"pipe trick", "[[a (b)|]]", `L(a (b) | )`},
+		{"external link", "[https://example.org Example site]", `X(https://example.org | "Example site")`},
+		{"bare bracket", "[not a link]", `"[not a link]"`},
+		{"comment", "a<!-- {{b}} | c -->d", `"a" C "d"`},
+		{"unterminated comment", "a<!-- b", `"a" C`},
+		{"nowiki", "<nowiki>{{a}} [[b]]</nowiki>", `<nowiki>("{{a}} [[b]]")`},
+		{"nested tags", "<div>a<div>b</div>c</div>", `<div>("a" <div>("b") "c")`},
+		{"uppercase tag", "<REF>a</ref>", `<ref>("a")`},
+		{"void tag", "a<br>b<br />c", `"a" <br/> "b" <br/> "c"`},
+		{"unclosed tag", "<small>a", `<small/> "a"`},
+		{"stray closing tag", "a</span>", `"a" </span>`},
+		{"less than", "a < b > c", `"a < b > c"`},
+		{"template parameter", "{{{1|default}}}", `"{{{1|default}}}"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dump(Parse(tt.src)); got != tt.want {
+				t.Errorf("Parse(%q)\ngot:  %s\nwant: %s", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRendererText(t *testing.T) {
+	r := &Renderer{Templates: map[string]TemplateFunc{
+		"lang": func(r *Renderer, t *Template) string { return r.ArgText(t, "2") },
+	}}
+
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"'''Bold''' and ''italic''", "Bold and italic"},
+		{"[[a|b]] [[c]] [[:Category:d|e]] [[Category:f]]", "b c e "},
+		{"[[File:x.jpg|thumb|caption]]text", "text"},
+		{"[[Paris, Texas|]] [[Help:Contents|]]", "Paris Contents"},
+		{"{{lang|fr|bonjour}} {{unknown|x}}", "bonjour "},
+		{"{{Lang|fr|un}} {{Template:lang|fr|deux}}", "un deux"},
+		{"a<ref>{{cite|b}}</ref> c<!-- d -->", "a c"},
+		{"line<br/>break", "line\nbreak"},
+		{"[https://x.org label] [https://y.org]", "label "},
+		{"<small>tiny [[link]]</small>", "tiny link"},
+	}
+
+	for _, tt := range tests {
+		if got := r.Text(Parse(tt.src)); got != tt.want {
+			t.Errorf("Text(%q) = %q, want %q", tt.src, got, tt.want)
+		}
+	}
+}
+
+// TestParseDegenerateInput guards against quadratic or worse rescanning of
+// unbalanced markup
+func TestParseDegenerateInput(t *testing.T) {
+	unclosed := strings.Repeat("{{a|[[b|", 2000)
+	if got := dump(Parse(unclosed)); got != fmt.Sprintf("%q", unclosed) {
+		t.Errorf("unbalanced markup was not kept as text")
+	}
+
+	// Only the innermost pair closes; everything around it is rescanned
+	nested := unclosed + "]]}}"
+	nodes := Parse(nested)
+	if tmpl := Find(nodes, func(*Template) bool { return true }); tmpl == nil {
+		t.Errorf("closed template was not found")
+	}
+}
@@ -0,0 +1,96 @@
+package wikitext
+
+import (
+	"strings"
+)
+
+// TemplateFunc renders a template as plain text
+type TemplateFunc func(r *Renderer, t *Template) string
+
+// Renderer converts parsed wikitext to readable plain text. Links become
+// their display text, formatting markup, references, comments, and files
+// are dropped, and templates are rendered by Templates (keyed by
+// NormalizedName), then by Fallback, or dropped when neither is set.
+type Renderer struct {
+	Templates map[string]TemplateFunc
+	Fallback  TemplateFunc // renders templates missing from Templates
+}
+
+// hiddenTags render to nothing
+var hiddenTags = map[string]bool{
+	"ref": true, "references": true, "includeonly": true, "templatedata": true,
+	"mapframe": true, "maplink": true, "graph": true, "timeline": true, "score": true,
+}
+
+// hiddenNamespaces are link prefixes that embed or categorize rather than link
+var hiddenNamespaces = []string{"file:", "image:", "media:", "category:"}
+
+// Text renders nodes as plain text
+func (r *Renderer) Text(nodes []Node) string {
+	var sb strings.Builder
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case *Text:
+			sb.WriteString(stripFormatting(n.Value))
+		case *Template:
+			if render, ok := r.Templates[n.NormalizedName()]; ok {
+				sb.WriteString(render(r, n))
+			} else if r.Fallback != nil {
+				sb.WriteString(r.Fallback(r, n))
+			}
+		case *Link:
+			sb.WriteString(r.linkText(n))
+		case *ExternalLink:
+			sb.WriteString(r.Text(n.Text))
+		case *Tag:
+			switch {
+			case n.Name == "br":
+				sb.WriteString("\n")
+			case hiddenTags[n.Name]:
+			default:
+				sb.WriteString(r.Text(n.Content))
+			}
+		}
+	}
+	return sb.String()
+}
+
+// linkText returns what a link displays
+func (r *Renderer) linkText(l *Link) string {
+	target := l.Target
+	lower := strings.ToLower(target)
+	for _, ns := range hiddenNamespaces {
+		if strings.HasPrefix(lower, ns) {
+			return ""
+		}
+	}
+	target = strings.TrimPrefix(target, ":")
+
+	if l.Text == nil {
+		return target
+	}
+	if text := r.Text(l.Text); strings.TrimSpace(text) != "" {
+		return text
+	}
+
+	// Pipe trick: [[Paris, Texas|]] displays "Paris"
+	if i := strings.Index(target, ":"); i >= 0 {
+		target = target[i+1:]
+	}
+	if i := strings.IndexAny(target, "(,"); i > 0 {
+		target = target[:i]
+	}
+	return strings.TrimSpace(target)
+}
+
+// stripFormatting removes bold and italic quote markup
+func stripFormatting(s string) string {
+	s = strings.ReplaceAll(s, "'''", "")
+	return strings.ReplaceAll(s, "''", "")
+}
+
+// ArgText renders a template parameter, or "" when it is absent
+func (r *Renderer) ArgText(t *Template, name string) string {
+	value, _ := t.Arg(name)
+	return strings.TrimSpace(r.Text(value))
+}
@@ -0,0 +1,129 @@
+// Package wikitext tokenizes MediaWiki markup into templates, links, tags,
+// and text, handling the nesting that regular expressions can't: templates
+// inside templates, piped links inside template parameters, and tags
+// spanning either.
+package wikitext
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Node is an element of parsed wikitext
+type Node interface {
+	node()
+}
+
+// Text is literal wikitext, including bold and italic quote markup
+type Text struct {
+	Value string
+}
+
+// Template is a {{name|param|key=value}} transclusion
+type Template struct {
+	Name   string
+	Params []Param
+}
+
+// Param is a template parameter. Positional parameters have an empty Name.
+type Param struct {
+	Name  string
+	Value []Node
+}
+
+// Link is an internal [[target|text]] link. Text is nil without a pipe.
+type Link struct {
+	Target string
+	Text   []Node
+}
+
+// ExternalLink is a bracketed [url text] link
+type ExternalLink struct {
+	URL  string
+	Text []Node
+}
+
+// Tag is an HTML or extension tag such as <ref>, <br/> or <small>
+type Tag struct {
+	Name        string // lowercase
+	Attrs       string
+	Content     []Node
+	SelfClosing bool // <tag/>, a void element like <br>, or an unclosed tag
+	Closing     bool // a stray </tag> without a matching opening tag
+}
+
+// Comment is an <!-- HTML comment -->
+type Comment struct {
+	Value string
+}
+
+func (*Text) node()         {}
+func (*Template) node()     {}
+func (*Link) node()         {}
+func (*ExternalLink) node() {}
+func (*Tag) node()          {}
+func (*Comment) node()      {}
+
+// Arg returns the value of a named parameter, or of a positional one when
+// name is "1", "2", ...
+func (t *Template) Arg(name string) ([]Node, bool) {
+	position := 0
+	for _, p := range t.Params {
+		if p.Name == "" {
+			position++
+			if strconv.Itoa(position) == name {
+				return p.Value, true
+			}
+		} else if p.Name == name {
+			return p.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Positional returns the positional parameters in order
+func (t *Template) Positional() [][]Node {
+	var values [][]Node
+	for _, p := range t.Params {
+		if p.Name == "" {
+			values = append(values, p.Value)
+		}
+	}
+	return values
+}
+
+// NormalizedName returns the template name lowercased, with underscores as
+// spaces and any "Template:" prefix removed, for matching against known names
+func (t *Template) NormalizedName() string {
+	name := strings.ToLower(strings.TrimSpace(strings.ReplaceAll(t.Name, "_", " ")))
+	name = strings.TrimPrefix(name, "template:")
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// Find returns the first template, depth first, for which match is true
+func Find(nodes []Node, match func(*Template) bool) *Template {
+	for _, n := range nodes {
+		var children [][]Node
+		switch n := n.(type) {
+		case *Template:
+			if match(n) {
+				return n
+			}
+			for _, p := range n.Params {
+				children = append(children, p.Value)
+			}
+		case *Link:
+			children = append(children, n.Text)
+		case *ExternalLink:
+			children = append(children, n.Text)
+		case *Tag:
+			children = append(children, n.Content)
+		}
+		for _, child := range children {
+			if t := Find(child, match); t != nil {
+				return t
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package zim
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// Cluster compression types, from the low four bits of a cluster's first
+// byte
+const (
+	compressionDefault = 0
+	compressionNone    = 1
+	compressionZlib    = 2
+	compressionBzip2   = 3
+	compressionXZ      = 4
+	compressionZstd    = 5
+)
+
+// extendedCluster marks a cluster whose blob offsets are 64-bit
+const extendedCluster = 0x10
+
+// maxClusterBytes bounds a compressed cluster's decompressed size. Writers
+// keep clusters to a few megabytes (libzim's default is 2 MB) and store
+// large media uncompressed, so anything bigger is corrupt or hostile.
+const maxClusterBytes = 64 << 20
+
+// UnsupportedCompressionError is returned for a cluster this build can't
+// decompress
+type UnsupportedCompressionError struct {
+	Compression string
+}
+
+func (e *UnsupportedCompressionError) Error() string {
+	return fmt.Sprintf("unsupported cluster compression %s", e.Compression)
+}
+
+// cluster is a decompressed cluster: blob offsets followed by the blobs
+type cluster struct {
+	data    []byte
+	offsets []uint64 // one more than the number of blobs
+}
+
+// blob returns the nth blob of a cluster
+func (c *cluster) blob(n uint32) ([]byte, error) {
+	if int(n)+1 >= len(c.offsets) {
+		return nil, fmt.Errorf("zim: blob %d out of range", n)
+	}
+	start, end := c.offsets[n], c.offsets[n+1]
+	if start > end || end > uint64(len(c.data)) {
+		return nil, fmt.Errorf("zim: blob %d is corrupt", n)
+	}
+	return c.data[start:end], nil
+}
+
+// decodeCluster decompresses a cluster as stored in the archive and reads
+// its blob offsets
+func decodeCluster(raw []byte) (*cluster, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("empty cluster")
+	}
+	info := raw[0]
+	data, err := decompress(info&0x0f, raw[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	size := 4
+	if info&extendedCluster != 0 {
+		size = 8
+	}
+	offset := func(i int) uint64 {
+		if size == 8 {
+			return binary.LittleEndian.Uint64(data[i*8:])
+		}
+		return uint64(binary.LittleEndian.Uint32(data[i*4:]))
+	}
+	if len(data) < size {
+		return nil, fmt.Errorf("truncated cluster")
+	}
+
+	// The first offset points past the offset list, so it gives its length
+	first := offset(0)
+	count := int(first) / size
+	if first%uint64(size) != 0 || count < 1 || count*size > len(data) {
+		return nil, fmt.Errorf("corrupt blob offsets")
+	}
+	c := &cluster{data: data, offsets: make([]uint64, count)}
+	for i := range count {
+		c.offsets[i] = offset(i)
+	}
+	return c, nil
+}
+
+// decompress decompresses cluster data, refusing to produce more than
+// maxClusterBytes. zlib and bzip2, which only old archives use, are read
+// with the standard library; xz and zstd, which current archives use, with
+// pure-Go decoders.
+func decompress(compression byte, data []byte) ([]byte, error) {
+	var r io.Reader
+	switch compression {
+	case compressionDefault, compressionNone:
+		return data, nil
+	case compressionZlib:
+		zr, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		r = zr
+	case compressionBzip2:
+		r = bzip2.NewReader(bytes.NewReader(data))
+	case compressionXZ:
+		xr, err := xz.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("xz: %w", err)
+		}
+		r = xr
+	case compressionZstd:
+		zr, err := zstd.NewReader(bytes.NewReader(data), zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(maxClusterBytes))
+		if err != nil {
+			return nil, fmt.Errorf("zstd: %w", err)
+		}
+		defer zr.Close()
+		r = zr
+	default:
+		return nil, &UnsupportedCompressionError{Compression: fmt.Sprintf("type %d", compression)}
+	}
+
+	out, err := io.ReadAll(io.LimitReader(r, maxClusterBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > maxClusterBytes {
+		return nil, fmt.Errorf("cluster decompresses to more than %d bytes", maxClusterBytes)
+	}
+	return out, nil
+}
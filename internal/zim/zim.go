@@ -0,0 +1,442 @@
+// Package zim reads ZIM archives, the offline format Kiwix uses for
+// exports of Wikipedia and other wikis. An archive is a directory of entries
+// sorted by URL and by title, whose content is stored in clusters of
+// blobs, usually compressed.
+package zim
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+)
+
+// magicNumber opens every ZIM archive
+const magicNumber = 72173914
+
+// headerSize is the size of the fixed header at the start of an archive
+const headerSize = 80
+
+// maxRedirects bounds the redirect chains Resolve follows
+const maxRedirects = 10
+
+// Special MIME type indexes of directory entries that have no content
+const (
+	mimeRedirect   = 0xffff
+	mimeLinkTarget = 0xfffe
+	mimeDeleted    = 0xfffd
+)
+
+// ErrNotFound is returned when an archive has no entry for a URL or title
+var ErrNotFound = errors.New("zim: entry not found")
+
+// Archive is an open ZIM file. It is safe for concurrent use.
+type Archive struct {
+	r    io.ReaderAt
+	file *os.File // nil when opened from a ReaderAt
+	size int64
+
+	entryCount    uint32
+	clusterCount  uint32
+	urlPtrPos     uint64
+	titlePtrPos   uint64
+	clusterPtrPos uint64
+	mainPage      uint32
+	checksumPos   uint64
+	mimeTypes     []string
+
+	clusters clusterCache
+}
+
+// Entry is a directory entry: a piece of content or a redirect to another
+// entry
+type Entry struct {
+	Index     uint32 // position in URL order
+	Namespace byte
+	URL       string
+	Title     string // the URL when the archive gives no title
+	MIMEType  string // empty for redirects
+
+	redirect      bool
+	redirectIndex uint32
+	cluster       uint32
+	blob          uint32
+}
+
+// IsRedirect reports whether the entry redirects to another
+func (e *Entry) IsRedirect() bool {
+	return e.redirect
+}
+
+// Open opens a ZIM archive on disk
+func Open(path string) (*Archive, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	a, err := NewArchive(f, info.Size())
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	a.file = f
+	return a, nil
+}
+
+// NewArchive reads a ZIM archive of the given size from r
+func NewArchive(r io.ReaderAt, size int64) (*Archive, error) {
+	header := make([]byte, headerSize)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return nil, fmt.Errorf("zim: read header: %w", err)
+	}
+	le := binary.LittleEndian
+	if le.Uint32(header[0:]) != magicNumber {
+		return nil, errors.New("zim: not a ZIM archive")
+	}
+
+	a := &Archive{
+		r:             r,
+		size:          size,
+		entryCount:    le.Uint32(header[24:]),
+		clusterCount:  le.Uint32(header[28:]),
+		urlPtrPos:     le.Uint64(header[32:]),
+		titlePtrPos:   le.Uint64(header[40:]),
+		clusterPtrPos: le.Uint64(header[48:]),
+		mainPage:      le.Uint32(header[64:]),
+		checksumPos:   le.Uint64(header[72:]),
+	}
+	a.clusters.entries = make(map[uint32]*cluster)
+
+	// The MIME type list follows the header: strings up to an empty one
+	mimeListPos := le.Uint64(header[56:])
+	buf := make([]byte, 4096)
+	n, err := r.ReadAt(buf, int64(mimeListPos))
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("zim: read MIME types: %w", err)
+	}
+	for list := buf[:n]; ; {
+		end := bytes.IndexByte(list, 0)
+		if end <= 0 {
+			break
+		}
+		a.mimeTypes = append(a.mimeTypes, string(list[:end]))
+		list = list[end+1:]
+	}
+	return a, nil
+}
+
+// Close closes the archive's file
+func (a *Archive) Close() error {
+	if a.file == nil {
+		return nil
+	}
+	return a.file.Close()
+}
+
+// EntryCount returns the number of directory entries
+func (a *Archive) EntryCount() int {
+	return int(a.entryCount)
+}
+
+// EntryAt returns the entry at a position in URL order
+func (a *Archive) EntryAt(index uint32) (*Entry, error) {
+	if index >= a.entryCount {
+		return nil, fmt.Errorf("zim: entry %d out of range", index)
+	}
+	pos, err := a.readUint64(a.urlPtrPos + 8*uint64(index))
+	if err != nil {
+		return nil, err
+	}
+
+	// Fixed fields, then the URL, title, and parameters; read enough for
+	// typical URLs and titles and more if they run longer
+	buf := make([]byte, 512)
+	for {
+		n, err := a.r.ReadAt(buf, int64(pos))
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("zim: read entry %d: %w", index, err)
+		}
+		entry, ok := a.parseEntry(index, buf[:n])
+		if ok {
+			return entry, nil
+		}
+		if n < len(buf) {
+			return nil, fmt.Errorf("zim: entry %d is truncated", index)
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// parseEntry decodes a directory entry, or reports that buf doesn't hold
+// all of it
+func (a *Archive) parseEntry(index uint32, buf []byte) (*Entry, bool) {
+	le := binary.LittleEndian
+	if len(buf) < 12 {
+		return nil, false
+	}
+	mime := le.Uint16(buf[0:])
+	entry := &Entry{Index: index, Namespace: buf[3]}
+
+	rest := buf[8:]
+	switch mime {
+	case mimeRedirect:
+		entry.redirect = true
+		entry.redirectIndex = le.Uint32(rest)
+		rest = rest[4:]
+	case mimeLinkTarget, mimeDeleted:
+	default:
+		if len(rest) < 8 {
+			return nil, false
+		}
+		entry.cluster = le.Uint32(rest)
+		entry.blob = le.Uint32(rest[4:])
+		rest = rest[8:]
+		if int(mime) < len(a.mimeTypes) {
+			entry.MIMEType = a.mimeTypes[mime]
+		}
+	}
+
+	urlEnd := bytes.IndexByte(rest, 0)
+	if urlEnd < 0 {
+		return nil, false
+	}
+	titleEnd := bytes.IndexByte(rest[urlEnd+1:], 0)
+	if titleEnd < 0 {
+		return nil, false
+	}
+	entry.URL = string(rest[:urlEnd])
+	entry.Title = string(rest[urlEnd+1 : urlEnd+1+titleEnd])
+	if entry.Title == "" {
+		entry.Title = entry.URL
+	}
+	return entry, true
+}
+
+// Lookup finds the entry for a URL in a namespace by binary search of the
+// URL index
+func (a *Archive) Lookup(namespace byte, url string) (*Entry, error) {
+	key := string(namespace) + url
+	var searchErr error
+	i := sort.Search(int(a.entryCount), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		entry, err := a.EntryAt(uint32(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return string(entry.Namespace)+entry.URL >= key
+	})
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	if i < int(a.entryCount) {
+		entry, err := a.EntryAt(uint32(i))
+		if err != nil {
+			return nil, err
+		}
+		if entry.Namespace == namespace && entry.URL == url {
+			return entry, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// LookupTitle finds the entry with a title in a namespace by binary search
+// of the title index
+func (a *Archive) LookupTitle(namespace byte, title string) (*Entry, error) {
+	key := string(namespace) + title
+	var searchErr error
+	entryAt := func(i int) *Entry {
+		index, err := a.readUint32(a.titlePtrPos + 4*uint64(i))
+		if err == nil {
+			var entry *Entry
+			if entry, err = a.EntryAt(index); err == nil {
+				return entry
+			}
+		}
+		searchErr = err
+		return nil
+	}
+	i := sort.Search(int(a.entryCount), func(i int) bool {
+		entry := entryAt(i)
+		return entry == nil || string(entry.Namespace)+entry.Title >= key
+	})
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	if i < int(a.entryCount) {
+		if entry := entryAt(i); entry != nil && entry.Namespace == namespace && entry.Title == title {
+			return entry, nil
+		}
+	}
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	return nil, ErrNotFound
+}
+
+// Resolve follows redirects from an entry to the entry with content
+func (a *Archive) Resolve(entry *Entry) (*Entry, error) {
+	for range maxRedirects {
+		if !entry.redirect {
+			return entry, nil
+		}
+		next, err := a.EntryAt(entry.redirectIndex)
+		if err != nil {
+			return nil, err
+		}
+		entry = next
+	}
+	return nil, fmt.Errorf("zim: more than %d redirects from %q", maxRedirects, entry.URL)
+}
+
+// MainPage returns the archive's main page entry, or ErrNotFound when it has
+// none
+func (a *Archive) MainPage() (*Entry, error) {
+	if a.mainPage == 0xffffffff {
+		return nil, ErrNotFound
+	}
+	return a.EntryAt(a.mainPage)
+}
+
+// Metadata returns a metadata value such as "Title" or "Language", or ""
+// when the archive doesn't set it
+func (a *Archive) Metadata(name string) string {
+	entry, err := a.Lookup('M', name)
+	if err != nil {
+		return ""
+	}
+	content, err := a.Content(entry)
+	if err != nil {
+		return ""
+	}
+	return string(content)
+}
+
+// Content returns the content of an entry, following redirects
+func (a *Archive) Content(entry *Entry) ([]byte, error) {
+	entry, err := a.Resolve(entry)
+	if err != nil {
+		return nil, err
+	}
+	if entry.MIMEType == "" {
+		return nil, fmt.Errorf("zim: entry %q has no content", entry.URL)
+	}
+
+	c, err := a.cluster(entry.cluster)
+	if err != nil {
+		return nil, err
+	}
+	return c.blob(entry.blob)
+}
+
+// cluster returns a decompressed cluster, from the cache if it was read
+// recently
+func (a *Archive) cluster(number uint32) (*cluster, error) {
+	if c := a.clusters.get(number); c != nil {
+		return c, nil
+	}
+	if number >= a.clusterCount {
+		return nil, fmt.Errorf("zim: cluster %d out of range", number)
+	}
+
+	start, err := a.readUint64(a.clusterPtrPos + 8*uint64(number))
+	if err != nil {
+		return nil, err
+	}
+	end := a.checksumPos
+	if number+1 < a.clusterCount {
+		if end, err = a.readUint64(a.clusterPtrPos + 8*uint64(number+1)); err != nil {
+			return nil, err
+		}
+	}
+	if end == 0 || end > uint64(a.size) {
+		end = uint64(a.size)
+	}
+	if end <= start {
+		return nil, fmt.Errorf("zim: cluster %d is empty", number)
+	}
+
+	raw := make([]byte, end-start)
+	if _, err := a.r.ReadAt(raw, int64(start)); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("zim: read cluster %d: %w", number, err)
+	}
+	c, err := decodeCluster(raw)
+	if err != nil {
+		return nil, fmt.Errorf("zim: cluster %d: %w", number, err)
+	}
+	a.clusters.put(number, c)
+	return c, nil
+}
+
+func (a *Archive) readUint32(pos uint64) (uint32, error) {
+	var buf [4]byte
+	if _, err := a.r.ReadAt(buf[:], int64(pos)); err != nil {
+		return 0, fmt.Errorf("zim: read at %d: %w", pos, err)
+	}
+	return binary.LittleEndian.Uint32(buf[:]), nil
+}
+
+func (a *Archive) readUint64(pos uint64) (uint64, error) {
+	var buf [8]byte
+	if _, err := a.r.ReadAt(buf[:], int64(pos)); err != nil {
+		return 0, fmt.Errorf("zim: read at %d: %w", pos, err)
+	}
+	return binary.LittleEndian.Uint64(buf[:]), nil
+}
+
+// clusterCacheSize is the number of decompressed clusters kept in memory
+const clusterCacheSize = 16
+
+// clusterCache keeps the most recently read clusters, since pages read
+// together are often stored together
+type clusterCache struct {
+	mu      sync.Mutex
+	entries map[uint32]*cluster
+	order   []uint32 // least recently used first
+}
+
+func (c *clusterCache) get(number uint32) *cluster {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cl, ok := c.entries[number]
+	if ok {
+		c.touch(number)
+	}
+	return cl
+}
+
+func (c *clusterCache) put(number uint32, cl *cluster) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[number]; !ok && len(c.entries) >= clusterCacheSize {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.entries[number] = cl
+	c.touch(number)
+}
+
+// touch moves a cluster to the most recently used end
+func (c *clusterCache) touch(number uint32) {
+	for i, n := range c.order {
+		if n == number {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+	c.order = append(c.order, number)
+}
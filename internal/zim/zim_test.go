@@ -0,0 +1,230 @@
+package zim
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// testEntry is an entry of an archive built by buildArchive
+type testEntry struct {
+	namespace byte
+	url       string
+	title     string
+	content   string // ignored for redirects
+	redirect  string // URL in the same namespace to redirect to
+}
+
+// buildArchive writes a ZIM archive with every content entry in one
+// cluster of the given compression
+func buildArchive(t *testing.T, entries []testEntry, compression byte) []byte {
+	t.Helper()
+	le := binary.LittleEndian
+
+	sort.Slice(entries, func(i, j int) bool {
+		return string(entries[i].namespace)+entries[i].url < string(entries[j].namespace)+entries[j].url
+	})
+	byURL := make(map[string]int)
+	for i, e := range entries {
+		byURL[string(e.namespace)+e.url] = i
+	}
+
+	// Cluster: offsets, then the blobs
+	var blobs [][]byte
+	blobOf := make(map[int]int)
+	for i, e := range entries {
+		if e.redirect == "" {
+			blobOf[i] = len(blobs)
+			blobs = append(blobs, []byte(e.content))
+		}
+	}
+	var data bytes.Buffer
+	offset := uint32(4 * (len(blobs) + 1))
+	for _, b := range blobs {
+		binary.Write(&data, le, offset)
+		offset += uint32(len(b))
+	}
+	binary.Write(&data, le, offset)
+	for _, b := range blobs {
+		data.Write(b)
+	}
+	clusterData := data.Bytes()
+	if compression == compressionZlib {
+		var z bytes.Buffer
+		w := zlib.NewWriter(&z)
+		w.Write(clusterData)
+		w.Close()
+		clusterData = z.Bytes()
+	}
+	if compression == compressionZstd {
+		enc, _ := zstd.NewWriter(nil)
+		clusterData = enc.EncodeAll(clusterData, nil)
+	}
+	if compression == compressionXZ {
+		var x bytes.Buffer
+		w, err := xz.NewWriter(&x)
+		if err != nil {
+			t.Fatalf("xz: %v", err)
+		}
+		w.Write(clusterData)
+		w.Close()
+		clusterData = x.Bytes()
+	}
+
+	// Directory entries
+	var dir bytes.Buffer
+	dirOffsets := make([]int, len(entries))
+	for i, e := range entries {
+		dirOffsets[i] = dir.Len()
+		if e.redirect != "" {
+			binary.Write(&dir, le, uint16(mimeRedirect))
+			dir.Write([]byte{0, e.namespace})
+			binary.Write(&dir, le, uint32(0))
+			binary.Write(&dir, le, uint32(byURL[string(e.namespace)+e.redirect]))
+		} else {
+			binary.Write(&dir, le, uint16(0))
+			dir.Write([]byte{0, e.namespace})
+			binary.Write(&dir, le, uint32(0))
+			binary.Write(&dir, le, uint32(0))
+			binary.Write(&dir, le, uint32(blobOf[i]))
+		}
+		dir.WriteString(e.url + "\x00" + e.title + "\x00")
+	}
+
+	titleOrder := make([]int, len(entries))
+	for i := range titleOrder {
+		titleOrder[i] = i
+	}
+	title := func(e testEntry) string {
+		if e.title == "" {
+			return e.url
+		}
+		return e.title
+	}
+	sort.Slice(titleOrder, func(i, j int) bool {
+		a, b := entries[titleOrder[i]], entries[titleOrder[j]]
+		return string(a.namespace)+title(a) < string(b.namespace)+title(b)
+	})
+
+	// Layout: header, MIME list, URL pointers, title pointers, entries,
+	// cluster pointer, cluster
+	mimeList := []byte("text/html\x00\x00")
+	mimePos := headerSize
+	urlPtrPos := mimePos + len(mimeList)
+	titlePtrPos := urlPtrPos + 8*len(entries)
+	dirPos := titlePtrPos + 4*len(entries)
+	clusterPtrPos := dirPos + dir.Len()
+	clusterPos := clusterPtrPos + 8
+	checksumPos := clusterPos + 1 + len(clusterData)
+
+	var out bytes.Buffer
+	header := make([]byte, headerSize)
+	le.PutUint32(header[0:], magicNumber)
+	le.PutUint16(header[4:], 6)
+	le.PutUint32(header[24:], uint32(len(entries)))
+	le.PutUint32(header[28:], 1)
+	le.PutUint64(header[32:], uint64(urlPtrPos))
+	le.PutUint64(header[40:], uint64(titlePtrPos))
+	le.PutUint64(header[48:], uint64(clusterPtrPos))
+	le.PutUint64(header[56:], uint64(mimePos))
+	le.PutUint32(header[64:], 0xffffffff)
+	le.PutUint32(header[68:], 0xffffffff)
+	le.PutUint64(header[72:], uint64(checksumPos))
+	out.Write(header)
+	out.Write(mimeList)
+	for _, off := range dirOffsets {
+		binary.Write(&out, le, uint64(dirPos+off))
+	}
+	for _, i := range titleOrder {
+		binary.Write(&out, le, uint32(i))
+	}
+	out.Write(dir.Bytes())
+	binary.Write(&out, le, uint64(clusterPos))
+	out.WriteByte(compression)
+	out.Write(clusterData)
+	out.Write(make([]byte, 16)) // checksum
+	return out.Bytes()
+}
+
+var testEntries = []testEntry{
+	{namespace: 'C', url: "Albert_Einstein", title: "Albert Einstein", content: "<p>Physicist</p>"},
+	{namespace: 'C', url: "Einstein", title: "Einstein", redirect: "Albert_Einstein"},
+	{namespace: 'C', url: "Zebra", content: "<p>Striped</p>"},
+	{namespace: 'M', url: "Title", content: "Test wiki"},
+}
+
+func TestArchive(t *testing.T) {
+	compressions := map[string]byte{"none": compressionNone, "zlib": compressionZlib, "zstd": compressionZstd, "xz": compressionXZ}
+
+	for name, compression := range compressions {
+		t.Run(name, func(t *testing.T) {
+			raw := buildArchive(t, append([]testEntry(nil), testEntries...), compression)
+			a, err := NewArchive(bytes.NewReader(raw), int64(len(raw)))
+			if err != nil {
+				t.Fatalf("NewArchive() error = %v", err)
+			}
+			if a.EntryCount() != 4 {
+				t.Errorf("EntryCount() = %d, want 4", a.EntryCount())
+			}
+
+			entry, err := a.Lookup('C', "Einstein")
+			if err != nil {
+				t.Fatalf("Lookup() error = %v", err)
+			}
+			if !entry.IsRedirect() {
+				t.Errorf("Einstein is not a redirect")
+			}
+			content, err := a.Content(entry)
+			if err != nil || string(content) != "<p>Physicist</p>" {
+				t.Errorf("Content() = %q, %v, want the redirect target's content", content, err)
+			}
+
+			entry, err = a.LookupTitle('C', "Zebra")
+			if err != nil || entry.URL != "Zebra" || entry.Title != "Zebra" {
+				t.Errorf("LookupTitle(Zebra) = %+v, %v", entry, err)
+			}
+			entry, err = a.LookupTitle('C', "Albert Einstein")
+			if err != nil || entry.URL != "Albert_Einstein" || entry.MIMEType != "text/html" {
+				t.Errorf("LookupTitle(Albert Einstein) = %+v, %v", entry, err)
+			}
+
+			if _, err := a.Lookup('C', "Missing"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Lookup(Missing) error = %v, want ErrNotFound", err)
+			}
+			if got := a.Metadata("Title"); got != "Test wiki" {
+				t.Errorf("Metadata(Title) = %q", got)
+			}
+			if _, err := a.MainPage(); !errors.Is(err, ErrNotFound) {
+				t.Errorf("MainPage() error = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestNotAnArchive(t *testing.T) {
+	raw := make([]byte, headerSize)
+	if _, err := NewArchive(bytes.NewReader(raw), int64(len(raw))); err == nil {
+		t.Error("NewArchive() accepted a file without the ZIM magic number")
+	}
+}
+
+func TestDecodeCluster(t *testing.T) {
+	if _, err := decodeCluster(nil); err == nil {
+		t.Error("decodeCluster(empty) succeeded")
+	}
+
+	// A small cluster that decompresses past maxClusterBytes
+	enc, _ := zstd.NewWriter(nil)
+	bomb := enc.EncodeAll(make([]byte, maxClusterBytes+1), nil)
+	_, err := decodeCluster(append([]byte{compressionZstd}, bomb...))
+	if err == nil || !strings.Contains(err.Error(), "more than") {
+		t.Errorf("decodeCluster(bomb) error = %v, want the size limit", err)
+	}
+}
@@ -4,43 +4,122 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/yourusername/mediawiki-mcp/config"
 	mcpServer "github.com/yourusername/mediawiki-mcp/internal/mcp"
+	"github.com/yourusername/mediawiki-mcp/internal/profiles"
+	"github.com/yourusername/mediawiki-mcp/internal/rpc"
+	"github.com/yourusername/mediawiki-mcp/internal/schedule"
+	"github.com/yourusername/mediawiki-mcp/internal/store"
+	"github.com/yourusername/mediawiki-mcp/internal/watch"
+	"github.com/yourusername/mediawiki-mcp/internal/wiki"
 )
 
 func main() {
-	// Load configuration
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "verify":
+			os.Exit(runVerify(os.Args[2:]))
+		case "selftest":
+			os.Exit(runSelftest(os.Args[2:]))
+		case "--check-config", "-check-config", "check-config":
+			os.Exit(runCheckConfig(os.Args[2:]))
+		}
+	}
+
+	// Load configuration, refusing to start with any setting invalid
 	cfg := config.Load()
+	if problems := configProblems(cfg); len(problems) > 0 {
+		log.Fatalf("Invalid configuration:\n  %s", strings.Join(problems, "\n  "))
+	}
 
-	log.Printf("Starting MediaWiki MCP Server v1.0.0")
+	log.Printf("Starting MediaWiki MCP Server v%s", config.Version)
 	log.Printf("Config: Port=%s, RateLimit=%.1f req/s, CacheTTL=%s",
 		cfg.Port, cfg.RateLimit, cfg.CacheTTL)
+	log.Printf("User-Agent: %s", cfg.UserAgent)
+	if cfg.PlaceholderUserAgent() {
+		if targets := wikimediaTargets(cfg); len(targets) > 0 {
+			log.Printf("Warning: the default User-Agent has no contact info, which Wikimedia's User-Agent policy "+
+				"(https://meta.wikimedia.org/wiki/User-Agent_policy) requires for %s; set MCP_CONTACT_EMAIL or MCP_USER_AGENT",
+				strings.Join(targets, ", "))
+		}
+	}
+
+	// Open the database for durable state, if configured
+	var db *store.Store
+	if cfg.DBPath != "" {
+		var err error
+		db, err = store.Open(cfg.DBPath)
+		if err != nil {
+			log.Fatalf("Database: %v", err)
+		}
+		defer db.Close()
+		log.Printf("Using database %s", cfg.DBPath)
+	}
 
 	// Create MCP server
-	server := mcpServer.NewServer(cfg)
+	server := mcpServer.NewServer(cfg, db)
+	if cfg.ProfilesFile != "" {
+		list, err := profiles.Load(cfg.ProfilesFile)
+		if err != nil {
+			log.Fatalf("Wiki profiles: %v", err)
+		}
+		if err := server.SetProfiles(list); err != nil {
+			log.Fatalf("Wiki profiles: %v", err)
+		}
+		log.Printf("Loaded %d wiki profiles", len(list))
+	}
 	mcpSrv := server.GetMCPServer()
 
-	// Create Streamable HTTP handler with stateless JSON responses
+	// Create Streamable HTTP handler with JSON responses, stateless unless
+	// sessions are enabled
 	handler := mcp.NewStreamableHTTPHandler(
 		func(*http.Request) *mcp.Server {
 			return mcpSrv
 		},
 		&mcp.StreamableHTTPOptions{
-			Stateless:    true, // No session validation required
-			JSONResponse: true, // Return application/json instead of text/event-stream
+			Stateless:    !cfg.Stateful, // No session validation required
+			JSONResponse: true,          // Return application/json instead of text/event-stream
 		},
 	)
+	if cfg.SessionDedup && !cfg.Stateful {
+		log.Printf("Warning: MCP_SESSION_DEDUP has no effect without MCP_STATEFUL=true")
+	}
 
 	// Register routes
-	http.Handle("/mcp", handler)
+	http.Handle("/mcp", server.RequireAPIToken(handler))
+	http.Handle("/tools/", server.ToolsHTTPHandler())
+	http.Handle("/openapi.json", server.OpenAPIHandler())
+	http.Handle("/api/v1/", server.RESTHandler())
+	http.Handle("/artifacts/", server.ArtifactsHandler())
+	http.Handle("/stats", server.StatsHandler())
+	http.Handle("/stats.json", server.StatsHandler())
+	if db != nil {
+		http.Handle("/audit/", server.AuditHandler())
+	}
+	if cfg.EditApproval {
+		http.Handle("/review/", server.ReviewHandler())
+		if len(cfg.ReviewerTokens) == 0 {
+			log.Printf("Warning: MCP_EDIT_APPROVAL is on but MCP_REVIEWER_TOKENS is empty, so held edits can't be reviewed")
+		}
+		if db == nil {
+			log.Printf("Warning: edits held for review are kept in memory and lost on restart; set MCP_DB_PATH to persist them")
+		}
+	}
+	if cfg.EnableGraphQL {
+		http.Handle("/graphql", server.GraphQLHandler())
+	}
 
 	// Health check endpoint
 	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -51,9 +130,22 @@ func main() {
 	// Info endpoint
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/plain")
-		fmt.Fprintf(w, "MediaWiki MCP Server v1.0.0\n")
+		fmt.Fprintf(w, "MediaWiki MCP Server v%s\n", config.Version)
 		fmt.Fprintf(w, "MCP endpoint: /mcp\n")
 		fmt.Fprintf(w, "Health check: /health\n")
+		fmt.Fprintf(w, "Statistics: /stats\n")
+		fmt.Fprintf(w, "OpenAPI spec: /openapi.json\n")
+		fmt.Fprintf(w, "REST API: /api/v1/\n")
+		fmt.Fprintf(w, "Job artifacts: /artifacts/{job_id}\n")
+		if db != nil {
+			fmt.Fprintf(w, "Audit records: /audit/{ref}\n")
+		}
+		if cfg.EditApproval {
+			fmt.Fprintf(w, "Edit review: /review/edits\n")
+		}
+		if cfg.EnableGraphQL {
+			fmt.Fprintf(w, "GraphQL: /graphql\n")
+		}
 	})
 
 	// Start HTTP server
@@ -63,6 +155,84 @@ func main() {
 		WriteTimeout: 30 * time.Second,
 	}
 
+	// Optional gRPC listener for internal service-to-service consumers
+	var grpcOpts []grpc.ServerOption
+	if cfg.GRPCTLSCert != "" {
+		creds, err := credentials.NewServerTLSFromFile(cfg.GRPCTLSCert, cfg.GRPCTLSKey)
+		if err != nil {
+			log.Fatalf("gRPC TLS: %v", err)
+		}
+		grpcOpts = append(grpcOpts, grpc.Creds(creds))
+	} else if cfg.GRPCPort != "" && len(cfg.APITokens) > 0 {
+		log.Printf("Warning: gRPC serves plaintext, so MCP_API_TOKENS are sent in the clear; set MCP_GRPC_TLS_CERT and MCP_GRPC_TLS_KEY")
+	}
+	grpcServer := rpc.NewServer(server, grpcOpts...)
+	if cfg.GRPCPort != "" {
+		lis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+		if err != nil {
+			log.Fatalf("gRPC listen: %v", err)
+		}
+		go func() {
+			log.Printf("gRPC listening on :%s", cfg.GRPCPort)
+			if err := grpcServer.Serve(lis); err != nil {
+				log.Printf("gRPC server error: %v", err)
+			}
+		}()
+	}
+
+	// Optional page watcher with outbound webhooks
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	var watcher *watch.Watcher
+	if len(cfg.WatchPages) > 0 {
+		pages, err := watch.ParsePages(cfg.WatchPages)
+		if err != nil {
+			log.Fatalf("Watch list: %v", err)
+		}
+		watcher = watch.NewWatcher(server.GetClient(), pages, cfg.WatchInterval)
+		if len(cfg.WebhookURLs) > 0 {
+			watcher.AddNotifier(watch.NewWebhookNotifier(cfg.WebhookURLs, cfg.WebhookSecret, cfg.UserAgent, cfg.RequestTimeout))
+		}
+		if cfg.EventStreams {
+			watcher.UseEventStream()
+		}
+		if db != nil {
+			if err := watcher.SetStateStore(db); err != nil {
+				log.Fatalf("Watch state: %v", err)
+			}
+		}
+		log.Printf("Watching %d pages every %s", len(pages), cfg.WatchInterval)
+		go watcher.Run(wiki.WithBackground(watchCtx))
+	}
+
+	// Optional Wikimedia EventStreams consumer for cache invalidation and
+	// near-real-time watch notifications
+	if cfg.EventStreams {
+		stream := watch.NewEventStream(cfg.EventStreamsURL, cfg.UserAgent, server.GetClient(), watcher)
+		log.Printf("Consuming EventStreams from %s", cfg.EventStreamsURL)
+		go stream.Run(wiki.WithBackground(watchCtx))
+	}
+
+	// Delete job artifacts past their retention period
+	go server.RunArtifactRetention(watchCtx)
+
+	// Optional scheduled report jobs
+	var scheduler *schedule.Scheduler
+	if cfg.ScheduleFile != "" {
+		jobs, err := schedule.LoadJobs(cfg.ScheduleFile)
+		if err != nil {
+			log.Fatalf("Schedule: %v", err)
+		}
+		scheduler = schedule.NewScheduler(server, server.GetJobs(), cfg.UserAgent, cfg.WebhookSecret, cfg.RequestTimeout)
+		for _, job := range jobs {
+			if err := scheduler.Add(job); err != nil {
+				log.Fatalf("Schedule: %v", err)
+			}
+		}
+		log.Printf("Scheduled %d report jobs", len(jobs))
+		scheduler.Start()
+	}
+
 	// Handle graceful shutdown
 	go func() {
 		sigChan := make(chan os.Signal, 1)
@@ -70,12 +240,18 @@ func main() {
 		<-sigChan
 
 		log.Println("Shutting down...")
+		stopWatch()
+		if scheduler != nil {
+			scheduler.Stop()
+		}
+		server.Close()
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
 		if err := httpServer.Shutdown(ctx); err != nil {
 			log.Printf("Error during shutdown: %v", err)
 		}
+		grpcServer.GracefulStop()
 	}()
 
 	log.Printf("Server listening on :%s", cfg.Port)
@@ -88,3 +264,26 @@ func main() {
 
 	log.Println("Server stopped")
 }
+
+// wikimediaTargets lists the Wikimedia hosts the configuration contacts at
+// startup: watched pages and the EventStreams feed
+func wikimediaTargets(cfg *config.Config) []string {
+	var targets []string
+	seen := make(map[string]bool)
+	add := func(wikiURL string) {
+		if wiki.IsWikimediaHost(wikiURL) && !seen[wikiURL] {
+			seen[wikiURL] = true
+			targets = append(targets, wikiURL)
+		}
+	}
+
+	for _, line := range cfg.WatchPages {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			add(fields[0])
+		}
+	}
+	if cfg.EventStreams {
+		add(cfg.EventStreamsURL)
+	}
+	return targets
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/config"
+	"github.com/yourusername/mediawiki-mcp/internal/selftest"
+)
+
+// runSelftest builds the server from the environment's configuration and
+// checks it end to end against an in-process fixture wiki, for container
+// health checks and smoke tests of config changes. It returns the exit
+// code: 0 when every check passed.
+func runSelftest(args []string) int {
+	fs := flag.NewFlagSet("selftest", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: mediawiki-mcp selftest [flags]\n\n"+
+			"Starts the server in-process with the current configuration and calls its page tools\n"+
+			"against a built-in fixture wiki, without network access.\n\n")
+		fs.PrintDefaults()
+	}
+	timeout := fs.Duration("timeout", 10*time.Second, "limit for the whole self-test")
+	verbose := fs.Bool("v", false, "show the server's log output")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	cfg := config.Load()
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "selftest: invalid configuration:\n%v\n", err)
+		return 1
+	}
+
+	start := time.Now()
+	if err := selftest.Run(ctx, cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "selftest: %v\n", err)
+		return 1
+	}
+	fmt.Printf("selftest: ok (%s)\n", time.Since(start).Round(time.Millisecond))
+	return 0
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/yourusername/mediawiki-mcp/internal/conformance"
+)
+
+// runVerify runs the conformance suite against a server and prints the
+// pass/fail matrix. It returns the exit code: 0 when no check failed.
+func runVerify(args []string) int {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: mediawiki-mcp verify --endpoint URL [flags]\n\n"+
+			"Calls every tool of the server at URL and checks the results against the tool API.\n\n")
+		fs.PrintDefaults()
+	}
+	var opts conformance.Options
+	fs.StringVar(&opts.Endpoint, "endpoint", "", "MCP endpoint of the server to verify, e.g. http://localhost:8080/mcp")
+	fs.StringVar(&opts.WikiURL, "wiki", "https://en.wikipedia.org", "wiki the tools are called against")
+	fs.StringVar(&opts.Title, "title", "Go (programming language)", "an article with sections, links, and references")
+	fs.StringVar(&opts.Category, "category", "Programming languages", "a category with a few pages")
+	fs.StringVar(&opts.TargetLanguage, "target-language", "de", "a language version of the article, for wiki_compare_languages")
+	fs.DurationVar(&opts.Timeout, "timeout", time.Minute, "limit for each tool call and for background jobs to finish")
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if opts.Endpoint == "" {
+		fs.Usage()
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report, err := conformance.Run(ctx, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "verify: %v\n", err)
+		return 1
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(report)
+	} else {
+		err = report.WriteMatrix(os.Stdout)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "verify: %v\n", err)
+		return 1
+	}
+
+	if report.Count(conformance.Fail) > 0 {
+		return 1
+	}
+	return 0
+}